@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
@@ -15,9 +17,14 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/auth"
 	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/clients"
 	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/fanout"
 	gwhttp "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/handlers"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/ratelimit"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/respcache"
 )
 
 const (
@@ -54,10 +61,93 @@ func main() {
 
 	log.Info("clients_initialized")
 
+	verifier, err := newVerifier(cfg.Auth, cl)
+	if err != nil {
+		log.Error("token_verifier_init_failed", slog.String("err", err.Error()))
+		return
+	}
+	log.Info("token_verifier_initialized", slog.String("verify", cfg.Auth.Verify))
+
+	limiter, closeLimiter, err := newRateLimiter(cfg.RateLimit)
+	if err != nil {
+		log.Error("rate_limiter_init_failed", slog.String("err", err.Error()))
+		return
+	}
+	defer closeLimiter()
+
+	if limiter == nil {
+		log.Warn("rate_limit_disabled")
+	} else {
+		log.Info("rate_limit_enabled", slog.String("backend", cfg.RateLimit.Backend))
+	}
+
+	cache, closeCache, err := newResponseCache(cfg.Cache)
+	if err != nil {
+		log.Error("response_cache_init_failed", slog.String("err", err.Error()))
+		return
+	}
+	defer closeCache()
+
+	if cache == nil {
+		log.Info("response_cache_disabled")
+	} else {
+		log.Info("response_cache_enabled", slog.String("backend", cfg.Cache.Backend), slog.Int("routes", len(cfg.Cache.Routes)))
+	}
+
+	// streamsDone закрывается при Shutdown: SSE-потоки и /ws не ждут клиентов, а завершаются сразу.
+	streamsDone := make(chan struct{})
+
+	// Живые ветки /ws: одна апстрим-подписка WatchComments на новость на реплику;
+	// автор комментария дополняется один раз на событие.
+	hub := fanout.New(cl.Comments, fanout.Options{
+		Buffer: cfg.WS.SendBuffer,
+		Enrich: handlers.New(cl).EnrichComment,
+		Logger: slog.Default(),
+	})
+	defer hub.Close()
+
 	opts := gwhttp.Options{
-		Logger:   slog.Default(),
-		Timeout:  cfg.Timeouts.Service,
-		BasePath: "/api",
+		Logger:            slog.Default(),
+		Timeout:           cfg.Timeouts.Service,
+		BasePath:          "/api",
+		Verifier:          verifier,
+		Admins:            cfg.Auth.Admins,
+		Moderators:        cfg.Auth.Moderators,
+		RateLimiter:       limiter,
+		RateLimits:        cfg.RateLimit.Policies(),
+		TrustForwardedFor: cfg.RateLimit.TrustForwardedFor,
+		Cache:             cache,
+		CacheRules:        cfg.Cache.Routes,
+		CacheMaxBodyBytes: cfg.Cache.MaxBodyBytes,
+		Stream: handlers.StreamOptions{
+			Heartbeat:    cfg.Stream.Heartbeat,
+			WriteTimeout: cfg.Stream.WriteTimeout,
+			Retry:        cfg.Stream.Retry,
+			Done:         streamsDone,
+		},
+		WS: handlers.WSOptions{
+			Hub:              hub,
+			MaxSubscriptions: cfg.WS.MaxSubscriptions,
+			MaxMessageBytes:  cfg.WS.MaxMessageBytes,
+			MessageRate:      cfg.WS.MessageRate,
+			MessageBurst:     cfg.WS.MessageBurst,
+			CommentRate:      cfg.WS.CommentRate,
+			SendBuffer:       cfg.WS.SendBuffer,
+			PingInterval:     cfg.WS.PingInterval,
+			WriteTimeout:     cfg.WS.WriteTimeout,
+			RequestTimeout:   cfg.Timeouts.Service,
+			AllowedOrigins:   cfg.WS.AllowedOrigins,
+			Done:             streamsDone,
+		},
+		GraphQL: handlers.GraphQLOptions{
+			MaxDepth:      cfg.GraphQL.MaxDepth,
+			MaxComplexity: cfg.GraphQL.MaxComplexity,
+			MaxBodyBytes:  cfg.GraphQL.MaxBodyBytes,
+		},
+		NewsPage: handlers.NewsPageOptions{
+			Timeout:          cfg.NewsPage.Timeout,
+			CommentsPageSize: cfg.NewsPage.CommentsPageSize,
+		},
 	}
 
 	apiHandler := gwhttp.NewRouter(cl, opts)
@@ -70,14 +160,24 @@ func main() {
 		_, _ = w.Write([]byte("ok"))
 	})
 
+	// /healthz — готовность шлюза и состояние предохранителей апстримов.
+	// Разомкнутый предохранитель — "degraded", но 200: шлюз отвечает (503 по
+	// этому апстриму), и выводить его из балансировки незачем.
 	metricsMux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
-		if atomic.LoadInt32(&ready) == 1 {
-			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write([]byte("ok"))
-			return
+		code, st := http.StatusOK, "ok"
+		upstreams := cl.Breakers()
+		for _, s := range upstreams {
+			if s != "closed" {
+				st = "degraded"
+			}
+		}
+		if atomic.LoadInt32(&ready) != 1 {
+			code, st = http.StatusServiceUnavailable, "not ready"
 		}
 
-		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": st, "upstreams": upstreams})
 	})
 
 	metricsMux.Handle("/metrics", promhttp.Handler())
@@ -98,6 +198,7 @@ func main() {
 		Handler:           apiMux,
 		ReadHeaderTimeout: 5 * time.Second,
 	}
+	apiSrv.RegisterOnShutdown(func() { close(streamsDone) })
 
 	metricsLn, err := net.Listen("tcp", metricsAddr)
 	if err != nil {
@@ -166,6 +267,62 @@ func main() {
 	log.Info("service_stopped")
 }
 
+// newVerifier создаёт проверку access-токенов по конфигу.
+func newVerifier(cfg config.AuthConfig, cl *clients.Clients) (auth.Verifier, error) {
+	switch cfg.Verify {
+	case "", "remote":
+		return auth.NewRemote(cl.Auth, cfg.CacheTTL, cfg.CacheSize), nil
+	case "local":
+		return auth.NewLocal(cfg.JWTSecret, cfg.Issuer, cfg.Audience)
+	default:
+		return nil, fmt.Errorf("unknown auth.verify %q", cfg.Verify)
+	}
+}
+
+// newRateLimiter создаёт бэкенд лимитов по конфигу; выключенные лимиты — nil.
+func newRateLimiter(cfg config.RateLimitConfig) (ratelimit.Limiter, func(), error) {
+	noop := func() {}
+	if !cfg.Enabled {
+		return nil, noop, nil
+	}
+
+	switch cfg.Backend {
+	case "", "memory":
+		return ratelimit.NewMemory(), noop, nil
+	case "redis":
+		rl, err := ratelimit.NewRedis(cfg.RedisURL, cfg.RedisPrefix)
+		if err != nil {
+			return nil, noop, err
+		}
+
+		return rl, func() { _ = rl.Close() }, nil
+	default:
+		return nil, noop, fmt.Errorf("unknown rate_limit.backend %q", cfg.Backend)
+	}
+}
+
+// newResponseCache создаёт хранилище кэша ответов по конфигу; выключенный кэш — nil.
+func newResponseCache(cfg config.CacheConfig) (respcache.Store, func(), error) {
+	noop := func() {}
+	if !cfg.Enabled {
+		return nil, noop, nil
+	}
+
+	switch cfg.Backend {
+	case "", "memory":
+		return respcache.NewMemory(cfg.Size), noop, nil
+	case "redis":
+		c, err := respcache.NewRedis(cfg.RedisURL, cfg.RedisPrefix)
+		if err != nil {
+			return nil, noop, err
+		}
+
+		return c, func() { _ = c.Close() }, nil
+	default:
+		return nil, noop, fmt.Errorf("unknown cache.backend %q", cfg.Backend)
+	}
+}
+
 func setupLogger(env string) *slog.Logger {
 	switch env {
 	case envLocal:
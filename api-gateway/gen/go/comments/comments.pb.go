@@ -21,6 +21,160 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Режим жизни веток комментариев новости.
+type ThreadPolicyMode int32
+
+const (
+	ThreadPolicyMode_THREAD_POLICY_MODE_UNSPECIFIED ThreadPolicyMode = 0 // по умолчанию: закрытие через глобальный TTL
+	ThreadPolicyMode_FOREVER                        ThreadPolicyMode = 1 // ветки открыты бессрочно
+	ThreadPolicyMode_EXPIRE                         ThreadPolicyMode = 2 // закрытие через ttl_days после создания корня
+	ThreadPolicyMode_LOCKED                         ThreadPolicyMode = 3 // все ветки новости только для чтения
+)
+
+// Enum value maps for ThreadPolicyMode.
+var (
+	ThreadPolicyMode_name = map[int32]string{
+		0: "THREAD_POLICY_MODE_UNSPECIFIED",
+		1: "FOREVER",
+		2: "EXPIRE",
+		3: "LOCKED",
+	}
+	ThreadPolicyMode_value = map[string]int32{
+		"THREAD_POLICY_MODE_UNSPECIFIED": 0,
+		"FOREVER":                        1,
+		"EXPIRE":                         2,
+		"LOCKED":                         3,
+	}
+)
+
+func (x ThreadPolicyMode) Enum() *ThreadPolicyMode {
+	p := new(ThreadPolicyMode)
+	*p = x
+	return p
+}
+
+func (x ThreadPolicyMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ThreadPolicyMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_comments_proto_enumTypes[0].Descriptor()
+}
+
+func (ThreadPolicyMode) Type() protoreflect.EnumType {
+	return &file_comments_proto_enumTypes[0]
+}
+
+func (x ThreadPolicyMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ThreadPolicyMode.Descriptor instead.
+func (ThreadPolicyMode) EnumDescriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{0}
+}
+
+type NotificationKind int32
+
+const (
+	NotificationKind_NOTIFICATION_KIND_UNSPECIFIED NotificationKind = 0
+	NotificationKind_REPLY                         NotificationKind = 1 // ответ на комментарий получателя
+	NotificationKind_MENTION                       NotificationKind = 2 // @упоминание получателя
+)
+
+// Enum value maps for NotificationKind.
+var (
+	NotificationKind_name = map[int32]string{
+		0: "NOTIFICATION_KIND_UNSPECIFIED",
+		1: "REPLY",
+		2: "MENTION",
+	}
+	NotificationKind_value = map[string]int32{
+		"NOTIFICATION_KIND_UNSPECIFIED": 0,
+		"REPLY":                         1,
+		"MENTION":                       2,
+	}
+)
+
+func (x NotificationKind) Enum() *NotificationKind {
+	p := new(NotificationKind)
+	*p = x
+	return p
+}
+
+func (x NotificationKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_comments_proto_enumTypes[1].Descriptor()
+}
+
+func (NotificationKind) Type() protoreflect.EnumType {
+	return &file_comments_proto_enumTypes[1]
+}
+
+func (x NotificationKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationKind.Descriptor instead.
+func (NotificationKind) EnumDescriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{1}
+}
+
+type CommentEventKind int32
+
+const (
+	CommentEventKind_COMMENT_EVENT_KIND_UNSPECIFIED CommentEventKind = 0
+	CommentEventKind_COMMENT_CREATED                CommentEventKind = 1 // новый комментарий (в т.ч. из досылки)
+	CommentEventKind_COMMENT_DELETED                CommentEventKind = 2 // мягкое удаление: в comment id, news_id, parent_id, is_deleted
+	CommentEventKind_COMMENT_REACTION               CommentEventKind = 3 // изменились реакции: в comment id, news_id, parent_id, reactions
+)
+
+// Enum value maps for CommentEventKind.
+var (
+	CommentEventKind_name = map[int32]string{
+		0: "COMMENT_EVENT_KIND_UNSPECIFIED",
+		1: "COMMENT_CREATED",
+		2: "COMMENT_DELETED",
+		3: "COMMENT_REACTION",
+	}
+	CommentEventKind_value = map[string]int32{
+		"COMMENT_EVENT_KIND_UNSPECIFIED": 0,
+		"COMMENT_CREATED":                1,
+		"COMMENT_DELETED":                2,
+		"COMMENT_REACTION":               3,
+	}
+)
+
+func (x CommentEventKind) Enum() *CommentEventKind {
+	p := new(CommentEventKind)
+	*p = x
+	return p
+}
+
+func (x CommentEventKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CommentEventKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_comments_proto_enumTypes[2].Descriptor()
+}
+
+func (CommentEventKind) Type() protoreflect.EnumType {
+	return &file_comments_proto_enumTypes[2]
+}
+
+func (x CommentEventKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CommentEventKind.Descriptor instead.
+func (CommentEventKind) EnumDescriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{2}
+}
+
 // Базовая модель комментария (плоская; дерево — через parent_id).
 type Comment struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -29,13 +183,17 @@ type Comment struct {
 	ParentId      string                 `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`              // "" - корень
 	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                    // из users-service
 	Username      string                 `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`                              // из users-service
-	Content       string                 `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`                                // текст (маскируется при is_deleted=true)
+	Content       string                 `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`                                // исходный текст/Markdown (маскируется при is_deleted=true)
 	Level         int32                  `protobuf:"varint,7,opt,name=level,proto3" json:"level,omitempty"`                                   // глубина (0 для корня), вычисляется на записи
 	RepliesCount  int32                  `protobuf:"varint,8,opt,name=replies_count,json=repliesCount,proto3" json:"replies_count,omitempty"` // счётчик прямых детей (для UI)
 	IsDeleted     bool                   `protobuf:"varint,9,opt,name=is_deleted,json=isDeleted,proto3" json:"is_deleted,omitempty"`          // мягкое удаление
 	CreatedAt     int64                  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     int64                  `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	ExpiresAt     int64                  `protobuf:"varint,12,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	RootId        string                 `protobuf:"bytes,13,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"`                                                                    // корень ветки ("" у самого корня)
+	IsLocked      bool                   `protobuf:"varint,14,opt,name=is_locked,json=isLocked,proto3" json:"is_locked,omitempty"`                                                             // ветка заблокирована модератором (только чтение)
+	ContentHtml   string                 `protobuf:"bytes,15,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`                                                     // санитизированный HTML из content (Markdown-подмножество)
+	Reactions     map[string]int64       `protobuf:"bytes,16,rep,name=reactions,proto3" json:"reactions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // реакция -> число поставивших (нулевые опускаются)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -154,20 +312,108 @@ func (x *Comment) GetExpiresAt() int64 {
 	return 0
 }
 
+func (x *Comment) GetRootId() string {
+	if x != nil {
+		return x.RootId
+	}
+	return ""
+}
+
+func (x *Comment) GetIsLocked() bool {
+	if x != nil {
+		return x.IsLocked
+	}
+	return false
+}
+
+func (x *Comment) GetContentHtml() string {
+	if x != nil {
+		return x.ContentHtml
+	}
+	return ""
+}
+
+func (x *Comment) GetReactions() map[string]int64 {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+type ThreadPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewsId        string                 `protobuf:"bytes,1,opt,name=news_id,json=newsId,proto3" json:"news_id,omitempty"`
+	Mode          ThreadPolicyMode       `protobuf:"varint,2,opt,name=mode,proto3,enum=comments.v1.ThreadPolicyMode" json:"mode,omitempty"`
+	TtlDays       int32                  `protobuf:"varint,3,opt,name=ttl_days,json=ttlDays,proto3" json:"ttl_days,omitempty"` // для EXPIRE (и эффективный TTL для UNSPECIFIED)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThreadPolicy) Reset() {
+	*x = ThreadPolicy{}
+	mi := &file_comments_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThreadPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThreadPolicy) ProtoMessage() {}
+
+func (x *ThreadPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThreadPolicy.ProtoReflect.Descriptor instead.
+func (*ThreadPolicy) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ThreadPolicy) GetNewsId() string {
+	if x != nil {
+		return x.NewsId
+	}
+	return ""
+}
+
+func (x *ThreadPolicy) GetMode() ThreadPolicyMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ThreadPolicyMode_THREAD_POLICY_MODE_UNSPECIFIED
+}
+
+func (x *ThreadPolicy) GetTtlDays() int32 {
+	if x != nil {
+		return x.TtlDays
+	}
+	return 0
+}
+
 type CreateCommentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NewsId        string                 `protobuf:"bytes,1,opt,name=news_id,json=newsId,proto3" json:"news_id,omitempty"`
 	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // опциональный; если задан — это reply
 	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Username      string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
-	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"` // Markdown-подмножество: выделение, код, цитаты, ссылки
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateCommentRequest) Reset() {
 	*x = CreateCommentRequest{}
-	mi := &file_comments_proto_msgTypes[1]
+	mi := &file_comments_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -179,7 +425,7 @@ func (x *CreateCommentRequest) String() string {
 func (*CreateCommentRequest) ProtoMessage() {}
 
 func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_comments_proto_msgTypes[1]
+	mi := &file_comments_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -192,7 +438,7 @@ func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCommentRequest.ProtoReflect.Descriptor instead.
 func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
-	return file_comments_proto_rawDescGZIP(), []int{1}
+	return file_comments_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateCommentRequest) GetNewsId() string {
@@ -239,7 +485,7 @@ type CreateCommentResponse struct {
 
 func (x *CreateCommentResponse) Reset() {
 	*x = CreateCommentResponse{}
-	mi := &file_comments_proto_msgTypes[2]
+	mi := &file_comments_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -251,7 +497,7 @@ func (x *CreateCommentResponse) String() string {
 func (*CreateCommentResponse) ProtoMessage() {}
 
 func (x *CreateCommentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_comments_proto_msgTypes[2]
+	mi := &file_comments_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -264,7 +510,7 @@ func (x *CreateCommentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCommentResponse.ProtoReflect.Descriptor instead.
 func (*CreateCommentResponse) Descriptor() ([]byte, []int) {
-	return file_comments_proto_rawDescGZIP(), []int{2}
+	return file_comments_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreateCommentResponse) GetComment() *Comment {
@@ -283,7 +529,7 @@ type DeleteCommentRequest struct {
 
 func (x *DeleteCommentRequest) Reset() {
 	*x = DeleteCommentRequest{}
-	mi := &file_comments_proto_msgTypes[3]
+	mi := &file_comments_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -295,7 +541,7 @@ func (x *DeleteCommentRequest) String() string {
 func (*DeleteCommentRequest) ProtoMessage() {}
 
 func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_comments_proto_msgTypes[3]
+	mi := &file_comments_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -308,7 +554,7 @@ func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
 func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
-	return file_comments_proto_rawDescGZIP(), []int{3}
+	return file_comments_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DeleteCommentRequest) GetId() string {
@@ -326,7 +572,7 @@ type DeleteCommentResponse struct {
 
 func (x *DeleteCommentResponse) Reset() {
 	*x = DeleteCommentResponse{}
-	mi := &file_comments_proto_msgTypes[4]
+	mi := &file_comments_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -338,7 +584,7 @@ func (x *DeleteCommentResponse) String() string {
 func (*DeleteCommentResponse) ProtoMessage() {}
 
 func (x *DeleteCommentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_comments_proto_msgTypes[4]
+	mi := &file_comments_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -351,7 +597,7 @@ func (x *DeleteCommentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCommentResponse.ProtoReflect.Descriptor instead.
 func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
-	return file_comments_proto_rawDescGZIP(), []int{4}
+	return file_comments_proto_rawDescGZIP(), []int{5}
 }
 
 type CommentByIDRequest struct {
@@ -363,7 +609,7 @@ type CommentByIDRequest struct {
 
 func (x *CommentByIDRequest) Reset() {
 	*x = CommentByIDRequest{}
-	mi := &file_comments_proto_msgTypes[5]
+	mi := &file_comments_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -375,7 +621,7 @@ func (x *CommentByIDRequest) String() string {
 func (*CommentByIDRequest) ProtoMessage() {}
 
 func (x *CommentByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_comments_proto_msgTypes[5]
+	mi := &file_comments_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -388,7 +634,7 @@ func (x *CommentByIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommentByIDRequest.ProtoReflect.Descriptor instead.
 func (*CommentByIDRequest) Descriptor() ([]byte, []int) {
-	return file_comments_proto_rawDescGZIP(), []int{5}
+	return file_comments_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CommentByIDRequest) GetId() string {
@@ -407,7 +653,7 @@ type CommentByIDResponse struct {
 
 func (x *CommentByIDResponse) Reset() {
 	*x = CommentByIDResponse{}
-	mi := &file_comments_proto_msgTypes[6]
+	mi := &file_comments_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -419,7 +665,7 @@ func (x *CommentByIDResponse) String() string {
 func (*CommentByIDResponse) ProtoMessage() {}
 
 func (x *CommentByIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_comments_proto_msgTypes[6]
+	mi := &file_comments_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -432,7 +678,7 @@ func (x *CommentByIDResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommentByIDResponse.ProtoReflect.Descriptor instead.
 func (*CommentByIDResponse) Descriptor() ([]byte, []int) {
-	return file_comments_proto_rawDescGZIP(), []int{6}
+	return file_comments_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CommentByIDResponse) GetComment() *Comment {
@@ -453,7 +699,7 @@ type ListByNewsRequest struct {
 
 func (x *ListByNewsRequest) Reset() {
 	*x = ListByNewsRequest{}
-	mi := &file_comments_proto_msgTypes[7]
+	mi := &file_comments_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -465,7 +711,7 @@ func (x *ListByNewsRequest) String() string {
 func (*ListByNewsRequest) ProtoMessage() {}
 
 func (x *ListByNewsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_comments_proto_msgTypes[7]
+	mi := &file_comments_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -478,7 +724,7 @@ func (x *ListByNewsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListByNewsRequest.ProtoReflect.Descriptor instead.
 func (*ListByNewsRequest) Descriptor() ([]byte, []int) {
-	return file_comments_proto_rawDescGZIP(), []int{7}
+	return file_comments_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListByNewsRequest) GetNewsId() string {
@@ -512,7 +758,7 @@ type ListByNewsResponse struct {
 
 func (x *ListByNewsResponse) Reset() {
 	*x = ListByNewsResponse{}
-	mi := &file_comments_proto_msgTypes[8]
+	mi := &file_comments_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -524,7 +770,7 @@ func (x *ListByNewsResponse) String() string {
 func (*ListByNewsResponse) ProtoMessage() {}
 
 func (x *ListByNewsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_comments_proto_msgTypes[8]
+	mi := &file_comments_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -537,7 +783,7 @@ func (x *ListByNewsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListByNewsResponse.ProtoReflect.Descriptor instead.
 func (*ListByNewsResponse) Descriptor() ([]byte, []int) {
-	return file_comments_proto_rawDescGZIP(), []int{8}
+	return file_comments_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListByNewsResponse) GetComments() []*Comment {
@@ -565,7 +811,7 @@ type ListRepliesRequest struct {
 
 func (x *ListRepliesRequest) Reset() {
 	*x = ListRepliesRequest{}
-	mi := &file_comments_proto_msgTypes[9]
+	mi := &file_comments_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -577,7 +823,7 @@ func (x *ListRepliesRequest) String() string {
 func (*ListRepliesRequest) ProtoMessage() {}
 
 func (x *ListRepliesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_comments_proto_msgTypes[9]
+	mi := &file_comments_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -590,7 +836,7 @@ func (x *ListRepliesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRepliesRequest.ProtoReflect.Descriptor instead.
 func (*ListRepliesRequest) Descriptor() ([]byte, []int) {
-	return file_comments_proto_rawDescGZIP(), []int{9}
+	return file_comments_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListRepliesRequest) GetParentId() string {
@@ -624,7 +870,7 @@ type ListRepliesResponse struct {
 
 func (x *ListRepliesResponse) Reset() {
 	*x = ListRepliesResponse{}
-	mi := &file_comments_proto_msgTypes[10]
+	mi := &file_comments_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -636,7 +882,7 @@ func (x *ListRepliesResponse) String() string {
 func (*ListRepliesResponse) ProtoMessage() {}
 
 func (x *ListRepliesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_comments_proto_msgTypes[10]
+	mi := &file_comments_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -649,7 +895,7 @@ func (x *ListRepliesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRepliesResponse.ProtoReflect.Descriptor instead.
 func (*ListRepliesResponse) Descriptor() ([]byte, []int) {
-	return file_comments_proto_rawDescGZIP(), []int{10}
+	return file_comments_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListRepliesResponse) GetComments() []*Comment {
@@ -666,67 +912,1828 @@ func (x *ListRepliesResponse) GetNextPageToken() string {
 	return ""
 }
 
-var File_comments_proto protoreflect.FileDescriptor
+type Notification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // получатель
+	Kind          NotificationKind       `protobuf:"varint,3,opt,name=kind,proto3,enum=comments.v1.NotificationKind" json:"kind,omitempty"`
+	CommentId     string                 `protobuf:"bytes,4,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"` // комментарий-источник
+	NewsId        string                 `protobuf:"bytes,5,opt,name=news_id,json=newsId,proto3" json:"news_id,omitempty"`
+	ThreadId      string                 `protobuf:"bytes,6,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"` // корень ветки
+	ActorId       string                 `protobuf:"bytes,7,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`    // автор комментария-источника
+	ActorUsername string                 `protobuf:"bytes,8,opt,name=actor_username,json=actorUsername,proto3" json:"actor_username,omitempty"`
+	IsRead        bool                   `protobuf:"varint,9,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_comments_proto_rawDesc = "" +
-	"\n" +
-	"\x0ecomments.proto\x12\vcomments.v1\"\xd5\x02\n" +
-	"\aComment\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\anews_id\x18\x02 \x01(\tR\x06newsId\x12\x1b\n" +
-	"\tparent_id\x18\x03 \x01(\tR\bparentId\x12\x17\n" +
-	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1a\n" +
-	"\busername\x18\x05 \x01(\tR\busername\x12\x18\n" +
-	"\acontent\x18\x06 \x01(\tR\acontent\x12\x14\n" +
-	"\x05level\x18\a \x01(\x05R\x05level\x12#\n" +
-	"\rreplies_count\x18\b \x01(\x05R\frepliesCount\x12\x1d\n" +
-	"\n" +
-	"is_deleted\x18\t \x01(\bR\tisDeleted\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\n" +
-	" \x01(\x03R\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\v \x01(\x03R\tupdatedAt\x12\x1d\n" +
-	"\n" +
-	"expires_at\x18\f \x01(\x03R\texpiresAt\"\x9b\x01\n" +
-	"\x14CreateCommentRequest\x12\x17\n" +
-	"\anews_id\x18\x01 \x01(\tR\x06newsId\x12\x1b\n" +
-	"\tparent_id\x18\x02 \x01(\tR\bparentId\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1a\n" +
-	"\busername\x18\x04 \x01(\tR\busername\x12\x18\n" +
-	"\acontent\x18\x05 \x01(\tR\acontent\"G\n" +
-	"\x15CreateCommentResponse\x12.\n" +
-	"\acomment\x18\x01 \x01(\v2\x14.comments.v1.CommentR\acomment\"&\n" +
-	"\x14DeleteCommentRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
-	"\x15DeleteCommentResponse\"$\n" +
-	"\x12CommentByIDRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"E\n" +
-	"\x13CommentByIDResponse\x12.\n" +
-	"\acomment\x18\x01 \x01(\v2\x14.comments.v1.CommentR\acomment\"h\n" +
-	"\x11ListByNewsRequest\x12\x17\n" +
-	"\anews_id\x18\x01 \x01(\tR\x06newsId\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
-	"\n" +
-	"page_token\x18\x03 \x01(\tR\tpageToken\"n\n" +
-	"\x12ListByNewsResponse\x120\n" +
-	"\bcomments\x18\x01 \x03(\v2\x14.comments.v1.CommentR\bcomments\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"m\n" +
-	"\x12ListRepliesRequest\x12\x1b\n" +
-	"\tparent_id\x18\x01 \x01(\tR\bparentId\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
-	"\n" +
-	"page_token\x18\x03 \x01(\tR\tpageToken\"o\n" +
-	"\x13ListRepliesResponse\x120\n" +
-	"\bcomments\x18\x01 \x03(\v2\x14.comments.v1.CommentR\bcomments\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\xb4\x03\n" +
-	"\x0fCommentsService\x12V\n" +
-	"\rCreateComment\x12!.comments.v1.CreateCommentRequest\x1a\".comments.v1.CreateCommentResponse\x12V\n" +
-	"\rDeleteComment\x12!.comments.v1.DeleteCommentRequest\x1a\".comments.v1.DeleteCommentResponse\x12P\n" +
-	"\vCommentByID\x12\x1f.comments.v1.CommentByIDRequest\x1a .comments.v1.CommentByIDResponse\x12M\n" +
-	"\n" +
-	"ListByNews\x12\x1e.comments.v1.ListByNewsRequest\x1a\x1f.comments.v1.ListByNewsResponse\x12P\n" +
-	"\vListReplies\x12\x1f.comments.v1.ListRepliesRequest\x1a .comments.v1.ListRepliesResponseBGZEgithub.com/pribylovaa/go-news-aggregator/proto/comments/v1;commentsv1b\x06proto3"
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_comments_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Notification) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Notification) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Notification) GetKind() NotificationKind {
+	if x != nil {
+		return x.Kind
+	}
+	return NotificationKind_NOTIFICATION_KIND_UNSPECIFIED
+}
+
+func (x *Notification) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *Notification) GetNewsId() string {
+	if x != nil {
+		return x.NewsId
+	}
+	return ""
+}
+
+func (x *Notification) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *Notification) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *Notification) GetActorUsername() string {
+	if x != nil {
+		return x.ActorUsername
+	}
+	return ""
+}
+
+func (x *Notification) GetIsRead() bool {
+	if x != nil {
+		return x.IsRead
+	}
+	return false
+}
+
+func (x *Notification) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ListNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UnreadOnly    bool                   `protobuf:"varint,2,opt,name=unread_only,json=unreadOnly,proto3" json:"unread_only,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_comments_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListNotificationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetUnreadOnly() bool {
+	if x != nil {
+		return x.UnreadOnly
+	}
+	return false
+}
+
+func (x *ListNotificationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListNotificationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_comments_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *ListNotificationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type MarkReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Ids           []string               `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+	All           bool                   `protobuf:"varint,3,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadRequest) Reset() {
+	*x = MarkReadRequest{}
+	mi := &file_comments_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadRequest) ProtoMessage() {}
+
+func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MarkReadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MarkReadRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *MarkReadRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type MarkReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updated       int64                  `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadResponse) Reset() {
+	*x = MarkReadResponse{}
+	mi := &file_comments_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadResponse) ProtoMessage() {}
+
+func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkReadResponse) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MarkReadResponse) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+type UnreadCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnreadCountRequest) Reset() {
+	*x = UnreadCountRequest{}
+	mi := &file_comments_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnreadCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnreadCountRequest) ProtoMessage() {}
+
+func (x *UnreadCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnreadCountRequest.ProtoReflect.Descriptor instead.
+func (*UnreadCountRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UnreadCountRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnreadCountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnreadCountResponse) Reset() {
+	*x = UnreadCountResponse{}
+	mi := &file_comments_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnreadCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnreadCountResponse) ProtoMessage() {}
+
+func (x *UnreadCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnreadCountResponse.ProtoReflect.Descriptor instead.
+func (*UnreadCountResponse) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UnreadCountResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type MuteThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CommentId     string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteThreadRequest) Reset() {
+	*x = MuteThreadRequest{}
+	mi := &file_comments_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteThreadRequest) ProtoMessage() {}
+
+func (x *MuteThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteThreadRequest.ProtoReflect.Descriptor instead.
+func (*MuteThreadRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MuteThreadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MuteThreadRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+type MuteThreadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ThreadId      string                 `protobuf:"bytes,1,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteThreadResponse) Reset() {
+	*x = MuteThreadResponse{}
+	mi := &file_comments_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteThreadResponse) ProtoMessage() {}
+
+func (x *MuteThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteThreadResponse.ProtoReflect.Descriptor instead.
+func (*MuteThreadResponse) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MuteThreadResponse) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+type WatchNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchNotificationsRequest) Reset() {
+	*x = WatchNotificationsRequest{}
+	mi := &file_comments_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchNotificationsRequest) ProtoMessage() {}
+
+func (x *WatchNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*WatchNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchNotificationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type WatchCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewsId        string                 `protobuf:"bytes,1,opt,name=news_id,json=newsId,proto3" json:"news_id,omitempty"`
+	LastEventId   string                 `protobuf:"bytes,2,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"` // event_id последнего полученного события; пусто — только новые
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCommentsRequest) Reset() {
+	*x = WatchCommentsRequest{}
+	mi := &file_comments_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCommentsRequest) ProtoMessage() {}
+
+func (x *WatchCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCommentsRequest.ProtoReflect.Descriptor instead.
+func (*WatchCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WatchCommentsRequest) GetNewsId() string {
+	if x != nil {
+		return x.NewsId
+	}
+	return ""
+}
+
+func (x *WatchCommentsRequest) GetLastEventId() string {
+	if x != nil {
+		return x.LastEventId
+	}
+	return ""
+}
+
+type CommentEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Курсор возобновления (id комментария); у COMMENT_DELETED и COMMENT_REACTION пуст —
+	// они не досылаются.
+	EventId string   `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Comment *Comment `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	// Пропущенное после last_event_id не восстановить (слишком много или битый курсор):
+	// перечитайте ListByNews. event_id и comment пусты.
+	ResetRequired bool             `protobuf:"varint,3,opt,name=reset_required,json=resetRequired,proto3" json:"reset_required,omitempty"`
+	Kind          CommentEventKind `protobuf:"varint,4,opt,name=kind,proto3,enum=comments.v1.CommentEventKind" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommentEvent) Reset() {
+	*x = CommentEvent{}
+	mi := &file_comments_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentEvent) ProtoMessage() {}
+
+func (x *CommentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentEvent.ProtoReflect.Descriptor instead.
+func (*CommentEvent) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CommentEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *CommentEvent) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+func (x *CommentEvent) GetResetRequired() bool {
+	if x != nil {
+		return x.ResetRequired
+	}
+	return false
+}
+
+func (x *CommentEvent) GetKind() CommentEventKind {
+	if x != nil {
+		return x.Kind
+	}
+	return CommentEventKind_COMMENT_EVENT_KIND_UNSPECIFIED
+}
+
+// Реакция user_id на комментарий; повторная постановка/снятие — no-op.
+type ReactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommentId     string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reaction      string                 `protobuf:"bytes,3,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	Remove        bool                   `protobuf:"varint,4,opt,name=remove,proto3" json:"remove,omitempty"` // true — снять реакцию
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactRequest) Reset() {
+	*x = ReactRequest{}
+	mi := &file_comments_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactRequest) ProtoMessage() {}
+
+func (x *ReactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactRequest.ProtoReflect.Descriptor instead.
+func (*ReactRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReactRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *ReactRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReactRequest) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+func (x *ReactRequest) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type ReactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"` // id, news_id, parent_id, root_id, reactions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactResponse) Reset() {
+	*x = ReactResponse{}
+	mi := &file_comments_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactResponse) ProtoMessage() {}
+
+func (x *ReactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactResponse.ProtoReflect.Descriptor instead.
+func (*ReactResponse) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReactResponse) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+type LockThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommentId     string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	Locked        bool                   `protobuf:"varint,2,opt,name=locked,proto3" json:"locked,omitempty"` // false — разблокировать
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockThreadRequest) Reset() {
+	*x = LockThreadRequest{}
+	mi := &file_comments_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockThreadRequest) ProtoMessage() {}
+
+func (x *LockThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockThreadRequest.ProtoReflect.Descriptor instead.
+func (*LockThreadRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LockThreadRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *LockThreadRequest) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+type LockThreadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"` // корень ветки после изменения
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockThreadResponse) Reset() {
+	*x = LockThreadResponse{}
+	mi := &file_comments_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockThreadResponse) ProtoMessage() {}
+
+func (x *LockThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockThreadResponse.ProtoReflect.Descriptor instead.
+func (*LockThreadResponse) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *LockThreadResponse) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+type GetThreadPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewsId        string                 `protobuf:"bytes,1,opt,name=news_id,json=newsId,proto3" json:"news_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThreadPolicyRequest) Reset() {
+	*x = GetThreadPolicyRequest{}
+	mi := &file_comments_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThreadPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadPolicyRequest) ProtoMessage() {}
+
+func (x *GetThreadPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetThreadPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetThreadPolicyRequest) GetNewsId() string {
+	if x != nil {
+		return x.NewsId
+	}
+	return ""
+}
+
+type GetThreadPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *ThreadPolicy          `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThreadPolicyResponse) Reset() {
+	*x = GetThreadPolicyResponse{}
+	mi := &file_comments_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThreadPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadPolicyResponse) ProtoMessage() {}
+
+func (x *GetThreadPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetThreadPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetThreadPolicyResponse) GetPolicy() *ThreadPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type SetThreadPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *ThreadPolicy          `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetThreadPolicyRequest) Reset() {
+	*x = SetThreadPolicyRequest{}
+	mi := &file_comments_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetThreadPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetThreadPolicyRequest) ProtoMessage() {}
+
+func (x *SetThreadPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetThreadPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetThreadPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SetThreadPolicyRequest) GetPolicy() *ThreadPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type SetThreadPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *ThreadPolicy          `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetThreadPolicyResponse) Reset() {
+	*x = SetThreadPolicyResponse{}
+	mi := &file_comments_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetThreadPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetThreadPolicyResponse) ProtoMessage() {}
+
+func (x *SetThreadPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetThreadPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetThreadPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SetThreadPolicyResponse) GetPolicy() *ThreadPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+// Счётчики комментариев новости (мягко удалённые не учитываются).
+type NewsCounts struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NewsId         string                 `protobuf:"bytes,1,opt,name=news_id,json=newsId,proto3" json:"news_id,omitempty"`
+	Total          int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`                                           // все неудалённые комментарии
+	Roots          int64                  `protobuf:"varint,3,opt,name=roots,proto3" json:"roots,omitempty"`                                           // неудалённые корни
+	LastActivityAt int64                  `protobuf:"varint,4,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"` // Unix UTC последнего комментария; 0 — комментариев не было
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NewsCounts) Reset() {
+	*x = NewsCounts{}
+	mi := &file_comments_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewsCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewsCounts) ProtoMessage() {}
+
+func (x *NewsCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewsCounts.ProtoReflect.Descriptor instead.
+func (*NewsCounts) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *NewsCounts) GetNewsId() string {
+	if x != nil {
+		return x.NewsId
+	}
+	return ""
+}
+
+func (x *NewsCounts) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *NewsCounts) GetRoots() int64 {
+	if x != nil {
+		return x.Roots
+	}
+	return 0
+}
+
+func (x *NewsCounts) GetLastActivityAt() int64 {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return 0
+}
+
+type CountsByNewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewsIds       []string               `protobuf:"bytes,1,rep,name=news_ids,json=newsIds,proto3" json:"news_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountsByNewsRequest) Reset() {
+	*x = CountsByNewsRequest{}
+	mi := &file_comments_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountsByNewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountsByNewsRequest) ProtoMessage() {}
+
+func (x *CountsByNewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountsByNewsRequest.ProtoReflect.Descriptor instead.
+func (*CountsByNewsRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CountsByNewsRequest) GetNewsIds() []string {
+	if x != nil {
+		return x.NewsIds
+	}
+	return nil
+}
+
+type CountsByNewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        []*NewsCounts          `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountsByNewsResponse) Reset() {
+	*x = CountsByNewsResponse{}
+	mi := &file_comments_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountsByNewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountsByNewsResponse) ProtoMessage() {}
+
+func (x *CountsByNewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountsByNewsResponse.ProtoReflect.Descriptor instead.
+func (*CountsByNewsResponse) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CountsByNewsResponse) GetCounts() []*NewsCounts {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+// include_deleted — только для модераторов (роль проверяет API-Gateway):
+// мягко удалённые комментарии возвращаются «надгробиями»: is_deleted=true, текст стёрт.
+type ListByUserRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IncludeDeleted bool                   `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	PageSize       int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken      string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListByUserRequest) Reset() {
+	*x = ListByUserRequest{}
+	mi := &file_comments_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListByUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListByUserRequest) ProtoMessage() {}
+
+func (x *ListByUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListByUserRequest.ProtoReflect.Descriptor instead.
+func (*ListByUserRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListByUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListByUserRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListByUserRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListByUserRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListByUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListByUserResponse) Reset() {
+	*x = ListByUserResponse{}
+	mi := &file_comments_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListByUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListByUserResponse) ProtoMessage() {}
+
+func (x *ListByUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListByUserResponse.ProtoReflect.Descriptor instead.
+func (*ListByUserResponse) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListByUserResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListByUserResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// page_token — ключ последнего элемента (created_at, id): остаётся валидным,
+// даже если набор user_ids между страницами изменился.
+type ListByUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListByUsersRequest) Reset() {
+	*x = ListByUsersRequest{}
+	mi := &file_comments_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListByUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListByUsersRequest) ProtoMessage() {}
+
+func (x *ListByUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListByUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListByUsersRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListByUsersRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *ListByUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListByUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListByUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListByUsersResponse) Reset() {
+	*x = ListByUsersResponse{}
+	mi := &file_comments_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListByUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListByUsersResponse) ProtoMessage() {}
+
+func (x *ListByUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListByUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListByUsersResponse) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListByUsersResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListByUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SearchCommentsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Query          string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                                          // синтаксис $text MongoDB: слова, "фразы", -исключения; до 256 символов
+	NewsId         string                 `protobuf:"bytes,2,opt,name=news_id,json=newsId,proto3" json:"news_id,omitempty"`                          // необязательный фильтр
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                          // необязательный фильтр
+	IncludeDeleted bool                   `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"` // см. ListByUserRequest
+	PageSize       int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken      string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchCommentsRequest) Reset() {
+	*x = SearchCommentsRequest{}
+	mi := &file_comments_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCommentsRequest) ProtoMessage() {}
+
+func (x *SearchCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCommentsRequest.ProtoReflect.Descriptor instead.
+func (*SearchCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SearchCommentsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchCommentsRequest) GetNewsId() string {
+	if x != nil {
+		return x.NewsId
+	}
+	return ""
+}
+
+func (x *SearchCommentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SearchCommentsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *SearchCommentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchCommentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCommentsResponse) Reset() {
+	*x = SearchCommentsResponse{}
+	mi := &file_comments_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCommentsResponse) ProtoMessage() {}
+
+func (x *SearchCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comments_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCommentsResponse.ProtoReflect.Descriptor instead.
+func (*SearchCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_comments_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SearchCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *SearchCommentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_comments_proto protoreflect.FileDescriptor
+
+const file_comments_proto_rawDesc = "" +
+	"\n" +
+	"\x0ecomments.proto\x12\vcomments.v1\"\xaf\x04\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\anews_id\x18\x02 \x01(\tR\x06newsId\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x05 \x01(\tR\busername\x12\x18\n" +
+	"\acontent\x18\x06 \x01(\tR\acontent\x12\x14\n" +
+	"\x05level\x18\a \x01(\x05R\x05level\x12#\n" +
+	"\rreplies_count\x18\b \x01(\x05R\frepliesCount\x12\x1d\n" +
+	"\n" +
+	"is_deleted\x18\t \x01(\bR\tisDeleted\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\x03R\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\f \x01(\x03R\texpiresAt\x12\x17\n" +
+	"\aroot_id\x18\r \x01(\tR\x06rootId\x12\x1b\n" +
+	"\tis_locked\x18\x0e \x01(\bR\bisLocked\x12!\n" +
+	"\fcontent_html\x18\x0f \x01(\tR\vcontentHtml\x12A\n" +
+	"\treactions\x18\x10 \x03(\v2#.comments.v1.Comment.ReactionsEntryR\treactions\x1a<\n" +
+	"\x0eReactionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"u\n" +
+	"\fThreadPolicy\x12\x17\n" +
+	"\anews_id\x18\x01 \x01(\tR\x06newsId\x121\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x1d.comments.v1.ThreadPolicyModeR\x04mode\x12\x19\n" +
+	"\bttl_days\x18\x03 \x01(\x05R\attlDays\"\x9b\x01\n" +
+	"\x14CreateCommentRequest\x12\x17\n" +
+	"\anews_id\x18\x01 \x01(\tR\x06newsId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\"G\n" +
+	"\x15CreateCommentResponse\x12.\n" +
+	"\acomment\x18\x01 \x01(\v2\x14.comments.v1.CommentR\acomment\"&\n" +
+	"\x14DeleteCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
+	"\x15DeleteCommentResponse\"$\n" +
+	"\x12CommentByIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"E\n" +
+	"\x13CommentByIDResponse\x12.\n" +
+	"\acomment\x18\x01 \x01(\v2\x14.comments.v1.CommentR\acomment\"h\n" +
+	"\x11ListByNewsRequest\x12\x17\n" +
+	"\anews_id\x18\x01 \x01(\tR\x06newsId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"n\n" +
+	"\x12ListByNewsResponse\x120\n" +
+	"\bcomments\x18\x01 \x03(\v2\x14.comments.v1.CommentR\bcomments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"m\n" +
+	"\x12ListRepliesRequest\x12\x1b\n" +
+	"\tparent_id\x18\x01 \x01(\tR\bparentId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"o\n" +
+	"\x13ListRepliesResponse\x120\n" +
+	"\bcomments\x18\x01 \x03(\v2\x14.comments.v1.CommentR\bcomments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xb9\x02\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x121\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\x1d.comments.v1.NotificationKindR\x04kind\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x04 \x01(\tR\tcommentId\x12\x17\n" +
+	"\anews_id\x18\x05 \x01(\tR\x06newsId\x12\x1b\n" +
+	"\tthread_id\x18\x06 \x01(\tR\bthreadId\x12\x19\n" +
+	"\bactor_id\x18\a \x01(\tR\aactorId\x12%\n" +
+	"\x0eactor_username\x18\b \x01(\tR\ractorUsername\x12\x17\n" +
+	"\ais_read\x18\t \x01(\bR\x06isRead\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt\"\x90\x01\n" +
+	"\x18ListNotificationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vunread_only\x18\x02 \x01(\bR\n" +
+	"unreadOnly\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\x84\x01\n" +
+	"\x19ListNotificationsResponse\x12?\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x19.comments.v1.NotificationR\rnotifications\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"N\n" +
+	"\x0fMarkReadRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\tR\x03ids\x12\x10\n" +
+	"\x03all\x18\x03 \x01(\bR\x03all\",\n" +
+	"\x10MarkReadResponse\x12\x18\n" +
+	"\aupdated\x18\x01 \x01(\x03R\aupdated\"-\n" +
+	"\x12UnreadCountRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"+\n" +
+	"\x13UnreadCountResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\"K\n" +
+	"\x11MuteThreadRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x02 \x01(\tR\tcommentId\"1\n" +
+	"\x12MuteThreadResponse\x12\x1b\n" +
+	"\tthread_id\x18\x01 \x01(\tR\bthreadId\"4\n" +
+	"\x19WatchNotificationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"S\n" +
+	"\x14WatchCommentsRequest\x12\x17\n" +
+	"\anews_id\x18\x01 \x01(\tR\x06newsId\x12\"\n" +
+	"\rlast_event_id\x18\x02 \x01(\tR\vlastEventId\"\xb3\x01\n" +
+	"\fCommentEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12.\n" +
+	"\acomment\x18\x02 \x01(\v2\x14.comments.v1.CommentR\acomment\x12%\n" +
+	"\x0ereset_required\x18\x03 \x01(\bR\rresetRequired\x121\n" +
+	"\x04kind\x18\x04 \x01(\x0e2\x1d.comments.v1.CommentEventKindR\x04kind\"z\n" +
+	"\fReactRequest\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\breaction\x18\x03 \x01(\tR\breaction\x12\x16\n" +
+	"\x06remove\x18\x04 \x01(\bR\x06remove\"?\n" +
+	"\rReactResponse\x12.\n" +
+	"\acomment\x18\x01 \x01(\v2\x14.comments.v1.CommentR\acomment\"J\n" +
+	"\x11LockThreadRequest\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\x12\x16\n" +
+	"\x06locked\x18\x02 \x01(\bR\x06locked\"D\n" +
+	"\x12LockThreadResponse\x12.\n" +
+	"\acomment\x18\x01 \x01(\v2\x14.comments.v1.CommentR\acomment\"1\n" +
+	"\x16GetThreadPolicyRequest\x12\x17\n" +
+	"\anews_id\x18\x01 \x01(\tR\x06newsId\"L\n" +
+	"\x17GetThreadPolicyResponse\x121\n" +
+	"\x06policy\x18\x01 \x01(\v2\x19.comments.v1.ThreadPolicyR\x06policy\"K\n" +
+	"\x16SetThreadPolicyRequest\x121\n" +
+	"\x06policy\x18\x01 \x01(\v2\x19.comments.v1.ThreadPolicyR\x06policy\"L\n" +
+	"\x17SetThreadPolicyResponse\x121\n" +
+	"\x06policy\x18\x01 \x01(\v2\x19.comments.v1.ThreadPolicyR\x06policy\"{\n" +
+	"\n" +
+	"NewsCounts\x12\x17\n" +
+	"\anews_id\x18\x01 \x01(\tR\x06newsId\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05roots\x18\x03 \x01(\x03R\x05roots\x12(\n" +
+	"\x10last_activity_at\x18\x04 \x01(\x03R\x0elastActivityAt\"0\n" +
+	"\x13CountsByNewsRequest\x12\x19\n" +
+	"\bnews_ids\x18\x01 \x03(\tR\anewsIds\"G\n" +
+	"\x14CountsByNewsResponse\x12/\n" +
+	"\x06counts\x18\x01 \x03(\v2\x17.comments.v1.NewsCountsR\x06counts\"\x91\x01\n" +
+	"\x11ListByUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"n\n" +
+	"\x12ListByUserResponse\x120\n" +
+	"\bcomments\x18\x01 \x03(\v2\x14.comments.v1.CommentR\bcomments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"k\n" +
+	"\x12ListByUsersRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"o\n" +
+	"\x13ListByUsersResponse\x120\n" +
+	"\bcomments\x18\x01 \x03(\v2\x14.comments.v1.CommentR\bcomments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xc4\x01\n" +
+	"\x15SearchCommentsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x17\n" +
+	"\anews_id\x18\x02 \x01(\tR\x06newsId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12'\n" +
+	"\x0finclude_deleted\x18\x04 \x01(\bR\x0eincludeDeleted\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"r\n" +
+	"\x16SearchCommentsResponse\x120\n" +
+	"\bcomments\x18\x01 \x03(\v2\x14.comments.v1.CommentR\bcomments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*[\n" +
+	"\x10ThreadPolicyMode\x12\"\n" +
+	"\x1eTHREAD_POLICY_MODE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aFOREVER\x10\x01\x12\n" +
+	"\n" +
+	"\x06EXPIRE\x10\x02\x12\n" +
+	"\n" +
+	"\x06LOCKED\x10\x03*M\n" +
+	"\x10NotificationKind\x12!\n" +
+	"\x1dNOTIFICATION_KIND_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05REPLY\x10\x01\x12\v\n" +
+	"\aMENTION\x10\x02*v\n" +
+	"\x10CommentEventKind\x12\"\n" +
+	"\x1eCOMMENT_EVENT_KIND_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fCOMMENT_CREATED\x10\x01\x12\x13\n" +
+	"\x0fCOMMENT_DELETED\x10\x02\x12\x14\n" +
+	"\x10COMMENT_REACTION\x10\x032\x9b\r\n" +
+	"\x0fCommentsService\x12V\n" +
+	"\rCreateComment\x12!.comments.v1.CreateCommentRequest\x1a\".comments.v1.CreateCommentResponse\x12V\n" +
+	"\rDeleteComment\x12!.comments.v1.DeleteCommentRequest\x1a\".comments.v1.DeleteCommentResponse\x12P\n" +
+	"\vCommentByID\x12\x1f.comments.v1.CommentByIDRequest\x1a .comments.v1.CommentByIDResponse\x12M\n" +
+	"\n" +
+	"ListByNews\x12\x1e.comments.v1.ListByNewsRequest\x1a\x1f.comments.v1.ListByNewsResponse\x12P\n" +
+	"\vListReplies\x12\x1f.comments.v1.ListRepliesRequest\x1a .comments.v1.ListRepliesResponse\x12S\n" +
+	"\fCountsByNews\x12 .comments.v1.CountsByNewsRequest\x1a!.comments.v1.CountsByNewsResponse\x12M\n" +
+	"\n" +
+	"ListByUser\x12\x1e.comments.v1.ListByUserRequest\x1a\x1f.comments.v1.ListByUserResponse\x12P\n" +
+	"\vListByUsers\x12\x1f.comments.v1.ListByUsersRequest\x1a .comments.v1.ListByUsersResponse\x12Y\n" +
+	"\x0eSearchComments\x12\".comments.v1.SearchCommentsRequest\x1a#.comments.v1.SearchCommentsResponse\x12b\n" +
+	"\x11ListNotifications\x12%.comments.v1.ListNotificationsRequest\x1a&.comments.v1.ListNotificationsResponse\x12G\n" +
+	"\bMarkRead\x12\x1c.comments.v1.MarkReadRequest\x1a\x1d.comments.v1.MarkReadResponse\x12P\n" +
+	"\vUnreadCount\x12\x1f.comments.v1.UnreadCountRequest\x1a .comments.v1.UnreadCountResponse\x12M\n" +
+	"\n" +
+	"MuteThread\x12\x1e.comments.v1.MuteThreadRequest\x1a\x1f.comments.v1.MuteThreadResponse\x12O\n" +
+	"\fUnmuteThread\x12\x1e.comments.v1.MuteThreadRequest\x1a\x1f.comments.v1.MuteThreadResponse\x12Y\n" +
+	"\x12WatchNotifications\x12&.comments.v1.WatchNotificationsRequest\x1a\x19.comments.v1.Notification0\x01\x12O\n" +
+	"\rWatchComments\x12!.comments.v1.WatchCommentsRequest\x1a\x19.comments.v1.CommentEvent0\x01\x12>\n" +
+	"\x05React\x12\x19.comments.v1.ReactRequest\x1a\x1a.comments.v1.ReactResponse\x12M\n" +
+	"\n" +
+	"LockThread\x12\x1e.comments.v1.LockThreadRequest\x1a\x1f.comments.v1.LockThreadResponse\x12\\\n" +
+	"\x0fGetThreadPolicy\x12#.comments.v1.GetThreadPolicyRequest\x1a$.comments.v1.GetThreadPolicyResponse\x12\\\n" +
+	"\x0fSetThreadPolicy\x12#.comments.v1.SetThreadPolicyRequest\x1a$.comments.v1.SetThreadPolicyResponseBGZEgithub.com/pribylovaa/go-news-aggregator/proto/comments/v1;commentsv1b\x06proto3"
 
 var (
 	file_comments_proto_rawDescOnce sync.Once
@@ -740,40 +2747,120 @@ func file_comments_proto_rawDescGZIP() []byte {
 	return file_comments_proto_rawDescData
 }
 
-var file_comments_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_comments_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_comments_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_comments_proto_goTypes = []any{
-	(*Comment)(nil),               // 0: comments.v1.Comment
-	(*CreateCommentRequest)(nil),  // 1: comments.v1.CreateCommentRequest
-	(*CreateCommentResponse)(nil), // 2: comments.v1.CreateCommentResponse
-	(*DeleteCommentRequest)(nil),  // 3: comments.v1.DeleteCommentRequest
-	(*DeleteCommentResponse)(nil), // 4: comments.v1.DeleteCommentResponse
-	(*CommentByIDRequest)(nil),    // 5: comments.v1.CommentByIDRequest
-	(*CommentByIDResponse)(nil),   // 6: comments.v1.CommentByIDResponse
-	(*ListByNewsRequest)(nil),     // 7: comments.v1.ListByNewsRequest
-	(*ListByNewsResponse)(nil),    // 8: comments.v1.ListByNewsResponse
-	(*ListRepliesRequest)(nil),    // 9: comments.v1.ListRepliesRequest
-	(*ListRepliesResponse)(nil),   // 10: comments.v1.ListRepliesResponse
+	(ThreadPolicyMode)(0),             // 0: comments.v1.ThreadPolicyMode
+	(NotificationKind)(0),             // 1: comments.v1.NotificationKind
+	(CommentEventKind)(0),             // 2: comments.v1.CommentEventKind
+	(*Comment)(nil),                   // 3: comments.v1.Comment
+	(*ThreadPolicy)(nil),              // 4: comments.v1.ThreadPolicy
+	(*CreateCommentRequest)(nil),      // 5: comments.v1.CreateCommentRequest
+	(*CreateCommentResponse)(nil),     // 6: comments.v1.CreateCommentResponse
+	(*DeleteCommentRequest)(nil),      // 7: comments.v1.DeleteCommentRequest
+	(*DeleteCommentResponse)(nil),     // 8: comments.v1.DeleteCommentResponse
+	(*CommentByIDRequest)(nil),        // 9: comments.v1.CommentByIDRequest
+	(*CommentByIDResponse)(nil),       // 10: comments.v1.CommentByIDResponse
+	(*ListByNewsRequest)(nil),         // 11: comments.v1.ListByNewsRequest
+	(*ListByNewsResponse)(nil),        // 12: comments.v1.ListByNewsResponse
+	(*ListRepliesRequest)(nil),        // 13: comments.v1.ListRepliesRequest
+	(*ListRepliesResponse)(nil),       // 14: comments.v1.ListRepliesResponse
+	(*Notification)(nil),              // 15: comments.v1.Notification
+	(*ListNotificationsRequest)(nil),  // 16: comments.v1.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil), // 17: comments.v1.ListNotificationsResponse
+	(*MarkReadRequest)(nil),           // 18: comments.v1.MarkReadRequest
+	(*MarkReadResponse)(nil),          // 19: comments.v1.MarkReadResponse
+	(*UnreadCountRequest)(nil),        // 20: comments.v1.UnreadCountRequest
+	(*UnreadCountResponse)(nil),       // 21: comments.v1.UnreadCountResponse
+	(*MuteThreadRequest)(nil),         // 22: comments.v1.MuteThreadRequest
+	(*MuteThreadResponse)(nil),        // 23: comments.v1.MuteThreadResponse
+	(*WatchNotificationsRequest)(nil), // 24: comments.v1.WatchNotificationsRequest
+	(*WatchCommentsRequest)(nil),      // 25: comments.v1.WatchCommentsRequest
+	(*CommentEvent)(nil),              // 26: comments.v1.CommentEvent
+	(*ReactRequest)(nil),              // 27: comments.v1.ReactRequest
+	(*ReactResponse)(nil),             // 28: comments.v1.ReactResponse
+	(*LockThreadRequest)(nil),         // 29: comments.v1.LockThreadRequest
+	(*LockThreadResponse)(nil),        // 30: comments.v1.LockThreadResponse
+	(*GetThreadPolicyRequest)(nil),    // 31: comments.v1.GetThreadPolicyRequest
+	(*GetThreadPolicyResponse)(nil),   // 32: comments.v1.GetThreadPolicyResponse
+	(*SetThreadPolicyRequest)(nil),    // 33: comments.v1.SetThreadPolicyRequest
+	(*SetThreadPolicyResponse)(nil),   // 34: comments.v1.SetThreadPolicyResponse
+	(*NewsCounts)(nil),                // 35: comments.v1.NewsCounts
+	(*CountsByNewsRequest)(nil),       // 36: comments.v1.CountsByNewsRequest
+	(*CountsByNewsResponse)(nil),      // 37: comments.v1.CountsByNewsResponse
+	(*ListByUserRequest)(nil),         // 38: comments.v1.ListByUserRequest
+	(*ListByUserResponse)(nil),        // 39: comments.v1.ListByUserResponse
+	(*ListByUsersRequest)(nil),        // 40: comments.v1.ListByUsersRequest
+	(*ListByUsersResponse)(nil),       // 41: comments.v1.ListByUsersResponse
+	(*SearchCommentsRequest)(nil),     // 42: comments.v1.SearchCommentsRequest
+	(*SearchCommentsResponse)(nil),    // 43: comments.v1.SearchCommentsResponse
+	nil,                               // 44: comments.v1.Comment.ReactionsEntry
 }
 var file_comments_proto_depIdxs = []int32{
-	0,  // 0: comments.v1.CreateCommentResponse.comment:type_name -> comments.v1.Comment
-	0,  // 1: comments.v1.CommentByIDResponse.comment:type_name -> comments.v1.Comment
-	0,  // 2: comments.v1.ListByNewsResponse.comments:type_name -> comments.v1.Comment
-	0,  // 3: comments.v1.ListRepliesResponse.comments:type_name -> comments.v1.Comment
-	1,  // 4: comments.v1.CommentsService.CreateComment:input_type -> comments.v1.CreateCommentRequest
-	3,  // 5: comments.v1.CommentsService.DeleteComment:input_type -> comments.v1.DeleteCommentRequest
-	5,  // 6: comments.v1.CommentsService.CommentByID:input_type -> comments.v1.CommentByIDRequest
-	7,  // 7: comments.v1.CommentsService.ListByNews:input_type -> comments.v1.ListByNewsRequest
-	9,  // 8: comments.v1.CommentsService.ListReplies:input_type -> comments.v1.ListRepliesRequest
-	2,  // 9: comments.v1.CommentsService.CreateComment:output_type -> comments.v1.CreateCommentResponse
-	4,  // 10: comments.v1.CommentsService.DeleteComment:output_type -> comments.v1.DeleteCommentResponse
-	6,  // 11: comments.v1.CommentsService.CommentByID:output_type -> comments.v1.CommentByIDResponse
-	8,  // 12: comments.v1.CommentsService.ListByNews:output_type -> comments.v1.ListByNewsResponse
-	10, // 13: comments.v1.CommentsService.ListReplies:output_type -> comments.v1.ListRepliesResponse
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	44, // 0: comments.v1.Comment.reactions:type_name -> comments.v1.Comment.ReactionsEntry
+	0,  // 1: comments.v1.ThreadPolicy.mode:type_name -> comments.v1.ThreadPolicyMode
+	3,  // 2: comments.v1.CreateCommentResponse.comment:type_name -> comments.v1.Comment
+	3,  // 3: comments.v1.CommentByIDResponse.comment:type_name -> comments.v1.Comment
+	3,  // 4: comments.v1.ListByNewsResponse.comments:type_name -> comments.v1.Comment
+	3,  // 5: comments.v1.ListRepliesResponse.comments:type_name -> comments.v1.Comment
+	1,  // 6: comments.v1.Notification.kind:type_name -> comments.v1.NotificationKind
+	15, // 7: comments.v1.ListNotificationsResponse.notifications:type_name -> comments.v1.Notification
+	3,  // 8: comments.v1.CommentEvent.comment:type_name -> comments.v1.Comment
+	2,  // 9: comments.v1.CommentEvent.kind:type_name -> comments.v1.CommentEventKind
+	3,  // 10: comments.v1.ReactResponse.comment:type_name -> comments.v1.Comment
+	3,  // 11: comments.v1.LockThreadResponse.comment:type_name -> comments.v1.Comment
+	4,  // 12: comments.v1.GetThreadPolicyResponse.policy:type_name -> comments.v1.ThreadPolicy
+	4,  // 13: comments.v1.SetThreadPolicyRequest.policy:type_name -> comments.v1.ThreadPolicy
+	4,  // 14: comments.v1.SetThreadPolicyResponse.policy:type_name -> comments.v1.ThreadPolicy
+	35, // 15: comments.v1.CountsByNewsResponse.counts:type_name -> comments.v1.NewsCounts
+	3,  // 16: comments.v1.ListByUserResponse.comments:type_name -> comments.v1.Comment
+	3,  // 17: comments.v1.ListByUsersResponse.comments:type_name -> comments.v1.Comment
+	3,  // 18: comments.v1.SearchCommentsResponse.comments:type_name -> comments.v1.Comment
+	5,  // 19: comments.v1.CommentsService.CreateComment:input_type -> comments.v1.CreateCommentRequest
+	7,  // 20: comments.v1.CommentsService.DeleteComment:input_type -> comments.v1.DeleteCommentRequest
+	9,  // 21: comments.v1.CommentsService.CommentByID:input_type -> comments.v1.CommentByIDRequest
+	11, // 22: comments.v1.CommentsService.ListByNews:input_type -> comments.v1.ListByNewsRequest
+	13, // 23: comments.v1.CommentsService.ListReplies:input_type -> comments.v1.ListRepliesRequest
+	36, // 24: comments.v1.CommentsService.CountsByNews:input_type -> comments.v1.CountsByNewsRequest
+	38, // 25: comments.v1.CommentsService.ListByUser:input_type -> comments.v1.ListByUserRequest
+	40, // 26: comments.v1.CommentsService.ListByUsers:input_type -> comments.v1.ListByUsersRequest
+	42, // 27: comments.v1.CommentsService.SearchComments:input_type -> comments.v1.SearchCommentsRequest
+	16, // 28: comments.v1.CommentsService.ListNotifications:input_type -> comments.v1.ListNotificationsRequest
+	18, // 29: comments.v1.CommentsService.MarkRead:input_type -> comments.v1.MarkReadRequest
+	20, // 30: comments.v1.CommentsService.UnreadCount:input_type -> comments.v1.UnreadCountRequest
+	22, // 31: comments.v1.CommentsService.MuteThread:input_type -> comments.v1.MuteThreadRequest
+	22, // 32: comments.v1.CommentsService.UnmuteThread:input_type -> comments.v1.MuteThreadRequest
+	24, // 33: comments.v1.CommentsService.WatchNotifications:input_type -> comments.v1.WatchNotificationsRequest
+	25, // 34: comments.v1.CommentsService.WatchComments:input_type -> comments.v1.WatchCommentsRequest
+	27, // 35: comments.v1.CommentsService.React:input_type -> comments.v1.ReactRequest
+	29, // 36: comments.v1.CommentsService.LockThread:input_type -> comments.v1.LockThreadRequest
+	31, // 37: comments.v1.CommentsService.GetThreadPolicy:input_type -> comments.v1.GetThreadPolicyRequest
+	33, // 38: comments.v1.CommentsService.SetThreadPolicy:input_type -> comments.v1.SetThreadPolicyRequest
+	6,  // 39: comments.v1.CommentsService.CreateComment:output_type -> comments.v1.CreateCommentResponse
+	8,  // 40: comments.v1.CommentsService.DeleteComment:output_type -> comments.v1.DeleteCommentResponse
+	10, // 41: comments.v1.CommentsService.CommentByID:output_type -> comments.v1.CommentByIDResponse
+	12, // 42: comments.v1.CommentsService.ListByNews:output_type -> comments.v1.ListByNewsResponse
+	14, // 43: comments.v1.CommentsService.ListReplies:output_type -> comments.v1.ListRepliesResponse
+	37, // 44: comments.v1.CommentsService.CountsByNews:output_type -> comments.v1.CountsByNewsResponse
+	39, // 45: comments.v1.CommentsService.ListByUser:output_type -> comments.v1.ListByUserResponse
+	41, // 46: comments.v1.CommentsService.ListByUsers:output_type -> comments.v1.ListByUsersResponse
+	43, // 47: comments.v1.CommentsService.SearchComments:output_type -> comments.v1.SearchCommentsResponse
+	17, // 48: comments.v1.CommentsService.ListNotifications:output_type -> comments.v1.ListNotificationsResponse
+	19, // 49: comments.v1.CommentsService.MarkRead:output_type -> comments.v1.MarkReadResponse
+	21, // 50: comments.v1.CommentsService.UnreadCount:output_type -> comments.v1.UnreadCountResponse
+	23, // 51: comments.v1.CommentsService.MuteThread:output_type -> comments.v1.MuteThreadResponse
+	23, // 52: comments.v1.CommentsService.UnmuteThread:output_type -> comments.v1.MuteThreadResponse
+	15, // 53: comments.v1.CommentsService.WatchNotifications:output_type -> comments.v1.Notification
+	26, // 54: comments.v1.CommentsService.WatchComments:output_type -> comments.v1.CommentEvent
+	28, // 55: comments.v1.CommentsService.React:output_type -> comments.v1.ReactResponse
+	30, // 56: comments.v1.CommentsService.LockThread:output_type -> comments.v1.LockThreadResponse
+	32, // 57: comments.v1.CommentsService.GetThreadPolicy:output_type -> comments.v1.GetThreadPolicyResponse
+	34, // 58: comments.v1.CommentsService.SetThreadPolicy:output_type -> comments.v1.SetThreadPolicyResponse
+	39, // [39:59] is the sub-list for method output_type
+	19, // [19:39] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_comments_proto_init() }
@@ -786,13 +2873,14 @@ func file_comments_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_comments_proto_rawDesc), len(file_comments_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      3,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_comments_proto_goTypes,
 		DependencyIndexes: file_comments_proto_depIdxs,
+		EnumInfos:         file_comments_proto_enumTypes,
 		MessageInfos:      file_comments_proto_msgTypes,
 	}.Build()
 	File_comments_proto = out.File
@@ -19,11 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CommentsService_CreateComment_FullMethodName = "/comments.v1.CommentsService/CreateComment"
-	CommentsService_DeleteComment_FullMethodName = "/comments.v1.CommentsService/DeleteComment"
-	CommentsService_CommentByID_FullMethodName   = "/comments.v1.CommentsService/CommentByID"
-	CommentsService_ListByNews_FullMethodName    = "/comments.v1.CommentsService/ListByNews"
-	CommentsService_ListReplies_FullMethodName   = "/comments.v1.CommentsService/ListReplies"
+	CommentsService_CreateComment_FullMethodName      = "/comments.v1.CommentsService/CreateComment"
+	CommentsService_DeleteComment_FullMethodName      = "/comments.v1.CommentsService/DeleteComment"
+	CommentsService_CommentByID_FullMethodName        = "/comments.v1.CommentsService/CommentByID"
+	CommentsService_ListByNews_FullMethodName         = "/comments.v1.CommentsService/ListByNews"
+	CommentsService_ListReplies_FullMethodName        = "/comments.v1.CommentsService/ListReplies"
+	CommentsService_CountsByNews_FullMethodName       = "/comments.v1.CommentsService/CountsByNews"
+	CommentsService_ListByUser_FullMethodName         = "/comments.v1.CommentsService/ListByUser"
+	CommentsService_ListByUsers_FullMethodName        = "/comments.v1.CommentsService/ListByUsers"
+	CommentsService_SearchComments_FullMethodName     = "/comments.v1.CommentsService/SearchComments"
+	CommentsService_ListNotifications_FullMethodName  = "/comments.v1.CommentsService/ListNotifications"
+	CommentsService_MarkRead_FullMethodName           = "/comments.v1.CommentsService/MarkRead"
+	CommentsService_UnreadCount_FullMethodName        = "/comments.v1.CommentsService/UnreadCount"
+	CommentsService_MuteThread_FullMethodName         = "/comments.v1.CommentsService/MuteThread"
+	CommentsService_UnmuteThread_FullMethodName       = "/comments.v1.CommentsService/UnmuteThread"
+	CommentsService_WatchNotifications_FullMethodName = "/comments.v1.CommentsService/WatchNotifications"
+	CommentsService_WatchComments_FullMethodName      = "/comments.v1.CommentsService/WatchComments"
+	CommentsService_React_FullMethodName              = "/comments.v1.CommentsService/React"
+	CommentsService_LockThread_FullMethodName         = "/comments.v1.CommentsService/LockThread"
+	CommentsService_GetThreadPolicy_FullMethodName    = "/comments.v1.CommentsService/GetThreadPolicy"
+	CommentsService_SetThreadPolicy_FullMethodName    = "/comments.v1.CommentsService/SetThreadPolicy"
 )
 
 // CommentsServiceClient is the client API for CommentsService service.
@@ -37,6 +52,35 @@ type CommentsServiceClient interface {
 	ListByNews(ctx context.Context, in *ListByNewsRequest, opts ...grpc.CallOption) (*ListByNewsResponse, error)
 	// Подзагрузка ответов для ветки (дети одного parent_id), сначала старые.
 	ListReplies(ctx context.Context, in *ListRepliesRequest, opts ...grpc.CallOption) (*ListRepliesResponse, error)
+	// Счётчики комментариев для набора новостей (до 200 id, порядок запроса сохраняется).
+	CountsByNews(ctx context.Context, in *CountsByNewsRequest, opts ...grpc.CallOption) (*CountsByNewsResponse, error)
+	// Комментарии автора (корни и ответы), сначала новые.
+	ListByUser(ctx context.Context, in *ListByUserRequest, opts ...grpc.CallOption) (*ListByUserResponse, error)
+	// Общая лента комментариев нескольких авторов (до 500), сначала новые; без удалённых.
+	ListByUsers(ctx context.Context, in *ListByUsersRequest, opts ...grpc.CallOption) (*ListByUsersResponse, error)
+	// Полнотекстовый поиск по тексту комментариев, сначала новые.
+	SearchComments(ctx context.Context, in *SearchCommentsRequest, opts ...grpc.CallOption) (*SearchCommentsResponse, error)
+	// Уведомления об ответах и @упоминаниях («входящие»), сначала новые.
+	ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error)
+	// Отметить прочитанными выбранные уведомления либо все (all=true).
+	MarkRead(ctx context.Context, in *MarkReadRequest, opts ...grpc.CallOption) (*MarkReadResponse, error)
+	UnreadCount(ctx context.Context, in *UnreadCountRequest, opts ...grpc.CallOption) (*UnreadCountResponse, error)
+	// Заглушить/вернуть уведомления по ветке; comment_id — любой комментарий ветки.
+	MuteThread(ctx context.Context, in *MuteThreadRequest, opts ...grpc.CallOption) (*MuteThreadResponse, error)
+	UnmuteThread(ctx context.Context, in *MuteThreadRequest, opts ...grpc.CallOption) (*MuteThreadResponse, error)
+	// Живая подписка на новые уведомления пользователя.
+	WatchNotifications(ctx context.Context, in *WatchNotificationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notification], error)
+	// Живая подписка на комментарии новости (корни и ответы): создание, удаление и реакции.
+	// С last_event_id сначала досылаются пропущенные созданные (или событие reset_required).
+	// Медленный клиент отключается с RESOURCE_EXHAUSTED.
+	WatchComments(ctx context.Context, in *WatchCommentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommentEvent], error)
+	// Поставить/снять реакцию пользователя на комментарий (like, love, laugh, wow, sad, angry).
+	React(ctx context.Context, in *ReactRequest, opts ...grpc.CallOption) (*ReactResponse, error)
+	// Модерация: заблокировать/разблокировать ветку; comment_id — любой комментарий ветки.
+	LockThread(ctx context.Context, in *LockThreadRequest, opts ...grpc.CallOption) (*LockThreadResponse, error)
+	// Политика жизни веток новости (истёкшие и заблокированные ветки остаются доступны для чтения).
+	GetThreadPolicy(ctx context.Context, in *GetThreadPolicyRequest, opts ...grpc.CallOption) (*GetThreadPolicyResponse, error)
+	SetThreadPolicy(ctx context.Context, in *SetThreadPolicyRequest, opts ...grpc.CallOption) (*SetThreadPolicyResponse, error)
 }
 
 type commentsServiceClient struct {
@@ -97,6 +141,174 @@ func (c *commentsServiceClient) ListReplies(ctx context.Context, in *ListReplies
 	return out, nil
 }
 
+func (c *commentsServiceClient) CountsByNews(ctx context.Context, in *CountsByNewsRequest, opts ...grpc.CallOption) (*CountsByNewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountsByNewsResponse)
+	err := c.cc.Invoke(ctx, CommentsService_CountsByNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsServiceClient) ListByUser(ctx context.Context, in *ListByUserRequest, opts ...grpc.CallOption) (*ListByUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListByUserResponse)
+	err := c.cc.Invoke(ctx, CommentsService_ListByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsServiceClient) ListByUsers(ctx context.Context, in *ListByUsersRequest, opts ...grpc.CallOption) (*ListByUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListByUsersResponse)
+	err := c.cc.Invoke(ctx, CommentsService_ListByUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsServiceClient) SearchComments(ctx context.Context, in *SearchCommentsRequest, opts ...grpc.CallOption) (*SearchCommentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchCommentsResponse)
+	err := c.cc.Invoke(ctx, CommentsService_SearchComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsServiceClient) ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationsResponse)
+	err := c.cc.Invoke(ctx, CommentsService_ListNotifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsServiceClient) MarkRead(ctx context.Context, in *MarkReadRequest, opts ...grpc.CallOption) (*MarkReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkReadResponse)
+	err := c.cc.Invoke(ctx, CommentsService_MarkRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsServiceClient) UnreadCount(ctx context.Context, in *UnreadCountRequest, opts ...grpc.CallOption) (*UnreadCountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnreadCountResponse)
+	err := c.cc.Invoke(ctx, CommentsService_UnreadCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsServiceClient) MuteThread(ctx context.Context, in *MuteThreadRequest, opts ...grpc.CallOption) (*MuteThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MuteThreadResponse)
+	err := c.cc.Invoke(ctx, CommentsService_MuteThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsServiceClient) UnmuteThread(ctx context.Context, in *MuteThreadRequest, opts ...grpc.CallOption) (*MuteThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MuteThreadResponse)
+	err := c.cc.Invoke(ctx, CommentsService_UnmuteThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsServiceClient) WatchNotifications(ctx context.Context, in *WatchNotificationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CommentsService_ServiceDesc.Streams[0], CommentsService_WatchNotifications_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchNotificationsRequest, Notification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CommentsService_WatchNotificationsClient = grpc.ServerStreamingClient[Notification]
+
+func (c *commentsServiceClient) WatchComments(ctx context.Context, in *WatchCommentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommentEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CommentsService_ServiceDesc.Streams[1], CommentsService_WatchComments_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCommentsRequest, CommentEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CommentsService_WatchCommentsClient = grpc.ServerStreamingClient[CommentEvent]
+
+func (c *commentsServiceClient) React(ctx context.Context, in *ReactRequest, opts ...grpc.CallOption) (*ReactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactResponse)
+	err := c.cc.Invoke(ctx, CommentsService_React_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsServiceClient) LockThread(ctx context.Context, in *LockThreadRequest, opts ...grpc.CallOption) (*LockThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LockThreadResponse)
+	err := c.cc.Invoke(ctx, CommentsService_LockThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsServiceClient) GetThreadPolicy(ctx context.Context, in *GetThreadPolicyRequest, opts ...grpc.CallOption) (*GetThreadPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetThreadPolicyResponse)
+	err := c.cc.Invoke(ctx, CommentsService_GetThreadPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsServiceClient) SetThreadPolicy(ctx context.Context, in *SetThreadPolicyRequest, opts ...grpc.CallOption) (*SetThreadPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetThreadPolicyResponse)
+	err := c.cc.Invoke(ctx, CommentsService_SetThreadPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommentsServiceServer is the server API for CommentsService service.
 // All implementations must embed UnimplementedCommentsServiceServer
 // for forward compatibility.
@@ -108,6 +320,35 @@ type CommentsServiceServer interface {
 	ListByNews(context.Context, *ListByNewsRequest) (*ListByNewsResponse, error)
 	// Подзагрузка ответов для ветки (дети одного parent_id), сначала старые.
 	ListReplies(context.Context, *ListRepliesRequest) (*ListRepliesResponse, error)
+	// Счётчики комментариев для набора новостей (до 200 id, порядок запроса сохраняется).
+	CountsByNews(context.Context, *CountsByNewsRequest) (*CountsByNewsResponse, error)
+	// Комментарии автора (корни и ответы), сначала новые.
+	ListByUser(context.Context, *ListByUserRequest) (*ListByUserResponse, error)
+	// Общая лента комментариев нескольких авторов (до 500), сначала новые; без удалённых.
+	ListByUsers(context.Context, *ListByUsersRequest) (*ListByUsersResponse, error)
+	// Полнотекстовый поиск по тексту комментариев, сначала новые.
+	SearchComments(context.Context, *SearchCommentsRequest) (*SearchCommentsResponse, error)
+	// Уведомления об ответах и @упоминаниях («входящие»), сначала новые.
+	ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error)
+	// Отметить прочитанными выбранные уведомления либо все (all=true).
+	MarkRead(context.Context, *MarkReadRequest) (*MarkReadResponse, error)
+	UnreadCount(context.Context, *UnreadCountRequest) (*UnreadCountResponse, error)
+	// Заглушить/вернуть уведомления по ветке; comment_id — любой комментарий ветки.
+	MuteThread(context.Context, *MuteThreadRequest) (*MuteThreadResponse, error)
+	UnmuteThread(context.Context, *MuteThreadRequest) (*MuteThreadResponse, error)
+	// Живая подписка на новые уведомления пользователя.
+	WatchNotifications(*WatchNotificationsRequest, grpc.ServerStreamingServer[Notification]) error
+	// Живая подписка на комментарии новости (корни и ответы): создание, удаление и реакции.
+	// С last_event_id сначала досылаются пропущенные созданные (или событие reset_required).
+	// Медленный клиент отключается с RESOURCE_EXHAUSTED.
+	WatchComments(*WatchCommentsRequest, grpc.ServerStreamingServer[CommentEvent]) error
+	// Поставить/снять реакцию пользователя на комментарий (like, love, laugh, wow, sad, angry).
+	React(context.Context, *ReactRequest) (*ReactResponse, error)
+	// Модерация: заблокировать/разблокировать ветку; comment_id — любой комментарий ветки.
+	LockThread(context.Context, *LockThreadRequest) (*LockThreadResponse, error)
+	// Политика жизни веток новости (истёкшие и заблокированные ветки остаются доступны для чтения).
+	GetThreadPolicy(context.Context, *GetThreadPolicyRequest) (*GetThreadPolicyResponse, error)
+	SetThreadPolicy(context.Context, *SetThreadPolicyRequest) (*SetThreadPolicyResponse, error)
 	mustEmbedUnimplementedCommentsServiceServer()
 }
 
@@ -133,6 +374,51 @@ func (UnimplementedCommentsServiceServer) ListByNews(context.Context, *ListByNew
 func (UnimplementedCommentsServiceServer) ListReplies(context.Context, *ListRepliesRequest) (*ListRepliesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListReplies not implemented")
 }
+func (UnimplementedCommentsServiceServer) CountsByNews(context.Context, *CountsByNewsRequest) (*CountsByNewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountsByNews not implemented")
+}
+func (UnimplementedCommentsServiceServer) ListByUser(context.Context, *ListByUserRequest) (*ListByUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListByUser not implemented")
+}
+func (UnimplementedCommentsServiceServer) ListByUsers(context.Context, *ListByUsersRequest) (*ListByUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListByUsers not implemented")
+}
+func (UnimplementedCommentsServiceServer) SearchComments(context.Context, *SearchCommentsRequest) (*SearchCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchComments not implemented")
+}
+func (UnimplementedCommentsServiceServer) ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotifications not implemented")
+}
+func (UnimplementedCommentsServiceServer) MarkRead(context.Context, *MarkReadRequest) (*MarkReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkRead not implemented")
+}
+func (UnimplementedCommentsServiceServer) UnreadCount(context.Context, *UnreadCountRequest) (*UnreadCountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnreadCount not implemented")
+}
+func (UnimplementedCommentsServiceServer) MuteThread(context.Context, *MuteThreadRequest) (*MuteThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuteThread not implemented")
+}
+func (UnimplementedCommentsServiceServer) UnmuteThread(context.Context, *MuteThreadRequest) (*MuteThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnmuteThread not implemented")
+}
+func (UnimplementedCommentsServiceServer) WatchNotifications(*WatchNotificationsRequest, grpc.ServerStreamingServer[Notification]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNotifications not implemented")
+}
+func (UnimplementedCommentsServiceServer) WatchComments(*WatchCommentsRequest, grpc.ServerStreamingServer[CommentEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchComments not implemented")
+}
+func (UnimplementedCommentsServiceServer) React(context.Context, *ReactRequest) (*ReactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method React not implemented")
+}
+func (UnimplementedCommentsServiceServer) LockThread(context.Context, *LockThreadRequest) (*LockThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockThread not implemented")
+}
+func (UnimplementedCommentsServiceServer) GetThreadPolicy(context.Context, *GetThreadPolicyRequest) (*GetThreadPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThreadPolicy not implemented")
+}
+func (UnimplementedCommentsServiceServer) SetThreadPolicy(context.Context, *SetThreadPolicyRequest) (*SetThreadPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetThreadPolicy not implemented")
+}
 func (UnimplementedCommentsServiceServer) mustEmbedUnimplementedCommentsServiceServer() {}
 func (UnimplementedCommentsServiceServer) testEmbeddedByValue()                         {}
 
@@ -244,6 +530,262 @@ func _CommentsService_ListReplies_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommentsService_CountsByNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountsByNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).CountsByNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_CountsByNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).CountsByNews(ctx, req.(*CountsByNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentsService_ListByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListByUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).ListByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_ListByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).ListByUser(ctx, req.(*ListByUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentsService_ListByUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListByUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).ListByUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_ListByUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).ListByUsers(ctx, req.(*ListByUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentsService_SearchComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).SearchComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_SearchComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).SearchComments(ctx, req.(*SearchCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentsService_ListNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).ListNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_ListNotifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).ListNotifications(ctx, req.(*ListNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentsService_MarkRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).MarkRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_MarkRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).MarkRead(ctx, req.(*MarkReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentsService_UnreadCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnreadCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).UnreadCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_UnreadCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).UnreadCount(ctx, req.(*UnreadCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentsService_MuteThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).MuteThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_MuteThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).MuteThread(ctx, req.(*MuteThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentsService_UnmuteThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).UnmuteThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_UnmuteThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).UnmuteThread(ctx, req.(*MuteThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentsService_WatchNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNotificationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CommentsServiceServer).WatchNotifications(m, &grpc.GenericServerStream[WatchNotificationsRequest, Notification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CommentsService_WatchNotificationsServer = grpc.ServerStreamingServer[Notification]
+
+func _CommentsService_WatchComments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCommentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CommentsServiceServer).WatchComments(m, &grpc.GenericServerStream[WatchCommentsRequest, CommentEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CommentsService_WatchCommentsServer = grpc.ServerStreamingServer[CommentEvent]
+
+func _CommentsService_React_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).React(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_React_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).React(ctx, req.(*ReactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentsService_LockThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).LockThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_LockThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).LockThread(ctx, req.(*LockThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentsService_GetThreadPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).GetThreadPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_GetThreadPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).GetThreadPolicy(ctx, req.(*GetThreadPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentsService_SetThreadPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetThreadPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServiceServer).SetThreadPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentsService_SetThreadPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServiceServer).SetThreadPolicy(ctx, req.(*SetThreadPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CommentsService_ServiceDesc is the grpc.ServiceDesc for CommentsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -271,7 +813,70 @@ var CommentsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListReplies",
 			Handler:    _CommentsService_ListReplies_Handler,
 		},
+		{
+			MethodName: "CountsByNews",
+			Handler:    _CommentsService_CountsByNews_Handler,
+		},
+		{
+			MethodName: "ListByUser",
+			Handler:    _CommentsService_ListByUser_Handler,
+		},
+		{
+			MethodName: "ListByUsers",
+			Handler:    _CommentsService_ListByUsers_Handler,
+		},
+		{
+			MethodName: "SearchComments",
+			Handler:    _CommentsService_SearchComments_Handler,
+		},
+		{
+			MethodName: "ListNotifications",
+			Handler:    _CommentsService_ListNotifications_Handler,
+		},
+		{
+			MethodName: "MarkRead",
+			Handler:    _CommentsService_MarkRead_Handler,
+		},
+		{
+			MethodName: "UnreadCount",
+			Handler:    _CommentsService_UnreadCount_Handler,
+		},
+		{
+			MethodName: "MuteThread",
+			Handler:    _CommentsService_MuteThread_Handler,
+		},
+		{
+			MethodName: "UnmuteThread",
+			Handler:    _CommentsService_UnmuteThread_Handler,
+		},
+		{
+			MethodName: "React",
+			Handler:    _CommentsService_React_Handler,
+		},
+		{
+			MethodName: "LockThread",
+			Handler:    _CommentsService_LockThread_Handler,
+		},
+		{
+			MethodName: "GetThreadPolicy",
+			Handler:    _CommentsService_GetThreadPolicy_Handler,
+		},
+		{
+			MethodName: "SetThreadPolicy",
+			Handler:    _CommentsService_SetThreadPolicy_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchNotifications",
+			Handler:       _CommentsService_WatchNotifications_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchComments",
+			Handler:       _CommentsService_WatchComments_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "comments.proto",
 }
@@ -19,8 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	NewsService_ListNews_FullMethodName = "/news.NewsService/ListNews"
-	NewsService_NewsByID_FullMethodName = "/news.NewsService/NewsByID"
+	NewsService_ListNews_FullMethodName             = "/news.NewsService/ListNews"
+	NewsService_NewsByID_FullMethodName             = "/news.NewsService/NewsByID"
+	NewsService_NewsByIDs_FullMethodName            = "/news.NewsService/NewsByIDs"
+	NewsService_ListPersonalizedNews_FullMethodName = "/news.NewsService/ListPersonalizedNews"
+	NewsService_MarkRead_FullMethodName             = "/news.NewsService/MarkRead"
+	NewsService_MarkAllRead_FullMethodName          = "/news.NewsService/MarkAllRead"
+	NewsService_WatchNews_FullMethodName            = "/news.NewsService/WatchNews"
 )
 
 // NewsServiceClient is the client API for NewsService service.
@@ -29,6 +34,19 @@ const (
 type NewsServiceClient interface {
 	ListNews(ctx context.Context, in *ListNewsRequest, opts ...grpc.CallOption) (*ListNewsResponse, error)
 	NewsByID(ctx context.Context, in *NewsByIDRequest, opts ...grpc.CallOption) (*NewsByIDResponse, error)
+	// Пакетное чтение одним запросом: порядок запроса сохраняется,
+	// отсутствующие id возвращаются в missing_ids, а не ошибкой.
+	NewsByIDs(ctx context.Context, in *NewsByIDsRequest, opts ...grpc.CallOption) (*NewsByIDsResponse, error)
+	// Персональная лента: предпочтения читателя (users-service), ранжирование
+	// свежесть × интерес и ограничение доли одного источника на странице.
+	ListPersonalizedNews(ctx context.Context, in *ListPersonalizedNewsRequest, opts ...grpc.CallOption) (*ListPersonalizedNewsResponse, error)
+	// История прочтений: отметить открытые новости; отметить прочитанным всё до позиции ленты.
+	MarkRead(ctx context.Context, in *MarkReadRequest, opts ...grpc.CallOption) (*MarkReadResponse, error)
+	MarkAllRead(ctx context.Context, in *MarkAllReadRequest, opts ...grpc.CallOption) (*MarkAllReadResponse, error)
+	// Живая лента: новости, впервые сохранённые ingest, в порядке вставки.
+	// С last_event_id сначала досылаются пропущенные (или событие reset_required, если их
+	// не восстановить). Медленный клиент отключается с RESOURCE_EXHAUSTED.
+	WatchNews(ctx context.Context, in *WatchNewsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NewsEvent], error)
 }
 
 type newsServiceClient struct {
@@ -59,12 +77,84 @@ func (c *newsServiceClient) NewsByID(ctx context.Context, in *NewsByIDRequest, o
 	return out, nil
 }
 
+func (c *newsServiceClient) NewsByIDs(ctx context.Context, in *NewsByIDsRequest, opts ...grpc.CallOption) (*NewsByIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NewsByIDsResponse)
+	err := c.cc.Invoke(ctx, NewsService_NewsByIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) ListPersonalizedNews(ctx context.Context, in *ListPersonalizedNewsRequest, opts ...grpc.CallOption) (*ListPersonalizedNewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPersonalizedNewsResponse)
+	err := c.cc.Invoke(ctx, NewsService_ListPersonalizedNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) MarkRead(ctx context.Context, in *MarkReadRequest, opts ...grpc.CallOption) (*MarkReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkReadResponse)
+	err := c.cc.Invoke(ctx, NewsService_MarkRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) MarkAllRead(ctx context.Context, in *MarkAllReadRequest, opts ...grpc.CallOption) (*MarkAllReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkAllReadResponse)
+	err := c.cc.Invoke(ctx, NewsService_MarkAllRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) WatchNews(ctx context.Context, in *WatchNewsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NewsEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NewsService_ServiceDesc.Streams[0], NewsService_WatchNews_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchNewsRequest, NewsEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NewsService_WatchNewsClient = grpc.ServerStreamingClient[NewsEvent]
+
 // NewsServiceServer is the server API for NewsService service.
 // All implementations must embed UnimplementedNewsServiceServer
 // for forward compatibility.
 type NewsServiceServer interface {
 	ListNews(context.Context, *ListNewsRequest) (*ListNewsResponse, error)
 	NewsByID(context.Context, *NewsByIDRequest) (*NewsByIDResponse, error)
+	// Пакетное чтение одним запросом: порядок запроса сохраняется,
+	// отсутствующие id возвращаются в missing_ids, а не ошибкой.
+	NewsByIDs(context.Context, *NewsByIDsRequest) (*NewsByIDsResponse, error)
+	// Персональная лента: предпочтения читателя (users-service), ранжирование
+	// свежесть × интерес и ограничение доли одного источника на странице.
+	ListPersonalizedNews(context.Context, *ListPersonalizedNewsRequest) (*ListPersonalizedNewsResponse, error)
+	// История прочтений: отметить открытые новости; отметить прочитанным всё до позиции ленты.
+	MarkRead(context.Context, *MarkReadRequest) (*MarkReadResponse, error)
+	MarkAllRead(context.Context, *MarkAllReadRequest) (*MarkAllReadResponse, error)
+	// Живая лента: новости, впервые сохранённые ingest, в порядке вставки.
+	// С last_event_id сначала досылаются пропущенные (или событие reset_required, если их
+	// не восстановить). Медленный клиент отключается с RESOURCE_EXHAUSTED.
+	WatchNews(*WatchNewsRequest, grpc.ServerStreamingServer[NewsEvent]) error
 	mustEmbedUnimplementedNewsServiceServer()
 }
 
@@ -81,6 +171,21 @@ func (UnimplementedNewsServiceServer) ListNews(context.Context, *ListNewsRequest
 func (UnimplementedNewsServiceServer) NewsByID(context.Context, *NewsByIDRequest) (*NewsByIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewsByID not implemented")
 }
+func (UnimplementedNewsServiceServer) NewsByIDs(context.Context, *NewsByIDsRequest) (*NewsByIDsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewsByIDs not implemented")
+}
+func (UnimplementedNewsServiceServer) ListPersonalizedNews(context.Context, *ListPersonalizedNewsRequest) (*ListPersonalizedNewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPersonalizedNews not implemented")
+}
+func (UnimplementedNewsServiceServer) MarkRead(context.Context, *MarkReadRequest) (*MarkReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkRead not implemented")
+}
+func (UnimplementedNewsServiceServer) MarkAllRead(context.Context, *MarkAllReadRequest) (*MarkAllReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkAllRead not implemented")
+}
+func (UnimplementedNewsServiceServer) WatchNews(*WatchNewsRequest, grpc.ServerStreamingServer[NewsEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNews not implemented")
+}
 func (UnimplementedNewsServiceServer) mustEmbedUnimplementedNewsServiceServer() {}
 func (UnimplementedNewsServiceServer) testEmbeddedByValue()                     {}
 
@@ -138,6 +243,89 @@ func _NewsService_NewsByID_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NewsService_NewsByIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewsByIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).NewsByIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_NewsByIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).NewsByIDs(ctx, req.(*NewsByIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_ListPersonalizedNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPersonalizedNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).ListPersonalizedNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_ListPersonalizedNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).ListPersonalizedNews(ctx, req.(*ListPersonalizedNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_MarkRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).MarkRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_MarkRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).MarkRead(ctx, req.(*MarkReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_MarkAllRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkAllReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).MarkAllRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_MarkAllRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).MarkAllRead(ctx, req.(*MarkAllReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_WatchNews_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNewsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NewsServiceServer).WatchNews(m, &grpc.GenericServerStream[WatchNewsRequest, NewsEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NewsService_WatchNewsServer = grpc.ServerStreamingServer[NewsEvent]
+
 // NewsService_ServiceDesc is the grpc.ServiceDesc for NewsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,7 +341,29 @@ var NewsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "NewsByID",
 			Handler:    _NewsService_NewsByID_Handler,
 		},
+		{
+			MethodName: "NewsByIDs",
+			Handler:    _NewsService_NewsByIDs_Handler,
+		},
+		{
+			MethodName: "ListPersonalizedNews",
+			Handler:    _NewsService_ListPersonalizedNews_Handler,
+		},
+		{
+			MethodName: "MarkRead",
+			Handler:    _NewsService_MarkRead_Handler,
+		},
+		{
+			MethodName: "MarkAllRead",
+			Handler:    _NewsService_MarkAllRead_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchNews",
+			Handler:       _NewsService_WatchNews_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "news.proto",
 }
@@ -0,0 +1,41 @@
+// auth — проверка access-токенов в api-gateway.
+//
+// Verifier проверяет токен одним из способов:
+//   - Remote — вызов AuthService.ValidateToken с кэшем результатов в памяти
+//     (ключ — SHA-256 токена, запись живёт не дольше exp токена);
+//   - Local — проверка подписи HS256 и claim’ов (iss/aud/exp) в самом шлюзе
+//     общим с auth-service секретом, без сетевых вызовов.
+//
+// ErrInvalidToken — токен отклонён; прочие ошибки — проверить токен не удалось
+// (auth-service недоступен), решение об ответе принимает вызывающий.
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	authv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/auth"
+	"google.golang.org/grpc"
+)
+
+// ErrInvalidToken — токен невалиден, просрочен или подписан не тем ключом.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims — проверенные данные access-токена.
+type Claims struct {
+	UserID string
+	Email  string
+	// ExpiresAt — срок действия токена; нулевое значение — неизвестен.
+	ExpiresAt time.Time
+}
+
+// Verifier — проверка access-токена.
+type Verifier interface {
+	Verify(ctx context.Context, token string) (Claims, error)
+}
+
+// TokenValidator — RPC проверки токена (реализуется authv1.AuthServiceClient).
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, in *authv1.ValidateTokenRequest, opts ...grpc.CallOption) (*authv1.ValidateTokenResponse, error)
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	authv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// Тесты проверки токенов:
+//   - Local: подпись, алгоритм, iss/aud/exp, формат uid;
+//   - Remote: кэш валидных/отклонённых токенов, срок записи, вытеснение, ошибки RPC.
+
+const testSecret = "test-secret"
+
+func signToken(t *testing.T, method jwt.SigningMethod, key any, c accessClaims) string {
+	t.Helper()
+
+	s, err := jwt.NewWithClaims(method, c).SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func validClaims(uid string, exp time.Time) accessClaims {
+	return accessClaims{
+		UserID: uid,
+		Email:  "u@example.com",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "auth-service",
+			Subject:   uid,
+			Audience:  jwt.ClaimStrings{"api-gateway"},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(exp),
+		},
+	}
+}
+
+func TestNewLocal_RequiresSecret(t *testing.T) {
+	_, err := NewLocal("", "auth-service", nil)
+	require.Error(t, err)
+}
+
+func TestLocal_Verify(t *testing.T) {
+	l, err := NewLocal(testSecret, "auth-service", []string{"api-gateway"})
+	require.NoError(t, err)
+
+	uid := uuid.NewString()
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	got, err := l.Verify(context.Background(), signToken(t, jwt.SigningMethodHS256, []byte(testSecret), validClaims(uid, exp)))
+	require.NoError(t, err)
+	require.Equal(t, uid, got.UserID)
+	require.Equal(t, "u@example.com", got.Email)
+	require.True(t, exp.Equal(got.ExpiresAt))
+
+	wrongAud := validClaims(uid, exp)
+	wrongAud.Audience = jwt.ClaimStrings{"other"}
+	wrongIss := validClaims(uid, exp)
+	wrongIss.Issuer = "other"
+	noExp := validClaims(uid, exp)
+	noExp.ExpiresAt = nil
+
+	cases := map[string]string{
+		"expired":      signToken(t, jwt.SigningMethodHS256, []byte(testSecret), validClaims(uid, time.Now().Add(-time.Minute))),
+		"wrong_secret": signToken(t, jwt.SigningMethodHS256, []byte("other"), validClaims(uid, exp)),
+		"wrong_alg":    signToken(t, jwt.SigningMethodHS384, []byte(testSecret), validClaims(uid, exp)),
+		"none_alg":     signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, validClaims(uid, exp)),
+		"wrong_aud":    signToken(t, jwt.SigningMethodHS256, []byte(testSecret), wrongAud),
+		"wrong_iss":    signToken(t, jwt.SigningMethodHS256, []byte(testSecret), wrongIss),
+		"no_exp":       signToken(t, jwt.SigningMethodHS256, []byte(testSecret), noExp),
+		"bad_uid":      signToken(t, jwt.SigningMethodHS256, []byte(testSecret), validClaims("not-a-uuid", exp)),
+		"garbage":      "not.a.jwt",
+	}
+
+	for name, token := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := l.Verify(context.Background(), token)
+			require.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+// fakeValidator — TokenValidator с фиксированными ответами по токену.
+type fakeValidator struct {
+	users map[string]string // token -> user_id
+	err   error
+	calls int
+}
+
+func (f *fakeValidator) ValidateToken(_ context.Context, in *authv1.ValidateTokenRequest, _ ...grpc.CallOption) (*authv1.ValidateTokenResponse, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	uid, ok := f.users[in.GetAccessToken()]
+	return &authv1.ValidateTokenResponse{Valid: ok, UserId: uid}, nil
+}
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time      { return c.t }
+func (c *fakeClock) add(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestRemote_CachesResults(t *testing.T) {
+	v := &fakeValidator{users: map[string]string{"t-user": "u-1"}}
+	clock := &fakeClock{t: time.Now()}
+	r := NewRemote(v, time.Minute, 10)
+	r.now = clock.now
+
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		got, err := r.Verify(ctx, "t-user")
+		require.NoError(t, err)
+		require.Equal(t, "u-1", got.UserID)
+	}
+	require.Equal(t, 1, v.calls)
+
+	// Отклонённый токен тоже кэшируется.
+	for i := 0; i < 2; i++ {
+		_, err := r.Verify(ctx, "bogus")
+		require.ErrorIs(t, err, ErrInvalidToken)
+	}
+	require.Equal(t, 2, v.calls)
+
+	// После ttl — повторная проверка.
+	clock.add(time.Minute)
+	_, err := r.Verify(ctx, "t-user")
+	require.NoError(t, err)
+	require.Equal(t, 3, v.calls)
+}
+
+func TestRemote_ErrorsAreNotCached(t *testing.T) {
+	v := &fakeValidator{users: map[string]string{"t-user": "u-1"}, err: errors.New("unavailable")}
+	r := NewRemote(v, time.Minute, 10)
+
+	_, err := r.Verify(context.Background(), "t-user")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrInvalidToken)
+
+	v.err = nil
+	got, err := r.Verify(context.Background(), "t-user")
+	require.NoError(t, err)
+	require.Equal(t, "u-1", got.UserID)
+	require.Equal(t, 2, v.calls)
+}
+
+func TestRemote_EntryLivesUntilTokenExpiry(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	exp := clock.t.Add(10 * time.Second)
+	token := signToken(t, jwt.SigningMethodHS256, []byte(testSecret), validClaims("u-1", exp))
+
+	v := &fakeValidator{users: map[string]string{token: "u-1"}}
+	r := NewRemote(v, time.Hour, 10)
+	r.now = clock.now
+
+	got, err := r.Verify(context.Background(), token)
+	require.NoError(t, err)
+	require.True(t, exp.Truncate(time.Second).Equal(got.ExpiresAt))
+
+	clock.add(5 * time.Second)
+	_, _ = r.Verify(context.Background(), token)
+	require.Equal(t, 1, v.calls)
+
+	// Токен истёк — запись не отдаётся, хоть ttl и не вышел.
+	clock.add(10 * time.Second)
+	_, _ = r.Verify(context.Background(), token)
+	require.Equal(t, 2, v.calls)
+}
+
+func TestRemote_EvictsWhenFull(t *testing.T) {
+	v := &fakeValidator{users: map[string]string{"a": "u-a", "b": "u-b", "c": "u-c"}}
+	r := NewRemote(v, time.Minute, 2)
+
+	for _, token := range []string{"a", "b", "c"} {
+		_, err := r.Verify(context.Background(), token)
+		require.NoError(t, err)
+	}
+	require.Len(t, r.entries, 2)
+
+	// Без кэша каждый вызов идёт в auth-service.
+	v.calls = 0
+	nc := NewRemote(v, 0, 0)
+	_, _ = nc.Verify(context.Background(), "a")
+	_, _ = nc.Verify(context.Background(), "a")
+	require.Equal(t, 2, v.calls)
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// leeway — допуск расхождения часов при проверке exp/iat (как в auth-service).
+const leeway = 5 * time.Second
+
+// accessClaims — claim’ы access JWT, который выпускает auth-service.
+type accessClaims struct {
+	UserID string `json:"uid"`
+	Email  string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// Local проверяет access JWT в шлюзе: подпись HS256, issuer, audience, срок действия.
+// Отзыв access-токенов auth-service не поддерживает, поэтому результат совпадает
+// с ValidateToken при том же секрете.
+type Local struct {
+	secret   []byte
+	issuer   string
+	audience []string
+}
+
+// NewLocal создаёт Local. Пустой secret — ошибка: без него проверить подпись нельзя.
+func NewLocal(secret, issuer string, audience []string) (*Local, error) {
+	const op = "auth/NewLocal"
+
+	if secret == "" {
+		return nil, fmt.Errorf("%s: empty jwt secret", op)
+	}
+
+	return &Local{secret: []byte(secret), issuer: issuer, audience: audience}, nil
+}
+
+// Verify реализует Verifier. Ошибка всегда ErrInvalidToken.
+func (l *Local) Verify(_ context.Context, token string) (Claims, error) {
+	const op = "auth/Local.Verify"
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithLeeway(leeway),
+		jwt.WithExpirationRequired(),
+	}
+	if l.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(l.issuer))
+	}
+	if len(l.audience) > 0 {
+		opts = append(opts, jwt.WithAudience(l.audience...))
+	}
+
+	var claims accessClaims
+	parsed, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return l.secret, nil
+	}, opts...)
+	if err != nil || !parsed.Valid {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return Claims{}, fmt.Errorf("%s: token expired: %w", op, ErrInvalidToken)
+		}
+
+		return Claims{}, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+	}
+
+	if _, err := uuid.Parse(claims.UserID); err != nil {
+		return Claims{}, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+	}
+
+	out := Claims{UserID: claims.UserID, Email: claims.Email}
+	if claims.ExpiresAt != nil {
+		out.ExpiresAt = claims.ExpiresAt.Time
+	}
+
+	return out, nil
+}
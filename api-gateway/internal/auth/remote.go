@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	authv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/auth"
+)
+
+// Remote проверяет токены через AuthService.ValidateToken и кэширует ответы.
+//
+// Кэшируются и валидные, и отклонённые токены (access JWT неизменяем и не отзывается):
+// запись валидного токена живёт min(ttl, exp), отклонённого — ttl. Ошибки RPC
+// не кэшируются. При заполнении кэша сначала удаляются просроченные записи,
+// затем — произвольные.
+type Remote struct {
+	v    TokenValidator
+	ttl  time.Duration
+	size int
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]remoteEntry
+}
+
+type remoteEntry struct {
+	claims  Claims
+	valid   bool
+	expires time.Time
+}
+
+// NewRemote создаёт Remote. ttl <= 0 или size <= 0 — без кэша.
+func NewRemote(v TokenValidator, ttl time.Duration, size int) *Remote {
+	return &Remote{
+		v:       v,
+		ttl:     ttl,
+		size:    size,
+		now:     time.Now,
+		entries: make(map[[sha256.Size]byte]remoteEntry),
+	}
+}
+
+// Verify реализует Verifier.
+func (r *Remote) Verify(ctx context.Context, token string) (Claims, error) {
+	const op = "auth/Remote.Verify"
+
+	key := sha256.Sum256([]byte(token))
+	if e, ok := r.cached(key); ok {
+		if !e.valid {
+			return Claims{}, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+		}
+
+		return e.claims, nil
+	}
+
+	resp, err := r.v.ValidateToken(ctx, &authv1.ValidateTokenRequest{AccessToken: token})
+	if err != nil {
+		return Claims{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !resp.GetValid() || resp.GetUserId() == "" {
+		r.store(key, remoteEntry{})
+		return Claims{}, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+	}
+
+	claims := Claims{UserID: resp.GetUserId(), Email: resp.GetEmail(), ExpiresAt: expiresAt(token)}
+	r.store(key, remoteEntry{claims: claims, valid: true})
+
+	return claims, nil
+}
+
+// cached возвращает непросроченную запись кэша.
+func (r *Remote) cached(key [sha256.Size]byte) (remoteEntry, bool) {
+	if r.ttl <= 0 || r.size <= 0 {
+		return remoteEntry{}, false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[key]
+	if !ok {
+		return remoteEntry{}, false
+	}
+
+	if !r.now().Before(e.expires) {
+		delete(r.entries, key)
+		return remoteEntry{}, false
+	}
+
+	return e, true
+}
+
+// store кладёт запись в кэш со сроком min(ttl, exp токена).
+func (r *Remote) store(key [sha256.Size]byte, e remoteEntry) {
+	if r.ttl <= 0 || r.size <= 0 {
+		return
+	}
+
+	now := r.now()
+	e.expires = now.Add(r.ttl)
+	if exp := e.claims.ExpiresAt; !exp.IsZero() && exp.Before(e.expires) {
+		e.expires = exp
+	}
+
+	if !now.Before(e.expires) {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entries[key]; !ok && len(r.entries) >= r.size {
+		r.evict(now)
+	}
+
+	r.entries[key] = e
+}
+
+// evict освобождает место: просроченные записи, а если их нет — одна произвольная.
+func (r *Remote) evict(now time.Time) {
+	for k, e := range r.entries {
+		if !now.Before(e.expires) {
+			delete(r.entries, k)
+		}
+	}
+
+	if len(r.entries) < r.size {
+		return
+	}
+
+	for k := range r.entries {
+		delete(r.entries, k)
+		return
+	}
+}
+
+// expiresAt — exp из payload токена без проверки подписи (её сделал auth-service);
+// нужен только чтобы не держать в кэше истёкший токен. Без exp — нулевое время.
+func expiresAt(token string) time.Time {
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil || claims.ExpiresAt == nil {
+		return time.Time{}
+	}
+
+	return claims.ExpiresAt.Time
+}
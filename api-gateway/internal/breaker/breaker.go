@@ -0,0 +1,228 @@
+// breaker — предохранитель (circuit breaker) исходящих вызовов к апстриму.
+//
+// Состояния:
+//   - closed — вызовы идут; FailureThreshold отказов подряд размыкают цепь;
+//   - open — вызовы отклоняются сразу (ErrOpen), пока не пройдёт OpenTimeout;
+//   - half-open — пропускается до HalfOpenRequests пробных вызовов: успех
+//     замыкает цепь, отказ снова размыкает её на OpenTimeout.
+//
+// Что считать отказом, решает вызывающий (Outcome): для gRPC — недоступность
+// и таймауты апстрима, но не бизнес-ошибки вроде NotFound.
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Значения Options по умолчанию (config.BreakerConfig).
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 10 * time.Second
+	defaultHalfOpenRequests = 1
+)
+
+// ErrOpen — вызов отклонён разомкнутым предохранителем.
+var ErrOpen = errors.New("breaker: circuit open")
+
+// State — состояние предохранителя; значение — метрика upstream_breaker_state.
+type State int
+
+const (
+	Closed State = iota
+	HalfOpen
+	Open
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case HalfOpen:
+		return "half_open"
+	case Open:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Outcome — итог вызова для предохранителя.
+type Outcome int
+
+const (
+	// Success — апстрим ответил (в т.ч. бизнес-ошибкой).
+	Success Outcome = iota
+	// Failure — апстрим недоступен или не ответил в срок.
+	Failure
+	// Ignored — вызов прерван клиентом: о здоровье апстрима ничего не говорит.
+	Ignored
+)
+
+// Options — параметры Breaker; нулевые поля — значения по умолчанию.
+type Options struct {
+	// FailureThreshold — отказов подряд до размыкания.
+	FailureThreshold int
+	// OpenTimeout — сколько цепь разомкнута до пробных вызовов.
+	OpenTimeout time.Duration
+	// HalfOpenRequests — одновременных пробных вызовов в half-open.
+	HalfOpenRequests int
+	// Now — источник времени (тесты); nil — time.Now.
+	Now func() time.Time
+}
+
+var (
+	breakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "api_gateway",
+		Subsystem: "upstream",
+		Name:      "breaker_state",
+		Help:      "Состояние предохранителя апстрима: 0 — closed, 1 — half_open, 2 — open.",
+	}, []string{"upstream"})
+
+	breakerTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "api_gateway",
+		Subsystem: "upstream",
+		Name:      "breaker_transitions_total",
+		Help:      "Переходы предохранителя апстрима по новому состоянию.",
+	}, []string{"upstream", "state"})
+
+	breakerRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "api_gateway",
+		Subsystem: "upstream",
+		Name:      "breaker_rejected_total",
+		Help:      "Вызовы, отклонённые разомкнутым предохранителем апстрима.",
+	}, []string{"upstream"})
+)
+
+// Breaker — предохранитель одного апстрима; безопасен для конкурентного использования.
+type Breaker struct {
+	name string
+	opts Options
+
+	mu       sync.Mutex
+	state    State
+	failures int       // отказов подряд в closed
+	openedAt time.Time // момент размыкания
+	probes   int       // пробных вызовов в полёте (half-open)
+}
+
+// New создаёт замкнутый предохранитель апстрима name (метка upstream в метриках).
+func New(name string, opts Options) *Breaker {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = defaultFailureThreshold
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = defaultOpenTimeout
+	}
+	if opts.HalfOpenRequests <= 0 {
+		opts.HalfOpenRequests = defaultHalfOpenRequests
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	breakerState.WithLabelValues(name).Set(float64(Closed))
+
+	return &Breaker{name: name, opts: opts}
+}
+
+// Name — имя апстрима.
+func (b *Breaker) Name() string { return b.name }
+
+// State — текущее состояние (open по истечении OpenTimeout считается half-open).
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == Open && !b.opts.Now().Before(b.openedAt.Add(b.opts.OpenTimeout)) {
+		return HalfOpen
+	}
+
+	return b.state
+}
+
+// Allow разрешает вызов или возвращает ErrOpen. Разрешённый вызов обязан
+// сообщить итог через done ровно один раз.
+func (b *Breaker) Allow() (done func(Outcome), err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Open:
+		if b.opts.Now().Before(b.openedAt.Add(b.opts.OpenTimeout)) {
+			breakerRejected.WithLabelValues(b.name).Inc()
+			return nil, ErrOpen
+		}
+		b.setState(HalfOpen)
+		fallthrough
+	case HalfOpen:
+		if b.probes >= b.opts.HalfOpenRequests {
+			breakerRejected.WithLabelValues(b.name).Inc()
+			return nil, ErrOpen
+		}
+		b.probes++
+		return b.doneProbe, nil
+	default:
+		return b.doneClosed, nil
+	}
+}
+
+func (b *Breaker) doneClosed(o Outcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Цепь могла разомкнуться, пока вызов был в полёте: его итог уже не важен.
+	if b.state != Closed {
+		return
+	}
+
+	switch o {
+	case Success:
+		b.failures = 0
+	case Failure:
+		b.failures++
+		if b.failures >= b.opts.FailureThreshold {
+			b.open()
+		}
+	}
+}
+
+func (b *Breaker) doneProbe(o Outcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probes--
+	if b.state != HalfOpen {
+		return
+	}
+
+	switch o {
+	case Success:
+		b.failures = 0
+		b.setState(Closed)
+	case Failure:
+		b.open()
+	}
+}
+
+// open размыкает цепь (под b.mu).
+func (b *Breaker) open() {
+	b.failures = 0
+	b.openedAt = b.opts.Now()
+	b.setState(Open)
+}
+
+// setState меняет состояние и метрики (под b.mu).
+func (b *Breaker) setState(s State) {
+	if b.state == s {
+		return
+	}
+
+	b.state = s
+	breakerState.WithLabelValues(b.name).Set(float64(s))
+	breakerTransitions.WithLabelValues(b.name, s.String()).Inc()
+}
@@ -0,0 +1,78 @@
+package breaker
+
+// Тесты предохранителя:
+//   - FailureThreshold отказов подряд размыкают цепь, успех обнуляет счётчик;
+//   - open отклоняет вызовы до OpenTimeout, затем — ограниченные пробы;
+//   - успешная проба замыкает цепь, неудачная — снова размыкает;
+//   - прерванные клиентом вызовы (Ignored) не влияют на состояние.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func call(t *testing.T, b *Breaker, o Outcome) {
+	t.Helper()
+
+	done, err := b.Allow()
+	require.NoError(t, err)
+	done(o)
+}
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	clk := &clock{t: time.Unix(1000, 0)}
+	b := New("test-open", Options{FailureThreshold: 3, OpenTimeout: time.Second, Now: clk.now})
+
+	call(t, b, Failure)
+	call(t, b, Failure)
+	call(t, b, Success) // обнуляет серию
+	call(t, b, Failure)
+	call(t, b, Ignored)
+	call(t, b, Failure)
+	require.Equal(t, Closed, b.State())
+
+	call(t, b, Failure)
+	require.Equal(t, Open, b.State())
+
+	_, err := b.Allow()
+	require.ErrorIs(t, err, ErrOpen)
+
+	clk.t = clk.t.Add(time.Second)
+	require.Equal(t, HalfOpen, b.State())
+}
+
+func TestBreaker_HalfOpenProbes(t *testing.T) {
+	clk := &clock{t: time.Unix(1000, 0)}
+	b := New("test-probe", Options{FailureThreshold: 1, OpenTimeout: time.Second, HalfOpenRequests: 1, Now: clk.now})
+
+	call(t, b, Failure)
+	clk.t = clk.t.Add(time.Second)
+
+	// Одна проба в полёте — остальные отклоняются.
+	probe, err := b.Allow()
+	require.NoError(t, err)
+	_, err = b.Allow()
+	require.ErrorIs(t, err, ErrOpen)
+
+	// Неудачная проба размыкает цепь заново.
+	probe(Failure)
+	require.Equal(t, Open, b.State())
+	_, err = b.Allow()
+	require.ErrorIs(t, err, ErrOpen)
+
+	// Прерванная проба освобождает слот, не меняя состояния.
+	clk.t = clk.t.Add(time.Second)
+	call(t, b, Ignored)
+	require.Equal(t, HalfOpen, b.State())
+
+	// Успешная проба замыкает цепь.
+	call(t, b, Success)
+	require.Equal(t, Closed, b.State())
+	call(t, b, Success)
+}
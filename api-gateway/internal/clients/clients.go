@@ -9,11 +9,12 @@ import (
 	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
 	newsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/news"
 	usersv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/users"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/breaker"
 	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/clients/interceptors"
 	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/pkg/tlsconfig"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Clients агрегирует все gRPC-клиенты апстрим-сервисов.
@@ -23,75 +24,130 @@ type Clients struct {
 	Comments commentsv1.CommentsServiceClient
 	Users    usersv1.UsersServiceClient
 
-	conns []*grpc.ClientConn
+	conns    []*grpc.ClientConn
+	breakers []*breaker.Breaker
 }
 
 // New создаёт gRPC-коннекты и клиенты для всех апстримов.
+//
+// Unary-вызовы каждого апстрима проходят его предохранитель (cfg.Resilience.Breaker),
+// хеджирование читающих методов (cfg.Resilience.Hedging) и ретраи идемпотентных
+// методов из service config (cfg.Resilience.Retry); таблица методов — resilience.go.
 func New(ctx context.Context, cfg config.Config, log *slog.Logger) (*Clients, error) {
 	const op = "internal/clients/New"
 
 	// Параметры исходящих вызовов.
 	timeout := cfg.Timeouts.Service
 	userAgent := "api-gateway"
+	res := cfg.Resilience
 
-	// Цепочка клиентских интерсепторов: metadata -> timeout -> logging.
-	chain := grpc.WithChainUnaryInterceptor(
-		interceptors.ClientWithMetadata(userAgent),
-		interceptors.ClientWithTimeout(timeout),
-		interceptors.ClientUnaryLoggingInterceptor(log),
+	// TLS/mTLS к апстримам; сертификаты перечитываются с диска, пока жив ctx.
+	grpcTLS, err := tlsconfig.New(cfg.GRPC.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Потоки (Watch*) живут, пока открыт клиентский запрос: без таймаута.
+	streamChain := grpc.WithChainStreamInterceptor(
+		interceptors.ClientStreamWithMetadata(userAgent),
+	)
+
+	var (
+		conns    []*grpc.ClientConn
+		breakers []*breaker.Breaker
 	)
+	closeAll := func() {
+		for _, c := range conns {
+			_ = c.Close()
+		}
+	}
 
 	// Фабрика коннектов.
-	dial := func(addr string) (*grpc.ClientConn, error) {
+	dial := func(u upstream, addr string) (*grpc.ClientConn, error) {
 		if addr == "" {
-			return nil, fmt.Errorf("%s: empty upstream addr", op)
+			return nil, fmt.Errorf("%s: %s: empty upstream addr", op, u.name)
 		}
 
-		return grpc.NewClient(
-			addr,
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-			chain,
-		)
-	}
+		// Цепочка клиентских интерсепторов: metadata -> timeout -> breaker -> hedging -> logging.
+		// Таймаут покрывает все попытки; предохранитель видит итог логического вызова,
+		// logging — каждую копию хеджирования.
+		unary := []grpc.UnaryClientInterceptor{
+			interceptors.ClientWithMetadata(userAgent),
+			interceptors.ClientWithTimeout(timeout),
+		}
+		if res.Breaker.Enabled {
+			b := breaker.New(u.name, breaker.Options{
+				FailureThreshold: res.Breaker.FailureThreshold,
+				OpenTimeout:      res.Breaker.OpenTimeout,
+				HalfOpenRequests: res.Breaker.HalfOpenRequests,
+			})
+			breakers = append(breakers, b)
+			unary = append(unary, interceptors.ClientWithBreaker(b))
+		}
+		if res.Hedging.Enabled {
+			unary = append(unary, interceptors.ClientWithHedging(interceptors.HedgingOptions{
+				Methods:     u.hedgeable(),
+				Delay:       res.Hedging.Delay,
+				MaxAttempts: res.Hedging.MaxAttempts,
+			}))
+		}
+		unary = append(unary, interceptors.ClientUnaryLoggingInterceptor(log))
+
+		opts := []grpc.DialOption{
+			grpcTLS.DialOption(),
+			grpc.WithChainUnaryInterceptor(unary...),
+			streamChain,
+		}
+
+		sc, err := serviceConfig(u, res.Retry)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", op, u.name, err)
+		}
+		if sc != "" {
+			opts = append(opts, grpc.WithDefaultServiceConfig(sc))
+		}
 
-	// Адреса апстримов из конфигурации.
-	authAddr := cfg.GRPC.AuthAddr
-	newsAddr := cfg.GRPC.NewsAddr
-	commentsAddr := cfg.GRPC.CommentsAddr
-	usersAddr := cfg.GRPC.UsersAddr
+		conn, err := grpc.NewClient(addr, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s dial: %w", op, u.name, err)
+		}
+		conns = append(conns, conn)
 
-	authConn, err := dial(authAddr)
+		return conn, nil
+	}
+
+	authConn, err := dial(authUpstream, cfg.GRPC.AuthAddr)
 	if err != nil {
-		return nil, fmt.Errorf("%s: auth dial: %w", op, err)
+		return nil, err
 	}
 
-	newsConn, err := dial(newsAddr)
+	newsConn, err := dial(newsUpstream, cfg.GRPC.NewsAddr)
 	if err != nil {
-		_ = authConn.Close()
-		return nil, fmt.Errorf("%s: news dial: %w", op, err)
+		closeAll()
+		return nil, err
 	}
 
-	commentsConn, err := dial(commentsAddr)
+	commentsConn, err := dial(commentsUpstream, cfg.GRPC.CommentsAddr)
 	if err != nil {
-		_ = authConn.Close()
-		_ = newsConn.Close()
-		return nil, fmt.Errorf("%s: comments dial: %w", op, err)
+		closeAll()
+		return nil, err
 	}
 
-	usersConn, err := dial(usersAddr)
+	usersConn, err := dial(usersUpstream, cfg.GRPC.UsersAddr)
 	if err != nil {
-		_ = authConn.Close()
-		_ = newsConn.Close()
-		_ = commentsConn.Close()
-		return nil, fmt.Errorf("%s: users dial: %w", op, err)
+		closeAll()
+		return nil, err
 	}
 
+	go grpcTLS.Run(ctx, log)
+
 	return &Clients{
 		Auth:     authv1.NewAuthServiceClient(authConn),
 		News:     newsv1.NewNewsServiceClient(newsConn),
 		Comments: commentsv1.NewCommentsServiceClient(commentsConn),
 		Users:    usersv1.NewUsersServiceClient(usersConn),
-		conns:    []*grpc.ClientConn{authConn, newsConn, commentsConn, usersConn},
+		conns:    conns,
+		breakers: breakers,
 	}, nil
 }
 
@@ -0,0 +1,48 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/breaker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ClientWithBreaker возвращает unary-клиентский интерсептор предохранителя апстрима.
+//
+// Контракт:
+//  1. разомкнутая цепь — вызов не уходит, ответ codes.Unavailable (HTTP 503);
+//  2. Unavailable и DeadlineExceeded апстрима — отказ, остальные коды — апстрим жив;
+//  3. вызов, прерванный клиентом (ctx отменён), на состояние не влияет.
+//
+// Стоит над ретраями и хеджированием: предохранитель видит итог логического вызова.
+func ClientWithBreaker(b *breaker.Breaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		done, err := b.Allow()
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "%s: circuit open", b.Name())
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		done(breakerOutcome(ctx, err))
+
+		return err
+	}
+}
+
+func breakerOutcome(ctx context.Context, err error) breaker.Outcome {
+	switch status.Code(err) {
+	case codes.OK:
+		return breaker.Success
+	case codes.Unavailable, codes.DeadlineExceeded:
+		if ctx.Err() == context.Canceled {
+			return breaker.Ignored
+		}
+		return breaker.Failure
+	case codes.Canceled:
+		return breaker.Ignored
+	default:
+		return breaker.Success
+	}
+}
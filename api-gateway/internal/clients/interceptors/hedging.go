@@ -0,0 +1,104 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// HedgingOptions — параметры ClientWithHedging.
+type HedgingOptions struct {
+	// Methods — полные имена хеджируемых методов ("/news.NewsService/ListNews");
+	// только чтение: лишняя копия не должна ничего менять.
+	Methods map[string]bool
+	// Delay — пауза перед следующей копией, если ответа ещё нет.
+	Delay time.Duration
+	// MaxAttempts — копий вызова всего, включая первую; < 2 — без хеджирования.
+	MaxAttempts int
+}
+
+var hedgedAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "api_gateway",
+	Subsystem: "upstream",
+	Name:      "hedged_attempts_total",
+	Help:      "Дополнительные (хеджированные) копии unary-вызовов по методу.",
+}, []string{"method"})
+
+// ClientWithHedging возвращает unary-клиентский интерсептор хеджирования
+// (grpc-go не исполняет hedgingPolicy из service config).
+//
+// Контракт:
+//  1. метод не из Methods — обычный вызов;
+//  2. нет ответа за Delay — уходит следующая копия, до MaxAttempts;
+//     Unavailable копии — следующая уходит сразу;
+//  3. первый ответ, кроме Unavailable, — итог вызова: он копируется в reply,
+//     остальные копии отменяются; все копии Unavailable — последняя ошибка.
+func ClientWithHedging(o HedgingOptions) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		dst, ok := reply.(proto.Message)
+		if !o.Methods[method] || o.MaxAttempts < 2 || !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			reply proto.Message
+			err   error
+		}
+		results := make(chan result, o.MaxAttempts)
+
+		launched := 0
+		launch := func() {
+			r := proto.Clone(dst)
+			proto.Reset(r)
+			launched++
+			if launched > 1 {
+				hedgedAttempts.WithLabelValues(method).Inc()
+			}
+
+			go func() {
+				results <- result{reply: r, err: invoker(ctx, method, req, r, cc, opts...)}
+			}()
+		}
+
+		launch()
+		timer := time.NewTimer(o.Delay)
+		defer timer.Stop()
+
+		var lastErr error
+		for received := 0; received < launched; {
+			select {
+			case <-timer.C:
+				if launched < o.MaxAttempts {
+					launch()
+					timer.Reset(o.Delay)
+				}
+			case res := <-results:
+				received++
+				if status.Code(res.err) != codes.Unavailable {
+					if res.err == nil {
+						proto.Reset(dst)
+						proto.Merge(dst, res.reply)
+					}
+					return res.err
+				}
+
+				lastErr = res.err
+				if launched < o.MaxAttempts {
+					launch()
+					timer.Reset(o.Delay)
+				}
+			}
+		}
+
+		return lastErr
+	}
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"log/slog"
 	"net"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	newsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/news"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/breaker"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
 	"github.com/pribylovaa/go-news-aggregator/pkg/log"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -61,6 +66,7 @@ func TestClientMetadata_AppendsHeaders(t *testing.T) {
 
 	ctx := context.WithValue(context.Background(), CtxRequestID, rid)
 	ctx = context.WithValue(ctx, CtxAuthToken, tok)
+	ctx = context.WithValue(ctx, CtxCaller, identity.Caller{UserID: "u-1", Roles: []string{identity.RoleAdmin}})
 
 	mdOut := metadata.MD{}
 	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
@@ -76,6 +82,28 @@ func TestClientMetadata_AppendsHeaders(t *testing.T) {
 	require.Equal(t, []string{rid}, mdOut.Get("x-request-id"))
 	require.Equal(t, []string{"Bearer " + tok}, mdOut.Get("authorization"))
 	require.Equal(t, []string{ua}, mdOut.Get("user-agent"))
+	require.Equal(t, []string{"u-1"}, mdOut.Get(identity.MDUserID))
+	require.Equal(t, []string{"admin"}, mdOut.Get(identity.MDUserRoles))
+}
+
+func TestClientStreamMetadata_AppendsHeaders(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.WithValue(context.Background(), CtxRequestID, "rid-1")
+
+	var mdOut metadata.MD
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		mdOut, _ = metadata.FromOutgoingContext(ctx)
+		return nil, nil
+	}
+
+	inter := ClientStreamWithMetadata("api-gateway")
+	_, err := inter(ctx, &grpc.StreamDesc{ServerStreams: true}, nil, "/news.NewsService/WatchNews", streamer)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"rid-1"}, mdOut.Get("x-request-id"))
+	require.Equal(t, []string{"api-gateway"}, mdOut.Get("user-agent"))
+	require.Empty(t, mdOut.Get("authorization"))
 }
 
 func TestClientMetadata_SkipEmptyValues(t *testing.T) {
@@ -95,6 +123,7 @@ func TestClientMetadata_SkipEmptyValues(t *testing.T) {
 	require.Empty(t, mdOut.Get("x-request-id"))
 	require.Empty(t, mdOut.Get("authorization"))
 	require.Empty(t, mdOut.Get("user-agent"))
+	require.Empty(t, mdOut.Get(identity.MDUserID))
 }
 
 func TestClientWithTimeout_SetsDeadline_AndInvokerSeesDeadlineExceeded(t *testing.T) {
@@ -236,3 +265,107 @@ func TestClientUnaryLoggingInterceptor_RequestIDIfPresent_IsUUID(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+// Разомкнутый предохранитель отвечает Unavailable, не вызывая апстрим;
+// бизнес-ошибки и отмена клиентом цепь не размыкают.
+func TestClientWithBreaker_OpensAndFailsFast(t *testing.T) {
+	t.Parallel()
+
+	b := breaker.New("test-interceptor", breaker.Options{FailureThreshold: 2, OpenTimeout: time.Hour})
+	inter := ClientWithBreaker(b)
+
+	calls := 0
+	invoke := func(ctx context.Context, code codes.Code) error {
+		return inter(ctx, "/news.NewsService/ListNews", nil, nil, nil, func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			calls++
+			return status.Error(code, "upstream")
+		})
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.Error(t, invoke(context.Background(), codes.NotFound))
+	require.Error(t, invoke(canceled, codes.Unavailable))
+	require.Error(t, invoke(context.Background(), codes.Unavailable))
+	require.Equal(t, breaker.Closed, b.State())
+
+	require.Error(t, invoke(context.Background(), codes.DeadlineExceeded))
+	require.Equal(t, breaker.Open, b.State())
+	require.Equal(t, 4, calls)
+
+	err := invoke(context.Background(), codes.OK)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, 4, calls, "open circuit must not call upstream")
+}
+
+// Медленная первая копия: ответ второй копии попадает в reply, первая отменяется.
+func TestClientWithHedging_SecondAttemptWins(t *testing.T) {
+	t.Parallel()
+
+	const method = "/news.NewsService/NewsByID"
+	inter := ClientWithHedging(HedgingOptions{
+		Methods:     map[string]bool{method: true},
+		Delay:       20 * time.Millisecond,
+		MaxAttempts: 2,
+	})
+
+	var (
+		mu       sync.Mutex
+		attempts int
+	)
+	firstCanceled := make(chan struct{})
+	invoker := func(ctx context.Context, _ string, _, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		mu.Lock()
+		attempts++
+		n := attempts
+		mu.Unlock()
+
+		if n == 1 {
+			<-ctx.Done()
+			close(firstCanceled)
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		reply.(*newsv1.NewsByIDResponse).Item = &newsv1.News{Id: "n1", Title: "second"}
+		return nil
+	}
+
+	reply := &newsv1.NewsByIDResponse{}
+	require.NoError(t, inter(context.Background(), method, &newsv1.NewsByIDRequest{Id: "n1"}, reply, nil, invoker))
+	require.Equal(t, "second", reply.GetItem().GetTitle())
+
+	select {
+	case <-firstCanceled:
+	case <-time.After(time.Second):
+		t.Fatal("slow attempt was not canceled")
+	}
+}
+
+// Unavailable копии сразу запускает следующую; прочие ошибки возвращаются как есть;
+// методы вне Methods не хеджируются.
+func TestClientWithHedging_ErrorsAndPassThrough(t *testing.T) {
+	t.Parallel()
+
+	const method = "/news.NewsService/NewsByID"
+	inter := ClientWithHedging(HedgingOptions{
+		Methods:     map[string]bool{method: true},
+		Delay:       time.Hour,
+		MaxAttempts: 3,
+	})
+
+	var calls atomic.Int32
+	codesSeq := []codes.Code{codes.Unavailable, codes.NotFound}
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		n := calls.Add(1)
+		return status.Error(codesSeq[n-1], "upstream")
+	}
+
+	err := inter(context.Background(), method, &newsv1.NewsByIDRequest{}, &newsv1.NewsByIDResponse{}, nil, invoker)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.EqualValues(t, 2, calls.Load())
+
+	calls.Store(0)
+	err = inter(context.Background(), "/news.NewsService/MarkRead", nil, &newsv1.NewsByIDResponse{}, nil, invoker)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.EqualValues(t, 1, calls.Load(), "non-hedged method is called once")
+}
@@ -3,6 +3,7 @@ package interceptors
 import (
 	"context"
 
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
@@ -12,32 +13,50 @@ type CtxKey string
 const (
 	CtxRequestID CtxKey = "request_id"
 	CtxAuthToken CtxKey = "auth_token"
+	// CtxCaller — проверенная личность вызывающего (identity.Caller), см. middleware.Identity.
+	CtxCaller CtxKey = "caller"
 )
 
 // ClientWithMetadata — добавляет в исходящий gRPC вызов заголовки:
 //   - x-request-id (если есть в контексте),
 //   - authorization: Bearer <token> (если есть в контексте),
+//   - x-user-id / x-user-roles (если в контексте есть аутентифицированный identity.Caller),
 //   - user-agent (если передан параметром).
 func ClientWithMetadata(userAgent string) grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		var pairs []string
+		return invoker(withOutgoingMetadata(ctx, userAgent), method, req, reply, cc, opts...)
+	}
+}
 
-		if v := ctx.Value(CtxRequestID); v != nil {
-			if rid, _ := v.(string); rid != "" {
-				pairs = append(pairs, "x-request-id", rid)
-			}
-		}
-		if v := ctx.Value(CtxAuthToken); v != nil {
-			if tok, _ := v.(string); tok != "" {
-				pairs = append(pairs, "authorization", "Bearer "+tok)
-			}
-		}
-		if userAgent != "" {
-			pairs = append(pairs, "user-agent", userAgent)
+// ClientStreamWithMetadata — ClientWithMetadata для потоковых вызовов (Watch*).
+func ClientStreamWithMetadata(userAgent string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withOutgoingMetadata(ctx, userAgent), desc, cc, method, opts...)
+	}
+}
+
+// withOutgoingMetadata — контекст с заголовками ClientWithMetadata.
+func withOutgoingMetadata(ctx context.Context, userAgent string) context.Context {
+	var pairs []string
+
+	if v := ctx.Value(CtxRequestID); v != nil {
+		if rid, _ := v.(string); rid != "" {
+			pairs = append(pairs, "x-request-id", rid)
 		}
-		if len(pairs) > 0 {
-			ctx = metadata.AppendToOutgoingContext(ctx, pairs...)
+	}
+	if v := ctx.Value(CtxAuthToken); v != nil {
+		if tok, _ := v.(string); tok != "" {
+			pairs = append(pairs, "authorization", "Bearer "+tok)
 		}
-		return invoker(ctx, method, req, reply, cc, opts...)
 	}
+	if c, ok := ctx.Value(CtxCaller).(identity.Caller); ok {
+		pairs = append(pairs, c.Pairs()...)
+	}
+	if userAgent != "" {
+		pairs = append(pairs, "user-agent", userAgent)
+	}
+	if len(pairs) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, pairs...)
+	}
+	return ctx
 }
@@ -0,0 +1,180 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	authv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/auth"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	newsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/news"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/users"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/config"
+)
+
+// upstream — апстрим шлюза и его методы, которые безопасно повторять.
+type upstream struct {
+	// name — метка метрик предохранителя и ключ /healthz.
+	name string
+	// reads — чтение: ретраи и хеджирование.
+	reads []string
+	// writes — идемпотентная запись (повтор даёт то же состояние): только ретраи.
+	// Создание, удаление, вход и ротация токенов сюда не входят.
+	writes []string
+}
+
+var (
+	authUpstream = upstream{
+		name:  "auth",
+		reads: []string{authv1.AuthService_ValidateToken_FullMethodName},
+	}
+
+	newsUpstream = upstream{
+		name: "news",
+		reads: []string{
+			newsv1.NewsService_ListNews_FullMethodName,
+			newsv1.NewsService_NewsByID_FullMethodName,
+			newsv1.NewsService_NewsByIDs_FullMethodName,
+			newsv1.NewsService_ListPersonalizedNews_FullMethodName,
+		},
+		writes: []string{
+			newsv1.NewsService_MarkRead_FullMethodName,
+			newsv1.NewsService_MarkAllRead_FullMethodName,
+		},
+	}
+
+	commentsUpstream = upstream{
+		name: "comments",
+		reads: []string{
+			commentsv1.CommentsService_CommentByID_FullMethodName,
+			commentsv1.CommentsService_ListByNews_FullMethodName,
+			commentsv1.CommentsService_ListReplies_FullMethodName,
+			commentsv1.CommentsService_CountsByNews_FullMethodName,
+			commentsv1.CommentsService_ListByUser_FullMethodName,
+			commentsv1.CommentsService_ListByUsers_FullMethodName,
+			commentsv1.CommentsService_SearchComments_FullMethodName,
+			commentsv1.CommentsService_ListNotifications_FullMethodName,
+			commentsv1.CommentsService_UnreadCount_FullMethodName,
+			commentsv1.CommentsService_GetThreadPolicy_FullMethodName,
+		},
+		writes: []string{
+			commentsv1.CommentsService_MarkRead_FullMethodName,
+			commentsv1.CommentsService_MuteThread_FullMethodName,
+			commentsv1.CommentsService_UnmuteThread_FullMethodName,
+			commentsv1.CommentsService_SetThreadPolicy_FullMethodName,
+		},
+	}
+
+	usersUpstream = upstream{
+		name: "users",
+		reads: []string{
+			usersv1.UsersService_ProfileByID_FullMethodName,
+			usersv1.UsersService_ProfilesByIDs_FullMethodName,
+			usersv1.UsersService_ResolveUsernames_FullMethodName,
+			usersv1.UsersService_CheckUsernameAvailability_FullMethodName,
+			usersv1.UsersService_SearchProfiles_FullMethodName,
+			usersv1.UsersService_ListFollowers_FullMethodName,
+			usersv1.UsersService_ListFollowing_FullMethodName,
+			usersv1.UsersService_GetPreferences_FullMethodName,
+			usersv1.UsersService_ListBookmarks_FullMethodName,
+		},
+		writes: []string{
+			usersv1.UsersService_UpdatePreferences_FullMethodName,
+			usersv1.UsersService_Follow_FullMethodName,
+			usersv1.UsersService_Unfollow_FullMethodName,
+			usersv1.UsersService_AddBookmark_FullMethodName,
+			usersv1.UsersService_RemoveBookmark_FullMethodName,
+		},
+	}
+)
+
+// hedgeable — полные имена читающих методов для interceptors.HedgingOptions.
+func (u upstream) hedgeable() map[string]bool {
+	out := make(map[string]bool, len(u.reads))
+	for _, m := range u.reads {
+		out[m] = true
+	}
+
+	return out
+}
+
+// Ограничение ретраев при массовых отказах (retryThrottling): каждый отказ
+// тратит токен, успех возвращает tokenRatio; при половине бюджета повторы
+// прекращаются — лежащий апстрим не получает x MaxAttempts нагрузки.
+const (
+	retryThrottleMaxTokens  = 10
+	retryThrottleTokenRatio = 0.1
+)
+
+// serviceConfig — gRPC service config апстрима с retryPolicy для идемпотентных
+// методов. Пауза перед повтором — случайная в [0, backoff), backoff растёт
+// от InitialBackoff в BackoffMultiplier раз до MaxBackoff. MaxAttempts <= 1 — "".
+func serviceConfig(u upstream, r config.RetryConfig) (string, error) {
+	if r.MaxAttempts <= 1 {
+		return "", nil
+	}
+	if r.InitialBackoff <= 0 || r.MaxBackoff < r.InitialBackoff || r.BackoffMultiplier <= 0 || len(r.Codes) == 0 {
+		return "", fmt.Errorf("invalid retry policy: need initial_backoff > 0, max_backoff >= initial_backoff, backoff_multiplier > 0 and codes")
+	}
+
+	type methodName struct {
+		Service string `json:"service"`
+		Method  string `json:"method"`
+	}
+
+	names := make([]methodName, 0, len(u.reads)+len(u.writes))
+	for _, full := range append(append([]string(nil), u.reads...), u.writes...) {
+		svc, method, ok := strings.Cut(strings.TrimPrefix(full, "/"), "/")
+		if !ok {
+			return "", fmt.Errorf("bad method name %q", full)
+		}
+		names = append(names, methodName{Service: svc, Method: method})
+	}
+
+	codes := make([]string, len(r.Codes))
+	for i, c := range r.Codes {
+		codes[i] = strings.ToUpper(strings.TrimSpace(c))
+	}
+
+	sc := map[string]any{
+		"methodConfig": []map[string]any{{
+			"name": names,
+			"retryPolicy": map[string]any{
+				"maxAttempts":          r.MaxAttempts,
+				"initialBackoff":       durationJSON(r.InitialBackoff),
+				"maxBackoff":           durationJSON(r.MaxBackoff),
+				"backoffMultiplier":    r.BackoffMultiplier,
+				"retryableStatusCodes": codes,
+			},
+		}},
+		"retryThrottling": map[string]any{
+			"maxTokens":  retryThrottleMaxTokens,
+			"tokenRatio": retryThrottleTokenRatio,
+		},
+	}
+
+	b, err := json.Marshal(sc)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// durationJSON — google.protobuf.Duration в JSON: "0.05s".
+func durationJSON(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+// Breakers — состояние предохранителей апстримов ("closed", "half_open", "open");
+// пусто, если предохранители выключены.
+func (c *Clients) Breakers() map[string]string {
+	out := make(map[string]string, len(c.breakers))
+	for _, b := range c.breakers {
+		out[b.Name()] = b.State().String()
+	}
+
+	return out
+}
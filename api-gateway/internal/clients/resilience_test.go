@@ -0,0 +1,60 @@
+package clients
+
+// Тесты resilience.go:
+//   - service config с ретраями принимается grpc-go для всех апстримов;
+//   - MaxAttempts <= 1 — без service config, некорректная политика — ошибка.
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/config"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestServiceConfig_ParsedByGRPC(t *testing.T) {
+	r := config.RetryConfig{
+		MaxAttempts:       3,
+		InitialBackoff:    50 * time.Millisecond,
+		MaxBackoff:        500 * time.Millisecond,
+		BackoffMultiplier: 2,
+		Codes:             []string{"unavailable", " RESOURCE_EXHAUSTED"},
+	}
+
+	for _, u := range []upstream{authUpstream, newsUpstream, commentsUpstream, usersUpstream} {
+		sc, err := serviceConfig(u, r)
+		require.NoError(t, err, u.name)
+
+		var parsed struct {
+			MethodConfig []struct {
+				Name        []map[string]string `json:"name"`
+				RetryPolicy map[string]any      `json:"retryPolicy"`
+			} `json:"methodConfig"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(sc), &parsed))
+		require.Len(t, parsed.MethodConfig, 1)
+		require.Len(t, parsed.MethodConfig[0].Name, len(u.reads)+len(u.writes))
+		require.Equal(t, "0.05s", parsed.MethodConfig[0].RetryPolicy["initialBackoff"])
+		require.Equal(t, []any{"UNAVAILABLE", "RESOURCE_EXHAUSTED"}, parsed.MethodConfig[0].RetryPolicy["retryableStatusCodes"])
+
+		// grpc-go разбирает service config при создании клиента.
+		conn, err := grpc.NewClient("localhost:1",
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithDefaultServiceConfig(sc),
+		)
+		require.NoError(t, err, u.name)
+		require.NoError(t, conn.Close())
+	}
+}
+
+func TestServiceConfig_DisabledAndInvalid(t *testing.T) {
+	sc, err := serviceConfig(newsUpstream, config.RetryConfig{MaxAttempts: 1})
+	require.NoError(t, err)
+	require.Empty(t, sc)
+
+	_, err = serviceConfig(newsUpstream, config.RetryConfig{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Millisecond, BackoffMultiplier: 2, Codes: []string{"UNAVAILABLE"}})
+	require.Error(t, err)
+}
@@ -14,6 +14,9 @@ import (
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/ratelimit"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/respcache"
+	"github.com/pribylovaa/go-news-aggregator/pkg/tlsconfig"
 )
 
 type Config struct {
@@ -22,6 +25,172 @@ type Config struct {
 	GRPC     GRPCConfig    `yaml:"grpc"`
 	Metrics  MetricsConfig `yaml:"metrics"`
 	Timeouts TimeoutConfig `yaml:"timeouts"`
+	Auth     AuthConfig    `yaml:"auth"`
+	// RateLimit — ограничение частоты запросов (middleware.RateLimit).
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	// Cache — кэш ответов публичных GET-маршрутов (middleware.Cache).
+	Cache CacheConfig `yaml:"cache"`
+	// Stream — потоки событий text/event-stream (/news/stream, /news/{news_id}/comments/stream,
+	// /users/{id}/notifications/stream).
+	Stream StreamConfig `yaml:"stream"`
+	// WS — WebSocket /ws: живые ветки комментариев и публикация по одному соединению.
+	WS WSConfig `yaml:"ws"`
+	// GraphQL — POST /graphql: лимиты глубины, сложности и размера запроса.
+	GraphQL GraphQLConfig `yaml:"graphql"`
+	// NewsPage — составной GET /news/{id}/page.
+	NewsPage NewsPageConfig `yaml:"news_page"`
+	// Resilience — ретраи, хеджирование и предохранители исходящих вызовов (internal/clients).
+	Resilience ResilienceConfig `yaml:"resilience"`
+}
+
+// StreamConfig — SSE-эндпоинты шлюза.
+type StreamConfig struct {
+	// Heartbeat — период комментария ": ping" в простаивающем потоке (держит прокси и ловит обрыв).
+	Heartbeat time.Duration `yaml:"heartbeat" env:"STREAM_HEARTBEAT" env-default:"15s"`
+	// WriteTimeout — предел записи одного события; клиент, не читающий дольше, отключается.
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"STREAM_WRITE_TIMEOUT" env-default:"10s"`
+	// Retry — пауза переподключения EventSource (поле retry:).
+	Retry time.Duration `yaml:"retry" env:"STREAM_RETRY" env-default:"3s"`
+}
+
+// WSConfig — WebSocket-эндпоинт /ws. Лимиты действуют на одно соединение;
+// превышение MaxMessageBytes или SendBuffer закрывает соединение, остальные — кадр error.
+type WSConfig struct {
+	// MaxSubscriptions — новостей, на которые одновременно подписано соединение.
+	MaxSubscriptions int `yaml:"max_subscriptions" env:"WS_MAX_SUBSCRIPTIONS" env-default:"20"`
+	// MaxMessageBytes — предел входящего кадра.
+	MaxMessageBytes int `yaml:"max_message_bytes" env:"WS_MAX_MESSAGE_BYTES" env-default:"16384"`
+	// MessageRate/MessageBurst — входящие кадры в секунду (токен-бакет).
+	MessageRate  int `yaml:"message_rate"  env:"WS_MESSAGE_RATE"  env-default:"10"`
+	MessageBurst int `yaml:"message_burst" env:"WS_MESSAGE_BURST" env-default:"20"`
+	// CommentRate — публикаций комментариев в минуту.
+	CommentRate int `yaml:"comment_rate" env:"WS_COMMENT_RATE" env-default:"6"`
+	// SendBuffer — исходящие кадры в очереди соединения; переполнение — клиент не читает.
+	SendBuffer int `yaml:"send_buffer" env:"WS_SEND_BUFFER" env-default:"64"`
+	// PingInterval — период ping-кадров; WriteTimeout — предел записи одного кадра.
+	PingInterval time.Duration `yaml:"ping_interval" env:"WS_PING_INTERVAL" env-default:"30s"`
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"WS_WRITE_TIMEOUT" env-default:"10s"`
+	// AllowedOrigins — допустимые Origin рукопожатия; пусто — любые
+	// (аутентификация — токеном, не cookie).
+	AllowedOrigins []string `yaml:"allowed_origins" env:"WS_ALLOWED_ORIGINS" env-separator:","`
+}
+
+// GraphQLConfig — лимиты /graphql; запрос сверх лимита отклоняется с 400 до обращения к апстримам.
+type GraphQLConfig struct {
+	// MaxDepth — предельная вложенность полей.
+	MaxDepth int `yaml:"max_depth" env:"GRAPHQL_MAX_DEPTH" env-default:"8"`
+	// MaxComplexity — предельная сложность: поле стоит 1, выбор внутри
+	// страницы умножается на её размер (pageSize/limit или размер по умолчанию).
+	MaxComplexity int `yaml:"max_complexity" env:"GRAPHQL_MAX_COMPLEXITY" env-default:"5000"`
+	// MaxBodyBytes — предельный размер тела запроса.
+	MaxBodyBytes int64 `yaml:"max_body_bytes" env:"GRAPHQL_MAX_BODY_BYTES" env-default:"65536"`
+}
+
+// NewsPageConfig — GET /news/{id}/page: новость, комментарии и авторы под одним дедлайном.
+// Не успевшие комментарии и авторы отдаются как missing, новость — всегда.
+type NewsPageConfig struct {
+	// Timeout — общий дедлайн вызовов страницы.
+	Timeout time.Duration `yaml:"timeout" env:"NEWS_PAGE_TIMEOUT" env-default:"2s"`
+	// CommentsPageSize — корневых комментариев без ?page_size=; 0 — по умолчанию comments-service.
+	CommentsPageSize int32 `yaml:"comments_page_size" env:"NEWS_PAGE_COMMENTS_PAGE_SIZE" env-default:"20"`
+}
+
+// ResilienceConfig — устойчивость unary-вызовов к апстримам. Параметры общие,
+// а применимость — по таблице методов internal/clients: ретраи — только
+// идемпотентные RPC, хеджирование — только чтение, потоки Watch* — без них.
+type ResilienceConfig struct {
+	Retry   RetryConfig   `yaml:"retry"`
+	Hedging HedgingConfig `yaml:"hedging"`
+	Breaker BreakerConfig `yaml:"breaker"`
+}
+
+// RetryConfig — retryPolicy gRPC service config: экспоненциальная пауза
+// со случайным джиттером, повторы только по Codes.
+type RetryConfig struct {
+	// MaxAttempts — попыток всего, включая первую; 1 — без повторов, gRPC ограничивает 5.
+	MaxAttempts       int           `yaml:"max_attempts"       env:"RETRY_MAX_ATTEMPTS"       env-default:"3"`
+	InitialBackoff    time.Duration `yaml:"initial_backoff"    env:"RETRY_INITIAL_BACKOFF"    env-default:"50ms"`
+	MaxBackoff        time.Duration `yaml:"max_backoff"        env:"RETRY_MAX_BACKOFF"        env-default:"500ms"`
+	BackoffMultiplier float64       `yaml:"backoff_multiplier" env:"RETRY_BACKOFF_MULTIPLIER" env-default:"2"`
+	// Codes — повторяемые gRPC-коды (UNAVAILABLE, RESOURCE_EXHAUSTED...).
+	Codes []string `yaml:"codes" env:"RETRY_CODES" env-separator:"," env-default:"UNAVAILABLE"`
+}
+
+// HedgingConfig — копии читающих вызовов, если ответа нет дольше Delay.
+type HedgingConfig struct {
+	Enabled bool          `yaml:"enabled" env:"HEDGING_ENABLED" env-default:"false"`
+	Delay   time.Duration `yaml:"delay"   env:"HEDGING_DELAY"   env-default:"100ms"`
+	// MaxAttempts — копий всего, включая первую.
+	MaxAttempts int `yaml:"max_attempts" env:"HEDGING_MAX_ATTEMPTS" env-default:"2"`
+}
+
+// BreakerConfig — предохранитель на каждый апстрим: разомкнутый отвечает 503 сразу.
+type BreakerConfig struct {
+	Enabled bool `yaml:"enabled" env:"BREAKER_ENABLED" env-default:"true"`
+	// FailureThreshold — отказов подряд (Unavailable/DeadlineExceeded) до размыкания.
+	FailureThreshold int `yaml:"failure_threshold" env:"BREAKER_FAILURE_THRESHOLD" env-default:"5"`
+	// OpenTimeout — сколько цепь разомкнута до пробных вызовов.
+	OpenTimeout time.Duration `yaml:"open_timeout" env:"BREAKER_OPEN_TIMEOUT" env-default:"10s"`
+	// HalfOpenRequests — одновременных пробных вызовов.
+	HalfOpenRequests int `yaml:"half_open_requests" env:"BREAKER_HALF_OPEN_REQUESTS" env-default:"1"`
+}
+
+// CacheConfig — кэш ответов шлюза.
+//
+// Routes — TTL по "METHOD /pattern" (шаблон chi без base path), например
+// "GET /news/{id}"; маршруты без правила не кэшируются. Routes задаются только в YAML.
+type CacheConfig struct {
+	Enabled bool `yaml:"enabled" env:"CACHE_ENABLED"`
+	// Backend — memory (LRU у каждой реплики свой) или redis (общий).
+	Backend     string `yaml:"backend"      env:"CACHE_BACKEND"      env-default:"memory"`
+	Size        int    `yaml:"size"         env:"CACHE_SIZE"         env-default:"10000"`
+	RedisURL    string `yaml:"redis_url"    env:"CACHE_REDIS_URL"`
+	RedisPrefix string `yaml:"redis_prefix" env:"CACHE_REDIS_PREFIX" env-default:"gw:cache:"`
+	// MaxBodyBytes — ответы больше не кэшируются.
+	MaxBodyBytes int                       `yaml:"max_body_bytes" env:"CACHE_MAX_BODY_BYTES" env-default:"1048576"`
+	Routes       map[string]respcache.Rule `yaml:"routes"`
+}
+
+// RateLimitConfig — токен-бакеты шлюза.
+//
+// Default действует на все маршруты без собственной политики (общие вёдра),
+// Routes — переопределения по "METHOD /pattern" (шаблон chi без base path),
+// например "POST /auth/login". Правило с rate: 0 не ограничивает.
+// Routes задаются только в YAML.
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	// Backend — memory (вёдра у каждой реплики свои) или redis (общие).
+	Backend     string `yaml:"backend"      env:"RATE_LIMIT_BACKEND"      env-default:"memory"`
+	RedisURL    string `yaml:"redis_url"    env:"RATE_LIMIT_REDIS_URL"`
+	RedisPrefix string `yaml:"redis_prefix" env:"RATE_LIMIT_REDIS_PREFIX" env-default:"gw:rl:"`
+	// TrustForwardedFor — IP клиента из X-Forwarded-For (шлюз за доверенным прокси).
+	TrustForwardedFor bool                        `yaml:"trust_forwarded_for" env:"RATE_LIMIT_TRUST_FORWARDED_FOR"`
+	Default           ratelimit.Policy            `yaml:"default" env-prefix:"RATE_LIMIT_DEFAULT_"`
+	Routes            map[string]ratelimit.Policy `yaml:"routes"`
+}
+
+// Policies — политики для middleware.RateLimit.
+func (c RateLimitConfig) Policies() ratelimit.Policies {
+	return ratelimit.Policies{Default: c.Default, Routes: c.Routes}
+}
+
+// AuthConfig — определение вызывающего по access-токену.
+// Admins/Moderators — user_id, которым шлюз выдаёт роли admin/moderator
+// (передаются сервисам в x-user-roles).
+//
+// Verify — способ проверки токена:
+//   - remote — AuthService.ValidateToken, ответы кэшируются на CacheTTL (CacheSize записей);
+//   - local — подпись HS256 и iss/aud/exp в шлюзе; JWTSecret, Issuer и Audience
+//     должны совпадать с настройками auth-service.
+type AuthConfig struct {
+	Admins     []string      `yaml:"admins"      env:"AUTH_ADMINS"      env-separator:","`
+	Moderators []string      `yaml:"moderators"  env:"AUTH_MODERATORS"  env-separator:","`
+	Verify     string        `yaml:"verify"      env:"AUTH_VERIFY"      env-default:"remote"`
+	CacheTTL   time.Duration `yaml:"cache_ttl"   env:"AUTH_CACHE_TTL"   env-default:"1m"`
+	CacheSize  int           `yaml:"cache_size"  env:"AUTH_CACHE_SIZE"  env-default:"10000"`
+	JWTSecret  string        `yaml:"jwt_secret"  env:"AUTH_JWT_SECRET"`
+	Issuer     string        `yaml:"issuer"      env:"AUTH_ISSUER"      env-default:"auth-service"`
+	Audience   []string      `yaml:"audience"    env:"AUTH_AUDIENCE"    env-separator:"," env-default:"api-gateway"`
 }
 
 // TimeoutConfig — таймаут сервиса.
@@ -51,6 +220,8 @@ type GRPCConfig struct {
 	NewsAddr     string `yaml:"news_addr"     env:"GRPC_NEWS_ADDR"     env-default:"0.0.0.0:50052"`
 	UsersAddr    string `yaml:"users_addr"    env:"GRPC_USERS_ADDR"    env-default:"0.0.0.0:50053"`
 	CommentsAddr string `yaml:"comments_addr" env:"GRPC_COMMENTS_ADDR" env-default:"0.0.0.0:50054"`
+	// TLS — TLS/mTLS исходящих gRPC-клиентов (pkg/tlsconfig).
+	TLS tlsconfig.Config `yaml:"tls" env-prefix:"GRPC_TLS_"`
 }
 
 // MustLoad — паника при ошибке загрузки.
@@ -175,6 +175,7 @@ func TestLoad_EnvOverlay_OverridesValuesFromFile(t *testing.T) {
 	t.Setenv("METRICS_HOST", "0.0.0.0")
 	t.Setenv("GRPC_AUTH_ADDR", "1.2.3.4:60081")
 	t.Setenv("SERVICE", "5s") // таймаут
+	t.Setenv("AUTH_ADMINS", "u-1,u-2")
 
 	cfg, err := Load(cfgPath)
 	require.NoError(t, err)
@@ -183,6 +184,7 @@ func TestLoad_EnvOverlay_OverridesValuesFromFile(t *testing.T) {
 	require.Equal(t, "0.0.0.0", cfg.Metrics.Host)
 	require.Equal(t, "1.2.3.4:60081", cfg.GRPC.AuthAddr)
 	require.Equal(t, 5*time.Second, cfg.Timeouts.Service)
+	require.Equal(t, []string{"u-1", "u-2"}, cfg.Auth.Admins)
 }
 
 // «Только ENV» без файлов.
@@ -217,6 +219,194 @@ func TestLoad_EnvOnly_OK(t *testing.T) {
 	require.Equal(t, 2*time.Second, cfg.Timeouts.Service)
 }
 
+// Политики лимитов: маршруты из YAML, правило по умолчанию — с ENV-оверлеем через env-prefix.
+func TestLoad_RateLimit(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "config.yaml", `
+rate_limit:
+  enabled: true
+  default:
+    ip: {rate: 20, burst: 40}
+    user: {rate: 10}
+  routes:
+    "POST /auth/login":
+      ip: {rate: 5, per: 1m}
+`)
+
+	t.Setenv("RATE_LIMIT_DEFAULT_USER_RATE", "30")
+
+	cfg, err := Load(cfgPath)
+	require.NoError(t, err)
+
+	rl := cfg.RateLimit
+	require.True(t, rl.Enabled)
+	require.Equal(t, "memory", rl.Backend)
+	require.Equal(t, "gw:rl:", rl.RedisPrefix)
+	require.Equal(t, 20, rl.Default.IP.Rate)
+	require.Equal(t, 40, rl.Default.IP.Capacity())
+	require.Equal(t, 30, rl.Default.User.Rate)
+	require.Equal(t, time.Second, rl.Default.User.Window())
+
+	login := rl.Policies().Routes["POST /auth/login"]
+	require.Equal(t, 5, login.IP.Capacity())
+	require.Equal(t, time.Minute, login.IP.Window())
+	require.False(t, login.User.Enabled())
+}
+
+// Кэш ответов: TTL маршрутов из YAML, значения по умолчанию и ENV-оверлей.
+func TestLoad_Cache(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "config.yaml", `
+cache:
+  enabled: true
+  routes:
+    "GET /news":
+      ttl: 30s
+    "GET /news/{id}":
+      ttl: 1m
+`)
+
+	t.Setenv("CACHE_BACKEND", "redis")
+
+	cfg, err := Load(cfgPath)
+	require.NoError(t, err)
+
+	c := cfg.Cache
+	require.True(t, c.Enabled)
+	require.Equal(t, "redis", c.Backend)
+	require.Equal(t, 10000, c.Size)
+	require.Equal(t, "gw:cache:", c.RedisPrefix)
+	require.Equal(t, 1<<20, c.MaxBodyBytes)
+	require.Equal(t, 30*time.Second, c.Routes["GET /news"].TTL)
+	require.Equal(t, time.Minute, c.Routes["GET /news/{id}"].TTL)
+}
+
+// Потоки событий: значения по умолчанию и ENV-оверлей.
+func TestLoad_Stream(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "config.yaml", `
+stream:
+  heartbeat: 20s
+`)
+
+	t.Setenv("STREAM_RETRY", "5s")
+
+	cfg, err := Load(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, 20*time.Second, cfg.Stream.Heartbeat)
+	require.Equal(t, 10*time.Second, cfg.Stream.WriteTimeout)
+	require.Equal(t, 5*time.Second, cfg.Stream.Retry)
+}
+
+func TestLoad_WS(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "config.yaml", `
+ws:
+  max_subscriptions: 5
+  allowed_origins: ["https://news.example"]
+`)
+
+	t.Setenv("WS_COMMENT_RATE", "2")
+
+	cfg, err := Load(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, 5, cfg.WS.MaxSubscriptions)
+	require.Equal(t, []string{"https://news.example"}, cfg.WS.AllowedOrigins)
+	require.Equal(t, 2, cfg.WS.CommentRate)
+	require.Equal(t, 16384, cfg.WS.MaxMessageBytes)
+	require.Equal(t, 10, cfg.WS.MessageRate)
+	require.Equal(t, 20, cfg.WS.MessageBurst)
+	require.Equal(t, 64, cfg.WS.SendBuffer)
+	require.Equal(t, 30*time.Second, cfg.WS.PingInterval)
+	require.Equal(t, 10*time.Second, cfg.WS.WriteTimeout)
+}
+
+func TestLoad_GraphQL(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "config.yaml", `
+graphql:
+  max_depth: 5
+`)
+
+	t.Setenv("GRAPHQL_MAX_COMPLEXITY", "300")
+
+	cfg, err := Load(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, 5, cfg.GraphQL.MaxDepth)
+	require.Equal(t, 300, cfg.GraphQL.MaxComplexity)
+	require.EqualValues(t, 65536, cfg.GraphQL.MaxBodyBytes)
+}
+
+func TestLoad_NewsPage(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "config.yaml", minimalYAML)
+
+	cfg, err := Load(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Second, cfg.NewsPage.Timeout)
+	require.EqualValues(t, 20, cfg.NewsPage.CommentsPageSize)
+
+	t.Setenv("NEWS_PAGE_TIMEOUT", "750ms")
+
+	cfg, err = Load(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, 750*time.Millisecond, cfg.NewsPage.Timeout)
+}
+
+func TestLoad_Resilience(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "config.yaml", `
+resilience:
+  retry:
+    codes: [UNAVAILABLE, RESOURCE_EXHAUSTED]
+  hedging:
+    enabled: true
+`)
+
+	t.Setenv("BREAKER_FAILURE_THRESHOLD", "3")
+
+	cfg, err := Load(cfgPath)
+	require.NoError(t, err)
+
+	r := cfg.Resilience
+	require.Equal(t, 3, r.Retry.MaxAttempts)
+	require.Equal(t, 50*time.Millisecond, r.Retry.InitialBackoff)
+	require.Equal(t, 500*time.Millisecond, r.Retry.MaxBackoff)
+	require.InDelta(t, 2.0, r.Retry.BackoffMultiplier, 1e-9)
+	require.Equal(t, []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"}, r.Retry.Codes)
+	require.True(t, r.Hedging.Enabled)
+	require.Equal(t, 100*time.Millisecond, r.Hedging.Delay)
+	require.Equal(t, 2, r.Hedging.MaxAttempts)
+	require.True(t, r.Breaker.Enabled)
+	require.Equal(t, 3, r.Breaker.FailureThreshold)
+	require.Equal(t, 10*time.Second, r.Breaker.OpenTimeout)
+	require.Equal(t, 1, r.Breaker.HalfOpenRequests)
+}
+
+// Проверка токенов: значения по умолчанию и ENV-оверлей.
+func TestLoad_Auth(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "config.yaml", minimalYAML)
+
+	cfg, err := Load(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, "remote", cfg.Auth.Verify)
+	require.Equal(t, time.Minute, cfg.Auth.CacheTTL)
+	require.Equal(t, 10000, cfg.Auth.CacheSize)
+	require.Equal(t, "auth-service", cfg.Auth.Issuer)
+	require.Equal(t, []string{"api-gateway"}, cfg.Auth.Audience)
+
+	t.Setenv("AUTH_VERIFY", "local")
+	t.Setenv("AUTH_JWT_SECRET", "s3cret")
+	t.Setenv("AUTH_MODERATORS", "m-1,m-2")
+
+	cfg, err = Load(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, "local", cfg.Auth.Verify)
+	require.Equal(t, "s3cret", cfg.Auth.JWTSecret)
+	require.Equal(t, []string{"m-1", "m-2"}, cfg.Auth.Moderators)
+}
+
 func TestMustLoad_OK(t *testing.T) {
 	t.Parallel()
 
@@ -235,3 +425,28 @@ func TestMustLoad_PanicsOnError(t *testing.T) {
 		_ = MustLoad(filepath.Join(t.TempDir(), "nope.yaml"))
 	})
 }
+
+// TLS gRPC: YAML и ENV с префиксом GRPC_TLS_.
+func TestLoad_GRPCTLS(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "config.yaml", `
+grpc:
+  tls:
+    enabled: true
+    ca_file: /etc/grpc-tls/ca.crt
+`)
+
+	t.Setenv("GRPC_TLS_CERT_FILE", "/tls/tls.crt")
+	t.Setenv("GRPC_TLS_KEY_FILE", "/tls/tls.key")
+
+	cfg, err := Load(cfgPath)
+	require.NoError(t, err)
+
+	tls := cfg.GRPC.TLS
+	require.True(t, tls.Enabled)
+	require.Equal(t, "/etc/grpc-tls/ca.crt", tls.CAFile)
+	require.Equal(t, "/tls/tls.crt", tls.CertFile)
+	require.Equal(t, "/tls/tls.key", tls.KeyFile)
+	require.False(t, tls.ClientAuth)
+	require.Equal(t, 30*time.Second, tls.ReloadInterval)
+}
@@ -96,8 +96,8 @@ func WriteError(w http.ResponseWriter, r *http.Request, err error) {
 //   - AlreadyExists (конфликты уникальности/дубликаты) -> 409
 //   - FailedPrecondition (логические ограничения: thread expired / max depth) -> 412
 //   - Unauthenticated -> 401 (auth: invalid credentials/token/expired/revoked)
-//   - PermissionDenied -> 403 (зарезервировано на будущее)
-//   - ResourceExhausted -> 429 (rate limit/квоты; зарезервировано)
+//   - PermissionDenied -> 403 (политики доступа маршрутов, чужой user_id)
+//   - ResourceExhausted -> 429 (лимиты шлюза middleware.RateLimit, квоты)
 //   - Aborted -> 409 (конфликт транзакции; зарезервировано)
 //   - Canceled -> 499 (клиент закрыл соединение)
 //   - DeadlineExceeded -> 504 (таймаут запроса к апстриму)
@@ -0,0 +1,318 @@
+// fanout — живые ветки комментариев для WebSocket-соединений шлюза.
+//
+// На каждую новость, на которую подписано хотя бы одно соединение реплики,
+// открыта ровно одна апстрим-подписка CommentsService.WatchComments; её события
+// раздаются локальным подписчикам через pubsub.Broker. Подписка закрывается,
+// когда уходит последний подписчик.
+//
+// Принципы:
+//   - чтение апстрима никогда не ждёт подписчиков: не успевающий подписчик
+//     отключается (Lagged), остальные продолжают получать события;
+//   - обрыв апстрима (в т.ч. RESOURCE_EXHAUSTED) — переподключение с последним
+//     event_id и экспоненциальной паузой с джиттером; пропущенное досылает сервис,
+//     а reset_required передаётся подписчикам как есть;
+//   - апстрим-подписка анонимная: чтение комментариев не требует токена;
+//   - событие конвертируется и обогащается (Options.Enrich) один раз на ветку,
+//     а не на каждого подписчика;
+//   - незнакомые виды событий апстрима пропускаются, а не выдаются за created.
+package fanout
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/pkg/pubsub"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Значения Options по умолчанию.
+const (
+	defaultBuffer     = 64
+	defaultMinBackoff = 500 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+
+	// enrichTimeout — предел Options.Enrich на событие: обогащение некритично
+	// и не должно задерживать ветку.
+	enrichTimeout = 2 * time.Second
+)
+
+// EventKind — вид события ветки.
+type EventKind string
+
+const (
+	EventCreated EventKind = "created"
+	EventDeleted EventKind = "deleted"
+	// EventReaction — изменились счётчики реакций комментария.
+	EventReaction EventKind = "reaction"
+	// EventReset — апстрим не смог досылать пропущенное (reset_required):
+	// подписчику стоит перечитать ветку.
+	EventReset EventKind = "reset"
+)
+
+// Event — событие ветки для локальных подписчиков.
+type Event struct {
+	Kind EventKind
+	// EventID — курсор апстрима; пусто у удалений, реакций и reset.
+	EventID string
+	// Comment — пусто у reset; у удаления — только идентификаторы и is_deleted,
+	// у реакции — идентификаторы и reactions.
+	Comment models.Comment
+}
+
+var (
+	upstreamThreads = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "api_gateway",
+		Subsystem: "ws",
+		Name:      "upstream_threads",
+		Help:      "Открытые апстрим-подписки WatchComments (одна на новость).",
+	})
+
+	upstreamReconnects = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "api_gateway",
+		Subsystem: "ws",
+		Name:      "upstream_reconnects_total",
+		Help:      "Переподключения апстрим-подписок WatchComments.",
+	})
+)
+
+// Options — параметры Hub.
+type Options struct {
+	// Buffer — буфер локального подписчика; переполнение — отключение (Lagged). 0 — 64.
+	Buffer int
+	// MinBackoff/MaxBackoff — пауза переподключения апстрима; 0 — 500ms/30s.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Enrich дополняет новый комментарий (данные автора); nil — без обогащения.
+	Enrich func(ctx context.Context, c *models.Comment)
+	Logger *slog.Logger
+}
+
+// Hub — апстрим-подписки по новостям с раздачей локальным подписчикам.
+type Hub struct {
+	client commentsv1.CommentsServiceClient
+	opts   Options
+	broker *pubsub.Broker[Event]
+
+	mu      sync.Mutex
+	threads map[string]*thread
+	closed  bool
+}
+
+// thread — апстрим-подписка новости и число её локальных подписчиков.
+type thread struct {
+	refs   int
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Subscription — локальная подписка на новость.
+// C закрывается после Close или отключения медленного подписчика (Lagged);
+// Close обязателен в обоих случаях — он освобождает апстрим-подписку.
+type Subscription struct {
+	*pubsub.Subscription[Event]
+	once  sync.Once
+	leave func()
+}
+
+// Close отписывает; последний подписчик новости закрывает апстрим. Повторный вызов безопасен.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.Subscription.Close()
+		s.leave()
+	})
+}
+
+// New создаёт Hub поверх клиента comments-service.
+func New(client commentsv1.CommentsServiceClient, opts Options) *Hub {
+	if opts.Buffer <= 0 {
+		opts.Buffer = defaultBuffer
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = defaultMinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(defaultMaxBackoff, opts.MinBackoff)
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+
+	return &Hub{
+		client:  client,
+		opts:    opts,
+		broker:  pubsub.New[Event](),
+		threads: make(map[string]*thread),
+	}
+}
+
+// Subscribe подписывает на события новости newsID (id должен быть валидным UUID).
+// Первый подписчик новости открывает апстрим-подписку. После Close хаба
+// возвращается уже закрытая подписка.
+func (h *Hub) Subscribe(newsID string) *Subscription {
+	sub := h.broker.Watch(newsID, h.opts.Buffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		sub.Close()
+		return &Subscription{Subscription: sub, leave: func() {}}
+	}
+
+	t, ok := h.threads[newsID]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		t = &thread{cancel: cancel, done: make(chan struct{})}
+		h.threads[newsID] = t
+		upstreamThreads.Inc()
+
+		go func() {
+			defer close(t.done)
+			h.run(ctx, newsID)
+		}()
+	}
+	t.refs++
+
+	return &Subscription{Subscription: sub, leave: func() { h.leave(newsID, t) }}
+}
+
+// leave снимает ссылку подписчика; последняя ссылка закрывает апстрим.
+func (h *Hub) leave(newsID string, t *thread) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	t.refs--
+	if t.refs > 0 || h.threads[newsID] != t {
+		return
+	}
+
+	delete(h.threads, newsID)
+	upstreamThreads.Dec()
+	t.cancel()
+}
+
+// Threads — число открытых апстрим-подписок.
+func (h *Hub) Threads() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.threads)
+}
+
+// Close закрывает все апстрим-подписки и ждёт их завершения.
+// Локальные подписки при этом не закрываются — их закрывают владельцы.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	h.closed = true
+	threads := h.threads
+	h.threads = make(map[string]*thread)
+	h.mu.Unlock()
+
+	for range threads {
+		upstreamThreads.Dec()
+	}
+	for _, t := range threads {
+		t.cancel()
+		<-t.done
+	}
+}
+
+// run держит апстрим-подписку новости до отмены ctx, переподключаясь с последним event_id.
+func (h *Hub) run(ctx context.Context, newsID string) {
+	lg := h.opts.Logger.With("news_id", newsID)
+
+	var last string
+	backoff := h.opts.MinBackoff
+
+	for {
+		received, err := h.watch(ctx, newsID, &last)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Сервис отключил отстающий апстрим или поток работал — переподключаемся сразу.
+		if received || status.Code(err) == codes.ResourceExhausted {
+			backoff = h.opts.MinBackoff
+		}
+
+		if !errors.Is(err, io.EOF) {
+			lg.Warn("fanout: upstream watch interrupted", "err", err, "retry_in", backoff)
+		}
+		upstreamReconnects.Inc()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(jitter(backoff)):
+		}
+		backoff = min(backoff*2, h.opts.MaxBackoff)
+	}
+}
+
+// watch читает одну апстрим-подписку до ошибки; received — было хотя бы одно событие.
+func (h *Hub) watch(ctx context.Context, newsID string, last *string) (received bool, err error) {
+	stream, err := h.client.WatchComments(ctx, &commentsv1.WatchCommentsRequest{NewsId: newsID, LastEventId: *last})
+	if err != nil {
+		return false, err
+	}
+
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+
+		switch {
+		case ev.GetResetRequired():
+			// Курсор больше не годится: дальше — только новые.
+			*last = ""
+		case ev.GetEventId() != "":
+			*last = ev.GetEventId()
+		}
+		if out, ok := h.event(ctx, ev); ok {
+			h.broker.Publish(newsID, out)
+		}
+	}
+}
+
+// event конвертирует событие апстрима; новые комментарии обогащаются Options.Enrich.
+// ok == false — незнакомый вид события (более новый comments-service): пропускается.
+func (h *Hub) event(ctx context.Context, ev *commentsv1.CommentEvent) (_ Event, ok bool) {
+	switch {
+	case ev.GetResetRequired():
+		return Event{Kind: EventReset}, true
+	case ev.GetKind() == commentsv1.CommentEventKind_COMMENT_DELETED:
+		return Event{Kind: EventDeleted, Comment: models.CommentFromProto(ev.GetComment())}, true
+	case ev.GetKind() == commentsv1.CommentEventKind_COMMENT_REACTION:
+		return Event{Kind: EventReaction, Comment: models.CommentFromProto(ev.GetComment())}, true
+	case ev.GetKind() != commentsv1.CommentEventKind_COMMENT_CREATED &&
+		ev.GetKind() != commentsv1.CommentEventKind_COMMENT_EVENT_KIND_UNSPECIFIED:
+		return Event{}, false
+	}
+
+	out := Event{Kind: EventCreated, EventID: ev.GetEventId(), Comment: models.CommentFromProto(ev.GetComment())}
+	if h.opts.Enrich != nil {
+		ectx, cancel := context.WithTimeout(ctx, enrichTimeout)
+		h.opts.Enrich(ectx, &out.Comment)
+		cancel()
+	}
+
+	return out, true
+}
+
+// jitter — пауза d ± 20%, чтобы переподключения реплик не совпадали.
+func jitter(d time.Duration) time.Duration {
+	delta := float64(d) * 0.2
+	return time.Duration(float64(d) - delta + rand.Float64()*2*delta)
+}
@@ -0,0 +1,187 @@
+package fanout
+
+// Тесты Hub:
+//   - одна апстрим-подписка на новость для всех подписчиков, закрытие с последним;
+//   - переподключение с последним event_id, reset_required сбрасывает курсор;
+//   - конвертация событий (created с Enrich, deleted, reset), незнакомые виды пропускаются;
+//   - медленный подписчик отключается, остальные получают события.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const newsID = "3f2c9d1e-8b7a-4c6d-9e0f-1a2b3c4d5e6f"
+
+// watchCall — один вызов WatchComments: события из канала, закрытие — Unavailable.
+type watchCall struct {
+	grpc.ClientStream
+	req    *commentsv1.WatchCommentsRequest
+	ctx    context.Context
+	events chan *commentsv1.CommentEvent
+}
+
+func (c *watchCall) Recv() (*commentsv1.CommentEvent, error) {
+	select {
+	case ev, ok := <-c.events:
+		if !ok {
+			return nil, status.Error(codes.Unavailable, "upstream gone")
+		}
+		return ev, nil
+	case <-c.ctx.Done():
+		return nil, status.FromContextError(c.ctx.Err()).Err()
+	}
+}
+
+type fakeUpstream struct {
+	commentsv1.CommentsServiceClient
+	calls chan *watchCall
+}
+
+func newFakeUpstream() *fakeUpstream {
+	return &fakeUpstream{calls: make(chan *watchCall, 4)}
+}
+
+func (f *fakeUpstream) WatchComments(ctx context.Context, in *commentsv1.WatchCommentsRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[commentsv1.CommentEvent], error) {
+	c := &watchCall{req: in, ctx: ctx, events: make(chan *commentsv1.CommentEvent, 8)}
+	f.calls <- c
+	return c, nil
+}
+
+func (f *fakeUpstream) next(t *testing.T) *watchCall {
+	t.Helper()
+
+	select {
+	case c := <-f.calls:
+		return c
+	case <-time.After(time.Second):
+		t.Fatal("no upstream WatchComments call")
+		return nil
+	}
+}
+
+func recvEvent(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+
+	select {
+	case ev, ok := <-sub.C:
+		require.True(t, ok, "subscription closed")
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("no event")
+		return Event{}
+	}
+}
+
+func TestHub_OneUpstreamPerThread(t *testing.T) {
+	up := newFakeUpstream()
+	hub := New(up, Options{Enrich: func(_ context.Context, c *models.Comment) { c.DisplayName = "Alice" }})
+	defer hub.Close()
+
+	a := hub.Subscribe(newsID)
+	b := hub.Subscribe(newsID)
+	call := up.next(t)
+	require.Equal(t, newsID, call.req.GetNewsId())
+	require.Equal(t, 1, hub.Threads())
+
+	call.events <- &commentsv1.CommentEvent{EventId: "c1", Comment: &commentsv1.Comment{Id: "c1", UserId: "u1"}}
+	for _, sub := range []*Subscription{a, b} {
+		ev := recvEvent(t, sub)
+		require.Equal(t, EventCreated, ev.Kind)
+		require.Equal(t, "c1", ev.EventID)
+		require.Equal(t, "Alice", ev.Comment.DisplayName, "enriched once for all subscribers")
+	}
+
+	a.Close()
+	a.Close()
+	require.NoError(t, call.ctx.Err(), "upstream stays while someone listens")
+	require.Equal(t, 1, hub.Threads())
+
+	b.Close()
+	<-call.ctx.Done()
+	require.Equal(t, 0, hub.Threads())
+	select {
+	case c := <-up.calls:
+		t.Fatalf("unexpected upstream call %v", c.req)
+	default:
+	}
+}
+
+func TestHub_ReconnectResumesFromLastEvent(t *testing.T) {
+	up := newFakeUpstream()
+	hub := New(up, Options{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	defer hub.Close()
+
+	sub := hub.Subscribe(newsID)
+	defer sub.Close()
+
+	call := up.next(t)
+	require.Empty(t, call.req.GetLastEventId())
+	// Вид события из будущей версии апстрима не становится created.
+	call.events <- &commentsv1.CommentEvent{Kind: commentsv1.CommentEventKind(99), Comment: &commentsv1.Comment{Id: "x"}}
+	call.events <- &commentsv1.CommentEvent{EventId: "c1", Comment: &commentsv1.Comment{Id: "c1"}}
+	call.events <- &commentsv1.CommentEvent{
+		Kind:    commentsv1.CommentEventKind_COMMENT_DELETED,
+		Comment: &commentsv1.Comment{Id: "c0", IsDeleted: true},
+	}
+	call.events <- &commentsv1.CommentEvent{
+		Kind:    commentsv1.CommentEventKind_COMMENT_REACTION,
+		Comment: &commentsv1.Comment{Id: "c1", Reactions: map[string]int64{"like": 2}},
+	}
+	close(call.events)
+
+	created := recvEvent(t, sub)
+	require.Equal(t, EventCreated, created.Kind)
+	require.Equal(t, "c1", created.Comment.ID)
+	del := recvEvent(t, sub)
+	require.Equal(t, EventDeleted, del.Kind)
+	require.Equal(t, "c0", del.Comment.ID)
+	require.Empty(t, del.EventID)
+	react := recvEvent(t, sub)
+	require.Equal(t, EventReaction, react.Kind)
+	require.Equal(t, map[string]int64{"like": 2}, react.Comment.Reactions)
+	require.Empty(t, react.EventID)
+
+	// Удаление и реакция курсор не двигают.
+	call = up.next(t)
+	require.Equal(t, "c1", call.req.GetLastEventId())
+
+	call.events <- &commentsv1.CommentEvent{ResetRequired: true}
+	close(call.events)
+	require.Equal(t, EventReset, recvEvent(t, sub).Kind)
+
+	call = up.next(t)
+	require.Empty(t, call.req.GetLastEventId(), "reset drops the cursor")
+}
+
+func TestHub_SlowSubscriberIsEvicted(t *testing.T) {
+	up := newFakeUpstream()
+	hub := New(up, Options{Buffer: 1})
+	defer hub.Close()
+
+	slow := hub.Subscribe(newsID)
+	defer slow.Close()
+	fast := hub.Subscribe(newsID)
+	defer fast.Close()
+
+	call := up.next(t)
+	for _, id := range []string{"c1", "c2", "c3"} {
+		call.events <- &commentsv1.CommentEvent{EventId: id, Comment: &commentsv1.Comment{Id: id}}
+		require.Equal(t, id, recvEvent(t, fast).EventID)
+	}
+
+	// Буфер медленного — одно событие; дальше он отключён.
+	require.Equal(t, "c1", recvEvent(t, slow).EventID)
+	_, ok := <-slow.C
+	require.False(t, ok)
+	require.True(t, slow.Lagged())
+	require.False(t, fast.Lagged())
+}
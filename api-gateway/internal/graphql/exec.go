@@ -0,0 +1,312 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// complexityCap — потолок подсчёта сложности: огромные размеры страниц
+// не переполняют int, а просто дают «слишком сложно».
+const complexityCap = 1 << 31
+
+// validator проверяет выбор по схеме и считает глубину и сложность
+// (фрагменты раскрываются; циклы фрагментов — ошибка). Глубина и сложность
+// фрагмента считаются один раз, а обход останавливается на первом
+// превышении лимита — вложенные фрагменты не раздувают проверку.
+type validator struct {
+	doc   *document
+	vars  map[string]any
+	opts  Options
+	errs  []*Error
+	stack []string // раскрываемые фрагменты
+	frags map[string]fragmentCost
+
+	limit *RequestError // превышен лимит; обход остановлен
+}
+
+// fragmentCost — глубина фрагмента относительно места раскрытия и его сложность.
+type fragmentCost struct {
+	depth, complexity int
+}
+
+func (v *validator) errorf(s *selection, format string, args ...any) {
+	v.errs = append(v.errs, &Error{
+		Message:   fmt.Sprintf(format, args...),
+		Locations: []Location{{Line: s.line, Column: s.column}},
+	})
+}
+
+// exceeds проверяет лимиты и при превышении останавливает обход.
+func (v *validator) exceeds(depth, complexity int) bool {
+	switch {
+	case v.limit != nil:
+	case v.opts.MaxDepth > 0 && depth > v.opts.MaxDepth:
+		v.limit = requestError(fmt.Sprintf("query depth %d exceeds limit %d", depth, v.opts.MaxDepth), nil)
+	case v.opts.MaxComplexity > 0 && complexity > v.opts.MaxComplexity:
+		v.limit = requestError(fmt.Sprintf("query complexity %d exceeds limit %d", complexity, v.opts.MaxComplexity), nil)
+	}
+
+	return v.limit != nil
+}
+
+// selectionSet возвращает глубину самого глубокого поля и сложность выбора.
+func (v *validator) selectionSet(obj *Object, sel []selection, depth int) (maxDepth, complexity int) {
+	for i := range sel {
+		if v.exceeds(maxDepth, complexity) {
+			return maxDepth, complexity
+		}
+		s := &sel[i]
+
+		switch {
+		case s.spread != "":
+			f := v.doc.fragments[s.spread]
+			switch {
+			case f == nil:
+				v.errorf(s, "unknown fragment %q", s.spread)
+				continue
+			case slices.Contains(v.stack, s.spread):
+				v.errorf(s, "fragment %q spreads itself", s.spread)
+				continue
+			case f.on != obj.Name:
+				v.errorf(s, "fragment %q on %s cannot be spread on %s", s.spread, f.on, obj.Name)
+				continue
+			}
+
+			fc, ok := v.frags[s.spread]
+			if !ok {
+				v.stack = append(v.stack, s.spread)
+				d, c := v.selectionSet(obj, f.sel, depth)
+				v.stack = v.stack[:len(v.stack)-1]
+
+				fc = fragmentCost{depth: max(d-depth, 0), complexity: c}
+				v.frags[s.spread] = fc
+			}
+			maxDepth, complexity = max(maxDepth, depth+fc.depth), min(complexity+fc.complexity, complexityCap)
+
+		case s.inline:
+			if s.on != "" && s.on != obj.Name {
+				v.errorf(s, "inline fragment on %s cannot be used on %s", s.on, obj.Name)
+				continue
+			}
+			d, c := v.selectionSet(obj, s.sel, depth)
+			maxDepth, complexity = max(maxDepth, d), min(complexity+c, complexityCap)
+
+		case s.name == "__typename":
+			if s.sel != nil {
+				v.errorf(s, "field \"__typename\" has no subfields")
+			}
+			maxDepth = max(maxDepth, depth)
+
+		default:
+			d, c := v.field(obj, s, depth)
+			maxDepth, complexity = max(maxDepth, d), min(complexity+c, complexityCap)
+		}
+	}
+	v.exceeds(maxDepth, complexity)
+
+	return maxDepth, complexity
+}
+
+func (v *validator) field(obj *Object, s *selection, depth int) (int, int) {
+	if v.exceeds(depth, 0) {
+		return depth, 0
+	}
+
+	def, ok := obj.Fields[s.name]
+	if !ok {
+		v.errorf(s, "cannot query field %q on type %s", s.name, obj.Name)
+		return depth, 0
+	}
+
+	args, err := fieldArgs(def, s.args, v.vars)
+	if err != nil {
+		v.errorf(s, "field %q: %v", s.name, err)
+		return depth, 0
+	}
+
+	cost := def.Cost
+	if cost <= 0 {
+		cost = 1
+	}
+
+	child, ok := baseType(def.Type).(*Object)
+	if !ok {
+		if s.sel != nil {
+			v.errorf(s, "field %q of type %s has no subfields", s.name, def.Type.typeName())
+		}
+		return depth, cost
+	}
+	if s.sel == nil {
+		v.errorf(s, "field %q of type %s must have a selection of subfields", s.name, def.Type.typeName())
+		return depth, cost
+	}
+
+	mult := 1
+	if def.SizeArg != "" {
+		mult = def.SizeDefault
+		if n, ok := args[def.SizeArg].(int); ok && n > 0 {
+			mult = n
+		}
+		mult = max(mult, 1)
+	}
+
+	next := depth + 1
+	if def.flat {
+		next = depth
+	}
+
+	d, c := v.selectionSet(child, s.sel, next)
+	if c > 0 && mult > complexityCap/c {
+		return d, complexityCap
+	}
+
+	return d, min(cost+mult*c, complexityCap)
+}
+
+// baseType — тип элемента для списков.
+func baseType(t Type) Type {
+	for {
+		l, ok := t.(list)
+		if !ok {
+			return t
+		}
+		t = l.of
+	}
+}
+
+// executor исполняет проверенный запрос по уровням.
+type executor struct {
+	ctx  context.Context
+	doc  *document
+	vars map[string]any
+	opts Options
+	errs []*Error
+}
+
+// task — объект ответа, ждущий исполнения своего выбора.
+type task struct {
+	obj    *Object
+	sel    []selection
+	source any
+	out    *orderedMap
+	path   []any
+}
+
+// call — вызванный резолвер поля.
+type call struct {
+	t   *task
+	f   *collected
+	def *Field
+	val any
+	err error
+}
+
+func (e *executor) run(root *Object, sel []selection) *orderedMap {
+	data := newOrderedMap(len(sel))
+	tasks := []task{{obj: root, sel: sel, out: data}}
+	for len(tasks) > 0 {
+		tasks = e.level(tasks)
+	}
+
+	return data
+}
+
+// level вызывает резолверы всех полей уровня, затем вычисляет Thunk
+// (первый же вычисленный Thunk загрузчика забирает все ключи уровня)
+// и возвращает объекты следующего уровня.
+func (e *executor) level(tasks []task) []task {
+	var calls []*call
+	for i := range tasks {
+		t := &tasks[i]
+		for _, f := range collect(t.obj, t.sel, e.doc, e.vars) {
+			if f.name == "__typename" {
+				t.out.set(f.key, t.obj.Name)
+				continue
+			}
+
+			def := t.obj.Fields[f.name]
+			t.out.set(f.key, nil) // порядок полей — как в запросе
+
+			c := &call{t: t, f: f, def: def}
+			args, err := fieldArgs(def, f.args, e.vars)
+			if err != nil {
+				c.err = err
+			} else {
+				c.val, c.err = def.Resolve(Params{Ctx: e.ctx, Source: t.source, Args: args})
+			}
+			calls = append(calls, c)
+		}
+	}
+
+	var next []task
+	for _, c := range calls {
+		path := append(slices.Clone(c.t.path), c.f.key)
+
+		if th, ok := c.val.(Thunk); ok && c.err == nil {
+			c.val, c.err = th()
+		}
+		if c.err != nil {
+			e.fieldError(c.err, c.f, path)
+			continue
+		}
+
+		v, err := e.complete(c.def.Type, c.val, c.f, path, &next)
+		if err != nil {
+			e.fieldError(err, c.f, path)
+			continue
+		}
+		c.t.out.set(c.f.key, v)
+	}
+
+	return next
+}
+
+// complete приводит значение резолвера к типу поля; объекты ставятся в очередь
+// следующего уровня. Элементы срезов структур передаются дальше указателями.
+func (e *executor) complete(t Type, v any, f *collected, path []any, next *[]task) (any, error) {
+	if isNil(v) {
+		return nil, nil
+	}
+
+	switch t := t.(type) {
+	case *Object:
+		m := newOrderedMap(len(f.sel))
+		*next = append(*next, task{obj: t, sel: f.sel, source: v, out: m, path: path})
+		return m, nil
+	case list:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("graphql: %T is not a list", v)
+		}
+
+		out := make([]any, rv.Len())
+		for i := range out {
+			item := rv.Index(i)
+			if item.Kind() == reflect.Struct && item.CanAddr() {
+				item = item.Addr()
+			}
+
+			x, err := e.complete(t.of, item.Interface(), f, append(slices.Clone(path), i), next)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = x
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+func (e *executor) fieldError(err error, f *collected, path []any) {
+	ge := &Error{Message: err.Error()}
+	if e.opts.FormatError != nil {
+		ge = e.opts.FormatError(e.ctx, err)
+	}
+	ge.Path = path
+	ge.Locations = []Location{{Line: f.line, Column: f.col}}
+
+	e.errs = append(e.errs, ge)
+}
@@ -0,0 +1,80 @@
+package graphql
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// StructFields — скалярные поля по json-тегам структуры sample (snake_case -> camelCase):
+// string — String (id и *_id — ID), bool — Boolean, целые — Int, float — Float,
+// []string — [String]. Вложенные структуры, map и указатели пропускаются —
+// их описывают явными полями. skip — исключаемые json-имена.
+//
+// Источник резолвера — указатель на структуру или сама структура.
+func StructFields(sample any, skip ...string) map[string]*Field {
+	t := reflect.TypeOf(sample)
+	out := make(map[string]*Field, t.NumField())
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+
+		tag, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if tag == "" || tag == "-" || slices.Contains(skip, tag) {
+			continue
+		}
+
+		typ := scalarOf(sf.Type, tag)
+		if typ == nil {
+			continue
+		}
+
+		index := i
+		out[camelCase(tag)] = &Field{
+			Type: typ,
+			Resolve: func(p Params) (any, error) {
+				return reflect.Indirect(reflect.ValueOf(p.Source)).Field(index).Interface(), nil
+			},
+		}
+	}
+
+	return out
+}
+
+func scalarOf(t reflect.Type, tag string) Type {
+	switch t.Kind() {
+	case reflect.String:
+		if tag == "id" || strings.HasSuffix(tag, "_id") {
+			return ID
+		}
+		return String
+	case reflect.Bool:
+		return Boolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Int
+	case reflect.Float32, reflect.Float64:
+		return Float
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return ListOf(String)
+		}
+	}
+
+	return nil
+}
+
+// camelCase: short_description -> shortDescription.
+func camelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,516 @@
+// graphql — минимальный исполнитель GraphQL-запросов для api-gateway.
+//
+// Схема описывается кодом (Object/Field), резолверы — обычные функции поверх
+// gRPC-клиентов. Поддерживается чтение: query, переменные, алиасы, фрагменты,
+// @skip/@include, __typename и интроспекция (__schema, __type).
+//
+// Принципы:
+//   - исполнение идёт по уровням (в ширину): сначала вызываются резолверы всех
+//     полей уровня, затем вычисляются отложенные значения (Thunk). Так Loader
+//     собирает ключи всего уровня — например, авторов всех комментариев
+//     страницы — и забирает их одним вызовом;
+//   - до исполнения запрос проверяется по схеме и ограничивается глубиной
+//     и сложностью (Options.MaxDepth/MaxComplexity);
+//   - ошибка резолвера обнуляет поле и попадает в errors с путём,
+//     остальной ответ отдаётся (частичный результат).
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Type — тип значения поля: *Scalar, *Object или List.
+type Type interface {
+	typeName() string
+}
+
+// Scalar — скалярный тип; значение отдаётся как есть (json).
+type Scalar struct {
+	Name string
+}
+
+func (s *Scalar) typeName() string { return s.Name }
+
+// Встроенные скаляры.
+var (
+	ID      = &Scalar{Name: "ID"}
+	String  = &Scalar{Name: "String"}
+	Int     = &Scalar{Name: "Int"}
+	Float   = &Scalar{Name: "Float"}
+	Boolean = &Scalar{Name: "Boolean"}
+)
+
+// ListOf — список значений типа t; значение резолвера — срез.
+func ListOf(t Type) Type { return list{of: t} }
+
+type list struct {
+	of Type
+}
+
+func (l list) typeName() string { return "[" + l.of.typeName() + "]" }
+
+// Object — объектный тип. Поля можно добавлять после создания (рекурсивные типы).
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+func (o *Object) typeName() string { return o.Name }
+
+// Field — поле объекта.
+type Field struct {
+	Type Type
+	Args map[string]*Arg
+	// Resolve возвращает значение поля или Thunk (отложенное, для Loader).
+	Resolve func(p Params) (any, error)
+	// Cost — стоимость поля в сложности запроса; 0 — 1.
+	Cost int
+	// SizeArg — аргумент с размером страницы: сложность вложенного выбора
+	// умножается на его значение (без аргумента — на SizeDefault).
+	SizeArg     string
+	SizeDefault int
+
+	flat bool // не увеличивает глубину (ofType интроспекции)
+}
+
+// Arg — аргумент поля: скалярный тип и значение по умолчанию (nil — нет).
+type Arg struct {
+	Type     *Scalar
+	Required bool
+	Default  any
+}
+
+// Params — вход резолвера.
+type Params struct {
+	Ctx    context.Context
+	Source any
+	// Args — приведённые аргументы: ID/String — string, Int — int, Float — float64,
+	// Boolean — bool; отсутствующие без значения по умолчанию — не заданы.
+	Args map[string]any
+}
+
+// String — строковый аргумент или "".
+func (p Params) String(name string) string {
+	s, _ := p.Args[name].(string)
+	return s
+}
+
+// Int — целочисленный аргумент или 0.
+func (p Params) Int(name string) int {
+	n, _ := p.Args[name].(int)
+	return n
+}
+
+// Thunk — отложенное значение поля: вычисляется после вызова всех резолверов уровня.
+type Thunk func() (any, error)
+
+// Schema — корневые типы. Query дополняется полями интроспекции.
+type Schema struct {
+	Query *Object
+
+	intro introspection
+}
+
+// Options — ограничения и оформление ошибок.
+type Options struct {
+	// MaxDepth — предел вложенности выбора; 0 — без предела.
+	MaxDepth int
+	// MaxComplexity — предел сложности (сумма Cost с учётом размеров страниц); 0 — без предела.
+	MaxComplexity int
+	// FormatError оформляет ошибку резолвера; nil — message = err.Error().
+	FormatError func(ctx context.Context, err error) *Error
+}
+
+// Request — тело POST-запроса GraphQL.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response — ответ: data (нет при ошибке запроса) и errors.
+type Response struct {
+	Data   any      `json:"data,omitempty"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// Error — ошибка GraphQL.
+type Error struct {
+	Message    string         `json:"message"`
+	Locations  []Location     `json:"locations,omitempty"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Location — позиция в документе запроса.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// RequestError — запрос не исполнялся: синтаксис, несоответствие схеме или лимиты.
+type RequestError struct {
+	Errors []*Error
+}
+
+func (e *RequestError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Message)
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Execute разбирает, проверяет и исполняет запрос. Ошибка — только *RequestError
+// (запрос не исполнялся); ошибки полей — в Response.Errors.
+func (s *Schema) Execute(ctx context.Context, req Request, opts Options) (*Response, error) {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return nil, requestError(err.Error(), nil)
+	}
+
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return nil, requestError(err.Error(), nil)
+	}
+	if op.kind != "query" {
+		return nil, requestError(fmt.Sprintf("%s operations are not supported", op.kind), nil)
+	}
+
+	vars, err := coerceVariables(op, req.Variables)
+	if err != nil {
+		return nil, requestError(err.Error(), nil)
+	}
+
+	v := &validator{doc: doc, vars: vars, opts: opts, frags: make(map[string]fragmentCost)}
+	root := s.root()
+	v.selectionSet(root, op.sel, 1)
+	if len(v.errs) > 0 {
+		return nil, &RequestError{Errors: v.errs}
+	}
+	if v.limit != nil {
+		return nil, v.limit
+	}
+
+	e := &executor{ctx: ctx, doc: doc, vars: vars, opts: opts}
+	data := e.run(root, op.sel)
+
+	return &Response{Data: data, Errors: e.errs}, nil
+}
+
+func requestError(msg string, loc *Location) *RequestError {
+	e := &Error{Message: msg}
+	if loc != nil {
+		e.Locations = []Location{*loc}
+	}
+
+	return &RequestError{Errors: []*Error{e}}
+}
+
+// operation выбирает операцию по имени; без имени — единственную.
+func (d *document) operation(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, fmt.Errorf("operationName is required for documents with several operations")
+		}
+		return d.operations[0], nil
+	}
+
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// coerceVariables — значения переменных из запроса и значения по умолчанию.
+func coerceVariables(op *operation, in map[string]any) (map[string]any, error) {
+	out := make(map[string]any, len(op.variables))
+	for _, def := range op.variables {
+		if v, ok := in[def.name]; ok && v != nil {
+			out[def.name] = v
+			continue
+		}
+		if def.defValue != nil {
+			v, err := def.defValue.resolve(nil)
+			if err != nil {
+				return nil, err
+			}
+			out[def.name] = v
+			continue
+		}
+		if def.nonNull {
+			return nil, fmt.Errorf("variable $%s is required", def.name)
+		}
+	}
+
+	return out, nil
+}
+
+// resolve — Go-значение литерала (Int — int64, Float — float64) с подстановкой переменных.
+func (v value) resolve(vars map[string]any) (any, error) {
+	switch v.kind {
+	case valueVariable:
+		return vars[v.raw], nil
+	case valueInt:
+		var n int64
+		if _, err := fmt.Sscan(v.raw, &n); err != nil {
+			return nil, fmt.Errorf("invalid Int %s", v.raw)
+		}
+		return n, nil
+	case valueFloat:
+		var f float64
+		if _, err := fmt.Sscan(v.raw, &f); err != nil {
+			return nil, fmt.Errorf("invalid Float %s", v.raw)
+		}
+		return f, nil
+	case valueString, valueEnum:
+		return v.raw, nil
+	case valueBoolean:
+		return v.raw == "true", nil
+	case valueNull:
+		return nil, nil
+	case valueList:
+		out := make([]any, 0, len(v.list))
+		for _, item := range v.list {
+			x, err := item.resolve(vars)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, x)
+		}
+		return out, nil
+	default:
+		out := make(map[string]any, len(v.fields))
+		for _, f := range v.fields {
+			x, err := f.val.resolve(vars)
+			if err != nil {
+				return nil, err
+			}
+			out[f.name] = x
+		}
+		return out, nil
+	}
+}
+
+// coerceArg приводит значение аргумента к скаляру схемы.
+func coerceArg(t *Scalar, v any) (any, error) {
+	switch t {
+	case Int:
+		switch n := v.(type) {
+		case int64:
+			if n == int64(int32(n)) {
+				return int(n), nil
+			}
+		case float64: // переменные из JSON
+			if n == float64(int32(n)) {
+				return int(n), nil
+			}
+		case json.Number:
+			if i, err := n.Int64(); err == nil && i == int64(int32(i)) {
+				return int(i), nil
+			}
+		}
+	case Float:
+		switch n := v.(type) {
+		case int64:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		}
+	case Boolean:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case ID:
+		switch x := v.(type) {
+		case string:
+			return x, nil
+		case int64:
+			return fmt.Sprint(x), nil
+		}
+	default:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("expected %s, got %v", t.Name, v)
+}
+
+// fieldArgs — приведённые аргументы поля.
+func fieldArgs(def *Field, args []argument, vars map[string]any) (map[string]any, error) {
+	out := make(map[string]any, len(def.Args))
+	for _, a := range args {
+		spec, ok := def.Args[a.name]
+		if !ok {
+			return nil, fmt.Errorf("unknown argument %q", a.name)
+		}
+
+		raw, err := a.val.resolve(vars)
+		if err != nil {
+			return nil, err
+		}
+		if raw == nil {
+			continue
+		}
+
+		v, err := coerceArg(spec.Type, raw)
+		if err != nil {
+			return nil, fmt.Errorf("argument %q: %w", a.name, err)
+		}
+		out[a.name] = v
+	}
+
+	for name, spec := range def.Args {
+		if _, ok := out[name]; ok {
+			continue
+		}
+		switch {
+		case spec.Default != nil:
+			out[name] = spec.Default
+		case spec.Required:
+			return nil, fmt.Errorf("argument %q is required", name)
+		}
+	}
+
+	return out, nil
+}
+
+// included — @skip(if:)/@include(if:).
+func included(dirs []directive, vars map[string]any) bool {
+	for _, d := range dirs {
+		if d.name != "skip" && d.name != "include" {
+			continue
+		}
+
+		cond := false
+		for _, a := range d.args {
+			if a.name == "if" {
+				v, _ := a.val.resolve(vars)
+				cond, _ = v.(bool)
+			}
+		}
+		if d.name == "skip" && cond || d.name == "include" && !cond {
+			return false
+		}
+	}
+
+	return true
+}
+
+// collected — поле выбора после раскрытия фрагментов; одноимённые ключи объединены.
+type collected struct {
+	key  string
+	name string
+	args []argument
+	sel  []selection
+	line int
+	col  int
+}
+
+// collect раскрывает фрагменты выбора для объекта obj (в порядке документа).
+func collect(obj *Object, sel []selection, doc *document, vars map[string]any) []*collected {
+	var out []*collected
+	index := make(map[string]*collected)
+	visited := make(map[string]bool)
+
+	var walk func(sel []selection)
+	walk = func(sel []selection) {
+		for i := range sel {
+			s := &sel[i]
+			if !included(s.directives, vars) {
+				continue
+			}
+
+			switch {
+			case s.spread != "":
+				f := doc.fragments[s.spread]
+				if f == nil || visited[s.spread] || f.on != obj.Name {
+					continue
+				}
+				visited[s.spread] = true
+				walk(f.sel)
+			case s.inline:
+				if s.on == "" || s.on == obj.Name {
+					walk(s.sel)
+				}
+			default:
+				key := s.responseKey()
+				if c, ok := index[key]; ok {
+					c.sel = append(c.sel, s.sel...)
+					continue
+				}
+				c := &collected{key: key, name: s.name, args: s.args, sel: s.sel, line: s.line, col: s.column}
+				index[key] = c
+				out = append(out, c)
+			}
+		}
+	}
+	walk(sel)
+
+	return out
+}
+
+// orderedMap — объект ответа с порядком полей запроса.
+type orderedMap struct {
+	keys []string
+	vals map[string]any
+}
+
+func newOrderedMap(n int) *orderedMap {
+	return &orderedMap{keys: make([]string, 0, n), vals: make(map[string]any, n)}
+}
+
+func (m *orderedMap) set(k string, v any) {
+	if _, ok := m.vals[k]; !ok {
+		m.keys = append(m.keys, k)
+	}
+	m.vals[k] = v
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		b.Write(key)
+		b.WriteByte(':')
+		val, err := json.Marshal(m.vals[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(val)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+// isNil — nil или nil-указатель/срез/map.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface, reflect.Func:
+		return rv.IsNil()
+	}
+
+	return false
+}
@@ -0,0 +1,386 @@
+package graphql
+
+// Тесты исполнителя:
+//   - алиасы, фрагменты, @skip/@include, переменные, __typename, порядок полей;
+//   - Loader: ключи уровня — одной пачкой, повторы схлопываются;
+//   - ошибки резолвера — null и errors с путём, остальное отдаётся;
+//   - ошибки запроса (синтаксис, схема, аргументы, циклы фрагментов, mutation);
+//   - лимиты глубины и сложности (в т.ч. без переполнения на огромных страницах);
+//   - вложенные фрагменты проверяются за линейное время;
+//   - интроспекция: __type, стандартный запрос клиентов в пределах лимитов;
+//   - FuzzParse: разбор и исполнение произвольного текста не паникуют.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	OwnerID string `json:"owner_id"`
+	Score   int64  `json:"score"`
+}
+
+type testUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// testSchema: items(first) и item(id) поверх n элементов, owner — через Loader.
+func testSchema(n int, owners *Loader[string, *testUser]) *Schema {
+	items := make([]testItem, n)
+	for i := range items {
+		items[i] = testItem{ID: fmt.Sprint(i), Name: fmt.Sprintf("item-%d", i), OwnerID: fmt.Sprintf("u%d", i%2), Score: int64(i * 10)}
+	}
+
+	user := &Object{Name: "User", Fields: StructFields(testUser{})}
+	item := &Object{Name: "Item", Fields: StructFields(testItem{})}
+	item.Fields["owner"] = &Field{Type: user, Resolve: func(p Params) (any, error) {
+		return owners.Load(p.Ctx, p.Source.(*testItem).OwnerID), nil
+	}}
+	item.Fields["children"] = &Field{
+		Type:    ListOf(item),
+		Args:    map[string]*Arg{"first": {Type: Int, Default: 2}},
+		SizeArg: "first", SizeDefault: 2,
+		Resolve: func(p Params) (any, error) { return items[:min(max(p.Int("first"), 0), n)], nil },
+	}
+	item.Fields["broken"] = &Field{Type: String, Resolve: func(Params) (any, error) {
+		return nil, errors.New("boom")
+	}}
+
+	query := &Object{Name: "Query", Fields: map[string]*Field{
+		"items": {
+			Type:    ListOf(item),
+			Args:    map[string]*Arg{"first": {Type: Int, Default: 10}},
+			SizeArg: "first", SizeDefault: 10,
+			Resolve: func(p Params) (any, error) { return items[:min(max(p.Int("first"), 0), n)], nil },
+		},
+		"item": {
+			Type: item,
+			Args: map[string]*Arg{"id": {Type: ID, Required: true}},
+			Resolve: func(p Params) (any, error) {
+				for i := range items {
+					if items[i].ID == p.String("id") {
+						return &items[i], nil
+					}
+				}
+				return nil, nil
+			},
+		},
+		"echo": {
+			Type:    String,
+			Args:    map[string]*Arg{"s": {Type: String}},
+			Resolve: func(p Params) (any, error) { return p.String("s"), nil },
+		},
+	}}
+
+	return &Schema{Query: query}
+}
+
+func newOwners(calls *[][]string) *Loader[string, *testUser] {
+	return NewLoader(func(_ context.Context, keys []string) (map[string]*testUser, error) {
+		*calls = append(*calls, keys)
+		out := make(map[string]*testUser, len(keys))
+		for _, k := range keys {
+			if k != "u1" { // u1 — «не найден»
+				out[k] = &testUser{ID: k, Name: strings.ToUpper(k)}
+			}
+		}
+		return out, nil
+	})
+}
+
+func execJSON(t *testing.T, s *Schema, req Request, opts Options) string {
+	t.Helper()
+
+	resp, err := s.Execute(context.Background(), req, opts)
+	require.NoError(t, err)
+
+	b, err := json.Marshal(resp)
+	require.NoError(t, err)
+
+	return string(b)
+}
+
+func TestExecute_SelectionFeatures(t *testing.T) {
+	var calls [][]string
+	s := testSchema(3, newOwners(&calls))
+
+	got := execJSON(t, s, Request{
+		Query: `
+			# комментарий
+			query Q($id: ID!, $withScore: Boolean = false) {
+				first: item(id: $id) { ...F score @include(if: $withScore) }
+				second: item(id: "2") { __typename ... on Item { name } id @skip(if: true) }
+				missing: item(id: "42") { id }
+				echo(s: "aA\n")
+			}
+			fragment F on Item { id name }`,
+		Variables: map[string]any{"id": "1", "withScore": true},
+	}, Options{})
+
+	require.JSONEq(t, `{"data":{
+		"first":{"id":"1","name":"item-1","score":10},
+		"second":{"__typename":"Item","name":"item-2"},
+		"missing":null,
+		"echo":"aA\n"}}`, got)
+	require.Less(t, strings.Index(got, `"first"`), strings.Index(got, `"second"`), "fields keep query order")
+	require.Empty(t, calls)
+}
+
+func TestExecute_LoaderBatchesLevel(t *testing.T) {
+	var calls [][]string
+	owners := newOwners(&calls)
+	s := testSchema(4, owners)
+
+	got := execJSON(t, s, Request{Query: `{ items { id owner { name } children(first: 1) { owner { id } } } }`}, Options{})
+
+	require.Equal(t, 1, owners.Batches(), "all owners of a level in one batch")
+	require.Equal(t, [][]string{{"u0", "u1"}}, calls, "duplicate keys are fetched once")
+	require.Contains(t, got, `{"id":"0","owner":{"name":"U0"},"children":[{"owner":{"id":"u0"}}]}`)
+	require.Contains(t, got, `{"id":"1","owner":null,`)
+}
+
+func TestExecute_FieldErrorIsPartial(t *testing.T) {
+	var calls [][]string
+	s := testSchema(1, newOwners(&calls))
+
+	resp, err := s.Execute(context.Background(), Request{Query: `{ item(id: "0") { id broken } }`}, Options{
+		FormatError: func(_ context.Context, err error) *Error {
+			return &Error{Message: "internal error", Extensions: map[string]any{"code": "internal"}}
+		},
+	})
+	require.NoError(t, err)
+
+	b, _ := json.Marshal(resp)
+	require.JSONEq(t, `{
+		"data":{"item":{"id":"0","broken":null}},
+		"errors":[{"message":"internal error","locations":[{"line":1,"column":22}],"path":["item","broken"],"extensions":{"code":"internal"}}]
+	}`, string(b))
+}
+
+func TestExecute_RequestErrors(t *testing.T) {
+	var calls [][]string
+	s := testSchema(1, newOwners(&calls))
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`{ items { id }`, "unexpected end of document"},
+		{`{ echo(s: "x) }`, "unterminated string"},
+		{`{ nope }`, `cannot query field "nope" on type Query`},
+		{`{ item { id } }`, `argument "id" is required`},
+		{`{ items(first: "x") { id } }`, "expected Int"},
+		{`{ items }`, "must have a selection"},
+		{`{ echo { id } }`, "has no subfields"},
+		{`{ items { ...A } } fragment A on Item { ...B } fragment B on Item { ...A }`, "spreads itself"},
+		{`{ items { ...U } } fragment U on User { id }`, "cannot be spread on Item"},
+		{`mutation { echo }`, "mutation operations are not supported"},
+		{`query A { echo } query B { echo }`, "operationName is required"},
+		{`query($n: Int!) { items(first: $n) { id } }`, "variable $n is required"},
+	}
+
+	for _, tt := range tests {
+		_, err := s.Execute(context.Background(), Request{Query: tt.query}, Options{})
+
+		var reqErr *RequestError
+		require.ErrorAs(t, err, &reqErr, tt.query)
+		require.ErrorContains(t, err, tt.want, tt.query)
+	}
+}
+
+func TestExecute_Limits(t *testing.T) {
+	var calls [][]string
+	s := testSchema(2, newOwners(&calls))
+	ctx := context.Background()
+
+	// Глубина: items(1) -> children(2) -> owner(3) -> name(4).
+	q := `{ items { children { owner { name } } } }`
+	_, err := s.Execute(ctx, Request{Query: q}, Options{MaxDepth: 3})
+	require.ErrorContains(t, err, "query depth 4 exceeds limit 3")
+	_, err = s.Execute(ctx, Request{Query: q}, Options{MaxDepth: 4})
+	require.NoError(t, err)
+
+	// Сложность: items(1 + 5*(id 1 + children(1 + 2*id 1))) = 1 + 5*(1+3) = 21.
+	q = `{ items(first: 5) { id children { id } } }`
+	_, err = s.Execute(ctx, Request{Query: q}, Options{MaxComplexity: 20})
+	require.ErrorContains(t, err, "query complexity 21 exceeds limit 20")
+	_, err = s.Execute(ctx, Request{Query: q}, Options{MaxComplexity: 21})
+	require.NoError(t, err)
+
+	// Огромные страницы не переполняют счётчик.
+	q = `{ items(first: 2000000000) { children(first: 2000000000) { children(first: 2000000000) { id } } } }`
+	_, err = s.Execute(ctx, Request{Query: q}, Options{MaxComplexity: 1000})
+	require.ErrorContains(t, err, "exceeds limit 1000")
+}
+
+func TestExecute_NestedFragmentsAreLinear(t *testing.T) {
+	var calls [][]string
+	s := testSchema(2, newOwners(&calls))
+
+	// F<i> раскрывает F<i-1> дважды: без кеша фрагментов — 2^n обходов.
+	const n = 64
+	var b strings.Builder
+	fmt.Fprintf(&b, "{ items { ...F%d } } fragment F0 on Item { id }", n)
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, " fragment F%d on Item { ...F%d children { ...F%d } }", i, i-1, i-1)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.Execute(context.Background(), Request{Query: b.String()}, Options{MaxComplexity: 5000})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorContains(t, err, "exceeds limit 5000")
+	case <-time.After(5 * time.Second):
+		t.Fatal("validation of nested fragments is not linear")
+	}
+
+	// Без лимитов кеш фрагментов тоже не даёт проверке расти экспоненциально.
+	q := "{ items { ...G40 } } fragment G0 on Item { id }"
+	for i := 1; i <= 40; i++ {
+		q += fmt.Sprintf(" fragment G%d on Item { ...G%d ...G%d }", i, i-1, i-1)
+	}
+	got := execJSON(t, s, Request{Query: q}, Options{})
+	require.JSONEq(t, `{"data":{"items":[{"id":"0"},{"id":"1"}]}}`, got)
+}
+
+func TestExecute_IntrospectionType(t *testing.T) {
+	var calls [][]string
+	s := testSchema(1, newOwners(&calls))
+
+	got := execJSON(t, s, Request{Query: `{
+		__type(name: "Query") { kind name fields { name args { name defaultValue type { kind name ofType { name } } } type { kind ofType { name } } } }
+		user: __type(name: "User") { fields { name type { name } } }
+		none: __type(name: "Nope") { name }
+	}`}, Options{})
+
+	require.JSONEq(t, `{"data":{
+		"__type":{"kind":"OBJECT","name":"Query","fields":[
+			{"name":"echo","args":[{"name":"s","defaultValue":null,"type":{"kind":"SCALAR","name":"String","ofType":null}}],"type":{"kind":"SCALAR","ofType":null}},
+			{"name":"item","args":[{"name":"id","defaultValue":null,"type":{"kind":"NON_NULL","name":null,"ofType":{"name":"ID"}}}],"type":{"kind":"OBJECT","ofType":null}},
+			{"name":"items","args":[{"name":"first","defaultValue":"10","type":{"kind":"SCALAR","name":"Int","ofType":null}}],"type":{"kind":"LIST","ofType":{"name":"Item"}}}]},
+		"user":{"fields":[{"name":"id","type":{"name":"ID"}},{"name":"name","type":{"name":"String"}}]},
+		"none":null}}`, got)
+}
+
+// introspectionQuery — запрос схемы, который шлют GraphiQL и генераторы клиентов.
+const introspectionQuery = `
+	query IntrospectionQuery {
+		__schema {
+			queryType { name }
+			mutationType { name }
+			subscriptionType { name }
+			types { ...FullType }
+			directives { name description locations args { ...InputValue } }
+		}
+	}
+	fragment FullType on __Type {
+		kind name description
+		fields(includeDeprecated: true) {
+			name description
+			args { ...InputValue }
+			type { ...TypeRef }
+			isDeprecated deprecationReason
+		}
+		inputFields { ...InputValue }
+		interfaces { ...TypeRef }
+		enumValues(includeDeprecated: true) { name description isDeprecated deprecationReason }
+		possibleTypes { ...TypeRef }
+	}
+	fragment InputValue on __InputValue { name description type { ...TypeRef } defaultValue }
+	fragment TypeRef on __Type {
+		kind name
+		ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } } } }
+	}`
+
+func TestExecute_IntrospectionQuery(t *testing.T) {
+	var calls [][]string
+	s := testSchema(1, newOwners(&calls))
+
+	// ofType не увеличивает глубину: стандартный запрос укладывается в лимиты гейтвея.
+	resp, err := s.Execute(context.Background(), Request{Query: introspectionQuery}, Options{MaxDepth: 8, MaxComplexity: 5000})
+	require.NoError(t, err)
+	require.Empty(t, resp.Errors)
+
+	b, err := json.Marshal(resp)
+	require.NoError(t, err)
+
+	var out struct {
+		Data struct {
+			Schema struct {
+				QueryType struct{ Name string }
+				Types     []struct {
+					Kind, Name string
+					Fields     []struct{ Name string }
+				}
+				Directives []struct{ Name string }
+			} `json:"__schema"`
+		}
+	}
+	require.NoError(t, json.Unmarshal(b, &out))
+
+	var names, queryFields []string
+	for _, typ := range out.Data.Schema.Types {
+		names = append(names, typ.Name)
+		if typ.Name == "Query" {
+			for _, f := range typ.Fields {
+				queryFields = append(queryFields, f.Name)
+			}
+		}
+	}
+	require.Equal(t, "Query", out.Data.Schema.QueryType.Name)
+	require.Equal(t, []string{"Boolean", "Float", "ID", "Int", "Item", "Query", "String", "User"}, names)
+	require.Equal(t, []string{"echo", "item", "items"}, queryFields, "__schema/__type are not listed")
+	require.Len(t, out.Data.Schema.Directives, 2)
+
+	// Рекурсия через fields/type ограничена глубиной, как и обычный выбор.
+	_, err = s.Execute(context.Background(), Request{Query: `{ __schema { types { fields { type { fields { type { fields { type { name } } } } } } } } }`}, Options{MaxDepth: 8})
+	require.ErrorContains(t, err, "query depth 9 exceeds limit 8")
+
+	_, err = s.Execute(context.Background(), Request{Query: `{ __type(name: "Item") { nope } }`}, Options{})
+	require.ErrorContains(t, err, `cannot query field "nope" on type __Type`)
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		`{ items { id } }`,
+		`query Q($id: ID!, $n: Int = 2) { item(id: $id) { ...F children(first: $n) @skip(if: false) { id } } } fragment F on Item { name }`,
+		`{ echo(s: "\u0041\n\"x\"") a: echo(s: """block
+		"""), ... on Query { __typename } }`,
+		`{ items(first: -1.5e3) { id } }`,
+		`{ items(first: [1, {a: null}, ENUM]) { id } }`,
+		`mutation { echo }`,
+		`{ ...A } fragment A on Query { ...A }`,
+		introspectionQuery,
+	} {
+		f.Add(seed)
+	}
+
+	var calls [][]string
+	s := testSchema(3, newOwners(&calls))
+
+	f.Fuzz(func(t *testing.T, query string) {
+		doc, err := parse(query)
+		if err != nil {
+			require.Nil(t, doc)
+			return
+		}
+		require.NotEmpty(t, doc.operations)
+
+		// Разобранный документ проходит проверку и исполнение без паники.
+		_, _ = s.Execute(context.Background(), Request{Query: query}, Options{MaxDepth: 10, MaxComplexity: 1000})
+	})
+}
@@ -0,0 +1,260 @@
+package graphql
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"sync"
+)
+
+// Интроспекция: __schema и __type(name:) на корневом типе.
+//
+// Описываются типы, достижимые из Query, и встроенные скаляры; сами
+// __-типы в types не входят (клиенты вроде GraphiQL подставляют свои).
+// Поля схемы всегда nullable, обязательные аргументы — NON_NULL.
+// Описаний, enum, input-типов и интерфейсов в схеме нет — эти поля пусты.
+// Интроспекция проходит те же проверки и лимиты, что и обычный запрос;
+// только ofType не увеличивает глубину — цепочка обёрток конечна.
+
+// nonNull — обязательный аргумент в интроспекции.
+type nonNull struct {
+	of Type
+}
+
+func (n nonNull) typeName() string { return n.of.typeName() + "!" }
+
+// introField — источник __Field.
+type introField struct {
+	name string
+	def  *Field
+}
+
+// introInput — источник __InputValue.
+type introInput struct {
+	name string
+	arg  *Arg
+}
+
+// introDirective — источник __Directive.
+type introDirective struct {
+	name      string
+	locations []string
+	args      []introInput
+}
+
+// directives — поддерживаемые директивы: @skip и @include.
+var directives = []introDirective{
+	{name: "include", locations: []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"}, args: []introInput{{name: "if", arg: &Arg{Type: Boolean, Required: true}}}},
+	{name: "skip", locations: []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"}, args: []introInput{{name: "if", arg: &Arg{Type: Boolean, Required: true}}}},
+}
+
+// introspection — корневой тип с __schema/__type и именованные типы схемы.
+type introspection struct {
+	once  sync.Once
+	root  *Object
+	types map[string]Type
+}
+
+// root — Query с полями интроспекции; строится при первом запросе,
+// поэтому поля Query нужно добавить до первого Execute.
+func (s *Schema) root() *Object {
+	s.intro.once.Do(func() {
+		s.intro.types = namedTypes(s.Query)
+
+		root := &Object{Name: s.Query.Name, Fields: make(map[string]*Field, len(s.Query.Fields)+2)}
+		for name, f := range s.Query.Fields {
+			root.Fields[name] = f
+		}
+		root.Fields["__schema"] = &Field{
+			Type:    introSchema,
+			Resolve: func(Params) (any, error) { return s, nil },
+		}
+		root.Fields["__type"] = &Field{
+			Type: introType,
+			Args: map[string]*Arg{"name": {Type: String, Required: true}},
+			Resolve: func(p Params) (any, error) {
+				return s.intro.types[p.String("name")], nil
+			},
+		}
+		s.intro.root = root
+	})
+
+	return s.intro.root
+}
+
+// namedTypes — объекты и скаляры, достижимые из query, и встроенные скаляры.
+func namedTypes(query *Object) map[string]Type {
+	out := make(map[string]Type)
+	for _, sc := range []*Scalar{ID, String, Int, Float, Boolean} {
+		out[sc.Name] = sc
+	}
+
+	var walk func(t Type)
+	walk = func(t Type) {
+		switch t := baseType(t).(type) {
+		case *Scalar:
+			out[t.Name] = t
+		case *Object:
+			if _, ok := out[t.Name]; ok {
+				return
+			}
+			out[t.Name] = t
+			for _, f := range t.Fields {
+				walk(f.Type)
+				for _, a := range f.Args {
+					walk(a.Type)
+				}
+			}
+		}
+	}
+	walk(query)
+
+	return out
+}
+
+// Типы интроспекции. kind и locations — строки (enum в движке нет).
+var (
+	introSchema    = &Object{Name: "__Schema"}
+	introType      = &Object{Name: "__Type"}
+	introFieldType = &Object{Name: "__Field"}
+	introInputType = &Object{Name: "__InputValue"}
+	introEnumValue = &Object{Name: "__EnumValue"}
+	introDirType   = &Object{Name: "__Directive"}
+)
+
+func init() {
+	null := func(Params) (any, error) { return nil, nil }
+	includeDeprecated := map[string]*Arg{"includeDeprecated": {Type: Boolean, Default: false}}
+
+	introSchema.Fields = map[string]*Field{
+		"description": {Type: String, Resolve: null},
+		"types": {Type: ListOf(introType), Resolve: func(p Params) (any, error) {
+			s := p.Source.(*Schema)
+			types := make([]Type, 0, len(s.intro.types))
+			for _, t := range s.intro.types {
+				types = append(types, t)
+			}
+			slices.SortFunc(types, func(a, b Type) int { return cmp.Compare(a.typeName(), b.typeName()) })
+			return types, nil
+		}},
+		"queryType":        {Type: introType, Resolve: func(p Params) (any, error) { return p.Source.(*Schema).Query, nil }},
+		"mutationType":     {Type: introType, Resolve: null},
+		"subscriptionType": {Type: introType, Resolve: null},
+		"directives":       {Type: ListOf(introDirType), Resolve: func(Params) (any, error) { return directives, nil }},
+	}
+
+	introType.Fields = map[string]*Field{
+		"kind": {Type: String, Resolve: func(p Params) (any, error) {
+			switch p.Source.(type) {
+			case *Scalar:
+				return "SCALAR", nil
+			case *Object:
+				return "OBJECT", nil
+			case list:
+				return "LIST", nil
+			default:
+				return "NON_NULL", nil
+			}
+		}},
+		"name": {Type: String, Resolve: func(p Params) (any, error) {
+			switch t := p.Source.(type) {
+			case *Scalar:
+				return t.Name, nil
+			case *Object:
+				return t.Name, nil
+			}
+			return nil, nil
+		}},
+		"description":    {Type: String, Resolve: null},
+		"specifiedByURL": {Type: String, Resolve: null},
+		"fields": {Type: ListOf(introFieldType), Args: includeDeprecated, Resolve: func(p Params) (any, error) {
+			obj, ok := p.Source.(*Object)
+			if !ok {
+				return nil, nil
+			}
+			fields := make([]introField, 0, len(obj.Fields))
+			for name, def := range obj.Fields {
+				fields = append(fields, introField{name: name, def: def})
+			}
+			slices.SortFunc(fields, func(a, b introField) int { return cmp.Compare(a.name, b.name) })
+			return fields, nil
+		}},
+		"interfaces": {Type: ListOf(introType), Resolve: func(p Params) (any, error) {
+			if _, ok := p.Source.(*Object); ok {
+				return []Type{}, nil
+			}
+			return nil, nil
+		}},
+		"possibleTypes": {Type: ListOf(introType), Resolve: null},
+		"enumValues":    {Type: ListOf(introEnumValue), Args: includeDeprecated, Resolve: null},
+		"inputFields":   {Type: ListOf(introInputType), Args: includeDeprecated, Resolve: null},
+		"isOneOf":       {Type: Boolean, Resolve: null},
+		"ofType": {Type: introType, flat: true, Resolve: func(p Params) (any, error) {
+			switch t := p.Source.(type) {
+			case list:
+				return t.of, nil
+			case nonNull:
+				return t.of, nil
+			}
+			return nil, nil
+		}},
+	}
+
+	introFieldType.Fields = map[string]*Field{
+		"name":        {Type: String, Resolve: func(p Params) (any, error) { return p.Source.(*introField).name, nil }},
+		"description": {Type: String, Resolve: null},
+		"args": {Type: ListOf(introInputType), Args: includeDeprecated, Resolve: func(p Params) (any, error) {
+			def := p.Source.(*introField).def
+			args := make([]introInput, 0, len(def.Args))
+			for name, a := range def.Args {
+				args = append(args, introInput{name: name, arg: a})
+			}
+			slices.SortFunc(args, func(a, b introInput) int { return cmp.Compare(a.name, b.name) })
+			return args, nil
+		}},
+		"type":              {Type: introType, Resolve: func(p Params) (any, error) { return p.Source.(*introField).def.Type, nil }},
+		"isDeprecated":      {Type: Boolean, Resolve: func(Params) (any, error) { return false, nil }},
+		"deprecationReason": {Type: String, Resolve: null},
+	}
+
+	introInputType.Fields = map[string]*Field{
+		"name":        {Type: String, Resolve: func(p Params) (any, error) { return p.Source.(*introInput).name, nil }},
+		"description": {Type: String, Resolve: null},
+		"type": {Type: introType, Resolve: func(p Params) (any, error) {
+			a := p.Source.(*introInput).arg
+			if a.Required {
+				return nonNull{of: a.Type}, nil
+			}
+			return a.Type, nil
+		}},
+		"defaultValue": {Type: String, Resolve: func(p Params) (any, error) {
+			a := p.Source.(*introInput).arg
+			if a.Default == nil {
+				return nil, nil
+			}
+			// Литералы скаляров — Int, Float, Boolean и строки — в GraphQL и JSON совпадают.
+			b, err := json.Marshal(a.Default)
+			if err != nil {
+				return nil, err
+			}
+			return string(b), nil
+		}},
+		"isDeprecated":      {Type: Boolean, Resolve: func(Params) (any, error) { return false, nil }},
+		"deprecationReason": {Type: String, Resolve: null},
+	}
+
+	introEnumValue.Fields = map[string]*Field{
+		"name":              {Type: String, Resolve: null},
+		"description":       {Type: String, Resolve: null},
+		"isDeprecated":      {Type: Boolean, Resolve: null},
+		"deprecationReason": {Type: String, Resolve: null},
+	}
+
+	introDirType.Fields = map[string]*Field{
+		"name":         {Type: String, Resolve: func(p Params) (any, error) { return p.Source.(*introDirective).name, nil }},
+		"description":  {Type: String, Resolve: null},
+		"locations":    {Type: ListOf(String), Resolve: func(p Params) (any, error) { return p.Source.(*introDirective).locations, nil }},
+		"args":         {Type: ListOf(introInputType), Args: includeDeprecated, Resolve: func(p Params) (any, error) { return p.Source.(*introDirective).args, nil }},
+		"isRepeatable": {Type: Boolean, Resolve: func(Params) (any, error) { return false, nil }},
+	}
+}
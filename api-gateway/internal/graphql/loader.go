@@ -0,0 +1,88 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+// Loader — пакетная загрузка по ключам в пределах одного запроса (dataloader).
+//
+// Load только запоминает ключ и возвращает Thunk; первый вычисленный Thunk
+// забирает все накопленные ключи одним вызовом fetch. Исполнитель вычисляет
+// Thunk после вызова всех резолверов уровня, поэтому ключи уровня уходят
+// одной пачкой. Результаты (и ошибки) кэшируются до конца запроса.
+type Loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	entries map[K]*loaderEntry[V]
+	batches int
+}
+
+type loaderEntry[V any] struct {
+	val   V
+	found bool
+	err   error
+}
+
+// NewLoader создаёт Loader. fetch возвращает найденные значения; отсутствующий
+// ключ — null в ответе, ошибка — ошибка всех полей пачки.
+func NewLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{fetch: fetch, entries: make(map[K]*loaderEntry[V])}
+}
+
+// Load — отложенное значение по ключу.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) Thunk {
+	l.mu.Lock()
+	if _, ok := l.entries[key]; !ok {
+		l.entries[key] = nil
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.dispatch(ctx)
+
+		en := l.entries[key]
+		if en.err != nil {
+			return nil, en.err
+		}
+		if !en.found {
+			return nil, nil
+		}
+
+		return en.val, nil
+	}
+}
+
+// Batches — число вызовов fetch.
+func (l *Loader[K, V]) Batches() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.batches
+}
+
+// dispatch забирает накопленные ключи одним вызовом fetch (под l.mu).
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	if len(l.pending) == 0 {
+		return
+	}
+
+	keys := l.pending
+	l.pending = nil
+	l.batches++
+
+	vals, err := l.fetch(ctx, keys)
+	for _, k := range keys {
+		en := &loaderEntry[V]{err: err}
+		if err == nil {
+			en.val, en.found = vals[k]
+		}
+		l.entries[k] = en
+	}
+}
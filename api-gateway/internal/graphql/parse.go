@@ -0,0 +1,723 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Документ запроса (подмножество GraphQL, достаточное для чтения):
+// операции query/mutation, переменные со значениями по умолчанию, алиасы,
+// аргументы, фрагменты (именованные и inline), директивы @skip/@include.
+// Типы переменных разбираются, но приводятся по типам аргументов схемы.
+
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind      string // query | mutation | subscription
+	name      string
+	variables []*variableDef
+	sel       []selection
+}
+
+type variableDef struct {
+	name     string
+	nonNull  bool
+	defValue *value
+}
+
+type fragment struct {
+	name string
+	on   string
+	sel  []selection
+}
+
+// selection — поле, ...Fragment или ... on Type { }.
+type selection struct {
+	// Поле.
+	alias, name string
+	args        []argument
+	sel         []selection
+	// Фрагменты: spread — имя фрагмента; inline — on и sel.
+	spread string
+	inline bool
+	on     string
+
+	directives []directive
+	line       int
+	column     int
+}
+
+type argument struct {
+	name string
+	val  value
+}
+
+type directive struct {
+	name string
+	args []argument
+}
+
+type valueKind int
+
+const (
+	valueVariable valueKind = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBoolean
+	valueNull
+	valueEnum
+	valueList
+	valueObject
+)
+
+type value struct {
+	kind   valueKind
+	raw    string // имя переменной, литерал или enum
+	list   []value
+	fields []argument
+}
+
+// responseKey — ключ поля в ответе: алиас или имя.
+func (s *selection) responseKey() string {
+	if s.alias != "" {
+		return s.alias
+	}
+
+	return s.name
+}
+
+// parse разбирает документ запроса.
+func parse(src string) (*document, error) {
+	p := &parser{lex: lexer{src: src, line: 1, col: 1}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: make(map[string]*fragment)}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.tok.kind == tokPunct && p.tok.val == "{":
+			sel, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", sel: sel})
+		case p.tok.kind == tokName && p.tok.val == "fragment":
+			f, err := p.fragmentDefinition()
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := doc.fragments[f.name]; dup {
+				return nil, p.errorf("duplicate fragment %q", f.name)
+			}
+			doc.fragments[f.name] = f
+		case p.tok.kind == tokName && (p.tok.val == "query" || p.tok.val == "mutation" || p.tok.val == "subscription"):
+			op, err := p.operationDefinition()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, fmt.Errorf("document has no operations")
+	}
+
+	return doc, nil
+}
+
+type parser struct {
+	lex lexer
+	tok token
+}
+
+func (p *parser) next() error {
+	t, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = t
+
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("syntax error at %d:%d: %s", p.tok.line, p.tok.col, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokEOF {
+		return p.errorf("unexpected end of document")
+	}
+
+	return p.errorf("unexpected %q", p.tok.val)
+}
+
+func (p *parser) isPunct(v string) bool {
+	return p.tok.kind == tokPunct && p.tok.val == v
+}
+
+func (p *parser) expectPunct(v string) error {
+	if !p.isPunct(v) {
+		return p.unexpected()
+	}
+
+	return p.next()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokName {
+		return "", p.unexpected()
+	}
+	v := p.tok.val
+
+	return v, p.next()
+}
+
+func (p *parser) operationDefinition() (*operation, error) {
+	op := &operation{kind: p.tok.val}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokName {
+		op.name = p.tok.val
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.isPunct("(") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for !p.isPunct(")") {
+			v, err := p.variableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = append(op.variables, v)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+
+	sel, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.sel = sel
+
+	return op, nil
+}
+
+func (p *parser) variableDefinition() (*variableDef, error) {
+	if err := p.expectPunct("$"); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct(":"); err != nil {
+		return nil, err
+	}
+
+	v := &variableDef{name: name}
+	if v.nonNull, err = p.typeRef(); err != nil {
+		return nil, err
+	}
+
+	if p.isPunct("=") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		def, err := p.value(true)
+		if err != nil {
+			return nil, err
+		}
+		v.defValue = &def
+	}
+
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// typeRef пропускает ссылку на тип (Name, [Type], Type!); nonNull — внешний "!".
+func (p *parser) typeRef() (nonNull bool, err error) {
+	if p.isPunct("[") {
+		if err := p.next(); err != nil {
+			return false, err
+		}
+		if _, err := p.typeRef(); err != nil {
+			return false, err
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return false, err
+		}
+	} else if _, err := p.name(); err != nil {
+		return false, err
+	}
+
+	if p.isPunct("!") {
+		return true, p.next()
+	}
+
+	return false, nil
+}
+
+func (p *parser) fragmentDefinition() (*fragment, error) {
+	f := &fragment{}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if f.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if f.name == "on" {
+		return nil, p.errorf("fragment cannot be named \"on\"")
+	}
+	if p.tok.kind != tokName || p.tok.val != "on" {
+		return nil, p.unexpected()
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if f.on, err = p.name(); err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	if f.sel, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+
+	var out []selection
+	for !p.isPunct("}") {
+		s, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	if len(out) == 0 {
+		return nil, p.errorf("empty selection set")
+	}
+
+	return out, p.next()
+}
+
+func (p *parser) selection() (selection, error) {
+	s := selection{line: p.tok.line, column: p.tok.col}
+	var err error
+
+	if p.isPunct("...") {
+		if err := p.next(); err != nil {
+			return s, err
+		}
+
+		switch {
+		case p.tok.kind == tokName && p.tok.val == "on":
+			if err := p.next(); err != nil {
+				return s, err
+			}
+			if s.on, err = p.name(); err != nil {
+				return s, err
+			}
+			s.inline = true
+		case p.tok.kind == tokName:
+			s.spread = p.tok.val
+			if err := p.next(); err != nil {
+				return s, err
+			}
+			s.directives, err = p.directives()
+			return s, err
+		default:
+			s.inline = true
+		}
+
+		if s.directives, err = p.directives(); err != nil {
+			return s, err
+		}
+		s.sel, err = p.selectionSet()
+		return s, err
+	}
+
+	if s.name, err = p.name(); err != nil {
+		return s, err
+	}
+	if p.isPunct(":") {
+		if err := p.next(); err != nil {
+			return s, err
+		}
+		s.alias = s.name
+		if s.name, err = p.name(); err != nil {
+			return s, err
+		}
+	}
+
+	if s.args, err = p.arguments(false); err != nil {
+		return s, err
+	}
+	if s.directives, err = p.directives(); err != nil {
+		return s, err
+	}
+	if p.isPunct("{") {
+		if s.sel, err = p.selectionSet(); err != nil {
+			return s, err
+		}
+	}
+
+	return s, nil
+}
+
+func (p *parser) arguments(constant bool) ([]argument, error) {
+	if !p.isPunct("(") {
+		return nil, nil
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	var out []argument
+	for !p.isPunct(")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		v, err := p.value(constant)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, argument{name: name, val: v})
+	}
+
+	return out, p.next()
+}
+
+func (p *parser) directives() ([]directive, error) {
+	var out []directive
+	for p.isPunct("@") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.arguments(false)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, directive{name: name, args: args})
+	}
+
+	return out, nil
+}
+
+// value — литерал или переменная; constant запрещает переменные (значения по умолчанию).
+func (p *parser) value(constant bool) (value, error) {
+	t := p.tok
+	switch {
+	case t.kind == tokPunct && t.val == "$" && !constant:
+		if err := p.next(); err != nil {
+			return value{}, err
+		}
+		name, err := p.name()
+		return value{kind: valueVariable, raw: name}, err
+	case t.kind == tokInt:
+		return value{kind: valueInt, raw: t.val}, p.next()
+	case t.kind == tokFloat:
+		return value{kind: valueFloat, raw: t.val}, p.next()
+	case t.kind == tokString:
+		return value{kind: valueString, raw: t.val}, p.next()
+	case t.kind == tokName:
+		v := value{kind: valueEnum, raw: t.val}
+		switch t.val {
+		case "true", "false":
+			v.kind = valueBoolean
+		case "null":
+			v.kind = valueNull
+		}
+		return v, p.next()
+	case t.kind == tokPunct && t.val == "[":
+		if err := p.next(); err != nil {
+			return value{}, err
+		}
+		v := value{kind: valueList}
+		for !p.isPunct("]") {
+			item, err := p.value(constant)
+			if err != nil {
+				return value{}, err
+			}
+			v.list = append(v.list, item)
+		}
+		return v, p.next()
+	case t.kind == tokPunct && t.val == "{":
+		if err := p.next(); err != nil {
+			return value{}, err
+		}
+		v := value{kind: valueObject}
+		for !p.isPunct("}") {
+			name, err := p.name()
+			if err != nil {
+				return value{}, err
+			}
+			if err := p.expectPunct(":"); err != nil {
+				return value{}, err
+			}
+			item, err := p.value(constant)
+			if err != nil {
+				return value{}, err
+			}
+			v.fields = append(v.fields, argument{name: name, val: item})
+		}
+		return v, p.next()
+	default:
+		return value{}, p.unexpected()
+	}
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind      tokenKind
+	val       string
+	line, col int
+}
+
+// lexer — лексер GraphQL: запятые и комментарии незначимы.
+type lexer struct {
+	src       string
+	pos       int
+	line, col int
+}
+
+func (l *lexer) errorf(format string, args ...any) error {
+	return fmt.Errorf("syntax error at %d:%d: %s", l.line, l.col, fmt.Sprintf(format, args...))
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	// Пропуск незначимого: пробелы, переводы строк, запятые, BOM, комментарии.
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			goto token
+		}
+	}
+
+	return token{kind: tokEOF, line: l.line, col: l.col}, nil
+
+token:
+	t := token{line: l.line, col: l.col}
+	c := l.src[l.pos]
+
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		t.kind, t.val = tokPunct, "..."
+		l.advance(3)
+	case strings.IndexByte("!$&()[]{}:=@|", c) >= 0:
+		t.kind, t.val = tokPunct, string(c)
+		l.advance(1)
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		t.kind, t.val = tokName, l.src[start:l.pos]
+	case c == '-' || isDigit(c):
+		return l.number(t)
+	case c == '"':
+		return l.string(t)
+	default:
+		r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+		return t, l.errorf("unexpected character %q", r)
+	}
+
+	return t, nil
+}
+
+func (l *lexer) number(t token) (token, error) {
+	start := l.pos
+	t.kind = tokInt
+
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+	if !l.digits() {
+		return t, l.errorf("invalid number")
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		t.kind = tokFloat
+		l.advance(1)
+		if !l.digits() {
+			return t, l.errorf("invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		t.kind = tokFloat
+		l.advance(1)
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		if !l.digits() {
+			return t, l.errorf("invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos])) {
+		return t, l.errorf("invalid number")
+	}
+
+	t.val = l.src[start:l.pos]
+
+	return t, nil
+}
+
+func (l *lexer) digits() bool {
+	start := l.pos
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.advance(1)
+	}
+
+	return l.pos > start
+}
+
+func (l *lexer) string(t token) (token, error) {
+	t.kind = tokString
+
+	// Блочная строка: """...""" без экранирования (кроме \""").
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		l.advance(3)
+		end := strings.Index(l.src[l.pos:], `"""`)
+		for end > 0 && l.src[l.pos+end-1] == '\\' {
+			next := strings.Index(l.src[l.pos+end+3:], `"""`)
+			if next < 0 {
+				end = -1
+				break
+			}
+			end += 3 + next
+		}
+		if end < 0 {
+			return t, l.errorf("unterminated string")
+		}
+		t.val = strings.ReplaceAll(l.src[l.pos:l.pos+end], `\"""`, `"""`)
+		l.advance(end + 3)
+		return t, nil
+	}
+
+	l.advance(1)
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+			return t, l.errorf("unterminated string")
+		}
+
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.advance(1)
+			t.val = b.String()
+			return t, nil
+		case c == '\\':
+			if l.pos+1 >= len(l.src) {
+				return t, l.errorf("unterminated string")
+			}
+			esc := l.src[l.pos+1]
+			switch esc {
+			case '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.pos+6 > len(l.src) {
+					return t, l.errorf("invalid unicode escape")
+				}
+				n, err := strconv.ParseUint(l.src[l.pos+2:l.pos+6], 16, 32)
+				if err != nil {
+					return t, l.errorf("invalid unicode escape")
+				}
+				b.WriteRune(rune(n))
+				l.advance(4)
+			default:
+				return t, l.errorf("invalid escape \\%c", esc)
+			}
+			l.advance(2)
+		default:
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			b.WriteRune(r)
+			l.advance(size)
+		}
+	}
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/users"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddBookmark — сохранить новость {news_id} в закладки вызывающего.
+func (h *Handlers) AddBookmark(w http.ResponseWriter, r *http.Request) {
+	h.changeBookmark(w, r, h.Clients.Users.AddBookmark)
+}
+
+// RemoveBookmark — убрать новость {news_id} из закладок вызывающего.
+func (h *Handlers) RemoveBookmark(w http.ResponseWriter, r *http.Request) {
+	h.changeBookmark(w, r, h.Clients.Users.RemoveBookmark)
+}
+
+func (h *Handlers) changeBookmark(
+	w http.ResponseWriter,
+	r *http.Request,
+	call func(ctx context.Context, in *usersv1.BookmarkRequest, opts ...grpc.CallOption) (*usersv1.BookmarkResponse, error),
+) {
+	caller, ok := middleware.CallerFrom(r.Context())
+	if !ok {
+		apierrors.WriteError(w, r, status.Error(codes.Unauthenticated, "unauthenticated"))
+		return
+	}
+
+	newsID := chi.URLParam(r, "news_id")
+	if newsID == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	resp, err := call(r.Context(), &usersv1.BookmarkRequest{UserId: caller.UserID, NewsId: newsID})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.BookmarkResponse{Changed: resp.GetChanged()})
+}
+
+// ListBookmarks — закладки вызывающего с новостями, сначала недавно сохранённые.
+func (h *Handlers) ListBookmarks(w http.ResponseWriter, r *http.Request) {
+	caller, ok := middleware.CallerFrom(r.Context())
+	if !ok {
+		apierrors.WriteError(w, r, status.Error(codes.Unauthenticated, "unauthenticated"))
+		return
+	}
+
+	q := r.URL.Query()
+	req := &usersv1.ListBookmarksRequest{UserId: caller.UserID, PageToken: q.Get("page_token")}
+	if v := q.Get("page_size"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n < 0 {
+			apierrors.WriteError(w, r, statusErrorInvalidArgument())
+			return
+		}
+
+		req.PageSize = int32(n)
+	}
+
+	resp, err := h.Clients.Users.ListBookmarks(r.Context(), req)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.BookmarksFromProto(resp))
+}
@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/users"
 	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
 	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	logctx "github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (h *Handlers) CreateComment(w http.ResponseWriter, r *http.Request) {
@@ -16,16 +21,45 @@ func (h *Handlers) CreateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.Clients.Comments.CreateComment(r.Context(), in.ToProto())
+	if err := bindCaller(r, &in.UserID); err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	username, err := h.authorUsername(r.Context(), in.UserID)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	resp, err := h.Clients.Comments.CreateComment(r.Context(), in.ToProto(username))
 	if err != nil {
 		apierrors.WriteError(w, r, err)
 		return
 	}
 
 	out := models.CreateCommentFromProto(resp)
+	h.mergeAuthor(r, out.Comment)
+
 	writeJSON(w, http.StatusCreated, out)
 }
 
+// authorUsername — username автора комментария из его профиля в users-service.
+// Клиент username не передаёт: иначе мог бы подписаться чужим именем.
+// Нет профиля — FailedPrecondition (сначала нужно создать профиль).
+func (h *Handlers) authorUsername(ctx context.Context, userID string) (string, error) {
+	p, err := h.Clients.Users.ProfileByID(ctx, &usersv1.ProfileByIDRequest{UserId: userID})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return "", status.Error(codes.FailedPrecondition, "profile required to comment")
+		}
+
+		return "", err
+	}
+
+	return p.GetUsername(), nil
+}
+
 func (h *Handlers) GetCommentByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
@@ -39,7 +73,10 @@ func (h *Handlers) GetCommentByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, models.GetCommentFromProto(resp))
+	out := models.GetCommentFromProto(resp)
+	h.mergeAuthor(r, out.Comment)
+
+	writeJSON(w, http.StatusOK, out)
 }
 
 func (h *Handlers) ListRootComments(w http.ResponseWriter, r *http.Request) {
@@ -68,7 +105,10 @@ func (h *Handlers) ListRootComments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, models.ListRootCommentsFromProto(resp))
+	out := models.ListRootCommentsFromProto(resp)
+	h.mergeAuthors(r, out.Comments)
+
+	writeJSON(w, http.StatusOK, out)
 }
 
 func (h *Handlers) ListReplies(w http.ResponseWriter, r *http.Request) {
@@ -97,5 +137,71 @@ func (h *Handlers) ListReplies(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, models.ListRepliesFromProto(resp))
+	out := models.ListRepliesFromProto(resp)
+	h.mergeAuthors(r, out.Comments)
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+// maxProfilesBatch — размер пачки ProfilesByIDs (лимит users-service по умолчанию).
+const maxProfilesBatch = 200
+
+// mergeAuthors дополняет комментарии актуальными display_name/avatar_url авторов
+// (ProfilesByIDs, по одному вызову на maxProfilesBatch уникальных авторов).
+// Как и счётчики в ленте — некритичное обогащение: при ошибке users-service
+// комментарии отдаются без него.
+func (h *Handlers) mergeAuthors(r *http.Request, comments []models.Comment) {
+	_ = h.mergeAuthorsCtx(r.Context(), comments)
+}
+
+// mergeAuthorsCtx — mergeAuthors вне HTTP-запроса (живые ветки /ws);
+// ошибку users-service логирует и возвращает (секция authors в /news/{id}/page).
+func (h *Handlers) mergeAuthorsCtx(ctx context.Context, comments []models.Comment) error {
+	seen := make(map[string]struct{}, len(comments))
+	ids := make([]string, 0, len(comments))
+	for _, c := range comments {
+		if c.UserID == "" {
+			continue
+		}
+
+		if _, dup := seen[c.UserID]; dup {
+			continue
+		}
+
+		seen[c.UserID] = struct{}{}
+		ids = append(ids, c.UserID)
+	}
+
+	for start := 0; start < len(ids); start += maxProfilesBatch {
+		end := min(start+maxProfilesBatch, len(ids))
+
+		resp, err := h.Clients.Users.ProfilesByIDs(ctx, &usersv1.ProfilesByIDsRequest{UserIds: ids[start:end]})
+		if err != nil {
+			logctx.From(ctx).Warn("comment authors unavailable", "err", err)
+			return err
+		}
+
+		models.MergeAuthors(comments, resp)
+	}
+
+	return nil
+}
+
+// mergeAuthor — mergeAuthors для одиночного комментария (nil допустим).
+func (h *Handlers) mergeAuthor(r *http.Request, c *models.Comment) {
+	if c == nil {
+		return
+	}
+
+	one := []models.Comment{*c}
+	h.mergeAuthors(r, one)
+	*c = one[0]
+}
+
+// EnrichComment — mergeAuthor для fanout.Hub: автор дополняется один раз
+// на событие ветки, а не на каждое WebSocket-соединение.
+func (h *Handlers) EnrichComment(ctx context.Context, c *models.Comment) {
+	one := []models.Comment{*c}
+	_ = h.mergeAuthorsCtx(ctx, one)
+	*c = one[0]
 }
@@ -0,0 +1,47 @@
+package handlers
+
+// Тесты создания комментария (comments.go):
+//   - username — из профиля автора; устаревшее поле username в теле принимается
+//     строгим декодером и игнорируется;
+//   - прочие неизвестные поля — 400.
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/clients"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateComment_IgnoresLegacyUsername(t *testing.T) {
+	comments := &wsComments{created: make(chan *commentsv1.CreateCommentRequest, 1)}
+	h := New(&clients.Clients{Comments: comments, Users: fakeUsers{}})
+	srv := httptest.NewServer(middleware.Chain(http.HandlerFunc(h.CreateComment),
+		middleware.Logging(slog.New(slog.DiscardHandler)), middleware.AuthBearer(), middleware.Identity(wsVerifier{}, nil, nil), middleware.OptionalAuth()))
+	t.Cleanup(srv.Close)
+
+	post := func(body string) int {
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/comments", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer good")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	require.Equal(t, http.StatusCreated, post(`{"news_id":"`+wsNewsID+`","username":"mallory","content":"hi"}`))
+	in := <-comments.created
+	require.Equal(t, "u1", in.GetUserId())
+	require.Equal(t, "name-u1", in.GetUsername())
+
+	require.Equal(t, http.StatusBadRequest, post(`{"news_id":"`+wsNewsID+`","nickname":"mallory","content":"hi"}`))
+	require.Empty(t, comments.created)
+}
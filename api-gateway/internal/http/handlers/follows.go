@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/users"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxActivityAuthors — сколько подписок учитывается в ленте активности
+	// (совпадает с лимитом ListByUsers в comments-service).
+	maxActivityAuthors = 500
+	// followingBatch — размер страницы ListFollowing при сборе подписок.
+	followingBatch = 100
+)
+
+// Follow — подписка вызывающего на пользователя {id}.
+func (h *Handlers) Follow(w http.ResponseWriter, r *http.Request) {
+	h.changeFollow(w, r, h.Clients.Users.Follow)
+}
+
+// Unfollow — отписка вызывающего от пользователя {id}.
+func (h *Handlers) Unfollow(w http.ResponseWriter, r *http.Request) {
+	h.changeFollow(w, r, h.Clients.Users.Unfollow)
+}
+
+func (h *Handlers) changeFollow(
+	w http.ResponseWriter,
+	r *http.Request,
+	call func(ctx context.Context, in *usersv1.FollowRequest, opts ...grpc.CallOption) (*usersv1.FollowResponse, error),
+) {
+	caller, ok := middleware.CallerFrom(r.Context())
+	if !ok {
+		apierrors.WriteError(w, r, status.Error(codes.Unauthenticated, "unauthenticated"))
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	resp, err := call(r.Context(), &usersv1.FollowRequest{FollowerId: caller.UserID, FolloweeId: id})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.FollowResponse{Changed: resp.GetChanged()})
+}
+
+// ListFollowers — подписчики пользователя {id}.
+func (h *Handlers) ListFollowers(w http.ResponseWriter, r *http.Request) {
+	h.listFollows(w, r, h.Clients.Users.ListFollowers)
+}
+
+// ListFollowing — подписки пользователя {id}.
+func (h *Handlers) ListFollowing(w http.ResponseWriter, r *http.Request) {
+	h.listFollows(w, r, h.Clients.Users.ListFollowing)
+}
+
+func (h *Handlers) listFollows(
+	w http.ResponseWriter,
+	r *http.Request,
+	call func(ctx context.Context, in *usersv1.ListFollowsRequest, opts ...grpc.CallOption) (*usersv1.ListFollowsResponse, error),
+) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	q := r.URL.Query()
+	req := &usersv1.ListFollowsRequest{UserId: id, PageToken: q.Get("page_token")}
+	if v := q.Get("page_size"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n < 0 {
+			apierrors.WriteError(w, r, statusErrorInvalidArgument())
+			return
+		}
+
+		req.PageSize = int32(n)
+	}
+
+	resp, err := call(r.Context(), req)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.FollowsFromProto(resp))
+}
+
+// MyActivity — свежие комментарии тех, на кого подписан вызывающий, одной лентой
+// (сначала новые). Подписки собираются из users-service на каждый запрос, а
+// курсор — keyset comments-service по (created_at, id): он не зависит от набора
+// подписок и остаётся валидным, даже если между страницами подписки изменились.
+func (h *Handlers) MyActivity(w http.ResponseWriter, r *http.Request) {
+	caller, ok := middleware.CallerFrom(r.Context())
+	if !ok {
+		apierrors.WriteError(w, r, status.Error(codes.Unauthenticated, "unauthenticated"))
+		return
+	}
+
+	q := r.URL.Query()
+	size, _, ok := parsePageAndDeleted(q.Get("page_size"), "")
+	if !ok {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	ids, err := h.followingIDs(r, caller.UserID)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	if len(ids) == 0 {
+		writeJSON(w, http.StatusOK, models.CommentsPageResponse{})
+		return
+	}
+
+	resp, err := h.Clients.Comments.ListByUsers(r.Context(), &commentsv1.ListByUsersRequest{
+		UserIds:   ids,
+		PageSize:  size,
+		PageToken: q.Get("page_token"),
+	})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	out := models.CommentsPageFromProto(resp.GetComments(), resp.GetNextPageToken())
+	h.mergeAuthors(r, out.Comments)
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+// followingIDs собирает id подписок пользователя (не больше maxActivityAuthors,
+// самые свежие подписки первыми).
+func (h *Handlers) followingIDs(r *http.Request, userID string) ([]string, error) {
+	var (
+		ids   []string
+		token string
+	)
+
+	for len(ids) < maxActivityAuthors {
+		resp, err := h.Clients.Users.ListFollowing(r.Context(), &usersv1.ListFollowsRequest{
+			UserId:    userID,
+			PageSize:  followingBatch,
+			PageToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range resp.GetEntries() {
+			if len(ids) == maxActivityAuthors {
+				break
+			}
+
+			ids = append(ids, e.GetProfile().GetUserId())
+		}
+
+		token = resp.GetNextPageToken()
+		if token == "" {
+			break
+		}
+	}
+
+	return ids, nil
+}
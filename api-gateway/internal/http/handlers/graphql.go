@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	newsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/news"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/users"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/graphql"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	logctx "github.com/pribylovaa/go-news-aggregator/pkg/log"
+)
+
+// Значения GraphQLOptions по умолчанию (config.GraphQLConfig).
+const (
+	defaultGraphQLMaxDepth      = 8
+	defaultGraphQLMaxComplexity = 5000
+	defaultGraphQLMaxBodyBytes  = 64 << 10
+)
+
+// Размеры страниц сервисов по умолчанию (limits.default) — множители сложности
+// для полей без явного размера.
+const (
+	defaultNewsPageSize     = 12
+	defaultCommentsPageSize = 20
+)
+
+// maxNewsBatch — размер пачки NewsByIDs (limits.max_batch news-service по умолчанию).
+const maxNewsBatch = 100
+
+// GraphQLOptions — параметры /graphql; нулевые поля — значения по умолчанию.
+type GraphQLOptions struct {
+	// MaxDepth — предельная вложенность полей запроса.
+	MaxDepth int
+	// MaxComplexity — предельная сложность: поле стоит 1, выбор внутри списка
+	// умножается на размер страницы.
+	MaxComplexity int
+	// MaxBodyBytes — предельный размер тела запроса.
+	MaxBodyBytes int64
+}
+
+func (o GraphQLOptions) withDefaults() GraphQLOptions {
+	if o.MaxDepth <= 0 {
+		o.MaxDepth = defaultGraphQLMaxDepth
+	}
+	if o.MaxComplexity <= 0 {
+		o.MaxComplexity = defaultGraphQLMaxComplexity
+	}
+	if o.MaxBodyBytes <= 0 {
+		o.MaxBodyBytes = defaultGraphQLMaxBodyBytes
+	}
+
+	return o
+}
+
+// ExecuteGraphQL — POST /graphql: News, Comment и Profile одним запросом.
+//
+// Авторы комментариев и новости комментариев грузятся загрузчиками запроса:
+// все ключи одного уровня — одним ProfilesByIDs/NewsByIDs. Ошибки полей
+// отдаются рядом с частичными data с extensions.code из apierrors;
+// некорректный запрос (синтаксис, схема, лимиты) — 400 без data.
+func (h *Handlers) ExecuteGraphQL(w http.ResponseWriter, r *http.Request) {
+	opts := h.GraphQL.withDefaults()
+
+	var in models.GraphQLRequest
+	r.Body = http.MaxBytesReader(w, r.Body, opts.MaxBodyBytes)
+	if err := decodeStrict(r, &in); err != nil {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	req := graphql.Request{Query: in.Query, OperationName: in.OperationName, Variables: in.Variables}
+	ctx := context.WithValue(r.Context(), gqlContextKey{}, newGQLRequest(h, r))
+	resp, err := gqlSchema.Execute(ctx, req, graphql.Options{
+		MaxDepth:      opts.MaxDepth,
+		MaxComplexity: opts.MaxComplexity,
+		FormatError: func(_ context.Context, err error) *graphql.Error {
+			return gqlError(r, err)
+		},
+	})
+
+	var reqErr *graphql.RequestError
+	if errors.As(err, &reqErr) {
+		for _, e := range reqErr.Errors {
+			e.Extensions = gqlError(r, statusErrorInvalidArgument()).Extensions
+		}
+		writeJSON(w, http.StatusBadRequest, graphql.Response{Errors: reqErr.Errors})
+		return
+	}
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// gqlError — ошибка поля в терминах apierrors: безопасное message,
+// code и request_id в extensions. Детали апстрима в ответ не попадают.
+func gqlError(r *http.Request, err error) *graphql.Error {
+	_, resp := apierrors.ToHTTP(err)
+
+	ext := map[string]any{"code": resp.Error.Code}
+	if rid := r.Header.Get("X-Request-Id"); rid != "" {
+		ext["request_id"] = rid
+	}
+
+	if resp.Error.Code == "internal" {
+		logctx.From(r.Context()).Error("graphql field failed", "err", err)
+	}
+
+	return &graphql.Error{Message: resp.Error.Message, Extensions: ext}
+}
+
+type gqlContextKey struct{}
+
+// gqlRequest — состояние одного запроса /graphql: клиенты, вызывающий
+// и загрузчики (кэш и пачки живут до конца запроса).
+type gqlRequest struct {
+	h        *Handlers
+	userID   string
+	profiles *graphql.Loader[string, *models.User]
+	news     *graphql.Loader[string, *models.News]
+}
+
+func newGQLRequest(h *Handlers, r *http.Request) *gqlRequest {
+	q := &gqlRequest{h: h}
+	if caller, ok := middleware.CallerFrom(r.Context()); ok {
+		q.userID = caller.UserID
+	}
+
+	q.profiles = graphql.NewLoader(q.fetchProfiles)
+	q.news = graphql.NewLoader(q.fetchNews)
+
+	return q
+}
+
+func gqlFrom(ctx context.Context) *gqlRequest {
+	return ctx.Value(gqlContextKey{}).(*gqlRequest)
+}
+
+// fetchProfiles — профили пачками по maxProfilesBatch.
+func (q *gqlRequest) fetchProfiles(ctx context.Context, ids []string) (map[string]*models.User, error) {
+	out := make(map[string]*models.User, len(ids))
+	for start := 0; start < len(ids); start += maxProfilesBatch {
+		end := min(start+maxProfilesBatch, len(ids))
+
+		resp, err := q.h.Clients.Users.ProfilesByIDs(ctx, &usersv1.ProfilesByIDsRequest{UserIds: ids[start:end]})
+		if err != nil {
+			return nil, err
+		}
+
+		for id, p := range resp.GetProfiles() {
+			u := models.UserFromProto(p)
+			out[id] = &u
+		}
+	}
+
+	return out, nil
+}
+
+// fetchNews — новости пачками по maxNewsBatch (is_read — для вызывающего).
+func (q *gqlRequest) fetchNews(ctx context.Context, ids []string) (map[string]*models.News, error) {
+	out := make(map[string]*models.News, len(ids))
+	for start := 0; start < len(ids); start += maxNewsBatch {
+		end := min(start+maxNewsBatch, len(ids))
+
+		resp, err := q.h.Clients.News.NewsByIDs(ctx, &newsv1.NewsByIDsRequest{Ids: ids[start:end], UserId: q.userID})
+		if err != nil {
+			return nil, err
+		}
+
+		batch := models.NewsBatchFromProto(resp)
+		for i := range batch.Items {
+			out[batch.Items[i].ID] = &batch.Items[i]
+		}
+	}
+
+	return out, nil
+}
+
+// gqlPage — страница connection-поля.
+type gqlPage struct {
+	items         any
+	nextPageToken string
+}
+
+// pageSize — аргумент pageSize/limit: отрицательный — InvalidArgument.
+func pageSize(p graphql.Params, name string) (int32, error) {
+	n := p.Int(name)
+	if n < 0 {
+		return 0, statusErrorInvalidArgument()
+	}
+
+	return int32(n), nil
+}
+
+var gqlSchema = newGQLSchema()
+
+// newGQLSchema — схема /graphql:
+//
+//	type Query {
+//	  news(id: ID!): News
+//	  newsList(limit: Int, pageToken: String): NewsConnection
+//	  comment(id: ID!): Comment
+//	  profile(id: ID!): Profile
+//	}
+//
+// News/Comment/Profile — поля REST-моделей в camelCase плюс связи:
+// News.comments, Comment.replies (страницы), Comment.author, Comment.news.
+func newGQLSchema() *graphql.Schema {
+	profile := &graphql.Object{Name: "Profile", Fields: graphql.StructFields(models.User{}, "avatar_key")}
+	news := &graphql.Object{Name: "News", Fields: graphql.StructFields(models.News{})}
+	// display_name/avatar_url комментария заменяет поле author.
+	comment := &graphql.Object{Name: "Comment", Fields: graphql.StructFields(models.Comment{}, "display_name", "avatar_url")}
+
+	connection := func(name string, of graphql.Type) *graphql.Object {
+		return &graphql.Object{Name: name, Fields: map[string]*graphql.Field{
+			"items": {Type: graphql.ListOf(of), Resolve: func(p graphql.Params) (any, error) {
+				return p.Source.(*gqlPage).items, nil
+			}},
+			"nextPageToken": {Type: graphql.String, Resolve: func(p graphql.Params) (any, error) {
+				return p.Source.(*gqlPage).nextPageToken, nil
+			}},
+		}}
+	}
+	newsConn := connection("NewsConnection", news)
+	commentConn := connection("CommentConnection", comment)
+
+	pageArgs := map[string]*graphql.Arg{
+		"pageSize":  {Type: graphql.Int},
+		"pageToken": {Type: graphql.String},
+	}
+
+	news.Fields["comments"] = &graphql.Field{
+		Type: commentConn, Args: pageArgs,
+		SizeArg: "pageSize", SizeDefault: defaultCommentsPageSize,
+		Resolve: func(p graphql.Params) (any, error) {
+			n, err := pageSize(p, "pageSize")
+			if err != nil {
+				return nil, err
+			}
+
+			req := models.ListRootCommentsRequest{NewsID: p.Source.(*models.News).ID, PageSize: n, PageToken: p.String("pageToken")}
+			resp, err := gqlFrom(p.Ctx).h.Clients.Comments.ListByNews(p.Ctx, req.ToProto())
+			if err != nil {
+				return nil, err
+			}
+
+			out := models.ListRootCommentsFromProto(resp)
+			return &gqlPage{items: out.Comments, nextPageToken: out.NextPageToken}, nil
+		},
+	}
+
+	comment.Fields["replies"] = &graphql.Field{
+		Type: commentConn, Args: pageArgs,
+		SizeArg: "pageSize", SizeDefault: defaultCommentsPageSize,
+		Resolve: func(p graphql.Params) (any, error) {
+			n, err := pageSize(p, "pageSize")
+			if err != nil {
+				return nil, err
+			}
+
+			req := models.ListRepliesRequest{ParentID: p.Source.(*models.Comment).ID, PageSize: n, PageToken: p.String("pageToken")}
+			resp, err := gqlFrom(p.Ctx).h.Clients.Comments.ListReplies(p.Ctx, req.ToProto())
+			if err != nil {
+				return nil, err
+			}
+
+			out := models.ListRepliesFromProto(resp)
+			return &gqlPage{items: out.Comments, nextPageToken: out.NextPageToken}, nil
+		},
+	}
+	comment.Fields["author"] = &graphql.Field{Type: profile, Resolve: func(p graphql.Params) (any, error) {
+		c := p.Source.(*models.Comment)
+		if c.UserID == "" {
+			return nil, nil
+		}
+		return gqlFrom(p.Ctx).profiles.Load(p.Ctx, c.UserID), nil
+	}}
+	comment.Fields["news"] = &graphql.Field{Type: news, Resolve: func(p graphql.Params) (any, error) {
+		return gqlFrom(p.Ctx).news.Load(p.Ctx, p.Source.(*models.Comment).NewsID), nil
+	}}
+
+	idArg := map[string]*graphql.Arg{"id": {Type: graphql.ID, Required: true}}
+	query := &graphql.Object{Name: "Query", Fields: map[string]*graphql.Field{
+		// news — через загрузчик (NewsByIDs): без отметки прочтения, в отличие от GET /news/{id}.
+		"news": {Type: news, Args: idArg, Resolve: func(p graphql.Params) (any, error) {
+			return gqlFrom(p.Ctx).news.Load(p.Ctx, p.String("id")), nil
+		}},
+		"newsList": {
+			Type: newsConn,
+			Args: map[string]*graphql.Arg{
+				"limit":     {Type: graphql.Int},
+				"pageToken": {Type: graphql.String},
+			},
+			SizeArg: "limit", SizeDefault: defaultNewsPageSize,
+			Resolve: func(p graphql.Params) (any, error) {
+				n, err := pageSize(p, "limit")
+				if err != nil {
+					return nil, err
+				}
+
+				q := gqlFrom(p.Ctx)
+				req := models.NewsListRequest{Limit: n, PageToken: p.String("pageToken"), UserID: q.userID}
+				resp, err := q.h.Clients.News.ListNews(p.Ctx, req.ToProto())
+				if err != nil {
+					return nil, err
+				}
+
+				out := models.NewsListFromProto(resp)
+				return &gqlPage{items: out.Items, nextPageToken: out.NextPageToken}, nil
+			},
+		},
+		"comment": {Type: comment, Args: idArg, Resolve: func(p graphql.Params) (any, error) {
+			resp, err := gqlFrom(p.Ctx).h.Clients.Comments.CommentByID(p.Ctx, (&models.GetCommentRequest{ID: p.String("id")}).ToProto())
+			if err != nil {
+				return nil, err
+			}
+			return models.GetCommentFromProto(resp).Comment, nil
+		}},
+		"profile": {Type: profile, Args: idArg, Resolve: func(p graphql.Params) (any, error) {
+			return gqlFrom(p.Ctx).profiles.Load(p.Ctx, p.String("id")), nil
+		}},
+	}}
+
+	return &graphql.Schema{Query: query}
+}
@@ -0,0 +1,195 @@
+package handlers
+
+// Тесты /graphql (graphql.go):
+//   - лента с комментариями и авторами: все авторы страницы — один ProfilesByIDs,
+//     новости комментариев — один NewsByIDs, ненайденный автор — null;
+//   - ошибки апстрима: null и errors с extensions.code из apierrors, без деталей;
+//   - некорректный запрос и превышение сложности — 400 invalid_argument.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	newsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/news"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/users"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/clients"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// gqlNews — две новости в ленте, NewsByIDs со счётчиком вызовов.
+type gqlNews struct {
+	newsv1.NewsServiceClient
+	mu    sync.Mutex
+	batch [][]string
+}
+
+func (f *gqlNews) ListNews(context.Context, *newsv1.ListNewsRequest, ...grpc.CallOption) (*newsv1.ListNewsResponse, error) {
+	return &newsv1.ListNewsResponse{
+		Items:         []*newsv1.News{{Id: "n1", Title: "first"}, {Id: "n2", Title: "second"}},
+		NextPageToken: "next",
+	}, nil
+}
+
+func (f *gqlNews) NewsByIDs(_ context.Context, in *newsv1.NewsByIDsRequest, _ ...grpc.CallOption) (*newsv1.NewsByIDsResponse, error) {
+	f.mu.Lock()
+	f.batch = append(f.batch, in.GetIds())
+	f.mu.Unlock()
+
+	out := &newsv1.NewsByIDsResponse{}
+	for _, id := range in.GetIds() {
+		out.Items = append(out.Items, &newsv1.News{Id: id, Title: "title " + id})
+	}
+	return out, nil
+}
+
+// gqlComments — у каждой новости три корня от u1, u2, u3; CommentByID — NotFound.
+type gqlComments struct {
+	commentsv1.CommentsServiceClient
+}
+
+func (gqlComments) ListByNews(_ context.Context, in *commentsv1.ListByNewsRequest, _ ...grpc.CallOption) (*commentsv1.ListByNewsResponse, error) {
+	out := &commentsv1.ListByNewsResponse{}
+	for i := 1; i <= 3; i++ {
+		out.Comments = append(out.Comments, &commentsv1.Comment{
+			Id:      fmt.Sprintf("%s-c%d", in.GetNewsId(), i),
+			NewsId:  in.GetNewsId(),
+			UserId:  fmt.Sprintf("u%d", i),
+			Content: "hello",
+		})
+	}
+	return out, nil
+}
+
+func (gqlComments) CommentByID(context.Context, *commentsv1.CommentByIDRequest, ...grpc.CallOption) (*commentsv1.CommentByIDResponse, error) {
+	return nil, status.Error(codes.NotFound, "comment 42 not found in collection comments")
+}
+
+// gqlUsers — профили u1 и u2 (u3 не найден), запоминает пачки.
+type gqlUsers struct {
+	usersv1.UsersServiceClient
+	mu    sync.Mutex
+	batch [][]string
+}
+
+func (f *gqlUsers) ProfilesByIDs(_ context.Context, in *usersv1.ProfilesByIDsRequest, _ ...grpc.CallOption) (*usersv1.ProfilesByIDsResponse, error) {
+	f.mu.Lock()
+	f.batch = append(f.batch, in.GetUserIds())
+	f.mu.Unlock()
+
+	out := &usersv1.ProfilesByIDsResponse{Profiles: map[string]*usersv1.Profile{}}
+	for _, id := range in.GetUserIds() {
+		if id != "u3" {
+			out.Profiles[id] = &usersv1.Profile{UserId: id, Username: "name-" + id}
+		}
+	}
+	return out, nil
+}
+
+func newGraphQLServer(t *testing.T, cl *clients.Clients, opts GraphQLOptions) *httptest.Server {
+	t.Helper()
+
+	h := New(cl)
+	h.GraphQL = opts
+	r := chi.NewRouter()
+	r.Post("/graphql", h.ExecuteGraphQL)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func postGraphQL(t *testing.T, srv *httptest.Server, body string) (int, map[string]any) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/graphql", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", "rid-1")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var out map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+
+	return resp.StatusCode, out
+}
+
+func TestGraphQL_BatchesAuthors(t *testing.T) {
+	news, users := &gqlNews{}, &gqlUsers{}
+	srv := newGraphQLServer(t, &clients.Clients{News: news, Comments: gqlComments{}, Users: users}, GraphQLOptions{})
+
+	code, out := postGraphQL(t, srv, `{"query":"query($n: Int) { newsList(limit: $n) { nextPageToken items { id title comments { items { id author { userId username } news { title } } } } } }","variables":{"n":2}}`)
+	require.Equal(t, http.StatusOK, code)
+	require.NotContains(t, out, "errors")
+
+	require.Len(t, users.batch, 1, "authors of all comments in one ProfilesByIDs")
+	ids := slices.Clone(users.batch[0])
+	slices.Sort(ids)
+	require.Equal(t, []string{"u1", "u2", "u3"}, ids)
+	require.Len(t, news.batch, 1, "news of all comments in one NewsByIDs")
+
+	b, _ := json.Marshal(out)
+	got := string(b)
+	require.Contains(t, got, `"nextPageToken":"next"`)
+	require.Contains(t, got, `{"author":{"userId":"u1","username":"name-u1"},"id":"n1-c1","news":{"title":"title n1"}}`)
+	require.Contains(t, got, `{"author":null,"id":"n2-c3","news":{"title":"title n2"}}`)
+}
+
+func TestGraphQL_ErrorMapping(t *testing.T) {
+	srv := newGraphQLServer(t, &clients.Clients{News: &gqlNews{}, Comments: gqlComments{}, Users: &gqlUsers{}}, GraphQLOptions{MaxComplexity: 100})
+
+	// Ошибка апстрима — частичный ответ, код и request_id из apierrors.
+	code, out := postGraphQL(t, srv, `{"query":"{ comment(id: \"42\") { id } profile(id: \"u1\") { username } }"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, map[string]any{"comment": nil, "profile": map[string]any{"username": "name-u1"}}, out["data"])
+	require.Equal(t, []any{map[string]any{
+		"message":    "not found",
+		"locations":  []any{map[string]any{"line": float64(1), "column": float64(3)}},
+		"path":       []any{"comment"},
+		"extensions": map[string]any{"code": "not_found", "request_id": "rid-1"},
+	}}, out["errors"])
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"unknown field", `{"query":"{ comment(id: \"1\") { secret } }"}`, `cannot query field \"secret\" on type Comment`},
+		{"complexity", `{"query":"{ newsList(limit: 50) { items { comments { items { id } } } } }"}`, "exceeds limit 100"},
+		{"negative page", `{"query":"{ newsList(limit: -1) { items { id } } }"}`, ""},
+	}
+	for _, tt := range tests {
+		code, out := postGraphQL(t, srv, tt.body)
+		if tt.want == "" {
+			// Отрицательный размер страницы — ошибка поля InvalidArgument.
+			require.Equal(t, http.StatusOK, code, tt.name)
+		} else {
+			require.Equal(t, http.StatusBadRequest, code, tt.name)
+			require.NotContains(t, out, "data", tt.name)
+		}
+
+		b, _ := json.Marshal(out["errors"])
+		require.Contains(t, string(b), `"code":"invalid_argument"`, tt.name)
+		require.Contains(t, string(b), tt.want, tt.name)
+	}
+
+	// Тело не по формату — обычная ошибка REST.
+	code, out = postGraphQL(t, srv, `{"query":"{ __typename }","extra":1}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Equal(t, "invalid_argument", out["error"].(map[string]any)["code"])
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/clients"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -12,6 +14,14 @@ import (
 // Handlers агрегирует зависимости (grpc-клиенты).
 type Handlers struct {
 	Clients *clients.Clients
+	// Stream — параметры SSE-эндпоинтов; нулевые поля — значения по умолчанию.
+	Stream StreamOptions
+	// WS — параметры /ws; без Hub эндпоинт отвечает 503.
+	WS WSOptions
+	// GraphQL — лимиты /graphql; нулевые поля — значения по умолчанию.
+	GraphQL GraphQLOptions
+	// NewsPage — дедлайн и размер секции комментариев /news/{id}/page.
+	NewsPage NewsPageOptions
 }
 
 func New(c *clients.Clients) *Handlers {
@@ -37,3 +47,32 @@ func decodeStrict(r *http.Request, value any) error {
 func statusErrorInvalidArgument() error {
 	return status.Error(codes.InvalidArgument, "invalid argument")
 }
+
+// statusErrorPermissionDenied — действие не разрешено вызывающему.
+func statusErrorPermissionDenied() error {
+	return status.Error(codes.PermissionDenied, "permission denied")
+}
+
+// isModerator — вызывающий с ролью moderator или admin.
+func isModerator(r *http.Request) bool {
+	caller, ok := middleware.CallerFrom(r.Context())
+	return ok && middleware.HasAnyRole(caller, identity.RoleModerator, identity.RoleAdmin)
+}
+
+// bindCaller подставляет вызывающего в user_id тела запроса (если тот пуст)
+// и запрещает действовать от чужого имени; admin — от имени любого пользователя.
+func bindCaller(r *http.Request, userID *string) error {
+	caller, ok := middleware.CallerFrom(r.Context())
+	if !ok {
+		return status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	switch {
+	case *userID == "":
+		*userID = caller.UserID
+	case *userID != caller.UserID && !caller.HasRole(identity.RoleAdmin):
+		return statusErrorPermissionDenied()
+	}
+
+	return nil
+}
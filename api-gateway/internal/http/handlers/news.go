@@ -3,13 +3,28 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	newsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/news"
 	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
 	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	logctx "github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// ListNews — лента новостей. С токеном у новостей заполняется is_read,
+// only_unread=true (только с токеном, иначе 401) скрывает прочитанные.
+// С ?ids= — пакетное чтение (см. newsByIDs), параметры ленты игнорируются.
 func (h *Handlers) ListNews(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.URL.Query()["ids"]; ok {
+		h.newsByIDs(w, r)
+		return
+	}
+
 	var req models.NewsListRequest
 	if v := r.URL.Query().Get("limit"); v != "" {
 		n, err := strconv.ParseInt(v, 10, 32)
@@ -23,15 +38,92 @@ func (h *Handlers) ListNews(w http.ResponseWriter, r *http.Request) {
 
 	req.PageToken = r.URL.Query().Get("page_token")
 
+	if v := r.URL.Query().Get("only_unread"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			apierrors.WriteError(w, r, statusErrorInvalidArgument())
+			return
+		}
+
+		req.OnlyUnread = b
+	}
+
+	caller, ok := middleware.CallerFrom(r.Context())
+	if ok {
+		req.UserID = caller.UserID
+	} else if req.OnlyUnread {
+		apierrors.WriteError(w, r, status.Error(codes.Unauthenticated, "unauthenticated"))
+		return
+	}
+
 	resp, err := h.Clients.News.ListNews(r.Context(), req.ToProto())
 	if err != nil {
 		apierrors.WriteError(w, r, err)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, models.NewsListFromProto(resp))
+	out := models.NewsListFromProto(resp)
+	h.mergeCommentCounts(r, out.Items)
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+// newsByIDs — новости по списку id (?ids=a,b,c или повтор ?ids=) одним вызовом NewsByIDs:
+// в порядке запроса, отсутствующие — в missing_ids. Пустой список — 400,
+// больше limits.max_batch news-service — 400 от апстрима.
+func (h *Handlers) newsByIDs(w http.ResponseWriter, r *http.Request) {
+	var req models.NewsBatchRequest
+	for _, v := range r.URL.Query()["ids"] {
+		for _, id := range strings.Split(v, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				req.IDs = append(req.IDs, id)
+			}
+		}
+	}
+
+	if len(req.IDs) == 0 {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	if caller, ok := middleware.CallerFrom(r.Context()); ok {
+		req.UserID = caller.UserID
+	}
+
+	resp, err := h.Clients.News.NewsByIDs(r.Context(), req.ToProto())
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	out := models.NewsBatchFromProto(resp)
+	h.mergeCommentCounts(r, out.Items)
+
+	writeJSON(w, http.StatusOK, out)
 }
 
+// mergeCommentCounts дополняет ленту счётчиками комментариев одним батч-запросом.
+// Счётчики — не критичное обогащение: при ошибке comments-service лента отдаётся без них.
+func (h *Handlers) mergeCommentCounts(r *http.Request, items []models.News) {
+	if len(items) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, it := range items {
+		ids = append(ids, it.ID)
+	}
+
+	counts, err := h.Clients.Comments.CountsByNews(r.Context(), &commentsv1.CountsByNewsRequest{NewsIds: ids})
+	if err != nil {
+		logctx.From(r.Context()).Warn("comment counts unavailable", "err", err)
+		return
+	}
+
+	models.MergeCommentCounts(items, counts)
+}
+
+// GetNewsByID — новость по id; открытие вызывающим отмечает её прочитанной.
 func (h *Handlers) GetNewsByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
@@ -45,5 +137,22 @@ func (h *Handlers) GetNewsByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if caller, ok := middleware.CallerFrom(r.Context()); ok {
+		h.markOpened(r, caller.UserID, resp.GetItem().GetId())
+	}
+
 	writeJSON(w, http.StatusOK, models.NewsGetFromProto(resp))
 }
+
+// markOpened отмечает открытую новость прочитанной. Отметка — не критичный
+// побочный эффект: при ошибке news-service новость всё равно отдаётся.
+func (h *Handlers) markOpened(r *http.Request, userID, newsID string) {
+	if newsID == "" {
+		return
+	}
+
+	req := &newsv1.MarkReadRequest{UserId: userID, NewsIds: []string{newsID}}
+	if _, err := h.Clients.News.MarkRead(r.Context(), req); err != nil {
+		logctx.From(r.Context()).Warn("mark read failed", "news_id", newsID, "err", err)
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+)
+
+func (h *Handlers) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	var req models.ListNotificationsRequest
+	req.UserID = chi.URLParam(r, "id")
+	if req.UserID == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	q := r.URL.Query()
+	if v := q.Get("page_size"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n < 0 {
+			apierrors.WriteError(w, r, statusErrorInvalidArgument())
+			return
+		}
+
+		req.PageSize = int32(n)
+	}
+
+	if v := q.Get("unread_only"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			apierrors.WriteError(w, r, statusErrorInvalidArgument())
+			return
+		}
+
+		req.UnreadOnly = b
+	}
+
+	req.PageToken = q.Get("page_token")
+
+	resp, err := h.Clients.Comments.ListNotifications(r.Context(), req.ToProto())
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.ListNotificationsFromProto(resp))
+}
+
+func (h *Handlers) MarkNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	var in models.MarkReadRequest
+	if err := decodeStrict(r, &in); err != nil {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	in.UserID = chi.URLParam(r, "id")
+	if in.UserID == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	resp, err := h.Clients.Comments.MarkRead(r.Context(), in.ToProto())
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.MarkReadResponse{Updated: resp.GetUpdated()})
+}
+
+func (h *Handlers) UnreadNotificationsCount(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	resp, err := h.Clients.Comments.UnreadCount(r.Context(), &commentsv1.UnreadCountRequest{UserId: id})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.UnreadCountResponse{Count: resp.GetCount()})
+}
+
+func (h *Handlers) MuteThread(w http.ResponseWriter, r *http.Request) {
+	h.setThreadMuted(w, r, true)
+}
+
+func (h *Handlers) UnmuteThread(w http.ResponseWriter, r *http.Request) {
+	h.setThreadMuted(w, r, false)
+}
+
+// setThreadMuted — общая часть MuteThread/UnmuteThread.
+func (h *Handlers) setThreadMuted(w http.ResponseWriter, r *http.Request, muted bool) {
+	var in models.MuteThreadRequest
+	if err := decodeStrict(r, &in); err != nil {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	in.CommentID = chi.URLParam(r, "id")
+	if in.CommentID == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	if err := bindCaller(r, &in.UserID); err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	call := h.Clients.Comments.UnmuteThread
+	if muted {
+		call = h.Clients.Comments.MuteThread
+	}
+
+	resp, err := call(r.Context(), in.ToProto())
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.MuteThreadResponse{ThreadID: resp.GetThreadId()})
+}
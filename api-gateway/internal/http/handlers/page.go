@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	logctx "github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// defaultNewsPageTimeout — дедлайн GET /news/{id}/page по умолчанию (config.NewsPageConfig).
+const defaultNewsPageTimeout = 2 * time.Second
+
+// Секции страницы новости, которые могут отсутствовать в ответе.
+const (
+	pageSectionComments = "comments"
+	pageSectionAuthors  = "authors"
+)
+
+// NewsPageOptions — параметры GET /news/{id}/page; нулевые поля — значения по умолчанию.
+type NewsPageOptions struct {
+	// Timeout — общий дедлайн всех вызовов страницы.
+	Timeout time.Duration
+	// CommentsPageSize — корневых комментариев без ?page_size=; 0 — limits.default comments-service.
+	CommentsPageSize int32
+}
+
+func (o NewsPageOptions) withDefaults() NewsPageOptions {
+	if o.Timeout <= 0 {
+		o.Timeout = defaultNewsPageTimeout
+	}
+
+	return o
+}
+
+var newsPagePartial = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "api_gateway",
+	Subsystem: "news_page",
+	Name:      "partial_total",
+	Help:      "Ответы GET /news/{id}/page без секции (comments, authors).",
+}, []string{"section"})
+
+// GetNewsPage — страница новости одним запросом: NewsByID параллельно с первой
+// страницей ListByNews и авторами её комментариев (ProfilesByIDs), всё под
+// одним дедлайном NewsPageOptions.Timeout.
+//
+// Ошибка новости — ошибка запроса (404, 504...). Комментарии и авторы —
+// не критичны: при ошибке или таймауте новость отдаётся, а секция попадает
+// в missing. С токеном новость, как и в GET /news/{id}, отмечается прочитанной.
+func (h *Handlers) GetNewsPage(w http.ResponseWriter, r *http.Request) {
+	opts := h.NewsPage.withDefaults()
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	commentsReq := models.ListRootCommentsRequest{NewsID: id, PageSize: opts.CommentsPageSize}
+	if v := r.URL.Query().Get("page_size"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n < 0 {
+			apierrors.WriteError(w, r, statusErrorInvalidArgument())
+			return
+		}
+
+		commentsReq.PageSize = int32(n)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), opts.Timeout)
+	defer cancel()
+
+	// Секцию comments заполняет горутина; читается после wg.Wait.
+	var (
+		wg  sync.WaitGroup
+		out = models.NewsPageResponse{Missing: make([]string, 0, 2)}
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// Recover не видит паник чужой горутины: секция просто пропадает.
+		defer func() {
+			if rec := recover(); rec != nil {
+				logctx.From(ctx).Error("news page comments panic", "news_id", id, "reason", rec)
+				out.Comments = nil
+				out.Missing = []string{pageSectionComments}
+			}
+		}()
+
+		resp, err := h.Clients.Comments.ListByNews(ctx, commentsReq.ToProto())
+		if err != nil {
+			logctx.From(ctx).Warn("news page comments unavailable", "news_id", id, "err", err)
+			out.Missing = append(out.Missing, pageSectionComments)
+			return
+		}
+
+		page := models.ListRootCommentsFromProto(resp)
+		if err := h.mergeAuthorsCtx(ctx, page.Comments); err != nil {
+			out.Missing = append(out.Missing, pageSectionAuthors)
+		}
+		out.Comments = &page
+	}()
+
+	newsResp, err := h.Clients.News.NewsByID(ctx, (&models.NewsGetRequest{ID: id}).ToProto())
+	if err != nil {
+		cancel()
+		wg.Wait()
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	if caller, ok := middleware.CallerFrom(r.Context()); ok {
+		h.markOpened(r.WithContext(ctx), caller.UserID, newsResp.GetItem().GetId())
+	}
+
+	wg.Wait()
+
+	if it := models.NewsGetFromProto(newsResp).Item; it != nil {
+		out.News = *it
+	}
+	for _, s := range out.Missing {
+		newsPagePartial.WithLabelValues(s).Inc()
+	}
+
+	writeJSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,175 @@
+package handlers
+
+// Тесты /news/{id}/page (page.go):
+//   - новость и комментарии запрашиваются параллельно, авторы дополняют комментарии;
+//   - комментарии не успели к дедлайну — новость отдаётся, missing: ["comments"];
+//   - users-service недоступен — комментарии без авторов, missing: ["authors"];
+//   - ошибка новости — ошибка запроса; некорректный page_size — 400.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	newsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/news"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/users"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/clients"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pageNews отвечает только после старта ListByNews (started закрыт) —
+// последовательные вызовы упёрлись бы в дедлайн.
+type pageNews struct {
+	newsv1.NewsServiceClient
+	started <-chan struct{}
+	err     error
+}
+
+func (f *pageNews) NewsByID(ctx context.Context, in *newsv1.NewsByIDRequest, _ ...grpc.CallOption) (*newsv1.NewsByIDResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	select {
+	case <-f.started:
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	return &newsv1.NewsByIDResponse{Item: &newsv1.News{Id: in.GetId(), Title: "article"}}, nil
+}
+
+// pageComments — два корня; с hang ждёт дедлайна.
+type pageComments struct {
+	commentsv1.CommentsServiceClient
+	started  chan struct{}
+	hang     bool
+	pageSize int32
+}
+
+func (f *pageComments) ListByNews(ctx context.Context, in *commentsv1.ListByNewsRequest, _ ...grpc.CallOption) (*commentsv1.ListByNewsResponse, error) {
+	f.pageSize = in.GetPageSize()
+	close(f.started)
+
+	if f.hang {
+		<-ctx.Done()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	return &commentsv1.ListByNewsResponse{
+		Comments: []*commentsv1.Comment{
+			{Id: "c1", NewsId: in.GetNewsId(), UserId: "u1"},
+			{Id: "c2", NewsId: in.GetNewsId(), UserId: "u2"},
+		},
+		NextPageToken: "p2",
+	}, nil
+}
+
+// pageUsers — профили всех запрошенных; с err — ошибка.
+type pageUsers struct {
+	usersv1.UsersServiceClient
+	err error
+}
+
+func (f pageUsers) ProfilesByIDs(_ context.Context, in *usersv1.ProfilesByIDsRequest, _ ...grpc.CallOption) (*usersv1.ProfilesByIDsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	out := &usersv1.ProfilesByIDsResponse{Profiles: map[string]*usersv1.Profile{}}
+	for _, id := range in.GetUserIds() {
+		out.Profiles[id] = &usersv1.Profile{UserId: id, Username: "name-" + id}
+	}
+	return out, nil
+}
+
+func newPageServer(t *testing.T, comments *pageComments, news *pageNews, users pageUsers) *httptest.Server {
+	t.Helper()
+
+	news.started = comments.started
+	h := New(&clients.Clients{News: news, Comments: comments, Users: users})
+	h.NewsPage = NewsPageOptions{Timeout: 300 * time.Millisecond, CommentsPageSize: 20}
+
+	r := chi.NewRouter()
+	r.Get("/news/{id}/page", h.GetNewsPage)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func getPage(t *testing.T, url string) (int, models.NewsPageResponse) {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var out models.NewsPageResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+
+	return resp.StatusCode, out
+}
+
+func TestGetNewsPage_Full(t *testing.T) {
+	comments := &pageComments{started: make(chan struct{})}
+	srv := newPageServer(t, comments, &pageNews{}, pageUsers{})
+
+	code, out := getPage(t, srv.URL+"/news/n1/page")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "article", out.News.Title)
+	require.Empty(t, out.Missing)
+	require.NotNil(t, out.Comments)
+	require.Equal(t, "p2", out.Comments.NextPageToken)
+	require.Len(t, out.Comments.Comments, 2)
+	require.Equal(t, "name-u2", out.Comments.Comments[1].DisplayName)
+	require.EqualValues(t, 20, comments.pageSize, "page size from options")
+}
+
+func TestGetNewsPage_PartialSections(t *testing.T) {
+	t.Run("comments timeout", func(t *testing.T) {
+		srv := newPageServer(t, &pageComments{started: make(chan struct{}), hang: true}, &pageNews{}, pageUsers{})
+
+		start := time.Now()
+		code, out := getPage(t, srv.URL+"/news/n1/page")
+		require.Equal(t, http.StatusOK, code)
+		require.Less(t, time.Since(start), 2*time.Second, "one deadline for the whole page")
+		require.Equal(t, "article", out.News.Title)
+		require.Nil(t, out.Comments)
+		require.Equal(t, []string{"comments"}, out.Missing)
+	})
+
+	t.Run("authors unavailable", func(t *testing.T) {
+		srv := newPageServer(t, &pageComments{started: make(chan struct{})}, &pageNews{}, pageUsers{err: status.Error(codes.Unavailable, "down")})
+
+		code, out := getPage(t, srv.URL+"/news/n1/page?page_size=5")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, []string{"authors"}, out.Missing)
+		require.Len(t, out.Comments.Comments, 2)
+		require.Empty(t, out.Comments.Comments[0].DisplayName)
+	})
+}
+
+func TestGetNewsPage_Errors(t *testing.T) {
+	srv := newPageServer(t, &pageComments{started: make(chan struct{})}, &pageNews{err: status.Error(codes.NotFound, "no news")}, pageUsers{})
+
+	resp, err := http.Get(srv.URL + "/news/n1/page")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = http.Get(srv.URL + "/news/n1/page?page_size=-1")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	newsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/news"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/users"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetMyPreferences — предпочтения вызывающего; без валидного токена — 401.
+func (h *Handlers) GetMyPreferences(w http.ResponseWriter, r *http.Request) {
+	caller, ok := middleware.CallerFrom(r.Context())
+	if !ok {
+		apierrors.WriteError(w, r, status.Error(codes.Unauthenticated, "unauthenticated"))
+		return
+	}
+
+	resp, err := h.Clients.Users.GetPreferences(r.Context(), &usersv1.GetPreferencesRequest{UserId: caller.UserID})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.PreferencesFromProto(resp))
+}
+
+// UpdateMyPreferences — частичное обновление предпочтений вызывающего.
+// Пустое тело — 400: без маски users-service перезаписал бы все списки.
+func (h *Handlers) UpdateMyPreferences(w http.ResponseWriter, r *http.Request) {
+	caller, ok := middleware.CallerFrom(r.Context())
+	if !ok {
+		apierrors.WriteError(w, r, status.Error(codes.Unauthenticated, "unauthenticated"))
+		return
+	}
+
+	var in models.UpdatePreferencesRequest
+	if err := decodeStrict(r, &in); err != nil {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	req := in.ToProto(caller.UserID)
+	if len(req.GetUpdateMask().GetPaths()) == 0 {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	resp, err := h.Clients.Users.UpdatePreferences(r.Context(), req)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.PreferencesFromProto(resp))
+}
+
+// ListPersonalizedNews — лента с учётом предпочтений вызывающего.
+// Без токена отдаётся та же лента, ранжированная только по свежести и разнообразию источников.
+func (h *Handlers) ListPersonalizedNews(w http.ResponseWriter, r *http.Request) {
+	req := &newsv1.ListPersonalizedNewsRequest{PageToken: r.URL.Query().Get("page_token")}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			apierrors.WriteError(w, r, statusErrorInvalidArgument())
+			return
+		}
+
+		req.Limit = int32(n)
+	}
+
+	if caller, ok := middleware.CallerFrom(r.Context()); ok {
+		req.UserId = caller.UserID
+	}
+
+	resp, err := h.Clients.News.ListPersonalizedNews(r.Context(), req)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	out := models.PersonalizedNewsFromProto(resp)
+	h.mergeCommentCounts(r, out.Items)
+
+	writeJSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+)
+
+func (h *Handlers) AddReaction(w http.ResponseWriter, r *http.Request) {
+	h.setReaction(w, r, false)
+}
+
+func (h *Handlers) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	h.setReaction(w, r, true)
+}
+
+// setReaction — общая часть AddReaction/RemoveReaction. Реакция ставится от имени
+// вызывающего; повторная постановка (и снятие отсутствующей) счётчики не меняет.
+func (h *Handlers) setReaction(w http.ResponseWriter, r *http.Request, remove bool) {
+	in := &commentsv1.ReactRequest{
+		CommentId: chi.URLParam(r, "id"),
+		Reaction:  chi.URLParam(r, "reaction"),
+		Remove:    remove,
+	}
+	if in.CommentId == "" || in.Reaction == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	if err := bindCaller(r, &in.UserId); err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	resp, err := h.Clients.Comments.React(r.Context(), in)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.ReactFromProto(resp))
+}
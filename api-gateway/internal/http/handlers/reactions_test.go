@@ -0,0 +1,87 @@
+package handlers
+
+// Тесты реакций (reactions.go):
+//   - PUT ставит, DELETE снимает реакцию от имени владельца токена;
+//   - в ответе — комментарий с актуальными счётчиками;
+//   - без токена — 401 без вызова апстрима.
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/clients"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type reactComments struct {
+	commentsv1.CommentsServiceClient
+	reacts chan *commentsv1.ReactRequest
+}
+
+func (f *reactComments) React(_ context.Context, in *commentsv1.ReactRequest, _ ...grpc.CallOption) (*commentsv1.ReactResponse, error) {
+	f.reacts <- in
+
+	reactions := map[string]int64{}
+	if !in.GetRemove() {
+		reactions[in.GetReaction()] = 1
+	}
+
+	return &commentsv1.ReactResponse{Comment: &commentsv1.Comment{Id: in.GetCommentId(), Reactions: reactions}}, nil
+}
+
+func TestReactions(t *testing.T) {
+	comments := &reactComments{reacts: make(chan *commentsv1.ReactRequest, 1)}
+	h := New(&clients.Clients{Comments: comments})
+	r := chi.NewRouter()
+	r.Put("/comments/{id}/reactions/{reaction}", h.AddReaction)
+	r.Delete("/comments/{id}/reactions/{reaction}", h.RemoveReaction)
+	srv := httptest.NewServer(middleware.Chain(r,
+		middleware.Logging(slog.New(slog.DiscardHandler)), middleware.AuthBearer(), middleware.Identity(wsVerifier{}, nil, nil), middleware.OptionalAuth()))
+	t.Cleanup(srv.Close)
+
+	do := func(method, token string) (int, models.ReactResponse) {
+		req, err := http.NewRequest(method, srv.URL+"/comments/c1/reactions/like", nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var out models.ReactResponse
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		}
+
+		return resp.StatusCode, out
+	}
+
+	code, out := do(http.MethodPut, "good")
+	require.Equal(t, http.StatusOK, code)
+	in := <-comments.reacts
+	require.Equal(t, "c1", in.GetCommentId())
+	require.Equal(t, "u1", in.GetUserId())
+	require.Equal(t, "like", in.GetReaction())
+	require.False(t, in.GetRemove())
+	require.Equal(t, map[string]int64{"like": 1}, out.Comment.Reactions)
+
+	code, out = do(http.MethodDelete, "good")
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, (<-comments.reacts).GetRemove())
+	require.Empty(t, out.Comment.Reactions)
+
+	code, _ = do(http.MethodPut, "")
+	require.Equal(t, http.StatusUnauthorized, code)
+	require.Empty(t, comments.reacts)
+}
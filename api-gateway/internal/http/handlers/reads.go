@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MarkNewsRead — отметить новости прочитанными вызывающим; без валидного токена — 401.
+func (h *Handlers) MarkNewsRead(w http.ResponseWriter, r *http.Request) {
+	caller, ok := middleware.CallerFrom(r.Context())
+	if !ok {
+		apierrors.WriteError(w, r, status.Error(codes.Unauthenticated, "unauthenticated"))
+		return
+	}
+
+	var in models.MarkNewsReadRequest
+	if err := decodeStrict(r, &in); err != nil || len(in.NewsIDs) == 0 {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	if _, err := h.Clients.News.MarkRead(r.Context(), in.ToProto(caller.UserID)); err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+// MarkAllNewsRead — «прочитано всё»: страница ленты, запрошенная с page_token, и всё старше
+// (пустое тело — до текущего момента).
+func (h *Handlers) MarkAllNewsRead(w http.ResponseWriter, r *http.Request) {
+	caller, ok := middleware.CallerFrom(r.Context())
+	if !ok {
+		apierrors.WriteError(w, r, status.Error(codes.Unauthenticated, "unauthenticated"))
+		return
+	}
+
+	var in models.MarkAllNewsReadRequest
+	if err := decodeStrict(r, &in); err != nil && !errors.Is(err, io.EOF) {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	if _, err := h.Clients.News.MarkAllRead(r.Context(), in.ToProto(caller.UserID)); err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct{}{})
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+)
+
+// ListUserComments — GET /users/{id}/comments: комментарии автора, сначала новые.
+func (h *Handlers) ListUserComments(w http.ResponseWriter, r *http.Request) {
+	var req models.ListUserCommentsRequest
+	req.UserID = chi.URLParam(r, "id")
+	if req.UserID == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	q := r.URL.Query()
+	var ok bool
+	if req.PageSize, req.IncludeDeleted, ok = parsePageAndDeleted(q.Get("page_size"), q.Get("include_deleted")); !ok {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	if req.IncludeDeleted && !isModerator(r) {
+		apierrors.WriteError(w, r, statusErrorPermissionDenied())
+		return
+	}
+
+	req.PageToken = q.Get("page_token")
+
+	resp, err := h.Clients.Comments.ListByUser(r.Context(), req.ToProto())
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	out := models.CommentsPageFromProto(resp.GetComments(), resp.GetNextPageToken())
+	h.mergeAuthors(r, out.Comments)
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+// SearchComments — GET /comments/search?q=: полнотекстовый поиск по комментариям.
+func (h *Handlers) SearchComments(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	req := models.SearchCommentsRequest{
+		Query:     q.Get("q"),
+		NewsID:    q.Get("news_id"),
+		UserID:    q.Get("user_id"),
+		PageToken: q.Get("page_token"),
+	}
+	if req.Query == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	var ok bool
+	if req.PageSize, req.IncludeDeleted, ok = parsePageAndDeleted(q.Get("page_size"), q.Get("include_deleted")); !ok {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	if req.IncludeDeleted && !isModerator(r) {
+		apierrors.WriteError(w, r, statusErrorPermissionDenied())
+		return
+	}
+
+	resp, err := h.Clients.Comments.SearchComments(r.Context(), req.ToProto())
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	out := models.CommentsPageFromProto(resp.GetComments(), resp.GetNextPageToken())
+	h.mergeAuthors(r, out.Comments)
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+// parsePageAndDeleted разбирает page_size и include_deleted (пустые значения — по умолчанию).
+func parsePageAndDeleted(pageSize, includeDeleted string) (int32, bool, bool) {
+	var size int32
+	if pageSize != "" {
+		n, err := strconv.ParseInt(pageSize, 10, 32)
+		if err != nil || n < 0 {
+			return 0, false, false
+		}
+
+		size = int32(n)
+	}
+
+	var deleted bool
+	if includeDeleted != "" {
+		b, err := strconv.ParseBool(includeDeleted)
+		if err != nil {
+			return 0, false, false
+		}
+
+		deleted = b
+	}
+
+	return size, deleted, true
+}
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	newsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/news"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	logctx "github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Значения StreamOptions по умолчанию (config.StreamConfig).
+const (
+	defaultStreamHeartbeat    = 15 * time.Second
+	defaultStreamWriteTimeout = 10 * time.Second
+	defaultStreamRetry        = 3 * time.Second
+)
+
+// StreamOptions — параметры потоков text/event-stream.
+type StreamOptions struct {
+	// Heartbeat — период комментария ": ping" в простаивающем потоке.
+	Heartbeat time.Duration
+	// WriteTimeout — предел записи одного события; медленный клиент отключается.
+	WriteTimeout time.Duration
+	// Retry — пауза переподключения EventSource (поле retry:).
+	Retry time.Duration
+	// Done закрывается при остановке сервера: открытые потоки завершаются
+	// (клиенты переподключаются к другой реплике с Last-Event-ID).
+	Done <-chan struct{}
+}
+
+func (o StreamOptions) withDefaults() StreamOptions {
+	if o.Heartbeat <= 0 {
+		o.Heartbeat = defaultStreamHeartbeat
+	}
+	if o.WriteTimeout <= 0 {
+		o.WriteTimeout = defaultStreamWriteTimeout
+	}
+	if o.Retry <= 0 {
+		o.Retry = defaultStreamRetry
+	}
+
+	return o
+}
+
+// sseEvent — событие потока: id (курсор Last-Event-ID), имя и JSON-данные.
+type sseEvent struct {
+	ID   string
+	Name string
+	Data any
+}
+
+// StreamNews — живая лента (WatchNews) как text/event-stream:
+//   - event: news, id: курсор, data: News — новость, впервые сохранённая ingest;
+//   - event: reset — пропущенное после Last-Event-ID не восстановить, перечитайте GET /news;
+//   - event: error, data: ErrorResponse — поток прерван апстримом (EventSource переподключится).
+//
+// Возобновление — заголовок Last-Event-ID (EventSource шлёт его сам) или ?last_event_id=.
+func (h *Handlers) StreamNews(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	stream, err := h.Clients.News.WatchNews(ctx, &newsv1.WatchNewsRequest{LastEventId: lastEventID(r)})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	h.serveSSE(ctx, w, r, func() (sseEvent, error) {
+		ev, err := stream.Recv()
+		if err != nil {
+			return sseEvent{}, err
+		}
+
+		if ev.GetResetRequired() {
+			return sseEvent{Name: "reset", Data: struct{}{}}, nil
+		}
+
+		return sseEvent{ID: ev.GetEventId(), Name: "news", Data: models.NewsFromProto(ev.GetItem())}, nil
+	})
+}
+
+// StreamComments — комментарии новости (WatchComments) как text/event-stream:
+//   - event: comment, id: курсор, data: Comment (корни и ответы, с данными автора);
+//   - event: comment_deleted, data: Comment (id, news_id, parent_id, is_deleted) — без id, курсор не сдвигается;
+//   - event: comment_reaction, data: Comment (id, news_id, parent_id, root_id, reactions) — без id;
+//   - event: reset — перечитайте GET /news/{news_id}/comments;
+//   - event: error — как у StreamNews.
+//
+// Неверный news_id — 400 до открытия потока.
+func (h *Handlers) StreamComments(w http.ResponseWriter, r *http.Request) {
+	newsID := chi.URLParam(r, "news_id")
+	if _, err := uuid.Parse(newsID); err != nil {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	stream, err := h.Clients.Comments.WatchComments(ctx, &commentsv1.WatchCommentsRequest{
+		NewsId:      newsID,
+		LastEventId: lastEventID(r),
+	})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	h.serveSSE(ctx, w, r, func() (sseEvent, error) {
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				return sseEvent{}, err
+			}
+
+			if ev.GetResetRequired() {
+				return sseEvent{Name: "reset", Data: struct{}{}}, nil
+			}
+
+			c := models.CommentFromProto(ev.GetComment())
+			switch ev.GetKind() {
+			case commentsv1.CommentEventKind_COMMENT_DELETED:
+				return sseEvent{Name: "comment_deleted", Data: c}, nil
+			case commentsv1.CommentEventKind_COMMENT_REACTION:
+				return sseEvent{Name: "comment_reaction", Data: c}, nil
+			case commentsv1.CommentEventKind_COMMENT_CREATED, commentsv1.CommentEventKind_COMMENT_EVENT_KIND_UNSPECIFIED:
+				h.mergeAuthor(r, &c)
+				return sseEvent{ID: ev.GetEventId(), Name: "comment", Data: c}, nil
+			}
+			// Незнакомый вид события (более новый comments-service) — пропускается.
+		}
+	})
+}
+
+// StreamNotifications — новые уведомления пользователя {id} (WatchNotifications) как text/event-stream:
+//   - event: notification, data: Notification — без id: курсора у потока нет;
+//   - event: error — как у StreamNews.
+//
+// Возобновления нет: пропущенное (переподключение, переполнение буфера сервиса)
+// дочитывается через GET /users/{id}/notifications.
+func (h *Handlers) StreamNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(userID); err != nil {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	stream, err := h.Clients.Comments.WatchNotifications(ctx, &commentsv1.WatchNotificationsRequest{UserId: userID})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	h.serveSSE(ctx, w, r, func() (sseEvent, error) {
+		n, err := stream.Recv()
+		if err != nil {
+			return sseEvent{}, err
+		}
+
+		return sseEvent{Name: "notification", Data: models.NotificationFromProto(n)}, nil
+	})
+}
+
+// lastEventID — курсор возобновления: заголовок Last-Event-ID или ?last_event_id=.
+func lastEventID(r *http.Request) string {
+	if v := strings.TrimSpace(r.Header.Get("Last-Event-ID")); v != "" {
+		return v
+	}
+
+	return strings.TrimSpace(r.URL.Query().Get("last_event_id"))
+}
+
+// serveSSE отдаёт события recv клиенту до отмены запроса, остановки сервера или ошибки.
+//
+// Чтение апстрима идёт в отдельной горутине без буфера: пока клиент не дочитал
+// событие, следующее не читается — gRPC flow control доносит давление до сервиса,
+// а тот отключает отстающую подписку (RESOURCE_EXHAUSTED) вместо торможения ingest.
+// Запись события дольше WriteTimeout обрывает соединение; клиент переподключается
+// с Last-Event-ID.
+func (h *Handlers) serveSSE(ctx context.Context, w http.ResponseWriter, r *http.Request, recv func() (sseEvent, error)) {
+	opts := h.Stream.withDefaults()
+	rc := http.NewResponseController(w)
+	lg := logctx.From(ctx)
+
+	hdr := w.Header()
+	hdr.Set("Content-Type", "text/event-stream")
+	hdr.Set("Cache-Control", "no-cache")
+	hdr.Set("Connection", "keep-alive")
+	hdr.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(s string) error {
+		if err := rc.SetWriteDeadline(time.Now().Add(opts.WriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		if _, err := io.WriteString(w, s); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	if err := write("retry: " + strconv.FormatInt(opts.Retry.Milliseconds(), 10) + "\n\n"); err != nil {
+		return
+	}
+
+	type result struct {
+		ev  sseEvent
+		err error
+	}
+	events := make(chan result)
+	go func() {
+		for {
+			ev, err := recv()
+			select {
+			case events <- result{ev, err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(opts.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-opts.Done:
+			return
+		case <-heartbeat.C:
+			if err := write(": ping\n\n"); err != nil {
+				return
+			}
+		case res := <-events:
+			if res.err != nil {
+				if errors.Is(res.err, io.EOF) || status.Code(res.err) == codes.Canceled {
+					return
+				}
+
+				lg.Warn("event stream upstream error", "err", res.err)
+				_ = write(formatSSE(errorEvent(r, res.err)))
+				return
+			}
+
+			if err := write(formatSSE(res.ev)); err != nil {
+				lg.Info("event stream client gone", "err", err)
+				return
+			}
+			heartbeat.Reset(opts.Heartbeat)
+		}
+	}
+}
+
+// errorEvent — event: error с телом, как у apierrors.WriteError.
+func errorEvent(r *http.Request, err error) sseEvent {
+	_, resp := apierrors.ToHTTP(err)
+	if rid := r.Header.Get("X-Request-Id"); rid != "" {
+		resp.Error.RequestID = rid
+	}
+
+	return sseEvent{Name: "error", Data: resp}
+}
+
+// formatSSE сериализует событие в формат text/event-stream (data — одна строка JSON).
+func formatSSE(ev sseEvent) string {
+	data, err := json.Marshal(ev.Data)
+	if err != nil {
+		data = []byte("{}")
+	}
+
+	var b strings.Builder
+	if ev.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", ev.ID)
+	}
+	if ev.Name != "" {
+		fmt.Fprintf(&b, "event: %s\n", ev.Name)
+	}
+	fmt.Fprintf(&b, "data: %s\n\n", data)
+
+	return b.String()
+}
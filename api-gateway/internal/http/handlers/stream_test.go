@@ -0,0 +1,278 @@
+package handlers
+
+// Тесты SSE-эндпоинтов (stream.go):
+//   - заголовки, retry:, формат id/event/data, reset, comment_deleted и comment_reaction без id;
+//   - незнакомый вид события апстрима не выдаётся за comment;
+//   - Last-Event-ID (заголовок и query) уходит в апстрим;
+//   - ошибка апстрима — event: error с кодом apierrors, затем конец потока;
+//   - heartbeat в простаивающем потоке, завершение по Done;
+//   - уведомления: event notification без id, пользователь из пути;
+//   - неверный news_id/user_id — 400 до открытия потока.
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	newsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/news"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/users"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/clients"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStream — клиентский серверный стрим: события из канала, закрытие — io.EOF.
+type fakeStream[T any] struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events chan *T
+	err    error
+}
+
+func (f *fakeStream[T]) Recv() (*T, error) {
+	select {
+	case ev, ok := <-f.events:
+		if !ok {
+			if f.err != nil {
+				return nil, f.err
+			}
+			return nil, io.EOF
+		}
+		return ev, nil
+	case <-f.ctx.Done():
+		return nil, status.FromContextError(f.ctx.Err()).Err()
+	}
+}
+
+type fakeNews struct {
+	newsv1.NewsServiceClient
+	events chan *newsv1.NewsEvent
+	err    error
+	last   chan string
+}
+
+func (f *fakeNews) WatchNews(ctx context.Context, in *newsv1.WatchNewsRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[newsv1.NewsEvent], error) {
+	f.last <- in.GetLastEventId()
+	return &fakeStream[newsv1.NewsEvent]{ctx: ctx, events: f.events, err: f.err}, nil
+}
+
+type fakeComments struct {
+	commentsv1.CommentsServiceClient
+	events        chan *commentsv1.CommentEvent
+	notifications chan *commentsv1.Notification
+	notifyUser    chan string
+}
+
+func (f *fakeComments) WatchComments(ctx context.Context, _ *commentsv1.WatchCommentsRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[commentsv1.CommentEvent], error) {
+	return &fakeStream[commentsv1.CommentEvent]{ctx: ctx, events: f.events}, nil
+}
+
+func (f *fakeComments) WatchNotifications(ctx context.Context, in *commentsv1.WatchNotificationsRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[commentsv1.Notification], error) {
+	f.notifyUser <- in.GetUserId()
+	return &fakeStream[commentsv1.Notification]{ctx: ctx, events: f.notifications}, nil
+}
+
+type fakeUsers struct {
+	usersv1.UsersServiceClient
+}
+
+func (fakeUsers) ProfilesByIDs(context.Context, *usersv1.ProfilesByIDsRequest, ...grpc.CallOption) (*usersv1.ProfilesByIDsResponse, error) {
+	return &usersv1.ProfilesByIDsResponse{}, nil
+}
+
+// ProfileByID: профиль "name-<id>"; у "noprofile" профиля нет.
+func (fakeUsers) ProfileByID(_ context.Context, in *usersv1.ProfileByIDRequest, _ ...grpc.CallOption) (*usersv1.Profile, error) {
+	if in.GetUserId() == "noprofile" {
+		return nil, status.Error(codes.NotFound, "profile not found")
+	}
+	return &usersv1.Profile{UserId: in.GetUserId(), Username: "name-" + in.GetUserId()}, nil
+}
+
+func newStreamServer(t *testing.T, cl *clients.Clients, opts StreamOptions) *httptest.Server {
+	t.Helper()
+
+	h := New(cl)
+	h.Stream = opts
+	r := chi.NewRouter()
+	r.Get("/news/stream", h.StreamNews)
+	r.Get("/news/{news_id}/comments/stream", h.StreamComments)
+	r.Get("/users/{id}/notifications/stream", h.StreamNotifications)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// readEvent читает строки до пустой (конец события).
+func readEvent(t *testing.T, rd *bufio.Reader) []string {
+	t.Helper()
+
+	var lines []string
+	for {
+		line, err := rd.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestStreamNews_EventsAndResume(t *testing.T) {
+	news := &fakeNews{events: make(chan *newsv1.NewsEvent, 4), last: make(chan string, 1)}
+	srv := newStreamServer(t, &clients.Clients{News: news}, StreamOptions{Retry: 2 * time.Second, Heartbeat: time.Hour})
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/news/stream?last_event_id=query", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "7")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	require.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+	require.Equal(t, "7", <-news.last, "header wins over query")
+
+	rd := bufio.NewReader(resp.Body)
+	require.Equal(t, []string{"retry: 2000"}, readEvent(t, rd))
+
+	news.events <- &newsv1.NewsEvent{ResetRequired: true}
+	news.events <- &newsv1.NewsEvent{EventId: "8", Item: &newsv1.News{Id: "n8", Title: "t"}}
+	close(news.events)
+
+	require.Equal(t, []string{"event: reset", "data: {}"}, readEvent(t, rd))
+
+	ev := readEvent(t, rd)
+	require.Len(t, ev, 3)
+	require.Equal(t, "id: 8", ev[0])
+	require.Equal(t, "event: news", ev[1])
+	require.Contains(t, ev[2], `"id":"n8"`)
+
+	// io.EOF апстрима — конец потока без event: error.
+	rest, err := io.ReadAll(rd)
+	require.NoError(t, err)
+	require.Empty(t, string(rest))
+}
+
+func TestStreamNews_QueryCursorAndUpstreamError(t *testing.T) {
+	news := &fakeNews{
+		events: make(chan *newsv1.NewsEvent),
+		err:    status.Error(codes.ResourceExhausted, "client is too slow"),
+		last:   make(chan string, 1),
+	}
+	close(news.events)
+	srv := newStreamServer(t, &clients.Clients{News: news}, StreamOptions{Heartbeat: time.Hour})
+
+	resp, err := http.Get(srv.URL + "/news/stream?last_event_id=42")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "42", <-news.last)
+
+	rd := bufio.NewReader(resp.Body)
+	readEvent(t, rd) // retry:
+
+	ev := readEvent(t, rd)
+	require.Equal(t, "event: error", ev[0])
+	require.Contains(t, ev[1], `"code":"resource_exhausted"`)
+
+	rest, err := io.ReadAll(rd)
+	require.NoError(t, err)
+	require.Empty(t, string(rest))
+}
+
+func TestStreamComments_HeartbeatAndDone(t *testing.T) {
+	comments := &fakeComments{events: make(chan *commentsv1.CommentEvent, 1)}
+	done := make(chan struct{})
+	srv := newStreamServer(t, &clients.Clients{Comments: comments, Users: fakeUsers{}}, StreamOptions{
+		Heartbeat: 20 * time.Millisecond,
+		Done:      done,
+	})
+
+	resp, err := http.Get(srv.URL + "/news/3f2c9d1e-8b7a-4c6d-9e0f-1a2b3c4d5e6f/comments/stream")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	rd := bufio.NewReader(resp.Body)
+	readEvent(t, rd) // retry:
+
+	comments.events <- &commentsv1.CommentEvent{EventId: "c1", Comment: &commentsv1.Comment{Id: "c1", UserId: "u1"}}
+	ev := readEvent(t, rd)
+	require.Equal(t, []string{"id: c1", "event: comment"}, ev[:2])
+
+	comments.events <- &commentsv1.CommentEvent{
+		Kind:    commentsv1.CommentEventKind_COMMENT_DELETED,
+		Comment: &commentsv1.Comment{Id: "c1", IsDeleted: true},
+	}
+	ev = readEvent(t, rd)
+	require.Equal(t, "event: comment_deleted", ev[0], "deletions carry no id")
+
+	comments.events <- &commentsv1.CommentEvent{Kind: commentsv1.CommentEventKind(99), Comment: &commentsv1.Comment{Id: "x"}}
+	comments.events <- &commentsv1.CommentEvent{
+		Kind:    commentsv1.CommentEventKind_COMMENT_REACTION,
+		Comment: &commentsv1.Comment{Id: "c1", Reactions: map[string]int64{"like": 1}},
+	}
+	ev = readEvent(t, rd)
+	require.Equal(t, "event: comment_reaction", ev[0], "reactions carry no id, unknown kinds are skipped")
+	require.Contains(t, ev[1], `"id":"c1"`)
+	require.Contains(t, ev[1], `"reactions":{"like":1}`)
+
+	require.Equal(t, []string{": ping"}, readEvent(t, rd))
+
+	close(done)
+	_, err = io.ReadAll(rd)
+	require.NoError(t, err)
+}
+
+func TestStreamComments_InvalidNewsID(t *testing.T) {
+	srv := newStreamServer(t, &clients.Clients{}, StreamOptions{})
+
+	resp, err := http.Get(srv.URL + "/news/not-a-uuid/comments/stream")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+}
+
+func TestStreamNotifications(t *testing.T) {
+	comments := &fakeComments{notifications: make(chan *commentsv1.Notification, 1), notifyUser: make(chan string, 1)}
+	srv := newStreamServer(t, &clients.Clients{Comments: comments}, StreamOptions{Heartbeat: time.Hour})
+
+	resp, err := http.Get(srv.URL + "/users/bad/notifications/stream")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	uid := "3f2c9d1e-8b7a-4c6d-9e0f-1a2b3c4d5e6f"
+	resp, err = http.Get(srv.URL + "/users/" + uid + "/notifications/stream")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	require.Equal(t, uid, <-comments.notifyUser)
+
+	rd := bufio.NewReader(resp.Body)
+	readEvent(t, rd) // retry:
+
+	comments.notifications <- &commentsv1.Notification{Id: "n1", UserId: uid, Kind: commentsv1.NotificationKind_MENTION, CommentId: "c1"}
+	close(comments.notifications)
+
+	ev := readEvent(t, rd)
+	require.Len(t, ev, 2, "no id: the stream has no cursor")
+	require.Equal(t, "event: notification", ev[0])
+	require.Contains(t, ev[1], `"id":"n1"`)
+	require.Contains(t, ev[1], `"kind":"mention"`)
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+)
+
+func (h *Handlers) LockThread(w http.ResponseWriter, r *http.Request) {
+	h.setThreadLocked(w, r, true)
+}
+
+func (h *Handlers) UnlockThread(w http.ResponseWriter, r *http.Request) {
+	h.setThreadLocked(w, r, false)
+}
+
+// setThreadLocked — общая часть LockThread/UnlockThread.
+func (h *Handlers) setThreadLocked(w http.ResponseWriter, r *http.Request, locked bool) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	resp, err := h.Clients.Comments.LockThread(r.Context(), &commentsv1.LockThreadRequest{CommentId: id, Locked: locked})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.LockThreadFromProto(resp))
+}
+
+func (h *Handlers) GetThreadPolicy(w http.ResponseWriter, r *http.Request) {
+	newsID := chi.URLParam(r, "news_id")
+	if newsID == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	resp, err := h.Clients.Comments.GetThreadPolicy(r.Context(), &commentsv1.GetThreadPolicyRequest{NewsId: newsID})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.ThreadPolicyFromProto(resp.GetPolicy()))
+}
+
+func (h *Handlers) SetThreadPolicy(w http.ResponseWriter, r *http.Request) {
+	var in models.SetThreadPolicyRequest
+	if err := decodeStrict(r, &in); err != nil {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	in.NewsID = chi.URLParam(r, "news_id")
+	req, ok := in.ToProto()
+	if in.NewsID == "" || !ok {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	resp, err := h.Clients.Comments.SetThreadPolicy(r.Context(), req)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.ThreadPolicyFromProto(resp.GetPolicy()))
+}
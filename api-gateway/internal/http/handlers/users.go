@@ -2,11 +2,15 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	usersv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/users"
 	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
 	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (h *Handlers) GetProfile(w http.ResponseWriter, r *http.Request) {
@@ -25,6 +29,23 @@ func (h *Handlers) GetProfile(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, models.UserFromProto(resp))
 }
 
+// GetMe — полный профиль вызывающего (по токену); без валидного токена — 401.
+func (h *Handlers) GetMe(w http.ResponseWriter, r *http.Request) {
+	caller, ok := middleware.CallerFrom(r.Context())
+	if !ok {
+		apierrors.WriteError(w, r, status.Error(codes.Unauthenticated, "unauthenticated"))
+		return
+	}
+
+	resp, err := h.Clients.Users.ProfileByID(r.Context(), &usersv1.ProfileByIDRequest{UserId: caller.UserID})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.UserFromProto(resp))
+}
+
 func (h *Handlers) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
@@ -95,3 +116,66 @@ func (h *Handlers) AvatarConfirm(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, models.UserFromProto(resp))
 }
+
+func (h *Handlers) AvatarDelete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	resp, err := h.Clients.Users.DeleteAvatar(r.Context(), &usersv1.DeleteAvatarRequest{UserId: id})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.UserFromProto(resp))
+}
+
+func (h *Handlers) UsernameAvailability(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	username := q.Get("username")
+	if username == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	resp, err := h.Clients.Users.CheckUsernameAvailability(r.Context(), &usersv1.CheckUsernameAvailabilityRequest{
+		Username: username,
+		UserId:   q.Get("user_id"),
+	})
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.UsernameAvailabilityFromProto(resp))
+}
+
+func (h *Handlers) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	req := &usersv1.SearchProfilesRequest{Query: q.Get("q")}
+	if req.Query == "" {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n < 0 {
+			apierrors.WriteError(w, r, statusErrorInvalidArgument())
+			return
+		}
+
+		req.Limit = int32(n)
+	}
+
+	resp, err := h.Clients.Users.SearchProfiles(r.Context(), req)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.SearchUsersFromProto(resp))
+}
@@ -0,0 +1,525 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/fanout"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/ratelimit"
+	logctx "github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Значения WSOptions по умолчанию (config.WSConfig).
+const (
+	defaultWSMaxSubscriptions = 20
+	defaultWSMaxMessageBytes  = 16 << 10
+	defaultWSMessageRate      = 10
+	defaultWSMessageBurst     = 20
+	defaultWSCommentRate      = 6
+	defaultWSSendBuffer       = 64
+	defaultWSPingInterval     = 30 * time.Second
+	defaultWSWriteTimeout     = 10 * time.Second
+	defaultWSRequestTimeout   = 3 * time.Second
+)
+
+// Типы кадров /ws (models.WSClientMessage/WSServerMessage).
+const (
+	wsSubscribe   = "subscribe"
+	wsUnsubscribe = "unsubscribe"
+	wsComment     = "comment"
+	wsPing        = "ping"
+
+	wsSubscribed      = "subscribed"
+	wsUnsubscribed    = "unsubscribed"
+	wsCommentCreated  = "comment.created"
+	wsCommentDeleted  = "comment.deleted"
+	wsCommentReaction = "comment.reaction"
+	wsReset           = "reset"
+	wsAck             = "ack"
+	wsPong            = "pong"
+	wsError           = "error"
+)
+
+var (
+	wsConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "api_gateway",
+		Subsystem: "ws",
+		Name:      "connections",
+		Help:      "Открытые WebSocket-соединения /ws.",
+	})
+
+	wsDisconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "api_gateway",
+		Subsystem: "ws",
+		Name:      "disconnects_total",
+		Help:      "Закрытые WebSocket-соединения по причине (client, slow_client, frame_too_large, write_error, shutdown).",
+	}, []string{"reason"})
+)
+
+// WSOptions — параметры /ws; нулевые поля — значения по умолчанию.
+type WSOptions struct {
+	// Hub — общие апстрим-подписки веток; nil — /ws отвечает 503.
+	Hub *fanout.Hub
+	// MaxSubscriptions — предел веток на соединение.
+	MaxSubscriptions int
+	// MaxMessageBytes — предел кадра клиента; больший кадр закрывает соединение.
+	MaxMessageBytes int
+	// MessageRate/MessageBurst — кадров клиента в секунду и всплеск.
+	MessageRate  int
+	MessageBurst int
+	// CommentRate — комментариев в минуту с соединения.
+	CommentRate int
+	// SendBuffer — очередь исходящих кадров; переполнение — клиент не успевает, соединение закрывается.
+	SendBuffer int
+	// PingInterval — период ping-кадров; WriteTimeout — предел записи кадра.
+	PingInterval time.Duration
+	WriteTimeout time.Duration
+	// RequestTimeout — дедлайн CreateComment (timeouts.service).
+	RequestTimeout time.Duration
+	// AllowedOrigins — допустимые Origin рукопожатия; пусто — любые.
+	AllowedOrigins []string
+	// Done закрывается при остановке сервера: соединения закрываются,
+	// клиенты переподключаются к другой реплике.
+	Done <-chan struct{}
+}
+
+func (o WSOptions) withDefaults() WSOptions {
+	if o.MaxSubscriptions <= 0 {
+		o.MaxSubscriptions = defaultWSMaxSubscriptions
+	}
+	if o.MaxMessageBytes <= 0 {
+		o.MaxMessageBytes = defaultWSMaxMessageBytes
+	}
+	if o.MessageRate <= 0 {
+		o.MessageRate = defaultWSMessageRate
+	}
+	if o.MessageBurst <= 0 {
+		o.MessageBurst = defaultWSMessageBurst
+	}
+	if o.CommentRate <= 0 {
+		o.CommentRate = defaultWSCommentRate
+	}
+	if o.SendBuffer <= 0 {
+		o.SendBuffer = defaultWSSendBuffer
+	}
+	if o.PingInterval <= 0 {
+		o.PingInterval = defaultWSPingInterval
+	}
+	if o.WriteTimeout <= 0 {
+		o.WriteTimeout = defaultWSWriteTimeout
+	}
+	if o.RequestTimeout <= 0 {
+		o.RequestTimeout = defaultWSRequestTimeout
+	}
+
+	return o
+}
+
+// WebSocket — /ws: живые ветки комментариев нескольких новостей на одном соединении.
+//
+// Кадры клиента (JSON):
+//   - {"type":"subscribe","news_id":...} / {"type":"unsubscribe","news_id":...};
+//   - {"type":"comment","news_id":...,"parent_id":...,"content":...} —
+//     публикация от имени владельца токена, username — из его профиля (поле username
+//     в кадре игнорируется); токен передаётся при подключении
+//     (Authorization: Bearer или ?access_token=) и проверяется до апгрейда;
+//   - {"type":"ping"}.
+//
+// Кадры шлюза: subscribed/unsubscribed/ack/pong — ответы с id кадра клиента;
+// comment.created/comment.deleted/comment.reaction/reset — события веток; error — с APIError.
+//
+// Каждая ветка — подписка fanout.Hub: одна апстрим-подписка на новость на реплику.
+// Лимиты соединения — число веток, размер и частота кадров, частота комментариев;
+// клиент, не успевающий читать (переполнение SendBuffer), отключается.
+func (h *Handlers) WebSocket(w http.ResponseWriter, r *http.Request) {
+	opts := h.WS.withDefaults()
+	if opts.Hub == nil {
+		apierrors.WriteError(w, r, status.Error(codes.Unavailable, "websocket is disabled"))
+		return
+	}
+
+	// Без апгрейда — обычный JSON-ответ, а не ошибка рукопожатия после hijack.
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		apierrors.WriteError(w, r, statusErrorInvalidArgument())
+		return
+	}
+
+	srv := websocket.Server{
+		Handshake: func(_ *websocket.Config, req *http.Request) error {
+			return checkOrigin(req, opts.AllowedOrigins)
+		},
+		Handler: func(ws *websocket.Conn) { h.serveWS(r, ws, opts) },
+	}
+	srv.ServeHTTP(hijackWriter{ResponseWriter: w}, r)
+}
+
+// checkOrigin сверяет Origin рукопожатия со списком; пустой список — любой Origin
+// (клиенты без браузера Origin не шлют).
+func checkOrigin(r *http.Request, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	origin := r.Header.Get("Origin")
+	if slices.Contains(allowed, origin) {
+		return nil
+	}
+
+	return fmt.Errorf("origin %q is not allowed", origin)
+}
+
+// hijackWriter даёт websocket.Server http.Hijacker сквозь обёртки middleware (Unwrap).
+type hijackWriter struct {
+	http.ResponseWriter
+}
+
+func (w hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// wsConn — состояние одного соединения /ws.
+type wsConn struct {
+	h    *Handlers
+	ws   *websocket.Conn
+	opts WSOptions
+	// r — исходный запрос с вызывающим (middleware.Identity); контекст — ctx.
+	r      *http.Request
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	out    chan models.WSServerMessage
+	limits *ratelimit.Memory
+
+	stopOnce sync.Once
+
+	mu   sync.Mutex
+	subs map[string]*fanout.Subscription
+}
+
+// serveWS обслуживает соединение после рукопожатия. Контекст hijack-нутого
+// запроса не отменяется при разрыве, поэтому у соединения собственный.
+func (h *Handlers) serveWS(r *http.Request, ws *websocket.Conn, opts WSOptions) {
+	ctx, cancel := context.WithCancel(r.Context())
+	ws.MaxPayloadBytes = opts.MaxMessageBytes
+
+	c := &wsConn{
+		h:      h,
+		ws:     ws,
+		opts:   opts,
+		r:      r.WithContext(ctx),
+		ctx:    ctx,
+		cancel: cancel,
+		out:    make(chan models.WSServerMessage, opts.SendBuffer),
+		limits: ratelimit.NewMemory(),
+		subs:   make(map[string]*fanout.Subscription),
+	}
+
+	wsConnections.Inc()
+	defer wsConnections.Dec()
+
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		c.writeLoop()
+	}()
+
+	c.readLoop()
+	c.stop("client")
+	c.closeSubs()
+	<-writerDone
+}
+
+// stop завершает соединение; причина учитывается один раз — первая.
+func (c *wsConn) stop(reason string) {
+	c.stopOnce.Do(func() {
+		wsDisconnects.WithLabelValues(reason).Inc()
+		c.cancel()
+	})
+}
+
+// send ставит кадр в очередь без ожидания: переполненная очередь — клиент
+// не успевает читать, и соединение закрывается, а не тормозит ветки.
+func (c *wsConn) send(m models.WSServerMessage) {
+	select {
+	case c.out <- m:
+	case <-c.ctx.Done():
+	default:
+		logctx.From(c.ctx).Info("ws: send buffer overflow, closing")
+		c.stop("slow_client")
+	}
+}
+
+// sendError — кадр error с кодом apierrors; msg заменяет общее сообщение
+// для ошибок самого шлюза (ошибки апстрима отдаются без деталей).
+func (c *wsConn) sendError(in models.WSClientMessage, err error, msg string) {
+	_, resp := apierrors.ToHTTP(err)
+	if msg != "" {
+		resp.Error.Message = msg
+	}
+	if rid := c.r.Header.Get("X-Request-Id"); rid != "" {
+		resp.Error.RequestID = rid
+	}
+
+	c.send(models.WSServerMessage{Type: wsError, ID: in.ID, NewsID: in.NewsID, Error: &resp.Error})
+}
+
+// writeLoop — единственный писатель соединения: кадры очереди и ping.
+// При остановке дописывает уже поставленные кадры (например, последний error)
+// и закрывает соединение — это же прерывает readLoop.
+func (c *wsConn) writeLoop() {
+	defer c.ws.Close()
+
+	ping := time.NewTicker(c.opts.PingInterval)
+	defer ping.Stop()
+
+	write := func(m models.WSServerMessage) error {
+		if err := c.ws.SetWriteDeadline(time.Now().Add(c.opts.WriteTimeout)); err != nil {
+			return err
+		}
+		return websocket.JSON.Send(c.ws, m)
+	}
+
+	for {
+		select {
+		case m := <-c.out:
+			if err := write(m); err != nil {
+				c.stop("write_error")
+				return
+			}
+		case <-ping.C:
+			if err := c.ping(); err != nil {
+				c.stop("write_error")
+				return
+			}
+		case <-c.opts.Done:
+			c.stop("shutdown")
+			return
+		case <-c.ctx.Done():
+			for {
+				select {
+				case m := <-c.out:
+					if write(m) != nil {
+						return
+					}
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// ping пишет ping-кадр; браузер отвечает pong сам, а мёртвое соединение
+// упирается в WriteTimeout.
+func (c *wsConn) ping() error {
+	if err := c.ws.SetWriteDeadline(time.Now().Add(c.opts.WriteTimeout)); err != nil {
+		return err
+	}
+
+	c.ws.PayloadType = websocket.PingFrame
+	defer func() { c.ws.PayloadType = websocket.TextFrame }()
+
+	_, err := c.ws.Write(nil)
+	return err
+}
+
+// readLoop читает кадры клиента до разрыва, слишком большого кадра или остановки.
+func (c *wsConn) readLoop() {
+	for {
+		var in models.WSClientMessage
+		err := websocket.JSON.Receive(c.ws, &in)
+
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case err == nil:
+		case errors.As(err, &syntaxErr) || errors.As(err, &typeErr):
+			c.sendError(in, statusErrorInvalidArgument(), "malformed frame")
+			continue
+		case errors.Is(err, websocket.ErrFrameTooLarge):
+			c.sendError(in, status.Error(codes.ResourceExhausted, ""), "frame too large")
+			c.stop("frame_too_large")
+			return
+		default:
+			return
+		}
+
+		if c.ctx.Err() != nil {
+			return
+		}
+		c.handle(in)
+	}
+}
+
+// handle разбирает кадр клиента.
+func (c *wsConn) handle(in models.WSClientMessage) {
+	d, _ := c.limits.Allow(c.ctx, "messages", ratelimit.Rule{Rate: c.opts.MessageRate, Burst: c.opts.MessageBurst})
+	if !d.Allowed {
+		c.sendError(in, status.Error(codes.ResourceExhausted, ""), "too many messages")
+		return
+	}
+
+	switch in.Type {
+	case wsSubscribe:
+		c.subscribe(in)
+	case wsUnsubscribe:
+		c.unsubscribe(in)
+	case wsComment:
+		c.comment(in)
+	case wsPing:
+		c.send(models.WSServerMessage{Type: wsPong, ID: in.ID})
+	default:
+		c.sendError(in, statusErrorInvalidArgument(), "unknown frame type")
+	}
+}
+
+// subscribe подписывает соединение на ветку; повторная подписка — тот же ответ.
+func (c *wsConn) subscribe(in models.WSClientMessage) {
+	if _, err := uuid.Parse(in.NewsID); err != nil {
+		c.sendError(in, statusErrorInvalidArgument(), "invalid news_id")
+		return
+	}
+
+	c.mu.Lock()
+	if _, ok := c.subs[in.NewsID]; ok {
+		c.mu.Unlock()
+		c.send(models.WSServerMessage{Type: wsSubscribed, ID: in.ID, NewsID: in.NewsID})
+		return
+	}
+	if len(c.subs) >= c.opts.MaxSubscriptions {
+		c.mu.Unlock()
+		c.sendError(in, status.Error(codes.ResourceExhausted, ""), "too many subscriptions")
+		return
+	}
+	sub := c.opts.Hub.Subscribe(in.NewsID)
+	c.subs[in.NewsID] = sub
+	c.mu.Unlock()
+
+	c.send(models.WSServerMessage{Type: wsSubscribed, ID: in.ID, NewsID: in.NewsID})
+	go c.forward(in.NewsID, sub)
+}
+
+// forward переносит события ветки в очередь соединения до закрытия подписки.
+func (c *wsConn) forward(newsID string, sub *fanout.Subscription) {
+	for ev := range sub.C {
+		c.send(wsEvent(newsID, ev))
+	}
+
+	if !sub.Lagged() {
+		return
+	}
+
+	// Подписку отключил брокер: освобождаем место и сообщаем клиенту.
+	c.mu.Lock()
+	if c.subs[newsID] == sub {
+		delete(c.subs, newsID)
+	}
+	c.mu.Unlock()
+	sub.Close()
+
+	c.sendError(models.WSClientMessage{NewsID: newsID}, status.Error(codes.ResourceExhausted, ""), "subscription lagged, resubscribe")
+}
+
+// wsEvent — кадр события ветки.
+func wsEvent(newsID string, ev fanout.Event) models.WSServerMessage {
+	switch ev.Kind {
+	case fanout.EventReset:
+		return models.WSServerMessage{Type: wsReset, NewsID: newsID}
+	case fanout.EventDeleted:
+		return models.WSServerMessage{Type: wsCommentDeleted, NewsID: newsID, Comment: &ev.Comment}
+	case fanout.EventReaction:
+		return models.WSServerMessage{Type: wsCommentReaction, NewsID: newsID, Comment: &ev.Comment}
+	default:
+		return models.WSServerMessage{Type: wsCommentCreated, NewsID: newsID, EventID: ev.EventID, Comment: &ev.Comment}
+	}
+}
+
+// unsubscribe снимает подписку; отписка от неподписанной ветки — тот же ответ.
+func (c *wsConn) unsubscribe(in models.WSClientMessage) {
+	c.mu.Lock()
+	sub, ok := c.subs[in.NewsID]
+	delete(c.subs, in.NewsID)
+	c.mu.Unlock()
+
+	if ok {
+		sub.Close()
+	}
+	c.send(models.WSServerMessage{Type: wsUnsubscribed, ID: in.ID, NewsID: in.NewsID})
+}
+
+// comment публикует комментарий от имени владельца токена подключения.
+// Токен проверен при рукопожатии; истёкший за время соединения — unauthenticated
+// (клиент переподключается с новым).
+func (c *wsConn) comment(in models.WSClientMessage) {
+	if exp, ok := middleware.TokenExpiresAt(c.ctx); ok && time.Now().After(exp) {
+		c.sendError(in, status.Error(codes.Unauthenticated, ""), "token expired, reconnect")
+		return
+	}
+
+	req := models.CreateCommentRequest{
+		NewsID:   in.NewsID,
+		ParentID: in.ParentID,
+		Content:  in.Content,
+	}
+	if err := bindCaller(c.r, &req.UserID); err != nil {
+		c.sendError(in, err, "")
+		return
+	}
+
+	d, _ := c.limits.Allow(c.ctx, "comments", ratelimit.Rule{Rate: c.opts.CommentRate, Per: time.Minute})
+	if !d.Allowed {
+		c.sendError(in, status.Error(codes.ResourceExhausted, ""), "too many comments")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.opts.RequestTimeout)
+	defer cancel()
+
+	username, err := c.h.authorUsername(ctx, req.UserID)
+	if err != nil {
+		c.sendError(in, err, "")
+		return
+	}
+
+	resp, err := c.h.Clients.Comments.CreateComment(ctx, req.ToProto(username))
+	if err != nil {
+		c.sendError(in, err, "")
+		return
+	}
+
+	out := models.CreateCommentFromProto(resp)
+	if out.Comment != nil {
+		c.h.EnrichComment(ctx, out.Comment)
+	}
+	c.send(models.WSServerMessage{Type: wsAck, ID: in.ID, NewsID: in.NewsID, Comment: out.Comment})
+}
+
+// closeSubs закрывает все подписки соединения.
+func (c *wsConn) closeSubs() {
+	c.mu.Lock()
+	subs := c.subs
+	c.subs = make(map[string]*fanout.Subscription)
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.Close()
+	}
+}
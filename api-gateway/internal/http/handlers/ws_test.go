@@ -0,0 +1,253 @@
+package handlers
+
+// Тесты /ws (ws.go):
+//   - подписка двух соединений на новость — одна апстрим-подписка, события обоим;
+//   - comment.deleted, comment.reaction, unsubscribe, ping/pong;
+//   - лимиты: число веток, битый кадр, слишком большой кадр закрывает соединение;
+//   - публикация: без токена — unauthenticated, с ?access_token= — от имени владельца
+//     (username — из его профиля), без профиля — failed_precondition;
+//   - без апгрейда — 400, без Hub — 503.
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/auth"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/clients"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/fanout"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
+)
+
+const wsNewsID = "3f2c9d1e-8b7a-4c6d-9e0f-1a2b3c4d5e6f"
+
+// wsComments — апстрим для /ws: общий канал событий, счётчик WatchComments,
+// последний CreateComment.
+type wsComments struct {
+	commentsv1.CommentsServiceClient
+	events  chan *commentsv1.CommentEvent
+	watches atomic.Int32
+	created chan *commentsv1.CreateCommentRequest
+}
+
+func (f *wsComments) WatchComments(ctx context.Context, _ *commentsv1.WatchCommentsRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[commentsv1.CommentEvent], error) {
+	f.watches.Add(1)
+	return &fakeStream[commentsv1.CommentEvent]{ctx: ctx, events: f.events}, nil
+}
+
+func (f *wsComments) CreateComment(_ context.Context, in *commentsv1.CreateCommentRequest, _ ...grpc.CallOption) (*commentsv1.CreateCommentResponse, error) {
+	f.created <- in
+	return &commentsv1.CreateCommentResponse{Comment: &commentsv1.Comment{Id: "c9", NewsId: in.GetNewsId(), UserId: in.GetUserId()}}, nil
+}
+
+// wsVerifier принимает токен "good" пользователя u1 и "noprofile" — пользователя без профиля.
+type wsVerifier struct{}
+
+func (wsVerifier) Verify(_ context.Context, token string) (auth.Claims, error) {
+	switch token {
+	case "good":
+		return auth.Claims{UserID: "u1", ExpiresAt: time.Now().Add(time.Hour)}, nil
+	case "noprofile":
+		return auth.Claims{UserID: "noprofile", ExpiresAt: time.Now().Add(time.Hour)}, nil
+	}
+	return auth.Claims{}, auth.ErrInvalidToken
+}
+
+func newWSServer(t *testing.T, comments *wsComments, opts WSOptions) *httptest.Server {
+	t.Helper()
+
+	cl := &clients.Clients{Comments: comments, Users: fakeUsers{}}
+	h := New(cl)
+	if opts.Hub == nil {
+		opts.Hub = fanout.New(comments, fanout.Options{})
+		t.Cleanup(opts.Hub.Close)
+	}
+	h.WS = opts
+
+	// Logging оборачивает ResponseWriter: апгрейд должен пройти сквозь обёртку.
+	srv := httptest.NewServer(middleware.Chain(http.HandlerFunc(h.WebSocket),
+		middleware.Logging(slog.New(slog.DiscardHandler)), middleware.AuthBearer(), middleware.Identity(wsVerifier{}, nil, nil), middleware.OptionalAuth()))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func dialWS(t *testing.T, srv *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws"+query, "", srv.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+
+	return ws
+}
+
+func wsSend(t *testing.T, ws *websocket.Conn, m models.WSClientMessage) {
+	t.Helper()
+	require.NoError(t, websocket.JSON.Send(ws, m))
+}
+
+func wsRecv(t *testing.T, ws *websocket.Conn) models.WSServerMessage {
+	t.Helper()
+
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var m models.WSServerMessage
+	require.NoError(t, websocket.JSON.Receive(ws, &m))
+
+	return m
+}
+
+func TestWebSocket_SubscribeAndEvents(t *testing.T) {
+	comments := &wsComments{events: make(chan *commentsv1.CommentEvent, 4)}
+	srv := newWSServer(t, comments, WSOptions{})
+
+	a := dialWS(t, srv, "")
+	b := dialWS(t, srv, "")
+	for _, ws := range []*websocket.Conn{a, b} {
+		wsSend(t, ws, models.WSClientMessage{Type: "subscribe", ID: "1", NewsID: wsNewsID})
+		m := wsRecv(t, ws)
+		require.Equal(t, "subscribed", m.Type)
+		require.Equal(t, "1", m.ID)
+	}
+	require.Equal(t, int32(1), comments.watches.Load(), "one upstream per thread")
+
+	comments.events <- &commentsv1.CommentEvent{EventId: "c1", Comment: &commentsv1.Comment{Id: "c1", NewsId: wsNewsID}}
+	for _, ws := range []*websocket.Conn{a, b} {
+		m := wsRecv(t, ws)
+		require.Equal(t, "comment.created", m.Type)
+		require.Equal(t, wsNewsID, m.NewsID)
+		require.Equal(t, "c1", m.EventID)
+		require.Equal(t, "c1", m.Comment.ID)
+	}
+
+	comments.events <- &commentsv1.CommentEvent{
+		Kind:    commentsv1.CommentEventKind_COMMENT_DELETED,
+		Comment: &commentsv1.Comment{Id: "c1", NewsId: wsNewsID, IsDeleted: true},
+	}
+	m := wsRecv(t, a)
+	require.Equal(t, "comment.deleted", m.Type)
+	require.True(t, m.Comment.IsDeleted)
+	require.Empty(t, m.EventID)
+
+	comments.events <- &commentsv1.CommentEvent{
+		Kind:    commentsv1.CommentEventKind_COMMENT_REACTION,
+		Comment: &commentsv1.Comment{Id: "c1", NewsId: wsNewsID, Reactions: map[string]int64{"like": 1}},
+	}
+	m = wsRecv(t, a)
+	require.Equal(t, "comment.reaction", m.Type)
+	require.Equal(t, map[string]int64{"like": 1}, m.Comment.Reactions)
+	require.Empty(t, m.EventID)
+
+	wsSend(t, a, models.WSClientMessage{Type: "unsubscribe", ID: "2", NewsID: wsNewsID})
+	require.Equal(t, "unsubscribed", wsRecv(t, a).Type)
+
+	wsSend(t, a, models.WSClientMessage{Type: "ping", ID: "3"})
+	m = wsRecv(t, a)
+	require.Equal(t, "pong", m.Type)
+	require.Equal(t, "3", m.ID)
+}
+
+func TestWebSocket_Limits(t *testing.T) {
+	comments := &wsComments{events: make(chan *commentsv1.CommentEvent)}
+	srv := newWSServer(t, comments, WSOptions{MaxSubscriptions: 1, MaxMessageBytes: 256})
+	ws := dialWS(t, srv, "")
+
+	wsSend(t, ws, models.WSClientMessage{Type: "subscribe", NewsID: "not-a-uuid"})
+	m := wsRecv(t, ws)
+	require.Equal(t, "error", m.Type)
+	require.Equal(t, "invalid_argument", m.Error.Code)
+
+	wsSend(t, ws, models.WSClientMessage{Type: "subscribe", NewsID: wsNewsID})
+	require.Equal(t, "subscribed", wsRecv(t, ws).Type)
+	wsSend(t, ws, models.WSClientMessage{Type: "subscribe", ID: "2", NewsID: "0b8d7a6c-5e4f-4a3b-9c2d-1e0f9a8b7c6d"})
+	m = wsRecv(t, ws)
+	require.Equal(t, "error", m.Type)
+	require.Equal(t, "2", m.ID)
+	require.Equal(t, "resource_exhausted", m.Error.Code)
+	require.Equal(t, "too many subscriptions", m.Error.Message)
+
+	require.NoError(t, websocket.Message.Send(ws, "{not json"))
+	m = wsRecv(t, ws)
+	require.Equal(t, "invalid_argument", m.Error.Code)
+
+	require.NoError(t, websocket.Message.Send(ws, `{"type":"ping","id":"`+strings.Repeat("x", 512)+`"}`))
+	m = wsRecv(t, ws)
+	require.Equal(t, "frame too large", m.Error.Message)
+
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var rest models.WSServerMessage
+	require.Error(t, websocket.JSON.Receive(ws, &rest), "connection is closed after an oversized frame")
+}
+
+func TestWebSocket_Comment(t *testing.T) {
+	comments := &wsComments{created: make(chan *commentsv1.CreateCommentRequest, 1)}
+	srv := newWSServer(t, comments, WSOptions{})
+	frame := models.WSClientMessage{Type: "comment", ID: "7", NewsID: wsNewsID, Content: "hi"}
+
+	anon := dialWS(t, srv, "")
+	wsSend(t, anon, frame)
+	m := wsRecv(t, anon)
+	require.Equal(t, "error", m.Type)
+	require.Equal(t, "unauthenticated", m.Error.Code)
+
+	ws := dialWS(t, srv, "?access_token=good")
+	wsSend(t, ws, frame)
+	m = wsRecv(t, ws)
+	require.Equal(t, "ack", m.Type)
+	require.Equal(t, "7", m.ID)
+	require.Equal(t, "c9", m.Comment.ID)
+
+	// Username — из профиля автора, а не из кадра клиента.
+	in := <-comments.created
+	require.Equal(t, "u1", in.GetUserId())
+	require.Equal(t, "name-u1", in.GetUsername())
+	require.Equal(t, "hi", in.GetContent())
+
+	// Устаревшее поле username в кадре игнорируется.
+	require.NoError(t, websocket.Message.Send(ws, `{"type":"comment","id":"8","news_id":"`+wsNewsID+`","username":"mallory","content":"hi"}`))
+	m = wsRecv(t, ws)
+	require.Equal(t, "ack", m.Type)
+	require.Equal(t, "name-u1", (<-comments.created).GetUsername())
+
+	// Без профиля комментировать нельзя.
+	np := dialWS(t, srv, "?access_token=noprofile")
+	wsSend(t, np, frame)
+	m = wsRecv(t, np)
+	require.Equal(t, "error", m.Type)
+	require.Equal(t, "failed_precondition", m.Error.Code)
+	require.Empty(t, comments.created)
+}
+
+func TestWebSocket_HandshakeErrors(t *testing.T) {
+	srv := newWSServer(t, &wsComments{}, WSOptions{})
+
+	resp, err := http.Get(srv.URL + "/ws")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Токен из query проверяется до апгрейда.
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/ws?access_token=bad", nil)
+	require.NoError(t, err)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	h := New(&clients.Clients{})
+	rec := httptest.NewRecorder()
+	h.WebSocket(rec, httptest.NewRequest(http.MethodGet, "/ws", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
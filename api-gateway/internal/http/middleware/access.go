@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/auth"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Политики доступа маршрутов. Ставятся при регистрации (r.With(...)) после Identity
+// и отвечают до вызова хендлера, то есть до любого запроса в апстримы:
+//   - Public — маршрут открыт всем, предъявленный токен ни на что не влияет;
+//   - OptionalAuth — вызывающий необязателен, но предъявленный токен должен быть валиден;
+//   - RequireAuth — нужен валидный токен и, если заданы, одна из ролей;
+//   - RequireSelf — вызывающий совпадает с user_id из пути (или admin).
+//
+// Ответы: невалидный токен и его отсутствие — 401, нет роли/чужой user_id — 403,
+// токен не удалось проверить (auth-service недоступен) — 503.
+
+// Public — явная отметка открытого маршрута (например, /auth/login).
+func Public() Middleware {
+	return func(next http.Handler) http.Handler { return next }
+}
+
+// OptionalAuth пропускает анонимные запросы и отклоняет запросы с непроверенным токеном.
+func OptionalAuth() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := authErrFrom(r.Context()); err != nil {
+				apierrors.WriteError(w, r, authStatus(err))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireAuth требует аутентифицированного вызывающего; roles — достаточно любой из них.
+func RequireAuth(roles ...string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := authErrFrom(r.Context()); err != nil {
+				apierrors.WriteError(w, r, authStatus(err))
+				return
+			}
+
+			caller, ok := CallerFrom(r.Context())
+			if !ok {
+				apierrors.WriteError(w, r, status.Error(codes.Unauthenticated, "unauthenticated"))
+				return
+			}
+
+			if len(roles) > 0 && !HasAnyRole(caller, roles...) {
+				apierrors.WriteError(w, r, status.Error(codes.PermissionDenied, "permission denied"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSelf требует, чтобы URL-параметр param совпадал с user_id вызывающего;
+// admin действует от имени любого пользователя. Ставится после RequireAuth.
+func RequireSelf(param string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			caller, ok := CallerFrom(r.Context())
+			if !ok {
+				apierrors.WriteError(w, r, status.Error(codes.Unauthenticated, "unauthenticated"))
+				return
+			}
+
+			if chi.URLParam(r, param) != caller.UserID && !caller.HasRole(identity.RoleAdmin) {
+				apierrors.WriteError(w, r, status.Error(codes.PermissionDenied, "permission denied"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// HasAnyRole — у вызывающего есть хотя бы одна из ролей.
+func HasAnyRole(c identity.Caller, roles ...string) bool {
+	for _, role := range roles {
+		if c.HasRole(role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authStatus — gRPC-статус для ошибки проверки токена.
+func authStatus(err error) error {
+	if errors.Is(err, auth.ErrInvalidToken) {
+		return status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	return status.Error(codes.Unavailable, "auth unavailable")
+}
@@ -10,23 +10,35 @@ import (
 
 // AuthBearer извлекает Bearer-токен из Authorization и кладёт "сырой" токен
 // в контекст по ключу interceptors.CtxAuthToken.
+//
+// Рукопожатие WebSocket (Upgrade: websocket) без Authorization может передать
+// токен в ?access_token=: браузерный WebSocket не умеет задавать заголовки.
 func AuthBearer() Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			auth := r.Header.Get("Authorization")
-
-			if auth != "" {
-				const prefix = "Bearer "
-				if strings.HasPrefix(auth, prefix) && len(auth) > len(prefix) {
-					token := strings.TrimSpace(auth[len(prefix):])
-
-					if token != "" {
-						ctx := context.WithValue(r.Context(), interceptors.CtxAuthToken, token)
-						r = r.WithContext(ctx)
-					}
-				}
+			token := bearerToken(r)
+			if token != "" {
+				ctx := context.WithValue(r.Context(), interceptors.CtxAuthToken, token)
+				r = r.WithContext(ctx)
 			}
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// bearerToken — токен из Authorization или (только для WebSocket) из ?access_token=.
+func bearerToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		const prefix = "Bearer "
+		if strings.HasPrefix(auth, prefix) && len(auth) > len(prefix) {
+			return strings.TrimSpace(auth[len(prefix):])
+		}
+		return ""
+	}
+
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return strings.TrimSpace(r.URL.Query().Get("access_token"))
+	}
+
+	return ""
+}
@@ -0,0 +1,248 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/respcache"
+	logctx "github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "api_gateway",
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Запросы кэшируемых маршрутов по результату: hit, miss, coalesced, bypass.",
+	}, []string{"route", "result"})
+
+	cacheErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "api_gateway",
+		Subsystem: "cache",
+		Name:      "backend_errors_total",
+		Help:      "Ошибки хранилища кэша ответов (запрос при этом идёт в апстрим).",
+	})
+)
+
+// defaultMaxCachedBody — предел тела кэшируемого ответа по умолчанию.
+const defaultMaxCachedBody = 1 << 20
+
+// CacheOptions — параметры Cache.
+type CacheOptions struct {
+	Store respcache.Store
+	// Rules — правила по "METHOD /pattern" маршрута; маршрут без правила не кэшируется.
+	Rules map[string]respcache.Rule
+	// Routes — роутер, в котором ищется шаблон маршрута.
+	Routes chi.Routes
+	// MaxBodyBytes — ответы больше не кэшируются; 0 — 1 MiB.
+	MaxBodyBytes int
+}
+
+// Cache кэширует ответы GET-маршрутов из Rules (ставится после RateLimit).
+//
+//   - анонимный запрос: ответ 200 берётся из Store или кладётся туда на Rule.TTL;
+//     одновременные промахи по одному ключу (путь + query) объединяются — в апстрим
+//     идёт один запрос. Cache-Control: public, max-age=<остаток TTL>;
+//   - запрос с Authorization (персональный ответ: is_read, only_unread, ...) мимо
+//     Store, Cache-Control: private, no-cache;
+//   - ответ 200 в обоих случаях получает сильный ETag; совпавший If-None-Match — 304.
+//
+// X-Cache: HIT | MISS | BYPASS. Ошибка хранилища не ломает запрос (fail-open).
+func Cache(opts CacheOptions) Middleware {
+	maxBody := opts.MaxBodyBytes
+	if maxBody <= 0 {
+		maxBody = defaultMaxCachedBody
+	}
+
+	var group singleflight.Group
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if opts.Store == nil || r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			route := r.Method + " " + routePattern(opts.Routes, r)
+			rule, ok := opts.Rules[route]
+			if !ok || rule.TTL <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Authorization")
+
+			_, authed := CallerFrom(r.Context())
+			if authed || r.Header.Get("Authorization") != "" {
+				cacheRequests.WithLabelValues(route, "bypass").Inc()
+
+				rec := newBufferWriter()
+				next.ServeHTTP(rec, r)
+				if rec.status != http.StatusOK {
+					rec.copyTo(w)
+					return
+				}
+
+				writeCached(w, r, rec.entry(time.Time{}), "private, no-cache", "BYPASS")
+				return
+			}
+
+			ctx := r.Context()
+			key := route + " " + r.URL.Path + "?" + r.URL.Query().Encode()
+
+			e, found, err := opts.Store.Get(ctx, key)
+			if err != nil {
+				cacheErrors.Inc()
+				logctx.From(ctx).Warn("response cache get failed", "err", err)
+			}
+			if found {
+				cacheRequests.WithLabelValues(route, "hit").Inc()
+				writeCached(w, r, e, publicCacheControl(e.TTL(time.Now())), "HIT")
+				return
+			}
+
+			// Промах: ведущий запрос выполняет хендлер, остальные ждут его ответ.
+			leader := false
+			v, _, _ := group.Do(key, func() (any, error) {
+				leader = true
+				rec := newBufferWriter()
+				next.ServeHTTP(rec, r)
+				if rec.status == http.StatusOK && rec.body.Len() <= maxBody {
+					e := rec.entry(time.Now().Add(rule.TTL))
+					rec.cached = &e
+				}
+				return rec, nil
+			})
+			rec := v.(*bufferWriter)
+
+			if !leader {
+				// Неуспешный ответ ведущего (в т.ч. с его request_id) не разделяем.
+				if rec.cached == nil {
+					cacheRequests.WithLabelValues(route, "miss").Inc()
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				cacheRequests.WithLabelValues(route, "coalesced").Inc()
+				writeCached(w, r, *rec.cached, publicCacheControl(rec.cached.TTL(time.Now())), "MISS")
+				return
+			}
+
+			cacheRequests.WithLabelValues(route, "miss").Inc()
+			if rec.cached == nil {
+				rec.copyTo(w)
+				return
+			}
+
+			if err := opts.Store.Set(ctx, key, *rec.cached); err != nil {
+				cacheErrors.Inc()
+				logctx.From(ctx).Warn("response cache set failed", "err", err)
+			}
+
+			writeCached(w, r, *rec.cached, publicCacheControl(rule.TTL), "MISS")
+		})
+	}
+}
+
+// writeCached отдаёт ответ 200 с ETag или 304, если If-None-Match совпал.
+func writeCached(w http.ResponseWriter, r *http.Request, e respcache.Entry, cacheControl, xCache string) {
+	h := w.Header()
+	h.Set("ETag", e.ETag)
+	h.Set("Cache-Control", cacheControl)
+	h.Set("X-Cache", xCache)
+
+	if etagMatch(r.Header.Get("If-None-Match"), e.ETag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if e.ContentType != "" {
+		h.Set("Content-Type", e.ContentType)
+	}
+	h.Set("Content-Length", strconv.Itoa(len(e.Body)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(e.Body)
+}
+
+// etagMatch — слабое сравнение If-None-Match со списком тегов (RFC 9110, 13.1.2).
+func etagMatch(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || strings.TrimPrefix(t, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func publicCacheControl(ttl time.Duration) string {
+	return "public, max-age=" + strconv.FormatInt(int64(ttl/time.Second), 10)
+}
+
+// bufferWriter — ResponseWriter, который копит ответ в памяти.
+type bufferWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+	// cached — запись для кэша (nil — ответ не кэшируется); после Do только читается.
+	cached *respcache.Entry
+}
+
+func newBufferWriter() *bufferWriter {
+	return &bufferWriter{header: make(http.Header)}
+}
+
+func (b *bufferWriter) Header() http.Header { return b.header }
+
+func (b *bufferWriter) WriteHeader(code int) {
+	if b.status == 0 {
+		b.status = code
+	}
+}
+
+func (b *bufferWriter) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+
+	return b.body.Write(p)
+}
+
+// entry — запись кэша из ответа.
+func (b *bufferWriter) entry(expires time.Time) respcache.Entry {
+	body := b.body.Bytes()
+
+	return respcache.Entry{
+		ContentType: b.header.Get("Content-Type"),
+		Body:        body,
+		ETag:        respcache.ETag(body),
+		Expires:     expires,
+	}
+}
+
+// copyTo отдаёт накопленный ответ как есть.
+func (b *bufferWriter) copyTo(w http.ResponseWriter) {
+	for k, v := range b.header {
+		w.Header()[k] = v
+	}
+
+	status := b.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	w.WriteHeader(status)
+	_, _ = w.Write(b.body.Bytes())
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/auth"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/clients/interceptors"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
+	logctx "github.com/pribylovaa/go-news-aggregator/pkg/log"
+)
+
+// authErrKey — ключ контекста с ошибкой проверки токена (см. authErrFrom).
+type authErrKey struct{}
+
+// expiresKey — ключ контекста со сроком действия проверенного токена (см. TokenExpiresAt).
+type expiresKey struct{}
+
+// Identity определяет вызывающего по Bearer-токену (ставится после AuthBearer):
+// валидный токен -> identity.Caller в контексте по ключу interceptors.CtxCaller,
+// откуда ClientWithMetadata передаёт его сервисам (x-user-id/x-user-roles).
+// admins/moderators — user_id с ролями admin/moderator.
+//
+// Непроверенный токен (невалидный или auth-service недоступен) убирается из
+// контекста, чтобы не уйти в апстримы; запрос продолжается анонимно, а ответ
+// 401/503 отдаёт политика маршрута (OptionalAuth/RequireAuth).
+func Identity(v auth.Verifier, admins, moderators []string) Middleware {
+	adminSet := userSet(admins)
+	moderatorSet := userSet(moderators)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, _ := r.Context().Value(interceptors.CtxAuthToken).(string)
+			if token == "" || v == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, err := v.Verify(r.Context(), token)
+			if err != nil {
+				if !errors.Is(err, auth.ErrInvalidToken) {
+					logctx.From(r.Context()).Warn("identity: verify token failed", "err", err)
+				}
+
+				ctx := context.WithValue(r.Context(), interceptors.CtxAuthToken, "")
+				ctx = context.WithValue(ctx, authErrKey{}, err)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			caller := identity.Caller{UserID: claims.UserID}
+			if _, ok := adminSet[caller.UserID]; ok {
+				caller.Roles = append(caller.Roles, identity.RoleAdmin)
+			}
+			if _, ok := moderatorSet[caller.UserID]; ok {
+				caller.Roles = append(caller.Roles, identity.RoleModerator)
+			}
+
+			ctx := context.WithValue(r.Context(), interceptors.CtxCaller, caller)
+			ctx = context.WithValue(ctx, expiresKey{}, claims.ExpiresAt)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// CallerFrom возвращает аутентифицированного вызывающего из контекста запроса.
+func CallerFrom(ctx context.Context) (identity.Caller, bool) {
+	c, ok := ctx.Value(interceptors.CtxCaller).(identity.Caller)
+	return c, ok && c.Authenticated()
+}
+
+// TokenExpiresAt — срок действия токена вызывающего; false — токена нет или срок неизвестен.
+// Нужен долгоживущим соединениям (WebSocket), которые проверяют токен один раз.
+func TokenExpiresAt(ctx context.Context) (time.Time, bool) {
+	exp, _ := ctx.Value(expiresKey{}).(time.Time)
+	return exp, !exp.IsZero()
+}
+
+// authErrFrom — ошибка проверки предъявленного токена; nil — токена не было или он валиден.
+func authErrFrom(ctx context.Context) error {
+	err, _ := ctx.Value(authErrKey{}).(error)
+	return err
+}
+
+// userSet — множество непустых user_id.
+func userSet(ids []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" {
+			set[id] = struct{}{}
+		}
+	}
+
+	return set
+}
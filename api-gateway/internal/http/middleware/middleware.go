@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"net/http"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // Middleware — стандартный net/http мидлвар.
@@ -15,6 +17,22 @@ func Chain(h http.Handler, mws ...Middleware) http.Handler {
 	return h
 }
 
+// routePattern — шаблон chi маршрута запроса в routes ("" — не найден).
+// Работает и до маршрутизации (в Use): внутри смонтированного роутера
+// путь берётся относительно точки монтирования.
+func routePattern(routes chi.Routes, r *http.Request) string {
+	if routes == nil {
+		return ""
+	}
+
+	path := r.URL.Path
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+		path = rctx.RoutePath
+	}
+
+	return routes.Find(chi.NewRouteContext(), r.Method, path)
+}
+
 // statusWriter оборачивает ResponseWriter, чтобы перехватить статус и размер.
 type statusWriter struct {
 	http.ResponseWriter
@@ -37,6 +55,10 @@ func (w *statusWriter) Write(p []byte) (int, error) {
 	return count, err
 }
 
+// Unwrap — исходный ResponseWriter для http.ResponseController (Flush, SetWriteDeadline
+// в потоковых ответах).
+func (w *statusWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
 func newStatusWriter(w http.ResponseWriter) *statusWriter {
 	return &statusWriter{ResponseWriter: w}
 }
@@ -3,15 +3,25 @@ package middleware
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	authv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/auth"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/auth"
 	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/clients/interceptors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/ratelimit"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/respcache"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 // capHandler — тестовый slog.Handler, который:
@@ -194,6 +204,234 @@ func TestAuthBearer_IgnoresInvalidHeader(t *testing.T) {
 	require.False(t, found)
 }
 
+func TestAuthBearer_QueryTokenOnlyForWebSocket(t *testing.T) {
+	var token string
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _ = r.Context().Value(interceptors.CtxAuthToken).(string)
+		w.WriteHeader(http.StatusOK)
+	})
+	chain := Chain(h, AuthBearer())
+
+	// Обычный запрос: ?access_token= игнорируется.
+	chain.ServeHTTP(httptest.NewRecorder(), makeReq("/news?access_token=q"))
+	require.Empty(t, token)
+
+	// Рукопожатие WebSocket.
+	req := makeReq("/ws?access_token=q")
+	req.Header.Set("Upgrade", "websocket")
+	chain.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "q", token)
+
+	// Authorization важнее query.
+	req = makeReq("/ws?access_token=q")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Authorization", "Bearer h")
+	chain.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "h", token)
+}
+
+// verifierFunc — auth.Verifier из функции.
+type verifierFunc func(ctx context.Context, token string) (auth.Claims, error)
+
+func (f verifierFunc) Verify(ctx context.Context, token string) (auth.Claims, error) {
+	return f(ctx, token)
+}
+
+func TestIdentity_TokenExpiresAt(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	v := verifierFunc(func(context.Context, string) (auth.Claims, error) {
+		return auth.Claims{UserID: "u-1", ExpiresAt: exp}, nil
+	})
+
+	var got time.Time
+	var ok bool
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok = TokenExpiresAt(r.Context())
+	})
+	chain := Chain(h, AuthBearer(), Identity(v, nil, nil))
+
+	chain.ServeHTTP(httptest.NewRecorder(), makeReq("/ws"))
+	require.False(t, ok)
+
+	req := makeReq("/ws")
+	req.Header.Set("Authorization", "Bearer t")
+	chain.ServeHTTP(httptest.NewRecorder(), req)
+	require.True(t, ok)
+	require.Equal(t, exp, got)
+}
+
+// fakeValidator — auth.TokenValidator с фиксированными ответами по токену.
+type fakeValidator struct {
+	users map[string]string // token -> user_id
+	err   error
+	calls int
+}
+
+func (f *fakeValidator) ValidateToken(_ context.Context, in *authv1.ValidateTokenRequest, _ ...grpc.CallOption) (*authv1.ValidateTokenResponse, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	uid, ok := f.users[in.GetAccessToken()]
+	return &authv1.ValidateTokenResponse{Valid: ok, UserId: uid}, nil
+}
+
+func TestIdentity_ResolvesCaller(t *testing.T) {
+	v := &fakeValidator{users: map[string]string{"t-user": "u-1", "t-admin": "u-2", "t-mod": "u-3"}}
+
+	var got identity.Caller
+	var found bool
+	var token string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, found = CallerFrom(r.Context())
+		token, _ = r.Context().Value(interceptors.CtxAuthToken).(string)
+		w.WriteHeader(http.StatusOK)
+	})
+	chain := Chain(h, AuthBearer(), Identity(auth.NewRemote(v, 0, 0), []string{" u-2 "}, []string{"u-3"}))
+
+	do := func(auth string) {
+		req := makeReq("/me")
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		chain.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// Без токена auth-service не вызывается.
+	do("")
+	require.False(t, found)
+	require.Zero(t, v.calls)
+
+	do("Bearer t-user")
+	require.True(t, found)
+	require.Equal(t, identity.Caller{UserID: "u-1"}, got)
+	require.Equal(t, "t-user", token)
+
+	do("Bearer t-admin")
+	require.True(t, found)
+	require.True(t, got.HasRole(identity.RoleAdmin))
+	require.False(t, got.HasRole(identity.RoleModerator))
+
+	do("Bearer t-mod")
+	require.True(t, found)
+	require.Equal(t, []string{identity.RoleModerator}, got.Roles)
+
+	// Невалидный токен — анонимный запрос, токен в апстримы не уходит.
+	do("Bearer bogus")
+	require.False(t, found)
+	require.Empty(t, token)
+
+	// Ошибка auth-service — тоже анонимно, без паники/500.
+	v.err = errors.New("unavailable")
+	do("Bearer t-user")
+	require.False(t, found)
+	require.Empty(t, token)
+}
+
+// newAccessAPI — роутер с политиками доступа; upstream считает дошедшие до хендлера запросы.
+func newAccessAPI(v auth.TokenValidator, upstream *int) http.Handler {
+	ok := func(w http.ResponseWriter, _ *http.Request) {
+		*upstream++
+		w.WriteHeader(http.StatusOK)
+	}
+
+	r := chi.NewRouter()
+	r.Use(AuthBearer(), Identity(auth.NewRemote(v, time.Minute, 100), []string{"u-admin"}, []string{"u-mod"}))
+	r.With(Public()).Post("/auth/login", ok)
+	r.With(OptionalAuth()).Get("/news", ok)
+	r.With(RequireAuth()).Get("/me", ok)
+	r.With(RequireAuth(identity.RoleModerator, identity.RoleAdmin)).Post("/comments/{id}/lock", ok)
+	r.With(RequireAuth(), RequireSelf("id")).Patch("/users/{id}", ok)
+
+	return r
+}
+
+func TestAccessPolicies(t *testing.T) {
+	v := &fakeValidator{users: map[string]string{
+		"t-user":  "u-1",
+		"t-other": "u-2",
+		"t-mod":   "u-mod",
+		"t-admin": "u-admin",
+	}}
+
+	var upstream int
+	api := newAccessAPI(v, &upstream)
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		{"public_anonymous", http.MethodPost, "/auth/login", "", http.StatusOK},
+		{"public_bad_token", http.MethodPost, "/auth/login", "bogus", http.StatusOK},
+		{"optional_anonymous", http.MethodGet, "/news", "", http.StatusOK},
+		{"optional_user", http.MethodGet, "/news", "t-user", http.StatusOK},
+		{"optional_bad_token", http.MethodGet, "/news", "bogus", http.StatusUnauthorized},
+		{"required_anonymous", http.MethodGet, "/me", "", http.StatusUnauthorized},
+		{"required_bad_token", http.MethodGet, "/me", "bogus", http.StatusUnauthorized},
+		{"required_user", http.MethodGet, "/me", "t-user", http.StatusOK},
+		{"role_missing", http.MethodPost, "/comments/c1/lock", "t-user", http.StatusForbidden},
+		{"role_moderator", http.MethodPost, "/comments/c1/lock", "t-mod", http.StatusOK},
+		{"role_admin", http.MethodPost, "/comments/c1/lock", "t-admin", http.StatusOK},
+		{"self_owner", http.MethodPatch, "/users/u-1", "t-user", http.StatusOK},
+		{"self_other", http.MethodPatch, "/users/u-1", "t-other", http.StatusForbidden},
+		{"self_admin", http.MethodPatch, "/users/u-1", "t-admin", http.StatusOK},
+		{"self_anonymous", http.MethodPatch, "/users/u-1", "", http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			before := upstream
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rr := httptest.NewRecorder()
+			api.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.want, rr.Code)
+			if tc.want == http.StatusOK {
+				require.Equal(t, before+1, upstream)
+				return
+			}
+
+			// Отказ — до хендлера, в формате apierrors.
+			require.Equal(t, before, upstream)
+			var env errEnvelope
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &env))
+			require.NotEmpty(t, env.Error.Code)
+		})
+	}
+}
+
+func TestAccessPolicies_AuthUnavailable(t *testing.T) {
+	v := &fakeValidator{err: errors.New("unavailable")}
+
+	var upstream int
+	api := newAccessAPI(v, &upstream)
+
+	do := func(method, path, token string) int {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		api.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	require.Equal(t, http.StatusServiceUnavailable, do(http.MethodGet, "/me", "t-user"))
+	require.Equal(t, http.StatusServiceUnavailable, do(http.MethodGet, "/news", "t-user"))
+	require.Equal(t, http.StatusOK, do(http.MethodGet, "/news", ""))
+	require.Equal(t, http.StatusOK, do(http.MethodPost, "/auth/login", "t-user"))
+	require.Equal(t, 2, upstream)
+}
+
 func TestTimeout_SetsDeadline_WhenAbsent(t *testing.T) {
 	var hasDeadline bool
 	var left time.Duration
@@ -207,7 +445,7 @@ func TestTimeout_SetsDeadline_WhenAbsent(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	chain := Chain(h, Timeout(50*time.Millisecond))
+	chain := Chain(h, Timeout(TimeoutOptions{Timeout: 50 * time.Millisecond}))
 	rr := httptest.NewRecorder()
 	req := makeReq("/timeout")
 	chain.ServeHTTP(rr, req)
@@ -229,7 +467,7 @@ func TestTimeout_DoesNotOverrideExistingDeadline(t *testing.T) {
 	defer cancel()
 	req := makeReq("/timeout2").WithContext(parent)
 
-	chain := Chain(h, Timeout(1*time.Second)) // больше, чем у родителя
+	chain := Chain(h, Timeout(TimeoutOptions{Timeout: time.Second})) // больше, чем у родителя
 	rr := httptest.NewRecorder()
 	chain.ServeHTTP(rr, req)
 
@@ -237,6 +475,45 @@ func TestTimeout_DoesNotOverrideExistingDeadline(t *testing.T) {
 	require.WithinDuration(t, parentDL, childDL, time.Millisecond)
 }
 
+func TestTimeout_SkipsOnlyLongLivedRoutes(t *testing.T) {
+	var hasDeadline bool
+	h := func(w http.ResponseWriter, r *http.Request) {
+		_, hasDeadline = r.Context().Deadline()
+		w.WriteHeader(http.StatusOK)
+	}
+
+	// Маршруты смонтированы под /api, как при BasePath: шаблон ищется от корня.
+	root := chi.NewRouter()
+	root.Use(Timeout(TimeoutOptions{
+		Timeout:   50 * time.Millisecond,
+		Routes:    root,
+		LongLived: []string{"GET /api/news/stream", "GET /api/ws"},
+	}))
+	api := chi.NewRouter()
+	api.Get("/news", h)
+	api.Get("/news/stream", h)
+	api.Get("/ws", h)
+	root.Mount("/api", api)
+
+	do := func(path string, header ...string) bool {
+		req := makeReq(path)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		hasDeadline = false
+		root.ServeHTTP(httptest.NewRecorder(), req)
+		return hasDeadline
+	}
+
+	require.False(t, do("/api/news/stream"), "SSE without Accept header is still long-lived")
+	require.False(t, do("/api/ws"))
+	require.True(t, do("/api/news"))
+
+	// Заголовки не снимают дедлайн с обычных маршрутов.
+	require.True(t, do("/api/news", "Accept", "text/event-stream"))
+	require.True(t, do("/api/news", "Upgrade", "websocket", "Connection", "Upgrade"))
+}
+
 func TestRecover_ConvertsPanicTo500(t *testing.T) {
 	panicHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		panic("boom")
@@ -308,3 +585,285 @@ func TestStatusWriter_CountsBytes_AndDefaultStatus200(t *testing.T) {
 	require.Equal(t, http.StatusOK, sw.status) // статус умолчаний — 200
 	require.Equal(t, 4, sw.count)
 }
+
+func TestStatusWriter_FlushThroughResponseController(t *testing.T) {
+	rr := httptest.NewRecorder()
+	sw := newStatusWriter(rr)
+
+	_, _ = sw.Write([]byte("data: x\n\n"))
+	require.NoError(t, http.NewResponseController(sw).Flush())
+	require.True(t, rr.Flushed)
+}
+
+// errLimiter — бэкенд лимитов, который всегда недоступен.
+type errLimiter struct{}
+
+func (errLimiter) Allow(context.Context, string, ratelimit.Rule) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, errors.New("redis down")
+}
+
+// newRateLimitedAPI — роутер /api, как в NewRouter: лимиты на смонтированном под-роутере.
+func newRateLimitedAPI(l ratelimit.Limiter) http.Handler {
+	api := chi.NewRouter()
+	api.Use(RateLimit(RateLimitOptions{
+		Limiter: l,
+		Policies: ratelimit.Policies{
+			Default: ratelimit.Policy{
+				IP:   ratelimit.Rule{Rate: 3},
+				User: ratelimit.Rule{Rate: 5},
+			},
+			Routes: map[string]ratelimit.Policy{
+				"POST /auth/login": {IP: ratelimit.Rule{Rate: 1, Per: time.Minute}},
+			},
+		},
+		Routes: api,
+	}))
+
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+	api.Post("/auth/login", ok)
+	api.Get("/news/{id}", ok)
+	api.Get("/news", ok)
+
+	root := chi.NewRouter()
+	// Вызывающий — из заголовка (вместо Identity).
+	root.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if uid := r.Header.Get("X-Test-User"); uid != "" {
+				r = r.WithContext(context.WithValue(r.Context(), interceptors.CtxCaller, identity.Caller{UserID: uid}))
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	root.Mount("/api", api)
+
+	return root
+}
+
+func TestRateLimit_RoutePolicyAndHeaders(t *testing.T) {
+	h := newRateLimitedAPI(ratelimit.NewMemory())
+
+	login := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+		req.RemoteAddr = ip + ":1234"
+		req.Header.Set("X-Request-Id", "rid-1")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := login("10.0.0.1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "60", rec.Header().Get("RateLimit-Reset"))
+	require.Equal(t, "1;w=60", rec.Header().Get("RateLimit-Policy"))
+
+	rec = login("10.0.0.1")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "60", rec.Header().Get("Retry-After"))
+
+	var body errEnvelope
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "resource_exhausted", body.Error.Code)
+	require.Equal(t, "rid-1", body.Error.RequestID)
+
+	// Другой IP — своё ведро.
+	require.Equal(t, http.StatusOK, login("10.0.0.2").Code)
+}
+
+func TestRateLimit_DefaultPolicy_SharedBuckets_AndUserScope(t *testing.T) {
+	h := newRateLimitedAPI(ratelimit.NewMemory())
+
+	get := func(path, user string) int {
+		req := makeReq(path)
+		if user != "" {
+			req.Header.Set("X-Test-User", user)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Маршруты без своей политики делят ведро IP (ёмкость 3).
+	require.Equal(t, http.StatusOK, get("/api/news", ""))
+	require.Equal(t, http.StatusOK, get("/api/news/1", ""))
+	require.Equal(t, http.StatusOK, get("/api/news/2", ""))
+	require.Equal(t, http.StatusTooManyRequests, get("/api/news", ""))
+
+	// С того же IP аутентифицированный вызывающий лимитируется по user_id (ёмкость 5).
+	for i := 0; i < 5; i++ {
+		require.Equal(t, http.StatusOK, get("/api/news", "u-1"))
+	}
+	require.Equal(t, http.StatusTooManyRequests, get("/api/news", "u-1"))
+	require.Equal(t, http.StatusOK, get("/api/news", "u-2"))
+}
+
+func TestRateLimit_FailOpen_AndDisabled(t *testing.T) {
+	for _, l := range []ratelimit.Limiter{errLimiter{}, nil} {
+		h := newRateLimitedAPI(l)
+		for i := 0; i < 5; i++ {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, makeReq("/api/news"))
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Empty(t, rec.Header().Get("RateLimit-Limit"))
+		}
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	req := makeReq("/")
+	req.Header.Set("X-Forwarded-For", "1.1.1.1, 2.2.2.2")
+
+	require.Equal(t, "127.0.0.1", clientIP(req, false))
+	require.Equal(t, "2.2.2.2", clientIP(req, true))
+}
+
+// newCachedAPI — роутер /api, как в NewRouter: кэш на смонтированном под-роутере.
+// Хендлер /news/{id} отвечает телом с id и считает вызовы; release (если задан)
+// задерживает ответ, "404" — ответ 404.
+func newCachedAPI(calls *atomic.Int32, release <-chan struct{}) http.Handler {
+	api := chi.NewRouter()
+	api.Use(Cache(CacheOptions{
+		Store:  respcache.NewMemory(100),
+		Rules:  map[string]respcache.Rule{"GET /news/{id}": {TTL: time.Minute}},
+		Routes: api,
+	}))
+
+	news := func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if release != nil {
+			<-release
+		}
+
+		id := chi.URLParam(r, "id")
+		if id == "404" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"` + id + `"}`))
+	}
+	api.Get("/news/{id}", news)
+	api.Get("/news", news)
+
+	root := chi.NewRouter()
+	root.Mount("/api", api)
+
+	return root
+}
+
+func TestCache_HitETagAndNotModified(t *testing.T) {
+	var calls atomic.Int32
+	h := newCachedAPI(&calls, nil)
+
+	get := func(target, inm string) *httptest.ResponseRecorder {
+		req := makeReq(target)
+		if inm != "" {
+			req.Header.Set("If-None-Match", inm)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/api/news/1", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "MISS", rec.Header().Get("X-Cache"))
+	require.Equal(t, "public, max-age=60", rec.Header().Get("Cache-Control"))
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, `{"id":"1"}`, rec.Body.String())
+	etag := rec.Header().Get("ETag")
+	require.Equal(t, respcache.ETag([]byte(`{"id":"1"}`)), etag)
+
+	rec = get("/api/news/1", "")
+	require.Equal(t, "HIT", rec.Header().Get("X-Cache"))
+	require.Equal(t, etag, rec.Header().Get("ETag"))
+	require.Equal(t, `{"id":"1"}`, rec.Body.String())
+	require.EqualValues(t, 1, calls.Load())
+
+	rec = get("/api/news/1", `"other", W/`+etag)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.String())
+	require.Equal(t, etag, rec.Header().Get("ETag"))
+
+	// Другой ключ — свой промах; маршрут без правила не кэшируется.
+	require.Equal(t, "MISS", get("/api/news/2", "").Header().Get("X-Cache"))
+	rec = get("/api/news", "")
+	require.Empty(t, rec.Header().Get("X-Cache"))
+	require.Empty(t, rec.Header().Get("ETag"))
+	require.EqualValues(t, 3, calls.Load())
+}
+
+func TestCache_BypassForAuthorized(t *testing.T) {
+	var calls atomic.Int32
+	h := newCachedAPI(&calls, nil)
+
+	get := func(inm string) *httptest.ResponseRecorder {
+		req := makeReq("/api/news/1")
+		req.Header.Set("Authorization", "Bearer t")
+		if inm != "" {
+			req.Header.Set("If-None-Match", inm)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "BYPASS", rec.Header().Get("X-Cache"))
+	require.Equal(t, "private, no-cache", rec.Header().Get("Cache-Control"))
+	require.Equal(t, "Authorization", rec.Header().Get("Vary"))
+
+	rec = get(rec.Header().Get("ETag"))
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.EqualValues(t, 2, calls.Load())
+
+	// Персональные ответы не попали в общий кэш.
+	anon := httptest.NewRecorder()
+	h.ServeHTTP(anon, makeReq("/api/news/1"))
+	require.Equal(t, "MISS", anon.Header().Get("X-Cache"))
+}
+
+func TestCache_ErrorsNotCached(t *testing.T) {
+	var calls atomic.Int32
+	h := newCachedAPI(&calls, nil)
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, makeReq("/api/news/404"))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.Empty(t, rec.Header().Get("ETag"))
+	}
+	require.EqualValues(t, 2, calls.Load())
+}
+
+func TestCache_CoalescesConcurrentMisses(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	h := newCachedAPI(&calls, release)
+
+	const n = 5
+	recs := make([]*httptest.ResponseRecorder, n)
+	var wg sync.WaitGroup
+	for i := range recs {
+		recs[i] = httptest.NewRecorder()
+		wg.Add(1)
+		go func(rec *httptest.ResponseRecorder) {
+			defer wg.Done()
+			h.ServeHTTP(rec, makeReq("/api/news/1"))
+		}(recs[i])
+	}
+
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond) // остальные запросы ждут ведущего
+	close(release)
+	wg.Wait()
+
+	require.EqualValues(t, 1, calls.Load())
+	for _, rec := range recs {
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, `{"id":"1"}`, rec.Body.String())
+	}
+}
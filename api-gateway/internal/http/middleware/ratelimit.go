@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/ratelimit"
+	logctx "github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	rateLimitRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "api_gateway",
+		Subsystem: "rate_limit",
+		Name:      "rejected_total",
+		Help:      "Запросы, отклонённые с 429, по политике маршрута и типу ведра.",
+	}, []string{"route", "scope"})
+
+	rateLimitErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "api_gateway",
+		Subsystem: "rate_limit",
+		Name:      "backend_errors_total",
+		Help:      "Ошибки бэкенда лимитов (запрос при этом пропускается).",
+	})
+)
+
+// defaultRoute — метка и имя вёдер политики по умолчанию.
+const defaultRoute = "default"
+
+// RateLimitOptions — параметры RateLimit.
+type RateLimitOptions struct {
+	Limiter  ratelimit.Limiter
+	Policies ratelimit.Policies
+	// Routes — роутер, в котором ищется шаблон маршрута (ключ Policies.Routes).
+	// nil — все запросы по политике по умолчанию.
+	Routes chi.Routes
+	// TrustForwardedFor — брать IP клиента из последнего элемента X-Forwarded-For
+	// (только за доверенным прокси, который этот заголовок дописывает).
+	TrustForwardedFor bool
+}
+
+// RateLimit ограничивает частоту запросов токен-бакетами (ставится после Identity).
+//
+// Ведро выбирается так:
+//   - политика — Policies.Routes по "METHOD /pattern" маршрута, иначе Policies.Default;
+//   - аутентифицированный вызывающий — ведро по user_id (правило User), если оно задано;
+//   - иначе — ведро по IP клиента (правило IP); пустое правило не ограничивает.
+//
+// Ответ содержит RateLimit-Limit/Remaining/Reset и RateLimit-Policy; при отказе —
+// 429 в формате apierrors и Retry-After. Ошибка бэкенда не блокирует запрос
+// (fail-open): она логируется и считается в метрике.
+func RateLimit(opts RateLimitOptions) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if opts.Limiter == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			route, policy := opts.policyFor(r)
+
+			rule, scope, subject := policy.IP, "ip", clientIP(r, opts.TrustForwardedFor)
+			if caller, ok := CallerFrom(r.Context()); ok && policy.User.Enabled() {
+				rule, scope, subject = policy.User, "user", caller.UserID
+			}
+
+			if !rule.Enabled() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			d, err := opts.Limiter.Allow(r.Context(), scope+":"+subject+":"+route, rule)
+			if err != nil {
+				rateLimitErrors.Inc()
+				logctx.From(r.Context()).Warn("rate limit backend failed", "err", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(d.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+			h.Set("RateLimit-Reset", ceilSeconds(d.Reset))
+			h.Set("RateLimit-Policy", strconv.Itoa(rule.Capacity())+";w="+ceilSeconds(rule.Window()))
+
+			if !d.Allowed {
+				rateLimitRejected.WithLabelValues(route, scope).Inc()
+				h.Set("Retry-After", ceilSeconds(d.RetryAfter))
+				apierrors.WriteError(w, r, status.Error(codes.ResourceExhausted, "rate limit exceeded"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// policyFor возвращает ключ политики и её правила для запроса.
+func (o RateLimitOptions) policyFor(r *http.Request) (string, ratelimit.Policy) {
+	if o.Routes == nil || len(o.Policies.Routes) == 0 {
+		return defaultRoute, o.Policies.Default
+	}
+
+	pattern := routePattern(o.Routes, r)
+	if pattern == "" {
+		return defaultRoute, o.Policies.Default
+	}
+
+	key := r.Method + " " + pattern
+	if p, ok := o.Policies.Routes[key]; ok {
+		return key, p
+	}
+
+	return defaultRoute, o.Policies.Default
+}
+
+// clientIP — IP клиента без порта.
+func clientIP(r *http.Request, trustForwarded bool) string {
+	if trustForwarded {
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			parts := strings.Split(xff, ",")
+			if ip := strings.TrimSpace(parts[len(parts)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// ceilSeconds — длительность в целых секундах с округлением вверх.
+func ceilSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
@@ -3,27 +3,52 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"slices"
 	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
+// TimeoutOptions — параметры Timeout.
+type TimeoutOptions struct {
+	// Timeout — дедлайн запроса; <=0 делает мидлвар no-op.
+	Timeout time.Duration
+	// Routes — роутер, по которому ищется шаблон маршрута запроса.
+	Routes chi.Routes
+	// LongLived — долгоживущие маршруты "METHOD /pattern" (SSE, WebSocket):
+	// идут без дедлайна, их время жизни ограничивают сами хендлеры.
+	// Решает только маршрут, а не заголовки запроса.
+	LongLived []string
+}
+
 // Timeout навешивает deadline на запрос, если его ещё нет.
-// Значение <=0 делает мидлвар no-op.
-func Timeout(d time.Duration) Middleware {
+func Timeout(opts TimeoutOptions) Middleware {
 	return func(next http.Handler) http.Handler {
-		// Если d<=0, возвращаем исходный handler без обёртки.
-		if d <= 0 {
+		// Если Timeout<=0, возвращаем исходный handler без обёртки.
+		if opts.Timeout <= 0 {
 			return next
 		}
 
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if _, ok := r.Context().Deadline(); ok {
+			if _, ok := r.Context().Deadline(); ok || opts.isLongLived(r) {
 				next.ServeHTTP(w, r) // уважаем существующий deadline.
 				return
 			}
 
-			ctx, cancel := context.WithTimeout(r.Context(), d)
+			ctx, cancel := context.WithTimeout(r.Context(), opts.Timeout)
 			defer cancel()
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// isLongLived — маршрут запроса из LongLived.
+func (o TimeoutOptions) isLongLived(r *http.Request) bool {
+	if len(o.LongLived) == 0 {
+		return false
+	}
+
+	pattern := routePattern(o.Routes, r)
+
+	return pattern != "" && slices.Contains(o.LongLived, r.Method+" "+pattern)
+}
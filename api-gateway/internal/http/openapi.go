@@ -0,0 +1,155 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/handlers"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/openapi"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
+)
+
+// Теги операций.
+const (
+	tagAuth          = "auth"
+	tagNews          = "news"
+	tagComments      = "comments"
+	tagUsers         = "users"
+	tagNotifications = "notifications"
+	tagGraphQL       = "graphql"
+)
+
+// Query-параметры, которые встречаются в нескольких маршрутах.
+var (
+	paramIncludeDeleted = openapi.Param{Name: "include_deleted", Type: "boolean", Description: "Режим модератора: включать «надгробия» мягко удалённых, без текста (moderator/admin)."}
+	paramQuery          = openapi.Param{Name: "q", Type: "string", Required: true, Description: "Строка поиска."}
+	paramLastEventID    = openapi.Param{Name: "last_event_id", Type: "string", Description: "Курсор возобновления, если нельзя передать заголовок Last-Event-ID."}
+	headerLastEventID   = openapi.Param{Name: "Last-Event-ID", Type: "string", Description: "id последнего полученного события (EventSource передаёт сам)."}
+)
+
+// contentTypeEventStream — потоковые ответы (SSE): схема ответа описывает data события.
+const contentTypeEventStream = "text/event-stream"
+
+var moderatorRoles = []string{identity.RoleModerator, identity.RoleAdmin}
+
+// operations — описание каждого маршрута registerRoutes для OpenAPI.
+// Ключ — "METHOD /pattern"; маршрут без описания (и наоборот) ломает сборку документа.
+var operations = map[string]openapi.Operation{
+	// auth
+	"POST /auth/register": {Summary: "Регистрация", Tag: tagAuth, Access: openapi.AccessPublic, Body: models.AuthRegisterRequest{}, Response: models.AuthResponse{}},
+	"POST /auth/login":    {Summary: "Вход по email и паролю", Tag: tagAuth, Access: openapi.AccessPublic, Body: models.AuthLoginRequest{}, Response: models.AuthResponse{}},
+	"POST /auth/refresh":  {Summary: "Обновление пары токенов", Tag: tagAuth, Access: openapi.AccessPublic, Body: models.AuthRefreshRequest{}, Response: models.AuthResponse{}},
+	"POST /auth/revoke":   {Summary: "Отзыв refresh-токена", Tag: tagAuth, Access: openapi.AccessPublic, Body: models.AuthRevokeRequest{}, Response: models.AuthRevokeResponse{}},
+	"POST /auth/validate": {Summary: "Проверка access-токена", Tag: tagAuth, Access: openapi.AccessPublic, Body: models.AuthValidateRequest{}, Response: models.AuthValidateResponse{}},
+
+	// news
+	"GET /news": {
+		Summary: "Лента новостей; с ids — пакетное чтение", Tag: tagNews, Access: openapi.AccessOptional,
+		Query: []openapi.Param{
+			openapi.Limit, openapi.PageToken,
+			{Name: "only_unread", Type: "boolean", Description: "Только непрочитанные (нужен токен)."},
+			{Name: "ids", Type: "string", Description: "id через запятую (или повтором параметра): ответ — NewsBatchResponse."},
+		},
+		Response: openapi.OneOf{models.NewsListResponse{}, models.NewsBatchResponse{}},
+	},
+	"GET /news/personalized": {Summary: "Персональная лента", Tag: tagNews, Access: openapi.AccessOptional, Query: []openapi.Param{openapi.Limit, openapi.PageToken}, Response: models.NewsListResponse{}},
+	"GET /news/stream": {
+		Summary: "Живая лента: event news (id — курсор), reset, error", Tag: tagNews, Access: openapi.AccessOptional,
+		Query: []openapi.Param{paramLastEventID}, Headers: []openapi.Param{headerLastEventID},
+		Response: models.News{}, ContentType: contentTypeEventStream,
+	},
+	"GET /news/{id}": {Summary: "Новость по id (с токеном — отмечается прочитанной)", Tag: tagNews, Access: openapi.AccessOptional, Response: models.NewsGetResponse{}},
+	"GET /news/{id}/page": {
+		Summary: "Страница новости: новость, первая страница комментариев и авторы под одним дедлайном; не полученные секции — в missing", Tag: tagNews, Access: openapi.AccessOptional,
+		Query: []openapi.Param{openapi.PageSize}, Response: models.NewsPageResponse{},
+	},
+
+	// comments
+	"POST /comments": {Summary: "Создание комментария или ответа", Tag: tagComments, Access: openapi.AccessRequired, Body: models.CreateCommentRequest{}, Response: models.CreateCommentResponse{}, Status: http.StatusCreated},
+	"GET /comments/search": {
+		Summary: "Полнотекстовый поиск по комментариям", Tag: tagComments, Access: openapi.AccessOptional,
+		Query: []openapi.Param{
+			paramQuery,
+			{Name: "news_id", Type: "string", Description: "Фильтр по новости."},
+			{Name: "user_id", Type: "string", Description: "Фильтр по автору."},
+			paramIncludeDeleted, openapi.PageSize, openapi.PageToken,
+		},
+		Response: models.CommentsPageResponse{},
+	},
+	"GET /comments/{id}":           {Summary: "Комментарий по id", Tag: tagComments, Access: openapi.AccessOptional, Response: models.GetCommentResponse{}},
+	"GET /news/{news_id}/comments": {Summary: "Корневые комментарии новости", Tag: tagComments, Access: openapi.AccessOptional, Query: []openapi.Param{openapi.PageSize, openapi.PageToken}, Response: models.ListRootCommentsResponse{}},
+	"GET /news/{news_id}/comments/stream": {
+		Summary: "Комментарии новости: event comment (id — курсор), comment_deleted, comment_reaction, reset, error", Tag: tagComments, Access: openapi.AccessOptional,
+		Query: []openapi.Param{paramLastEventID}, Headers: []openapi.Param{headerLastEventID},
+		Response: models.Comment{}, ContentType: contentTypeEventStream,
+	},
+	"GET /ws": {
+		Summary: "WebSocket: живые ветки нескольких новостей и публикация комментариев (кадры — в README)", Tag: tagComments, Access: openapi.AccessOptional,
+		Query:  []openapi.Param{{Name: "access_token", Type: "string", Description: "Access-токен, если клиент не может передать заголовок Authorization (только при апгрейде)."}},
+		Status: http.StatusSwitchingProtocols,
+	},
+	"GET /comments/{id}/replies":                 {Summary: "Ответы на комментарий", Tag: tagComments, Access: openapi.AccessOptional, Query: []openapi.Param{openapi.PageSize, openapi.PageToken}, Response: models.ListRepliesResponse{}},
+	"POST /comments/{id}/mute":                   {Summary: "Заглушить уведомления ветки", Tag: tagComments, Access: openapi.AccessRequired, Body: models.MuteThreadRequest{}, Response: models.MuteThreadResponse{}},
+	"POST /comments/{id}/unmute":                 {Summary: "Вернуть уведомления ветки", Tag: tagComments, Access: openapi.AccessRequired, Body: models.MuteThreadRequest{}, Response: models.MuteThreadResponse{}},
+	"PUT /comments/{id}/reactions/{reaction}":    {Summary: "Поставить реакцию (like, love, laugh, wow, sad, angry)", Tag: tagComments, Access: openapi.AccessRequired, Response: models.ReactResponse{}},
+	"DELETE /comments/{id}/reactions/{reaction}": {Summary: "Снять реакцию", Tag: tagComments, Access: openapi.AccessRequired, Response: models.ReactResponse{}},
+	"POST /comments/{id}/lock":                   {Summary: "Заблокировать ветку", Tag: tagComments, Access: openapi.AccessRequired, Roles: moderatorRoles, Response: models.LockThreadResponse{}},
+	"POST /comments/{id}/unlock":                 {Summary: "Разблокировать ветку", Tag: tagComments, Access: openapi.AccessRequired, Roles: moderatorRoles, Response: models.LockThreadResponse{}},
+	"GET /news/{news_id}/comments/policy":        {Summary: "Политика жизни веток новости", Tag: tagComments, Access: openapi.AccessOptional, Response: models.ThreadPolicy{}},
+	"PUT /news/{news_id}/comments/policy":        {Summary: "Установка политики веток", Tag: tagComments, Access: openapi.AccessRequired, Roles: moderatorRoles, Body: models.SetThreadPolicyRequest{}, Response: models.ThreadPolicy{}},
+
+	// graphql
+	"POST /graphql": {
+		Summary: "GraphQL: News, Comment и Profile одним запросом (схема — в README); ошибки полей — в errors с кодом 200", Tag: tagGraphQL, Access: openapi.AccessOptional,
+		Body: models.GraphQLRequest{}, Response: models.GraphQLResponse{},
+	},
+	// users
+	"GET /me":                         {Summary: "Профиль вызывающего", Tag: tagUsers, Access: openapi.AccessRequired, Response: models.User{}},
+	"GET /me/activity":                {Summary: "Комментарии подписок вызывающего", Tag: tagUsers, Access: openapi.AccessRequired, Query: []openapi.Param{openapi.PageSize, openapi.PageToken}, Response: models.CommentsPageResponse{}},
+	"GET /me/preferences":             {Summary: "Предпочтения ленты", Tag: tagUsers, Access: openapi.AccessRequired, Response: models.Preferences{}},
+	"PATCH /me/preferences":           {Summary: "Частичное обновление предпочтений", Tag: tagUsers, Access: openapi.AccessRequired, Body: models.UpdatePreferencesRequest{}, Response: models.Preferences{}},
+	"GET /me/bookmarks":               {Summary: "Закладки", Tag: tagUsers, Access: openapi.AccessRequired, Query: []openapi.Param{openapi.PageSize, openapi.PageToken}, Response: models.BookmarksResponse{}},
+	"PUT /me/bookmarks/{news_id}":     {Summary: "Добавить закладку", Tag: tagUsers, Access: openapi.AccessRequired, Response: models.BookmarkResponse{}},
+	"DELETE /me/bookmarks/{news_id}":  {Summary: "Удалить закладку", Tag: tagUsers, Access: openapi.AccessRequired, Response: models.BookmarkResponse{}},
+	"POST /me/read":                   {Summary: "Отметить новости прочитанными", Tag: tagNews, Access: openapi.AccessRequired, Body: models.MarkNewsReadRequest{}, Response: struct{}{}},
+	"POST /me/read/all":               {Summary: "Отметить прочитанной ленту до позиции", Tag: tagNews, Access: openapi.AccessRequired, Body: models.MarkAllNewsReadRequest{}, OptionalBody: true, Response: struct{}{}},
+	"POST /me/following/{id}":         {Summary: "Подписаться", Tag: tagUsers, Access: openapi.AccessRequired, Response: models.FollowResponse{}},
+	"DELETE /me/following/{id}":       {Summary: "Отписаться", Tag: tagUsers, Access: openapi.AccessRequired, Response: models.FollowResponse{}},
+	"GET /users/search":               {Summary: "Префиксный поиск профилей", Tag: tagUsers, Access: openapi.AccessOptional, Query: []openapi.Param{paramQuery, openapi.Limit}, Response: models.SearchUsersResponse{}},
+	"GET /users/availability":         {Summary: "Проверка username", Tag: tagUsers, Access: openapi.AccessOptional, Query: []openapi.Param{{Name: "username", Type: "string", Required: true}, {Name: "user_id", Type: "string", Description: "Свой username не считается занятым."}}, Response: models.UsernameAvailabilityResponse{}},
+	"GET /users/{id}":                 {Summary: "Профиль пользователя", Tag: tagUsers, Access: openapi.AccessOptional, Response: models.User{}},
+	"PATCH /users/{id}":               {Summary: "Изменение профиля", Tag: tagUsers, Access: openapi.AccessSelf, Body: models.UpdateUserRequest{}, Response: models.User{}},
+	"POST /users/{id}/avatar/presign": {Summary: "URL загрузки аватара", Tag: tagUsers, Access: openapi.AccessSelf, Body: models.AvatarPresignRequest{}, Response: models.AvatarPresignResponse{}},
+	"POST /users/{id}/avatar/confirm": {Summary: "Подтверждение загрузки аватара", Tag: tagUsers, Access: openapi.AccessSelf, Body: models.AvatarConfirmRequest{}, Response: models.User{}},
+	"DELETE /users/{id}/avatar":       {Summary: "Удаление аватара", Tag: tagUsers, Access: openapi.AccessSelf, Response: models.User{}},
+	"GET /users/{id}/comments":        {Summary: "Комментарии автора", Tag: tagComments, Access: openapi.AccessOptional, Query: []openapi.Param{paramIncludeDeleted, openapi.PageSize, openapi.PageToken}, Response: models.CommentsPageResponse{}},
+	"GET /users/{id}/followers":       {Summary: "Подписчики", Tag: tagUsers, Access: openapi.AccessOptional, Query: []openapi.Param{openapi.PageSize, openapi.PageToken}, Response: models.FollowsResponse{}},
+	"GET /users/{id}/following":       {Summary: "Подписки", Tag: tagUsers, Access: openapi.AccessOptional, Query: []openapi.Param{openapi.PageSize, openapi.PageToken}, Response: models.FollowsResponse{}},
+
+	// notifications
+	"GET /users/{id}/notifications": {
+		Summary: "Уведомления", Tag: tagNotifications, Access: openapi.AccessSelf,
+		Query:    []openapi.Param{{Name: "unread_only", Type: "boolean", Description: "Только непрочитанные."}, openapi.PageSize, openapi.PageToken},
+		Response: models.ListNotificationsResponse{},
+	},
+	"GET /users/{id}/notifications/unread_count": {Summary: "Число непрочитанных уведомлений", Tag: tagNotifications, Access: openapi.AccessSelf, Response: models.UnreadCountResponse{}},
+	"POST /users/{id}/notifications/read":        {Summary: "Отметить уведомления прочитанными", Tag: tagNotifications, Access: openapi.AccessSelf, Body: models.MarkReadRequest{}, Response: models.MarkReadResponse{}},
+	"GET /users/{id}/notifications/stream": {
+		Summary: "Новые уведомления: event notification, error; без возобновления — пропущенное читается из списка", Tag: tagNotifications, Access: openapi.AccessSelf,
+		Response: models.Notification{}, ContentType: contentTypeEventStream,
+	},
+}
+
+// OpenAPI собирает документ из таблицы маршрутов registerRoutes и operations.
+func OpenAPI() (*openapi.Document, error) {
+	r := chi.NewRouter()
+	registerRoutes(r, &handlers.Handlers{})
+
+	return openapi.Build(r, operations, openapi.Info{
+		Title:   "go-news-aggregator API",
+		Version: "1.0.0",
+		Server:  "/api",
+	})
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/auth"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/handlers"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+// Тесты документа OpenAPI:
+//   - openapi.json совпадает со сборкой из маршрутов и моделей (обновление: go test ./internal/http -run TestOpenAPI -update);
+//   - политика доступа в operations совпадает с реальной политикой маршрута;
+//   - /openapi.json и /docs отдаются роутером.
+
+var update = flag.Bool("update", false, "перезаписать internal/openapi/openapi.json")
+
+const specPath = "../openapi/openapi.json"
+
+func TestOpenAPI_SpecUpToDate(t *testing.T) {
+	doc, err := OpenAPI()
+	require.NoError(t, err)
+
+	got, err := json.MarshalIndent(doc, "", "  ")
+	require.NoError(t, err)
+	got = append(got, '\n')
+
+	if *update {
+		require.NoError(t, os.WriteFile(specPath, got, 0o644))
+		return
+	}
+
+	require.Equal(t, string(openapi.Spec), string(got),
+		"openapi.json устарел: go test ./internal/http -run TestOpenAPI_SpecUpToDate -update")
+}
+
+// stubVerifier — токен "user" — обычный пользователь u-1, остальные невалидны.
+type stubVerifier struct{}
+
+func (stubVerifier) Verify(_ context.Context, token string) (auth.Claims, error) {
+	if token == "user" {
+		return auth.Claims{UserID: "u-1"}, nil
+	}
+
+	return auth.Claims{}, auth.ErrInvalidToken
+}
+
+// Клиенты не заданы: запрос, дошедший до хендлера, паникует и превращается в 500,
+// отказ политики доступа — 401/403 до хендлера.
+func TestOpenAPI_AccessMatchesRoutes(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(middleware.Recover(), middleware.AuthBearer(), middleware.Identity(stubVerifier{}, nil, nil))
+	registerRoutes(r, &handlers.Handlers{})
+
+	do := func(method, path, token string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	for key, op := range operations {
+		method, pattern, _ := strings.Cut(key, " ")
+		// Путь с чужим user_id: RequireSelf отклоняет u-1.
+		path := strings.NewReplacer("{id}", "u-2", "{news_id}", "n-1").Replace(pattern)
+
+		t.Run(key, func(t *testing.T) {
+			switch op.Access {
+			case openapi.AccessPublic:
+				require.NotEqual(t, http.StatusUnauthorized, do(method, path, "bogus"))
+			case openapi.AccessOptional:
+				require.Equal(t, http.StatusUnauthorized, do(method, path, "bogus"))
+				require.NotEqual(t, http.StatusUnauthorized, do(method, path, ""))
+			case openapi.AccessRequired, openapi.AccessSelf:
+				require.Equal(t, http.StatusUnauthorized, do(method, path, ""))
+				code := do(method, path, "user")
+				if len(op.Roles) > 0 || op.Access == openapi.AccessSelf {
+					require.Equal(t, http.StatusForbidden, code)
+				} else {
+					require.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, code)
+				}
+			default:
+				t.Fatalf("unknown access %q", op.Access)
+			}
+		})
+	}
+}
+
+func TestRouter_ServesOpenAPI(t *testing.T) {
+	r := NewRouter(nil, Options{BasePath: "/api"})
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+	require.Equal(t, openapi.Version, doc["openapi"])
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Body.String(), "/docs/swagger-init.js")
+	require.NotEmpty(t, rr.Header().Get("Content-Security-Policy"))
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/docs/swagger-init.js", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Body.String(), "/openapi.json")
+}
+
+func TestRouter_LongLivedRoutesAreRegistered(t *testing.T) {
+	r := chi.NewRouter()
+	registerRoutes(r, &handlers.Handlers{})
+
+	for _, p := range longLivedRoutes {
+		require.Equal(t, p, r.Find(chi.NewRouteContext(), http.MethodGet, p), "long-lived route %s", p)
+	}
+}
@@ -2,14 +2,20 @@ package http
 
 import (
 	"log/slog"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/auth"
 	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/clients"
 	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/handlers"
 	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/http/middleware"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/openapi"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/ratelimit"
+	"github.com/pribylovaa/go-news-aggregator/api-gateway/internal/respcache"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
 )
 
 // Options — параметры сборки HTTP-роутера.
@@ -17,6 +23,32 @@ type Options struct {
 	Logger   *slog.Logger
 	Timeout  time.Duration
 	BasePath string
+	// Verifier — проверка access-токенов; nil — все запросы анонимные.
+	Verifier auth.Verifier
+	// Admins — user_id с ролью admin (config.AuthConfig.Admins).
+	Admins []string
+	// Moderators — user_id с ролью moderator (config.AuthConfig.Moderators).
+	Moderators []string
+	// RateLimiter — бэкенд лимитов; nil — без ограничений.
+	RateLimiter ratelimit.Limiter
+	// RateLimits — политики лимитов (config.RateLimitConfig).
+	RateLimits ratelimit.Policies
+	// TrustForwardedFor — IP клиента для лимитов из X-Forwarded-For.
+	TrustForwardedFor bool
+	// Cache — хранилище кэша ответов; nil — без кэша.
+	Cache respcache.Store
+	// CacheRules — TTL кэшируемых маршрутов (config.CacheConfig.Routes).
+	CacheRules map[string]respcache.Rule
+	// CacheMaxBodyBytes — предел тела кэшируемого ответа; 0 — 1 MiB.
+	CacheMaxBodyBytes int
+	// Stream — параметры потоков text/event-stream (config.StreamConfig).
+	Stream handlers.StreamOptions
+	// WS — параметры /ws (config.WSConfig, fanout.Hub).
+	WS handlers.WSOptions
+	// GraphQL — лимиты /graphql (config.GraphQLConfig).
+	GraphQL handlers.GraphQLOptions
+	// NewsPage — параметры /news/{id}/page (config.NewsPageConfig).
+	NewsPage handlers.NewsPageOptions
 }
 
 // NewRouter собирает chi-роутер с подключёнными middleware и регистрацией хендлеров.
@@ -30,50 +62,144 @@ func NewRouter(cl *clients.Clients, opts Options) chi.Router {
 		middleware.Logging(opts.Logger), // кладём request-scoped логгер в контекст и логируем
 		middleware.AuthBearer(),         // вынимаем Bearer токен в контекст для gRPC-клиентов
 	)
+	bp := normalizeBasePath(opts.BasePath)
 	if opts.Timeout > 0 {
-		root.Use(middleware.Timeout(opts.Timeout)) // общий дедлайн запроса
+		// Общий дедлайн запроса; потоковые маршруты — без него. Маршрут ищется
+		// от корня, поэтому шаблоны — с BasePath.
+		longLived := make([]string, 0, len(longLivedRoutes))
+		for _, p := range longLivedRoutes {
+			longLived = append(longLived, http.MethodGet+" "+bp+p)
+		}
+		root.Use(middleware.Timeout(middleware.TimeoutOptions{
+			Timeout:   opts.Timeout,
+			Routes:    root,
+			LongLived: longLived,
+		}))
 	}
+	root.Use(middleware.Identity(opts.Verifier, opts.Admins, opts.Moderators)) // токен -> вызывающий (x-user-id для апстримов)
 
 	// Зависимости хендлеров.
 	h := handlers.New(cl)
+	h.Stream = opts.Stream
+	h.WS = opts.WS
+	h.GraphQL = opts.GraphQL
+	h.NewsPage = opts.NewsPage
+
+	// Регистрация маршрутов. Лимиты и кэш — на роутере API: по нему ищется шаблон маршрута.
+	api := root
+	if bp != "" {
+		api = chi.NewRouter()
+	}
+
+	api.Use(middleware.RateLimit(middleware.RateLimitOptions{
+		Limiter:           opts.RateLimiter,
+		Policies:          opts.RateLimits,
+		Routes:            api,
+		TrustForwardedFor: opts.TrustForwardedFor,
+	}))
+	api.Use(middleware.Cache(middleware.CacheOptions{
+		Store:        opts.Cache,
+		Rules:        opts.CacheRules,
+		Routes:       api,
+		MaxBodyBytes: opts.CacheMaxBodyBytes,
+	}))
+	registerRoutes(api, h)
 
-	// Регистрация маршрутов.
-	bp := normalizeBasePath(opts.BasePath)
 	if bp != "" {
-		sub := chi.NewRouter()
-		registerRoutes(sub, h)
-		root.Mount(bp, sub)
-		return root
+		root.Mount(bp, api)
 	}
 
-	registerRoutes(root, h)
+	// Документация API: OpenAPI 3.1 и Swagger UI (вне BasePath, без аутентификации).
+	root.Get("/openapi.json", openapi.SpecHandler())
+	root.Get("/docs", openapi.UIHandler())
+	root.Get("/docs/swagger-init.js", openapi.UIInitHandler())
+
 	return root
 }
 
+// longLivedRoutes — потоковые GET-маршруты (SSE и WebSocket) без общего дедлайна:
+// соединение живёт, пока его не закроет клиент или хендлер.
+var longLivedRoutes = []string{
+	"/news/stream",
+	"/news/{news_id}/comments/stream",
+	"/ws",
+	"/users/{id}/notifications/stream",
+}
+
 // registerRoutes — единая точка регистрации всех REST-эндпойнтов.
+// Каждый маршрут регистрируется с политикой доступа (см. middleware.Public/OptionalAuth/RequireAuth):
+// отказ 401/403 уходит до вызова апстримов.
 func registerRoutes(r chi.Router, h *handlers.Handlers) {
+	var (
+		public   = r.With(middleware.Public())
+		optional = r.With(middleware.OptionalAuth())
+		user     = r.With(middleware.RequireAuth())
+		self     = r.With(middleware.RequireAuth(), middleware.RequireSelf("id"))
+		moder    = r.With(middleware.RequireAuth(identity.RoleModerator, identity.RoleAdmin))
+	)
+
 	// auth
-	r.Post("/auth/register", h.RegisterUser)
-	r.Post("/auth/login", h.LoginUser)
-	r.Post("/auth/refresh", h.RefreshToken)
-	r.Post("/auth/revoke", h.RevokeToken)
-	r.Post("/auth/validate", h.ValidateToken)
+	public.Post("/auth/register", h.RegisterUser)
+	public.Post("/auth/login", h.LoginUser)
+	public.Post("/auth/refresh", h.RefreshToken)
+	public.Post("/auth/revoke", h.RevokeToken)
+	public.Post("/auth/validate", h.ValidateToken)
 
 	// news
-	r.Get("/news", h.ListNews)
-	r.Get("/news/{id}", h.GetNewsByID)
+	optional.Get("/news", h.ListNews)
+	optional.Get("/news/personalized", h.ListPersonalizedNews)
+	optional.Get("/news/stream", h.StreamNews)
+	optional.Get("/news/{id}", h.GetNewsByID)
+	optional.Get("/news/{id}/page", h.GetNewsPage)
 
 	// comments
-	r.Post("/comments", h.CreateComment)
-	r.Get("/comments/{id}", h.GetCommentByID)
-	r.Get("/news/{news_id}/comments", h.ListRootComments)
-	r.Get("/comments/{id}/replies", h.ListReplies)
+	user.Post("/comments", h.CreateComment)
+	optional.Get("/comments/search", h.SearchComments)
+	optional.Get("/comments/{id}", h.GetCommentByID)
+	optional.Get("/news/{news_id}/comments", h.ListRootComments)
+	optional.Get("/news/{news_id}/comments/stream", h.StreamComments)
+	optional.Get("/ws", h.WebSocket)
+	optional.Get("/comments/{id}/replies", h.ListReplies)
+	user.Post("/comments/{id}/mute", h.MuteThread)
+	user.Post("/comments/{id}/unmute", h.UnmuteThread)
+	user.Put("/comments/{id}/reactions/{reaction}", h.AddReaction)
+	user.Delete("/comments/{id}/reactions/{reaction}", h.RemoveReaction)
+	moder.Post("/comments/{id}/lock", h.LockThread)
+	moder.Post("/comments/{id}/unlock", h.UnlockThread)
+	optional.Get("/news/{news_id}/comments/policy", h.GetThreadPolicy)
+	moder.Put("/news/{news_id}/comments/policy", h.SetThreadPolicy)
+
+	// graphql
+	optional.Post("/graphql", h.ExecuteGraphQL)
 
 	// users
-	r.Get("/users/{id}", h.GetProfile)
-	r.Patch("/users/{id}", h.UpdateProfile)
-	r.Post("/users/{id}/avatar/presign", h.AvatarPresign)
-	r.Post("/users/{id}/avatar/confirm", h.AvatarConfirm)
+	user.Get("/me", h.GetMe)
+	user.Get("/me/activity", h.MyActivity)
+	user.Get("/me/preferences", h.GetMyPreferences)
+	user.Patch("/me/preferences", h.UpdateMyPreferences)
+	user.Get("/me/bookmarks", h.ListBookmarks)
+	user.Put("/me/bookmarks/{news_id}", h.AddBookmark)
+	user.Delete("/me/bookmarks/{news_id}", h.RemoveBookmark)
+	user.Post("/me/read", h.MarkNewsRead)
+	user.Post("/me/read/all", h.MarkAllNewsRead)
+	user.Post("/me/following/{id}", h.Follow)
+	user.Delete("/me/following/{id}", h.Unfollow)
+	optional.Get("/users/search", h.SearchUsers)
+	optional.Get("/users/availability", h.UsernameAvailability)
+	optional.Get("/users/{id}", h.GetProfile)
+	self.Patch("/users/{id}", h.UpdateProfile)
+	self.Post("/users/{id}/avatar/presign", h.AvatarPresign)
+	self.Post("/users/{id}/avatar/confirm", h.AvatarConfirm)
+	self.Delete("/users/{id}/avatar", h.AvatarDelete)
+	optional.Get("/users/{id}/comments", h.ListUserComments)
+	optional.Get("/users/{id}/followers", h.ListFollowers)
+	optional.Get("/users/{id}/following", h.ListFollowing)
+
+	// notifications
+	self.Get("/users/{id}/notifications", h.ListNotifications)
+	self.Get("/users/{id}/notifications/unread_count", h.UnreadNotificationsCount)
+	self.Post("/users/{id}/notifications/read", h.MarkNotificationsRead)
+	self.Get("/users/{id}/notifications/stream", h.StreamNotifications)
 }
 
 // normalizeBasePath приводит BasePath к виду "/something" (или empty, если пустая строка).
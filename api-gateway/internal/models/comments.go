@@ -4,24 +4,35 @@ type Comment struct {
 	ID           string `json:"id"` // Mongo ObjectID
 	NewsID       string `json:"news_id"`
 	ParentID     string `json:"parent_id"` // "" — корень
+	RootID       string `json:"root_id"`   // корень ветки; "" — у самого корня
 	UserID       string `json:"user_id"`
 	Username     string `json:"username"`
-	Content      string `json:"content"`
+	Content      string `json:"content"`       // исходный текст (Markdown-подмножество)
+	ContentHTML  string `json:"content_html"`  // санитизированный HTML для отображения
 	Level        int32  `json:"level"`         // 0 — корень
 	RepliesCount int32  `json:"replies_count"` // прямые дети
 	IsDeleted    bool   `json:"is_deleted"`
+	IsLocked     bool   `json:"is_locked"`  // ветка только для чтения (модерация)
 	CreatedAt    int64  `json:"created_at"` // Unix UTC
 	UpdatedAt    int64  `json:"updated_at"` // Unix UTC
-	ExpiresAt    int64  `json:"expires_at"` // Unix UTC
+	ExpiresAt    int64  `json:"expires_at"` // Unix UTC; после — только чтение, 0 — бессрочно
+	// Счётчики реакций по типу (like, love, ...); нулевые не передаются.
+	Reactions map[string]int64 `json:"reactions,omitempty"`
+	// Актуальные данные автора из users-service (username в комментарии — снимок на момент записи).
+	// Пусто, если профиль не найден или users-service недоступен.
+	DisplayName string `json:"display_name,omitempty"`
+	AvatarURL   string `json:"avatar_url,omitempty"` // наименьшее превью, иначе оригинал
 }
 
-// Создание (корневой или ответ).
+// Создание (корневой или ответ). Username автора шлюз берёт из его профиля.
 type CreateCommentRequest struct {
 	NewsID   string `json:"news_id"`
 	ParentID string `json:"parent_id,omitempty"` // если задан — reply
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
+	UserID   string `json:"user_id,omitempty"`   // пусто — вызывающий
 	Content  string `json:"content"`
+	// Username — устарело и игнорируется: принимается, чтобы строгий декодер
+	// не отвечал 400 старым клиентам.
+	Username string `json:"username,omitempty" openapi:"deprecated"`
 }
 
 type CreateCommentResponse struct {
@@ -56,3 +67,101 @@ type ListRepliesResponse struct {
 	Comments      []Comment `json:"comments"`
 	NextPageToken string    `json:"next_page_token"`
 }
+
+// Комментарии автора (сначала новые): user_id берётся из пути.
+// IncludeDeleted — режим модератора: удалённые комментарии как «надгробия» (без текста).
+type ListUserCommentsRequest struct {
+	UserID         string `json:"user_id"`
+	IncludeDeleted bool   `json:"include_deleted"`
+	PageSize       int32  `json:"page_size"`
+	PageToken      string `json:"page_token"`
+}
+
+// Полнотекстовый поиск по комментариям; news_id/user_id — необязательные фильтры.
+type SearchCommentsRequest struct {
+	Query          string `json:"q"`
+	NewsID         string `json:"news_id"`
+	UserID         string `json:"user_id"`
+	IncludeDeleted bool   `json:"include_deleted"`
+	PageSize       int32  `json:"page_size"`
+	PageToken      string `json:"page_token"`
+}
+
+// Страница комментариев (история автора, результаты поиска).
+type CommentsPageResponse struct {
+	Comments      []Comment `json:"comments"`
+	NextPageToken string    `json:"next_page_token"`
+}
+
+// Блокировка/разблокировка ветки: id комментария берётся из пути.
+type LockThreadResponse struct {
+	Comment *Comment `json:"comment"` // корень ветки
+}
+
+// Ответ на постановку/снятие реакции: комментарий с актуальными счётчиками.
+type ReactResponse struct {
+	Comment *Comment `json:"comment"`
+}
+
+// Политика жизни веток новости.
+type ThreadPolicy struct {
+	NewsID  string `json:"news_id"`
+	Mode    string `json:"mode"`               // "default" | "forever" | "expire" | "locked"
+	TTLDays int32  `json:"ttl_days,omitempty"` // для "expire" (и эффективный TTL для "default")
+}
+
+// Установка политики: news_id берётся из пути.
+type SetThreadPolicyRequest struct {
+	NewsID  string `json:"-"`
+	Mode    string `json:"mode"`
+	TTLDays int32  `json:"ttl_days,omitempty"`
+}
+
+// Уведомление об ответе/упоминании.
+type Notification struct {
+	ID            string `json:"id"`
+	UserID        string `json:"user_id"` // получатель
+	Kind          string `json:"kind"`    // "reply" | "mention"
+	CommentID     string `json:"comment_id"`
+	NewsID        string `json:"news_id"`
+	ThreadID      string `json:"thread_id"`
+	ActorID       string `json:"actor_id"`
+	ActorUsername string `json:"actor_username"`
+	IsRead        bool   `json:"is_read"`
+	CreatedAt     int64  `json:"created_at"` // Unix UTC
+}
+
+// Список уведомлений пользователя.
+type ListNotificationsRequest struct {
+	UserID     string `json:"user_id"`
+	UnreadOnly bool   `json:"unread_only"`
+	PageSize   int32  `json:"page_size"`
+	PageToken  string `json:"page_token"`
+}
+type ListNotificationsResponse struct {
+	Notifications []Notification `json:"notifications"`
+	NextPageToken string         `json:"next_page_token"`
+}
+
+// Отметка прочтения: ids либо all=true.
+type MarkReadRequest struct {
+	UserID string   `json:"-"`
+	IDs    []string `json:"ids,omitempty"`
+	All    bool     `json:"all,omitempty"`
+}
+type MarkReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+type UnreadCountResponse struct {
+	Count int64 `json:"count"`
+}
+
+// Заглушение ветки: id комментария берётся из пути.
+type MuteThreadRequest struct {
+	UserID    string `json:"user_id,omitempty"` // пусто — вызывающий
+	CommentID string `json:"-"`
+}
+type MuteThreadResponse struct {
+	ThreadID string `json:"thread_id"`
+}
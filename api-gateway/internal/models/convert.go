@@ -1,6 +1,9 @@
 package models
 
 import (
+	"strconv"
+	"strings"
+
 	authv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/auth"
 	commentsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/comments"
 	newsv1 "github.com/pribylovaa/go-news-aggregator/api-gateway/gen/go/news"
@@ -80,7 +83,7 @@ func UserFromProto(u *usersv1.Profile) User {
 		return User{}
 	}
 
-	return User{
+	out := User{
 		UserID:    u.GetUserId(),
 		Username:  u.GetUsername(),
 		Age:       u.GetAge(),
@@ -90,7 +93,51 @@ func UserFromProto(u *usersv1.Profile) User {
 		UpdatedAt: u.GetUpdatedAt(),
 		Country:   u.GetCountry(),
 		Gender:    Gender(u.GetGender()),
+
+		FollowersCount: u.GetFollowersCount(),
+		FollowingCount: u.GetFollowingCount(),
+	}
+
+	if p := u.GetPrivacy(); p != nil {
+		out.Privacy = &UserPrivacy{
+			Age:     visibilityFromProto(p.GetAge()),
+			Gender:  visibilityFromProto(p.GetGender()),
+			Country: visibilityFromProto(p.GetCountry()),
+		}
+	}
+
+	for _, v := range u.GetAvatarVariants() {
+		if v.GetUrl() == "" {
+			continue
+		}
+
+		if out.AvatarURLs == nil {
+			out.AvatarURLs = make(map[string]string, len(u.GetAvatarVariants()))
+		}
+
+		out.AvatarURLs[strconv.FormatUint(uint64(v.GetSize()), 10)] = v.GetUrl()
+	}
+
+	return out
+}
+
+// FollowsFromProto — страница ListFollowers/ListFollowing.
+func FollowsFromProto(resp *usersv1.ListFollowsResponse) FollowsResponse {
+	out := FollowsResponse{
+		NextPageToken: resp.GetNextPageToken(),
+	}
+
+	if list := resp.GetEntries(); len(list) > 0 {
+		out.Entries = make([]FollowEntry, 0, len(list))
+		for _, it := range list {
+			out.Entries = append(out.Entries, FollowEntry{
+				User:       UserFromProto(it.GetProfile()),
+				FollowedAt: it.GetFollowedAt(),
+			})
+		}
 	}
+
+	return out
 }
 
 func (m UpdateUserRequest) ToProto() *usersv1.UpdateProfileRequest {
@@ -123,6 +170,26 @@ func (m UpdateUserRequest) ToProto() *usersv1.UpdateProfileRequest {
 		paths = append(paths, "gender")
 	}
 
+	if m.Privacy != nil {
+		req.Privacy = &usersv1.Privacy{}
+		for _, f := range []struct {
+			path string
+			val  string
+			dst  *usersv1.Visibility
+		}{
+			{"privacy.age", m.Privacy.Age, &req.Privacy.Age},
+			{"privacy.gender", m.Privacy.Gender, &req.Privacy.Gender},
+			{"privacy.country", m.Privacy.Country, &req.Privacy.Country},
+		} {
+			if f.val == "" {
+				continue
+			}
+
+			*f.dst = visibilityToProto(f.val)
+			paths = append(paths, f.path)
+		}
+	}
+
 	if len(paths) > 0 {
 		req.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
 	}
@@ -130,6 +197,113 @@ func (m UpdateUserRequest) ToProto() *usersv1.UpdateProfileRequest {
 	return req
 }
 
+func PreferencesFromProto(p *usersv1.Preferences) Preferences {
+	return Preferences{
+		FollowedCategories: nonNilStrings(p.GetFollowedCategories()),
+		MutedCategories:    nonNilStrings(p.GetMutedCategories()),
+		MutedSources:       nonNilStrings(p.GetMutedSources()),
+		Languages:          nonNilStrings(p.GetLanguages()),
+		UpdatedAt:          p.GetUpdatedAt(),
+	}
+}
+
+// nonNilStrings — пустой список отдаётся как [], а не null.
+func nonNilStrings(v []string) []string {
+	if v == nil {
+		return []string{}
+	}
+
+	return v
+}
+
+func BookmarksFromProto(r *usersv1.ListBookmarksResponse) BookmarksResponse {
+	out := BookmarksResponse{
+		Bookmarks:     make([]Bookmark, 0, len(r.GetBookmarks())),
+		NextPageToken: r.GetNextPageToken(),
+	}
+
+	for _, b := range r.GetBookmarks() {
+		item := Bookmark{
+			NewsID:      b.GetNewsId(),
+			SavedAt:     b.GetSavedAt(),
+			NewsMissing: b.GetNewsMissing(),
+		}
+
+		if n := b.GetNews(); n != nil {
+			item.News = &News{
+				ID:               n.GetId(),
+				Title:            n.GetTitle(),
+				Category:         n.GetCategory(),
+				ShortDescription: n.GetShortDescription(),
+				Link:             n.GetLink(),
+				ImageURL:         n.GetImageUrl(),
+				PublishedAt:      n.GetPublishedAt(),
+				Source:           n.GetSource(),
+				Language:         n.GetLanguage(),
+			}
+		}
+
+		out.Bookmarks = append(out.Bookmarks, item)
+	}
+
+	return out
+}
+
+// ToProto — update_mask формируется по переданным спискам.
+func (m UpdatePreferencesRequest) ToProto(userID string) *usersv1.UpdatePreferencesRequest {
+	req := &usersv1.UpdatePreferencesRequest{
+		UserId:      userID,
+		Preferences: &usersv1.Preferences{},
+		UpdateMask:  &fieldmaskpb.FieldMask{},
+	}
+
+	for _, f := range []struct {
+		path string
+		val  *[]string
+		dst  *[]string
+	}{
+		{"followed_categories", m.FollowedCategories, &req.Preferences.FollowedCategories},
+		{"muted_categories", m.MutedCategories, &req.Preferences.MutedCategories},
+		{"muted_sources", m.MutedSources, &req.Preferences.MutedSources},
+		{"languages", m.Languages, &req.Preferences.Languages},
+	} {
+		if f.val == nil {
+			continue
+		}
+
+		*f.dst = *f.val
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, f.path)
+	}
+
+	return req
+}
+
+// visibilityToProto — неизвестное значение уходит как недопустимый enum,
+// чтобы users-service ответил InvalidArgument.
+func visibilityToProto(v string) usersv1.Visibility {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "public":
+		return usersv1.Visibility_PUBLIC
+	case "registered":
+		return usersv1.Visibility_REGISTERED
+	case "private":
+		return usersv1.Visibility_PRIVATE
+	default:
+		return usersv1.Visibility(-1)
+	}
+}
+
+func visibilityFromProto(v usersv1.Visibility) string {
+	switch v {
+	case usersv1.Visibility_REGISTERED:
+		return "registered"
+	case usersv1.Visibility_PRIVATE:
+		return "private"
+	default:
+		return "public"
+	}
+}
+
 func (m AvatarPresignRequest) ToProto() *usersv1.AvatarUploadURLRequest {
 	return &usersv1.AvatarUploadURLRequest{
 		UserId:        m.UserID,
@@ -166,9 +340,31 @@ func (m AvatarConfirmRequest) ToProto() *usersv1.ConfirmAvatarUploadRequest {
 
 func (m NewsListRequest) ToProto() *newsv1.ListNewsRequest {
 	return &newsv1.ListNewsRequest{
-		Limit:     m.Limit,
-		PageToken: m.PageToken,
+		Limit:      m.Limit,
+		PageToken:  m.PageToken,
+		UserId:     m.UserID,
+		OnlyUnread: m.OnlyUnread,
+	}
+}
+
+func (m NewsBatchRequest) ToProto() *newsv1.NewsByIDsRequest {
+	return &newsv1.NewsByIDsRequest{
+		Ids:    m.IDs,
+		UserId: m.UserID,
+	}
+}
+
+func NewsBatchFromProto(r *newsv1.NewsByIDsResponse) NewsBatchResponse {
+	out := NewsBatchResponse{
+		Items:      make([]News, 0, len(r.GetItems())),
+		MissingIDs: nonNilStrings(r.GetMissingIds()),
+	}
+
+	for _, it := range r.GetItems() {
+		out.Items = append(out.Items, NewsFromProto(it))
 	}
+
+	return out
 }
 
 func (m NewsGetRequest) ToProto() *newsv1.NewsByIDRequest {
@@ -177,6 +373,20 @@ func (m NewsGetRequest) ToProto() *newsv1.NewsByIDRequest {
 	}
 }
 
+func (m MarkNewsReadRequest) ToProto(userID string) *newsv1.MarkReadRequest {
+	return &newsv1.MarkReadRequest{
+		UserId:  userID,
+		NewsIds: m.NewsIDs,
+	}
+}
+
+func (m MarkAllNewsReadRequest) ToProto(userID string) *newsv1.MarkAllReadRequest {
+	return &newsv1.MarkAllReadRequest{
+		UserId:    userID,
+		PageToken: m.PageToken,
+	}
+}
+
 func NewsFromProto(n *newsv1.News) News {
 	if n == nil {
 		return News{}
@@ -192,6 +402,9 @@ func NewsFromProto(n *newsv1.News) News {
 		ImageURL:         n.GetImageUrl(),
 		PublishedAt:      n.GetPublishedAt(),
 		FetchedAt:        n.GetFetchedAt(),
+		Source:           n.GetSource(),
+		Language:         n.GetLanguage(),
+		IsRead:           n.GetIsRead(),
 	}
 }
 
@@ -215,6 +428,23 @@ func NewsListFromProto(r *newsv1.ListNewsResponse) NewsListResponse {
 	return out
 }
 
+func PersonalizedNewsFromProto(r *newsv1.ListPersonalizedNewsResponse) NewsListResponse {
+	out := NewsListResponse{}
+	if r == nil {
+		return out
+	}
+
+	out.NextPageToken = r.GetNextPageToken()
+	if items := r.GetItems(); len(items) > 0 {
+		out.Items = make([]News, 0, len(items))
+		for _, it := range items {
+			out.Items = append(out.Items, NewsFromProto(it))
+		}
+	}
+
+	return out
+}
+
 func NewsGetFromProto(r *newsv1.NewsByIDResponse) NewsGetResponse {
 	if r == nil {
 		return NewsGetResponse{}
@@ -238,24 +468,50 @@ func CommentFromProto(c *commentsv1.Comment) Comment {
 		ID:           c.GetId(),
 		NewsID:       c.GetNewsId(),
 		ParentID:     c.GetParentId(),
+		RootID:       c.GetRootId(),
 		UserID:       c.GetUserId(),
 		Username:     c.GetUsername(),
 		Content:      c.GetContent(),
+		ContentHTML:  c.GetContentHtml(),
 		Level:        c.GetLevel(),
 		RepliesCount: c.GetRepliesCount(),
 		IsDeleted:    c.GetIsDeleted(),
+		IsLocked:     c.GetIsLocked(),
 		CreatedAt:    c.GetCreatedAt(),
 		UpdatedAt:    c.GetUpdatedAt(),
 		ExpiresAt:    c.GetExpiresAt(),
+		Reactions:    c.GetReactions(),
+	}
+}
+
+// MergeCommentCounts проставляет счётчики комментариев элементам ленты (по news_id).
+func MergeCommentCounts(items []News, r *commentsv1.CountsByNewsResponse) {
+	byID := make(map[string]*commentsv1.NewsCounts, len(r.GetCounts()))
+	for _, c := range r.GetCounts() {
+		byID[c.GetNewsId()] = c
+	}
+
+	for i := range items {
+		c, ok := byID[items[i].ID]
+		if !ok {
+			continue
+		}
+
+		items[i].Comments = &CommentCounts{
+			Total:          c.GetTotal(),
+			Roots:          c.GetRoots(),
+			LastActivityAt: c.GetLastActivityAt(),
+		}
 	}
 }
 
-func (m CreateCommentRequest) ToProto() *commentsv1.CreateCommentRequest {
+// ToProto — запрос к comments-service; username — из профиля автора (см. CreateCommentRequest).
+func (m CreateCommentRequest) ToProto(username string) *commentsv1.CreateCommentRequest {
 	return &commentsv1.CreateCommentRequest{
 		NewsId:   m.NewsID,
 		ParentId: m.ParentID,
 		UserId:   m.UserID,
-		Username: m.Username,
+		Username: username,
 		Content:  m.Content,
 	}
 }
@@ -348,3 +604,217 @@ func ListRepliesFromProto(r *commentsv1.ListRepliesResponse) ListRepliesResponse
 
 	return out
 }
+
+// История комментариев автора.
+func (m ListUserCommentsRequest) ToProto() *commentsv1.ListByUserRequest {
+	return &commentsv1.ListByUserRequest{
+		UserId:         m.UserID,
+		IncludeDeleted: m.IncludeDeleted,
+		PageSize:       m.PageSize,
+		PageToken:      m.PageToken,
+	}
+}
+
+// Полнотекстовый поиск.
+func (m SearchCommentsRequest) ToProto() *commentsv1.SearchCommentsRequest {
+	return &commentsv1.SearchCommentsRequest{
+		Query:          m.Query,
+		NewsId:         m.NewsID,
+		UserId:         m.UserID,
+		IncludeDeleted: m.IncludeDeleted,
+		PageSize:       m.PageSize,
+		PageToken:      m.PageToken,
+	}
+}
+
+// CommentsPageFromProto — общий конвертер страниц ListByUser/SearchComments.
+func CommentsPageFromProto(list []*commentsv1.Comment, nextPageToken string) CommentsPageResponse {
+	out := CommentsPageResponse{
+		NextPageToken: nextPageToken,
+	}
+
+	if len(list) > 0 {
+		out.Comments = make([]Comment, 0, len(list))
+		for _, it := range list {
+			out.Comments = append(out.Comments, CommentFromProto(it))
+		}
+	}
+
+	return out
+}
+
+// Политики веток.
+var threadPolicyModes = map[string]commentsv1.ThreadPolicyMode{
+	"default": commentsv1.ThreadPolicyMode_THREAD_POLICY_MODE_UNSPECIFIED,
+	"forever": commentsv1.ThreadPolicyMode_FOREVER,
+	"expire":  commentsv1.ThreadPolicyMode_EXPIRE,
+	"locked":  commentsv1.ThreadPolicyMode_LOCKED,
+}
+
+func LockThreadFromProto(r *commentsv1.LockThreadResponse) LockThreadResponse {
+	if r == nil || r.GetComment() == nil {
+		return LockThreadResponse{}
+	}
+
+	c := CommentFromProto(r.GetComment())
+	return LockThreadResponse{Comment: &c}
+}
+
+func ReactFromProto(r *commentsv1.ReactResponse) ReactResponse {
+	if r == nil || r.GetComment() == nil {
+		return ReactResponse{}
+	}
+
+	c := CommentFromProto(r.GetComment())
+	return ReactResponse{Comment: &c}
+}
+
+func ThreadPolicyFromProto(p *commentsv1.ThreadPolicy) ThreadPolicy {
+	if p == nil {
+		return ThreadPolicy{}
+	}
+
+	out := ThreadPolicy{NewsID: p.GetNewsId(), TTLDays: p.GetTtlDays()}
+	for name, mode := range threadPolicyModes {
+		if mode == p.GetMode() {
+			out.Mode = name
+		}
+	}
+
+	return out
+}
+
+// ToProto возвращает false при неизвестном mode (пустой mode — "default").
+func (m SetThreadPolicyRequest) ToProto() (*commentsv1.SetThreadPolicyRequest, bool) {
+	name := m.Mode
+	if name == "" {
+		name = "default"
+	}
+
+	mode, ok := threadPolicyModes[name]
+	if !ok {
+		return nil, false
+	}
+
+	return &commentsv1.SetThreadPolicyRequest{Policy: &commentsv1.ThreadPolicy{
+		NewsId:  m.NewsID,
+		Mode:    mode,
+		TtlDays: m.TTLDays,
+	}}, true
+}
+
+// Уведомления.
+func NotificationFromProto(n *commentsv1.Notification) Notification {
+	if n == nil {
+		return Notification{}
+	}
+
+	var kind string
+	switch n.GetKind() {
+	case commentsv1.NotificationKind_REPLY:
+		kind = "reply"
+	case commentsv1.NotificationKind_MENTION:
+		kind = "mention"
+	}
+
+	return Notification{
+		ID:            n.GetId(),
+		UserID:        n.GetUserId(),
+		Kind:          kind,
+		CommentID:     n.GetCommentId(),
+		NewsID:        n.GetNewsId(),
+		ThreadID:      n.GetThreadId(),
+		ActorID:       n.GetActorId(),
+		ActorUsername: n.GetActorUsername(),
+		IsRead:        n.GetIsRead(),
+		CreatedAt:     n.GetCreatedAt(),
+	}
+}
+
+func (m ListNotificationsRequest) ToProto() *commentsv1.ListNotificationsRequest {
+	return &commentsv1.ListNotificationsRequest{
+		UserId:     m.UserID,
+		UnreadOnly: m.UnreadOnly,
+		PageSize:   m.PageSize,
+		PageToken:  m.PageToken,
+	}
+}
+
+func ListNotificationsFromProto(r *commentsv1.ListNotificationsResponse) ListNotificationsResponse {
+	out := ListNotificationsResponse{
+		Notifications: []Notification{},
+	}
+	if r == nil {
+		return out
+	}
+
+	out.NextPageToken = r.GetNextPageToken()
+	for _, it := range r.GetNotifications() {
+		out.Notifications = append(out.Notifications, NotificationFromProto(it))
+	}
+
+	return out
+}
+
+func (m MarkReadRequest) ToProto() *commentsv1.MarkReadRequest {
+	return &commentsv1.MarkReadRequest{
+		UserId: m.UserID,
+		Ids:    m.IDs,
+		All:    m.All,
+	}
+}
+
+func (m MuteThreadRequest) ToProto() *commentsv1.MuteThreadRequest {
+	return &commentsv1.MuteThreadRequest{
+		UserId:    m.UserID,
+		CommentId: m.CommentID,
+	}
+}
+
+func UsernameAvailabilityFromProto(r *usersv1.CheckUsernameAvailabilityResponse) UsernameAvailabilityResponse {
+	return UsernameAvailabilityResponse{
+		Username:  r.GetUsername(),
+		Available: r.GetAvailable(),
+		Reason:    r.GetReason(),
+	}
+}
+
+func SearchUsersFromProto(r *usersv1.SearchProfilesResponse) SearchUsersResponse {
+	out := SearchUsersResponse{Users: []User{}}
+	for _, p := range r.GetProfiles() {
+		out.Users = append(out.Users, UserFromProto(p))
+	}
+
+	return out
+}
+
+// MergeAuthors проставляет комментариям актуальные display_name/avatar_url авторов
+// из ответа ProfilesByIDs; комментарии ненайденных авторов остаются как есть.
+func MergeAuthors(comments []Comment, r *usersv1.ProfilesByIDsResponse) {
+	profiles := r.GetProfiles()
+	for i := range comments {
+		p, ok := profiles[comments[i].UserID]
+		if !ok {
+			continue
+		}
+
+		comments[i].DisplayName = p.GetUsername()
+		comments[i].AvatarURL = authorAvatarURL(p)
+	}
+}
+
+// authorAvatarURL — наименьшее превью с публичным URL, иначе avatar_url оригинала.
+func authorAvatarURL(p *usersv1.Profile) string {
+	var best *usersv1.AvatarVariant
+	for _, v := range p.GetAvatarVariants() {
+		if v.GetUrl() != "" && (best == nil || v.GetSize() < best.GetSize()) {
+			best = v
+		}
+	}
+
+	if best != nil {
+		return best.GetUrl()
+	}
+
+	return p.GetAvatarUrl()
+}
@@ -1,8 +1,10 @@
 package models
 
 type NewsListRequest struct {
-	Limit     int32  `json:"limit"`      // == proto limit
-	PageToken string `json:"page_token"` // == proto page_token
+	Limit      int32  `json:"limit"`       // == proto limit
+	PageToken  string `json:"page_token"`  // == proto page_token
+	UserID     string `json:"-"`           // вызывающий: отметки is_read
+	OnlyUnread bool   `json:"only_unread"` // только непрочитанные; требует вызывающего
 }
 
 type NewsListResponse struct {
@@ -10,6 +12,17 @@ type NewsListResponse struct {
 	NextPageToken string `json:"next_page_token"`
 }
 
+// Пакетное чтение: GET /news?ids=a,b,c.
+type NewsBatchRequest struct {
+	IDs    []string `json:"ids"`
+	UserID string   `json:"-"` // вызывающий: отметки is_read
+}
+
+type NewsBatchResponse struct {
+	Items      []News   `json:"items"`       // в порядке ids, без повторов
+	MissingIDs []string `json:"missing_ids"` // нет такой новости или некорректный id
+}
+
 type NewsGetRequest struct {
 	ID string `json:"id"`
 }
@@ -18,6 +31,16 @@ type NewsGetResponse struct {
 	Item *News `json:"item"`
 }
 
+// NewsPageResponse — GET /news/{id}/page: новость и первая страница корневых
+// комментариев с данными авторов. Новость есть всегда; секции, не полученные
+// в срок, перечислены в missing ("comments", "authors") — без них comments
+// отсутствует или комментарии идут без display_name/avatar_url.
+type NewsPageResponse struct {
+	News     News                      `json:"news"`
+	Comments *ListRootCommentsResponse `json:"comments,omitempty"`
+	Missing  []string                  `json:"missing"`
+}
+
 type News struct {
 	ID               string `json:"id"`
 	Title            string `json:"title"`
@@ -28,4 +51,26 @@ type News struct {
 	ImageURL         string `json:"image_url"`
 	PublishedAt      int64  `json:"published_at"` // Unix UTC
 	FetchedAt        int64  `json:"fetched_at"`   // Unix UTC
+	Source           string `json:"source"`       // хост источника
+	Language         string `json:"language"`     // ISO 639-1; "" — неизвестен
+	IsRead           bool   `json:"is_read"`      // прочитана вызывающим; анонимно — всегда false
+
+	Comments *CommentCounts `json:"comments,omitempty"` // только в ListNews; нет — comments-service недоступен
+}
+
+// Счётчики комментариев новости (без мягко удалённых).
+type CommentCounts struct {
+	Total          int64 `json:"total"`
+	Roots          int64 `json:"roots"`
+	LastActivityAt int64 `json:"last_activity_at"` // Unix UTC; 0 — комментариев не было
+}
+
+// Отметка прочтения новостей вызывающим.
+type MarkNewsReadRequest struct {
+	NewsIDs []string `json:"news_ids"`
+}
+
+// «Прочитано всё»: страница ленты, запрошенная с page_token, и всё старше; пустой — до текущего момента.
+type MarkAllNewsReadRequest struct {
+	PageToken string `json:"page_token"`
 }
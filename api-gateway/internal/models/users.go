@@ -15,25 +15,42 @@ type User struct {
 	Age       uint32 `json:"age"`
 	AvatarURL string `json:"avatar_url"`
 	AvatarKey string `json:"avatar_key"`
-	CreatedAt int64  `json:"created_at"` // Unix UTC
-	UpdatedAt int64  `json:"updated_at"` // Unix UTC
-	Country   string `json:"country"`
-	Gender    Gender `json:"gender"`
+	// AvatarURLs — превью аватара по стороне в пикселях: {"64": "...", "128": "...", "512": "..."}.
+	AvatarURLs map[string]string `json:"avatar_urls,omitempty"`
+	CreatedAt  int64             `json:"created_at"` // Unix UTC
+	UpdatedAt  int64             `json:"updated_at"` // Unix UTC
+	Country    string            `json:"country"`
+	Gender     Gender            `json:"gender"`
+	// Счётчики подписок (поддерживаются users-service вместе с таблицей follows).
+	FollowersCount uint32 `json:"followers_count"`
+	FollowingCount uint32 `json:"following_count"`
+	// Privacy — только для владельца (GET /me, свой /users/{id}) и admin.
+	Privacy *UserPrivacy `json:"privacy,omitempty"`
+}
+
+// Видимость атрибутов профиля: "public" | "registered" | "private".
+// У чужого профиля скрытые атрибуты отдаются пустыми.
+type UserPrivacy struct {
+	Age     string `json:"age,omitempty"`
+	Gender  string `json:"gender,omitempty"`
+	Country string `json:"country,omitempty"`
 }
 
 // Запрос на изменение профиля; поля опциональные, маска управляется на b/e.
 // На REST принимаем ровно те же поля; update_mask передаём в gRPC внутри handler.
 type UpdateUserRequest struct {
-	UserID   string `json:"user_id"`
+	UserID   string `json:"user_id,omitempty"` // берётся из пути
 	Username string `json:"username,omitempty"`
 	Age      uint32 `json:"age,omitempty"`
 	Country  string `json:"country,omitempty"`
 	Gender   Gender `json:"gender,omitempty"`
+	// Privacy — меняются только переданные ключи.
+	Privacy *UserPrivacy `json:"privacy,omitempty"`
 }
 
 // Пресайн на загрузку аватара.
 type AvatarPresignRequest struct {
-	UserID        string `json:"user_id"`
+	UserID        string `json:"user_id,omitempty"` // берётся из пути
 	ContentType   string `json:"content_type"`
 	ContentLength uint64 `json:"content_length"`
 }
@@ -46,6 +63,72 @@ type AvatarPresignResponse struct {
 }
 
 type AvatarConfirmRequest struct {
-	UserID    string `json:"user_id"`
+	UserID    string `json:"user_id,omitempty"` // берётся из пути
 	AvatarKey string `json:"avatar_key"`
 }
+
+// Проверка username: reason — "" | "invalid" | "reserved" | "taken".
+type UsernameAvailabilityResponse struct {
+	Username  string `json:"username"`
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Префиксный поиск профилей (автодополнение @упоминаний).
+type SearchUsersResponse struct {
+	Users []User `json:"users"`
+}
+
+// Подписка: профиль второй стороны и момент подписки.
+type FollowEntry struct {
+	User       User  `json:"user"`
+	FollowedAt int64 `json:"followed_at"` // Unix UTC
+}
+
+// Страница подписчиков/подписок пользователя (сначала новые).
+type FollowsResponse struct {
+	Entries       []FollowEntry `json:"entries"`
+	NextPageToken string        `json:"next_page_token"`
+}
+
+// Результат Follow/Unfollow: changed=false — состояние уже было таким.
+type FollowResponse struct {
+	Changed bool `json:"changed"`
+}
+
+// Предпочтения читателя (персональная лента).
+type Preferences struct {
+	FollowedCategories []string `json:"followed_categories"`
+	MutedCategories    []string `json:"muted_categories"`
+	MutedSources       []string `json:"muted_sources"`
+	Languages          []string `json:"languages"`
+	UpdatedAt          int64    `json:"updated_at,omitempty"` // Unix UTC; 0 — не задавались
+}
+
+// Частичное обновление предпочтений: меняются только переданные списки, [] — очистка.
+type UpdatePreferencesRequest struct {
+	FollowedCategories *[]string `json:"followed_categories,omitempty"`
+	MutedCategories    *[]string `json:"muted_categories,omitempty"`
+	MutedSources       *[]string `json:"muted_sources,omitempty"`
+	Languages          *[]string `json:"languages,omitempty"`
+}
+
+// Закладка читателя. News нет, если новость удалена (news_missing=true)
+// или news-service временно недоступен.
+type Bookmark struct {
+	NewsID      string `json:"news_id"`
+	SavedAt     int64  `json:"saved_at"` // Unix UTC
+	News        *News  `json:"news,omitempty"`
+	NewsMissing bool   `json:"news_missing,omitempty"`
+}
+
+// Страница закладок (сначала недавно сохранённые).
+type BookmarksResponse struct {
+	Bookmarks     []Bookmark `json:"bookmarks"`
+	NextPageToken string     `json:"next_page_token"`
+}
+
+// Результат добавления/удаления закладки: changed=false — состояние уже было таким.
+type BookmarkResponse struct {
+	Changed bool `json:"changed"`
+}
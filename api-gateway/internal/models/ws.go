@@ -0,0 +1,29 @@
+package models
+
+import apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+
+// WSClientMessage — кадр клиента в /ws (JSON, текстовый кадр).
+type WSClientMessage struct {
+	// Type — subscribe | unsubscribe | comment | ping.
+	Type string `json:"type"`
+	// ID — идентификатор запроса клиента; возвращается в ответном кадре.
+	ID     string `json:"id,omitempty"`
+	NewsID string `json:"news_id,omitempty"`
+	// Поля comment (как в CreateCommentRequest; user_id — из токена).
+	ParentID string `json:"parent_id,omitempty"`
+	Content  string `json:"content,omitempty"`
+}
+
+// WSServerMessage — кадр шлюза в /ws.
+type WSServerMessage struct {
+	// Type — subscribed | unsubscribed | comment.created | comment.deleted |
+	// comment.reaction | reset | ack | pong | error.
+	Type string `json:"type"`
+	// ID — ID кадра клиента, на который это ответ; пусто у событий веток.
+	ID     string `json:"id,omitempty"`
+	NewsID string `json:"news_id,omitempty"`
+	// EventID — курсор события (как id: в SSE); пусто у удалений, реакций и reset.
+	EventID string              `json:"event_id,omitempty"`
+	Comment *Comment            `json:"comment,omitempty"`
+	Error   *apierrors.APIError `json:"error,omitempty"`
+}
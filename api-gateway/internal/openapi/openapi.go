@@ -0,0 +1,318 @@
+// openapi — документ OpenAPI 3.1 для REST-поверхности api-gateway.
+//
+// Документ собирается из реальной таблицы маршрутов (chi.Walk по роутеру из
+// registerRoutes) и описаний операций: модели тела и ответа — это Go-типы из
+// internal/models, их схемы строятся рефлексией по json-тегам. Ошибки описаны
+// типами apierrors, пагинация — общими параметрами (PageSize, PageToken, Limit).
+//
+// Готовый документ хранится в openapi.json рядом с пакетом (go:embed) и отдаётся
+// по /openapi.json; страница /docs — Swagger UI поверх него. Тест в internal/http
+// сверяет файл со сборкой из маршрутов и моделей и падает при расхождении.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	apierrors "github.com/pribylovaa/go-news-aggregator/api-gateway/internal/errors"
+)
+
+// Version — версия OpenAPI документа.
+const Version = "3.1.0"
+
+// Access — требование операции к аутентификации (см. middleware.Public/OptionalAuth/RequireAuth).
+type Access string
+
+const (
+	AccessPublic   Access = "public"
+	AccessOptional Access = "optional"
+	AccessRequired Access = "required"
+	// AccessSelf — RequireAuth + RequireSelf: user_id из пути совпадает с вызывающим.
+	AccessSelf Access = "self"
+)
+
+// Operation — описание маршрута "METHOD /pattern".
+type Operation struct {
+	Summary string
+	Tag     string
+	Access  Access
+	// Roles — достаточно любой из ролей (RequireAuth(roles...)).
+	Roles []string
+	Query []Param
+	// Headers — параметры запроса в заголовках (например, Last-Event-ID).
+	Headers []Param
+	// Body — модель тела запроса; nil — без тела.
+	Body any
+	// OptionalBody — тело можно не передавать.
+	OptionalBody bool
+	// Response — модель ответа; OneOf — один из нескольких вариантов.
+	Response any
+	// Status — код успешного ответа; 0 — 200.
+	Status int
+	// ContentType — тип успешного ответа; "" — application/json.
+	// Для text/event-stream Response описывает data одного события.
+	ContentType string
+}
+
+// OneOf — ответ одной из моделей (например, в зависимости от query-параметров).
+type OneOf []any
+
+// Param — query-параметр (или заголовок в Operation.Headers).
+type Param struct {
+	Name        string
+	Type        string // "string" | "integer" | "boolean"
+	Description string
+	Required    bool
+}
+
+// Общие параметры пагинации: в документе — components/parameters.
+var (
+	PageSize  = Param{Name: "page_size", Type: "integer", Description: "Размер страницы; 0 — по умолчанию сервиса."}
+	PageToken = Param{Name: "page_token", Type: "string", Description: "Курсор следующей страницы (next_page_token предыдущего ответа)."}
+	Limit     = Param{Name: "limit", Type: "integer", Description: "Размер страницы; 0 — по умолчанию сервиса."}
+)
+
+var sharedParams = []Param{PageSize, PageToken, Limit}
+
+// Info — заголовок документа.
+type Info struct {
+	Title   string
+	Version string
+	// Server — базовый путь API (BasePath роутера), например "/api".
+	Server string
+}
+
+// Build собирает документ по маршрутам routes и описаниям ops (ключ — "METHOD /pattern").
+// Маршрут без описания и описание без маршрута — ошибка: документ должен
+// покрывать ровно таблицу маршрутов.
+func Build(routes chi.Routes, ops map[string]Operation, info Info) (*Document, error) {
+	const op = "openapi/Build"
+
+	b := newBuilder()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    InfoObject{Title: info.Title, Version: info.Version},
+		Paths:   make(map[string]PathItem),
+	}
+	if info.Server != "" {
+		doc.Servers = []Server{{URL: info.Server}}
+	}
+
+	seen := make(map[string]bool, len(ops))
+	var problems []string
+
+	err := chi.Walk(routes, func(method, route string, handler http.Handler, _ ...func(http.Handler) http.Handler) error {
+		key := method + " " + route
+		o, ok := ops[key]
+		if !ok {
+			problems = append(problems, "undocumented route "+key)
+			return nil
+		}
+		seen[key] = true
+
+		item := doc.Paths[route]
+		if item == nil {
+			item = make(PathItem)
+			doc.Paths[route] = item
+		}
+		item[strings.ToLower(method)] = b.operation(route, o, handlerName(handler))
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for key := range ops {
+		if !seen[key] {
+			problems = append(problems, "documented route without handler "+key)
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("%s: %s", op, strings.Join(problems, "; "))
+	}
+
+	doc.Components = b.components()
+
+	return doc, nil
+}
+
+// builder накапливает схемы моделей по мере обхода операций.
+type builder struct {
+	schemas map[string]*Schema
+	// names — тип -> имя схемы (обнаружение конфликтов одноимённых типов).
+	names map[string]reflect.Type
+}
+
+func newBuilder() *builder {
+	b := &builder{schemas: make(map[string]*Schema), names: make(map[string]reflect.Type)}
+	b.schema(reflect.TypeOf(apierrors.ErrorResponse{}))
+
+	return b
+}
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+func (b *builder) operation(route string, o Operation, id string) *OperationObject {
+	out := &OperationObject{
+		OperationID: id,
+		Summary:     o.Summary,
+		Responses:   make(map[string]*Response),
+		Access:      string(o.Access),
+		Roles:       o.Roles,
+	}
+	if o.Tag != "" {
+		out.Tags = []string{o.Tag}
+	}
+
+	for _, m := range pathParam.FindAllStringSubmatch(route, -1) {
+		out.Parameters = append(out.Parameters, &Parameter{
+			Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string"},
+		})
+	}
+
+	for _, p := range o.Query {
+		out.Parameters = append(out.Parameters, queryParam(p))
+	}
+
+	for _, p := range o.Headers {
+		out.Parameters = append(out.Parameters, &Parameter{
+			Name: p.Name, In: "header", Description: p.Description, Required: p.Required, Schema: &Schema{Type: p.Type},
+		})
+	}
+
+	if o.Body != nil {
+		out.RequestBody = &RequestBody{
+			Required: !o.OptionalBody,
+			Content:  jsonContent(b.schema(reflect.TypeOf(o.Body))),
+		}
+	}
+
+	status := o.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	contentType := o.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	success := &Response{Description: http.StatusText(status)}
+	switch resp := o.Response.(type) {
+	case nil:
+	case OneOf:
+		s := &Schema{}
+		for _, m := range resp {
+			s.OneOf = append(s.OneOf, b.schema(reflect.TypeOf(m)))
+		}
+		success.Content = map[string]MediaType{contentType: {Schema: s}}
+	default:
+		success.Content = map[string]MediaType{contentType: {Schema: b.schema(reflect.TypeOf(resp))}}
+	}
+	out.Responses[fmt.Sprint(status)] = success
+
+	// Ошибки: общие для всех операций и зависящие от политики доступа.
+	errs := []string{"400", "429", "default"}
+	switch o.Access {
+	case AccessOptional:
+		errs = append(errs, "401", "503")
+		out.Security = []SecurityRequirement{{}, {bearerScheme: {}}}
+	case AccessRequired, AccessSelf:
+		errs = append(errs, "401", "503")
+		out.Security = []SecurityRequirement{{bearerScheme: {}}}
+	default:
+		out.Security = []SecurityRequirement{}
+	}
+	if len(o.Roles) > 0 || o.Access == AccessSelf {
+		errs = append(errs, "403")
+	}
+	for _, code := range errs {
+		out.Responses[code] = &Response{Ref: "#/components/responses/" + errorResponses[code].name}
+	}
+
+	return out
+}
+
+func queryParam(p Param) *Parameter {
+	for _, s := range sharedParams {
+		if s == p {
+			return &Parameter{Ref: "#/components/parameters/" + p.Name}
+		}
+	}
+
+	return &Parameter{
+		Name:        p.Name,
+		In:          "query",
+		Description: p.Description,
+		Required:    p.Required,
+		Schema:      &Schema{Type: p.Type},
+	}
+}
+
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: s}}
+}
+
+// bearerScheme — имя схемы безопасности Bearer JWT.
+const bearerScheme = "bearerAuth"
+
+// errorResponses — ответы с ошибкой в формате apierrors.ErrorResponse.
+var errorResponses = map[string]struct{ name, description string }{
+	"400":     {"BadRequest", "Некорректные параметры или тело запроса (invalid_argument)."},
+	"401":     {"Unauthorized", "Нет токена или он невалиден (unauthenticated)."},
+	"403":     {"Forbidden", "Нет нужной роли или чужой user_id (permission_denied)."},
+	"429":     {"TooManyRequests", "Превышен лимит запросов; см. Retry-After (resource_exhausted)."},
+	"503":     {"Unavailable", "Сервис или проверка токена недоступны (unavailable)."},
+	"default": {"Error", "Ошибка в едином формате { error: { code, message, request_id } }."},
+}
+
+func (b *builder) components() Components {
+	c := Components{
+		Schemas:    b.schemas,
+		Parameters: make(map[string]*Parameter, len(sharedParams)),
+		Responses:  make(map[string]*Response, len(errorResponses)),
+		SecuritySchemes: map[string]*SecurityScheme{
+			bearerScheme: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		},
+	}
+
+	for _, p := range sharedParams {
+		c.Parameters[p.Name] = &Parameter{
+			Name: p.Name, In: "query", Description: p.Description, Schema: &Schema{Type: p.Type},
+		}
+	}
+
+	errSchema := &Schema{Ref: "#/components/schemas/ErrorResponse"}
+	for _, r := range errorResponses {
+		c.Responses[r.name] = &Response{Description: r.description, Content: jsonContent(errSchema)}
+	}
+
+	return c
+}
+
+// handlerName — имя хендлера для operationId: "(*Handlers).ListNews-fm" -> "ListNews".
+func handlerName(h http.Handler) string {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		return ""
+	}
+
+	fn := runtime.FuncForPC(reflect.ValueOf(f).Pointer())
+	if fn == nil {
+		return ""
+	}
+
+	name := fn.Name()
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return strings.TrimSuffix(name, "-fm")
+}
@@ -0,0 +1,171 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// Тесты сборки документа:
+//   - схемы моделей по json-тегам (required, omitempty, указатели, map, unsigned, встраивание, deprecated);
+//   - расхождение маршрутов и описаний — ошибка;
+//   - операции: path/query-параметры, ссылки на общие параметры и ответы, security;
+//   - потоковые ответы: text/event-stream и параметры-заголовки;
+//   - Swagger UI: CSP, внешние ссылки — только закреплённая версия, без inline-скриптов.
+
+type base struct {
+	ID string `json:"id"`
+}
+
+type item struct {
+	base
+	Name    string            `json:"name"`
+	Count   uint32            `json:"count,omitempty"`
+	Tags    []string          `json:"tags"`
+	Meta    map[string]string `json:"meta,omitempty"`
+	Extra   map[string]any    `json:"extra,omitempty"`
+	Next    *item             `json:"next"`
+	Skipped string            `json:"-"`
+	Old     string            `json:"old,omitempty" openapi:"deprecated"`
+}
+
+type page struct {
+	Items []item `json:"items"`
+}
+
+func TestSchema_FromModels(t *testing.T) {
+	b := newBuilder()
+	ref := b.schema(reflect.TypeOf(page{}))
+	require.Equal(t, "#/components/schemas/page", ref.Ref)
+
+	it := b.schemas["item"]
+	require.NotNil(t, it)
+	require.Equal(t, []string{"id", "name", "tags"}, it.Required)
+	require.ElementsMatch(t, []string{"id", "name", "count", "tags", "meta", "extra", "next", "old"}, keys(it.Properties))
+	require.True(t, it.Properties["old"].Deprecated)
+	require.False(t, it.Properties["name"].Deprecated)
+	require.Equal(t, 0, *it.Properties["count"].Minimum)
+	require.Equal(t, "string", it.Properties["meta"].AdditionalProperties.Type)
+	require.Equal(t, &Schema{}, it.Properties["extra"].AdditionalProperties)
+	require.Equal(t, "#/components/schemas/item", it.Properties["next"].Ref)
+	require.Equal(t, "#/components/schemas/item", b.schemas["page"].Properties["items"].Items.Ref)
+
+	// Ошибки apierrors всегда в components.
+	require.Contains(t, b.schemas, "ErrorResponse")
+	require.Contains(t, b.schemas, "APIError")
+}
+
+func TestBuild_RoutesMustMatchOperations(t *testing.T) {
+	r := chi.NewRouter()
+	ok := func(http.ResponseWriter, *http.Request) {}
+	r.Get("/items", ok)
+	r.Post("/items/{id}", ok)
+
+	_, err := Build(r, map[string]Operation{
+		"GET /items":  {Access: AccessPublic},
+		"GET /absent": {Access: AccessPublic},
+	}, Info{})
+	require.ErrorContains(t, err, "undocumented route POST /items/{id}")
+	require.ErrorContains(t, err, "documented route without handler GET /absent")
+}
+
+func TestBuild_Operation(t *testing.T) {
+	r := chi.NewRouter()
+	ok := func(http.ResponseWriter, *http.Request) {}
+	r.Get("/items", ok)
+	r.Patch("/users/{id}/items", ok)
+
+	doc, err := Build(r, map[string]Operation{
+		"GET /items": {
+			Access:   AccessOptional,
+			Query:    []Param{PageSize, PageToken, {Name: "q", Type: "string", Required: true}},
+			Response: page{},
+		},
+		"PATCH /users/{id}/items": {
+			Access: AccessSelf, Body: item{}, Response: item{}, Status: http.StatusCreated,
+		},
+	}, Info{Title: "t", Version: "1", Server: "/api"})
+	require.NoError(t, err)
+	require.Equal(t, Version, doc.OpenAPI)
+	require.Equal(t, "/api", doc.Servers[0].URL)
+
+	list := doc.Paths["/items"]["get"]
+	require.Len(t, list.Parameters, 3)
+	require.Equal(t, "#/components/parameters/page_size", list.Parameters[0].Ref)
+	require.Equal(t, "q", list.Parameters[2].Name)
+	require.True(t, list.Parameters[2].Required)
+	require.Equal(t, []SecurityRequirement{{}, {bearerScheme: {}}}, list.Security)
+	require.Contains(t, list.Responses, "401")
+	require.NotContains(t, list.Responses, "403")
+	require.Equal(t, "#/components/responses/TooManyRequests", list.Responses["429"].Ref)
+
+	upd := doc.Paths["/users/{id}/items"]["patch"]
+	require.Equal(t, "id", upd.Parameters[0].Name)
+	require.Equal(t, "path", upd.Parameters[0].In)
+	require.True(t, upd.RequestBody.Required)
+	require.Contains(t, upd.Responses, "201")
+	require.Contains(t, upd.Responses, "403")
+	require.Equal(t, "self", upd.Access)
+
+	require.Contains(t, doc.Components.Parameters, "page_token")
+	require.Contains(t, doc.Components.Responses, "Forbidden")
+	require.Equal(t, "bearer", doc.Components.SecuritySchemes[bearerScheme].Scheme)
+}
+
+func TestBuild_EventStreamOperation(t *testing.T) {
+	r := chi.NewRouter()
+	r.Get("/items/stream", func(http.ResponseWriter, *http.Request) {})
+
+	doc, err := Build(r, map[string]Operation{
+		"GET /items/stream": {
+			Access:      AccessPublic,
+			Headers:     []Param{{Name: "Last-Event-ID", Type: "string"}},
+			Response:    item{},
+			ContentType: "text/event-stream",
+		},
+	}, Info{})
+	require.NoError(t, err)
+
+	op := doc.Paths["/items/stream"]["get"]
+	require.Equal(t, "header", op.Parameters[0].In)
+	require.Equal(t, "Last-Event-ID", op.Parameters[0].Name)
+	require.Contains(t, op.Responses["200"].Content, "text/event-stream")
+	require.NotContains(t, op.Responses["200"].Content, "application/json")
+	require.Equal(t, "#/components/schemas/item", op.Responses["200"].Content["text/event-stream"].Schema.Ref)
+}
+
+func keys(m map[string]*Schema) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+
+	return out
+}
+
+func TestUIHandler_CSP(t *testing.T) {
+	rr := httptest.NewRecorder()
+	UIHandler()(rr, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, uiCSP, rr.Header().Get("Content-Security-Policy"))
+	require.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+
+	page := rr.Body.String()
+	refs := regexp.MustCompile(`(?:src|href)="([^"]+)"`).FindAllStringSubmatch(page, -1)
+	require.NotEmpty(t, refs)
+	for _, m := range refs {
+		if strings.HasPrefix(m[1], "/") {
+			continue
+		}
+		require.Truef(t, strings.HasPrefix(m[1], swaggerCDN), "%s is outside CSP", m[1])
+	}
+
+	// Inline-скрипты CSP запрещает: у каждого <script> — src.
+	require.Equal(t, strings.Count(page, "<script"), strings.Count(page, "<script src="))
+}
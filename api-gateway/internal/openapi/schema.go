@@ -0,0 +1,123 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// schema возвращает схему типа t: именованные структуры попадают в
+// components/schemas и подставляются ссылкой, остальное — inline.
+//
+// Правила:
+//   - поле берёт имя из json-тега, "-" и неэкспортируемые пропускаются;
+//   - обязательны поля без omitempty и не указатели (указатель может быть null/отсутствовать);
+//   - встроенные структуры раскрываются в свойства внешней;
+//   - тег openapi:"deprecated" помечает поле устаревшим (deprecated: true);
+//   - беззнаковые целые получают minimum: 0; map[string]T — additionalProperties;
+//   - any — пустая схема (любое JSON-значение).
+func (b *builder) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32", Minimum: zero()}
+	case reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Minimum: zero()}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.object(t)
+		}
+
+		return b.named(t)
+	case reflect.Interface:
+		return &Schema{}
+	default:
+		panic(fmt.Sprintf("openapi: unsupported type %s", t))
+	}
+}
+
+// named регистрирует именованную структуру в components/schemas.
+func (b *builder) named(t reflect.Type) *Schema {
+	ref := &Schema{Ref: "#/components/schemas/" + t.Name()}
+
+	if prev, ok := b.names[t.Name()]; ok {
+		if prev != t {
+			panic(fmt.Sprintf("openapi: schema name conflict %s: %s vs %s", t.Name(), prev, t))
+		}
+
+		return ref
+	}
+
+	// Сначала резервируем имя: рекурсивные типы ссылаются на себя.
+	b.names[t.Name()] = t
+	b.schemas[t.Name()] = b.object(t)
+
+	return ref
+}
+
+// object — схема структуры со свойствами по json-тегам.
+func (b *builder) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	b.fields(t, s)
+
+	return s
+}
+
+func (b *builder) fields(t reflect.Type, s *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			b.fields(f.Type, s)
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := b.schema(f.Type)
+		if f.Tag.Get("openapi") == "deprecated" {
+			prop.Deprecated = true
+		}
+
+		s.Properties[name] = prop
+
+		if !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+func zero() *int {
+	v := 0
+	return &v
+}
@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// Spec — сгенерированный документ (openapi.json); обновляется тестом internal/http
+// с флагом -update.
+//
+//go:embed openapi.json
+var Spec []byte
+
+//go:embed swagger.html
+var swaggerHTML []byte
+
+//go:embed swagger-init.js
+var swaggerInit []byte
+
+// swaggerCDN — закреплённая версия Swagger UI на unpkg; ссылки в swagger.html должны
+// начинаться с неё, иначе CSP страницы их заблокирует.
+const swaggerCDN = "https://unpkg.com/swagger-ui-dist@5.17.14/"
+
+// uiCSP — политика страницы /docs: скрипты — только свои и закреплённой версии Swagger UI,
+// стили — оттуда же (inline-стили ставит сам Swagger UI), запросы — только к шлюзу.
+const uiCSP = "default-src 'none'; " +
+	"script-src 'self' " + swaggerCDN + "; " +
+	"style-src " + swaggerCDN + " 'unsafe-inline'; " +
+	"img-src 'self' data:; " +
+	"connect-src 'self'; " +
+	"base-uri 'none'; form-action 'none'; frame-ancestors 'none'"
+
+// SpecHandler отдаёт Spec.
+func SpecHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(Spec)
+	}
+}
+
+// UIHandler отдаёт страницу Swagger UI, которая загружает /openapi.json.
+// Скрипты и стили Swagger UI страница берёт с CDN (unpkg, версия закреплена);
+// Content-Security-Policy не даёт ей загрузить что-либо ещё или выполнить inline-скрипт.
+func UIHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		h := w.Header()
+		h.Set("Content-Type", "text/html; charset=utf-8")
+		h.Set("Content-Security-Policy", uiCSP)
+		h.Set("X-Content-Type-Options", "nosniff")
+		h.Set("Referrer-Policy", "no-referrer")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(swaggerHTML)
+	}
+}
+
+// UIInitHandler отдаёт скрипт инициализации страницы UIHandler (/docs/swagger-init.js).
+func UIInitHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(swaggerInit)
+	}
+}
@@ -0,0 +1,5 @@
+// Инициализация Swagger UI для /docs; отдельный файл, чтобы CSP страницы обходилась без 'unsafe-inline'.
+window.onload = () => {
+  // validatorUrl: null — без бейджа validator.swagger.io (внешний запрос, запрещённый CSP).
+  window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui", validatorUrl: null });
+};
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepEvery — как часто Memory удаляет восполненные вёдра.
+const sweepEvery = time.Minute
+
+// Memory — вёдра в памяти процесса. Подходит для одной реплики шлюза:
+// у каждой реплики свои вёдра, и суммарный лимит растёт с их числом.
+type Memory struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// full — момент, когда ведро восполнится: после него запись не нужна.
+	full time.Time
+}
+
+// NewMemory создаёт пустой Memory.
+func NewMemory() *Memory {
+	return &Memory{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// Allow реализует Limiter. Ошибок не возвращает.
+func (m *Memory) Allow(_ context.Context, key string, rule Rule) (Decision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if now.Sub(m.lastSweep) >= sweepEvery {
+		m.sweep(now)
+	}
+
+	tokens := float64(rule.Capacity())
+	b, ok := m.buckets[key]
+	if ok {
+		tokens = refill(b.tokens, now.Sub(b.last), rule)
+	} else {
+		b = &bucket{}
+		m.buckets[key] = b
+	}
+
+	d, left := take(tokens, rule)
+	b.tokens, b.last, b.full = left, now, now.Add(d.Reset)
+
+	return d, nil
+}
+
+// sweep удаляет вёдра, которые уже восполнились: новое ведро с полной ёмкостью
+// ведёт себя так же.
+func (m *Memory) sweep(now time.Time) {
+	for k, b := range m.buckets {
+		if !now.Before(b.full) {
+			delete(m.buckets, k)
+		}
+	}
+
+	m.lastSweep = now
+}
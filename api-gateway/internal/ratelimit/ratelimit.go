@@ -0,0 +1,131 @@
+// ratelimit — токен-бакеты для HTTP-ограничений api-gateway.
+//
+// Правило (Rule) описывает ведро: ёмкость Burst и пополнение Rate токенов за Per.
+// Каждый запрос забирает один токен; пустое ведро — отказ до появления токена.
+// Состояние ведра хранит бэкенд (Limiter): в памяти процесса (Memory) или
+// в Redis (Redis) — общее для всех реплик шлюза.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Rule — параметры одного ведра.
+type Rule struct {
+	// Rate — сколько запросов восполняется за Per; 0 — без ограничения.
+	Rate int `yaml:"rate" env:"RATE"`
+	// Per — окно пополнения; 0 — секунда.
+	Per time.Duration `yaml:"per" env:"PER"`
+	// Burst — ёмкость ведра (всплеск); 0 — равна Rate.
+	Burst int `yaml:"burst" env:"BURST"`
+}
+
+// Enabled — правило ограничивает запросы.
+func (r Rule) Enabled() bool { return r.Rate > 0 }
+
+// Capacity — ёмкость ведра.
+func (r Rule) Capacity() int {
+	if r.Burst > 0 {
+		return r.Burst
+	}
+
+	return r.Rate
+}
+
+// Window — окно пополнения с учётом значения по умолчанию.
+func (r Rule) Window() time.Duration {
+	if r.Per > 0 {
+		return r.Per
+	}
+
+	return time.Second
+}
+
+// perSecond — скорость пополнения, токенов в секунду.
+func (r Rule) perSecond() float64 {
+	return float64(r.Rate) / r.Window().Seconds()
+}
+
+// Policy — правила маршрута: для анонимных запросов (по IP)
+// и для аутентифицированных (по user_id).
+type Policy struct {
+	IP   Rule `yaml:"ip"   env-prefix:"IP_"`
+	User Rule `yaml:"user" env-prefix:"USER_"`
+}
+
+// Policies — политика по умолчанию и переопределения для маршрутов.
+// Ключ Routes — "METHOD /pattern" в терминах шаблонов chi без BasePath
+// (например, "POST /auth/login", "GET /news/{id}"). У маршрута с собственной
+// политикой отдельные вёдра; остальные маршруты делят вёдра Default.
+type Policies struct {
+	Default Policy
+	Routes  map[string]Policy
+}
+
+// Decision — результат попытки забрать токен.
+type Decision struct {
+	Allowed bool
+	// Limit — ёмкость ведра.
+	Limit int
+	// Remaining — целых токенов после запроса.
+	Remaining int
+	// Reset — через сколько ведро восполнится полностью.
+	Reset time.Duration
+	// RetryAfter — через сколько появится токен (только при отказе).
+	RetryAfter time.Duration
+}
+
+// Limiter — хранилище вёдер.
+type Limiter interface {
+	// Allow забирает токен из ведра key по правилу rule.
+	// Ошибка — бэкенд недоступен; решение о пропуске запроса принимает вызывающий.
+	Allow(ctx context.Context, key string, rule Rule) (Decision, error)
+}
+
+// refill — токены ведра спустя elapsed после состояния tokens.
+func refill(tokens float64, elapsed time.Duration, rule Rule) float64 {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	return math.Min(float64(rule.Capacity()), tokens+elapsed.Seconds()*rule.perSecond())
+}
+
+// take пытается забрать токен: возвращает решение и остаток токенов.
+func take(tokens float64, rule Rule) (Decision, float64) {
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	return decide(allowed, tokens, rule), tokens
+}
+
+// decide собирает Decision по остатку токенов left.
+func decide(allowed bool, left float64, rule Rule) Decision {
+	rate := rule.perSecond()
+	capacity := float64(rule.Capacity())
+
+	d := Decision{
+		Allowed:   allowed,
+		Limit:     rule.Capacity(),
+		Remaining: int(math.Floor(left)),
+		Reset:     seconds((capacity - left) / rate),
+	}
+
+	if !allowed {
+		d.RetryAfter = seconds((1 - left) / rate)
+	}
+
+	return d
+}
+
+func seconds(s float64) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
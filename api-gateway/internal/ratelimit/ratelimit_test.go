@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// Тесты токен-бакетов:
+//   - Memory: всплеск, пополнение, Reset/RetryAfter, независимость ключей, уборка;
+//   - Redis: тот же сценарий на живом Redis (только с RATE_LIMIT_TEST_REDIS_URL).
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time      { return c.t }
+func (c *fakeClock) add(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestMemory(c *fakeClock) *Memory {
+	m := NewMemory()
+	m.now = c.now
+	return m
+}
+
+func allow(t *testing.T, l Limiter, key string, r Rule) Decision {
+	t.Helper()
+	d, err := l.Allow(context.Background(), key, r)
+	require.NoError(t, err)
+	return d
+}
+
+func TestRule_Defaults(t *testing.T) {
+	r := Rule{Rate: 5}
+	require.True(t, r.Enabled())
+	require.Equal(t, 5, r.Capacity())
+	require.Equal(t, time.Second, r.Window())
+
+	r = Rule{Rate: 5, Per: time.Minute, Burst: 10}
+	require.Equal(t, 10, r.Capacity())
+	require.Equal(t, time.Minute, r.Window())
+
+	require.False(t, Rule{}.Enabled())
+}
+
+func TestMemory_BurstAndRefill(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := newTestMemory(clock)
+
+	// 2 токена в секунду, ёмкость 3.
+	rule := Rule{Rate: 2, Burst: 3}
+
+	for want := 2; want >= 0; want-- {
+		d := allow(t, m, "k", rule)
+		require.True(t, d.Allowed)
+		require.Equal(t, 3, d.Limit)
+		require.Equal(t, want, d.Remaining)
+	}
+
+	d := allow(t, m, "k", rule)
+	require.False(t, d.Allowed)
+	require.Equal(t, 0, d.Remaining)
+	require.Equal(t, 500*time.Millisecond, d.RetryAfter)
+	require.Equal(t, 1500*time.Millisecond, d.Reset)
+
+	// Другой ключ — своё ведро.
+	require.True(t, allow(t, m, "other", rule).Allowed)
+
+	clock.add(500 * time.Millisecond)
+	d = allow(t, m, "k", rule)
+	require.True(t, d.Allowed)
+	require.Equal(t, 0, d.Remaining)
+
+	// Пополнение не превышает ёмкость.
+	clock.add(time.Hour)
+	d = allow(t, m, "k", rule)
+	require.True(t, d.Allowed)
+	require.Equal(t, 2, d.Remaining)
+}
+
+func TestMemory_SweepsFullBuckets(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := newTestMemory(clock)
+
+	rule := Rule{Rate: 1, Per: time.Minute}
+	allow(t, m, "a", rule)
+	allow(t, m, "b", Rule{Rate: 1, Per: time.Hour})
+	require.Len(t, m.buckets, 2)
+
+	// Через 2 минуты «a» восполнено и удаляется, «b» — ещё нет.
+	clock.add(2 * time.Minute)
+	allow(t, m, "c", rule)
+	require.Len(t, m.buckets, 2)
+	require.Contains(t, m.buckets, "b")
+	require.Contains(t, m.buckets, "c")
+}
+
+func TestRedis_BurstAndRefill(t *testing.T) {
+	url := os.Getenv("RATE_LIMIT_TEST_REDIS_URL")
+	if url == "" {
+		t.Skip("redis tests are disabled (set RATE_LIMIT_TEST_REDIS_URL)")
+	}
+
+	r, err := NewRedis(url, "test:rl:")
+	require.NoError(t, err)
+	defer r.Close()
+
+	key := uuid.NewString()
+	rule := Rule{Rate: 1, Per: 200 * time.Millisecond, Burst: 2}
+
+	require.True(t, allow(t, r, key, rule).Allowed)
+	d := allow(t, r, key, rule)
+	require.True(t, d.Allowed)
+	require.Equal(t, 0, d.Remaining)
+
+	d = allow(t, r, key, rule)
+	require.False(t, d.Allowed)
+	require.Positive(t, d.RetryAfter)
+
+	time.Sleep(250 * time.Millisecond)
+	require.True(t, allow(t, r, key, rule).Allowed)
+}
@@ -0,0 +1,104 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript атомарно пополняет ведро и забирает токен.
+// Время — часы Redis (TIME), чтобы реплики шлюза с разным временем не расходились.
+// KEYS[1] — ведро; ARGV[1] — токенов в миллисекунду; ARGV[2] — ёмкость.
+// Возвращает {1|0, остаток токенов строкой}; ключ живёт до полного восполнения.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local cap = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local st = redis.call('HMGET', KEYS[1], 'tk', 'ts')
+local tokens = tonumber(st[1])
+local ts = tonumber(st[2])
+if tokens == nil or ts == nil then
+	tokens = cap
+	ts = now
+end
+
+tokens = math.min(cap, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tk', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.max(1, math.ceil((cap - tokens) / rate)))
+
+return {allowed, tostring(tokens)}
+`)
+
+// Redis — вёдра в Redis, общие для всех реплик шлюза.
+type Redis struct {
+	rdb    *redis.Client
+	prefix string
+}
+
+// NewRedis создаёт клиент Redis из URL (например, redis://:pass@host:6379/0)
+// и проверяет соединение. Пустой prefix — "gw:rl:".
+func NewRedis(redisURL, prefix string) (*Redis, error) {
+	const op = "ratelimit/NewRedis"
+
+	if prefix == "" {
+		prefix = "gw:rl:"
+	}
+
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rdb := redis.NewClient(opt)
+
+	// Fail-fast на старте.
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		_ = rdb.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Redis{rdb: rdb, prefix: prefix}, nil
+}
+
+// Allow реализует Limiter одним вызовом скрипта (EVALSHA с откатом на EVAL).
+func (l *Redis) Allow(ctx context.Context, key string, rule Rule) (Decision, error) {
+	const op = "ratelimit/Redis.Allow"
+
+	perMs := rule.perSecond() / 1000
+
+	res, err := takeScript.Run(ctx, l.rdb, []string{l.prefix + key},
+		strconv.FormatFloat(perMs, 'g', -1, 64), rule.Capacity()).Slice()
+	if err != nil {
+		return Decision{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(res) != 2 {
+		return Decision{}, fmt.Errorf("%s: unexpected reply %v", op, res)
+	}
+
+	allowed, _ := res[0].(int64)
+	raw, _ := res[1].(string)
+
+	left, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return Decision{}, fmt.Errorf("%s: parse tokens: %w", op, err)
+	}
+
+	return decide(allowed == 1, left, rule), nil
+}
+
+// Close закрывает клиент Redis.
+func (l *Redis) Close() error {
+	return l.rdb.Close()
+}
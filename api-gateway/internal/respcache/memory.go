@@ -0,0 +1,86 @@
+package respcache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory — LRU-кэш в памяти процесса: не больше size записей, при переполнении
+// вытесняется давно не читанная. Просроченные записи удаляются при чтении.
+type Memory struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List // front — последняя прочитанная/записанная
+	items map[string]*list.Element
+	now   func() time.Time
+}
+
+type memoryItem struct {
+	key   string
+	entry Entry
+}
+
+// NewMemory создаёт Memory на size записей (size <= 0 — 1).
+func NewMemory(size int) *Memory {
+	if size <= 0 {
+		size = 1
+	}
+
+	return &Memory{size: size, ll: list.New(), items: make(map[string]*list.Element), now: time.Now}
+}
+
+// Get реализует Store. Ошибок не возвращает.
+func (m *Memory) Get(_ context.Context, key string) (Entry, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return Entry{}, false, nil
+	}
+
+	it := el.Value.(*memoryItem)
+	if !m.now().Before(it.entry.Expires) {
+		m.remove(el)
+		return Entry{}, false, nil
+	}
+
+	m.ll.MoveToFront(el)
+
+	return it.entry, true, nil
+}
+
+// Set реализует Store. Ошибок не возвращает.
+func (m *Memory) Set(_ context.Context, key string, e Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		el.Value.(*memoryItem).entry = e
+		m.ll.MoveToFront(el)
+		return nil
+	}
+
+	m.items[key] = m.ll.PushFront(&memoryItem{key: key, entry: e})
+
+	for m.ll.Len() > m.size {
+		m.remove(m.ll.Back())
+	}
+
+	return nil
+}
+
+// Len — число записей (включая ещё не удалённые просроченные).
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.ll.Len()
+}
+
+func (m *Memory) remove(el *list.Element) {
+	m.ll.Remove(el)
+	delete(m.items, el.Value.(*memoryItem).key)
+}
@@ -0,0 +1,93 @@
+package respcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis — ответы в Redis (JSON), общие для всех реплик шлюза; срок — PX ключа.
+type Redis struct {
+	rdb    *redis.Client
+	prefix string
+	now    func() time.Time
+}
+
+// NewRedis создаёт клиент Redis из URL (например, redis://:pass@host:6379/0)
+// и проверяет соединение. Пустой prefix — "gw:cache:".
+func NewRedis(redisURL, prefix string) (*Redis, error) {
+	const op = "respcache/NewRedis"
+
+	if prefix == "" {
+		prefix = "gw:cache:"
+	}
+
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rdb := redis.NewClient(opt)
+
+	// Fail-fast на старте.
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		_ = rdb.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Redis{rdb: rdb, prefix: prefix, now: time.Now}, nil
+}
+
+// Get реализует Store.
+func (c *Redis) Get(ctx context.Context, key string) (Entry, bool, error) {
+	const op = "respcache/Redis.Get"
+
+	raw, err := c.rdb.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var e Entry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return Entry{}, false, fmt.Errorf("%s: decode: %w", op, err)
+	}
+
+	if !c.now().Before(e.Expires) {
+		return Entry{}, false, nil
+	}
+
+	return e, true, nil
+}
+
+// Set реализует Store. Просроченная запись не сохраняется.
+func (c *Redis) Set(ctx context.Context, key string, e Entry) error {
+	const op = "respcache/Redis.Set"
+
+	ttl := e.TTL(c.now())
+	if ttl <= 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("%s: encode: %w", op, err)
+	}
+
+	if err := c.rdb.Set(ctx, c.prefix+key, raw, ttl).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Close закрывает клиент Redis.
+func (c *Redis) Close() error {
+	return c.rdb.Close()
+}
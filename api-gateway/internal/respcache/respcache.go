@@ -0,0 +1,47 @@
+// respcache — кэш ответов публичных GET-маршрутов api-gateway.
+//
+// Кэшируются только успешные (200) ответы анонимных запросов: тело, Content-Type
+// и сильный ETag (SHA-256 тела). Срок жизни задаётся правилом маршрута (Rule.TTL).
+// Хранилище (Store) — LRU в памяти процесса (Memory) или Redis (Redis) — общий
+// для всех реплик шлюза.
+package respcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Rule — правило кэширования маршрута.
+type Rule struct {
+	// TTL — сколько ответ живёт в кэше; 0 — маршрут не кэшируется.
+	TTL time.Duration `yaml:"ttl"`
+}
+
+// Entry — закэшированный ответ.
+type Entry struct {
+	ContentType string    `json:"content_type"`
+	Body        []byte    `json:"body"`
+	ETag        string    `json:"etag"`
+	Expires     time.Time `json:"expires"`
+}
+
+// TTL — сколько записи осталось жить относительно now.
+func (e Entry) TTL(now time.Time) time.Duration {
+	return e.Expires.Sub(now)
+}
+
+// Store — хранилище ответов.
+type Store interface {
+	// Get возвращает непросроченную запись; found=false — промах.
+	Get(ctx context.Context, key string) (Entry, bool, error)
+	// Set сохраняет запись до e.Expires.
+	Set(ctx context.Context, key string, e Entry) error
+}
+
+// ETag — сильный ETag тела: первые 16 байт SHA-256 в hex, в кавычках.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
@@ -0,0 +1,114 @@
+package respcache
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// Тесты хранилищ ответов:
+//   - ETag: стабилен для тела, различается для разных тел;
+//   - Memory: срок жизни записи, вытеснение давно не читанных (LRU);
+//   - Redis: запись/чтение/срок на живом Redis (только с CACHE_TEST_REDIS_URL).
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time      { return c.t }
+func (c *fakeClock) add(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestMemory(c *fakeClock, size int) *Memory {
+	m := NewMemory(size)
+	m.now = c.now
+	return m
+}
+
+func entry(body string, expires time.Time) Entry {
+	return Entry{ContentType: "application/json", Body: []byte(body), ETag: ETag([]byte(body)), Expires: expires}
+}
+
+func get(t *testing.T, s Store, key string) (Entry, bool) {
+	t.Helper()
+	e, ok, err := s.Get(context.Background(), key)
+	require.NoError(t, err)
+	return e, ok
+}
+
+func TestETag(t *testing.T) {
+	a := ETag([]byte(`{"id":"1"}`))
+	require.Equal(t, a, ETag([]byte(`{"id":"1"}`)))
+	require.NotEqual(t, a, ETag([]byte(`{"id":"2"}`)))
+	require.Regexp(t, `^"[0-9a-f]{32}"$`, a)
+}
+
+func TestMemory_Expiry(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := newTestMemory(clock, 10)
+
+	require.NoError(t, m.Set(context.Background(), "k", entry("v", clock.t.Add(time.Minute))))
+
+	e, ok := get(t, m, "k")
+	require.True(t, ok)
+	require.Equal(t, "v", string(e.Body))
+	require.Equal(t, time.Minute, e.TTL(clock.t))
+
+	clock.add(time.Minute)
+	_, ok = get(t, m, "k")
+	require.False(t, ok)
+	require.Zero(t, m.Len())
+}
+
+func TestMemory_EvictsLeastRecentlyUsed(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := newTestMemory(clock, 2)
+	exp := clock.t.Add(time.Hour)
+	ctx := context.Background()
+
+	require.NoError(t, m.Set(ctx, "a", entry("a", exp)))
+	require.NoError(t, m.Set(ctx, "b", entry("b", exp)))
+
+	// Чтение "a" делает самой давней "b".
+	_, ok := get(t, m, "a")
+	require.True(t, ok)
+
+	require.NoError(t, m.Set(ctx, "c", entry("c", exp)))
+	require.Equal(t, 2, m.Len())
+
+	_, ok = get(t, m, "b")
+	require.False(t, ok)
+	_, ok = get(t, m, "a")
+	require.True(t, ok)
+
+	// Перезапись существующего ключа не вытесняет.
+	require.NoError(t, m.Set(ctx, "c", entry("c2", exp)))
+	e, ok := get(t, m, "c")
+	require.True(t, ok)
+	require.Equal(t, "c2", string(e.Body))
+	require.Equal(t, 2, m.Len())
+}
+
+func TestRedis_SetGetExpiry(t *testing.T) {
+	url := os.Getenv("CACHE_TEST_REDIS_URL")
+	if url == "" {
+		t.Skip("redis tests are disabled (set CACHE_TEST_REDIS_URL)")
+	}
+
+	r, err := NewRedis(url, "test:cache:")
+	require.NoError(t, err)
+	defer r.Close()
+
+	key := uuid.NewString()
+	require.NoError(t, r.Set(context.Background(), key, entry("v", time.Now().Add(200*time.Millisecond))))
+
+	e, ok := get(t, r, key)
+	require.True(t, ok)
+	require.Equal(t, "v", string(e.Body))
+	require.Equal(t, ETag([]byte("v")), e.ETag)
+
+	time.Sleep(250 * time.Millisecond)
+	_, ok = get(t, r, key)
+	require.False(t, ok)
+}
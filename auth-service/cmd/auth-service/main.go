@@ -25,6 +25,7 @@ import (
 	"github.com/pribylovaa/go-news-aggregator/auth-service/internal/storage/postgres"
 	auth "github.com/pribylovaa/go-news-aggregator/auth-service/internal/transport/grpc"
 	"github.com/pribylovaa/go-news-aggregator/pkg/interceptors"
+	"github.com/pribylovaa/go-news-aggregator/pkg/tlsconfig"
 
 	"google.golang.org/grpc"
 	health "google.golang.org/grpc/health"
@@ -53,6 +54,21 @@ func main() {
 	// Корневой контекст по сигналам.
 	rootCtx, rootCancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 
+	// TLS gRPC-сервера и клиентов; сертификаты перечитываются с диска до остановки.
+	grpcTLS, err := tlsconfig.New(cfg.GRPC.TLS)
+	if err != nil {
+		log.Error("grpc_tls_init_failed", slog.String("err", err.Error()))
+		rootCancel()
+		os.Exit(1)
+	}
+	grpcCreds, err := grpcTLS.ServerOption()
+	if err != nil {
+		log.Error("grpc_tls_init_failed", slog.String("err", err.Error()))
+		rootCancel()
+		os.Exit(1)
+	}
+	go grpcTLS.Run(rootCtx, log)
+
 	// Подключение к БД c таймаутом.
 	dbCtx, dbCancel := context.WithTimeout(rootCtx, 10*time.Second)
 	str, err := postgres.New(dbCtx, cfg.DB.DatabaseURL)
@@ -118,6 +134,7 @@ func main() {
 
 	// gRPC сервер и интерсепторы.
 	grpcOpts := []grpc.ServerOption{
+		grpcCreds,
 		grpc.ChainUnaryInterceptor(
 			interceptors.Recover(log),
 			interceptors.UnaryLoggingInterceptor(log),
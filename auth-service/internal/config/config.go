@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/pribylovaa/go-news-aggregator/pkg/tlsconfig"
 )
 
 // Config — корневая конфигурация сервиса.
@@ -42,6 +43,8 @@ type HTTPConfig struct {
 type GRPCConfig struct {
 	Host string `yaml:"host" env:"HOST" env-default:"0.0.0.0"`
 	Port string `yaml:"port" env:"PORT" env-default:"50051"`
+	// TLS — TLS/mTLS gRPC-сервера и исходящих gRPC-клиентов (pkg/tlsconfig).
+	TLS tlsconfig.Config `yaml:"tls" env-prefix:"GRPC_TLS_"`
 }
 
 // Addr возвращает адрес в формате host:port.
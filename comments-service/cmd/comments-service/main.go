@@ -17,12 +17,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/pribylovaa/go-news-aggregator/pkg/interceptors"
+	"github.com/pribylovaa/go-news-aggregator/pkg/tlsconfig"
 
 	commentsv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/comments"
 	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/config"
 	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/service"
 	csmongo "github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage/mongo"
 	commentsgrpc "github.com/pribylovaa/go-news-aggregator/comments-service/internal/transport/grpc"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/users"
 
 	"google.golang.org/grpc"
 	health "google.golang.org/grpc/health"
@@ -50,6 +52,21 @@ func main() {
 
 	rootCtx, rootCancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 
+	// TLS gRPC-сервера и клиентов; сертификаты перечитываются с диска до остановки.
+	grpcTLS, err := tlsconfig.New(cfg.GRPC.TLS)
+	if err != nil {
+		log.Error("grpc_tls_init_failed", slog.String("err", err.Error()))
+		rootCancel()
+		os.Exit(1)
+	}
+	grpcCreds, err := grpcTLS.ServerOption()
+	if err != nil {
+		log.Error("grpc_tls_init_failed", slog.String("err", err.Error()))
+		rootCancel()
+		os.Exit(1)
+	}
+	go grpcTLS.Run(rootCtx, log)
+
 	dbCtx, dbCancel := context.WithTimeout(rootCtx, 10*time.Second)
 	mongoStore, err := csmongo.New(dbCtx, cfg)
 	dbCancel()
@@ -61,6 +78,22 @@ func main() {
 	log.Info("mongo_connected")
 
 	svc := service.New(mongoStore, *cfg)
+
+	// Опционально: users-service для уведомлений об @упоминаниях.
+	var usersClient *users.Client
+	if cfg.Users.Addr != "" {
+		usersClient, err = users.New(cfg.Users.Addr, grpcTLS.DialOption())
+		if err != nil {
+			log.Error("users_client_init_failed", slog.String("err", err.Error()))
+			rootCancel()
+			_ = mongoStore.Close(context.Background())
+			os.Exit(1)
+		}
+		svc.SetUserResolver(usersClient)
+		log.Info("users_client_initialized", slog.String("addr", cfg.Users.Addr))
+	} else {
+		log.Warn("users_addr_empty_mentions_disabled")
+	}
 	log.Info("service_initialized")
 
 	// HTTP readiness/liveness/metrics
@@ -98,6 +131,7 @@ func main() {
 	grpc_prometheus.EnableHandlingTimeHistogram()
 
 	grpcOpts := []grpc.ServerOption{
+		grpcCreds,
 		grpc.ChainUnaryInterceptor(
 			interceptors.Recover(log),
 			interceptors.UnaryLoggingInterceptor(log),
@@ -175,7 +209,14 @@ func main() {
 	shutdownCancel()
 	_ = httpSrv.Shutdown(context.Background())
 
+	// Фоновые уведомления завершаются до закрытия users-клиента и хранилища
+	// (каждое ограничено notifications.timeout).
+	svc.Wait()
+
 	rootCancel()
+	if usersClient != nil {
+		_ = usersClient.Close()
+	}
 	_ = mongoStore.Close(context.Background())
 
 	log.Info("service_stopped")
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/pribylovaa/go-news-aggregator/pkg/tlsconfig"
 )
 
 // Config — корневая конфигурация сервиса.
@@ -24,6 +25,41 @@ type Config struct {
 	Limits   LimitsConfig  `yaml:"limits"`
 	TTL      TTLConfig     `yaml:"ttl"`
 	Timeouts TimeoutConfig `yaml:"timeouts"`
+
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Users         UsersConfig         `yaml:"users"`
+	Watch         WatchConfig         `yaml:"watch"`
+}
+
+// NotificationsConfig — уведомления об ответах и @упоминаниях.
+type NotificationsConfig struct {
+	// Enabled включает генерацию уведомлений при создании комментария.
+	// Без дефолта: cleanenv перетирает явный false из YAML значением env-default.
+	Enabled bool `yaml:"enabled" env:"NOTIFICATIONS_ENABLED"`
+	// TTL — срок хранения уведомления во «входящих». Значение должно быть >= 1h.
+	TTL time.Duration `yaml:"ttl" env:"NOTIFICATIONS_TTL" env-default:"720h"`
+	// MaxMentions — сколько уникальных @username из одного комментария учитывается.
+	MaxMentions int `yaml:"max_mentions" env:"NOTIFICATIONS_MAX_MENTIONS" env-default:"10"`
+	// StreamBuffer — размер буфера живой подписки; при переполнении события отбрасываются.
+	StreamBuffer int `yaml:"stream_buffer" env:"NOTIFICATIONS_STREAM_BUFFER" env-default:"16"`
+	// Timeout — дедлайн генерации уведомлений по одному комментарию (резолвер
+	// @username, заглушения, запись). Уведомления создаются после ответа клиенту.
+	Timeout time.Duration `yaml:"timeout" env:"NOTIFICATIONS_TIMEOUT" env-default:"3s"`
+}
+
+// WatchConfig — живая подписка на комментарии новости (WatchComments).
+type WatchConfig struct {
+	// Buffer — размер буфера подписчика; не успевший его вычитать отключается.
+	Buffer int `yaml:"buffer" env:"WATCH_BUFFER" env-default:"64"`
+	// Replay — сколько пропущенных комментариев досылается по last_event_id;
+	// если пропущено больше — событие reset_required.
+	Replay int `yaml:"replay" env:"WATCH_REPLAY" env-default:"200"`
+}
+
+// UsersConfig — клиент users-service (разрешение @username).
+// Пустой Addr отключает уведомления об упоминаниях.
+type UsersConfig struct {
+	Addr string `yaml:"addr" env:"USERS_ADDR"`
 }
 
 // TimeoutConfig — сервисные таймауты (общий дедлайн обработки запроса).
@@ -35,6 +71,8 @@ type TimeoutConfig struct {
 type GRPCConfig struct {
 	Host string `yaml:"host" env:"GRPC_HOST" env-default:"0.0.0.0"`
 	Port string `yaml:"port" env:"GRPC_PORT" env-default:"50054"`
+	// TLS — TLS/mTLS gRPC-сервера и исходящих gRPC-клиентов (pkg/tlsconfig).
+	TLS tlsconfig.Config `yaml:"tls" env-prefix:"GRPC_TLS_"`
 }
 
 // HTTPConfig — опциональный HTTP (health/metrics/pprof).
@@ -65,13 +103,15 @@ type TTLConfig struct {
 	Thread time.Duration `yaml:"thread" env:"THREAD_TTL" env-default:"168h"`
 }
 
-// LimitsConfig — лимиты на выдачу и глубину дерева.
+// LimitsConfig — лимиты на выдачу, глубину дерева и размер комментария.
 type LimitsConfig struct {
 	// Пагинация: page_size=0 -> берём Default; верхняя граница — Max.
 	Default int32 `yaml:"default"   env:"DEFAULT_LIMIT" env-default:"20"`
 	Max     int32 `yaml:"max"       env:"MAX_LIMIT"     env-default:"300"`
 	// Максимально допустимая глубина ветвления (level). Корень = 0.
 	MaxDepth int32 `yaml:"max_depth" env:"MAX_DEPTH"    env-default:"6"`
+	// Максимальная длина отрендеренного HTML комментария (в символах).
+	MaxRendered int `yaml:"max_rendered" env:"MAX_RENDERED" env-default:"10000"`
 }
 
 // MustLoad — обёртка над Load с panic при ошибке.
@@ -199,5 +239,33 @@ func (c *Config) validate() error {
 		return fmt.Errorf("limits.max_depth is too large (<= 32)")
 	}
 
+	if c.Limits.MaxRendered <= 0 {
+		return fmt.Errorf("limits.max_rendered must be > 0")
+	}
+
+	if c.Notifications.TTL < time.Hour {
+		return fmt.Errorf("notifications.ttl must be at least 1h")
+	}
+
+	if c.Notifications.MaxMentions < 0 {
+		return fmt.Errorf("notifications.max_mentions must be >= 0")
+	}
+
+	if c.Notifications.StreamBuffer <= 0 {
+		return fmt.Errorf("notifications.stream_buffer must be > 0")
+	}
+
+	if c.Notifications.Timeout <= 0 {
+		return fmt.Errorf("notifications.timeout must be > 0")
+	}
+
+	if c.Watch.Buffer <= 0 {
+		return fmt.Errorf("watch.buffer must be > 0")
+	}
+
+	if c.Watch.Replay < 0 {
+		return fmt.Errorf("watch.replay must be >= 0")
+	}
+
 	return nil
 }
@@ -41,10 +41,22 @@ limits:
   default: 15
   max: 200
   max_depth: 8
+  max_rendered: 5000
 ttl:
   thread: "240h"
 timeouts:
   service: 3s
+notifications:
+  enabled: false
+  ttl: "48h"
+  max_mentions: 3
+  stream_buffer: 4
+  timeout: 2s
+users:
+  addr: "users:50053"
+watch:
+  buffer: 8
+  replay: 50
 `
 
 // Минимально валидный YAML (только обязательные поля).
@@ -107,9 +119,19 @@ func TestLoad_WithExplicitPath_OK(t *testing.T) {
 	require.EqualValues(t, int32(15), cfg.Limits.Default)
 	require.EqualValues(t, int32(200), cfg.Limits.Max)
 	require.EqualValues(t, int32(8), cfg.Limits.MaxDepth)
+	require.Equal(t, 5000, cfg.Limits.MaxRendered)
 
 	require.Equal(t, 240*time.Hour, cfg.TTL.Thread)
 	require.Equal(t, 3*time.Second, cfg.Timeouts.Service)
+
+	require.False(t, cfg.Notifications.Enabled)
+	require.Equal(t, 48*time.Hour, cfg.Notifications.TTL)
+	require.Equal(t, 3, cfg.Notifications.MaxMentions)
+	require.Equal(t, 4, cfg.Notifications.StreamBuffer)
+	require.Equal(t, 2*time.Second, cfg.Notifications.Timeout)
+	require.Equal(t, "users:50053", cfg.Users.Addr)
+	require.Equal(t, 8, cfg.Watch.Buffer)
+	require.Equal(t, 50, cfg.Watch.Replay)
 }
 
 // TestLoad_WithExplicitPath_BrokenYAML — битый YAML по явному пути.
@@ -143,8 +165,17 @@ func TestLoad_WithCONFIG_PATH_OK(t *testing.T) {
 	require.EqualValues(t, int32(20), cfg.Limits.Default)
 	require.EqualValues(t, int32(300), cfg.Limits.Max)
 	require.EqualValues(t, int32(6), cfg.Limits.MaxDepth)
+	require.Equal(t, 10000, cfg.Limits.MaxRendered)
 	require.Equal(t, 168*time.Hour, cfg.TTL.Thread)
 	require.Equal(t, 5*time.Second, cfg.Timeouts.Service)
+	require.False(t, cfg.Notifications.Enabled)
+	require.Equal(t, 720*time.Hour, cfg.Notifications.TTL)
+	require.Equal(t, 10, cfg.Notifications.MaxMentions)
+	require.Equal(t, 16, cfg.Notifications.StreamBuffer)
+	require.Equal(t, 3*time.Second, cfg.Notifications.Timeout)
+	require.Empty(t, cfg.Users.Addr)
+	require.Equal(t, 64, cfg.Watch.Buffer)
+	require.Equal(t, 200, cfg.Watch.Replay)
 }
 
 // TestLoad_WithLocalYAML_OK — если нет CONFIG_PATH, берётся ./local.yaml.
@@ -302,6 +333,34 @@ limits: { default: 100, max: 10, max_depth: 0 }
 	require.Contains(t, err.Error(), "limits.default must be <= limits.max")
 }
 
+func TestLoad_InvalidNotificationsTTL_ReturnsError(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "bad_notifications.yaml", `
+db: { url: "mongodb://localhost:27017/comments" }
+notifications: { ttl: "30m" }
+`)
+
+	_, err := Load(cfgPath)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "notifications.ttl must be at least 1h")
+}
+
+func TestLoad_InvalidWatch_ReturnsError(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "bad_watch.yaml", `
+db: { url: "mongodb://localhost:27017/comments" }
+watch: { buffer: -1 }
+`)
+
+	_, err := Load(cfgPath)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "watch.buffer must be > 0")
+}
+
 // TestMustLoad_OK — успешная загрузка по явному пути.
 func TestMustLoad_OK(t *testing.T) {
 	t.Parallel()
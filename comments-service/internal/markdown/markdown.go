@@ -0,0 +1,309 @@
+// markdown — рендер ограниченного подмножества Markdown для комментариев в безопасный HTML.
+//
+// Поддерживается:
+//   - абзацы (разделяются пустой строкой), одиночный перевод строки — <br>;
+//   - *курсив* / _курсив_, **жирный** / __жирный__;
+//   - `код` и блоки кода ``` ... ```;
+//   - цитаты "> ..." (вложенность ограничена maxQuoteDepth);
+//   - ссылки [текст](url) только со схемами http/https/mailto; получают rel="nofollow ugc".
+//
+// Безопасность обеспечивается построением: сырой HTML во входе не пропускается никогда —
+// весь текст экранируется, а теги генерирует только сам рендер из фиксированного набора.
+// Всё, что не распознано как разметка, выводится как экранированный текст.
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+const (
+	// maxQuoteDepth — максимальная вложенность цитат; глубже "> " выводится как текст.
+	maxQuoteDepth = 5
+	// maxInlineDepth — максимальная вложенность выделений (защита от патологических входов).
+	maxInlineDepth = 8
+)
+
+// allowedSchemes — схемы, допустимые в ссылках.
+var allowedSchemes = map[string]struct{}{
+	"http":   {},
+	"https":  {},
+	"mailto": {},
+}
+
+// Render преобразует исходный текст комментария в санитизированный HTML.
+func Render(src string) string {
+	return renderBlocks(normalize(src), 0)
+}
+
+// RenderPlain рендерит текст без разметки: только экранирование, абзацы и переводы строк.
+// Используется для комментариев, сохранённых до появления Markdown.
+func RenderPlain(src string) string {
+	var b strings.Builder
+	for _, para := range splitParagraphs(strings.Split(normalize(src), "\n")) {
+		b.WriteString("<p>")
+		for i, line := range para {
+			if i > 0 {
+				b.WriteString("<br>\n")
+			}
+			b.WriteString(html.EscapeString(line))
+		}
+		b.WriteString("</p>\n")
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// normalize приводит переводы строк к \n и обрезает пробелы по краям.
+func normalize(src string) string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+
+	return strings.TrimSpace(src)
+}
+
+// splitParagraphs группирует строки в абзацы по пустым строкам.
+func splitParagraphs(lines []string) [][]string {
+	var out [][]string
+	var cur []string
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if len(cur) > 0 {
+				out = append(out, cur)
+				cur = nil
+			}
+			continue
+		}
+		cur = append(cur, line)
+	}
+
+	if len(cur) > 0 {
+		out = append(out, cur)
+	}
+
+	return out
+}
+
+// renderBlocks разбирает блочную структуру: блоки кода, цитаты и абзацы.
+func renderBlocks(src string, quoteDepth int) string {
+	lines := strings.Split(src, "\n")
+
+	var b strings.Builder
+	var para []string
+	flush := func() {
+		if len(para) == 0 {
+			return
+		}
+
+		b.WriteString("<p>")
+		for i, line := range para {
+			if i > 0 {
+				b.WriteString("<br>\n")
+			}
+			b.WriteString(renderInline(strings.TrimSpace(line), 0))
+		}
+		b.WriteString("</p>\n")
+		para = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+
+			b.WriteString("<pre><code>")
+			b.WriteString(html.EscapeString(strings.Join(code, "\n")))
+			b.WriteString("</code></pre>\n")
+
+		case strings.HasPrefix(trimmed, ">") && quoteDepth < maxQuoteDepth:
+			flush()
+
+			var quoted []string
+			for ; i < len(lines); i++ {
+				t := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(t, ">") {
+					break
+				}
+				t = strings.TrimPrefix(t, ">")
+				quoted = append(quoted, strings.TrimPrefix(t, " "))
+			}
+			i--
+
+			b.WriteString("<blockquote>\n")
+			b.WriteString(renderBlocks(strings.Join(quoted, "\n"), quoteDepth+1))
+			b.WriteString("\n</blockquote>\n")
+
+		default:
+			para = append(para, line)
+		}
+	}
+	flush()
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renderInline рендерит строчную разметку: экранирование, код, ссылки и выделения.
+func renderInline(s string, depth int) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte("\\`*_[]()>#", s[i+1]) >= 0:
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end > 0 {
+				b.WriteString("<code>")
+				b.WriteString(html.EscapeString(s[i+1 : i+1+end]))
+				b.WriteString("</code>")
+				i += end + 2
+				continue
+			}
+
+		case c == '[':
+			if out, n, ok := renderLink(s[i:], depth); ok {
+				b.WriteString(out)
+				i += n
+				continue
+			}
+
+		case (c == '*' || c == '_') && depth < maxInlineDepth:
+			if out, n, ok := renderEmphasis(s, i, depth); ok {
+				b.WriteString(out)
+				i += n
+				continue
+			}
+		}
+
+		b.WriteString(html.EscapeString(s[i : i+1]))
+		i++
+	}
+
+	return b.String()
+}
+
+// renderEmphasis пытается распознать выделение, начинающееся в s[i].
+// Возвращает HTML, число поглощённых байт и признак успеха.
+func renderEmphasis(s string, i int, depth int) (string, int, bool) {
+	c := s[i]
+	delim := string(c)
+	tag := "em"
+	if strings.HasPrefix(s[i:], delim+delim) {
+		delim += delim
+		tag = "strong"
+	}
+
+	// '_' внутри слова (snake_case) не является разметкой.
+	if c == '_' && i > 0 && isWordByte(s[i-1]) {
+		return "", 0, false
+	}
+
+	start := i + len(delim)
+	if start >= len(s) || s[start] == ' ' {
+		return "", 0, false
+	}
+
+	for pos := start; pos < len(s); {
+		k := strings.Index(s[pos:], delim)
+		if k < 0 {
+			break
+		}
+
+		closeAt := pos + k
+		after := closeAt + len(delim)
+		// В серии "***" двойной разделитель закрывается последними символами.
+		for len(delim) == 2 && after < len(s) && s[after] == c {
+			closeAt++
+			after++
+		}
+		valid := closeAt > start && s[closeAt-1] != ' '
+		if c == '_' && after < len(s) && isWordByte(s[after]) {
+			valid = false
+		}
+		// Для одиночного разделителя не путаем его с частью "**"/"__".
+		if len(delim) == 1 && (after < len(s) && s[after] == c || s[closeAt-1] == c) {
+			valid = false
+		}
+
+		if valid {
+			inner := renderInline(s[start:closeAt], depth+1)
+			return "<" + tag + ">" + inner + "</" + tag + ">", after - i, true
+		}
+
+		pos = closeAt + 1
+	}
+
+	return "", 0, false
+}
+
+// renderLink распознаёт [текст](url) в начале s.
+// Ссылки с недопустимой схемой не распознаются и выводятся как текст.
+func renderLink(s string, depth int) (string, int, bool) {
+	closeText := strings.Index(s, "](")
+	if closeText <= 1 {
+		return "", 0, false
+	}
+
+	text := s[1:closeText]
+	if strings.ContainsAny(text, "[]") {
+		return "", 0, false
+	}
+
+	rest := s[closeText+2:]
+	closeURL := strings.IndexByte(rest, ')')
+	if closeURL <= 0 {
+		return "", 0, false
+	}
+
+	href, ok := safeURL(rest[:closeURL])
+	if !ok {
+		return "", 0, false
+	}
+
+	out := `<a href="` + html.EscapeString(href) + `" rel="nofollow ugc">` + renderInline(text, depth+1) + "</a>"
+	return out, closeText + 2 + closeURL + 1, true
+}
+
+// safeURL проверяет ссылку: без пробелов и управляющих символов, схема из allowedSchemes.
+func safeURL(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.ContainsAny(raw, " \t\n\"'<>`") {
+		return "", false
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if _, ok := allowedSchemes[scheme]; !ok {
+		return "", false
+	}
+
+	if scheme != "mailto" && u.Host == "" {
+		return "", false
+	}
+
+	return u.String(), true
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
@@ -0,0 +1,77 @@
+package markdown
+
+// Тесты рендера Markdown-подмножества (internal/markdown/markdown.go).
+//
+//  Проверяем:
+//  - выделения, код, цитаты, абзацы и переводы строк;
+//  - ссылки: rel="nofollow ugc", допустимые схемы, отбрасывание javascript:/data:;
+//  - экранирование любого сырого HTML и атрибутов;
+//  - RenderPlain для комментариев без разметки.
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "hello", "<p>hello</p>"},
+		{"emphasis", "*a* and **b** and _c_", "<p><em>a</em> and <strong>b</strong> and <em>c</em></p>"},
+		{"nested", "**bold *it***", "<p><strong>bold <em>it</em></strong></p>"},
+		{"snake_case", "use snake_case_name here", "<p>use snake_case_name here</p>"},
+		{"unclosed", "2 * 3 = 6 and *open", "<p>2 * 3 = 6 and *open</p>"},
+		{"code", "run `rm -rf <dir>` now", "<p>run <code>rm -rf &lt;dir&gt;</code> now</p>"},
+		{"code ignores markup", "`*not em*`", "<p><code>*not em*</code></p>"},
+		{"escape", `\*literal\*`, "<p>*literal*</p>"},
+		{"paragraphs", "a\nb\n\nc", "<p>a<br>\nb</p>\n<p>c</p>"},
+		{"code block", "```\n<b>x</b>\n  y\n```", "<pre><code>&lt;b&gt;x&lt;/b&gt;\n  y</code></pre>"},
+		{"quote", "> quoted *text*\n> more\n\nafter", "<blockquote>\n<p>quoted <em>text</em><br>\nmore</p>\n</blockquote>\n<p>after</p>"},
+		{"link", "see [docs](https://example.com/a?b=1&c=2)", `<p>see <a href="https://example.com/a?b=1&amp;c=2" rel="nofollow ugc">docs</a></p>`},
+		{"link emphasis", "[**x**](http://e.com)", `<p><a href="http://e.com" rel="nofollow ugc"><strong>x</strong></a></p>`},
+		{"mailto", "[mail](mailto:a@b.c)", `<p><a href="mailto:a@b.c" rel="nofollow ugc">mail</a></p>`},
+		{"javascript link", "[x](javascript:alert(1))", "<p>[x](javascript:alert(1))</p>"},
+		{"data link", "[x](data:text/html;base64,AAAA)", "<p>[x](data:text/html;base64,AAAA)</p>"},
+		{"relative link", "[x](/etc/passwd)", "<p>[x](/etc/passwd)</p>"},
+		{"quote in url", `[x](https://e.com/"onmouseover=)`, `<p>[x](https://e.com/&#34;onmouseover=)</p>`},
+		{"raw html", `<script>alert("x")</script>`, "<p>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</p>"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, Render(tc.in))
+		})
+	}
+}
+
+func TestRender_QuoteDepthLimited(t *testing.T) {
+	out := Render(strings.Repeat(">", 50) + " deep")
+	require.Equal(t, maxQuoteDepth, strings.Count(out, "<blockquote>"))
+}
+
+func TestRender_NeverEmitsRawTags(t *testing.T) {
+	inputs := []string{
+		"<img src=x onerror=alert(1)>",
+		"**<i>x</i>**",
+		"[<b>x</b>](https://e.com)",
+		"> <iframe>",
+		"`</code><script>`",
+	}
+
+	for _, in := range inputs {
+		out := Render(in)
+		for _, tag := range []string{"<img", "<i>", "<b>", "<iframe", "<script"} {
+			require.NotContains(t, out, tag, "input %q", in)
+		}
+	}
+}
+
+func TestRenderPlain(t *testing.T) {
+	require.Equal(t, "<p>a *b* &lt;c&gt;<br>\nd</p>\n<p>e</p>", RenderPlain("a *b* <c>\r\nd\n\n\ne"))
+	require.Equal(t, "", RenderPlain("  "))
+}
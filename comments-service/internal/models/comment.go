@@ -12,24 +12,51 @@ import (
 //   - ID — ObjectID MongoDB. Наружу/вовнутрь конвертируется в string.
 //   - NewsID/UserID/Username — UUID из смежных сервисов (news-service/users-service).
 //   - ParentID — ObjectID родителя.
+//   - RootID — ObjectID корня ветки (пусто у самого корня); см. ThreadID.
+//   - Content — исходный текст (подмножество Markdown, см. internal/markdown).
+//   - ContentHTML — санитизированный HTML, отрендеренный при записи; пуст у комментариев,
+//     сохранённых до появления Markdown (для них HTML строится из plain text при чтении).
 //   - Level — глубина ветки (корень = 0). Проверяется на запись по cfg.Limits.MaxDepth.
 //   - RepliesCount — количество прямых детей (для UI, может обновляться асинхронно).
-//   - IsDeleted — мягкое удаление; при отдаче наружу content может маскироваться.
-//   - ExpiresAt — единая «дата смерти» ветки; у ответов совпадает с корнем (TTL-индекс).
+//   - Reactions — реакция -> число поставивших (см. Reactions); нулевые счётчики опускаются.
+//   - IsDeleted — мягкое удаление: content и content_html стираются, остаётся «надгробие».
+//   - IsLocked — ветка заблокирована модератором (только чтение); одинаково у всех комментариев ветки.
+//   - ExpiresAt — момент, после которого ветка закрыта для записи; у ответов совпадает с корнем.
+//     Нулевое значение — ветка бессрочная. Физически комментарии не удаляются.
 //   - CreatedAt/UpdatedAt — наружу/внутрь gRPC конвертируем в int64.
 type Comment struct {
-	ID           string    `bson:"_id,omitempty"`
-	NewsID       uuid.UUID `bson:"news_id"`
-	ParentID     string    `bson:"parent_id"`
-	UserID       uuid.UUID `bson:"user_id"`
-	Username     string    `bson:"username"`
-	Content      string    `bson:"content"`
-	Level        int32     `bson:"level"`
-	RepliesCount int32     `bson:"replies_count"`
-	IsDeleted    bool      `bson:"is_deleted"`
-	CreatedAt    time.Time `bson:"created_at"`
-	UpdatedAt    time.Time `bson:"updated_at"`
-	ExpiresAt    time.Time `bson:"expires_at"`
+	ID           string           `bson:"_id,omitempty"`
+	NewsID       uuid.UUID        `bson:"news_id"`
+	ParentID     string           `bson:"parent_id"`
+	RootID       string           `bson:"root_id,omitempty"`
+	UserID       uuid.UUID        `bson:"user_id"`
+	Username     string           `bson:"username"`
+	Content      string           `bson:"content"`
+	ContentHTML  string           `bson:"content_html,omitempty"`
+	Level        int32            `bson:"level"`
+	RepliesCount int32            `bson:"replies_count"`
+	Reactions    map[string]int64 `bson:"reactions,omitempty"`
+	IsDeleted    bool             `bson:"is_deleted"`
+	IsLocked     bool             `bson:"is_locked"`
+	CreatedAt    time.Time        `bson:"created_at"`
+	UpdatedAt    time.Time        `bson:"updated_at"`
+	ExpiresAt    time.Time        `bson:"expires_at,omitempty"`
+}
+
+// ThreadID возвращает идентификатор ветки: ID корня для ответов и собственный ID для корня.
+// root_id старых ответов проставляется при старте хранилища; ответ, корень которого
+// не нашёлся (родитель удалён), считается корнем собственной ветки.
+func (c Comment) ThreadID() string {
+	if c.RootID != "" {
+		return c.RootID
+	}
+
+	return c.ID
+}
+
+// IsExpired сообщает, истёк ли срок записи в ветку на момент now.
+func (c Comment) IsExpired(now time.Time) bool {
+	return !c.ExpiresAt.IsZero() && !now.Before(c.ExpiresAt)
 }
 
 // ListParams — базовые параметры постраничной выдачи.
@@ -43,3 +70,26 @@ type Page struct {
 	Items         []Comment
 	NextPageToken string
 }
+
+// SearchQuery — параметры полнотекстового поиска комментариев.
+//   - Text — поисковая строка (синтаксис $text MongoDB: слова, "фразы", -исключения).
+//   - NewsID/UserID — необязательные фильтры (uuid.Nil — без фильтра).
+//   - IncludeDeleted — не исключать мягко удалённые (только для модераторов); их текст стёрт,
+//     поэтому по тексту они не находятся.
+type SearchQuery struct {
+	Text           string
+	NewsID         uuid.UUID
+	UserID         uuid.UUID
+	IncludeDeleted bool
+}
+
+// NewsCounts — счётчики комментариев одной новости.
+//   - Total/Roots — число неудалённых комментариев/корней (мягко удалённые не учитываются;
+//     закрытые по сроку ветки остаются видимыми и учитываются).
+//   - LastActivityAt — время последнего созданного комментария (нулевое — комментариев не было).
+type NewsCounts struct {
+	NewsID         uuid.UUID `bson:"_id"`
+	Total          int64     `bson:"total"`
+	Roots          int64     `bson:"roots"`
+	LastActivityAt time.Time `bson:"last_activity_at,omitempty"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// CommentEventKind — тип события живой подписки на комментарии новости.
+type CommentEventKind string
+
+const (
+	// CommentEventCreated — новый комментарий.
+	CommentEventCreated CommentEventKind = "created"
+	// CommentEventDeleted — мягкое удаление; в Comment заполнены ID, NewsID, ParentID, RootID.
+	CommentEventDeleted CommentEventKind = "deleted"
+	// CommentEventReaction — изменились реакции; в Comment заполнены ID, NewsID, ParentID, RootID, Reactions.
+	CommentEventReaction CommentEventKind = "reaction"
+)
+
+// CommentEvent — событие брокера живых подписок (в хранилище не пишется).
+type CommentEvent struct {
+	Kind    CommentEventKind
+	Comment Comment
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationKind — причина уведомления.
+type NotificationKind string
+
+const (
+	// NotificationKindReply — ответ на комментарий получателя.
+	NotificationKindReply NotificationKind = "reply"
+	// NotificationKindMention — упоминание получателя через @username.
+	NotificationKindMention NotificationKind = "mention"
+)
+
+// Notification — запись во «входящих» пользователя (MongoDB).
+// Важно:
+//   - UserID — получатель; ActorID/ActorUsername — автор комментария-источника.
+//   - CommentID — комментарий, породивший уведомление; ThreadID — корень его ветки.
+//   - ExpiresAt — срок хранения записи (TTL-индекс), наружу не отдаётся.
+type Notification struct {
+	ID            string           `bson:"_id,omitempty"`
+	UserID        uuid.UUID        `bson:"user_id"`
+	Kind          NotificationKind `bson:"kind"`
+	CommentID     string           `bson:"comment_id"`
+	NewsID        uuid.UUID        `bson:"news_id"`
+	ThreadID      string           `bson:"thread_id"`
+	ActorID       uuid.UUID        `bson:"actor_id"`
+	ActorUsername string           `bson:"actor_username"`
+	IsRead        bool             `bson:"is_read"`
+	CreatedAt     time.Time        `bson:"created_at"`
+	ExpiresAt     time.Time        `bson:"expires_at"`
+}
+
+// NotificationsPage — результат постраничной выдачи уведомлений.
+type NotificationsPage struct {
+	Items         []Notification
+	NextPageToken string
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ThreadPolicyMode — режим жизни веток комментариев новости.
+type ThreadPolicyMode string
+
+const (
+	// ThreadPolicyDefault — политика не задана: ветки закрываются через cfg.TTL.Thread.
+	ThreadPolicyDefault ThreadPolicyMode = ""
+	// ThreadPolicyForever — ветки открыты для записи бессрочно.
+	ThreadPolicyForever ThreadPolicyMode = "forever"
+	// ThreadPolicyExpire — ветки закрываются для записи через TTL после создания корня.
+	ThreadPolicyExpire ThreadPolicyMode = "expire"
+	// ThreadPolicyLocked — все ветки новости доступны только для чтения.
+	ThreadPolicyLocked ThreadPolicyMode = "locked"
+)
+
+// ThreadPolicy — политика жизни веток для одной новости (MongoDB).
+// TTL имеет смысл только для ThreadPolicyExpire (и для Default — как эффективное значение из конфига).
+type ThreadPolicy struct {
+	NewsID    uuid.UUID        `bson:"news_id"`
+	Mode      ThreadPolicyMode `bson:"mode"`
+	TTL       time.Duration    `bson:"ttl"`
+	UpdatedAt time.Time        `bson:"updated_at"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "slices"
+
+// Reactions — допустимые реакции на комментарии.
+// Пользователь может поставить комментарию несколько разных реакций, каждую — один раз.
+var Reactions = []string{"like", "love", "laugh", "wow", "sad", "angry"}
+
+// IsReaction сообщает, входит ли r в Reactions.
+func IsReaction(r string) bool {
+	return slices.Contains(Reactions, r)
+}
@@ -5,14 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/pribylovaa/go-news-aggregator/pkg/log"
 
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/markdown"
 	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
 	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
 )
 
+// maxCountsBatch — максимум новостей в одном запросе CountsByNews (страница ленты с запасом).
+const maxCountsBatch = 200
+
 // Входные структуры сервисного слоя.
 
 // CreateCommentInput — создание корневого комментария или ответа.
@@ -48,14 +53,20 @@ type ListRepliesInput struct {
 // Валидация:
 //   - UserID обязателен (uuid.Nil -> ErrInvalidArgument);
 //   - Username и Content нормализуются (TrimSpace) и не должны быть пустыми;
-//   - Если ParentID пуст (создание корня) — NewsID обязателен (uuid.Nil -> ErrInvalidArgument).
+//   - Если ParentID пуст (создание корня) — NewsID обязателен (uuid.Nil -> ErrInvalidArgument);
+//   - Content рендерится из Markdown-подмножества в санитизированный HTML (internal/markdown);
+//     HTML длиннее cfg.Limits.MaxRendered символов -> ErrInvalidArgument.
 //
 // Поведение/ошибки:
 //   - ErrParentNotFound — если указан ParentID, но родитель отсутствует;
-//   - ErrThreadExpired — если истёк TTL ветки (корня);
+//   - ErrThreadExpired — если ветка закрыта для записи (истёк срок, заблокирована модератором
+//     или политикой новости);
 //   - ErrMaxDepthExceeded — если превышена максимальная глубина;
 //   - ErrConflict — конфликт уникальности;
 //   - ErrInternal — прочие ошибки стораджа/БД/контекста.
+//
+// После успешной записи (при cfg.Notifications.Enabled) в фоне создаются уведомления
+// об ответе и @упоминаниях — см. notifyAsync: их задержка и ошибки на запись не влияют.
 func (s *Service) CreateComment(ctx context.Context, in CreateCommentInput) (*models.Comment, error) {
 	const op = "service/comments/CreateComment"
 
@@ -90,12 +101,19 @@ func (s *Service) CreateComment(ctx context.Context, in CreateCommentInput) (*mo
 		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
 	}
 
+	rendered := markdown.Render(in.Content)
+	if limit := s.cfg.Limits.MaxRendered; limit > 0 && utf8.RuneCountInString(rendered) > limit {
+		lg.Warn("invalid argument: rendered content too long", "limit", limit)
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
 	comm := models.Comment{
-		NewsID:   in.NewsID,
-		ParentID: strings.TrimSpace(in.ParentID),
-		UserID:   in.UserID,
-		Username: in.Username,
-		Content:  in.Content,
+		NewsID:      in.NewsID,
+		ParentID:    strings.TrimSpace(in.ParentID),
+		UserID:      in.UserID,
+		Username:    in.Username,
+		Content:     in.Content,
+		ContentHTML: rendered,
 	}
 
 	result, err := s.storage.CreateComment(ctx, comm)
@@ -119,6 +137,12 @@ func (s *Service) CreateComment(ctx context.Context, in CreateCommentInput) (*mo
 		}
 	}
 
+	s.publishComment(models.CommentEventCreated, *result)
+
+	if s.cfg.Notifications.Enabled {
+		s.notifyAsync(ctx, *result)
+	}
+
 	return result, nil
 }
 
@@ -128,6 +152,7 @@ func (s *Service) CreateComment(ctx context.Context, in CreateCommentInput) (*mo
 //   - id не должен быть пустым.
 //
 // Поведение/ошибки:
+//   - первое удаление рассылается подписчикам WatchComments новости;
 //   - ErrNotFound — если комментарий не найден;
 //   - ErrInternal — иные ошибки стораджа.
 func (s *Service) DeleteComment(ctx context.Context, id string) error {
@@ -141,7 +166,8 @@ func (s *Service) DeleteComment(ctx context.Context, id string) error {
 		return fmt.Errorf("%s: %w", op, ErrInvalidArgument)
 	}
 
-	if err := s.storage.DeleteComment(ctx, id); err != nil {
+	deleted, err := s.storage.DeleteComment(ctx, id)
+	if err != nil {
 		switch {
 		case errors.Is(err, storage.ErrNotFound):
 			lg.Warn("comment not found")
@@ -152,6 +178,11 @@ func (s *Service) DeleteComment(ctx context.Context, id string) error {
 		}
 	}
 
+	// Повторное удаление (deleted == nil) подписчикам не рассылается.
+	if deleted != nil {
+		s.publishComment(models.CommentEventDeleted, *deleted)
+	}
+
 	return nil
 }
 
@@ -186,6 +217,8 @@ func (s *Service) CommentByID(ctx context.Context, id string) (*models.Comment,
 		}
 	}
 
+	withRenderedContent(result)
+
 	return result, nil
 }
 
@@ -222,6 +255,10 @@ func (s *Service) ListByNews(ctx context.Context, in ListByNewsInput) (*models.P
 		}
 	}
 
+	for i := range page.Items {
+		withRenderedContent(&page.Items[i])
+	}
+
 	return page, nil
 }
 
@@ -259,5 +296,73 @@ func (s *Service) ListReplies(ctx context.Context, in ListRepliesInput) (*models
 		}
 	}
 
+	for i := range page.Items {
+		withRenderedContent(&page.Items[i])
+	}
+
 	return page, nil
 }
+
+// withRenderedContent заполняет ContentHTML комментариев, сохранённых до появления Markdown:
+// их текст экранируется как plain text, а не интерпретируется как разметка.
+func withRenderedContent(c *models.Comment) {
+	if c == nil || c.IsDeleted || c.ContentHTML != "" || c.Content == "" {
+		return
+	}
+
+	c.ContentHTML = markdown.RenderPlain(c.Content)
+}
+
+// CountsByNews — счётчики комментариев для набора новостей (для карточек ленты).
+//
+// Валидация:
+//   - newsIDs непуст и не длиннее maxCountsBatch; uuid.Nil недопустим (-> ErrInvalidArgument).
+//
+// Поведение/ошибки:
+//   - дубликаты схлопываются; результат — в порядке первого появления ID;
+//   - для новостей без комментариев возвращаются нулевые счётчики;
+//   - ErrInternal — ошибки стораджа.
+func (s *Service) CountsByNews(ctx context.Context, newsIDs []uuid.UUID) ([]models.NewsCounts, error) {
+	const op = "service/comments/CountsByNews"
+
+	lg := log.From(ctx).With("op", op, "count", len(newsIDs))
+
+	if len(newsIDs) == 0 || len(newsIDs) > maxCountsBatch {
+		lg.Warn("invalid argument: news_ids size out of range")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	ids := make([]uuid.UUID, 0, len(newsIDs))
+	seen := make(map[uuid.UUID]struct{}, len(newsIDs))
+	for _, id := range newsIDs {
+		if id == uuid.Nil {
+			lg.Warn("invalid argument: empty news_id")
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+		}
+
+		if _, ok := seen[id]; ok {
+			continue
+		}
+
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	counts, err := s.storage.CountsByNews(ctx, ids)
+	if err != nil {
+		lg.Error("storage error on CountsByNews", "err", err)
+		return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	out := make([]models.NewsCounts, 0, len(ids))
+	for _, id := range ids {
+		c, ok := counts[id]
+		if !ok {
+			c = models.NewsCounts{NewsID: id}
+		}
+
+		out = append(out, c)
+	}
+
+	return out, nil
+}
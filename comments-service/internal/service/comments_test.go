@@ -225,12 +225,12 @@ func TestService_DeleteComment_Mapping(t *testing.T) {
 	defer ctrl.Finish()
 
 	// NotFound
-	ms.EXPECT().DeleteComment(gomock.Any(), "42").Return(storage.ErrNotFound)
+	ms.EXPECT().DeleteComment(gomock.Any(), "42").Return(nil, storage.ErrNotFound)
 	err := s.DeleteComment(context.Background(), "42")
 	require.ErrorIs(t, err, ErrNotFound)
 
 	// Internal
-	ms.EXPECT().DeleteComment(gomock.Any(), "42").Return(errors.New("db down"))
+	ms.EXPECT().DeleteComment(gomock.Any(), "42").Return(nil, errors.New("db down"))
 	err = s.DeleteComment(context.Background(), "42")
 	require.ErrorIs(t, err, ErrInternal)
 }
@@ -240,7 +240,7 @@ func TestService_DeleteComment_OK(t *testing.T) {
 	s, ms, ctrl := newServiceWithMocks(t)
 	defer ctrl.Finish()
 
-	ms.EXPECT().DeleteComment(gomock.Any(), "55").Return(nil)
+	ms.EXPECT().DeleteComment(gomock.Any(), "55").Return(nil, nil)
 	require.NoError(t, s.DeleteComment(context.Background(), "55"))
 }
 
@@ -410,3 +410,89 @@ func TestService_ListReplies_OK(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, want, got)
 }
+
+// Markdown: в сторадж уходят исходник и отрендеренный HTML.
+func TestService_CreateComment_RendersMarkdown(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	ms.EXPECT().
+		CreateComment(gomock.Any(), gomock.AssignableToTypeOf(models.Comment{})).
+		DoAndReturn(func(_ context.Context, c models.Comment) (*models.Comment, error) {
+			require.Equal(t, "see [docs](https://e.com) *now*", c.Content)
+			require.Equal(t, `<p>see <a href="https://e.com" rel="nofollow ugc">docs</a> <em>now</em></p>`, c.ContentHTML)
+			return &c, nil
+		})
+
+	_, err := s.CreateComment(context.Background(), CreateCommentInput{
+		NewsID: uuid.New(), UserID: uuid.New(), Username: "alice", Content: "see [docs](https://e.com) *now*",
+	})
+	require.NoError(t, err)
+}
+
+// Лимит длины применяется к отрендеренному HTML: экранирование раздувает "<" до "&lt;".
+func TestService_CreateComment_RenderedTooLong(t *testing.T) {
+	s, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	s.cfg.Limits.MaxRendered = 20
+
+	_, err := s.CreateComment(context.Background(), CreateCommentInput{
+		NewsID: uuid.New(), UserID: uuid.New(), Username: "alice", Content: "<<<<<<",
+	})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+// Комментарии без content_html (до Markdown) рендерятся как экранированный plain text.
+func TestService_CommentByID_LegacyPlainText(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	legacy := mustComment(uuid.New(), "", "alice", "2 * 3 <b>")
+	deleted := mustComment(uuid.New(), "", "bob", "")
+	deleted.IsDeleted = true
+	ms.EXPECT().CommentByID(gomock.Any(), legacy.ID).Return(legacy, nil)
+	ms.EXPECT().ListByNews(gomock.Any(), deleted.NewsID.String(), gomock.Any()).
+		Return(&models.Page{Items: []models.Comment{*deleted}}, nil)
+
+	got, err := s.CommentByID(context.Background(), legacy.ID)
+	require.NoError(t, err)
+	require.Equal(t, "<p>2 * 3 &lt;b&gt;</p>", got.ContentHTML)
+
+	page, err := s.ListByNews(context.Background(), ListByNewsInput{NewsID: deleted.NewsID})
+	require.NoError(t, err)
+	require.Empty(t, page.Items[0].ContentHTML)
+}
+
+// CountsByNews: валидация, дедупликация, нули для новостей без комментариев, порядок запроса.
+func TestService_CountsByNews(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	_, err := s.CountsByNews(ctx, nil)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = s.CountsByNews(ctx, make([]uuid.UUID, maxCountsBatch+1))
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = s.CountsByNews(ctx, []uuid.UUID{uuid.New(), uuid.Nil})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	a, b := uuid.New(), uuid.New()
+	last := time.Now().UTC()
+	ms.EXPECT().CountsByNews(gomock.Any(), []uuid.UUID{a, b}).
+		Return(map[uuid.UUID]models.NewsCounts{b: {NewsID: b, Total: 3, Roots: 1, LastActivityAt: last}}, nil)
+
+	got, err := s.CountsByNews(ctx, []uuid.UUID{a, b, a})
+	require.NoError(t, err)
+	require.Equal(t, []models.NewsCounts{
+		{NewsID: a},
+		{NewsID: b, Total: 3, Roots: 1, LastActivityAt: last},
+	}, got)
+
+	ms.EXPECT().CountsByNews(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+	_, err = s.CountsByNews(ctx, []uuid.UUID{a})
+	require.ErrorIs(t, err, ErrInternal)
+}
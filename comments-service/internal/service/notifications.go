@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+)
+
+// maxMentionLength — верхняя граница длины @username (в рунах); более длинные токены игнорируются.
+const maxMentionLength = 64
+
+// mentionRe — @username в начале текста или после символа, не входящего в username
+// (чтобы не ловить e-mail вида a@b.c).
+var mentionRe = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@-])@([\p{L}\p{N}_][\p{L}\p{N}_.-]*)`)
+
+// ListNotificationsInput — параметры постраничной выдачи «входящих».
+type ListNotificationsInput struct {
+	UserID     uuid.UUID
+	UnreadOnly bool
+	PageSize   int32
+	PageToken  string
+}
+
+// MarkReadInput — отметка уведомлений прочитанными.
+// Правила: либо All=true (все уведомления получателя), либо непустой IDs.
+type MarkReadInput struct {
+	UserID uuid.UUID
+	IDs    []string
+	All    bool
+}
+
+// parseMentions извлекает уникальные @username (в нижнем регистре) в порядке появления.
+// Хвостовые '.'/'-' отбрасываются (конец предложения), учитывается не более limit имён.
+func parseMentions(content string, limit int) []string {
+	if limit <= 0 || !strings.Contains(content, "@") {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	var out []string
+	for _, m := range mentionRe.FindAllStringSubmatch(content, -1) {
+		name := strings.ToLower(strings.TrimRight(m[1], ".-"))
+		if name == "" || utf8.RuneCountInString(name) > maxMentionLength {
+			continue
+		}
+
+		if _, ok := seen[name]; ok {
+			continue
+		}
+
+		seen[name] = struct{}{}
+		out = append(out, name)
+		if len(out) == limit {
+			break
+		}
+	}
+
+	return out
+}
+
+// notifyAsync запускает notify в фоне: запись комментария не ждёт users-service
+// и хранилище уведомлений. Контекст отвязан от отмены запроса (значения — логгер,
+// metadata — сохраняются) и ограничен cfg.Notifications.Timeout. Остановка
+// сервиса дожидается фоновых уведомлений через Wait.
+func (s *Service) notifyAsync(ctx context.Context, comm models.Comment) {
+	ctx = context.WithoutCancel(ctx)
+	cancel := context.CancelFunc(func() {})
+	if d := s.cfg.Notifications.Timeout; d > 0 {
+		ctx, cancel = context.WithTimeout(ctx, d)
+	}
+
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		defer cancel()
+
+		s.notify(ctx, &comm)
+	}()
+}
+
+// notify формирует уведомления по только что созданному комментарию (best-effort).
+//
+// Получатели:
+//   - автор родительского комментария — "reply" (если это не сам автор ответа и родитель не удалён);
+//   - пользователи, упомянутые через @username и найденные в users-service, — "mention"
+//     (автору родителя второе уведомление не отправляется).
+//
+// Пользователи, заглушившие ветку, исключаются. Любые ошибки только логируются:
+// создание комментария из-за уведомлений не падает.
+func (s *Service) notify(ctx context.Context, comm *models.Comment) {
+	const op = "service/notifications/notify"
+
+	lg := log.From(ctx).With("op", op, "comment_id", comm.ID)
+
+	kinds := make(map[uuid.UUID]models.NotificationKind)
+	var recipients []uuid.UUID
+	add := func(id uuid.UUID, kind models.NotificationKind) {
+		if id == uuid.Nil || id == comm.UserID {
+			return
+		}
+
+		if _, ok := kinds[id]; ok {
+			return
+		}
+
+		kinds[id] = kind
+		recipients = append(recipients, id)
+	}
+
+	if comm.ParentID != "" {
+		parent, err := s.storage.CommentByID(ctx, comm.ParentID)
+		switch {
+		case err != nil:
+			lg.Warn("parent lookup failed", "err", err)
+		case !parent.IsDeleted:
+			add(parent.UserID, models.NotificationKindReply)
+		}
+	}
+
+	if s.users != nil {
+		if mentions := parseMentions(comm.Content, s.cfg.Notifications.MaxMentions); len(mentions) > 0 {
+			ids, err := s.users.ResolveUsernames(ctx, mentions)
+			if err != nil {
+				lg.Warn("resolve mentions failed", "err", err)
+			}
+
+			for _, name := range mentions {
+				if id, ok := ids[name]; ok {
+					add(id, models.NotificationKindMention)
+				}
+			}
+		}
+	}
+
+	if len(recipients) == 0 {
+		return
+	}
+
+	threadID := comm.ThreadID()
+	muted, err := s.storage.MutedUsers(ctx, threadID, recipients)
+	if err != nil {
+		// Не знаем, кто заглушил ветку — лучше не уведомлять никого, чем нарушить mute.
+		lg.Warn("muted users lookup failed", "err", err)
+		return
+	}
+
+	items := make([]models.Notification, 0, len(recipients))
+	for _, id := range recipients {
+		if _, ok := muted[id]; ok {
+			continue
+		}
+
+		items = append(items, models.Notification{
+			UserID:        id,
+			Kind:          kinds[id],
+			CommentID:     comm.ID,
+			NewsID:        comm.NewsID,
+			ThreadID:      threadID,
+			ActorID:       comm.UserID,
+			ActorUsername: comm.Username,
+		})
+	}
+
+	if len(items) == 0 {
+		return
+	}
+
+	saved, err := s.storage.CreateNotifications(ctx, items)
+	if err != nil {
+		lg.Warn("storage error on CreateNotifications", "err", err)
+		return
+	}
+
+	if s.notifications == nil {
+		return
+	}
+
+	for _, n := range saved {
+		s.notifications.Publish(n.UserID.String(), n)
+	}
+}
+
+// ListNotifications — страница «входящих» пользователя, сначала новые.
+//
+// Валидация:
+//   - UserID обязателен (uuid.Nil -> ErrInvalidArgument).
+//
+// Поведение/ошибки:
+//   - ErrInvalidCursor — если некорректный page_token;
+//   - ErrInternal — иные ошибки стораджа.
+func (s *Service) ListNotifications(ctx context.Context, in ListNotificationsInput) (*models.NotificationsPage, error) {
+	const op = "service/notifications/ListNotifications"
+
+	lg := log.From(ctx).With("op", op, "user_id", in.UserID.String())
+
+	if in.UserID == uuid.Nil {
+		lg.Warn("invalid argument: empty user_id")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	page, err := s.storage.ListNotifications(ctx, in.UserID, in.UnreadOnly, models.ListParams{
+		PageSize:  in.PageSize,
+		PageToken: in.PageToken,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvalidCursor):
+			lg.Warn("invalid cursor")
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidCursor)
+		default:
+			lg.Error("storage error on ListNotifications", "err", err)
+			return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+		}
+	}
+
+	return page, nil
+}
+
+// MarkRead — отметить уведомления прочитанными; возвращает число изменённых записей.
+//
+// Валидация:
+//   - UserID обязателен;
+//   - нужен либо All=true, либо непустой список IDs (иначе ErrInvalidArgument).
+//
+// Поведение/ошибки:
+//   - чужие/несуществующие IDs молча игнорируются;
+//   - ErrInternal — ошибки стораджа.
+func (s *Service) MarkRead(ctx context.Context, in MarkReadInput) (int64, error) {
+	const op = "service/notifications/MarkRead"
+
+	lg := log.From(ctx).With("op", op, "user_id", in.UserID.String(), "all", in.All)
+
+	if in.UserID == uuid.Nil {
+		lg.Warn("invalid argument: empty user_id")
+		return 0, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	var ids []string
+	if !in.All {
+		for _, id := range in.IDs {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+
+		if len(ids) == 0 {
+			lg.Warn("invalid argument: neither ids nor all")
+			return 0, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+		}
+	}
+
+	n, err := s.storage.MarkRead(ctx, in.UserID, ids)
+	if err != nil {
+		lg.Error("storage error on MarkRead", "err", err)
+		return 0, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	return n, nil
+}
+
+// UnreadCount — число непрочитанных уведомлений пользователя.
+//
+// Валидация:
+//   - userID обязателен (uuid.Nil -> ErrInvalidArgument).
+//
+// Поведение/ошибки:
+//   - ErrInternal — ошибки стораджа.
+func (s *Service) UnreadCount(ctx context.Context, userID uuid.UUID) (int64, error) {
+	const op = "service/notifications/UnreadCount"
+
+	lg := log.From(ctx).With("op", op, "user_id", userID.String())
+
+	if userID == uuid.Nil {
+		lg.Warn("invalid argument: empty user_id")
+		return 0, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	n, err := s.storage.UnreadCount(ctx, userID)
+	if err != nil {
+		lg.Error("storage error on UnreadCount", "err", err)
+		return 0, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	return n, nil
+}
+
+// MuteThread — отключить уведомления пользователя по ветке.
+// commentID может указывать на любой комментарий ветки — заглушается её корень.
+//
+// Валидация:
+//   - userID обязателен, commentID не пуст.
+//
+// Поведение/ошибки:
+//   - ErrNotFound — если комментарий не найден;
+//   - ErrInternal — иные ошибки стораджа.
+//
+// Возвращает идентификатор заглушённой ветки.
+func (s *Service) MuteThread(ctx context.Context, userID uuid.UUID, commentID string) (string, error) {
+	const op = "service/notifications/MuteThread"
+
+	return s.setThreadMuted(ctx, op, userID, commentID, true)
+}
+
+// UnmuteThread — вернуть уведомления пользователя по ветке (см. MuteThread).
+func (s *Service) UnmuteThread(ctx context.Context, userID uuid.UUID, commentID string) (string, error) {
+	const op = "service/notifications/UnmuteThread"
+
+	return s.setThreadMuted(ctx, op, userID, commentID, false)
+}
+
+// setThreadMuted — общая часть MuteThread/UnmuteThread.
+func (s *Service) setThreadMuted(ctx context.Context, op string, userID uuid.UUID, commentID string, muted bool) (string, error) {
+	commentID = strings.TrimSpace(commentID)
+	lg := log.From(ctx).With("op", op, "user_id", userID.String(), "comment_id", commentID)
+
+	if userID == uuid.Nil || commentID == "" {
+		lg.Warn("invalid argument: empty user_id or comment_id")
+		return "", fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	comm, err := s.storage.CommentByID(ctx, commentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			lg.Warn("comment not found")
+			return "", fmt.Errorf("%s: %w", op, ErrNotFound)
+		default:
+			lg.Error("storage error on CommentByID", "err", err)
+			return "", fmt.Errorf("%s: %w", op, ErrInternal)
+		}
+	}
+
+	threadID := comm.ThreadID()
+	if muted {
+		err = s.storage.MuteThread(ctx, userID, threadID)
+	} else {
+		err = s.storage.UnmuteThread(ctx, userID, threadID)
+	}
+
+	if err != nil {
+		lg.Error("storage error on thread mute", "err", err, "muted", muted)
+		return "", fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	return threadID, nil
+}
+
+// SubscribeNotifications — живая подписка на новые уведомления пользователя.
+// Возвращает канал событий и функцию отписки (обязательна к вызову).
+// Медленный подписчик теряет события сверх буфера cfg.Notifications.StreamBuffer —
+// пропущенное всегда доступно через ListNotifications.
+func (s *Service) SubscribeNotifications(ctx context.Context, userID uuid.UUID) (<-chan models.Notification, func(), error) {
+	const op = "service/notifications/SubscribeNotifications"
+
+	lg := log.From(ctx).With("op", op, "user_id", userID.String())
+
+	if userID == uuid.Nil {
+		lg.Warn("invalid argument: empty user_id")
+		return nil, nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	if s.notifications == nil {
+		lg.Error("notifications broker is not initialized")
+		return nil, nil, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	ch, cancel := s.notifications.Subscribe(userID.String(), s.cfg.Notifications.StreamBuffer)
+
+	return ch, cancel, nil
+}
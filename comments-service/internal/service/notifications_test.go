@@ -0,0 +1,334 @@
+package service
+
+// Тесты уведомлений comments-service (internal/service/notifications.go).
+//
+//  Проверяем:
+//  - разбор @упоминаний (границы слова, e-mail, дедупликация, лимит);
+//  - генерацию уведомлений при CreateComment: reply автору родителя, mention через резолвер,
+//    исключение самоуведомлений, заглушённых веток и дублей; best-effort при ошибках;
+//    фоновое исполнение под своим дедлайном (медленный резолвер не тормозит запись);
+//  - доставку в живую подписку;
+//  - валидацию и маппинг ошибок ListNotifications/MarkRead/UnreadCount/MuteThread.
+//
+// Запуск:
+//   go test ./internal/service -v -race -count=1
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver — детерминированный UserResolver для тестов.
+type fakeResolver struct {
+	ids   map[string]uuid.UUID
+	err   error
+	calls [][]string
+}
+
+func (f *fakeResolver) ResolveUsernames(_ context.Context, usernames []string) (map[string]uuid.UUID, error) {
+	f.calls = append(f.calls, usernames)
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	out := make(map[string]uuid.UUID)
+	for _, u := range usernames {
+		if id, ok := f.ids[u]; ok {
+			out[u] = id
+		}
+	}
+
+	return out, nil
+}
+
+// newNotifyingService — сервис с включёнными уведомлениями поверх моков.
+func newNotifyingService(t *testing.T, r UserResolver) (*Service, *mocks.MockStorage, *gomock.Controller) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	ms := mocks.NewMockStorage(ctrl)
+
+	cfg := config.Config{}
+	cfg.Notifications.Enabled = true
+	cfg.Notifications.MaxMentions = 5
+	cfg.Notifications.StreamBuffer = 4
+
+	s := New(ms, cfg)
+	if r != nil {
+		s.SetUserResolver(r)
+	}
+
+	return s, ms, ctrl
+}
+
+func TestParseMentions(t *testing.T) {
+	got := parseMentions("hi @Alice, @bob. mail me a@b.c and @alice again; (@carol-)", 10)
+	require.Equal(t, []string{"alice", "bob", "carol"}, got)
+
+	require.Equal(t, []string{"a", "b"}, parseMentions("@a @b @c", 2))
+	require.Nil(t, parseMentions("no mentions here", 10))
+	require.Nil(t, parseMentions("@a", 0))
+}
+
+// Ответ: автор родителя получает reply, упомянутый — mention, автор ответа себя не уведомляет.
+func TestService_CreateComment_NotifiesReplyAndMentions(t *testing.T) {
+	parentAuthor, mentioned, actor := uuid.New(), uuid.New(), uuid.New()
+	r := &fakeResolver{ids: map[string]uuid.UUID{"dave": mentioned, "me": actor, "pat": parentAuthor}}
+	s, ms, ctrl := newNotifyingService(t, r)
+	defer ctrl.Finish()
+
+	newsID := uuid.New()
+	parent := mustComment(newsID, "", "pat", "root")
+	parent.UserID = parentAuthor
+
+	created := mustComment(newsID, parent.ID, "me", "@dave @me @pat look")
+	created.UserID = actor
+	created.RootID = "root-1"
+
+	ms.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(created, nil)
+	ms.EXPECT().CommentByID(gomock.Any(), parent.ID).Return(parent, nil)
+	ms.EXPECT().MutedUsers(gomock.Any(), "root-1", []uuid.UUID{parentAuthor, mentioned}).
+		Return(map[uuid.UUID]struct{}{}, nil)
+	ms.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, items []models.Notification) ([]models.Notification, error) {
+			require.Len(t, items, 2)
+			require.Equal(t, parentAuthor, items[0].UserID)
+			require.Equal(t, models.NotificationKindReply, items[0].Kind)
+			require.Equal(t, mentioned, items[1].UserID)
+			require.Equal(t, models.NotificationKindMention, items[1].Kind)
+			for _, n := range items {
+				require.Equal(t, created.ID, n.CommentID)
+				require.Equal(t, "root-1", n.ThreadID)
+				require.Equal(t, actor, n.ActorID)
+				require.Equal(t, "me", n.ActorUsername)
+			}
+			return items, nil
+		})
+
+	_, err := s.CreateComment(context.Background(), CreateCommentInput{
+		ParentID: parent.ID, UserID: actor, Username: "me", Content: "@dave @me @pat look",
+	})
+	require.NoError(t, err)
+	s.Wait()
+	require.Len(t, r.calls, 1)
+}
+
+// Заглушённая ветка: уведомление не создаётся.
+func TestService_CreateComment_MutedThreadSkipped(t *testing.T) {
+	s, ms, ctrl := newNotifyingService(t, nil)
+	defer ctrl.Finish()
+
+	parentAuthor := uuid.New()
+	parent := mustComment(uuid.New(), "", "pat", "root")
+	parent.UserID = parentAuthor
+	created := mustComment(parent.NewsID, parent.ID, "me", "reply")
+	created.RootID = parent.ID
+
+	ms.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(created, nil)
+	ms.EXPECT().CommentByID(gomock.Any(), parent.ID).Return(parent, nil)
+	ms.EXPECT().MutedUsers(gomock.Any(), parent.ID, []uuid.UUID{parentAuthor}).
+		Return(map[uuid.UUID]struct{}{parentAuthor: {}}, nil)
+
+	_, err := s.CreateComment(context.Background(), CreateCommentInput{
+		ParentID: parent.ID, UserID: created.UserID, Username: "me", Content: "reply",
+	})
+	require.NoError(t, err)
+	s.Wait()
+}
+
+// Ошибки уведомлений не ломают создание комментария.
+func TestService_CreateComment_NotifyErrorsAreBestEffort(t *testing.T) {
+	r := &fakeResolver{err: errors.New("users down")}
+	s, ms, ctrl := newNotifyingService(t, r)
+	defer ctrl.Finish()
+
+	created := mustComment(uuid.New(), "", "me", "hello @dave")
+	ms.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(created, nil)
+
+	got, err := s.CreateComment(context.Background(), CreateCommentInput{
+		NewsID: created.NewsID, UserID: created.UserID, Username: "me", Content: "hello @dave",
+	})
+	require.NoError(t, err)
+	require.Equal(t, created, got)
+	s.Wait()
+}
+
+// blockingResolver — резолвер, отвечающий только по отмене контекста (медленный users-service).
+type blockingResolver struct {
+	done chan error
+}
+
+func (b *blockingResolver) ResolveUsernames(ctx context.Context, _ []string) (map[string]uuid.UUID, error) {
+	<-ctx.Done()
+	b.done <- ctx.Err()
+	return nil, ctx.Err()
+}
+
+// Медленный users-service не задерживает запись: уведомления идут в фоне
+// под собственным дедлайном, отмена запроса их не прерывает.
+func TestService_CreateComment_NotifyDoesNotBlockWrite(t *testing.T) {
+	r := &blockingResolver{done: make(chan error, 1)}
+	s, ms, ctrl := newNotifyingService(t, r)
+	defer ctrl.Finish()
+	s.cfg.Notifications.Timeout = 50 * time.Millisecond
+
+	created := mustComment(uuid.New(), "", "me", "hello @dave")
+	ms.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(created, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	start := time.Now()
+	got, err := s.CreateComment(ctx, CreateCommentInput{
+		NewsID: created.NewsID, UserID: created.UserID, Username: "me", Content: "hello @dave",
+	})
+	cancel()
+	require.NoError(t, err)
+	require.Equal(t, created, got)
+	require.Less(t, time.Since(start), 40*time.Millisecond)
+
+	s.Wait()
+	require.ErrorIs(t, <-r.done, context.DeadlineExceeded)
+}
+
+// При выключенных уведомлениях не вызываются ни резолвер, ни хранилище уведомлений.
+func TestService_CreateComment_NotificationsDisabled(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+	s.SetUserResolver(&fakeResolver{})
+
+	created := mustComment(uuid.New(), "parent", "me", "@dave")
+	ms.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(created, nil)
+
+	_, err := s.CreateComment(context.Background(), CreateCommentInput{
+		ParentID: "parent", UserID: created.UserID, Username: "me", Content: "@dave",
+	})
+	require.NoError(t, err)
+	s.Wait()
+}
+
+// Сохранённые уведомления доставляются в живую подписку получателя.
+func TestService_SubscribeNotifications_ReceivesCreated(t *testing.T) {
+	mentioned := uuid.New()
+	s, ms, ctrl := newNotifyingService(t, &fakeResolver{ids: map[string]uuid.UUID{"dave": mentioned}})
+	defer ctrl.Finish()
+
+	events, cancel, err := s.SubscribeNotifications(context.Background(), mentioned)
+	require.NoError(t, err)
+	defer cancel()
+
+	created := mustComment(uuid.New(), "", "me", "hi @dave")
+	ms.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(created, nil)
+	ms.EXPECT().MutedUsers(gomock.Any(), created.ID, []uuid.UUID{mentioned}).Return(nil, nil)
+	ms.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, items []models.Notification) ([]models.Notification, error) {
+			items[0].ID = "n1"
+			return items, nil
+		})
+
+	_, err = s.CreateComment(context.Background(), CreateCommentInput{
+		NewsID: created.NewsID, UserID: created.UserID, Username: "me", Content: "hi @dave",
+	})
+	require.NoError(t, err)
+
+	select {
+	case n := <-events:
+		require.Equal(t, "n1", n.ID)
+		require.Equal(t, mentioned, n.UserID)
+	case <-time.After(time.Second):
+		t.Fatal("notification was not delivered")
+	}
+
+	_, _, err = s.SubscribeNotifications(context.Background(), uuid.Nil)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestService_ListNotifications(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := s.ListNotifications(context.Background(), ListNotificationsInput{})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	uid := uuid.New()
+	ms.EXPECT().ListNotifications(gomock.Any(), uid, true, models.ListParams{PageSize: 5, PageToken: "bad"}).
+		Return(nil, storage.ErrInvalidCursor)
+	_, err = s.ListNotifications(context.Background(), ListNotificationsInput{UserID: uid, UnreadOnly: true, PageSize: 5, PageToken: "bad"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	want := &models.NotificationsPage{Items: []models.Notification{{ID: "n1", UserID: uid}}, NextPageToken: "next"}
+	ms.EXPECT().ListNotifications(gomock.Any(), uid, false, models.ListParams{}).Return(want, nil)
+	got, err := s.ListNotifications(context.Background(), ListNotificationsInput{UserID: uid})
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestService_MarkRead(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+
+	_, err := s.MarkRead(context.Background(), MarkReadInput{UserID: uid, IDs: []string{" "}})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	ms.EXPECT().MarkRead(gomock.Any(), uid, []string{"a", "b"}).Return(int64(2), nil)
+	n, err := s.MarkRead(context.Background(), MarkReadInput{UserID: uid, IDs: []string{" a ", "b"}})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, n)
+
+	// all=true -> ids игнорируются, в storage уходит пустой список.
+	ms.EXPECT().MarkRead(gomock.Any(), uid, nil).Return(int64(0), errors.New("db down"))
+	_, err = s.MarkRead(context.Background(), MarkReadInput{UserID: uid, IDs: []string{"a"}, All: true})
+	require.ErrorIs(t, err, ErrInternal)
+}
+
+func TestService_UnreadCount(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := s.UnreadCount(context.Background(), uuid.Nil)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	uid := uuid.New()
+	ms.EXPECT().UnreadCount(gomock.Any(), uid).Return(int64(3), nil)
+	n, err := s.UnreadCount(context.Background(), uid)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, n)
+}
+
+// MuteThread заглушает корень ветки, даже если передан id ответа.
+func TestService_MuteThread(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+
+	_, err := s.MuteThread(context.Background(), uid, " ")
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	ms.EXPECT().CommentByID(gomock.Any(), "missing").Return(nil, storage.ErrNotFound)
+	_, err = s.MuteThread(context.Background(), uid, "missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	reply := mustComment(uuid.New(), "p1", "x", "y")
+	reply.RootID = "root-1"
+	ms.EXPECT().CommentByID(gomock.Any(), reply.ID).Return(reply, nil)
+	ms.EXPECT().MuteThread(gomock.Any(), uid, "root-1").Return(nil)
+	threadID, err := s.MuteThread(context.Background(), uid, reply.ID)
+	require.NoError(t, err)
+	require.Equal(t, "root-1", threadID)
+
+	root := mustComment(uuid.New(), "", "x", "y")
+	ms.EXPECT().CommentByID(gomock.Any(), root.ID).Return(root, nil)
+	ms.EXPECT().UnmuteThread(gomock.Any(), uid, root.ID).Return(errors.New("db down"))
+	_, err = s.UnmuteThread(context.Background(), uid, root.ID)
+	require.ErrorIs(t, err, ErrInternal)
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+)
+
+// ReactInput — постановка/снятие реакции пользователя на комментарий.
+type ReactInput struct {
+	CommentID string
+	UserID    uuid.UUID
+	Reaction  string
+	Remove    bool
+}
+
+// React — поставить (или при Remove снять) реакцию на комментарий.
+//
+// Валидация:
+//   - CommentID не пуст, UserID обязателен (uuid.Nil -> ErrInvalidArgument);
+//   - Reaction нормализуется (TrimSpace, нижний регистр) и входит в models.Reactions.
+//
+// Поведение/ошибки:
+//   - повторная постановка/снятие — no-op, возвращаются текущие счётчики;
+//   - фактическое изменение рассылается подписчикам WatchComments новости;
+//   - ErrNotFound — если комментарий не найден или удалён;
+//   - ErrThreadExpired — постановка реакции в закрытой для записи ветке;
+//   - ErrInternal — иные ошибки стораджа.
+//
+// Возвращает комментарий с идентификаторами и счётчиками реакций (ID, NewsID, ParentID, RootID, Reactions).
+func (s *Service) React(ctx context.Context, in ReactInput) (*models.Comment, error) {
+	const op = "service/reactions/React"
+
+	in.CommentID = strings.TrimSpace(in.CommentID)
+	in.Reaction = strings.ToLower(strings.TrimSpace(in.Reaction))
+	lg := log.From(ctx).With(
+		"op", op,
+		"comment_id", in.CommentID,
+		"user_id", in.UserID.String(),
+		"reaction", in.Reaction,
+		"remove", in.Remove,
+	)
+
+	if in.CommentID == "" || in.UserID == uuid.Nil {
+		lg.Warn("invalid argument: empty comment_id or user_id")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	if !models.IsReaction(in.Reaction) {
+		lg.Warn("invalid argument: unknown reaction")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	comm, changed, err := s.storage.SetReaction(ctx, in.CommentID, in.UserID, in.Reaction, !in.Remove)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			lg.Warn("comment not found")
+			return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
+		case errors.Is(err, storage.ErrThreadExpired):
+			lg.Warn("thread expired")
+			return nil, fmt.Errorf("%s: %w", op, ErrThreadExpired)
+		default:
+			lg.Error("storage error on SetReaction", "err", err)
+			return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+		}
+	}
+
+	if changed {
+		s.publishComment(models.CommentEventReaction, *comm)
+	}
+
+	return comm, nil
+}
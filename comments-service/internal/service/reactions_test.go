@@ -0,0 +1,88 @@
+package service
+
+// Unit-тесты реакций (reactions.go):
+//  - валидация comment_id/user_id и реакции (нормализация регистра);
+//  - маппинг ErrNotFound/ErrThreadExpired/ErrInternal;
+//  - фактическое изменение публикуется подписчикам новости, no-op — нет.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_React_Validation(t *testing.T) {
+	s, _ := newWatchService(t)
+
+	ctx := context.Background()
+	for _, in := range []ReactInput{
+		{UserID: uuid.New(), Reaction: "like"},
+		{CommentID: "c1", Reaction: "like"},
+		{CommentID: "c1", UserID: uuid.New(), Reaction: "meh"},
+		{CommentID: "c1", UserID: uuid.New()},
+	} {
+		_, err := s.React(ctx, in)
+		require.ErrorIs(t, err, ErrInvalidArgument, "%+v", in)
+	}
+}
+
+func TestService_React_Errors(t *testing.T) {
+	s, ms := newWatchService(t)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	in := ReactInput{CommentID: "c1", UserID: userID, Reaction: "like"}
+
+	for storageErr, want := range map[error]error{
+		storage.ErrNotFound:      ErrNotFound,
+		storage.ErrThreadExpired: ErrThreadExpired,
+		errors.New("db down"):    ErrInternal,
+	} {
+		ms.EXPECT().SetReaction(gomock.Any(), "c1", userID, "like", true).Return(nil, false, storageErr)
+		_, err := s.React(ctx, in)
+		require.ErrorIs(t, err, want)
+	}
+}
+
+func TestService_React_PublishesChanges(t *testing.T) {
+	s, ms := newWatchService(t)
+
+	ctx := context.Background()
+	newsID, userID := uuid.New(), uuid.New()
+	w, err := s.WatchComments(ctx, newsID, "")
+	require.NoError(t, err)
+	defer w.Live.Close()
+
+	comm := &models.Comment{ID: "c1", NewsID: newsID, Reactions: map[string]int64{"love": 1}}
+	ms.EXPECT().SetReaction(gomock.Any(), "c1", userID, "love", true).Return(comm, true, nil)
+
+	got, err := s.React(ctx, ReactInput{CommentID: " c1 ", UserID: userID, Reaction: " Love "})
+	require.NoError(t, err)
+	require.Equal(t, comm, got)
+
+	select {
+	case ev := <-w.Live.C:
+		require.Equal(t, models.CommentEventReaction, ev.Kind)
+		require.Equal(t, map[string]int64{"love": 1}, ev.Comment.Reactions)
+	case <-time.After(time.Second):
+		t.Fatal("no live event")
+	}
+
+	// Снятие реакции, которой не было, — без события.
+	ms.EXPECT().SetReaction(gomock.Any(), "c1", userID, "love", false).Return(&models.Comment{ID: "c1", NewsID: newsID}, false, nil)
+	_, err = s.React(ctx, ReactInput{CommentID: "c1", UserID: userID, Reaction: "love", Remove: true})
+	require.NoError(t, err)
+
+	select {
+	case ev := <-w.Live.C:
+		t.Fatalf("unexpected event for no-op: %+v", ev)
+	default:
+	}
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+)
+
+// maxSearchQueryLen — максимальная длина поискового запроса (в символах).
+const maxSearchQueryLen = 256
+
+// ListByUserInput — параметры постраничной выдачи комментариев автора.
+// IncludeDeleted — режим модератора: в выдачу попадают «надгробия» мягко удалённых
+// комментариев (is_deleted=true, текст стёрт). Роль moderator/admin проверяет транспорт (pkg/identity).
+type ListByUserInput struct {
+	UserID         uuid.UUID
+	IncludeDeleted bool
+	PageSize       int32
+	PageToken      string
+}
+
+// maxListByUsers — максимум авторов в одном запросе ListByUsers
+// (лента подписок: api-gateway передаёт не больше стольких подписок).
+const maxListByUsers = 500
+
+// ListByUsersInput — параметры общей ленты комментариев нескольких авторов.
+type ListByUsersInput struct {
+	UserIDs   []uuid.UUID
+	PageSize  int32
+	PageToken string
+}
+
+// SearchCommentsInput — параметры полнотекстового поиска.
+// NewsID/UserID — необязательные фильтры (uuid.Nil — без фильтра).
+// IncludeDeleted — режим модератора (см. ListByUserInput).
+type SearchCommentsInput struct {
+	Query          string
+	NewsID         uuid.UUID
+	UserID         uuid.UUID
+	IncludeDeleted bool
+	PageSize       int32
+	PageToken      string
+}
+
+// ListByUser — страница комментариев автора (корни и ответы), сначала новые.
+// Используется страницей «мои комментарии» и модераторами.
+//
+// Валидация:
+//   - UserID обязателен (uuid.Nil -> ErrInvalidArgument).
+//
+// Поведение/ошибки:
+//   - без IncludeDeleted мягко удалённые комментарии исключаются;
+//   - ErrInvalidCursor — если некорректный page_token;
+//   - ErrInternal — иные ошибки стораджа.
+func (s *Service) ListByUser(ctx context.Context, in ListByUserInput) (*models.Page, error) {
+	const op = "service/search/ListByUser"
+
+	lg := log.From(ctx).With("op", op, "user_id", in.UserID.String(), "include_deleted", in.IncludeDeleted)
+
+	if in.UserID == uuid.Nil {
+		lg.Warn("invalid argument: empty user_id")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	page, err := s.storage.ListByUser(ctx, in.UserID, in.IncludeDeleted, models.ListParams{
+		PageSize:  in.PageSize,
+		PageToken: in.PageToken,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvalidCursor):
+			lg.Warn("invalid cursor")
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidCursor)
+		default:
+			lg.Error("storage error on ListByUser", "err", err)
+			return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+		}
+	}
+
+	prepareHistory(page)
+
+	return page, nil
+}
+
+// ListByUsers — общая лента комментариев нескольких авторов (корни и ответы), сначала новые.
+// Используется лентой активности подписок.
+//
+// Валидация:
+//   - UserIDs непуст, без uuid.Nil, после удаления дубликатов не длиннее maxListByUsers
+//     (-> ErrInvalidArgument).
+//
+// Поведение/ошибки:
+//   - мягко удалённые комментарии исключаются;
+//   - ErrInvalidCursor — если некорректный page_token;
+//   - ErrInternal — иные ошибки стораджа.
+func (s *Service) ListByUsers(ctx context.Context, in ListByUsersInput) (*models.Page, error) {
+	const op = "service/search/ListByUsers"
+
+	lg := log.From(ctx).With("op", op, "users", len(in.UserIDs))
+
+	seen := make(map[uuid.UUID]struct{}, len(in.UserIDs))
+	ids := make([]uuid.UUID, 0, len(in.UserIDs))
+	for _, id := range in.UserIDs {
+		if id == uuid.Nil {
+			lg.Warn("invalid argument: nil user_id")
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+		}
+
+		if _, dup := seen[id]; dup {
+			continue
+		}
+
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 || len(ids) > maxListByUsers {
+		lg.Warn("invalid argument: user_ids count out of range")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	page, err := s.storage.ListByUsers(ctx, ids, models.ListParams{
+		PageSize:  in.PageSize,
+		PageToken: in.PageToken,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvalidCursor):
+			lg.Warn("invalid cursor")
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidCursor)
+		default:
+			lg.Error("storage error on ListByUsers", "err", err)
+			return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+		}
+	}
+
+	prepareHistory(page)
+
+	return page, nil
+}
+
+// SearchComments — полнотекстовый поиск по комментариям, сначала новые.
+//
+// Валидация:
+//   - Query нормализуется (TrimSpace), не пуст и не длиннее maxSearchQueryLen символов.
+//
+// Поведение/ошибки:
+//   - без IncludeDeleted мягко удалённые комментарии исключаются;
+//   - ErrInvalidCursor — если некорректный page_token;
+//   - ErrInternal — иные ошибки стораджа.
+func (s *Service) SearchComments(ctx context.Context, in SearchCommentsInput) (*models.Page, error) {
+	const op = "service/search/SearchComments"
+
+	in.Query = strings.TrimSpace(in.Query)
+	lg := log.From(ctx).With("op", op, "include_deleted", in.IncludeDeleted)
+
+	if in.Query == "" || utf8.RuneCountInString(in.Query) > maxSearchQueryLen {
+		lg.Warn("invalid argument: query length out of range")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	page, err := s.storage.SearchComments(ctx, models.SearchQuery{
+		Text:           in.Query,
+		NewsID:         in.NewsID,
+		UserID:         in.UserID,
+		IncludeDeleted: in.IncludeDeleted,
+	}, models.ListParams{
+		PageSize:  in.PageSize,
+		PageToken: in.PageToken,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvalidCursor):
+			lg.Warn("invalid cursor")
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidCursor)
+		default:
+			lg.Error("storage error on SearchComments", "err", err)
+			return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+		}
+	}
+
+	prepareHistory(page)
+
+	return page, nil
+}
+
+// prepareHistory подготавливает элементы выдачи к отдаче наружу.
+// У удалённых комментариев текста нет — они отдаются как есть (is_deleted=true).
+func prepareHistory(page *models.Page) {
+	for i := range page.Items {
+		withRenderedContent(&page.Items[i])
+	}
+}
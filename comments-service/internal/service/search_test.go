@@ -0,0 +1,136 @@
+package service
+
+// Тесты истории комментариев автора и полнотекстового поиска (internal/service/search.go).
+//
+//  Проверяем:
+//  - ListByUser: валидацию user_id, маппинг ErrInvalidCursor/ErrInternal;
+//  - SearchComments: нормализацию и ограничения запроса, проброс фильтров;
+//  - режим модератора: удалённые комментарии отдаются «надгробиями» без текста.
+//
+// Запуск:
+//   go test ./internal/service -v -race -count=1
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ListByUser(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	_, err := s.ListByUser(ctx, ListByUserInput{})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	ms.EXPECT().ListByUser(gomock.Any(), userID, false, models.ListParams{PageToken: "bad"}).Return(nil, storage.ErrInvalidCursor)
+	_, err = s.ListByUser(ctx, ListByUserInput{UserID: userID, PageToken: "bad"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	ms.EXPECT().ListByUser(gomock.Any(), userID, false, gomock.Any()).Return(nil, errors.New("db down"))
+	_, err = s.ListByUser(ctx, ListByUserInput{UserID: userID})
+	require.ErrorIs(t, err, ErrInternal)
+
+	c := mustComment(uuid.New(), "", "alice", "hello")
+	ms.EXPECT().ListByUser(gomock.Any(), userID, false, models.ListParams{PageSize: 10}).
+		Return(&models.Page{Items: []models.Comment{*c}, NextPageToken: "next"}, nil)
+	page, err := s.ListByUser(ctx, ListByUserInput{UserID: userID, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, "next", page.NextPageToken)
+	require.Len(t, page.Items, 1)
+	require.Equal(t, "<p>hello</p>", page.Items[0].ContentHTML)
+}
+
+func TestService_ListByUser_ModeratorView(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	deleted := mustComment(uuid.New(), "", "alice", "")
+	deleted.IsDeleted = true
+	live := mustComment(uuid.New(), "", "alice", "hello")
+
+	ms.EXPECT().ListByUser(gomock.Any(), userID, true, gomock.Any()).
+		Return(&models.Page{Items: []models.Comment{*deleted, *live}}, nil)
+	page, err := s.ListByUser(ctx, ListByUserInput{UserID: userID, IncludeDeleted: true})
+	require.NoError(t, err)
+	require.True(t, page.Items[0].IsDeleted)
+	require.Empty(t, page.Items[0].Content)
+	require.Empty(t, page.Items[0].ContentHTML)
+	require.Equal(t, "<p>hello</p>", page.Items[1].ContentHTML)
+}
+
+func TestService_ListByUsers(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	a, b := uuid.New(), uuid.New()
+
+	tooMany := make([]uuid.UUID, maxListByUsers+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New()
+	}
+
+	for _, ids := range [][]uuid.UUID{nil, {a, uuid.Nil}, tooMany} {
+		_, err := s.ListByUsers(ctx, ListByUsersInput{UserIDs: ids})
+		require.ErrorIs(t, err, ErrInvalidArgument)
+	}
+
+	ms.EXPECT().ListByUsers(gomock.Any(), gomock.Any(), models.ListParams{PageToken: "bad"}).Return(nil, storage.ErrInvalidCursor)
+	_, err := s.ListByUsers(ctx, ListByUsersInput{UserIDs: []uuid.UUID{a}, PageToken: "bad"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	// Дубликаты схлопываются, порядок сохраняется.
+	c := mustComment(uuid.New(), "", "alice", "hello")
+	ms.EXPECT().ListByUsers(gomock.Any(), []uuid.UUID{a, b}, models.ListParams{PageSize: 5}).
+		Return(&models.Page{Items: []models.Comment{*c}, NextPageToken: "next"}, nil)
+	page, err := s.ListByUsers(ctx, ListByUsersInput{UserIDs: []uuid.UUID{a, b, a}, PageSize: 5})
+	require.NoError(t, err)
+	require.Equal(t, "next", page.NextPageToken)
+	require.Equal(t, "<p>hello</p>", page.Items[0].ContentHTML)
+
+	ms.EXPECT().ListByUsers(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+	_, err = s.ListByUsers(ctx, ListByUsersInput{UserIDs: []uuid.UUID{a}})
+	require.ErrorIs(t, err, ErrInternal)
+}
+
+func TestService_SearchComments(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	newsID := uuid.New()
+
+	_, err := s.SearchComments(ctx, SearchCommentsInput{Query: "   "})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = s.SearchComments(ctx, SearchCommentsInput{Query: strings.Repeat("я", maxSearchQueryLen+1)})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	ms.EXPECT().SearchComments(gomock.Any(), models.SearchQuery{Text: "go", NewsID: newsID}, models.ListParams{PageSize: 5}).
+		Return(&models.Page{Items: []models.Comment{*mustComment(newsID, "", "bob", "go go")}}, nil)
+	page, err := s.SearchComments(ctx, SearchCommentsInput{Query: "  go ", NewsID: newsID, PageSize: 5})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+
+	ms.EXPECT().SearchComments(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, storage.ErrInvalidCursor)
+	_, err = s.SearchComments(ctx, SearchCommentsInput{Query: "go", PageToken: "bad"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	ms.EXPECT().SearchComments(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+	_, err = s.SearchComments(ctx, SearchCommentsInput{Query: "go"})
+	require.ErrorIs(t, err, ErrInternal)
+}
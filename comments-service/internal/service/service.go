@@ -2,10 +2,15 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"sync"
 
+	"github.com/google/uuid"
 	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
 	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/pkg/pubsub"
 )
 
 var (
@@ -17,7 +22,8 @@ var (
 	ErrConflict = errors.New("conflict")
 	// ErrParentNotFound — родитель не найден.
 	ErrParentNotFound = errors.New("parent not found")
-	// ErrThreadExpired — ветка (корень) истекла по TTL, создание/изменение запрещено.
+	// ErrThreadExpired — ветка закрыта для записи (истёк срок, блокировка модератором
+	// или политикой новости); чтение доступно.
 	ErrThreadExpired = errors.New("thread expired")
 	// ErrMaxDepthExceeded — превышена максимально допустимая глубина.
 	ErrMaxDepthExceeded = errors.New("max depth exceeded")
@@ -27,16 +33,42 @@ var (
 	ErrInternal = errors.New("internal")
 )
 
+// UserResolver — разрешение @username в идентификаторы пользователей (users-service).
+// Ключи результата — username в нижнем регистре; ненайденные username отсутствуют.
+type UserResolver interface {
+	ResolveUsernames(ctx context.Context, usernames []string) (map[string]uuid.UUID, error)
+}
+
 // Service — описывает бизнес-логику news-service.
 type Service struct {
 	storage storage.Storage
 	cfg     config.Config
+
+	users         UserResolver
+	notifications *pubsub.Broker[models.Notification]
+	comments      *pubsub.Broker[models.CommentEvent]
+
+	background sync.WaitGroup // фоновые задачи после ответа (уведомления)
 }
 
 // New создает новый экземпляр Service.
 func New(storage storage.Storage, cfg config.Config) *Service {
 	return &Service{
-		storage: storage,
-		cfg:     cfg,
+		storage:       storage,
+		cfg:           cfg,
+		notifications: pubsub.New[models.Notification](),
+		comments:      pubsub.New[models.CommentEvent](),
 	}
 }
+
+// Wait дожидается фоновых задач, запущенных обработанными запросами
+// (уведомления). Вызывается при остановке после завершения gRPC-сервера.
+func (s *Service) Wait() {
+	s.background.Wait()
+}
+
+// SetUserResolver устанавливает резолвер @username (опционально).
+// Без резолвера уведомления об упоминаниях не создаются.
+func (s *Service) SetUserResolver(r UserResolver) {
+	s.users = r
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+)
+
+// maxThreadTTLDays — верхняя граница срока жизни веток в политике ThreadPolicyExpire (~10 лет).
+const maxThreadTTLDays = 3650
+
+// SetThreadPolicyInput — установка политики веток новости.
+// TTLDays обязателен только для ThreadPolicyExpire.
+type SetThreadPolicyInput struct {
+	NewsID  uuid.UUID
+	Mode    models.ThreadPolicyMode
+	TTLDays int32
+}
+
+// LockThread — блокировка/разблокировка ветки модератором.
+// commentID может указывать на любой комментарий ветки — блокируется вся ветка.
+// Заблокированная ветка доступна для чтения, запись возвращает ErrThreadExpired.
+//
+// Валидация:
+//   - commentID не пуст.
+//
+// Поведение/ошибки:
+//   - ErrNotFound — если комментарий не найден;
+//   - ErrInternal — иные ошибки стораджа.
+//
+// Возвращает корень ветки после изменения.
+func (s *Service) LockThread(ctx context.Context, commentID string, locked bool) (*models.Comment, error) {
+	const op = "service/threads/LockThread"
+
+	commentID = strings.TrimSpace(commentID)
+	lg := log.From(ctx).With("op", op, "comment_id", commentID, "locked", locked)
+
+	if commentID == "" {
+		lg.Warn("invalid argument: empty comment_id")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	root, err := s.storage.SetThreadLocked(ctx, commentID, locked)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			lg.Warn("comment not found")
+			return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
+		default:
+			lg.Error("storage error on SetThreadLocked", "err", err)
+			return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+		}
+	}
+
+	withRenderedContent(root)
+
+	return root, nil
+}
+
+// GetThreadPolicy — действующая политика веток новости.
+// Если политика не задана, возвращается ThreadPolicyDefault с TTL из cfg.TTL.Thread.
+//
+// Валидация:
+//   - newsID обязателен (uuid.Nil -> ErrInvalidArgument).
+//
+// Поведение/ошибки:
+//   - ErrInternal — ошибки стораджа.
+func (s *Service) GetThreadPolicy(ctx context.Context, newsID uuid.UUID) (*models.ThreadPolicy, error) {
+	const op = "service/threads/GetThreadPolicy"
+
+	lg := log.From(ctx).With("op", op, "news_id", newsID.String())
+
+	if newsID == uuid.Nil {
+		lg.Warn("invalid argument: empty news_id")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	policy, err := s.storage.ThreadPolicy(ctx, newsID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			return &models.ThreadPolicy{NewsID: newsID, Mode: models.ThreadPolicyDefault, TTL: s.cfg.TTL.Thread}, nil
+		default:
+			lg.Error("storage error on ThreadPolicy", "err", err)
+			return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+		}
+	}
+
+	return policy, nil
+}
+
+// SetThreadPolicy — установка политики веток новости; применяется и к уже существующим веткам.
+//
+// Валидация:
+//   - NewsID обязателен;
+//   - Mode — одно из значений models.ThreadPolicyMode;
+//   - для ThreadPolicyExpire TTLDays в диапазоне [1..maxThreadTTLDays], для прочих режимов игнорируется.
+//
+// Поведение/ошибки:
+//   - ThreadPolicyDefault сбрасывает политику к глобальному cfg.TTL.Thread;
+//   - ErrInternal — ошибки стораджа.
+func (s *Service) SetThreadPolicy(ctx context.Context, in SetThreadPolicyInput) (*models.ThreadPolicy, error) {
+	const op = "service/threads/SetThreadPolicy"
+
+	lg := log.From(ctx).With("op", op, "news_id", in.NewsID.String(), "mode", string(in.Mode))
+
+	if in.NewsID == uuid.Nil {
+		lg.Warn("invalid argument: empty news_id")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	policy := models.ThreadPolicy{NewsID: in.NewsID, Mode: in.Mode}
+
+	switch in.Mode {
+	case models.ThreadPolicyDefault, models.ThreadPolicyForever, models.ThreadPolicyLocked:
+	case models.ThreadPolicyExpire:
+		if in.TTLDays < 1 || in.TTLDays > maxThreadTTLDays {
+			lg.Warn("invalid argument: ttl_days out of range", "ttl_days", in.TTLDays)
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+		}
+
+		policy.TTL = time.Duration(in.TTLDays) * 24 * time.Hour
+	default:
+		lg.Warn("invalid argument: unknown mode")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	saved, err := s.storage.SetThreadPolicy(ctx, policy)
+	if err != nil {
+		lg.Error("storage error on SetThreadPolicy", "err", err)
+		return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	return saved, nil
+}
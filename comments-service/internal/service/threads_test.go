@@ -0,0 +1,127 @@
+package service
+
+// Тесты политик жизни веток и модераторской блокировки (internal/service/threads.go).
+//
+//  Проверяем:
+//  - LockThread: валидация, маппинг ErrNotFound/ErrInternal, возврат корня;
+//  - GetThreadPolicy: подстановку политики по умолчанию из cfg.TTL.Thread;
+//  - SetThreadPolicy: валидацию режима и ttl_days, перевод дней в TTL;
+//  - CreateComment: ErrThreadExpired для закрытой ветки.
+//
+// Запуск:
+//   go test ./internal/service -v -race -count=1
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_LockThread(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	_, err := s.LockThread(ctx, "  ", true)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	ms.EXPECT().SetThreadLocked(gomock.Any(), "missing", true).Return(nil, storage.ErrNotFound)
+	_, err = s.LockThread(ctx, "missing", true)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	ms.EXPECT().SetThreadLocked(gomock.Any(), "c1", true).Return(nil, errors.New("db down"))
+	_, err = s.LockThread(ctx, "c1", true)
+	require.ErrorIs(t, err, ErrInternal)
+
+	root := mustComment(uuid.New(), "", "alice", "hi")
+	root.IsLocked = true
+	ms.EXPECT().SetThreadLocked(gomock.Any(), "c2", true).Return(root, nil)
+	got, err := s.LockThread(ctx, " c2 ", true)
+	require.NoError(t, err)
+	require.True(t, got.IsLocked)
+}
+
+func TestService_GetThreadPolicy(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	s.cfg.TTL.Thread = 48 * time.Hour
+	ctx := context.Background()
+	newsID := uuid.New()
+
+	_, err := s.GetThreadPolicy(ctx, uuid.Nil)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	ms.EXPECT().ThreadPolicy(gomock.Any(), newsID).Return(nil, storage.ErrNotFound)
+	got, err := s.GetThreadPolicy(ctx, newsID)
+	require.NoError(t, err)
+	require.Equal(t, models.ThreadPolicyDefault, got.Mode)
+	require.Equal(t, 48*time.Hour, got.TTL)
+
+	ms.EXPECT().ThreadPolicy(gomock.Any(), newsID).Return(&models.ThreadPolicy{NewsID: newsID, Mode: models.ThreadPolicyLocked}, nil)
+	got, err = s.GetThreadPolicy(ctx, newsID)
+	require.NoError(t, err)
+	require.Equal(t, models.ThreadPolicyLocked, got.Mode)
+
+	ms.EXPECT().ThreadPolicy(gomock.Any(), newsID).Return(nil, errors.New("db down"))
+	_, err = s.GetThreadPolicy(ctx, newsID)
+	require.ErrorIs(t, err, ErrInternal)
+}
+
+func TestService_SetThreadPolicy_Validation(t *testing.T) {
+	s, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	newsID := uuid.New()
+
+	cases := []SetThreadPolicyInput{
+		{NewsID: uuid.Nil, Mode: models.ThreadPolicyForever},
+		{NewsID: newsID, Mode: "archive"},
+		{NewsID: newsID, Mode: models.ThreadPolicyExpire, TTLDays: 0},
+		{NewsID: newsID, Mode: models.ThreadPolicyExpire, TTLDays: maxThreadTTLDays + 1},
+	}
+	for _, in := range cases {
+		_, err := s.SetThreadPolicy(ctx, in)
+		require.ErrorIs(t, err, ErrInvalidArgument, "input %+v", in)
+	}
+}
+
+func TestService_SetThreadPolicy_OK(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	newsID := uuid.New()
+
+	ms.EXPECT().SetThreadPolicy(gomock.Any(), models.ThreadPolicy{NewsID: newsID, Mode: models.ThreadPolicyExpire, TTL: 30 * 24 * time.Hour}).
+		DoAndReturn(func(_ context.Context, p models.ThreadPolicy) (*models.ThreadPolicy, error) { return &p, nil })
+	got, err := s.SetThreadPolicy(ctx, SetThreadPolicyInput{NewsID: newsID, Mode: models.ThreadPolicyExpire, TTLDays: 30})
+	require.NoError(t, err)
+	require.Equal(t, 30*24*time.Hour, got.TTL)
+
+	// Для режимов без срока ttl_days игнорируется.
+	ms.EXPECT().SetThreadPolicy(gomock.Any(), models.ThreadPolicy{NewsID: newsID, Mode: models.ThreadPolicyForever}).
+		Return(nil, errors.New("db down"))
+	_, err = s.SetThreadPolicy(ctx, SetThreadPolicyInput{NewsID: newsID, Mode: models.ThreadPolicyForever, TTLDays: 5})
+	require.ErrorIs(t, err, ErrInternal)
+}
+
+func TestService_CreateComment_LockedThread(t *testing.T) {
+	s, ms, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	ms.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(nil, storage.ErrThreadExpired)
+	_, err := s.CreateComment(context.Background(), CreateCommentInput{
+		ParentID: "p1", UserID: uuid.New(), Username: "alice", Content: "late reply",
+	})
+	require.ErrorIs(t, err, ErrThreadExpired)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"github.com/pribylovaa/go-news-aggregator/pkg/pubsub"
+)
+
+// CommentsWatch — подписка на комментарии новости.
+//
+// Порядок выдачи: Replay, затем Live с пропуском созданных с ID <= последнего из Replay
+// (подписка оформляется до чтения пропущенного, поэтому события могут повториться;
+// ObjectID в hex сравниваются как строки). Удаления в Replay не попадают.
+type CommentsWatch struct {
+	// Reset — пропущенное после lastEventID не восстановить (больше cfg.Watch.Replay
+	// или битый курсор): клиенту нужно перечитать ListByNews.
+	Reset bool
+	// Replay — пропущенные комментарии по возрастанию ID.
+	Replay []models.Comment
+	// Live — создания и удаления; C закрывается, если подписчик не успевает (Lagged).
+	Live *pubsub.Subscription[models.CommentEvent]
+}
+
+// WatchComments подписывает на комментарии новости newsID (корни и ответы,
+// созданные и удалённые этой репликой). lastEventID — ID последнего полученного комментария;
+// пусто — только новые. Live обязательно закрыть.
+//
+// Ошибки: ErrInvalidArgument (пустой newsID), ErrInternal (хранилище; подписка при этом закрывается).
+func (s *Service) WatchComments(ctx context.Context, newsID uuid.UUID, lastEventID string) (*CommentsWatch, error) {
+	const op = "service/watch/WatchComments"
+
+	lg := log.From(ctx).With("op", op, "news_id", newsID.String())
+
+	if newsID == uuid.Nil {
+		lg.Warn("invalid argument: empty news_id")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	if s.comments == nil {
+		lg.Error("comments broker is not initialized")
+		return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	w := &CommentsWatch{Live: s.comments.Watch(newsID.String(), s.cfg.Watch.Buffer)}
+
+	lastEventID = strings.TrimSpace(lastEventID)
+	if lastEventID == "" {
+		return w, nil
+	}
+
+	// На одну больше лимита — чтобы отличить «ровно Replay» от «слишком много».
+	limit := s.cfg.Watch.Replay
+	missed, err := s.storage.CommentsAfter(ctx, newsID, lastEventID, int64(limit)+1)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			w.Reset = true
+			return w, nil
+		}
+
+		w.Live.Close()
+		lg.Error("storage error on CommentsAfter", "err", err)
+		return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	if len(missed) > limit {
+		w.Reset = true
+		return w, nil
+	}
+
+	w.Replay = missed
+
+	return w, nil
+}
+
+// publishComment рассылает событие kind о комментарии c подписчикам его новости.
+func (s *Service) publishComment(kind models.CommentEventKind, c models.Comment) {
+	if s.comments == nil {
+		return
+	}
+
+	s.comments.Publish(c.NewsID.String(), models.CommentEvent{Kind: kind, Comment: c})
+}
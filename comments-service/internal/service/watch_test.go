@@ -0,0 +1,150 @@
+package service
+
+// Unit-тесты живой подписки на комментарии (watch.go):
+//  - валидация news_id;
+//  - CreateComment публикует созданный комментарий подписчикам его новости;
+//  - DeleteComment публикует удаление один раз (повторное удаление — no-op);
+//  - досылка пропущенного по last_event_id, reset при битом курсоре и превышении Replay;
+//  - ошибка хранилища -> ErrInternal.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func newWatchService(t *testing.T) (*Service, *mocks.MockStorage) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	ms := mocks.NewMockStorage(ctrl)
+	cfg := config.Config{}
+	cfg.Watch.Buffer = 4
+	cfg.Watch.Replay = 2
+
+	return New(ms, cfg), ms
+}
+
+func TestService_WatchComments_InvalidNewsID(t *testing.T) {
+	s, _ := newWatchService(t)
+
+	_, err := s.WatchComments(context.Background(), uuid.Nil, "")
+	require.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestService_WatchComments_LiveFromCreate(t *testing.T) {
+	s, ms := newWatchService(t)
+
+	newsID, other := uuid.New(), uuid.New()
+	w, err := s.WatchComments(context.Background(), newsID, "")
+	require.NoError(t, err)
+	defer w.Live.Close()
+	require.False(t, w.Reset)
+	require.Empty(t, w.Replay)
+
+	created := mustComment(newsID, "", "u", "hello")
+	ms.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(mustComment(other, "", "u", "x"), nil)
+	ms.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(created, nil)
+
+	_, err = s.CreateComment(context.Background(), CreateCommentInput{NewsID: other, UserID: uuid.New(), Username: "u", Content: "x"})
+	require.NoError(t, err)
+	_, err = s.CreateComment(context.Background(), CreateCommentInput{NewsID: newsID, UserID: uuid.New(), Username: "u", Content: "hello"})
+	require.NoError(t, err)
+
+	select {
+	case ev := <-w.Live.C:
+		require.Equal(t, models.CommentEventCreated, ev.Kind)
+		require.Equal(t, created.ID, ev.Comment.ID)
+	case <-time.After(time.Second):
+		t.Fatal("no live event")
+	}
+
+	select {
+	case c := <-w.Live.C:
+		t.Fatalf("unexpected event for other news: %+v", c)
+	default:
+	}
+}
+
+func TestService_WatchComments_LiveFromDelete(t *testing.T) {
+	s, ms := newWatchService(t)
+
+	newsID := uuid.New()
+	w, err := s.WatchComments(context.Background(), newsID, "")
+	require.NoError(t, err)
+	defer w.Live.Close()
+
+	deleted := &models.Comment{ID: "650000000000000000000001", NewsID: newsID, ParentID: "650000000000000000000000", IsDeleted: true}
+	ms.EXPECT().DeleteComment(gomock.Any(), deleted.ID).Return(deleted, nil)
+	ms.EXPECT().DeleteComment(gomock.Any(), deleted.ID).Return(nil, nil)
+
+	require.NoError(t, s.DeleteComment(context.Background(), deleted.ID))
+	require.NoError(t, s.DeleteComment(context.Background(), deleted.ID))
+
+	select {
+	case ev := <-w.Live.C:
+		require.Equal(t, models.CommentEventDeleted, ev.Kind)
+		require.Equal(t, *deleted, ev.Comment)
+	case <-time.After(time.Second):
+		t.Fatal("no delete event")
+	}
+
+	select {
+	case ev := <-w.Live.C:
+		t.Fatalf("repeated delete must not be published: %+v", ev)
+	default:
+	}
+}
+
+func TestService_WatchComments_Replay(t *testing.T) {
+	s, ms := newWatchService(t)
+
+	newsID := uuid.New()
+	missed := []models.Comment{*mustComment(newsID, "", "a", "1"), *mustComment(newsID, "", "b", "2")}
+	ms.EXPECT().CommentsAfter(gomock.Any(), newsID, "last", int64(3)).Return(missed, nil)
+
+	w, err := s.WatchComments(context.Background(), newsID, " last ")
+	require.NoError(t, err)
+	defer w.Live.Close()
+
+	require.False(t, w.Reset)
+	require.Equal(t, missed, w.Replay)
+}
+
+func TestService_WatchComments_Reset(t *testing.T) {
+	s, ms := newWatchService(t)
+	newsID := uuid.New()
+
+	ms.EXPECT().CommentsAfter(gomock.Any(), newsID, "bad", int64(3)).Return(nil, storage.ErrInvalidCursor)
+	w, err := s.WatchComments(context.Background(), newsID, "bad")
+	require.NoError(t, err)
+	require.True(t, w.Reset)
+	w.Live.Close()
+
+	many := []models.Comment{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	ms.EXPECT().CommentsAfter(gomock.Any(), newsID, "old", int64(3)).Return(many, nil)
+	w, err = s.WatchComments(context.Background(), newsID, "old")
+	require.NoError(t, err)
+	require.True(t, w.Reset)
+	require.Empty(t, w.Replay)
+	w.Live.Close()
+}
+
+func TestService_WatchComments_StorageError(t *testing.T) {
+	s, ms := newWatchService(t)
+	newsID := uuid.New()
+
+	ms.EXPECT().CommentsAfter(gomock.Any(), newsID, "x", int64(3)).Return(nil, errors.New("db down"))
+	_, err := s.WatchComments(context.Background(), newsID, "x")
+	require.ErrorIs(t, err, ErrInternal)
+}
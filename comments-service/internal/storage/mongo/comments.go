@@ -74,9 +74,13 @@ func limitOrDefault(cfg *config.Config, pageSize int32) int64 {
 }
 
 // CreateComment создаёт комментарий (корневой или ответ).
-//   - Для корня выставляет Level=0, ExpiresAt = now + cfg.TTL.Thread.
-//   - Для ответа подтягивает NewsID/ExpiresAt из родителя, Level = parent.Level + 1.
-//   - На родителе инкрементирует replies_count.
+//   - Для корня выставляет Level=0, ExpiresAt по политике новости (см. threadExpiry);
+//     при политике ThreadPolicyLocked — storage.ErrThreadExpired.
+//   - Для ответа подтягивает NewsID/ExpiresAt/IsLocked из родителя, Level = parent.Level + 1,
+//     RootID = идентификатор ветки родителя.
+//   - Запись в ветку, которая истекла, заблокирована модератором или политикой новости,
+//     запрещена — storage.ErrThreadExpired.
+//   - На родителе инкрементирует replies_count, в счётчиках новости — total/roots и last_activity_at.
 func (m *Mongo) CreateComment(ctx context.Context, comm models.Comment) (*models.Comment, error) {
 	const op = "storage/mongo/CreateComment"
 
@@ -87,12 +91,22 @@ func (m *Mongo) CreateComment(ctx context.Context, comm models.Comment) (*models
 	// Базовая нормализация временных полей перед записью.
 	comm.CreatedAt = now
 	comm.UpdatedAt = now
+	comm.IsLocked = false
 
 	// Обработка корня/ответа.
 	if strings.TrimSpace(comm.ParentID) == "" {
 		// Корневой комментарий.
+		policy, err := m.policyOrDefault(ctx, comm.NewsID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if policy.Mode == models.ThreadPolicyLocked {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrThreadExpired)
+		}
+
 		comm.Level = 0
-		comm.ExpiresAt = toMS(now.Add(m.cfg.TTL.Thread))
+		comm.ExpiresAt = threadExpiry(*policy, now)
 	} else {
 		// Ответ: необходимо найти родителя и перенять часть полей/ограничений.
 		parentOID, err := primitive.ObjectIDFromHex(strings.TrimSpace(comm.ParentID))
@@ -109,6 +123,20 @@ func (m *Mongo) CreateComment(ctx context.Context, comm models.Comment) (*models
 			return nil, fmt.Errorf("%s: find parent: %w", op, err)
 		}
 
+		// Ветка закрыта для записи: блокировка модератора (общая для ветки) или истёкший срок.
+		if parent.IsLocked || parent.IsExpired(now) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrThreadExpired)
+		}
+
+		policy, err := m.policyOrDefault(ctx, parent.NewsID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if policy.Mode == models.ThreadPolicyLocked {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrThreadExpired)
+		}
+
 		// Проверка глубины дерева.
 		if parent.Level+1 > m.cfg.Limits.MaxDepth {
 			return nil, fmt.Errorf("%s: %w", op, storage.ErrMaxDepthExceeded)
@@ -118,8 +146,12 @@ func (m *Mongo) CreateComment(ctx context.Context, comm models.Comment) (*models
 		comm.NewsID = parent.NewsID
 
 		// У ответов единый срок жизни ветки как у корня.
-		comm.ExpiresAt = toMS(parent.ExpiresAt)
+		comm.ExpiresAt = parent.ExpiresAt
+		if !comm.ExpiresAt.IsZero() {
+			comm.ExpiresAt = toMS(comm.ExpiresAt)
+		}
 		comm.Level = parent.Level + 1
+		comm.RootID = parent.ThreadID()
 
 		// Инкремент счётчика у родителя по факту успешной вставки.
 		defer func() {
@@ -144,36 +176,66 @@ func (m *Mongo) CreateComment(ctx context.Context, comm models.Comment) (*models
 	}
 
 	comm.ID = oid.Hex()
+
+	// Счётчики новости — best-effort, как и replies_count: комментарий уже сохранён.
+	_ = m.bumpCounters(ctx, comm.NewsID, 1, comm.ParentID == "", comm.CreatedAt)
+
 	return &comm, nil
 }
 
-// DeleteComment помечает комментарий как удалённый (мягкое удаление).
+// DeleteComment помечает комментарий как удалённый (мягкое удаление) и уменьшает счётчики новости.
+// Текст стирается безвозвратно: остаётся «надгробие» с is_deleted=true.
+// Возвращает идентификаторы удалённого комментария; повторное удаление — no-op (nil, nil).
 // При отсутствии записи — storage.ErrNotFound.
-func (m *Mongo) DeleteComment(ctx context.Context, id string) error {
+func (m *Mongo) DeleteComment(ctx context.Context, id string) (*models.Comment, error) {
 	const op = "storage/mongo/DeleteComment"
 
 	oid, err := primitive.ObjectIDFromHex(strings.TrimSpace(id))
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
 	}
 
-	res, err := m.comments.UpdateByID(ctx, oid, bson.D{
-		{Key: "$set", Value: bson.D{
-			{Key: "is_deleted", Value: true},
-			{Key: "content", Value: ""},
-			{Key: "updated_at", Value: time.Now().UTC()},
-		}},
-	})
+	// Обновляем только ещё не удалённый документ — так счётчики уменьшаются ровно один раз.
+	var before models.Comment
+	err = m.comments.FindOneAndUpdate(ctx,
+		bson.D{{Key: "_id", Value: oid}, {Key: "is_deleted", Value: bson.D{{Key: "$ne", Value: true}}}},
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "is_deleted", Value: true},
+				{Key: "content", Value: ""},
+				{Key: "content_html", Value: ""},
+				{Key: "updated_at", Value: time.Now().UTC()},
+			}},
+		},
+		options.FindOneAndUpdate().SetProjection(bson.D{
+			{Key: "news_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "root_id", Value: 1},
+		}),
+	).Decode(&before)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
+		if !errors.Is(err, mongodriver.ErrNoDocuments) {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		n, err := m.comments.CountDocuments(ctx, bson.D{{Key: "_id", Value: oid}})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if n == 0 {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+		}
 
-	if res.MatchedCount == 0 {
-		return fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+		return nil, nil
 	}
 
-	return nil
+	// Счётчики новости — best-effort: удаление уже применено.
+	_ = m.bumpCounters(ctx, before.NewsID, -1, before.ParentID == "", time.Time{})
+
+	before.ID = oid.Hex()
+	before.IsDeleted = true
+
+	return &before, nil
 }
 
 // CommentByID возвращает комментарий по идентификатору.
@@ -352,3 +414,50 @@ func (m *Mongo) ListReplies(ctx context.Context, parentID string, param models.L
 		NextPageToken: next,
 	}, nil
 }
+
+// CommentsAfter возвращает комментарии новости с _id > afterID (корни и ответы).
+// Сортировка: _id ASC (порядок создания); мягко удалённые исключаются.
+// Некорректный afterID — storage.ErrInvalidCursor.
+func (m *Mongo) CommentsAfter(ctx context.Context, newsID uuid.UUID, afterID string, limit int64) ([]models.Comment, error) {
+	const op = "storage/mongo/CommentsAfter"
+
+	afterOID, err := primitive.ObjectIDFromHex(strings.TrimSpace(afterID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrInvalidCursor)
+	}
+
+	filter := bson.D{
+		{Key: "news_id", Value: newsID},
+		{Key: "_id", Value: bson.D{{Key: "$gt", Value: afterOID}}},
+		{Key: "is_deleted", Value: bson.D{{Key: "$ne", Value: true}}},
+	}
+
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cur, err := m.comments.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: find: %w", op, err)
+	}
+	defer cur.Close(ctx)
+
+	var items []models.Comment
+	for cur.Next(ctx) {
+		var comm models.Comment
+		if err := cur.Decode(&comm); err != nil {
+			return nil, fmt.Errorf("%s: decode: %w", op, err)
+		}
+
+		comm.CreatedAt = comm.CreatedAt.UTC()
+		comm.UpdatedAt = comm.UpdatedAt.UTC()
+		comm.ExpiresAt = comm.ExpiresAt.UTC()
+		items = append(items, comm)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, fmt.Errorf("%s: cursor: %w", op, err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,114 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// bumpCounters атомарно изменяет счётчики новости на delta (корни — если isRoot)
+// и сдвигает last_activity_at вперёд до activity (нулевое activity — без изменения).
+func (m *Mongo) bumpCounters(ctx context.Context, newsID uuid.UUID, delta int64, isRoot bool, activity time.Time) error {
+	inc := bson.D{{Key: "total", Value: delta}}
+	if isRoot {
+		inc = append(inc, bson.E{Key: "roots", Value: delta})
+	}
+
+	update := bson.D{{Key: "$inc", Value: inc}}
+	if !activity.IsZero() {
+		update = append(update, bson.E{Key: "$max", Value: bson.D{{Key: "last_activity_at", Value: activity}}})
+	}
+
+	_, err := m.counters.UpdateByID(ctx, newsID, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("bump counters: %w", err)
+	}
+
+	return nil
+}
+
+// CountsByNews возвращает счётчики для newsIDs. Новости без комментариев в результат не попадают.
+func (m *Mongo) CountsByNews(ctx context.Context, newsIDs []uuid.UUID) (map[uuid.UUID]models.NewsCounts, error) {
+	const op = "storage/mongo/CountsByNews"
+
+	out := make(map[uuid.UUID]models.NewsCounts, len(newsIDs))
+	if len(newsIDs) == 0 {
+		return out, nil
+	}
+
+	cur, err := m.counters.Find(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: newsIDs}}}})
+	if err != nil {
+		return nil, fmt.Errorf("%s: find: %w", op, err)
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var c models.NewsCounts
+		if err := cur.Decode(&c); err != nil {
+			return nil, fmt.Errorf("%s: decode: %w", op, err)
+		}
+
+		if c.Total <= 0 && c.LastActivityAt.IsZero() {
+			continue
+		}
+
+		c.LastActivityAt = c.LastActivityAt.UTC()
+		out[c.NewsID] = c
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, fmt.Errorf("%s: cursor: %w", op, err)
+	}
+
+	return out, nil
+}
+
+// backfillCounters строит счётчики по уже сохранённым комментариям.
+// Выполняется только при пустой коллекции счётчиков (первый запуск после появления счётчиков),
+// существующие документы не перезаписываются.
+func (m *Mongo) backfillCounters(ctx context.Context) error {
+	n, err := m.counters.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return fmt.Errorf("mongo count counters: %w", err)
+	}
+
+	if n > 0 {
+		return nil
+	}
+
+	pipeline := bson.A{
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$news_id"},
+			{Key: "total", Value: bson.D{{Key: "$sum", Value: bson.D{
+				{Key: "$cond", Value: bson.A{bson.D{{Key: "$eq", Value: bson.A{"$is_deleted", true}}}, 0, 1}},
+			}}}},
+			{Key: "roots", Value: bson.D{{Key: "$sum", Value: bson.D{
+				{Key: "$cond", Value: bson.A{
+					bson.D{{Key: "$and", Value: bson.A{
+						bson.D{{Key: "$eq", Value: bson.A{"$parent_id", ""}}},
+						bson.D{{Key: "$ne", Value: bson.A{"$is_deleted", true}}},
+					}}},
+					1, 0,
+				}},
+			}}}},
+			{Key: "last_activity_at", Value: bson.D{{Key: "$max", Value: "$created_at"}}},
+		}}},
+		bson.D{{Key: "$merge", Value: bson.D{
+			{Key: "into", Value: countersCollection},
+			{Key: "whenMatched", Value: "keepExisting"},
+			{Key: "whenNotMatched", Value: "insert"},
+		}}},
+	}
+
+	cur, err := m.comments.Aggregate(ctx, pipeline)
+	if err != nil {
+		return fmt.Errorf("mongo backfill counters: %w", err)
+	}
+
+	return cur.Close(ctx)
+}
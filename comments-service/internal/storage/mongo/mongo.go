@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
@@ -14,8 +15,13 @@ import (
 )
 
 const (
-	commentsCollection = "comments"
-	defaultDBName      = "comments"
+	commentsCollection       = "comments"
+	notificationsCollection  = "notifications"
+	threadMutesCollection    = "thread_mutes"
+	threadPoliciesCollection = "thread_policies"
+	countersCollection       = "news_counters"
+	reactionsCollection      = "comment_reactions"
+	defaultDBName            = "comments"
 )
 
 // Mongo - тонкий адаптер для подключения и коллекций MongoDB.
@@ -24,6 +30,13 @@ type Mongo struct {
 	client   *mongodriver.Client
 	db       *mongodriver.Database
 	comments *mongodriver.Collection
+
+	notifications *mongodriver.Collection
+	threadMutes   *mongodriver.Collection
+
+	threadPolicies *mongodriver.Collection
+	counters       *mongodriver.Collection
+	reactions      *mongodriver.Collection
 }
 
 // New подключается к MongoDB, проверяет его, подготавливает коллекции и обеспечивает индексацию.
@@ -54,6 +67,13 @@ func New(ctx context.Context, cfg *config.Config) (*Mongo, error) {
 		client:   cli,
 		db:       db,
 		comments: db.Collection(commentsCollection),
+
+		notifications: db.Collection(notificationsCollection),
+		threadMutes:   db.Collection(threadMutesCollection),
+
+		threadPolicies: db.Collection(threadPoliciesCollection),
+		counters:       db.Collection(countersCollection),
+		reactions:      db.Collection(reactionsCollection),
 	}
 
 	if err := m.ensureIndexes(ctx); err != nil {
@@ -61,6 +81,16 @@ func New(ctx context.Context, cfg *config.Config) (*Mongo, error) {
 		return nil, err
 	}
 
+	if err := m.backfillCounters(ctx); err != nil {
+		_ = m.Close(ctx)
+		return nil, err
+	}
+
+	if err := m.backfillRootIDs(ctx); err != nil {
+		_ = m.Close(ctx)
+		return nil, err
+	}
+
 	return m, nil
 }
 
@@ -69,33 +99,159 @@ func (m *Mongo) Close(ctx context.Context) error {
 }
 
 // ensureIndexes создает индексы, необходимые для службы комментариев.
-// - TTL по expires_at (expireAfterSeconds=0 -> используется временная метка, сохраненненная в документе)
+// - Комментарии физически не удаляются: устаревший TTL-индекс ttl_expires_at снимается
 // - Список корневых комментариев: news_id + parent_id + created_at(desc)
 // - Ответы в теме: parent_id + created_at(asc)
+// - Вся ветка целиком (блокировка/пересчёт срока): root_id
+// - История автора: user_id + created_at(desc) + _id(desc)
+// - Полнотекстовый поиск: text по content (язык по умолчанию — russian)
+// - Политики веток: уникальный news_id
+// - Уведомления: TTL по expires_at, лента получателя user_id + created_at(desc), счётчик непрочитанных
+// - Заглушённые ветки: уникальная пара user_id + thread_id
+// - Реакции: уникальная тройка comment_id + user_id + reaction
 func (m *Mongo) ensureIndexes(ctx context.Context) error {
 
+	// Раньше ветки удалялись TTL-индексом; теперь expires_at лишь закрывает ветку для записи.
+	if _, err := m.comments.Indexes().DropOne(ctx, "ttl_expires_at"); err != nil && !isIndexNotFound(err) {
+		return fmt.Errorf("mongo drop ttl index: %w", err)
+	}
+
+	if err := m.dropDeletedContent(ctx); err != nil {
+		return err
+	}
+
 	models := []mongodriver.IndexModel{
-		{
-			Keys:    bson.D{{Key: "expires_at", Value: 1}},
-			Options: options.Index().SetName("ttl_expires_at").SetExpireAfterSeconds(0),
-		},
 		{
 			Keys:    bson.D{{Key: "news_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "created_at", Value: -1}},
 			Options: options.Index().SetName("news_parent_created_desc"),
 		},
+		{
+			Keys:    bson.D{{Key: "news_id", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("news_id_asc"),
+		},
 		{
 			Keys:    bson.D{{Key: "parent_id", Value: 1}, {Key: "created_at", Value: 1}},
 			Options: options.Index().SetName("parent_created_asc"),
 		},
+		{
+			Keys:    bson.D{{Key: "root_id", Value: 1}},
+			Options: options.Index().SetName("root"),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("user_created_desc"),
+		},
+		{
+			Keys:    bson.D{{Key: "content", Value: "text"}},
+			Options: options.Index().SetName("content_text").SetDefaultLanguage("russian"),
+		},
 	}
 
 	_, err := m.comments.Indexes().CreateMany(ctx, models)
 	if err != nil {
 		return fmt.Errorf("mongo ensure indexes: %w", err)
 	}
+
+	_, err = m.notifications.Indexes().CreateMany(ctx, []mongodriver.IndexModel{
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("ttl_expires_at").SetExpireAfterSeconds(0),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("user_created_desc"),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "is_read", Value: 1}},
+			Options: options.Index().SetName("user_is_read"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("mongo ensure notifications indexes: %w", err)
+	}
+
+	_, err = m.threadMutes.Indexes().CreateMany(ctx, []mongodriver.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "thread_id", Value: 1}},
+			Options: options.Index().SetName("user_thread_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "thread_id", Value: 1}},
+			Options: options.Index().SetName("thread"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("mongo ensure thread mutes indexes: %w", err)
+	}
+
+	_, err = m.threadPolicies.Indexes().CreateOne(ctx, mongodriver.IndexModel{
+		Keys:    bson.D{{Key: "news_id", Value: 1}},
+		Options: options.Index().SetName("news_unique").SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("mongo ensure thread policies indexes: %w", err)
+	}
+
+	_, err = m.reactions.Indexes().CreateOne(ctx, mongodriver.IndexModel{
+		Keys:    bson.D{{Key: "comment_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "reaction", Value: 1}},
+		Options: options.Index().SetName("comment_user_reaction_unique").SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("mongo ensure reactions indexes: %w", err)
+	}
+
 	return nil
 }
 
+// dropDeletedContent убирает следы прежнего удаления, копировавшего текст в deleted_content:
+// текстовый индекс content_text по этому полю снимается (ensureIndexes создаст его заново
+// только по content), а само поле стирается. Повторный запуск — no-op.
+func (m *Mongo) dropDeletedContent(ctx context.Context) error {
+	cur, err := m.comments.Indexes().List(ctx)
+	if err != nil {
+		return fmt.Errorf("mongo list indexes: %w", err)
+	}
+
+	var specs []struct {
+		Name    string `bson:"name"`
+		Weights bson.M `bson:"weights"`
+	}
+	if err := cur.All(ctx, &specs); err != nil {
+		return fmt.Errorf("mongo list indexes: %w", err)
+	}
+
+	for _, spec := range specs {
+		if _, ok := spec.Weights["deleted_content"]; spec.Name != "content_text" || !ok {
+			continue
+		}
+
+		if _, err := m.comments.Indexes().DropOne(ctx, spec.Name); err != nil && !isIndexNotFound(err) {
+			return fmt.Errorf("mongo drop text index: %w", err)
+		}
+	}
+
+	_, err = m.comments.UpdateMany(ctx,
+		bson.D{{Key: "deleted_content", Value: bson.D{{Key: "$exists", Value: true}}}},
+		bson.D{{Key: "$unset", Value: bson.D{{Key: "deleted_content", Value: ""}}}},
+	)
+	if err != nil {
+		return fmt.Errorf("mongo wipe deleted_content: %w", err)
+	}
+
+	return nil
+}
+
+// isIndexNotFound сообщает, что удаляемого индекса (или самой коллекции) нет —
+// коды IndexNotFound (27) и NamespaceNotFound (26).
+func isIndexNotFound(err error) bool {
+	var ce mongodriver.CommandError
+	if errors.As(err, &ce) {
+		return ce.Code == 26 || ce.Code == 27
+	}
+
+	return false
+}
+
 // databaseFromURI извлекает имя базы данных из URI-пути mongodb.
 // Если оно отсутствует или не поддается расшифровке, возвращает разумное значение по умолчанию.
 func databaseFromURI(uri string) string {
@@ -16,7 +16,10 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // testTimeout — общий дедлайн на операции с БД в тестах.
@@ -98,6 +101,9 @@ func newTestConfig(t *testing.T) *config.Config {
 			Max:      100,
 			MaxDepth: 3,
 		},
+		Notifications: config.NotificationsConfig{
+			TTL: 24 * time.Hour,
+		},
 	}
 }
 
@@ -253,6 +259,25 @@ func TestCreateReply_InheritsNewsAndTTL_IncrementsCounter(t *testing.T) {
 		t.Fatalf("reply.ExpiresAt = %v, want %v (inherited from root)", reply.ExpiresAt, root.ExpiresAt)
 	}
 
+	if reply.RootID != root.ID || root.ThreadID() != root.ID {
+		t.Fatalf("reply.RootID = %q, root thread = %q, want %q", reply.RootID, root.ThreadID(), root.ID)
+	}
+
+	// Ответ на ответ относится к той же ветке.
+	nested, err := m.CreateComment(ctx, models.Comment{
+		ParentID: reply.ID,
+		UserID:   uuid.New(),
+		Username: "dave",
+		Content:  "nested",
+	})
+	if err != nil {
+		t.Fatalf("CreateComment(nested) error: %v", err)
+	}
+
+	if nested.RootID != root.ID {
+		t.Fatalf("nested.RootID = %q, want %q", nested.RootID, root.ID)
+	}
+
 	// Проверяем, что у родителя увеличился replies_count (инкремент после вставки).
 	parent, err := m.CommentByID(ctx, root.ID)
 	if err != nil {
@@ -346,27 +371,36 @@ func TestDeleteComment_SoftDelete(t *testing.T) {
 	defer cancel()
 
 	c, err := m.CreateComment(ctx, models.Comment{
-		NewsID:   uuid.New(),
-		UserID:   uuid.New(),
-		Username: "z",
-		Content:  "to be deleted",
+		NewsID:      uuid.New(),
+		UserID:      uuid.New(),
+		Username:    "z",
+		Content:     "to be *deleted*",
+		ContentHTML: "<p>to be <em>deleted</em></p>",
 	})
 
 	if err != nil {
 		t.Fatalf("CreateComment(root) error: %v", err)
 	}
 
-	if err := m.DeleteComment(ctx, c.ID); err != nil {
+	if stored, err := m.CommentByID(ctx, c.ID); err != nil || stored.ContentHTML != "<p>to be <em>deleted</em></p>" {
+		t.Fatalf("CommentByID before delete = %+v, %v; want stored content_html", stored, err)
+	}
+
+	deleted, err := m.DeleteComment(ctx, c.ID)
+	if err != nil {
 		t.Fatalf("DeleteComment error: %v", err)
 	}
+	if deleted == nil || deleted.ID != c.ID || deleted.NewsID != c.NewsID || !deleted.IsDeleted {
+		t.Fatalf("DeleteComment = %+v; want id/news_id of deleted comment", deleted)
+	}
 
 	got, err := m.CommentByID(ctx, c.ID)
 	if err != nil {
 		t.Fatalf("CommentByID after delete error: %v", err)
 	}
 
-	if !got.IsDeleted || got.Content != "" {
-		t.Fatalf("soft delete failed: is_deleted=%v, content=%q", got.IsDeleted, got.Content)
+	if !got.IsDeleted || got.Content != "" || got.ContentHTML != "" {
+		t.Fatalf("soft delete failed: is_deleted=%v, content=%q, content_html=%q", got.IsDeleted, got.Content, got.ContentHTML)
 	}
 }
 
@@ -511,6 +545,57 @@ func TestListReplies_PaginationAndOrder(t *testing.T) {
 	}
 }
 
+// TestCommentsAfter — досылка живой подписки: корни и ответы новости после курсора
+// по возрастанию _id, без удалённых и чужих новостей; битый курсор — ErrInvalidCursor.
+func TestCommentsAfter(t *testing.T) {
+	cfg := newTestConfig(t)
+	m := mustNewMongo(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	newsID := uuid.New()
+	create := func(news uuid.UUID, parentID string) *models.Comment {
+		c, err := m.CreateComment(ctx, models.Comment{
+			NewsID:   news,
+			ParentID: parentID,
+			UserID:   uuid.New(),
+			Username: "w",
+			Content:  "c",
+		})
+		if err != nil {
+			t.Fatalf("CreateComment error: %v", err)
+		}
+		return c
+	}
+
+	first := create(newsID, "")
+	root := create(newsID, "")
+	_ = create(uuid.New(), "")
+	reply := create(newsID, root.ID)
+	gone := create(newsID, "")
+	if _, err := m.DeleteComment(ctx, gone.ID); err != nil {
+		t.Fatalf("DeleteComment error: %v", err)
+	}
+
+	got, err := m.CommentsAfter(ctx, newsID, first.ID, 10)
+	if err != nil {
+		t.Fatalf("CommentsAfter error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != root.ID || got[1].ID != reply.ID {
+		t.Fatalf("CommentsAfter = %+v; want [root, reply]", got)
+	}
+
+	got, err = m.CommentsAfter(ctx, newsID, first.ID, 1)
+	if err != nil || len(got) != 1 || got[0].ID != root.ID {
+		t.Fatalf("CommentsAfter(limit=1) = %+v, %v; want [root]", got, err)
+	}
+
+	if _, err := m.CommentsAfter(ctx, newsID, "bad", 10); !errors.Is(err, storage.ErrInvalidCursor) {
+		t.Fatalf("want ErrInvalidCursor for bad cursor, got %v", err)
+	}
+}
+
 // TestEnsureIndexes_Created — индексы, создаваемые ensureIndexes, существуют.
 // Проверяем как по имени (если задано), так и по составу ключей — чтобы быть устойчивыми
 // к различиям в авто-именовании.
@@ -540,13 +625,13 @@ func TestEnsureIndexes_Created(t *testing.T) {
 			haveNames[name] = true
 		}
 
+		// Комментарии больше не удаляются физически: TTL-индекса быть не должно.
+		if _, ok := spec["expireAfterSeconds"]; ok {
+			haveTTL = true
+		}
+
 		// Проверяем состав ключей.
 		if k, ok := spec["key"].(map[string]any); ok {
-			// TTL: expires_at: 1
-			if len(k) == 1 && numEq(k["expires_at"], 1) {
-				haveTTL = true
-			}
-
 			// Корневые: news_id:1, parent_id:1, created_at:-1
 			if numEq(k["news_id"], 1) && numEq(k["parent_id"], 1) && numEq(k["created_at"], -1) {
 				haveRootList = true
@@ -563,12 +648,626 @@ func TestEnsureIndexes_Created(t *testing.T) {
 		t.Fatalf("cursor err: %v", err)
 	}
 
+	if haveTTL {
+		t.Fatalf("unexpected TTL index on comments; names=%v", haveNames)
+	}
+
 	// Разрешаем как проверку по имени (если явно задано в ensureIndexes), так и по составу ключей.
-	byNameOK := haveNames["ttl_expires_at"] && haveNames["news_parent_created_desc"] && haveNames["parent_created_asc"]
-	byKeysOK := haveTTL && haveRootList && haveRepliesList
+	byNameOK := haveNames["news_parent_created_desc"] && haveNames["parent_created_asc"]
+	byKeysOK := haveRootList && haveRepliesList
 
 	if !(byNameOK || byKeysOK) {
-		t.Fatalf("required indexes not found; names=%v, ttl=%v, root=%v, replies=%v", haveNames, haveTTL, haveRootList, haveRepliesList)
+		t.Fatalf("required indexes not found; names=%v, root=%v, replies=%v", haveNames, haveRootList, haveRepliesList)
+	}
+
+	if !haveNames["user_created_desc"] || !haveNames["content_text"] || !haveNames["news_id_asc"] {
+		t.Fatalf("history/search/watch indexes not found; names=%v", haveNames)
+	}
+}
+
+// TestEnsureIndexes_DropsDeletedContent — следы прежнего удаления с копией текста:
+// индекс content_text по deleted_content пересоздаётся только по content, поле стирается.
+func TestEnsureIndexes_DropsDeletedContent(t *testing.T) {
+	cfg := newTestConfig(t)
+	m := mustNewMongo(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	if _, err := m.comments.Indexes().DropOne(ctx, "content_text"); err != nil {
+		t.Fatalf("drop content_text: %v", err)
+	}
+	if _, err := m.comments.Indexes().CreateOne(ctx, mongodriver.IndexModel{
+		Keys:    bson.D{{Key: "content", Value: "text"}, {Key: "deleted_content", Value: "text"}},
+		Options: options.Index().SetName("content_text").SetDefaultLanguage("russian"),
+	}); err != nil {
+		t.Fatalf("create legacy content_text: %v", err)
+	}
+
+	id := primitive.NewObjectID()
+	if _, err := m.comments.InsertOne(ctx, bson.D{
+		{Key: "_id", Value: id}, {Key: "news_id", Value: uuid.New()}, {Key: "parent_id", Value: ""},
+		{Key: "user_id", Value: uuid.New()}, {Key: "username", Value: "u"}, {Key: "content", Value: ""},
+		{Key: "deleted_content", Value: "старый секрет"}, {Key: "is_deleted", Value: true},
+	}); err != nil {
+		t.Fatalf("insert legacy comment: %v", err)
+	}
+
+	// Повторный запуск — no-op.
+	for i := 0; i < 2; i++ {
+		if err := m.ensureIndexes(ctx); err != nil {
+			t.Fatalf("ensureIndexes #%d error: %v", i, err)
+		}
+	}
+
+	var raw bson.M
+	if err := m.comments.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&raw); err != nil {
+		t.Fatalf("FindOne error: %v", err)
+	}
+	if _, ok := raw["deleted_content"]; ok {
+		t.Fatalf("deleted_content survived: %+v", raw)
+	}
+
+	cur, err := m.comments.Indexes().List(ctx)
+	if err != nil {
+		t.Fatalf("Indexes().List error: %v", err)
+	}
+	var specs []bson.M
+	if err := cur.All(ctx, &specs); err != nil {
+		t.Fatalf("decode index specs: %v", err)
+	}
+	for _, spec := range specs {
+		if spec["name"] != "content_text" {
+			continue
+		}
+		weights, _ := spec["weights"].(bson.M)
+		if _, ok := weights["deleted_content"]; ok || weights["content"] == nil {
+			t.Fatalf("content_text weights = %v, want content only", weights)
+		}
+		return
+	}
+	t.Fatalf("content_text index not found")
+}
+
+// TestNotifications_CreateListMarkRead — лента уведомлений: порядок, unread_only, пагинация, MarkRead/UnreadCount.
+func TestNotifications_CreateListMarkRead(t *testing.T) {
+	cfg := newTestConfig(t)
+	m := mustNewMongo(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	user, other := uuid.New(), uuid.New()
+	var ids []string
+	for i := 0; i < 3; i++ {
+		saved, err := m.CreateNotifications(ctx, []models.Notification{
+			{UserID: user, Kind: models.NotificationKindReply, CommentID: fmt.Sprintf("c%d", i), IsRead: true},
+			{UserID: other, Kind: models.NotificationKindMention, CommentID: fmt.Sprintf("c%d", i)},
+		})
+		if err != nil {
+			t.Fatalf("CreateNotifications error: %v", err)
+		}
+		if saved[0].ID == "" || saved[0].IsRead || saved[0].ExpiresAt.IsZero() {
+			t.Fatalf("unexpected saved notification: %+v", saved[0])
+		}
+		ids = append(ids, saved[0].ID)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	if n, err := m.UnreadCount(ctx, user); err != nil || n != 3 {
+		t.Fatalf("UnreadCount = %d, %v; want 3", n, err)
+	}
+
+	page, err := m.ListNotifications(ctx, user, false, models.ListParams{})
+	if err != nil {
+		t.Fatalf("ListNotifications error: %v", err)
+	}
+	if len(page.Items) != 2 || page.Items[0].CommentID != "c2" || page.NextPageToken == "" {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+
+	page2, err := m.ListNotifications(ctx, user, false, models.ListParams{PageToken: page.NextPageToken})
+	if err != nil {
+		t.Fatalf("ListNotifications(page2) error: %v", err)
+	}
+	if len(page2.Items) != 1 || page2.Items[0].CommentID != "c0" {
+		t.Fatalf("unexpected second page: %+v", page2)
+	}
+
+	// Чужие/битые id игнорируются.
+	n, err := m.MarkRead(ctx, user, []string{ids[2], "bad-id"})
+	if err != nil || n != 1 {
+		t.Fatalf("MarkRead = %d, %v; want 1", n, err)
+	}
+
+	unread, err := m.ListNotifications(ctx, user, true, models.ListParams{PageSize: 10})
+	if err != nil {
+		t.Fatalf("ListNotifications(unread) error: %v", err)
+	}
+	if len(unread.Items) != 2 {
+		t.Fatalf("unread items = %d, want 2", len(unread.Items))
+	}
+
+	if n, err := m.MarkRead(ctx, user, nil); err != nil || n != 2 {
+		t.Fatalf("MarkRead(all) = %d, %v; want 2", n, err)
+	}
+	if n, _ := m.UnreadCount(ctx, user); n != 0 {
+		t.Fatalf("UnreadCount after MarkRead(all) = %d, want 0", n)
+	}
+	if n, _ := m.UnreadCount(ctx, other); n != 3 {
+		t.Fatalf("UnreadCount(other) = %d, want 3", n)
+	}
+
+	if _, err := m.ListNotifications(ctx, user, false, models.ListParams{PageToken: "%%%"}); !errors.Is(err, storage.ErrInvalidCursor) {
+		t.Fatalf("want ErrInvalidCursor, got %v", err)
+	}
+}
+
+// TestSetReaction — постановка/снятие идемпотентны, счётчики считают разных пользователей,
+// нулевые счётчики не отдаются; в закрытую ветку реакцию не поставить, а снять можно.
+func TestSetReaction(t *testing.T) {
+	cfg := newTestConfig(t)
+	m := mustNewMongo(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	c, err := m.CreateComment(ctx, models.Comment{NewsID: uuid.New(), UserID: uuid.New(), Username: "a", Content: "x"})
+	if err != nil {
+		t.Fatalf("CreateComment error: %v", err)
+	}
+
+	a, b := uuid.New(), uuid.New()
+	for i, want := range []bool{true, false} {
+		got, changed, err := m.SetReaction(ctx, c.ID, a, "like", true)
+		if err != nil || changed != want || got.Reactions["like"] != 1 || got.NewsID != c.NewsID {
+			t.Fatalf("SetReaction(a, like) #%d = %+v, %v, %v", i, got, changed, err)
+		}
+	}
+
+	if got, _, err := m.SetReaction(ctx, c.ID, b, "like", true); err != nil || got.Reactions["like"] != 2 {
+		t.Fatalf("SetReaction(b, like) = %+v, %v; want like=2", got, err)
+	}
+
+	got, changed, err := m.SetReaction(ctx, c.ID, b, "wow", false)
+	if err != nil || changed || got.Reactions["like"] != 2 {
+		t.Fatalf("SetReaction(b, wow, off) = %+v, %v, %v; want no-op", got, changed, err)
+	}
+
+	if _, err := m.SetThreadLocked(ctx, c.ID, true); err != nil {
+		t.Fatalf("SetThreadLocked error: %v", err)
+	}
+	if _, _, err := m.SetReaction(ctx, c.ID, b, "wow", true); !errors.Is(err, storage.ErrThreadExpired) {
+		t.Fatalf("SetReaction in locked thread: want ErrThreadExpired, got %v", err)
+	}
+	for _, u := range []uuid.UUID{a, b} {
+		if _, _, err := m.SetReaction(ctx, c.ID, u, "like", false); err != nil {
+			t.Fatalf("SetReaction(like, off) in locked thread error: %v", err)
+		}
+	}
+
+	stored, err := m.CommentByID(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("CommentByID error: %v", err)
+	}
+	if stored.Reactions["like"] != 0 {
+		t.Fatalf("stored reactions = %v, want like=0", stored.Reactions)
+	}
+
+	if _, err := m.DeleteComment(ctx, c.ID); err != nil {
+		t.Fatalf("DeleteComment error: %v", err)
+	}
+	if _, _, err := m.SetReaction(ctx, c.ID, a, "like", false); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("SetReaction on deleted: want ErrNotFound, got %v", err)
+	}
+	if _, _, err := m.SetReaction(ctx, "bad-id", a, "like", true); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("SetReaction(bad id): want ErrNotFound, got %v", err)
+	}
+}
+
+// TestThreadMutes — MuteThread/UnmuteThread идемпотентны, MutedUsers возвращает подмножество.
+func TestThreadMutes(t *testing.T) {
+	cfg := newTestConfig(t)
+	m := mustNewMongo(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	a, b := uuid.New(), uuid.New()
+	for i := 0; i < 2; i++ {
+		if err := m.MuteThread(ctx, a, "t1"); err != nil {
+			t.Fatalf("MuteThread error: %v", err)
+		}
+	}
+
+	muted, err := m.MutedUsers(ctx, "t1", []uuid.UUID{a, b})
+	if err != nil {
+		t.Fatalf("MutedUsers error: %v", err)
+	}
+	if _, ok := muted[a]; !ok || len(muted) != 1 {
+		t.Fatalf("muted = %v, want only %s", muted, a)
+	}
+
+	if err := m.UnmuteThread(ctx, a, "t1"); err != nil {
+		t.Fatalf("UnmuteThread error: %v", err)
+	}
+	if err := m.UnmuteThread(ctx, a, "t1"); err != nil {
+		t.Fatalf("UnmuteThread (repeat) error: %v", err)
+	}
+
+	muted, err = m.MutedUsers(ctx, "t1", []uuid.UUID{a, b})
+	if err != nil || len(muted) != 0 {
+		t.Fatalf("MutedUsers after unmute = %v, %v; want empty", muted, err)
+	}
+}
+
+// TestThreadLock — блокировка ветки: запись в любую её часть -> ErrThreadExpired, чтение доступно;
+// после разблокировки ответы снова принимаются.
+func TestThreadLock(t *testing.T) {
+	cfg := newTestConfig(t)
+	m := mustNewMongo(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	root, err := m.CreateComment(ctx, models.Comment{NewsID: uuid.New(), UserID: uuid.New(), Username: "a", Content: "root"})
+	if err != nil {
+		t.Fatalf("CreateComment(root) error: %v", err)
+	}
+	reply, err := m.CreateComment(ctx, models.Comment{ParentID: root.ID, UserID: uuid.New(), Username: "b", Content: "reply"})
+	if err != nil {
+		t.Fatalf("CreateComment(reply) error: %v", err)
+	}
+
+	// Блокируем через ответ — закрывается вся ветка.
+	locked, err := m.SetThreadLocked(ctx, reply.ID, true)
+	if err != nil {
+		t.Fatalf("SetThreadLocked error: %v", err)
+	}
+	if locked.ID != root.ID || !locked.IsLocked {
+		t.Fatalf("SetThreadLocked returned %+v, want locked root %s", locked, root.ID)
+	}
+
+	for _, parent := range []string{root.ID, reply.ID} {
+		_, err := m.CreateComment(ctx, models.Comment{ParentID: parent, UserID: uuid.New(), Username: "c", Content: "x"})
+		if !errors.Is(err, storage.ErrThreadExpired) {
+			t.Fatalf("reply to %s in locked thread: want ErrThreadExpired, got %v", parent, err)
+		}
+	}
+
+	got, err := m.CommentByID(ctx, reply.ID)
+	if err != nil || !got.IsLocked {
+		t.Fatalf("CommentByID(reply) = %+v, %v; want readable locked reply", got, err)
+	}
+
+	if _, err := m.SetThreadLocked(ctx, root.ID, false); err != nil {
+		t.Fatalf("SetThreadLocked(unlock) error: %v", err)
+	}
+	if _, err := m.CreateComment(ctx, models.Comment{ParentID: reply.ID, UserID: uuid.New(), Username: "c", Content: "x"}); err != nil {
+		t.Fatalf("reply after unlock error: %v", err)
+	}
+
+	if _, err := m.SetThreadLocked(ctx, "65e0a0c9fd2f000000000000", true); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("want ErrNotFound, got %v", err)
+	}
+}
+
+// TestBackfillRootIDs — ответы, сохранённые до появления root_id (глубина 1–3), получают
+// корень ветки: новый ответ на старый попадает в ту же ветку, блокировка закрывает её целиком;
+// ответ без родителя пропускается, повторный запуск ничего не меняет.
+func TestBackfillRootIDs(t *testing.T) {
+	cfg := newTestConfig(t)
+	m := mustNewMongo(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	// Документы в старом формате: без root_id и is_locked.
+	newsID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	ids := make([]primitive.ObjectID, 5)
+	for i := range ids {
+		ids[i] = primitive.NewObjectID()
+	}
+	legacy := func(id primitive.ObjectID, parent string, level int32) bson.D {
+		return bson.D{
+			{Key: "_id", Value: id}, {Key: "news_id", Value: newsID}, {Key: "parent_id", Value: parent},
+			{Key: "user_id", Value: uuid.New()}, {Key: "username", Value: "u"}, {Key: "content", Value: "x"},
+			{Key: "level", Value: level}, {Key: "replies_count", Value: 0}, {Key: "is_deleted", Value: false},
+			{Key: "created_at", Value: now}, {Key: "updated_at", Value: now},
+		}
+	}
+	orphan := primitive.NewObjectID()
+	docs := []any{
+		legacy(ids[0], "", 0),
+		legacy(ids[3], ids[2].Hex(), 3), // порядок вставки не совпадает с глубиной
+		legacy(ids[1], ids[0].Hex(), 1),
+		legacy(ids[2], ids[1].Hex(), 2),
+		legacy(ids[4], orphan.Hex(), 1),
+	}
+	if _, err := m.comments.InsertMany(ctx, docs); err != nil {
+		t.Fatalf("insert legacy comments: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := m.backfillRootIDs(ctx); err != nil {
+			t.Fatalf("backfillRootIDs #%d error: %v", i, err)
+		}
+	}
+
+	root := ids[0].Hex()
+	for _, id := range ids[1:4] {
+		got, err := m.CommentByID(ctx, id.Hex())
+		if err != nil || got.RootID != root {
+			t.Fatalf("CommentByID(%s) = %+v, %v; want root_id %s", id.Hex(), got, err, root)
+		}
+	}
+	if got, _ := m.CommentByID(ctx, ids[4].Hex()); got == nil || got.RootID != "" {
+		t.Fatalf("orphan reply = %+v; want empty root_id", got)
+	}
+
+	reply, err := m.CreateComment(ctx, models.Comment{ParentID: ids[2].Hex(), UserID: uuid.New(), Username: "c", Content: "new"})
+	if err != nil {
+		t.Fatalf("CreateComment(reply to legacy) error: %v", err)
+	}
+	if reply.RootID != root {
+		t.Fatalf("reply to legacy reply: root_id = %q, want %q", reply.RootID, root)
+	}
+
+	if _, err := m.SetThreadLocked(ctx, ids[3].Hex(), true); err != nil {
+		t.Fatalf("SetThreadLocked error: %v", err)
+	}
+	for _, id := range []string{root, ids[1].Hex(), ids[2].Hex(), ids[3].Hex(), reply.ID} {
+		got, err := m.CommentByID(ctx, id)
+		if err != nil || !got.IsLocked {
+			t.Fatalf("CommentByID(%s) = %+v, %v; want locked", id, got, err)
+		}
+	}
+}
+
+// TestThreadPolicy — политики новости: forever снимает срок, expire пересчитывает его
+// от created_at корня (истёкшая ветка читается, но не принимает ответы), locked закрывает
+// новость целиком, default возвращает глобальный TTL.
+func TestThreadPolicy(t *testing.T) {
+	cfg := newTestConfig(t)
+	m := mustNewMongo(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	newsID := uuid.New()
+	if _, err := m.ThreadPolicy(ctx, newsID); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("ThreadPolicy without policy: want ErrNotFound, got %v", err)
+	}
+
+	root, err := m.CreateComment(ctx, models.Comment{NewsID: newsID, UserID: uuid.New(), Username: "a", Content: "root"})
+	if err != nil {
+		t.Fatalf("CreateComment(root) error: %v", err)
+	}
+	reply, err := m.CreateComment(ctx, models.Comment{ParentID: root.ID, UserID: uuid.New(), Username: "b", Content: "reply"})
+	if err != nil {
+		t.Fatalf("CreateComment(reply) error: %v", err)
+	}
+
+	// forever: срок снят и у существующих, и у новых веток.
+	if _, err := m.SetThreadPolicy(ctx, models.ThreadPolicy{NewsID: newsID, Mode: models.ThreadPolicyForever}); err != nil {
+		t.Fatalf("SetThreadPolicy(forever) error: %v", err)
+	}
+	if got, _ := m.CommentByID(ctx, reply.ID); got == nil || !got.ExpiresAt.IsZero() {
+		t.Fatalf("reply.ExpiresAt after forever = %+v, want zero", got)
+	}
+	fresh, err := m.CreateComment(ctx, models.Comment{NewsID: newsID, UserID: uuid.New(), Username: "a", Content: "fresh"})
+	if err != nil || !fresh.ExpiresAt.IsZero() {
+		t.Fatalf("CreateComment under forever = %+v, %v; want zero ExpiresAt", fresh, err)
+	}
+
+	// expire: пересчёт от created_at корня; крошечный TTL — ветка уже истекла.
+	p, err := m.SetThreadPolicy(ctx, models.ThreadPolicy{NewsID: newsID, Mode: models.ThreadPolicyExpire, TTL: time.Millisecond})
+	if err != nil || p.Mode != models.ThreadPolicyExpire {
+		t.Fatalf("SetThreadPolicy(expire) = %+v, %v", p, err)
+	}
+	got, err := m.CommentByID(ctx, reply.ID)
+	if err != nil {
+		t.Fatalf("CommentByID(expired reply) error: %v", err)
+	}
+	if want := root.CreatedAt.Add(time.Millisecond); !got.ExpiresAt.Equal(want) {
+		t.Fatalf("reply.ExpiresAt = %v, want %v", got.ExpiresAt, want)
+	}
+	if _, err := m.CreateComment(ctx, models.Comment{ParentID: reply.ID, UserID: uuid.New(), Username: "c", Content: "x"}); !errors.Is(err, storage.ErrThreadExpired) {
+		t.Fatalf("reply to expired thread: want ErrThreadExpired, got %v", err)
+	}
+	if page, err := m.ListReplies(ctx, root.ID, models.ListParams{PageSize: 10}); err != nil || len(page.Items) != 1 {
+		t.Fatalf("ListReplies(expired thread) = %+v, %v; want 1 item", page, err)
+	}
+
+	// locked: новые корни запрещены.
+	if _, err := m.SetThreadPolicy(ctx, models.ThreadPolicy{NewsID: newsID, Mode: models.ThreadPolicyLocked}); err != nil {
+		t.Fatalf("SetThreadPolicy(locked) error: %v", err)
+	}
+	if _, err := m.CreateComment(ctx, models.Comment{NewsID: newsID, UserID: uuid.New(), Username: "a", Content: "x"}); !errors.Is(err, storage.ErrThreadExpired) {
+		t.Fatalf("root under locked policy: want ErrThreadExpired, got %v", err)
+	}
+	if stored, err := m.ThreadPolicy(ctx, newsID); err != nil || stored.Mode != models.ThreadPolicyLocked {
+		t.Fatalf("ThreadPolicy = %+v, %v; want locked", stored, err)
+	}
+
+	// default: политика удалена, сроки — по глобальному TTL.
+	p, err = m.SetThreadPolicy(ctx, models.ThreadPolicy{NewsID: newsID})
+	if err != nil || p.TTL != cfg.TTL.Thread {
+		t.Fatalf("SetThreadPolicy(default) = %+v, %v", p, err)
+	}
+	if _, err := m.ThreadPolicy(ctx, newsID); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("ThreadPolicy after reset: want ErrNotFound, got %v", err)
+	}
+	if _, err := m.CreateComment(ctx, models.Comment{ParentID: reply.ID, UserID: uuid.New(), Username: "c", Content: "x"}); err != nil {
+		t.Fatalf("reply after reset to default TTL error: %v", err)
+	}
+}
+
+// TestCountsByNews — счётчики: инкремент на создание, однократный декремент на мягкое удаление,
+// last_activity_at, новости без комментариев отсутствуют; backfill строит счётчики по старым данным.
+func TestCountsByNews(t *testing.T) {
+	cfg := newTestConfig(t)
+	m := mustNewMongo(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	newsID, empty := uuid.New(), uuid.New()
+	root, err := m.CreateComment(ctx, models.Comment{NewsID: newsID, UserID: uuid.New(), Username: "a", Content: "root"})
+	if err != nil {
+		t.Fatalf("CreateComment(root) error: %v", err)
+	}
+	reply, err := m.CreateComment(ctx, models.Comment{ParentID: root.ID, UserID: uuid.New(), Username: "b", Content: "reply"})
+	if err != nil {
+		t.Fatalf("CreateComment(reply) error: %v", err)
+	}
+
+	counts, err := m.CountsByNews(ctx, []uuid.UUID{newsID, empty})
+	if err != nil {
+		t.Fatalf("CountsByNews error: %v", err)
+	}
+	got := counts[newsID]
+	if got.Total != 2 || got.Roots != 1 || !got.LastActivityAt.Equal(reply.CreatedAt) {
+		t.Fatalf("counts = %+v, want total=2 roots=1 last=%v", got, reply.CreatedAt)
+	}
+	if _, ok := counts[empty]; ok {
+		t.Fatalf("news without comments must be absent: %+v", counts)
+	}
+
+	// Повторное удаление не уменьшает счётчики второй раз и ничего не возвращает.
+	for i := 0; i < 2; i++ {
+		deleted, err := m.DeleteComment(ctx, root.ID)
+		if err != nil {
+			t.Fatalf("DeleteComment #%d error: %v", i, err)
+		}
+		if (deleted != nil) != (i == 0) {
+			t.Fatalf("DeleteComment #%d = %+v; want comment only on first delete", i, deleted)
+		}
+	}
+	counts, _ = m.CountsByNews(ctx, []uuid.UUID{newsID})
+	if got := counts[newsID]; got.Total != 1 || got.Roots != 0 {
+		t.Fatalf("counts after delete = %+v, want total=1 roots=0", got)
+	}
+
+	if _, err := m.DeleteComment(ctx, "65e0a0c9fd2f000000000000"); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("DeleteComment(missing): want ErrNotFound, got %v", err)
+	}
+
+	// Backfill: при пустой коллекции счётчики пересчитываются по комментариям.
+	if err := m.counters.Drop(ctx); err != nil {
+		t.Fatalf("drop counters: %v", err)
+	}
+	if err := m.backfillCounters(ctx); err != nil {
+		t.Fatalf("backfillCounters error: %v", err)
+	}
+	counts, _ = m.CountsByNews(ctx, []uuid.UUID{newsID})
+	if got := counts[newsID]; got.Total != 1 || got.Roots != 0 || !got.LastActivityAt.Equal(reply.CreatedAt) {
+		t.Fatalf("counts after backfill = %+v, want total=1 roots=0", got)
+	}
+}
+
+// TestListByUserAndSearch — история автора и полнотекстовый поиск:
+// порядок/пагинация, фильтры и видимость мягко удалённых (только при includeDeleted).
+func TestListByUserAndSearch(t *testing.T) {
+	cfg := newTestConfig(t)
+	m := mustNewMongo(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	author, other := uuid.New(), uuid.New()
+	newsA, newsB := uuid.New(), uuid.New()
+
+	c1, err := m.CreateComment(ctx, models.Comment{NewsID: newsA, UserID: author, Username: "a", Content: "первый комментарий про golang"})
+	if err != nil {
+		t.Fatalf("CreateComment(c1) error: %v", err)
+	}
+	c2, err := m.CreateComment(ctx, models.Comment{ParentID: c1.ID, UserID: author, Username: "a", Content: "ответ про rust"})
+	if err != nil {
+		t.Fatalf("CreateComment(c2) error: %v", err)
+	}
+	c3, err := m.CreateComment(ctx, models.Comment{NewsID: newsB, UserID: author, Username: "a", Content: "спам golang спам"})
+	if err != nil {
+		t.Fatalf("CreateComment(c3) error: %v", err)
+	}
+	if _, err := m.CreateComment(ctx, models.Comment{NewsID: newsA, UserID: other, Username: "b", Content: "чужой golang"}); err != nil {
+		t.Fatalf("CreateComment(other) error: %v", err)
+	}
+	if _, err := m.DeleteComment(ctx, c3.ID); err != nil {
+		t.Fatalf("DeleteComment(c3) error: %v", err)
+	}
+
+	// Пагинация по одному: c2, c1 (c3 удалён).
+	var ids []string
+	token := ""
+	for i := 0; i < 3; i++ {
+		page, err := m.ListByUser(ctx, author, false, models.ListParams{PageSize: 1, PageToken: token})
+		if err != nil {
+			t.Fatalf("ListByUser error: %v", err)
+		}
+		for _, it := range page.Items {
+			ids = append(ids, it.ID)
+		}
+		if len(page.Items) == 0 {
+			break
+		}
+		token = page.NextPageToken
+	}
+	if len(ids) != 2 || ids[0] != c2.ID || ids[1] != c1.ID {
+		t.Fatalf("ListByUser ids = %v, want [%s %s]", ids, c2.ID, c1.ID)
+	}
+
+	page, err := m.ListByUser(ctx, author, true, models.ListParams{})
+	if err != nil {
+		t.Fatalf("ListByUser(includeDeleted) error: %v", err)
+	}
+	// Удалённый c3 — «надгробие»: текст стёрт.
+	if len(page.Items) != 3 || page.Items[0].ID != c3.ID || !page.Items[0].IsDeleted || page.Items[0].Content != "" {
+		t.Fatalf("ListByUser(includeDeleted) = %+v", page.Items)
+	}
+
+	if _, err := m.ListByUser(ctx, author, false, models.ListParams{PageToken: "%%%"}); !errors.Is(err, storage.ErrInvalidCursor) {
+		t.Fatalf("ListByUser(bad token): want ErrInvalidCursor, got %v", err)
+	}
+
+	// Общая лента двух авторов: все неудалённые, сначала новые.
+	page, err = m.ListByUsers(ctx, []uuid.UUID{author, other}, models.ListParams{PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListByUsers error: %v", err)
+	}
+	if len(page.Items) != 2 || page.Items[1].ID != c2.ID || page.NextPageToken == "" {
+		t.Fatalf("ListByUsers page 1 = %+v", page.Items)
+	}
+
+	// Курсор остаётся валидным при сужении набора авторов.
+	page, err = m.ListByUsers(ctx, []uuid.UUID{author}, models.ListParams{PageSize: 2, PageToken: page.NextPageToken})
+	if err != nil {
+		t.Fatalf("ListByUsers page 2 error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID != c1.ID {
+		t.Fatalf("ListByUsers page 2 = %+v, want only c1", page.Items)
+	}
+
+	page, err = m.SearchComments(ctx, models.SearchQuery{Text: "golang"}, models.ListParams{})
+	if err != nil {
+		t.Fatalf("SearchComments error: %v", err)
+	}
+	if len(page.Items) != 2 {
+		t.Fatalf("SearchComments(golang) = %d items, want 2", len(page.Items))
+	}
+
+	page, _ = m.SearchComments(ctx, models.SearchQuery{Text: "golang", UserID: author, NewsID: newsA}, models.ListParams{})
+	if len(page.Items) != 1 || page.Items[0].ID != c1.ID {
+		t.Fatalf("SearchComments(filters) = %+v, want only c1", page.Items)
+	}
+
+	// Текст удалённого стёрт — его не находит даже модератор.
+	for _, includeDeleted := range []bool{false, true} {
+		page, _ = m.SearchComments(ctx, models.SearchQuery{Text: "спам", IncludeDeleted: includeDeleted}, models.ListParams{})
+		if len(page.Items) != 0 {
+			t.Fatalf("SearchComments(спам, includeDeleted=%v) = %+v, want none", includeDeleted, page.Items)
+		}
 	}
 }
 
@@ -0,0 +1,247 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateNotifications сохраняет пачку уведомлений.
+//   - CreatedAt = now, ExpiresAt = now + cfg.Notifications.TTL, IsRead = false;
+//   - возвращает записи с проставленными ID.
+func (m *Mongo) CreateNotifications(ctx context.Context, items []models.Notification) ([]models.Notification, error) {
+	const op = "storage/mongo/CreateNotifications"
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	expires := now.Add(m.cfg.Notifications.TTL)
+
+	docs := make([]any, 0, len(items))
+	out := make([]models.Notification, 0, len(items))
+	for _, n := range items {
+		n.ID = ""
+		n.IsRead = false
+		n.CreatedAt = now
+		n.ExpiresAt = expires
+		docs = append(docs, n)
+		out = append(out, n)
+	}
+
+	res, err := m.notifications.InsertMany(ctx, docs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: insert: %w", op, err)
+	}
+
+	for i, id := range res.InsertedIDs {
+		oid, ok := id.(primitive.ObjectID)
+		if !ok {
+			return nil, fmt.Errorf("%s: inserted id type", op)
+		}
+
+		out[i].ID = oid.Hex()
+	}
+
+	return out, nil
+}
+
+// ListNotifications возвращает страницу уведомлений получателя.
+// Сортировка: created_at DESC, _id DESC (курсор — тот же формат, что и у комментариев).
+// При некорректном page_token — storage.ErrInvalidCursor.
+func (m *Mongo) ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, param models.ListParams) (*models.NotificationsPage, error) {
+	const op = "storage/mongo/ListNotifications"
+
+	limit := limitOrDefault(m.cfg, param.PageSize)
+
+	filter := bson.D{{Key: "user_id", Value: userID}}
+	if unreadOnly {
+		filter = append(filter, bson.E{Key: "is_read", Value: false})
+	}
+
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(limit)
+
+	if strings.TrimSpace(param.PageToken) != "" {
+		t, oid, decErr := decodeCursor(param.PageToken)
+		if decErr != nil {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrInvalidCursor)
+		}
+
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "created_at", Value: bson.D{{Key: "$lt", Value: t}}}},
+			bson.D{
+				{Key: "created_at", Value: t},
+				{Key: "_id", Value: bson.D{{Key: "$lt", Value: oid}}},
+			},
+		}})
+	}
+
+	cur, err := m.notifications.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: find: %w", op, err)
+	}
+	defer cur.Close(ctx)
+
+	var items []models.Notification
+	for cur.Next(ctx) {
+		var n models.Notification
+		if err := cur.Decode(&n); err != nil {
+			return nil, fmt.Errorf("%s: decode: %w", op, err)
+		}
+
+		n.CreatedAt = n.CreatedAt.UTC()
+		n.ExpiresAt = n.ExpiresAt.UTC()
+		items = append(items, n)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, fmt.Errorf("%s: cursor: %w", op, err)
+	}
+
+	var next string
+	if n := len(items); n > 0 {
+		last := items[n-1]
+		oid, _ := primitive.ObjectIDFromHex(last.ID)
+		next = encodeCursor(last.CreatedAt, oid)
+	}
+
+	return &models.NotificationsPage{
+		Items:         items,
+		NextPageToken: next,
+	}, nil
+}
+
+// MarkRead помечает уведомления получателя прочитанными.
+// Пустой ids — «прочитать всё». Некорректные ids игнорируются.
+func (m *Mongo) MarkRead(ctx context.Context, userID uuid.UUID, ids []string) (int64, error) {
+	const op = "storage/mongo/MarkRead"
+
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "is_read", Value: false},
+	}
+
+	if len(ids) > 0 {
+		oids := make([]primitive.ObjectID, 0, len(ids))
+		for _, id := range ids {
+			oid, err := primitive.ObjectIDFromHex(strings.TrimSpace(id))
+			if err != nil {
+				continue
+			}
+
+			oids = append(oids, oid)
+		}
+
+		if len(oids) == 0 {
+			return 0, nil
+		}
+
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$in", Value: oids}}})
+	}
+
+	res, err := m.notifications.UpdateMany(ctx, filter, bson.D{
+		{Key: "$set", Value: bson.D{{Key: "is_read", Value: true}}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res.ModifiedCount, nil
+}
+
+// UnreadCount возвращает число непрочитанных уведомлений получателя.
+func (m *Mongo) UnreadCount(ctx context.Context, userID uuid.UUID) (int64, error) {
+	const op = "storage/mongo/UnreadCount"
+
+	n, err := m.notifications.CountDocuments(ctx, bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "is_read", Value: false},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n, nil
+}
+
+// MuteThread отключает уведомления пользователя по ветке (upsert, идемпотентно).
+func (m *Mongo) MuteThread(ctx context.Context, userID uuid.UUID, threadID string) error {
+	const op = "storage/mongo/MuteThread"
+
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "thread_id", Value: threadID},
+	}
+
+	_, err := m.threadMutes.UpdateOne(ctx, filter, bson.D{
+		{Key: "$setOnInsert", Value: bson.D{{Key: "created_at", Value: time.Now().UTC()}}},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UnmuteThread снимает заглушку ветки (идемпотентно).
+func (m *Mongo) UnmuteThread(ctx context.Context, userID uuid.UUID, threadID string) error {
+	const op = "storage/mongo/UnmuteThread"
+
+	_, err := m.threadMutes.DeleteOne(ctx, bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "thread_id", Value: threadID},
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MutedUsers возвращает подмножество userIDs, заглушивших ветку threadID.
+func (m *Mongo) MutedUsers(ctx context.Context, threadID string, userIDs []uuid.UUID) (map[uuid.UUID]struct{}, error) {
+	const op = "storage/mongo/MutedUsers"
+
+	out := make(map[uuid.UUID]struct{})
+	if len(userIDs) == 0 {
+		return out, nil
+	}
+
+	cur, err := m.threadMutes.Find(ctx, bson.D{
+		{Key: "thread_id", Value: threadID},
+		{Key: "user_id", Value: bson.D{{Key: "$in", Value: userIDs}}},
+	}, options.Find().SetProjection(bson.D{{Key: "user_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("%s: find: %w", op, err)
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var doc struct {
+			UserID uuid.UUID `bson:"user_id"`
+		}
+		if err := cur.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("%s: decode: %w", op, err)
+		}
+
+		out[doc.UserID] = struct{}{}
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, fmt.Errorf("%s: cursor: %w", op, err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,115 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// reactionProjection — поля комментария, нужные SetReaction и событию реакции.
+var reactionProjection = bson.D{
+	{Key: "news_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "root_id", Value: 1},
+	{Key: "reactions", Value: 1}, {Key: "is_locked", Value: 1}, {Key: "expires_at", Value: 1},
+}
+
+// SetReaction ставит или снимает реакцию пользователя на комментарий.
+// Кто что поставил, хранится в comment_reactions (уникальная тройка comment_id + user_id + reaction),
+// а счётчики reactions комментария меняются только при фактической вставке/удалении записи —
+// так повторы и гонки одинаковых запросов не сбивают счётчики.
+func (m *Mongo) SetReaction(ctx context.Context, id string, userID uuid.UUID, reaction string, on bool) (*models.Comment, bool, error) {
+	const op = "storage/mongo/SetReaction"
+
+	oid, err := primitive.ObjectIDFromHex(strings.TrimSpace(id))
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+	}
+
+	var comm models.Comment
+	err = m.comments.FindOne(ctx,
+		bson.D{{Key: "_id", Value: oid}, {Key: "is_deleted", Value: bson.D{{Key: "$ne", Value: true}}}},
+		options.FindOne().SetProjection(reactionProjection),
+	).Decode(&comm)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocuments) {
+			return nil, false, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+		}
+
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if on {
+		// Ставить реакции, как и писать, в закрытой ветке нельзя.
+		if comm.IsLocked || comm.IsExpired(time.Now()) {
+			return nil, false, fmt.Errorf("%s: %w", op, storage.ErrThreadExpired)
+		}
+
+		policy, err := m.policyOrDefault(ctx, comm.NewsID)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if policy.Mode == models.ThreadPolicyLocked {
+			return nil, false, fmt.Errorf("%s: %w", op, storage.ErrThreadExpired)
+		}
+	}
+
+	key := bson.D{
+		{Key: "comment_id", Value: oid.Hex()},
+		{Key: "user_id", Value: userID},
+		{Key: "reaction", Value: reaction},
+	}
+
+	var delta int64
+	if on {
+		res, err := m.reactions.UpdateOne(ctx, key, bson.D{
+			{Key: "$setOnInsert", Value: bson.D{{Key: "created_at", Value: time.Now().UTC()}}},
+		}, options.Update().SetUpsert(true))
+		switch {
+		case mongodriver.IsDuplicateKeyError(err):
+			// Параллельный такой же запрос успел вставить запись — реакция уже стоит.
+		case err != nil:
+			return nil, false, fmt.Errorf("%s: %w", op, err)
+		case res.UpsertedCount == 1:
+			delta = 1
+		}
+	} else {
+		res, err := m.reactions.DeleteOne(ctx, key)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if res.DeletedCount == 1 {
+			delta = -1
+		}
+	}
+
+	if delta != 0 {
+		err = m.comments.FindOneAndUpdate(ctx,
+			bson.D{{Key: "_id", Value: oid}},
+			bson.D{{Key: "$inc", Value: bson.D{{Key: "reactions." + reaction, Value: delta}}}},
+			options.FindOneAndUpdate().SetProjection(reactionProjection).SetReturnDocument(options.After),
+		).Decode(&comm)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	comm.ID = oid.Hex()
+	for r, n := range comm.Reactions {
+		if n <= 0 {
+			delete(comm.Reactions, r)
+		}
+	}
+
+	return &comm, delta != 0, nil
+}
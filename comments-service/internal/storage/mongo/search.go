@@ -0,0 +1,139 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListByUser возвращает страницу комментариев автора (корни и ответы).
+// Сортировка: created_at DESC, _id DESC; курсор — как у ListByNews.
+// Мягко удалённые попадают в выдачу только при includeDeleted.
+// При некорректном page_token — storage.ErrInvalidCursor.
+func (m *Mongo) ListByUser(ctx context.Context, userID uuid.UUID, includeDeleted bool, param models.ListParams) (*models.Page, error) {
+	const op = "storage/mongo/ListByUser"
+
+	filter := bson.D{{Key: "user_id", Value: userID}}
+	if !includeDeleted {
+		filter = append(filter, bson.E{Key: "is_deleted", Value: bson.D{{Key: "$ne", Value: true}}})
+	}
+
+	page, err := m.findPageDesc(ctx, filter, param)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return page, nil
+}
+
+// ListByUsers возвращает ленту комментариев набора авторов (user_id $in).
+// Сортировка и курсор — как у ListByUser; мягко удалённые исключаются.
+// При некорректном page_token — storage.ErrInvalidCursor.
+func (m *Mongo) ListByUsers(ctx context.Context, userIDs []uuid.UUID, param models.ListParams) (*models.Page, error) {
+	const op = "storage/mongo/ListByUsers"
+
+	filter := bson.D{
+		{Key: "user_id", Value: bson.D{{Key: "$in", Value: userIDs}}},
+		{Key: "is_deleted", Value: bson.D{{Key: "$ne", Value: true}}},
+	}
+
+	page, err := m.findPageDesc(ctx, filter, param)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return page, nil
+}
+
+// SearchComments — полнотекстовый поиск по тексту комментариев (текстовый индекс content_text).
+// Совпадения упорядочены по свежести (created_at DESC, _id DESC), курсор — как у ListByNews.
+// Текст мягко удалённых стёрт, так что q.IncludeDeleted на совпадения по тексту не влияет.
+// При некорректном page_token — storage.ErrInvalidCursor.
+func (m *Mongo) SearchComments(ctx context.Context, q models.SearchQuery, param models.ListParams) (*models.Page, error) {
+	const op = "storage/mongo/SearchComments"
+
+	filter := bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: q.Text}}}}
+	if q.NewsID != uuid.Nil {
+		filter = append(filter, bson.E{Key: "news_id", Value: q.NewsID})
+	}
+
+	if q.UserID != uuid.Nil {
+		filter = append(filter, bson.E{Key: "user_id", Value: q.UserID})
+	}
+
+	if !q.IncludeDeleted {
+		filter = append(filter, bson.E{Key: "is_deleted", Value: bson.D{{Key: "$ne", Value: true}}})
+	}
+
+	page, err := m.findPageDesc(ctx, filter, param)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return page, nil
+}
+
+// findPageDesc — общая постраничная выборка с сортировкой created_at DESC, _id DESC
+// и курсором "меньше" (см. encodeCursor/decodeCursor).
+func (m *Mongo) findPageDesc(ctx context.Context, filter bson.D, param models.ListParams) (*models.Page, error) {
+	if strings.TrimSpace(param.PageToken) != "" {
+		t, oid, err := decodeCursor(param.PageToken)
+		if err != nil {
+			return nil, storage.ErrInvalidCursor
+		}
+
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "created_at", Value: bson.D{{Key: "$lt", Value: t}}}},
+			bson.D{
+				{Key: "created_at", Value: t},
+				{Key: "_id", Value: bson.D{{Key: "$lt", Value: oid}}},
+			},
+		}})
+	}
+
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(limitOrDefault(m.cfg, param.PageSize))
+
+	cur, err := m.comments.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, fmt.Errorf("find: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	var items []models.Comment
+	for cur.Next(ctx) {
+		var comm models.Comment
+		if err := cur.Decode(&comm); err != nil {
+			return nil, fmt.Errorf("decode: %w", err)
+		}
+
+		comm.CreatedAt = comm.CreatedAt.UTC()
+		comm.UpdatedAt = comm.UpdatedAt.UTC()
+		comm.ExpiresAt = comm.ExpiresAt.UTC()
+		items = append(items, comm)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, fmt.Errorf("cursor: %w", err)
+	}
+
+	var next string
+	if n := len(items); n > 0 {
+		last := items[n-1]
+		oid, _ := primitive.ObjectIDFromHex(last.ID)
+		next = encodeCursor(last.CreatedAt, oid)
+	}
+
+	return &models.Page{
+		Items:         items,
+		NextPageToken: next,
+	}, nil
+}
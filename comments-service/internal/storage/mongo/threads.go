@@ -0,0 +1,283 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// threadExpiry вычисляет expires_at новой ветки, созданной в момент now.
+// Нулевое значение — ветка бессрочная (или закрыта политикой целиком).
+func threadExpiry(p models.ThreadPolicy, now time.Time) time.Time {
+	switch p.Mode {
+	case models.ThreadPolicyForever, models.ThreadPolicyLocked:
+		return time.Time{}
+	default:
+		return now.Add(p.TTL)
+	}
+}
+
+// policyOrDefault возвращает политику новости; если она не задана —
+// ThreadPolicyDefault с TTL из cfg.TTL.Thread.
+func (m *Mongo) policyOrDefault(ctx context.Context, newsID uuid.UUID) (*models.ThreadPolicy, error) {
+	p, err := m.ThreadPolicy(ctx, newsID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return &models.ThreadPolicy{NewsID: newsID, Mode: models.ThreadPolicyDefault, TTL: m.cfg.TTL.Thread}, nil
+		}
+
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// threadFilter — все комментарии ветки с корнем rootID.
+// root_id ответов, созданных до его появления, проставляет backfillRootIDs.
+func threadFilter(rootID primitive.ObjectID) bson.D {
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "_id", Value: rootID}},
+		bson.D{{Key: "root_id", Value: rootID.Hex()}},
+	}}}
+}
+
+// backfillRootIDsBatch — размер пачки обновлений backfillRootIDs.
+const backfillRootIDsBatch = 500
+
+// backfillRootIDs проставляет root_id ответам, созданным до его появления.
+// Ответы обходятся по возрастанию level, поэтому корень родителя к моменту
+// обработки ребёнка уже известен; корень ищется по цепочке parent_id.
+// Ответы без найденного родителя (ветки, удалённые прежним TTL-индексом)
+// пропускаются. Повторный запуск ничего не меняет.
+func (m *Mongo) backfillRootIDs(ctx context.Context) error {
+	filter := bson.D{
+		{Key: "parent_id", Value: bson.D{{Key: "$ne", Value: ""}}},
+		{Key: "root_id", Value: bson.D{{Key: "$in", Value: bson.A{nil, ""}}}},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "level", Value: 1}, {Key: "_id", Value: 1}}).
+		SetProjection(bson.D{{Key: "_id", Value: 1}, {Key: "parent_id", Value: 1}})
+
+	cur, err := m.comments.Find(ctx, filter, opts)
+	if err != nil {
+		return fmt.Errorf("mongo backfill root_id: find: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	roots := make(map[string]string) // id -> корень ветки
+	var batch []mongodriver.WriteModel
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if _, err := m.comments.BulkWrite(ctx, batch, options.BulkWrite().SetOrdered(false)); err != nil {
+			return fmt.Errorf("mongo backfill root_id: write: %w", err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for cur.Next(ctx) {
+		var c models.Comment
+		if err := cur.Decode(&c); err != nil {
+			return fmt.Errorf("mongo backfill root_id: decode: %w", err)
+		}
+
+		root, err := m.rootOf(ctx, c.ParentID, roots)
+		if err != nil {
+			return err
+		}
+		if root == "" {
+			continue
+		}
+		roots[c.ID] = root
+
+		oid, err := primitive.ObjectIDFromHex(c.ID)
+		if err != nil {
+			continue
+		}
+		batch = append(batch, mongodriver.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: oid}}).
+			SetUpdate(bson.D{{Key: "$set", Value: bson.D{{Key: "root_id", Value: root}}}}))
+
+		if len(batch) >= backfillRootIDsBatch {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return fmt.Errorf("mongo backfill root_id: cursor: %w", err)
+	}
+
+	return flush()
+}
+
+// rootOf — корень ветки комментария parentID: из roots (уже обработанные
+// ответы) или по самому документу. "" — родитель не найден или его корень неизвестен.
+func (m *Mongo) rootOf(ctx context.Context, parentID string, roots map[string]string) (string, error) {
+	if root, ok := roots[parentID]; ok {
+		return root, nil
+	}
+
+	oid, err := primitive.ObjectIDFromHex(parentID)
+	if err != nil {
+		return "", nil
+	}
+
+	var parent models.Comment
+	err = m.comments.FindOne(ctx, bson.D{{Key: "_id", Value: oid}},
+		options.FindOne().SetProjection(bson.D{{Key: "parent_id", Value: 1}, {Key: "root_id", Value: 1}}),
+	).Decode(&parent)
+	switch {
+	case errors.Is(err, mongodriver.ErrNoDocuments):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("mongo backfill root_id: find parent: %w", err)
+	}
+
+	root := parent.RootID
+	if parent.ParentID == "" {
+		root = parent.ID
+	}
+	roots[parentID] = root
+
+	return root, nil
+}
+
+// SetThreadLocked выставляет is_locked всем комментариям ветки, которой принадлежит id,
+// и возвращает обновлённый корень. При отсутствии записи — storage.ErrNotFound.
+func (m *Mongo) SetThreadLocked(ctx context.Context, id string, locked bool) (*models.Comment, error) {
+	const op = "storage/mongo/SetThreadLocked"
+
+	comm, err := m.CommentByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rootOID, err := primitive.ObjectIDFromHex(comm.ThreadID())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+	}
+
+	_, err = m.comments.UpdateMany(ctx, threadFilter(rootOID), bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "is_locked", Value: locked},
+			{Key: "updated_at", Value: time.Now().UTC()},
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	root, err := m.CommentByID(ctx, rootOID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return root, nil
+}
+
+// ThreadPolicy возвращает политику веток новости.
+// Если политика не задана — storage.ErrNotFound.
+func (m *Mongo) ThreadPolicy(ctx context.Context, newsID uuid.UUID) (*models.ThreadPolicy, error) {
+	const op = "storage/mongo/ThreadPolicy"
+
+	var out models.ThreadPolicy
+	if err := m.threadPolicies.FindOne(ctx, bson.D{{Key: "news_id", Value: newsID}}).Decode(&out); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocuments) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	out.UpdatedAt = out.UpdatedAt.UTC()
+
+	return &out, nil
+}
+
+// SetThreadPolicy сохраняет политику новости (ThreadPolicyDefault — удаляет её)
+// и пересчитывает expires_at существующих веток:
+//   - Forever — срок снимается со всех комментариев новости;
+//   - Expire/Default — expires_at = created_at корня + TTL (для Default — cfg.TTL.Thread);
+//   - Locked — сроки не меняются, запись запрещается самой политикой.
+func (m *Mongo) SetThreadPolicy(ctx context.Context, policy models.ThreadPolicy) (*models.ThreadPolicy, error) {
+	const op = "storage/mongo/SetThreadPolicy"
+
+	policy.UpdatedAt = time.Now().UTC().Truncate(time.Millisecond)
+
+	if policy.Mode == models.ThreadPolicyDefault {
+		if _, err := m.threadPolicies.DeleteOne(ctx, bson.D{{Key: "news_id", Value: policy.NewsID}}); err != nil {
+			return nil, fmt.Errorf("%s: delete: %w", op, err)
+		}
+
+		policy.TTL = m.cfg.TTL.Thread
+	} else {
+		_, err := m.threadPolicies.ReplaceOne(ctx,
+			bson.D{{Key: "news_id", Value: policy.NewsID}},
+			policy,
+			options.Replace().SetUpsert(true),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: upsert: %w", op, err)
+		}
+	}
+
+	switch policy.Mode {
+	case models.ThreadPolicyForever:
+		_, err := m.comments.UpdateMany(ctx,
+			bson.D{{Key: "news_id", Value: policy.NewsID}},
+			bson.D{{Key: "$unset", Value: bson.D{{Key: "expires_at", Value: ""}}}},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: unset expiry: %w", op, err)
+		}
+	case models.ThreadPolicyExpire, models.ThreadPolicyDefault:
+		if err := m.recomputeExpiry(ctx, policy.NewsID, policy.TTL); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return &policy, nil
+}
+
+// recomputeExpiry проставляет каждой ветке новости expires_at = created_at корня + ttl.
+func (m *Mongo) recomputeExpiry(ctx context.Context, newsID uuid.UUID, ttl time.Duration) error {
+	cur, err := m.comments.Find(ctx,
+		bson.D{{Key: "news_id", Value: newsID}, {Key: "parent_id", Value: ""}},
+		options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}, {Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return fmt.Errorf("find roots: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var root struct {
+			ID        primitive.ObjectID `bson:"_id"`
+			CreatedAt time.Time          `bson:"created_at"`
+		}
+		if err := cur.Decode(&root); err != nil {
+			return fmt.Errorf("decode root: %w", err)
+		}
+
+		_, err := m.comments.UpdateMany(ctx, threadFilter(root.ID), bson.D{
+			{Key: "$set", Value: bson.D{{Key: "expires_at", Value: root.CreatedAt.UTC().Add(ttl)}}},
+		})
+		if err != nil {
+			return fmt.Errorf("update thread %s: %w", root.ID.Hex(), err)
+		}
+	}
+
+	return cur.Err()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
 )
 
@@ -16,25 +17,28 @@ var (
 	ErrConflict = errors.New("conflict")
 	// ErrParentNotFound — указан parent_id, но родитель не найден.
 	ErrParentNotFound = errors.New("parent not found")
-	// ErrThreadExpired — ветка (корень) истекла по TTL, создание/изменение запрещено.
+	// ErrThreadExpired — ветка закрыта для записи (истёк срок, заблокирована модератором
+	// или политикой новости); чтение при этом доступно.
 	ErrThreadExpired = errors.New("thread expired")
 	// ErrMaxDepthExceeded — превышена максимально допустимая глубина.
 	ErrMaxDepthExceeded = errors.New("max depth exceeded")
 )
 
-// Storage описывает операции над комментариями.
-type Storage interface {
+// CommentsStorage описывает операции над комментариями.
+type CommentsStorage interface {
 	// CreateComment создаёт корневой комментарий или ответ.
 	// Входной Comment должен содержать:
-	//   - NewsID, UserID, Username, Content (обязательные);
+	//   - NewsID, UserID, Username, Content (обязательные), ContentHTML (рендер Content);
 	//   - ParentID (опционально, если это ответ).
-	// Игнорируемые/вычисляемые полями хранилища: ID, Level, RepliesCount, IsDeleted, CreatedAt, UpdatedAt, ExpiresAt.
+	// Игнорируемые/вычисляемые полями хранилища: ID, RootID, Level, RepliesCount, IsDeleted, CreatedAt, UpdatedAt, ExpiresAt.
 	// Возможные ошибки: ErrParentNotFound, ErrThreadExpired, ErrMaxDepthExceeded, ErrConflict.
 	CreateComment(ctx context.Context, comment models.Comment) (*models.Comment, error)
 
-	// DeleteComment выполняет мягкое удаление (is_deleted=true) по идентификатору.
+	// DeleteComment выполняет мягкое удаление (is_deleted=true) по идентификатору
+	// и уменьшает счётчики новости. Возвращает удалённый комментарий (ID, NewsID,
+	// ParentID, RootID, IsDeleted); повторное удаление — no-op с nil.
 	// Если запись не найдена — ErrNotFound.
-	DeleteComment(ctx context.Context, id string) error
+	DeleteComment(ctx context.Context, id string) (*models.Comment, error)
 
 	// CommentByID возвращает комментарий по его строковому идентификатору.
 	// Если запись не найдена — ErrNotFound.
@@ -50,6 +54,80 @@ type Storage interface {
 	// При некорректном page_token — ErrInvalidCursor.
 	ListReplies(ctx context.Context, parentID string, p models.ListParams) (*models.Page, error)
 
+	// ListByUser возвращает страницу комментариев автора, сначала новые.
+	// Мягко удалённые включаются только при includeDeleted. При некорректном page_token — ErrInvalidCursor.
+	ListByUser(ctx context.Context, userID uuid.UUID, includeDeleted bool, p models.ListParams) (*models.Page, error)
+
+	// ListByUsers возвращает общую ленту комментариев нескольких авторов, сначала новые
+	// (мягко удалённые исключаются). Курсор — ключ (created_at, _id) последнего элемента,
+	// поэтому он остаётся валидным при изменении набора авторов между страницами.
+	// При некорректном page_token — ErrInvalidCursor.
+	ListByUsers(ctx context.Context, userIDs []uuid.UUID, p models.ListParams) (*models.Page, error)
+
+	// CommentsAfter возвращает до limit комментариев новости (корни и ответы) с _id > afterID
+	// по возрастанию _id — досылка пропущенного живой подпиской; мягко удалённые исключаются.
+	// Некорректный afterID — ErrInvalidCursor.
+	CommentsAfter(ctx context.Context, newsID uuid.UUID, afterID string, limit int64) ([]models.Comment, error)
+
+	// SearchComments выполняет полнотекстовый поиск (текстовый индекс MongoDB), сначала новые.
+	// Мягко удалённые включаются только при q.IncludeDeleted. При некорректном page_token — ErrInvalidCursor.
+	SearchComments(ctx context.Context, q models.SearchQuery, p models.ListParams) (*models.Page, error)
+
+	// CountsByNews возвращает счётчики комментариев для набора новостей (одним запросом).
+	// Новости без комментариев в результат не попадают. Мягко удалённые комментарии не учитываются.
+	CountsByNews(ctx context.Context, newsIDs []uuid.UUID) (map[uuid.UUID]models.NewsCounts, error)
+
+	// SetThreadLocked блокирует/разблокирует ветку, которой принадлежит комментарий id
+	// (флаг is_locked выставляется всем комментариям ветки). Возвращает обновлённый корень.
+	// Если комментарий не найден — ErrNotFound.
+	SetThreadLocked(ctx context.Context, id string, locked bool) (*models.Comment, error)
+
+	// SetReaction ставит (on=true) или снимает реакцию reaction пользователя userID на комментарий id
+	// и возвращает комментарий (ID, NewsID, ParentID, RootID, Reactions) со счётчиками после изменения.
+	// changed=false — реакция уже была в нужном состоянии, счётчики не менялись.
+	// Ошибки: ErrNotFound — комментария нет или он удалён; ErrThreadExpired — постановка
+	// реакции в закрытой для записи ветке (снять реакцию можно всегда).
+	SetReaction(ctx context.Context, id string, userID uuid.UUID, reaction string, on bool) (comment *models.Comment, changed bool, err error)
+
+	// ThreadPolicy возвращает политику веток новости. Если политика не задана — ErrNotFound.
+	ThreadPolicy(ctx context.Context, newsID uuid.UUID) (*models.ThreadPolicy, error)
+
+	// SetThreadPolicy сохраняет политику веток новости (ThreadPolicyDefault — удаляет её)
+	// и пересчитывает expires_at уже существующих веток новости.
+	SetThreadPolicy(ctx context.Context, policy models.ThreadPolicy) (*models.ThreadPolicy, error)
+}
+
+// NotificationsStorage описывает «входящие» пользователей и заглушенные ими ветки.
+type NotificationsStorage interface {
+	// CreateNotifications сохраняет пачку уведомлений и возвращает их с проставленными ID/CreatedAt.
+	CreateNotifications(ctx context.Context, items []models.Notification) ([]models.Notification, error)
+
+	// ListNotifications возвращает страницу уведомлений получателя, сначала новые.
+	// При unreadOnly=true — только непрочитанные. При некорректном page_token — ErrInvalidCursor.
+	ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, p models.ListParams) (*models.NotificationsPage, error)
+
+	// MarkRead помечает прочитанными уведомления получателя с указанными ids,
+	// либо все его уведомления, если ids пуст. Возвращает число изменённых записей.
+	MarkRead(ctx context.Context, userID uuid.UUID, ids []string) (int64, error)
+
+	// UnreadCount возвращает число непрочитанных уведомлений получателя.
+	UnreadCount(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// MuteThread отключает уведомления пользователя по ветке (идемпотентно).
+	MuteThread(ctx context.Context, userID uuid.UUID, threadID string) error
+
+	// UnmuteThread возвращает уведомления пользователя по ветке (идемпотентно).
+	UnmuteThread(ctx context.Context, userID uuid.UUID, threadID string) error
+
+	// MutedUsers возвращает подмножество userIDs, заглушивших ветку threadID.
+	MutedUsers(ctx context.Context, threadID string, userIDs []uuid.UUID) (map[uuid.UUID]struct{}, error)
+}
+
+// Storage — верхнеуровневый интерфейс хранилища comments-service.
+type Storage interface {
+	CommentsStorage
+	NotificationsStorage
+
 	// Close закрывает соединения/ресурсы хранилища.
 	Close(ctx context.Context) error
 }
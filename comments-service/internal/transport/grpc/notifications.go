@@ -0,0 +1,233 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/service"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requireSelf пропускает вызывающего с тем же user_id или с ролью admin:
+// «входящие», заглушения и реакции — только от имени владельца. x-user-id и x-user-roles
+// выставляет шлюз (доверие — как в requireModerator).
+func requireSelf(ctx context.Context, op string, userID uuid.UUID) error {
+	caller := identity.FromIncomingContext(ctx)
+	if caller.HasRole(identity.RoleAdmin) || strings.EqualFold(caller.UserID, userID.String()) {
+		return nil
+	}
+
+	return status.Errorf(codes.PermissionDenied, "%s: user_id does not match the caller", op)
+}
+
+// ListNotifications — страница «входящих» пользователя.
+func (s *CommentsServer) ListNotifications(ctx context.Context, req *commentsv1.ListNotificationsRequest) (*commentsv1.ListNotificationsResponse, error) {
+	const op = "transport/grpc/comments/ListNotifications"
+
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+	if err := requireSelf(ctx, op, userID); err != nil {
+		return nil, err
+	}
+
+	page, err := s.service.ListNotifications(ctx, service.ListNotificationsInput{
+		UserID:     userID,
+		UnreadOnly: req.GetUnreadOnly(),
+		PageSize:   req.GetPageSize(),
+		PageToken:  req.GetPageToken(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument), errors.Is(err, service.ErrInvalidCursor):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	items := make([]*commentsv1.Notification, 0, len(page.Items))
+	for i := range page.Items {
+		items = append(items, toProtoNotification(page.Items[i]))
+	}
+
+	return &commentsv1.ListNotificationsResponse{
+		Notifications: items,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// MarkRead — отметить уведомления прочитанными (по ids или все).
+func (s *CommentsServer) MarkRead(ctx context.Context, req *commentsv1.MarkReadRequest) (*commentsv1.MarkReadResponse, error) {
+	const op = "transport/grpc/comments/MarkRead"
+
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+	if err := requireSelf(ctx, op, userID); err != nil {
+		return nil, err
+	}
+
+	n, err := s.service.MarkRead(ctx, service.MarkReadInput{
+		UserID: userID,
+		IDs:    req.GetIds(),
+		All:    req.GetAll(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return &commentsv1.MarkReadResponse{Updated: n}, nil
+}
+
+// UnreadCount — число непрочитанных уведомлений пользователя.
+func (s *CommentsServer) UnreadCount(ctx context.Context, req *commentsv1.UnreadCountRequest) (*commentsv1.UnreadCountResponse, error) {
+	const op = "transport/grpc/comments/UnreadCount"
+
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+	if err := requireSelf(ctx, op, userID); err != nil {
+		return nil, err
+	}
+
+	n, err := s.service.UnreadCount(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return &commentsv1.UnreadCountResponse{Count: n}, nil
+}
+
+// MuteThread — заглушить уведомления по ветке комментария.
+func (s *CommentsServer) MuteThread(ctx context.Context, req *commentsv1.MuteThreadRequest) (*commentsv1.MuteThreadResponse, error) {
+	const op = "transport/grpc/comments/MuteThread"
+
+	return s.setThreadMuted(ctx, op, req, s.service.MuteThread)
+}
+
+// UnmuteThread — вернуть уведомления по ветке комментария.
+func (s *CommentsServer) UnmuteThread(ctx context.Context, req *commentsv1.MuteThreadRequest) (*commentsv1.MuteThreadResponse, error) {
+	const op = "transport/grpc/comments/UnmuteThread"
+
+	return s.setThreadMuted(ctx, op, req, s.service.UnmuteThread)
+}
+
+// setThreadMuted — общая часть MuteThread/UnmuteThread.
+func (s *CommentsServer) setThreadMuted(
+	ctx context.Context,
+	op string,
+	req *commentsv1.MuteThreadRequest,
+	fn func(context.Context, uuid.UUID, string) (string, error),
+) (*commentsv1.MuteThreadResponse, error) {
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+	if err := requireSelf(ctx, op, userID); err != nil {
+		return nil, err
+	}
+
+	threadID, err := fn(ctx, userID, req.GetCommentId())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		case errors.Is(err, service.ErrNotFound):
+			return nil, status.Errorf(codes.NotFound, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return &commentsv1.MuteThreadResponse{ThreadId: threadID}, nil
+}
+
+// WatchNotifications — server streaming новых уведомлений пользователя.
+// Поток живёт до отмены контекста клиентом; пропущенные при переполнении
+// буфера события клиент дочитывает через ListNotifications.
+func (s *CommentsServer) WatchNotifications(req *commentsv1.WatchNotificationsRequest, stream commentsv1.CommentsService_WatchNotificationsServer) error {
+	const op = "transport/grpc/comments/WatchNotifications"
+
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+
+	ctx := stream.Context()
+	if err := requireSelf(ctx, op, userID); err != nil {
+		return err
+	}
+	events, cancel, err := s.service.SubscribeNotifications(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.Send(toProtoNotification(n)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toProtoNotification — конвертация уведомления в protobuf.
+func toProtoNotification(n models.Notification) *commentsv1.Notification {
+	return &commentsv1.Notification{
+		Id:            n.ID,
+		UserId:        n.UserID.String(),
+		Kind:          toProtoNotificationKind(n.Kind),
+		CommentId:     n.CommentID,
+		NewsId:        n.NewsID.String(),
+		ThreadId:      n.ThreadID,
+		ActorId:       n.ActorID.String(),
+		ActorUsername: n.ActorUsername,
+		IsRead:        n.IsRead,
+		CreatedAt:     n.CreatedAt.UTC().Unix(),
+	}
+}
+
+func toProtoNotificationKind(k models.NotificationKind) commentsv1.NotificationKind {
+	switch k {
+	case models.NotificationKindReply:
+		return commentsv1.NotificationKind_REPLY
+	case models.NotificationKindMention:
+		return commentsv1.NotificationKind_MENTION
+	default:
+		return commentsv1.NotificationKind_NOTIFICATION_KIND_UNSPECIFIED
+	}
+}
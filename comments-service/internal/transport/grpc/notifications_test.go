@@ -0,0 +1,187 @@
+package grpc
+
+// Тесты gRPC-эндпоинтов уведомлений (internal/transport/grpc/notifications.go):
+//  - валидация user_id и маппинг ошибок сервиса -> gRPC codes;
+//  - доступ только владельцу user_id или admin;
+//  - конвертация Notification в protobuf;
+//  - WatchNotifications: завершение потока по отмене контекста клиента.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeNotificationsStream — минимальная реализация серверного стрима для тестов.
+type fakeNotificationsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*commentsv1.Notification
+}
+
+func (f *fakeNotificationsStream) Context() context.Context { return f.ctx }
+
+func (f *fakeNotificationsStream) Send(n *commentsv1.Notification) error {
+	f.sent = append(f.sent, n)
+	return nil
+}
+
+func TestGRPC_ListNotifications_InvalidUUID(t *testing.T) {
+	srv, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := srv.ListNotifications(context.Background(), &commentsv1.ListNotificationsRequest{UserId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPC_ListNotifications_InvalidCursor(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+	ms.EXPECT().ListNotifications(gomock.Any(), uid, false, gomock.Any()).Return(nil, storage.ErrInvalidCursor)
+
+	_, err := srv.ListNotifications(callerCtx(uid.String()), &commentsv1.ListNotificationsRequest{UserId: uid.String(), PageToken: "x"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPC_ListNotifications_OK(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	uid, actor, newsID := uuid.New(), uuid.New(), uuid.New()
+	ts := time.Unix(1710000000, 0).UTC()
+	ms.EXPECT().ListNotifications(gomock.Any(), uid, true, models.ListParams{PageSize: 10}).
+		Return(&models.NotificationsPage{
+			Items: []models.Notification{{
+				ID: "n1", UserID: uid, Kind: models.NotificationKindMention, CommentID: "c1",
+				NewsID: newsID, ThreadID: "t1", ActorID: actor, ActorUsername: "bob", CreatedAt: ts,
+			}},
+			NextPageToken: "next",
+		}, nil)
+
+	resp, err := srv.ListNotifications(callerCtx(uid.String()), &commentsv1.ListNotificationsRequest{
+		UserId: uid.String(), UnreadOnly: true, PageSize: 10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "next", resp.GetNextPageToken())
+	require.Len(t, resp.GetNotifications(), 1)
+
+	n := resp.GetNotifications()[0]
+	require.Equal(t, "n1", n.GetId())
+	require.Equal(t, uid.String(), n.GetUserId())
+	require.Equal(t, commentsv1.NotificationKind_MENTION, n.GetKind())
+	require.Equal(t, "c1", n.GetCommentId())
+	require.Equal(t, newsID.String(), n.GetNewsId())
+	require.Equal(t, "t1", n.GetThreadId())
+	require.Equal(t, actor.String(), n.GetActorId())
+	require.Equal(t, "bob", n.GetActorUsername())
+	require.False(t, n.GetIsRead())
+	require.Equal(t, ts.Unix(), n.GetCreatedAt())
+}
+
+func TestGRPC_MarkRead(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+
+	_, err := srv.MarkRead(callerCtx(uid.String()), &commentsv1.MarkReadRequest{UserId: uid.String()})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	ms.EXPECT().MarkRead(gomock.Any(), uid, nil).Return(int64(4), nil)
+	resp, err := srv.MarkRead(callerCtx(uid.String()), &commentsv1.MarkReadRequest{UserId: uid.String(), All: true})
+	require.NoError(t, err)
+	require.EqualValues(t, 4, resp.GetUpdated())
+}
+
+func TestGRPC_UnreadCount(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+	ms.EXPECT().UnreadCount(gomock.Any(), uid).Return(int64(0), errors.New("db down"))
+
+	_, err := srv.UnreadCount(callerCtx(uid.String()), &commentsv1.UnreadCountRequest{UserId: uid.String()})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGRPC_MuteThread(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+
+	ms.EXPECT().CommentByID(gomock.Any(), "missing").Return(nil, storage.ErrNotFound)
+	_, err := srv.MuteThread(callerCtx(uid.String()), &commentsv1.MuteThreadRequest{UserId: uid.String(), CommentId: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	reply := mustComment(uuid.New(), "p1", "x", "y")
+	reply.RootID = "root-1"
+	ms.EXPECT().CommentByID(gomock.Any(), reply.ID).Return(reply, nil)
+	ms.EXPECT().UnmuteThread(gomock.Any(), uid, "root-1").Return(nil)
+	resp, err := srv.UnmuteThread(callerCtx(uid.String(), identity.RoleAdmin), &commentsv1.MuteThreadRequest{UserId: uid.String(), CommentId: reply.ID})
+	require.NoError(t, err)
+	require.Equal(t, "root-1", resp.GetThreadId())
+}
+
+func TestGRPC_WatchNotifications(t *testing.T) {
+	srv, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	err := srv.WatchNotifications(&commentsv1.WatchNotificationsRequest{UserId: "bad"}, &fakeNotificationsStream{ctx: context.Background()})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	uid := uuid.NewString()
+	ctx, cancel := context.WithCancel(callerCtx(uid))
+	stream := &fakeNotificationsStream{ctx: ctx}
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.WatchNotifications(&commentsv1.WatchNotificationsRequest{UserId: uid}, stream)
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("stream did not stop after context cancel")
+	}
+}
+
+// Чужие «входящие» и заглушения: PermissionDenied до обращения к хранилищу
+// (моки без ожиданий); anonymous и moderator не исключение.
+func TestGRPC_NotificationsRequireSelf(t *testing.T) {
+	srv, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.NewString()
+	for name, ctx := range map[string]context.Context{
+		"anonymous": context.Background(),
+		"other":     callerCtx(uuid.NewString()),
+		"moderator": callerCtx(uuid.NewString(), identity.RoleModerator),
+	} {
+		_, err := srv.ListNotifications(ctx, &commentsv1.ListNotificationsRequest{UserId: uid})
+		require.Equal(t, codes.PermissionDenied, status.Code(err), name)
+		_, err = srv.MarkRead(ctx, &commentsv1.MarkReadRequest{UserId: uid, All: true})
+		require.Equal(t, codes.PermissionDenied, status.Code(err), name)
+		_, err = srv.UnreadCount(ctx, &commentsv1.UnreadCountRequest{UserId: uid})
+		require.Equal(t, codes.PermissionDenied, status.Code(err), name)
+		_, err = srv.MuteThread(ctx, &commentsv1.MuteThreadRequest{UserId: uid, CommentId: "c1"})
+		require.Equal(t, codes.PermissionDenied, status.Code(err), name)
+		err = srv.WatchNotifications(&commentsv1.WatchNotificationsRequest{UserId: uid}, &fakeNotificationsStream{ctx: ctx})
+		require.Equal(t, codes.PermissionDenied, status.Code(err), name)
+	}
+}
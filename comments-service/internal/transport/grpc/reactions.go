@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// React — поставить/снять реакцию; только от имени вызывающего (или admin).
+// Возвращает комментарий с идентификаторами и счётчиками реакций.
+func (s *CommentsServer) React(ctx context.Context, req *commentsv1.ReactRequest) (*commentsv1.ReactResponse, error) {
+	const op = "transport/grpc/comments/React"
+
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+	if err := requireSelf(ctx, op, userID); err != nil {
+		return nil, err
+	}
+
+	comm, err := s.service.React(ctx, service.ReactInput{
+		CommentID: req.GetCommentId(),
+		UserID:    userID,
+		Reaction:  req.GetReaction(),
+		Remove:    req.GetRemove(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		case errors.Is(err, service.ErrNotFound):
+			return nil, status.Errorf(codes.NotFound, "%s: %v", op, err)
+		case errors.Is(err, service.ErrThreadExpired):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return &commentsv1.ReactResponse{Comment: &commentsv1.Comment{
+		Id:        comm.ID,
+		NewsId:    comm.NewsID.String(),
+		ParentId:  comm.ParentID,
+		RootId:    comm.RootID,
+		Reactions: comm.Reactions,
+	}}, nil
+}
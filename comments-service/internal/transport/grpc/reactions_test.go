@@ -0,0 +1,93 @@
+package grpc
+
+// Тесты реакций (internal/transport/grpc/reactions.go и событие в watch.go):
+//  - чужой user_id — PermissionDenied до обращения к хранилищу, admin — можно;
+//  - валидация и маппинг ошибок сервиса -> gRPC codes;
+//  - изменение реакций уходит подписчикам WatchComments без курсора.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPC_React(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	ctx := callerCtx(userID.String())
+	req := &commentsv1.ReactRequest{CommentId: "c1", UserId: userID.String(), Reaction: "like"}
+
+	_, err := srv.React(ctx, &commentsv1.ReactRequest{CommentId: "c1", UserId: "bad", Reaction: "like"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.React(callerCtx(uuid.NewString()), req)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = srv.React(ctx, &commentsv1.ReactRequest{CommentId: "c1", UserId: userID.String(), Reaction: "meh"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	ms.EXPECT().SetReaction(gomock.Any(), "c1", userID, "like", true).Return(nil, false, storage.ErrNotFound)
+	_, err = srv.React(ctx, req)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	ms.EXPECT().SetReaction(gomock.Any(), "c1", userID, "like", true).Return(nil, false, storage.ErrThreadExpired)
+	_, err = srv.React(ctx, req)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	newsID := uuid.New()
+	ms.EXPECT().SetReaction(gomock.Any(), "c1", userID, "like", false).
+		Return(&models.Comment{ID: "c1", NewsID: newsID, Reactions: map[string]int64{"wow": 2}}, true, nil)
+	resp, err := srv.React(callerCtx(uuid.NewString(), identity.RoleAdmin), &commentsv1.ReactRequest{
+		CommentId: "c1", UserId: userID.String(), Reaction: "like", Remove: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "c1", resp.GetComment().GetId())
+	require.Equal(t, newsID.String(), resp.GetComment().GetNewsId())
+	require.Equal(t, map[string]int64{"wow": 2}, resp.GetComment().GetReactions())
+}
+
+func TestGRPC_WatchComments_ReactionEvent(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	newsID, userID := uuid.New(), uuid.New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := newFakeCommentsStream(ctx, false)
+	subscribed := make(chan struct{})
+	ms.EXPECT().CommentsAfter(gomock.Any(), newsID, "650000000000000000000000", gomock.Any()).
+		DoAndReturn(func(context.Context, uuid.UUID, string, int64) ([]models.Comment, error) {
+			close(subscribed)
+			return nil, nil
+		})
+	go func() {
+		_ = srv.WatchComments(&commentsv1.WatchCommentsRequest{NewsId: newsID.String(), LastEventId: "650000000000000000000000"}, stream)
+	}()
+	<-subscribed
+
+	// Реакция на уже отправленный (ID <= last) комментарий тоже доходит.
+	ms.EXPECT().SetReaction(gomock.Any(), "650000000000000000000000", userID, "love", true).
+		Return(&models.Comment{ID: "650000000000000000000000", NewsID: newsID, Reactions: map[string]int64{"love": 1}}, true, nil)
+	_, err := srv.React(callerCtx(userID.String()), &commentsv1.ReactRequest{
+		CommentId: "650000000000000000000000", UserId: userID.String(), Reaction: "love",
+	})
+	require.NoError(t, err)
+
+	ev := stream.next(t)
+	require.Equal(t, commentsv1.CommentEventKind_COMMENT_REACTION, ev.GetKind())
+	require.Empty(t, ev.GetEventId())
+	require.Equal(t, map[string]int64{"love": 1}, ev.GetComment().GetReactions())
+	require.Equal(t, newsID.String(), ev.GetComment().GetNewsId())
+}
@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListByUser — страница комментариев автора, сначала новые.
+// include_deleted — только для moderator/admin.
+func (s *CommentsServer) ListByUser(ctx context.Context, req *commentsv1.ListByUserRequest) (*commentsv1.ListByUserResponse, error) {
+	const op = "transport/grpc/comments/ListByUser"
+
+	if req.GetIncludeDeleted() {
+		if err := requireModerator(ctx, op); err != nil {
+			return nil, err
+		}
+	}
+
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+
+	page, err := s.service.ListByUser(ctx, service.ListByUserInput{
+		UserID:         userID,
+		IncludeDeleted: req.GetIncludeDeleted(),
+		PageSize:       req.GetPageSize(),
+		PageToken:      req.GetPageToken(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument), errors.Is(err, service.ErrInvalidCursor):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return &commentsv1.ListByUserResponse{
+		Comments:      toProtoComments(page.Items),
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// ListByUsers — общая лента комментариев нескольких авторов, сначала новые.
+func (s *CommentsServer) ListByUsers(ctx context.Context, req *commentsv1.ListByUsersRequest) (*commentsv1.ListByUsersResponse, error) {
+	const op = "transport/grpc/comments/ListByUsers"
+
+	ids := make([]uuid.UUID, 0, len(req.GetUserIds()))
+	for _, raw := range req.GetUserIds() {
+		id, err := uuid.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+		}
+		ids = append(ids, id)
+	}
+
+	page, err := s.service.ListByUsers(ctx, service.ListByUsersInput{
+		UserIDs:   ids,
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument), errors.Is(err, service.ErrInvalidCursor):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return &commentsv1.ListByUsersResponse{
+		Comments:      toProtoComments(page.Items),
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// SearchComments — полнотекстовый поиск по комментариям.
+// news_id/user_id необязательны; пустая строка — без фильтра.
+// include_deleted — только для moderator/admin.
+func (s *CommentsServer) SearchComments(ctx context.Context, req *commentsv1.SearchCommentsRequest) (*commentsv1.SearchCommentsResponse, error) {
+	const op = "transport/grpc/comments/SearchComments"
+
+	if req.GetIncludeDeleted() {
+		if err := requireModerator(ctx, op); err != nil {
+			return nil, err
+		}
+	}
+
+	newsID, err := parseOptionalUUID(req.GetNewsId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid news_id: %v", op, err)
+	}
+
+	userID, err := parseOptionalUUID(req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+
+	page, err := s.service.SearchComments(ctx, service.SearchCommentsInput{
+		Query:          req.GetQuery(),
+		NewsID:         newsID,
+		UserID:         userID,
+		IncludeDeleted: req.GetIncludeDeleted(),
+		PageSize:       req.GetPageSize(),
+		PageToken:      req.GetPageToken(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument), errors.Is(err, service.ErrInvalidCursor):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return &commentsv1.SearchCommentsResponse{
+		Comments:      toProtoComments(page.Items),
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// parseOptionalUUID разбирает необязательный идентификатор; пустая строка — uuid.Nil.
+func parseOptionalUUID(s string) (uuid.UUID, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(s)
+}
+
+func toProtoComments(items []models.Comment) []*commentsv1.Comment {
+	out := make([]*commentsv1.Comment, 0, len(items))
+	for i := range items {
+		out = append(out, toProtoComment(items[i]))
+	}
+
+	return out
+}
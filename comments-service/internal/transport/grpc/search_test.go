@@ -0,0 +1,103 @@
+package grpc
+
+// Тесты gRPC-эндпоинтов истории и поиска (internal/transport/grpc/search.go):
+//  - валидация идентификаторов (необязательные фильтры поиска допускают пустую строку);
+//  - маппинг ошибок сервиса -> gRPC codes и проброс страницы;
+//  - include_deleted — только для moderator/admin, остальным PermissionDenied до обращения к хранилищу.
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPC_ListByUser(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := srv.ListByUser(context.Background(), &commentsv1.ListByUserRequest{UserId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	userID := uuid.New()
+	_, err = srv.ListByUser(callerCtx(userID.String()), &commentsv1.ListByUserRequest{UserId: userID.String(), IncludeDeleted: true})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	ms.EXPECT().ListByUser(gomock.Any(), userID, true, gomock.Any()).Return(nil, storage.ErrInvalidCursor)
+	_, err = srv.ListByUser(callerCtx(uuid.NewString(), identity.RoleModerator), &commentsv1.ListByUserRequest{UserId: userID.String(), IncludeDeleted: true, PageToken: "x"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	c := mustComment(uuid.New(), "", "alice", "hi")
+	ms.EXPECT().ListByUser(gomock.Any(), userID, false, models.ListParams{PageSize: 3}).
+		Return(&models.Page{Items: []models.Comment{*c}, NextPageToken: "n"}, nil)
+	resp, err := srv.ListByUser(context.Background(), &commentsv1.ListByUserRequest{UserId: userID.String(), PageSize: 3})
+	require.NoError(t, err)
+	require.Len(t, resp.GetComments(), 1)
+	require.Equal(t, c.ID, resp.GetComments()[0].GetId())
+	require.Equal(t, "n", resp.GetNextPageToken())
+}
+
+func TestGRPC_SearchComments(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := srv.SearchComments(context.Background(), &commentsv1.SearchCommentsRequest{Query: "go", NewsId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.SearchComments(context.Background(), &commentsv1.SearchCommentsRequest{Query: " "})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	userID := uuid.New()
+	ms.EXPECT().SearchComments(gomock.Any(), models.SearchQuery{Text: "go", UserID: userID}, gomock.Any()).
+		Return(&models.Page{}, nil)
+	resp, err := srv.SearchComments(context.Background(), &commentsv1.SearchCommentsRequest{Query: "go", UserId: userID.String()})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetComments())
+
+	ms.EXPECT().SearchComments(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound)
+	_, err = srv.SearchComments(context.Background(), &commentsv1.SearchCommentsRequest{Query: "go"})
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	_, err = srv.SearchComments(context.Background(), &commentsv1.SearchCommentsRequest{Query: "go", IncludeDeleted: true})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	ms.EXPECT().SearchComments(gomock.Any(), models.SearchQuery{Text: "go", IncludeDeleted: true}, gomock.Any()).
+		Return(&models.Page{}, nil)
+	_, err = srv.SearchComments(callerCtx(uuid.NewString(), identity.RoleAdmin), &commentsv1.SearchCommentsRequest{Query: "go", IncludeDeleted: true})
+	require.NoError(t, err)
+}
+
+func TestGRPC_ListByUsers(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := srv.ListByUsers(context.Background(), &commentsv1.ListByUsersRequest{UserIds: []string{"bad"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.ListByUsers(context.Background(), &commentsv1.ListByUsersRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	a := uuid.New()
+	c := mustComment(uuid.New(), "", "alice", "hi")
+	ms.EXPECT().ListByUsers(gomock.Any(), []uuid.UUID{a}, models.ListParams{PageSize: 2, PageToken: "t"}).
+		Return(&models.Page{Items: []models.Comment{*c}, NextPageToken: "n"}, nil)
+	resp, err := srv.ListByUsers(context.Background(), &commentsv1.ListByUsersRequest{
+		UserIds: []string{a.String()}, PageSize: 2, PageToken: "t",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetComments(), 1)
+	require.Equal(t, "n", resp.GetNextPageToken())
+
+	ms.EXPECT().ListByUsers(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+	_, err = srv.ListByUsers(context.Background(), &commentsv1.ListByUsersRequest{UserIds: []string{a.String()}})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
@@ -16,6 +16,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	commentsv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/comments"
@@ -197,20 +198,87 @@ func (s *CommentsServer) ListReplies(ctx context.Context, req *commentsv1.ListRe
 	}, nil
 }
 
+// CountsByNews — счётчики комментариев для набора новостей.
+func (s *CommentsServer) CountsByNews(ctx context.Context, req *commentsv1.CountsByNewsRequest) (*commentsv1.CountsByNewsResponse, error) {
+	const op = "transport/grpc/comments/CountsByNews"
+
+	ids := make([]uuid.UUID, 0, len(req.GetNewsIds()))
+	for _, raw := range req.GetNewsIds() {
+		id, err := uuid.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: invalid news_id %q: %v", op, raw, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	counts, err := s.service.CountsByNews(ctx, ids)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	items := make([]*commentsv1.NewsCounts, 0, len(counts))
+	for _, c := range counts {
+		items = append(items, &commentsv1.NewsCounts{
+			NewsId:         c.NewsID.String(),
+			Total:          c.Total,
+			Roots:          c.Roots,
+			LastActivityAt: unixOrZero(c.LastActivityAt),
+		})
+	}
+
+	return &commentsv1.CountsByNewsResponse{Counts: items}, nil
+}
+
 // toProtoComment — конвертация доменной модели в protobuf.
 func toProtoComment(c models.Comment) *commentsv1.Comment {
 	return &commentsv1.Comment{
 		Id:           c.ID,
 		NewsId:       c.NewsID.String(),
 		ParentId:     c.ParentID,
+		RootId:       c.RootID,
 		UserId:       c.UserID.String(),
 		Username:     c.Username,
 		Content:      c.Content,
+		ContentHtml:  c.ContentHTML,
 		Level:        c.Level,
 		RepliesCount: c.RepliesCount,
 		IsDeleted:    c.IsDeleted,
+		IsLocked:     c.IsLocked,
+		Reactions:    nonZeroReactions(c.Reactions),
 		CreatedAt:    c.CreatedAt.UTC().Unix(),
 		UpdatedAt:    c.UpdatedAt.UTC().Unix(),
-		ExpiresAt:    c.ExpiresAt.UTC().Unix(),
+		ExpiresAt:    unixOrZero(c.ExpiresAt),
+	}
+}
+
+// nonZeroReactions — счётчики реакций без нулевых: снятая последняя реакция
+// оставляет в документе 0, а наружу такие не отдаются.
+func nonZeroReactions(r map[string]int64) map[string]int64 {
+	var out map[string]int64
+	for k, n := range r {
+		if n <= 0 {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]int64, len(r))
+		}
+		out[k] = n
 	}
+
+	return out
+}
+
+// unixOrZero — Unix-время; для нулевого time.Time (бессрочная ветка) — 0.
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UTC().Unix()
 }
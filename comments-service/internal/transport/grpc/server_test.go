@@ -34,7 +34,7 @@ func newServerWithMocks(t *testing.T) (*CommentsServer, *mocks.MockStorage, *gom
 	ms := mocks.NewMockStorage(ctrl)
 
 	// Предполагаем конструктор сервиса аналогично users-service: New(storage, cfg).
-	svc := service.New(ms, config.Config{})
+	svc := service.New(ms, config.Config{Watch: config.WatchConfig{Buffer: 1, Replay: 10}})
 	srv := NewCommentsServer(svc)
 
 	return srv, ms, ctrl
@@ -237,13 +237,13 @@ func TestGRPC_DeleteComment_ErrorMapping(t *testing.T) {
 	defer ctrl.Finish()
 
 	// NotFound
-	ms.EXPECT().DeleteComment(gomock.Any(), "42").Return(storage.ErrNotFound)
+	ms.EXPECT().DeleteComment(gomock.Any(), "42").Return(nil, storage.ErrNotFound)
 	_, err := srv.DeleteComment(context.Background(), &commentsv1.DeleteCommentRequest{Id: "42"})
 	require.Error(t, err)
 	require.Equal(t, codes.NotFound, status.Code(err))
 
 	// Internal
-	ms.EXPECT().DeleteComment(gomock.Any(), "42").Return(errors.New("db down"))
+	ms.EXPECT().DeleteComment(gomock.Any(), "42").Return(nil, errors.New("db down"))
 	_, err = srv.DeleteComment(context.Background(), &commentsv1.DeleteCommentRequest{Id: "42"})
 	require.Error(t, err)
 	require.Equal(t, codes.Internal, status.Code(err))
@@ -254,7 +254,7 @@ func TestGRPC_DeleteComment_OK(t *testing.T) {
 	srv, ms, ctrl := newServerWithMocks(t)
 	defer ctrl.Finish()
 
-	ms.EXPECT().DeleteComment(gomock.Any(), "55").Return(nil)
+	ms.EXPECT().DeleteComment(gomock.Any(), "55").Return(nil, nil)
 	_, err := srv.DeleteComment(context.Background(), &commentsv1.DeleteCommentRequest{Id: "55"})
 	require.NoError(t, err)
 }
@@ -291,6 +291,7 @@ func TestGRPC_CommentByID_OK(t *testing.T) {
 	defer ctrl.Finish()
 
 	want := mustComment(uuid.New(), "", "bob", "hi")
+	want.Reactions = map[string]int64{"like": 2, "sad": 0}
 	ms.EXPECT().CommentByID(gomock.Any(), want.ID).Return(want, nil)
 
 	resp, err := srv.CommentByID(context.Background(), &commentsv1.CommentByIDRequest{Id: want.ID})
@@ -311,6 +312,7 @@ func TestGRPC_CommentByID_OK(t *testing.T) {
 	require.Equal(t, want.CreatedAt.Unix(), c.GetCreatedAt())
 	require.Equal(t, want.UpdatedAt.Unix(), c.GetUpdatedAt())
 	require.Equal(t, want.ExpiresAt.Unix(), c.GetExpiresAt())
+	require.Equal(t, map[string]int64{"like": 2}, c.GetReactions(), "zero counters are omitted")
 }
 
 // Неверный UUID news_id валидируется на уровне транспорта.
@@ -453,3 +455,27 @@ func TestGRPC_ListReplies_OK(t *testing.T) {
 	require.Equal(t, a.ExpiresAt.Unix(), g0.GetExpiresAt())
 	require.Equal(t, b.ExpiresAt.Unix(), g1.GetExpiresAt())
 }
+
+// CountsByNews: невалидный UUID -> InvalidArgument; иначе счётчики в порядке запроса.
+func TestGRPC_CountsByNews(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := srv.CountsByNews(context.Background(), &commentsv1.CountsByNewsRequest{NewsIds: []string{"bad"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	a, b := uuid.New(), uuid.New()
+	last := time.Unix(1710000000, 0).UTC()
+	ms.EXPECT().CountsByNews(gomock.Any(), []uuid.UUID{a, b}).
+		Return(map[uuid.UUID]models.NewsCounts{a: {NewsID: a, Total: 5, Roots: 2, LastActivityAt: last}}, nil)
+
+	resp, err := srv.CountsByNews(context.Background(), &commentsv1.CountsByNewsRequest{NewsIds: []string{a.String(), b.String()}})
+	require.NoError(t, err)
+	require.Len(t, resp.GetCounts(), 2)
+	require.Equal(t, a.String(), resp.GetCounts()[0].GetNewsId())
+	require.EqualValues(t, 5, resp.GetCounts()[0].GetTotal())
+	require.EqualValues(t, 2, resp.GetCounts()[0].GetRoots())
+	require.Equal(t, last.Unix(), resp.GetCounts()[0].GetLastActivityAt())
+	require.Zero(t, resp.GetCounts()[1].GetTotal())
+	require.Zero(t, resp.GetCounts()[1].GetLastActivityAt())
+}
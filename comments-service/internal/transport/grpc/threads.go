@@ -0,0 +1,144 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/service"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requireModerator пропускает только вызывающих с ролью moderator или admin.
+// Роли берутся из x-user-roles, которые кладёт api-gateway (см. pkg/identity):
+// сервис доверяет metadata, потому что к нему ходит только шлюз — сетевая
+// изоляция или mTLS с allowed_sans. Проверка здесь не даёт вызывать модерацию
+// через маршруты шлюза без такой политики, но не защищает от чужого клиента
+// с доступом к сервису.
+func requireModerator(ctx context.Context, op string) error {
+	caller := identity.FromIncomingContext(ctx)
+	if caller.HasRole(identity.RoleModerator) || caller.HasRole(identity.RoleAdmin) {
+		return nil
+	}
+
+	return status.Errorf(codes.PermissionDenied, "%s: moderator role required", op)
+}
+
+// LockThread — блокировка/разблокировка ветки модератором. Возвращает корень ветки.
+func (s *CommentsServer) LockThread(ctx context.Context, req *commentsv1.LockThreadRequest) (*commentsv1.LockThreadResponse, error) {
+	const op = "transport/grpc/comments/LockThread"
+
+	if err := requireModerator(ctx, op); err != nil {
+		return nil, err
+	}
+
+	root, err := s.service.LockThread(ctx, req.GetCommentId(), req.GetLocked())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		case errors.Is(err, service.ErrNotFound):
+			return nil, status.Errorf(codes.NotFound, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return &commentsv1.LockThreadResponse{Comment: toProtoComment(*root)}, nil
+}
+
+// GetThreadPolicy — действующая политика веток новости.
+func (s *CommentsServer) GetThreadPolicy(ctx context.Context, req *commentsv1.GetThreadPolicyRequest) (*commentsv1.GetThreadPolicyResponse, error) {
+	const op = "transport/grpc/comments/GetThreadPolicy"
+
+	newsID, err := uuid.Parse(strings.TrimSpace(req.GetNewsId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid news_id: %v", op, err)
+	}
+
+	policy, err := s.service.GetThreadPolicy(ctx, newsID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return &commentsv1.GetThreadPolicyResponse{Policy: toProtoThreadPolicy(*policy)}, nil
+}
+
+// SetThreadPolicy — установка политики веток новости (moderator/admin).
+func (s *CommentsServer) SetThreadPolicy(ctx context.Context, req *commentsv1.SetThreadPolicyRequest) (*commentsv1.SetThreadPolicyResponse, error) {
+	const op = "transport/grpc/comments/SetThreadPolicy"
+
+	if err := requireModerator(ctx, op); err != nil {
+		return nil, err
+	}
+
+	in := req.GetPolicy()
+	if in == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: empty policy", op)
+	}
+
+	newsID, err := uuid.Parse(strings.TrimSpace(in.GetNewsId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid news_id: %v", op, err)
+	}
+
+	mode, ok := threadPolicyModeFromProto[in.GetMode()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: unknown mode %v", op, in.GetMode())
+	}
+
+	policy, err := s.service.SetThreadPolicy(ctx, service.SetThreadPolicyInput{
+		NewsID:  newsID,
+		Mode:    mode,
+		TTLDays: in.GetTtlDays(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return &commentsv1.SetThreadPolicyResponse{Policy: toProtoThreadPolicy(*policy)}, nil
+}
+
+var threadPolicyModeFromProto = map[commentsv1.ThreadPolicyMode]models.ThreadPolicyMode{
+	commentsv1.ThreadPolicyMode_THREAD_POLICY_MODE_UNSPECIFIED: models.ThreadPolicyDefault,
+	commentsv1.ThreadPolicyMode_FOREVER:                        models.ThreadPolicyForever,
+	commentsv1.ThreadPolicyMode_EXPIRE:                         models.ThreadPolicyExpire,
+	commentsv1.ThreadPolicyMode_LOCKED:                         models.ThreadPolicyLocked,
+}
+
+// toProtoThreadPolicy — конвертация политики в protobuf (TTL — в целых сутках).
+func toProtoThreadPolicy(p models.ThreadPolicy) *commentsv1.ThreadPolicy {
+	mode := commentsv1.ThreadPolicyMode_THREAD_POLICY_MODE_UNSPECIFIED
+	for k, v := range threadPolicyModeFromProto {
+		if v == p.Mode {
+			mode = k
+		}
+	}
+
+	var ttlDays int32
+	if p.Mode == models.ThreadPolicyExpire || p.Mode == models.ThreadPolicyDefault {
+		ttlDays = int32(p.TTL.Hours() / 24)
+	}
+
+	return &commentsv1.ThreadPolicy{
+		NewsId:  p.NewsID.String(),
+		Mode:    mode,
+		TtlDays: ttlDays,
+	}
+}
@@ -0,0 +1,110 @@
+package grpc
+
+// Тесты gRPC-эндпоинтов политик веток (internal/transport/grpc/threads.go):
+//  - валидация news_id/режима и маппинг ошибок сервиса -> gRPC codes;
+//  - конвертация ThreadPolicy и признака блокировки в protobuf;
+//  - блокировка и политика — только для moderator/admin: аноним и обычный пользователь — PermissionDenied.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// callerCtx — входящий контекст с личностью, которую передаёт шлюз.
+func callerCtx(userID string, roles ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(identity.Caller{UserID: userID, Roles: roles}.Pairs()...))
+}
+
+func TestGRPC_ModerationRequiresRole(t *testing.T) {
+	srv, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	newsID := uuid.New().String()
+	for name, ctx := range map[string]context.Context{
+		"anonymous": context.Background(),
+		"user":      callerCtx(uuid.NewString()),
+		"other":     callerCtx(uuid.NewString(), "editor"),
+	} {
+		_, err := srv.LockThread(ctx, &commentsv1.LockThreadRequest{CommentId: "c1", Locked: true})
+		require.Equal(t, codes.PermissionDenied, status.Code(err), name)
+
+		_, err = srv.SetThreadPolicy(ctx, &commentsv1.SetThreadPolicyRequest{
+			Policy: &commentsv1.ThreadPolicy{NewsId: newsID, Mode: commentsv1.ThreadPolicyMode_LOCKED},
+		})
+		require.Equal(t, codes.PermissionDenied, status.Code(err), name)
+	}
+}
+
+func TestGRPC_LockThread(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	ctx := callerCtx(uuid.NewString(), identity.RoleModerator)
+
+	ms.EXPECT().SetThreadLocked(gomock.Any(), "missing", true).Return(nil, storage.ErrNotFound)
+	_, err := srv.LockThread(ctx, &commentsv1.LockThreadRequest{CommentId: "missing", Locked: true})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	root := mustComment(uuid.New(), "", "alice", "hi")
+	root.IsLocked = true
+	root.ExpiresAt = time.Time{}
+	ms.EXPECT().SetThreadLocked(gomock.Any(), root.ID, true).Return(root, nil)
+	resp, err := srv.LockThread(ctx, &commentsv1.LockThreadRequest{CommentId: root.ID, Locked: true})
+	require.NoError(t, err)
+	require.True(t, resp.GetComment().GetIsLocked())
+	require.Zero(t, resp.GetComment().GetExpiresAt())
+}
+
+func TestGRPC_GetThreadPolicy(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := srv.GetThreadPolicy(context.Background(), &commentsv1.GetThreadPolicyRequest{NewsId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	newsID := uuid.New()
+	ms.EXPECT().ThreadPolicy(gomock.Any(), newsID).
+		Return(&models.ThreadPolicy{NewsID: newsID, Mode: models.ThreadPolicyExpire, TTL: 14 * 24 * time.Hour}, nil)
+	resp, err := srv.GetThreadPolicy(context.Background(), &commentsv1.GetThreadPolicyRequest{NewsId: newsID.String()})
+	require.NoError(t, err)
+	require.Equal(t, newsID.String(), resp.GetPolicy().GetNewsId())
+	require.Equal(t, commentsv1.ThreadPolicyMode_EXPIRE, resp.GetPolicy().GetMode())
+	require.EqualValues(t, 14, resp.GetPolicy().GetTtlDays())
+}
+
+func TestGRPC_SetThreadPolicy(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	ctx := callerCtx(uuid.NewString(), identity.RoleAdmin)
+
+	_, err := srv.SetThreadPolicy(ctx, &commentsv1.SetThreadPolicyRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	newsID := uuid.New()
+	_, err = srv.SetThreadPolicy(ctx, &commentsv1.SetThreadPolicyRequest{
+		Policy: &commentsv1.ThreadPolicy{NewsId: newsID.String(), Mode: commentsv1.ThreadPolicyMode_EXPIRE},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	ms.EXPECT().SetThreadPolicy(gomock.Any(), models.ThreadPolicy{NewsID: newsID, Mode: models.ThreadPolicyLocked}).
+		DoAndReturn(func(_ context.Context, p models.ThreadPolicy) (*models.ThreadPolicy, error) { return &p, nil })
+	resp, err := srv.SetThreadPolicy(ctx, &commentsv1.SetThreadPolicyRequest{
+		Policy: &commentsv1.ThreadPolicy{NewsId: newsID.String(), Mode: commentsv1.ThreadPolicyMode_LOCKED},
+	})
+	require.NoError(t, err)
+	require.Equal(t, commentsv1.ThreadPolicyMode_LOCKED, resp.GetPolicy().GetMode())
+	require.Zero(t, resp.GetPolicy().GetTtlDays())
+}
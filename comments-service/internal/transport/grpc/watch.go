@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchComments — server streaming комментариев новости: сначала пропущенные
+// после last_event_id (или событие reset_required), затем создания, удаления и реакции по мере поступления.
+// Поток живёт до отмены контекста клиентом.
+// Маппинг ошибок:
+//   - неверный news_id -> InvalidArgument;
+//   - клиент не успевает читать (переполнен буфер подписки) -> ResourceExhausted:
+//     переподключение с последним event_id досылает пропущенное;
+//   - прочее -> Internal.
+func (s *CommentsServer) WatchComments(req *commentsv1.WatchCommentsRequest, stream commentsv1.CommentsService_WatchCommentsServer) error {
+	const op = "transport/grpc/comments/WatchComments"
+
+	newsID, err := uuid.Parse(strings.TrimSpace(req.GetNewsId()))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%s: invalid news_id: %v", op, err)
+	}
+
+	ctx := stream.Context()
+	w, err := s.service.WatchComments(ctx, newsID, req.GetLastEventId())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+	defer w.Live.Close()
+
+	if w.Reset {
+		if err := stream.Send(&commentsv1.CommentEvent{ResetRequired: true}); err != nil {
+			return err
+		}
+	}
+
+	var last string
+	for _, c := range w.Replay {
+		if err := stream.Send(toProtoCommentEvent(c)); err != nil {
+			return err
+		}
+		last = c.ID
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.Live.C:
+			if !ok {
+				if w.Live.Lagged() {
+					return status.Errorf(codes.ResourceExhausted, "%s: client is too slow, resume with last event_id", op)
+				}
+				return nil
+			}
+
+			switch ev.Kind {
+			case models.CommentEventDeleted:
+				if err := stream.Send(toProtoDeletedEvent(ev.Comment)); err != nil {
+					return err
+				}
+				continue
+			case models.CommentEventReaction:
+				if err := stream.Send(toProtoReactionEvent(ev.Comment)); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// Уже отправлено из Replay.
+			if ev.Comment.ID <= last {
+				continue
+			}
+
+			if err := stream.Send(toProtoCommentEvent(ev.Comment)); err != nil {
+				return err
+			}
+			last = ev.Comment.ID
+		}
+	}
+}
+
+// toProtoCommentEvent — созданный комментарий с курсором возобновления.
+func toProtoCommentEvent(c models.Comment) *commentsv1.CommentEvent {
+	return &commentsv1.CommentEvent{
+		EventId: c.ID,
+		Comment: toProtoComment(c),
+		Kind:    commentsv1.CommentEventKind_COMMENT_CREATED,
+	}
+}
+
+// toProtoDeletedEvent — удаление: без курсора (удаления не досылаются),
+// в comment только идентификаторы.
+func toProtoDeletedEvent(c models.Comment) *commentsv1.CommentEvent {
+	return &commentsv1.CommentEvent{
+		Comment: &commentsv1.Comment{
+			Id:        c.ID,
+			NewsId:    c.NewsID.String(),
+			ParentId:  c.ParentID,
+			RootId:    c.RootID,
+			IsDeleted: true,
+		},
+		Kind: commentsv1.CommentEventKind_COMMENT_DELETED,
+	}
+}
+
+// toProtoReactionEvent — изменение реакций: без курсора (не досылается),
+// в comment только идентификаторы и счётчики реакций.
+func toProtoReactionEvent(c models.Comment) *commentsv1.CommentEvent {
+	return &commentsv1.CommentEvent{
+		Comment: &commentsv1.Comment{
+			Id:        c.ID,
+			NewsId:    c.NewsID.String(),
+			ParentId:  c.ParentID,
+			RootId:    c.RootID,
+			Reactions: c.Reactions,
+		},
+		Kind: commentsv1.CommentEventKind_COMMENT_REACTION,
+	}
+}
@@ -0,0 +1,227 @@
+package grpc
+
+// Тесты живой подписки на комментарии (internal/transport/grpc/watch.go):
+//  - валидация news_id;
+//  - досылка пропущенного, затем новые комментарии без повторов;
+//  - reset_required при битом курсоре;
+//  - удаление уходит без курсора, даже если комментарий уже был отправлен;
+//  - медленный клиент отключается с ResourceExhausted, не блокируя CreateComment.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	commentsv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/comments"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/comments-service/internal/storage"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCommentsStream — серверный стрим WatchComments: события уходят в канал sent;
+// пока gate не закрыт, Send блокируется (медленный клиент).
+type fakeCommentsStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	gate    chan struct{}
+	entered chan struct{}
+	sent    chan *commentsv1.CommentEvent
+}
+
+func newFakeCommentsStream(ctx context.Context, slow bool) *fakeCommentsStream {
+	f := &fakeCommentsStream{
+		ctx:     ctx,
+		gate:    make(chan struct{}),
+		entered: make(chan struct{}, 1),
+		sent:    make(chan *commentsv1.CommentEvent, 16),
+	}
+	if !slow {
+		close(f.gate)
+	}
+	return f
+}
+
+func (f *fakeCommentsStream) Context() context.Context { return f.ctx }
+
+func (f *fakeCommentsStream) Send(e *commentsv1.CommentEvent) error {
+	select {
+	case f.entered <- struct{}{}:
+	default:
+	}
+	<-f.gate
+	f.sent <- e
+	return nil
+}
+
+func (f *fakeCommentsStream) next(t *testing.T) *commentsv1.CommentEvent {
+	t.Helper()
+	select {
+	case e := <-f.sent:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event")
+		return nil
+	}
+}
+
+func TestGRPC_WatchComments_InvalidNewsID(t *testing.T) {
+	srv, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	err := srv.WatchComments(&commentsv1.WatchCommentsRequest{NewsId: "bad"}, newFakeCommentsStream(context.Background(), false))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPC_WatchComments_ReplayThenLive(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	newsID := uuid.New()
+	at := func(id string) models.Comment {
+		return models.Comment{ID: id, NewsID: newsID, UserID: uuid.New(), Username: "u", Content: "c"}
+	}
+	c1, c2, c3 := at("650000000000000000000001"), at("650000000000000000000002"), at("650000000000000000000003")
+
+	ms.EXPECT().CommentsAfter(gomock.Any(), newsID, "650000000000000000000000", gomock.Any()).
+		Return([]models.Comment{c1, c2}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := newFakeCommentsStream(ctx, false)
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.WatchComments(&commentsv1.WatchCommentsRequest{
+			NewsId:      newsID.String(),
+			LastEventId: "650000000000000000000000",
+		}, stream)
+	}()
+
+	require.Equal(t, c1.ID, stream.next(t).GetEventId())
+	require.Equal(t, c2.ID, stream.next(t).GetEventId())
+
+	// c2 уже отправлен из replay — повторно не уходит.
+	for _, c := range []models.Comment{c2, c3} {
+		ms.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(&c, nil)
+		_, err := srv.CreateComment(context.Background(), &commentsv1.CreateCommentRequest{
+			NewsId: newsID.String(), UserId: c.UserID.String(), Username: "u", Content: "c",
+		})
+		require.NoError(t, err)
+	}
+
+	ev := stream.next(t)
+	require.Equal(t, c3.ID, ev.GetEventId())
+	require.Equal(t, c3.ID, ev.GetComment().GetId())
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("stream did not stop after context cancel")
+	}
+}
+
+func TestGRPC_WatchComments_Reset(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	newsID := uuid.New()
+	ms.EXPECT().CommentsAfter(gomock.Any(), newsID, "bad", gomock.Any()).Return(nil, storage.ErrInvalidCursor)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := newFakeCommentsStream(ctx, false)
+	go func() {
+		_ = srv.WatchComments(&commentsv1.WatchCommentsRequest{NewsId: newsID.String(), LastEventId: "bad"}, stream)
+	}()
+
+	ev := stream.next(t)
+	require.True(t, ev.GetResetRequired())
+	require.Empty(t, ev.GetEventId())
+}
+
+func TestGRPC_WatchComments_DeleteEvent(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	newsID := uuid.New()
+	c1 := models.Comment{ID: "650000000000000000000001", NewsID: newsID, UserID: uuid.New(), Username: "u", Content: "c"}
+	ms.EXPECT().CommentsAfter(gomock.Any(), newsID, "650000000000000000000000", gomock.Any()).
+		Return([]models.Comment{c1}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := newFakeCommentsStream(ctx, false)
+	go func() {
+		_ = srv.WatchComments(&commentsv1.WatchCommentsRequest{
+			NewsId:      newsID.String(),
+			LastEventId: "650000000000000000000000",
+		}, stream)
+	}()
+
+	ev := stream.next(t)
+	require.Equal(t, commentsv1.CommentEventKind_COMMENT_CREATED, ev.GetKind())
+
+	// Подписка оформлена до досылки — удаление c1 (ID <= last) всё равно доходит.
+	ms.EXPECT().DeleteComment(gomock.Any(), c1.ID).
+		Return(&models.Comment{ID: c1.ID, NewsID: newsID, IsDeleted: true}, nil)
+	_, err := srv.DeleteComment(context.Background(), &commentsv1.DeleteCommentRequest{Id: c1.ID})
+	require.NoError(t, err)
+
+	ev = stream.next(t)
+	require.Equal(t, commentsv1.CommentEventKind_COMMENT_DELETED, ev.GetKind())
+	require.Empty(t, ev.GetEventId())
+	require.Equal(t, c1.ID, ev.GetComment().GetId())
+	require.True(t, ev.GetComment().GetIsDeleted())
+}
+
+func TestGRPC_WatchComments_SlowClientEvicted(t *testing.T) {
+	srv, ms, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	newsID := uuid.New()
+	stream := newFakeCommentsStream(context.Background(), true)
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.WatchComments(&commentsv1.WatchCommentsRequest{NewsId: newsID.String()}, stream)
+	}()
+
+	ms.EXPECT().CreateComment(gomock.Any(), gomock.Any()).
+		Return(&models.Comment{ID: "c", NewsID: newsID}, nil).AnyTimes()
+	create := func() {
+		_, err := srv.CreateComment(context.Background(), &commentsv1.CreateCommentRequest{
+			NewsId: newsID.String(), UserId: uuid.NewString(), Username: "u", Content: "c",
+		})
+		require.NoError(t, err)
+	}
+
+	// Подписка оформляется асинхронно: публикуем, пока поток не застрянет в Send.
+	deadline := time.After(2 * time.Second)
+	for waiting := true; waiting; {
+		create()
+		select {
+		case <-stream.entered:
+			waiting = false
+		case <-time.After(5 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("stream did not receive live event")
+		}
+	}
+
+	// Буфер подписки (1) заполняется, следующее событие отключает подписчика;
+	// CreateComment при этом не блокируется.
+	create()
+	create()
+	close(stream.gate)
+
+	select {
+	case err := <-done:
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	case <-time.After(time.Second):
+		t.Fatal("slow client was not evicted")
+	}
+}
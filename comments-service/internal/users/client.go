@@ -0,0 +1,65 @@
+// users — gRPC-клиент users-service для comments-service.
+// Используется только для разрешения @username в user_id (уведомления об упоминаниях).
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/comments-service/gen/go/users"
+
+	"google.golang.org/grpc"
+)
+
+// Client — тонкая обёртка над usersv1.UsersServiceClient.
+// Реализует service.UserResolver.
+type Client struct {
+	conn *grpc.ClientConn
+	api  usersv1.UsersServiceClient
+}
+
+// New создаёт клиент users-service по адресу addr (соединение ленивое).
+// creds — транспорт (TLS/mTLS или insecure, см. tlsconfig.Reloader.DialOption).
+func New(addr string, creds grpc.DialOption) (*Client, error) {
+	const op = "users/New"
+
+	if addr == "" {
+		return nil, fmt.Errorf("%s: empty addr", op)
+	}
+
+	conn, err := grpc.NewClient(addr, creds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Client{conn: conn, api: usersv1.NewUsersServiceClient(conn)}, nil
+}
+
+// ResolveUsernames разрешает username в user_id (ключи — username в нижнем регистре).
+// Некорректные user_id в ответе пропускаются.
+func (c *Client) ResolveUsernames(ctx context.Context, usernames []string) (map[string]uuid.UUID, error) {
+	const op = "users/ResolveUsernames"
+
+	resp, err := c.api.ResolveUsernames(ctx, &usersv1.ResolveUsernamesRequest{Usernames: usernames})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	out := make(map[string]uuid.UUID, len(resp.GetUserIds()))
+	for name, raw := range resp.GetUserIds() {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			continue
+		}
+
+		out[name] = id
+	}
+
+	return out, nil
+}
+
+// Close закрывает соединение.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -9,9 +9,370 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 	models "github.com/pribylovaa/go-news-aggregator/comments-service/internal/models"
 )
 
+// MockCommentsStorage is a mock of CommentsStorage interface.
+type MockCommentsStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentsStorageMockRecorder
+}
+
+// MockCommentsStorageMockRecorder is the mock recorder for MockCommentsStorage.
+type MockCommentsStorageMockRecorder struct {
+	mock *MockCommentsStorage
+}
+
+// NewMockCommentsStorage creates a new mock instance.
+func NewMockCommentsStorage(ctrl *gomock.Controller) *MockCommentsStorage {
+	mock := &MockCommentsStorage{ctrl: ctrl}
+	mock.recorder = &MockCommentsStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentsStorage) EXPECT() *MockCommentsStorageMockRecorder {
+	return m.recorder
+}
+
+// CommentByID mocks base method.
+func (m *MockCommentsStorage) CommentByID(ctx context.Context, id string) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommentByID", ctx, id)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommentByID indicates an expected call of CommentByID.
+func (mr *MockCommentsStorageMockRecorder) CommentByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommentByID", reflect.TypeOf((*MockCommentsStorage)(nil).CommentByID), ctx, id)
+}
+
+// CommentsAfter mocks base method.
+func (m *MockCommentsStorage) CommentsAfter(ctx context.Context, newsID uuid.UUID, afterID string, limit int64) ([]models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommentsAfter", ctx, newsID, afterID, limit)
+	ret0, _ := ret[0].([]models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommentsAfter indicates an expected call of CommentsAfter.
+func (mr *MockCommentsStorageMockRecorder) CommentsAfter(ctx, newsID, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommentsAfter", reflect.TypeOf((*MockCommentsStorage)(nil).CommentsAfter), ctx, newsID, afterID, limit)
+}
+
+// CountsByNews mocks base method.
+func (m *MockCommentsStorage) CountsByNews(ctx context.Context, newsIDs []uuid.UUID) (map[uuid.UUID]models.NewsCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountsByNews", ctx, newsIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]models.NewsCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountsByNews indicates an expected call of CountsByNews.
+func (mr *MockCommentsStorageMockRecorder) CountsByNews(ctx, newsIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountsByNews", reflect.TypeOf((*MockCommentsStorage)(nil).CountsByNews), ctx, newsIDs)
+}
+
+// CreateComment mocks base method.
+func (m *MockCommentsStorage) CreateComment(ctx context.Context, comment models.Comment) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ctx, comment)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentsStorageMockRecorder) CreateComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockCommentsStorage)(nil).CreateComment), ctx, comment)
+}
+
+// DeleteComment mocks base method.
+func (m *MockCommentsStorage) DeleteComment(ctx context.Context, id string) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, id)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentsStorageMockRecorder) DeleteComment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentsStorage)(nil).DeleteComment), ctx, id)
+}
+
+// ListByNews mocks base method.
+func (m *MockCommentsStorage) ListByNews(ctx context.Context, newsID string, p models.ListParams) (*models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByNews", ctx, newsID, p)
+	ret0, _ := ret[0].(*models.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByNews indicates an expected call of ListByNews.
+func (mr *MockCommentsStorageMockRecorder) ListByNews(ctx, newsID, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByNews", reflect.TypeOf((*MockCommentsStorage)(nil).ListByNews), ctx, newsID, p)
+}
+
+// ListByUser mocks base method.
+func (m *MockCommentsStorage) ListByUser(ctx context.Context, userID uuid.UUID, includeDeleted bool, p models.ListParams) (*models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID, includeDeleted, p)
+	ret0, _ := ret[0].(*models.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockCommentsStorageMockRecorder) ListByUser(ctx, userID, includeDeleted, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockCommentsStorage)(nil).ListByUser), ctx, userID, includeDeleted, p)
+}
+
+// ListByUsers mocks base method.
+func (m *MockCommentsStorage) ListByUsers(ctx context.Context, userIDs []uuid.UUID, p models.ListParams) (*models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUsers", ctx, userIDs, p)
+	ret0, _ := ret[0].(*models.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUsers indicates an expected call of ListByUsers.
+func (mr *MockCommentsStorageMockRecorder) ListByUsers(ctx, userIDs, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUsers", reflect.TypeOf((*MockCommentsStorage)(nil).ListByUsers), ctx, userIDs, p)
+}
+
+// ListReplies mocks base method.
+func (m *MockCommentsStorage) ListReplies(ctx context.Context, parentID string, p models.ListParams) (*models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReplies", ctx, parentID, p)
+	ret0, _ := ret[0].(*models.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReplies indicates an expected call of ListReplies.
+func (mr *MockCommentsStorageMockRecorder) ListReplies(ctx, parentID, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplies", reflect.TypeOf((*MockCommentsStorage)(nil).ListReplies), ctx, parentID, p)
+}
+
+// SearchComments mocks base method.
+func (m *MockCommentsStorage) SearchComments(ctx context.Context, q models.SearchQuery, p models.ListParams) (*models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchComments", ctx, q, p)
+	ret0, _ := ret[0].(*models.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchComments indicates an expected call of SearchComments.
+func (mr *MockCommentsStorageMockRecorder) SearchComments(ctx, q, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchComments", reflect.TypeOf((*MockCommentsStorage)(nil).SearchComments), ctx, q, p)
+}
+
+// SetReaction mocks base method.
+func (m *MockCommentsStorage) SetReaction(ctx context.Context, id string, userID uuid.UUID, reaction string, on bool) (*models.Comment, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReaction", ctx, id, userID, reaction, on)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetReaction indicates an expected call of SetReaction.
+func (mr *MockCommentsStorageMockRecorder) SetReaction(ctx, id, userID, reaction, on interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReaction", reflect.TypeOf((*MockCommentsStorage)(nil).SetReaction), ctx, id, userID, reaction, on)
+}
+
+// SetThreadLocked mocks base method.
+func (m *MockCommentsStorage) SetThreadLocked(ctx context.Context, id string, locked bool) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetThreadLocked", ctx, id, locked)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetThreadLocked indicates an expected call of SetThreadLocked.
+func (mr *MockCommentsStorageMockRecorder) SetThreadLocked(ctx, id, locked interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetThreadLocked", reflect.TypeOf((*MockCommentsStorage)(nil).SetThreadLocked), ctx, id, locked)
+}
+
+// SetThreadPolicy mocks base method.
+func (m *MockCommentsStorage) SetThreadPolicy(ctx context.Context, policy models.ThreadPolicy) (*models.ThreadPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetThreadPolicy", ctx, policy)
+	ret0, _ := ret[0].(*models.ThreadPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetThreadPolicy indicates an expected call of SetThreadPolicy.
+func (mr *MockCommentsStorageMockRecorder) SetThreadPolicy(ctx, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetThreadPolicy", reflect.TypeOf((*MockCommentsStorage)(nil).SetThreadPolicy), ctx, policy)
+}
+
+// ThreadPolicy mocks base method.
+func (m *MockCommentsStorage) ThreadPolicy(ctx context.Context, newsID uuid.UUID) (*models.ThreadPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ThreadPolicy", ctx, newsID)
+	ret0, _ := ret[0].(*models.ThreadPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ThreadPolicy indicates an expected call of ThreadPolicy.
+func (mr *MockCommentsStorageMockRecorder) ThreadPolicy(ctx, newsID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ThreadPolicy", reflect.TypeOf((*MockCommentsStorage)(nil).ThreadPolicy), ctx, newsID)
+}
+
+// MockNotificationsStorage is a mock of NotificationsStorage interface.
+type MockNotificationsStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationsStorageMockRecorder
+}
+
+// MockNotificationsStorageMockRecorder is the mock recorder for MockNotificationsStorage.
+type MockNotificationsStorageMockRecorder struct {
+	mock *MockNotificationsStorage
+}
+
+// NewMockNotificationsStorage creates a new mock instance.
+func NewMockNotificationsStorage(ctrl *gomock.Controller) *MockNotificationsStorage {
+	mock := &MockNotificationsStorage{ctrl: ctrl}
+	mock.recorder = &MockNotificationsStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationsStorage) EXPECT() *MockNotificationsStorageMockRecorder {
+	return m.recorder
+}
+
+// CreateNotifications mocks base method.
+func (m *MockNotificationsStorage) CreateNotifications(ctx context.Context, items []models.Notification) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotifications", ctx, items)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotifications indicates an expected call of CreateNotifications.
+func (mr *MockNotificationsStorageMockRecorder) CreateNotifications(ctx, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotifications", reflect.TypeOf((*MockNotificationsStorage)(nil).CreateNotifications), ctx, items)
+}
+
+// ListNotifications mocks base method.
+func (m *MockNotificationsStorage) ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, p models.ListParams) (*models.NotificationsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, userID, unreadOnly, p)
+	ret0, _ := ret[0].(*models.NotificationsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockNotificationsStorageMockRecorder) ListNotifications(ctx, userID, unreadOnly, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockNotificationsStorage)(nil).ListNotifications), ctx, userID, unreadOnly, p)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationsStorage) MarkRead(ctx context.Context, userID uuid.UUID, ids []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userID, ids)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationsStorageMockRecorder) MarkRead(ctx, userID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationsStorage)(nil).MarkRead), ctx, userID, ids)
+}
+
+// MuteThread mocks base method.
+func (m *MockNotificationsStorage) MuteThread(ctx context.Context, userID uuid.UUID, threadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteThread", ctx, userID, threadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteThread indicates an expected call of MuteThread.
+func (mr *MockNotificationsStorageMockRecorder) MuteThread(ctx, userID, threadID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteThread", reflect.TypeOf((*MockNotificationsStorage)(nil).MuteThread), ctx, userID, threadID)
+}
+
+// MutedUsers mocks base method.
+func (m *MockNotificationsStorage) MutedUsers(ctx context.Context, threadID string, userIDs []uuid.UUID) (map[uuid.UUID]struct{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MutedUsers", ctx, threadID, userIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]struct{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MutedUsers indicates an expected call of MutedUsers.
+func (mr *MockNotificationsStorageMockRecorder) MutedUsers(ctx, threadID, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MutedUsers", reflect.TypeOf((*MockNotificationsStorage)(nil).MutedUsers), ctx, threadID, userIDs)
+}
+
+// UnmuteThread mocks base method.
+func (m *MockNotificationsStorage) UnmuteThread(ctx context.Context, userID uuid.UUID, threadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmuteThread", ctx, userID, threadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmuteThread indicates an expected call of UnmuteThread.
+func (mr *MockNotificationsStorageMockRecorder) UnmuteThread(ctx, userID, threadID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteThread", reflect.TypeOf((*MockNotificationsStorage)(nil).UnmuteThread), ctx, userID, threadID)
+}
+
+// UnreadCount mocks base method.
+func (m *MockNotificationsStorage) UnreadCount(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnreadCount", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnreadCount indicates an expected call of UnreadCount.
+func (mr *MockNotificationsStorageMockRecorder) UnreadCount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnreadCount", reflect.TypeOf((*MockNotificationsStorage)(nil).UnreadCount), ctx, userID)
+}
+
 // MockStorage is a mock of Storage interface.
 type MockStorage struct {
 	ctrl     *gomock.Controller
@@ -64,6 +425,36 @@ func (mr *MockStorageMockRecorder) CommentByID(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommentByID", reflect.TypeOf((*MockStorage)(nil).CommentByID), ctx, id)
 }
 
+// CommentsAfter mocks base method.
+func (m *MockStorage) CommentsAfter(ctx context.Context, newsID uuid.UUID, afterID string, limit int64) ([]models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommentsAfter", ctx, newsID, afterID, limit)
+	ret0, _ := ret[0].([]models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommentsAfter indicates an expected call of CommentsAfter.
+func (mr *MockStorageMockRecorder) CommentsAfter(ctx, newsID, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommentsAfter", reflect.TypeOf((*MockStorage)(nil).CommentsAfter), ctx, newsID, afterID, limit)
+}
+
+// CountsByNews mocks base method.
+func (m *MockStorage) CountsByNews(ctx context.Context, newsIDs []uuid.UUID) (map[uuid.UUID]models.NewsCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountsByNews", ctx, newsIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]models.NewsCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountsByNews indicates an expected call of CountsByNews.
+func (mr *MockStorageMockRecorder) CountsByNews(ctx, newsIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountsByNews", reflect.TypeOf((*MockStorage)(nil).CountsByNews), ctx, newsIDs)
+}
+
 // CreateComment mocks base method.
 func (m *MockStorage) CreateComment(ctx context.Context, comment models.Comment) (*models.Comment, error) {
 	m.ctrl.T.Helper()
@@ -79,12 +470,28 @@ func (mr *MockStorageMockRecorder) CreateComment(ctx, comment interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockStorage)(nil).CreateComment), ctx, comment)
 }
 
+// CreateNotifications mocks base method.
+func (m *MockStorage) CreateNotifications(ctx context.Context, items []models.Notification) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotifications", ctx, items)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotifications indicates an expected call of CreateNotifications.
+func (mr *MockStorageMockRecorder) CreateNotifications(ctx, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotifications", reflect.TypeOf((*MockStorage)(nil).CreateNotifications), ctx, items)
+}
+
 // DeleteComment mocks base method.
-func (m *MockStorage) DeleteComment(ctx context.Context, id string) error {
+func (m *MockStorage) DeleteComment(ctx context.Context, id string) (*models.Comment, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteComment", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteComment indicates an expected call of DeleteComment.
@@ -108,6 +515,51 @@ func (mr *MockStorageMockRecorder) ListByNews(ctx, newsID, p interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByNews", reflect.TypeOf((*MockStorage)(nil).ListByNews), ctx, newsID, p)
 }
 
+// ListByUser mocks base method.
+func (m *MockStorage) ListByUser(ctx context.Context, userID uuid.UUID, includeDeleted bool, p models.ListParams) (*models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID, includeDeleted, p)
+	ret0, _ := ret[0].(*models.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockStorageMockRecorder) ListByUser(ctx, userID, includeDeleted, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockStorage)(nil).ListByUser), ctx, userID, includeDeleted, p)
+}
+
+// ListByUsers mocks base method.
+func (m *MockStorage) ListByUsers(ctx context.Context, userIDs []uuid.UUID, p models.ListParams) (*models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUsers", ctx, userIDs, p)
+	ret0, _ := ret[0].(*models.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUsers indicates an expected call of ListByUsers.
+func (mr *MockStorageMockRecorder) ListByUsers(ctx, userIDs, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUsers", reflect.TypeOf((*MockStorage)(nil).ListByUsers), ctx, userIDs, p)
+}
+
+// ListNotifications mocks base method.
+func (m *MockStorage) ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, p models.ListParams) (*models.NotificationsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, userID, unreadOnly, p)
+	ret0, _ := ret[0].(*models.NotificationsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockStorageMockRecorder) ListNotifications(ctx, userID, unreadOnly, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockStorage)(nil).ListNotifications), ctx, userID, unreadOnly, p)
+}
+
 // ListReplies mocks base method.
 func (m *MockStorage) ListReplies(ctx context.Context, parentID string, p models.ListParams) (*models.Page, error) {
 	m.ctrl.T.Helper()
@@ -122,3 +574,152 @@ func (mr *MockStorageMockRecorder) ListReplies(ctx, parentID, p interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplies", reflect.TypeOf((*MockStorage)(nil).ListReplies), ctx, parentID, p)
 }
+
+// MarkRead mocks base method.
+func (m *MockStorage) MarkRead(ctx context.Context, userID uuid.UUID, ids []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userID, ids)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockStorageMockRecorder) MarkRead(ctx, userID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockStorage)(nil).MarkRead), ctx, userID, ids)
+}
+
+// MuteThread mocks base method.
+func (m *MockStorage) MuteThread(ctx context.Context, userID uuid.UUID, threadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteThread", ctx, userID, threadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteThread indicates an expected call of MuteThread.
+func (mr *MockStorageMockRecorder) MuteThread(ctx, userID, threadID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteThread", reflect.TypeOf((*MockStorage)(nil).MuteThread), ctx, userID, threadID)
+}
+
+// MutedUsers mocks base method.
+func (m *MockStorage) MutedUsers(ctx context.Context, threadID string, userIDs []uuid.UUID) (map[uuid.UUID]struct{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MutedUsers", ctx, threadID, userIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]struct{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MutedUsers indicates an expected call of MutedUsers.
+func (mr *MockStorageMockRecorder) MutedUsers(ctx, threadID, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MutedUsers", reflect.TypeOf((*MockStorage)(nil).MutedUsers), ctx, threadID, userIDs)
+}
+
+// SearchComments mocks base method.
+func (m *MockStorage) SearchComments(ctx context.Context, q models.SearchQuery, p models.ListParams) (*models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchComments", ctx, q, p)
+	ret0, _ := ret[0].(*models.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchComments indicates an expected call of SearchComments.
+func (mr *MockStorageMockRecorder) SearchComments(ctx, q, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchComments", reflect.TypeOf((*MockStorage)(nil).SearchComments), ctx, q, p)
+}
+
+// SetReaction mocks base method.
+func (m *MockStorage) SetReaction(ctx context.Context, id string, userID uuid.UUID, reaction string, on bool) (*models.Comment, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReaction", ctx, id, userID, reaction, on)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetReaction indicates an expected call of SetReaction.
+func (mr *MockStorageMockRecorder) SetReaction(ctx, id, userID, reaction, on interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReaction", reflect.TypeOf((*MockStorage)(nil).SetReaction), ctx, id, userID, reaction, on)
+}
+
+// SetThreadLocked mocks base method.
+func (m *MockStorage) SetThreadLocked(ctx context.Context, id string, locked bool) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetThreadLocked", ctx, id, locked)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetThreadLocked indicates an expected call of SetThreadLocked.
+func (mr *MockStorageMockRecorder) SetThreadLocked(ctx, id, locked interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetThreadLocked", reflect.TypeOf((*MockStorage)(nil).SetThreadLocked), ctx, id, locked)
+}
+
+// SetThreadPolicy mocks base method.
+func (m *MockStorage) SetThreadPolicy(ctx context.Context, policy models.ThreadPolicy) (*models.ThreadPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetThreadPolicy", ctx, policy)
+	ret0, _ := ret[0].(*models.ThreadPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetThreadPolicy indicates an expected call of SetThreadPolicy.
+func (mr *MockStorageMockRecorder) SetThreadPolicy(ctx, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetThreadPolicy", reflect.TypeOf((*MockStorage)(nil).SetThreadPolicy), ctx, policy)
+}
+
+// ThreadPolicy mocks base method.
+func (m *MockStorage) ThreadPolicy(ctx context.Context, newsID uuid.UUID) (*models.ThreadPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ThreadPolicy", ctx, newsID)
+	ret0, _ := ret[0].(*models.ThreadPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ThreadPolicy indicates an expected call of ThreadPolicy.
+func (mr *MockStorageMockRecorder) ThreadPolicy(ctx, newsID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ThreadPolicy", reflect.TypeOf((*MockStorage)(nil).ThreadPolicy), ctx, newsID)
+}
+
+// UnmuteThread mocks base method.
+func (m *MockStorage) UnmuteThread(ctx context.Context, userID uuid.UUID, threadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmuteThread", ctx, userID, threadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmuteThread indicates an expected call of UnmuteThread.
+func (mr *MockStorageMockRecorder) UnmuteThread(ctx, userID, threadID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteThread", reflect.TypeOf((*MockStorage)(nil).UnmuteThread), ctx, userID, threadID)
+}
+
+// UnreadCount mocks base method.
+func (m *MockStorage) UnreadCount(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnreadCount", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnreadCount indicates an expected call of UnreadCount.
+func (mr *MockStorageMockRecorder) UnreadCount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnreadCount", reflect.TypeOf((*MockStorage)(nil).UnreadCount), ctx, userID)
+}
@@ -19,7 +19,9 @@ import (
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/service"
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/storage/postgres"
 	news "github.com/pribylovaa/go-news-aggregator/news-service/internal/transport/grpc"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/users"
 	"github.com/pribylovaa/go-news-aggregator/pkg/interceptors"
+	"github.com/pribylovaa/go-news-aggregator/pkg/tlsconfig"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -49,6 +51,21 @@ func main() {
 
 	rootCtx, rootCancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 
+	// TLS gRPC-сервера и клиентов; сертификаты перечитываются с диска до остановки.
+	grpcTLS, err := tlsconfig.New(cfg.GRPC.TLS)
+	if err != nil {
+		log.Error("grpc_tls_init_failed", slog.String("err", err.Error()))
+		rootCancel()
+		os.Exit(1)
+	}
+	grpcCreds, err := grpcTLS.ServerOption()
+	if err != nil {
+		log.Error("grpc_tls_init_failed", slog.String("err", err.Error()))
+		rootCancel()
+		os.Exit(1)
+	}
+	go grpcTLS.Run(rootCtx, log)
+
 	dbCtx, dbCancel := context.WithTimeout(rootCtx, 10*time.Second)
 	store, err := postgres.New(dbCtx, cfg.DB.URL)
 	dbCancel()
@@ -60,6 +77,22 @@ func main() {
 	log.Info("postgres_connected")
 
 	svc := service.New(store, *cfg)
+
+	// Опционально: users-service для предпочтений читателя (персональная лента).
+	var usersClient *users.Client
+	if cfg.Users.Addr != "" {
+		usersClient, err = users.New(cfg.Users.Addr, grpcTLS.DialOption())
+		if err != nil {
+			log.Error("users_client_init_failed", slog.String("err", err.Error()))
+			rootCancel()
+			store.Close()
+			os.Exit(1)
+		}
+		svc.SetPreferencesSource(usersClient)
+		log.Info("users_client_initialized", slog.String("addr", cfg.Users.Addr))
+	} else {
+		log.Warn("users_addr_empty_personalization_without_preferences")
+	}
 	log.Info("service_initialized")
 
 	var ready int32 // 0 — not ready; 1 — ready
@@ -98,6 +131,7 @@ func main() {
 	grpc_prometheus.EnableHandlingTimeHistogram()
 
 	grpcOpts := []grpc.ServerOption{
+		grpcCreds,
 		grpc.ChainUnaryInterceptor(
 			interceptors.Recover(log),
 			interceptors.UnaryLoggingInterceptor(log),
@@ -127,6 +161,8 @@ func main() {
 		}
 	}()
 
+	go svc.StartReadHistoryPurge(rootCtx)
+
 	addr := cfg.GRPC.Addr()
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -184,6 +220,9 @@ func main() {
 	_ = httpSrv.Shutdown(context.Background())
 
 	rootCancel()
+	if usersClient != nil {
+		_ = usersClient.Close()
+	}
 	store.Close()
 
 	log.Info("service_stopped")
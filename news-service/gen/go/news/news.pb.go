@@ -25,6 +25,8 @@ type ListNewsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
 	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`              // читатель: заполняет News.is_read; пусто — анонимно
+	OnlyUnread    bool                   `protobuf:"varint,4,opt,name=only_unread,json=onlyUnread,proto3" json:"only_unread,omitempty"` // только непрочитанные user_id (без user_id игнорируется)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,6 +75,20 @@ func (x *ListNewsRequest) GetPageToken() string {
 	return ""
 }
 
+func (x *ListNewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListNewsRequest) GetOnlyUnread() bool {
+	if x != nil {
+		return x.OnlyUnread
+	}
+	return false
+}
+
 type ListNewsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*News                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -125,6 +141,118 @@ func (x *ListNewsResponse) GetNextPageToken() string {
 	return ""
 }
 
+type ListPersonalizedNewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // пусто — анонимный читатель (без предпочтений)
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPersonalizedNewsRequest) Reset() {
+	*x = ListPersonalizedNewsRequest{}
+	mi := &file_news_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPersonalizedNewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPersonalizedNewsRequest) ProtoMessage() {}
+
+func (x *ListPersonalizedNewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_news_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPersonalizedNewsRequest.ProtoReflect.Descriptor instead.
+func (*ListPersonalizedNewsRequest) Descriptor() ([]byte, []int) {
+	return file_news_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPersonalizedNewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListPersonalizedNewsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPersonalizedNewsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListPersonalizedNewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*News                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` // в порядке ранжирования
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPersonalizedNewsResponse) Reset() {
+	*x = ListPersonalizedNewsResponse{}
+	mi := &file_news_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPersonalizedNewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPersonalizedNewsResponse) ProtoMessage() {}
+
+func (x *ListPersonalizedNewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_news_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPersonalizedNewsResponse.ProtoReflect.Descriptor instead.
+func (*ListPersonalizedNewsResponse) Descriptor() ([]byte, []int) {
+	return file_news_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPersonalizedNewsResponse) GetItems() []*News {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListPersonalizedNewsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type NewsByIDRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -134,7 +262,7 @@ type NewsByIDRequest struct {
 
 func (x *NewsByIDRequest) Reset() {
 	*x = NewsByIDRequest{}
-	mi := &file_news_proto_msgTypes[2]
+	mi := &file_news_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -146,7 +274,7 @@ func (x *NewsByIDRequest) String() string {
 func (*NewsByIDRequest) ProtoMessage() {}
 
 func (x *NewsByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_news_proto_msgTypes[2]
+	mi := &file_news_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,7 +287,7 @@ func (x *NewsByIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewsByIDRequest.ProtoReflect.Descriptor instead.
 func (*NewsByIDRequest) Descriptor() ([]byte, []int) {
-	return file_news_proto_rawDescGZIP(), []int{2}
+	return file_news_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *NewsByIDRequest) GetId() string {
@@ -178,7 +306,7 @@ type NewsByIDResponse struct {
 
 func (x *NewsByIDResponse) Reset() {
 	*x = NewsByIDResponse{}
-	mi := &file_news_proto_msgTypes[3]
+	mi := &file_news_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -190,7 +318,7 @@ func (x *NewsByIDResponse) String() string {
 func (*NewsByIDResponse) ProtoMessage() {}
 
 func (x *NewsByIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_news_proto_msgTypes[3]
+	mi := &file_news_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -203,7 +331,7 @@ func (x *NewsByIDResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewsByIDResponse.ProtoReflect.Descriptor instead.
 func (*NewsByIDResponse) Descriptor() ([]byte, []int) {
-	return file_news_proto_rawDescGZIP(), []int{3}
+	return file_news_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *NewsByIDResponse) GetItem() *News {
@@ -213,6 +341,110 @@ func (x *NewsByIDResponse) GetItem() *News {
 	return nil
 }
 
+type NewsByIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`                     // до limits.max_batch; дубликаты схлопываются
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // читатель: заполняет News.is_read; пусто — анонимно
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewsByIDsRequest) Reset() {
+	*x = NewsByIDsRequest{}
+	mi := &file_news_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewsByIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewsByIDsRequest) ProtoMessage() {}
+
+func (x *NewsByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_news_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewsByIDsRequest.ProtoReflect.Descriptor instead.
+func (*NewsByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_news_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *NewsByIDsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *NewsByIDsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type NewsByIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*News                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`                             // в порядке ids
+	MissingIds    []string               `protobuf:"bytes,2,rep,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"` // нет такой новости или некорректный id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewsByIDsResponse) Reset() {
+	*x = NewsByIDsResponse{}
+	mi := &file_news_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewsByIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewsByIDsResponse) ProtoMessage() {}
+
+func (x *NewsByIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_news_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewsByIDsResponse.ProtoReflect.Descriptor instead.
+func (*NewsByIDsResponse) Descriptor() ([]byte, []int) {
+	return file_news_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NewsByIDsResponse) GetItems() []*News {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *NewsByIDsResponse) GetMissingIds() []string {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
 type News struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -224,13 +456,16 @@ type News struct {
 	ImageUrl         string                 `protobuf:"bytes,7,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
 	PublishedAt      int64                  `protobuf:"varint,8,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
 	FetchedAt        int64                  `protobuf:"varint,9,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	Source           string                 `protobuf:"bytes,10,opt,name=source,proto3" json:"source,omitempty"`                // хост источника без "www."
+	Language         string                 `protobuf:"bytes,11,opt,name=language,proto3" json:"language,omitempty"`            // "en", "ru"; пусто — неизвестен
+	IsRead           bool                   `protobuf:"varint,12,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"` // читатель из запроса уже открывал новость (ListNews/ListPersonalizedNews)
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *News) Reset() {
 	*x = News{}
-	mi := &file_news_proto_msgTypes[4]
+	mi := &file_news_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -242,7 +477,7 @@ func (x *News) String() string {
 func (*News) ProtoMessage() {}
 
 func (x *News) ProtoReflect() protoreflect.Message {
-	mi := &file_news_proto_msgTypes[4]
+	mi := &file_news_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -255,7 +490,7 @@ func (x *News) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use News.ProtoReflect.Descriptor instead.
 func (*News) Descriptor() ([]byte, []int) {
-	return file_news_proto_rawDescGZIP(), []int{4}
+	return file_news_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *News) GetId() string {
@@ -321,25 +556,350 @@ func (x *News) GetFetchedAt() int64 {
 	return 0
 }
 
+func (x *News) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *News) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *News) GetIsRead() bool {
+	if x != nil {
+		return x.IsRead
+	}
+	return false
+}
+
+type MarkReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	NewsIds       []string               `protobuf:"bytes,2,rep,name=news_ids,json=newsIds,proto3" json:"news_ids,omitempty"` // 1..100; несуществующие пропускаются
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadRequest) Reset() {
+	*x = MarkReadRequest{}
+	mi := &file_news_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadRequest) ProtoMessage() {}
+
+func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_news_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+	return file_news_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MarkReadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MarkReadRequest) GetNewsIds() []string {
+	if x != nil {
+		return x.NewsIds
+	}
+	return nil
+}
+
+type MarkReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadResponse) Reset() {
+	*x = MarkReadResponse{}
+	mi := &file_news_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadResponse) ProtoMessage() {}
+
+func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_news_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkReadResponse) Descriptor() ([]byte, []int) {
+	return file_news_proto_rawDescGZIP(), []int{10}
+}
+
+type MarkAllReadRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// page_token, с которым клиент запросил страницу ListNews: прочитанными становятся
+	// эта страница и все более старые новости. Пусто — всё, опубликованное к текущему моменту.
+	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkAllReadRequest) Reset() {
+	*x = MarkAllReadRequest{}
+	mi := &file_news_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkAllReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkAllReadRequest) ProtoMessage() {}
+
+func (x *MarkAllReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_news_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkAllReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkAllReadRequest) Descriptor() ([]byte, []int) {
+	return file_news_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MarkAllReadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MarkAllReadRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type MarkAllReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkAllReadResponse) Reset() {
+	*x = MarkAllReadResponse{}
+	mi := &file_news_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkAllReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkAllReadResponse) ProtoMessage() {}
+
+func (x *MarkAllReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_news_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkAllReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkAllReadResponse) Descriptor() ([]byte, []int) {
+	return file_news_proto_rawDescGZIP(), []int{12}
+}
+
+type WatchNewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LastEventId   string                 `protobuf:"bytes,1,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"` // event_id последнего полученного события; пусто — только новые
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchNewsRequest) Reset() {
+	*x = WatchNewsRequest{}
+	mi := &file_news_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchNewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchNewsRequest) ProtoMessage() {}
+
+func (x *WatchNewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_news_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchNewsRequest.ProtoReflect.Descriptor instead.
+func (*WatchNewsRequest) Descriptor() ([]byte, []int) {
+	return file_news_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchNewsRequest) GetLastEventId() string {
+	if x != nil {
+		return x.LastEventId
+	}
+	return ""
+}
+
+type NewsEvent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // курсор возобновления (порядок вставки)
+	Item    *News                  `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	// Пропущенное после last_event_id не восстановить (слишком много или неизвестный
+	// курсор): перечитайте ListNews. event_id и item пусты.
+	ResetRequired bool `protobuf:"varint,3,opt,name=reset_required,json=resetRequired,proto3" json:"reset_required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewsEvent) Reset() {
+	*x = NewsEvent{}
+	mi := &file_news_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewsEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewsEvent) ProtoMessage() {}
+
+func (x *NewsEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_news_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewsEvent.ProtoReflect.Descriptor instead.
+func (*NewsEvent) Descriptor() ([]byte, []int) {
+	return file_news_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NewsEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *NewsEvent) GetItem() *News {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *NewsEvent) GetResetRequired() bool {
+	if x != nil {
+		return x.ResetRequired
+	}
+	return false
+}
+
 var File_news_proto protoreflect.FileDescriptor
 
 const file_news_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"news.proto\x12\x04news\"F\n" +
+	"news.proto\x12\x04news\"\x80\x01\n" +
 	"\x0fListNewsRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1d\n" +
 	"\n" +
-	"page_token\x18\x02 \x01(\tR\tpageToken\"\\\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vonly_unread\x18\x04 \x01(\bR\n" +
+	"onlyUnread\"\\\n" +
 	"\x10ListNewsResponse\x12 \n" +
 	"\x05items\x18\x01 \x03(\v2\n" +
 	".news.NewsR\x05items\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"k\n" +
+	"\x1bListPersonalizedNewsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"h\n" +
+	"\x1cListPersonalizedNewsResponse\x12 \n" +
+	"\x05items\x18\x01 \x03(\v2\n" +
+	".news.NewsR\x05items\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"!\n" +
 	"\x0fNewsByIDRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
 	"\x10NewsByIDResponse\x12\x1e\n" +
 	"\x04item\x18\x01 \x01(\v2\n" +
-	".news.NewsR\x04item\"\x93\x02\n" +
+	".news.NewsR\x04item\"=\n" +
+	"\x10NewsByIDsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"V\n" +
+	"\x11NewsByIDsResponse\x12 \n" +
+	"\x05items\x18\x01 \x03(\v2\n" +
+	".news.NewsR\x05items\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\tR\n" +
+	"missingIds\"\xe0\x02\n" +
 	"\x04News\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1a\n" +
@@ -350,10 +910,35 @@ const file_news_proto_rawDesc = "" +
 	"\timage_url\x18\a \x01(\tR\bimageUrl\x12!\n" +
 	"\fpublished_at\x18\b \x01(\x03R\vpublishedAt\x12\x1d\n" +
 	"\n" +
-	"fetched_at\x18\t \x01(\x03R\tfetchedAt2\x83\x01\n" +
+	"fetched_at\x18\t \x01(\x03R\tfetchedAt\x12\x16\n" +
+	"\x06source\x18\n" +
+	" \x01(\tR\x06source\x12\x1a\n" +
+	"\blanguage\x18\v \x01(\tR\blanguage\x12\x17\n" +
+	"\ais_read\x18\f \x01(\bR\x06isRead\"E\n" +
+	"\x0fMarkReadRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bnews_ids\x18\x02 \x03(\tR\anewsIds\"\x12\n" +
+	"\x10MarkReadResponse\"L\n" +
+	"\x12MarkAllReadRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\x15\n" +
+	"\x13MarkAllReadResponse\"6\n" +
+	"\x10WatchNewsRequest\x12\"\n" +
+	"\rlast_event_id\x18\x01 \x01(\tR\vlastEventId\"m\n" +
+	"\tNewsEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1e\n" +
+	"\x04item\x18\x02 \x01(\v2\n" +
+	".news.NewsR\x04item\x12%\n" +
+	"\x0ereset_required\x18\x03 \x01(\bR\rresetRequired2\xd7\x03\n" +
 	"\vNewsService\x129\n" +
 	"\bListNews\x12\x15.news.ListNewsRequest\x1a\x16.news.ListNewsResponse\x129\n" +
-	"\bNewsByID\x12\x15.news.NewsByIDRequest\x1a\x16.news.NewsByIDResponseBJZHgithub.com/pribylovaa/go-news-aggregator/news-service/gen/go/news;newsv1b\x06proto3"
+	"\bNewsByID\x12\x15.news.NewsByIDRequest\x1a\x16.news.NewsByIDResponse\x12<\n" +
+	"\tNewsByIDs\x12\x16.news.NewsByIDsRequest\x1a\x17.news.NewsByIDsResponse\x12]\n" +
+	"\x14ListPersonalizedNews\x12!.news.ListPersonalizedNewsRequest\x1a\".news.ListPersonalizedNewsResponse\x129\n" +
+	"\bMarkRead\x12\x15.news.MarkReadRequest\x1a\x16.news.MarkReadResponse\x12B\n" +
+	"\vMarkAllRead\x12\x18.news.MarkAllReadRequest\x1a\x19.news.MarkAllReadResponse\x126\n" +
+	"\tWatchNews\x12\x16.news.WatchNewsRequest\x1a\x0f.news.NewsEvent0\x01BJZHgithub.com/pribylovaa/go-news-aggregator/news-service/gen/go/news;newsv1b\x06proto3"
 
 var (
 	file_news_proto_rawDescOnce sync.Once
@@ -367,26 +952,49 @@ func file_news_proto_rawDescGZIP() []byte {
 	return file_news_proto_rawDescData
 }
 
-var file_news_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_news_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_news_proto_goTypes = []any{
-	(*ListNewsRequest)(nil),  // 0: news.ListNewsRequest
-	(*ListNewsResponse)(nil), // 1: news.ListNewsResponse
-	(*NewsByIDRequest)(nil),  // 2: news.NewsByIDRequest
-	(*NewsByIDResponse)(nil), // 3: news.NewsByIDResponse
-	(*News)(nil),             // 4: news.News
+	(*ListNewsRequest)(nil),              // 0: news.ListNewsRequest
+	(*ListNewsResponse)(nil),             // 1: news.ListNewsResponse
+	(*ListPersonalizedNewsRequest)(nil),  // 2: news.ListPersonalizedNewsRequest
+	(*ListPersonalizedNewsResponse)(nil), // 3: news.ListPersonalizedNewsResponse
+	(*NewsByIDRequest)(nil),              // 4: news.NewsByIDRequest
+	(*NewsByIDResponse)(nil),             // 5: news.NewsByIDResponse
+	(*NewsByIDsRequest)(nil),             // 6: news.NewsByIDsRequest
+	(*NewsByIDsResponse)(nil),            // 7: news.NewsByIDsResponse
+	(*News)(nil),                         // 8: news.News
+	(*MarkReadRequest)(nil),              // 9: news.MarkReadRequest
+	(*MarkReadResponse)(nil),             // 10: news.MarkReadResponse
+	(*MarkAllReadRequest)(nil),           // 11: news.MarkAllReadRequest
+	(*MarkAllReadResponse)(nil),          // 12: news.MarkAllReadResponse
+	(*WatchNewsRequest)(nil),             // 13: news.WatchNewsRequest
+	(*NewsEvent)(nil),                    // 14: news.NewsEvent
 }
 var file_news_proto_depIdxs = []int32{
-	4, // 0: news.ListNewsResponse.items:type_name -> news.News
-	4, // 1: news.NewsByIDResponse.item:type_name -> news.News
-	0, // 2: news.NewsService.ListNews:input_type -> news.ListNewsRequest
-	2, // 3: news.NewsService.NewsByID:input_type -> news.NewsByIDRequest
-	1, // 4: news.NewsService.ListNews:output_type -> news.ListNewsResponse
-	3, // 5: news.NewsService.NewsByID:output_type -> news.NewsByIDResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	8,  // 0: news.ListNewsResponse.items:type_name -> news.News
+	8,  // 1: news.ListPersonalizedNewsResponse.items:type_name -> news.News
+	8,  // 2: news.NewsByIDResponse.item:type_name -> news.News
+	8,  // 3: news.NewsByIDsResponse.items:type_name -> news.News
+	8,  // 4: news.NewsEvent.item:type_name -> news.News
+	0,  // 5: news.NewsService.ListNews:input_type -> news.ListNewsRequest
+	4,  // 6: news.NewsService.NewsByID:input_type -> news.NewsByIDRequest
+	6,  // 7: news.NewsService.NewsByIDs:input_type -> news.NewsByIDsRequest
+	2,  // 8: news.NewsService.ListPersonalizedNews:input_type -> news.ListPersonalizedNewsRequest
+	9,  // 9: news.NewsService.MarkRead:input_type -> news.MarkReadRequest
+	11, // 10: news.NewsService.MarkAllRead:input_type -> news.MarkAllReadRequest
+	13, // 11: news.NewsService.WatchNews:input_type -> news.WatchNewsRequest
+	1,  // 12: news.NewsService.ListNews:output_type -> news.ListNewsResponse
+	5,  // 13: news.NewsService.NewsByID:output_type -> news.NewsByIDResponse
+	7,  // 14: news.NewsService.NewsByIDs:output_type -> news.NewsByIDsResponse
+	3,  // 15: news.NewsService.ListPersonalizedNews:output_type -> news.ListPersonalizedNewsResponse
+	10, // 16: news.NewsService.MarkRead:output_type -> news.MarkReadResponse
+	12, // 17: news.NewsService.MarkAllRead:output_type -> news.MarkAllReadResponse
+	14, // 18: news.NewsService.WatchNews:output_type -> news.NewsEvent
+	12, // [12:19] is the sub-list for method output_type
+	5,  // [5:12] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_news_proto_init() }
@@ -400,7 +1008,7 @@ func file_news_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_news_proto_rawDesc), len(file_news_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/pribylovaa/go-news-aggregator/pkg/tlsconfig"
 )
 
 // Config — корневая конфигурация сервиса.
@@ -25,6 +26,53 @@ type Config struct {
 	Fetcher      FetcherConfig `yaml:"fetcher"`
 	LimitsConfig LimitsConfig  `yaml:"limits"`
 	Timeouts     TimeoutConfig `yaml:"timeouts"`
+	Users        UsersConfig   `yaml:"users"`
+	// Personalization — ранжирование персональной ленты (ListPersonalizedNews).
+	Personalization PersonalizationConfig `yaml:"personalization"`
+	// ReadHistory — история прочтений (is_read, only_unread).
+	ReadHistory ReadHistoryConfig `yaml:"read_history"`
+	// Watch — живая лента (WatchNews).
+	Watch WatchConfig `yaml:"watch"`
+}
+
+// WatchConfig — подписки на новые новости.
+type WatchConfig struct {
+	// Buffer — буфер подписчика; переполнивший его отключается (и возобновляет поток).
+	Buffer int `yaml:"buffer" env:"WATCH_BUFFER" env-default:"64"`
+	// Replay — сколько пропущенных новостей досылается по last_event_id; больше — событие reset.
+	Replay int `yaml:"replay" env:"WATCH_REPLAY" env-default:"200"`
+}
+
+// ReadHistoryConfig — хранение отметок о прочтении.
+// Отметки отдельных новостей ограничены по числу и возрасту; «прочитано всё до…»
+// хранится одной строкой на читателя и не истекает.
+type ReadHistoryConfig struct {
+	// Retention — сколько хранится отметка о прочтении отдельной новости.
+	Retention time.Duration `yaml:"retention" env:"READ_HISTORY_RETENTION" env-default:"720h"`
+	// MaxPerUser — максимум отдельных отметок на читателя (старые вытесняются).
+	MaxPerUser int `yaml:"max_per_user" env:"READ_HISTORY_MAX_PER_USER" env-default:"5000"`
+	// PurgeInterval — период фоновой уборки отметок старше Retention.
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"READ_HISTORY_PURGE_INTERVAL" env-default:"1h"`
+}
+
+// UsersConfig — клиент users-service (предпочтения читателя).
+// Пустой Addr — персональная лента ранжируется без предпочтений.
+type UsersConfig struct {
+	Addr string `yaml:"addr" env:"USERS_ADDR"`
+}
+
+// PersonalizationConfig — параметры ранжирования персональной ленты.
+// score = affinity * 0.5^(возраст/HalfLife), affinity = 1 (+ CategoryBoost для
+// отслеживаемых категорий).
+type PersonalizationConfig struct {
+	// CandidateFactor — во сколько раз окно кандидатов больше страницы.
+	CandidateFactor int `yaml:"candidate_factor" env:"PERSONALIZATION_CANDIDATE_FACTOR" env-default:"3"`
+	// HalfLife — за сколько вклад свежести падает вдвое.
+	HalfLife time.Duration `yaml:"half_life" env:"PERSONALIZATION_HALF_LIFE" env-default:"6h"`
+	// CategoryBoost — прибавка к affinity для отслеживаемой категории.
+	CategoryBoost float64 `yaml:"category_boost" env:"PERSONALIZATION_CATEGORY_BOOST" env-default:"1"`
+	// MaxSourceShare — максимальная доля страницы от одного источника (0 < x <= 1).
+	MaxSourceShare float64 `yaml:"max_source_share" env:"PERSONALIZATION_MAX_SOURCE_SHARE" env-default:"0.34"`
 }
 
 // TimeoutConfig — таймауты сервиса.
@@ -36,6 +84,8 @@ type TimeoutConfig struct {
 type GRPCConfig struct {
 	Host string `yaml:"host" env:"GRPC_HOST" env-default:"0.0.0.0"`
 	Port string `yaml:"port" env:"GRPC_PORT" env-default:"50052"`
+	// TLS — TLS/mTLS gRPC-сервера и исходящих gRPC-клиентов (pkg/tlsconfig).
+	TLS tlsconfig.Config `yaml:"tls" env-prefix:"GRPC_TLS_"`
 }
 
 // HTTPConfig — сетевые настройки HTTP-сервера.
@@ -72,6 +122,8 @@ type LimitsConfig struct {
 	Default int32 `yaml:"default" env:"DEFAULT_LIMIT" env-default:"12"`
 	// Верхняя граница для limit.
 	Max int32 `yaml:"max" env:"MAX_LIMIT" env-default:"300"`
+	// Сколько id можно запросить одним NewsByIDs.
+	MaxBatch int32 `yaml:"max_batch" env:"MAX_BATCH_IDS" env-default:"100"`
 }
 
 // MustLoad — обёртка над Load с panic при ошибке.
@@ -188,5 +240,35 @@ func (c *Config) validate() error {
 	if c.LimitsConfig.Default > c.LimitsConfig.Max {
 		return fmt.Errorf("limits.default must be <= limits.max")
 	}
+	if c.LimitsConfig.MaxBatch <= 0 {
+		return fmt.Errorf("limits.max_batch must be > 0")
+	}
+	if c.Personalization.CandidateFactor < 1 {
+		return fmt.Errorf("personalization.candidate_factor must be >= 1")
+	}
+	if c.Personalization.HalfLife <= 0 {
+		return fmt.Errorf("personalization.half_life must be > 0")
+	}
+	if c.Personalization.CategoryBoost < 0 {
+		return fmt.Errorf("personalization.category_boost must be >= 0")
+	}
+	if c.Personalization.MaxSourceShare <= 0 || c.Personalization.MaxSourceShare > 1 {
+		return fmt.Errorf("personalization.max_source_share must be in (0, 1]")
+	}
+	if c.ReadHistory.Retention <= 0 {
+		return fmt.Errorf("read_history.retention must be > 0")
+	}
+	if c.ReadHistory.MaxPerUser < 1 {
+		return fmt.Errorf("read_history.max_per_user must be >= 1")
+	}
+	if c.ReadHistory.PurgeInterval < time.Minute {
+		return fmt.Errorf("read_history.purge_interval must be at least 1m")
+	}
+	if c.Watch.Buffer < 1 {
+		return fmt.Errorf("watch.buffer must be >= 1")
+	}
+	if c.Watch.Replay < 0 {
+		return fmt.Errorf("watch.replay must be >= 0")
+	}
 	return nil
 }
@@ -88,6 +88,7 @@ func TestLoad_WithExplicitPath_OK(t *testing.T) {
 	require.Equal(t, 11*time.Minute, cfg.Fetcher.Interval)
 	require.EqualValues(t, 15, cfg.LimitsConfig.Default)
 	require.EqualValues(t, 200, cfg.LimitsConfig.Max)
+	require.EqualValues(t, 100, cfg.LimitsConfig.MaxBatch)
 	require.EqualValues(t, 5*time.Second, cfg.Timeouts.Service)
 }
 
@@ -162,6 +163,8 @@ func TestLoad_EnvOnly_OK(t *testing.T) {
 	require.EqualValues(t, 21, cfg.LimitsConfig.Default)
 	require.EqualValues(t, 333, cfg.LimitsConfig.Max)
 	require.EqualValues(t, 5*time.Second, cfg.Timeouts.Service)
+	require.Equal(t, 64, cfg.Watch.Buffer)
+	require.Equal(t, 200, cfg.Watch.Replay)
 	require.ElementsMatch(t, []string{"https://a.example/rss.xml", "https://b.example/rss.xml"}, cfg.Fetcher.Sources)
 }
 
@@ -250,3 +253,32 @@ func TestMustLoad_PanicsOnError(t *testing.T) {
 		_ = MustLoad(filepath.Join(t.TempDir(), "nope.yaml"))
 	})
 }
+
+// TestLoad_GRPCTLS — mTLS сервера из YAML, allowlist SAN из ENV (GRPC_TLS_).
+func TestLoad_GRPCTLS(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "config.yaml", minimalYAML+`
+grpc:
+  tls:
+    enabled: true
+    cert_file: /tls/tls.crt
+    key_file: /tls/tls.key
+    ca_file: /tls/ca.crt
+    client_auth: true
+`)
+
+	t.Setenv("GRPC_TLS_ALLOWED_SANS", "api-gateway.api-gateway.svc.cluster.local,users-service.users.svc.cluster.local")
+	t.Setenv("GRPC_TLS_RELOAD_INTERVAL", "1m")
+
+	cfg, err := Load(cfgPath)
+	require.NoError(t, err)
+
+	tls := cfg.GRPC.TLS
+	require.True(t, tls.Enabled)
+	require.True(t, tls.ClientAuth)
+	require.Equal(t, "/tls/tls.crt", tls.CertFile)
+	require.Equal(t, "/tls/ca.crt", tls.CAFile)
+	require.Equal(t, []string{"api-gateway.api-gateway.svc.cluster.local", "users-service.users.svc.cluster.local"}, tls.AllowedSANs)
+	require.Equal(t, time.Minute, tls.ReloadInterval)
+	require.Equal(t, "50052", cfg.GRPC.Port)
+}
@@ -32,6 +32,16 @@ type News struct {
 	PublishedAt time.Time
 	// FetchedAt - время загрузки новости в БД (UTC).
 	FetchedAt time.Time
+	// Source - источник: хост ссылки без "www." (см. pkg/feeds.Source).
+	Source string
+	// Language - язык ленты источника ("en", "ru"; пусто — неизвестен).
+	Language string
+	// IsRead - читатель из ListOptions.Reader уже открывал новость
+	// (или отметил прочитанным всё до неё); без читателя всегда false.
+	IsRead bool
+	// Seq - порядковый номер вставки (курсор живой ленты WatchNews);
+	// заполняется только SaveNews и NewsAfter.
+	Seq int64
 }
 
 // ListOptions — параметры выборки списков доменных сущностей.
@@ -39,9 +49,45 @@ type News struct {
 // Особенности:
 //   - при Limit == 0 применяется серверный default (из config.LimitsConfig.Default);
 //   - PageToken == "" -> первая страница
+//   - Filter == nil -> без фильтрации (хронологическая лента);
+//   - Reader == uuid.Nil -> без отметок прочтения (IsRead всегда false);
+//   - OnlyUnread -> только непрочитанные Reader (без Reader игнорируется).
 type ListOptions struct {
-	Limit     int32
-	PageToken string
+	Limit      int32
+	PageToken  string
+	Filter     *NewsFilter
+	Reader     uuid.UUID
+	OnlyUnread bool
+}
+
+// NewsFilter — ограничения выборки персональной ленты. Значения нормализованы
+// (pkg/feeds): категории сравниваются без учёта регистра, источники — по хосту.
+//
+// Особенности:
+//   - новости без языка проходят любой фильтр по Languages;
+//   - пустой список — ограничение не применяется.
+type NewsFilter struct {
+	ExcludeCategories []string
+	ExcludeSources    []string
+	Languages         []string
+}
+
+// Preferences — предпочтения читателя (хранятся в users-service).
+// Заглушение сильнее подписки: категория из MutedCategories скрывается,
+// даже если есть в FollowedCategories.
+type Preferences struct {
+	FollowedCategories []string
+	MutedCategories    []string
+	MutedSources       []string
+	Languages          []string
+}
+
+// NewsBatch — результат пакетного чтения по id.
+// Items — в порядке запроса (без повторов), MissingIDs — id, которых нет
+// (включая некорректные), тоже в порядке запроса.
+type NewsBatch struct {
+	Items      []News
+	MissingIDs []string
 }
 
 // Page — страница результатов со ссылкой на продолжение.
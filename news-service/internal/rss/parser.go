@@ -15,6 +15,7 @@ import (
 
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/service"
+	"github.com/pribylovaa/go-news-aggregator/pkg/feeds"
 	"github.com/pribylovaa/go-news-aggregator/pkg/log"
 )
 
@@ -122,6 +123,7 @@ func (p *Parser) fetchOne(ctx context.Context, src string) ([]models.News, error
 
 	output := make([]models.News, 0, len(doc.Channel.Items))
 	now := time.Time{}
+	lang := feeds.Language(doc.Channel.Language)
 	for _, item := range doc.Channel.Items {
 		title := strings.TrimSpace(item.Title)
 		link := canonicalLink(item.Link, item.GUID)
@@ -149,6 +151,7 @@ func (p *Parser) fetchOne(ctx context.Context, src string) ([]models.News, error
 			ImageURL:         pickImageURL(item),
 			PublishedAt:      pub,
 			FetchedAt:        now,
+			Language:         lang,
 		})
 	}
 
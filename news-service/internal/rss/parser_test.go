@@ -21,6 +21,7 @@ func mkRSS(items ...string) string {
      xmlns:content="http://purl.org/rss/1.0/modules/content/"
      xmlns:media="http://search.yahoo.com/mrss/">
   <channel>
+    <language>en-us</language>
     ` + strings.Join(items, "\n") + `
   </channel>
 </rss>`
@@ -283,6 +284,7 @@ func Test_ParseMany_HappyPath_And_Errors(t *testing.T) {
 	require.Equal(t, "https://cdn.example.org/e.jpg", it1.ImageURL)
 	require.True(t, it1.FetchedAt.IsZero(), "parser должен вернуть нулевой FetchedAt")
 	require.Equal(t, time.Date(2025, 9, 16, 9, 0, 0, 0, time.UTC), it1.PublishedAt)
+	require.Equal(t, "en", it1.Language, "язык канала нормализуется до основного подтега")
 
 	it2 := ok.Items[1]
 	require.Equal(t, "No Link but GUID", it2.Title)
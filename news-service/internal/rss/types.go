@@ -8,7 +8,9 @@ type rss struct {
 
 // channel - RSS-канал, содержащий список новостей.
 type channel struct {
-	Items []item `xml:"item"`
+	// Language — язык канала ("ru", "en-us"); проставляется всем записям ленты.
+	Language string `xml:"language"`
+	Items    []item `xml:"item"`
 }
 
 // item описывает одну новость в RSS-ленте.
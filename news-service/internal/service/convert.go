@@ -5,13 +5,15 @@ import (
 	"time"
 
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/pkg/feeds"
 )
 
 // finalizeNews доводит запись до инвариантов домена:
 //   - Title/Link обязательны (после TrimSpace) — иначе запись отбрасывается;
 //   - LongDescription := LongDescription || ShortDescription;
 //   - PublishedAt := PublishedAt || nowUTC (UTC);
-//   - FetchedAt := nowUTC (перекрывает любые внешние значения).
+//   - FetchedAt := nowUTC (перекрывает любые внешние значения);
+//   - Source := хост Link (pkg/feeds.Source), Language — нормализованный код языка.
 //
 // Возвращает (новость, ok=false если запись следует отбросить).
 func finalizeNews(news models.News, nowUTC time.Time) (models.News, bool) {
@@ -35,6 +37,8 @@ func finalizeNews(news models.News, nowUTC time.Time) (models.News, bool) {
 	}
 
 	news.FetchedAt = nowUTC
+	news.Source = feeds.Source(news.Link)
+	news.Language = feeds.Language(news.Language)
 
 	return news, true
 }
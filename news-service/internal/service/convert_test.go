@@ -67,6 +67,7 @@ func Test_finalizeNews(t *testing.T) {
 				ShortDescription: "teaser",
 				LongDescription:  "  long body  ",
 				Link:             "https://example/a",
+				Source:           "example",
 				ImageURL:         "  https://img/c.jpg  ",
 				PublishedAt:      time.Date(2024, 7, 1, 12, 30, 0, 0, utc),
 				FetchedAt:        time.Date(2024, 7, 1, 10, 30, 0, 0, utc),
@@ -85,6 +86,7 @@ func Test_finalizeNews(t *testing.T) {
 			want: models.News{
 				Title:            "T",
 				Link:             "https://example.org",
+				Source:           "example.org",
 				ShortDescription: "short",
 				LongDescription:  "short",
 				PublishedAt:      time.Date(2024, 7, 1, 12, 0, 0, 0, utc),
@@ -104,6 +106,7 @@ func Test_finalizeNews(t *testing.T) {
 			want: models.News{
 				Title:       "T",
 				Link:        "https://example.org",
+				Source:      "example.org",
 				PublishedAt: time.Date(2024, 7, 1, 12, 0, 0, 0, utc),
 				FetchedAt:   time.Date(2024, 7, 1, 12, 0, 0, 0, utc),
 			},
@@ -120,6 +123,7 @@ func Test_finalizeNews(t *testing.T) {
 			want: models.News{
 				Title:       "A",
 				Link:        "https://a",
+				Source:      "a",
 				PublishedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, utc),
 				FetchedAt:   time.Date(2025, 1, 2, 3, 4, 5, 0, utc),
 			},
@@ -136,6 +140,7 @@ func Test_finalizeNews(t *testing.T) {
 			want: models.News{
 				Title:       "A",
 				Link:        "https://a",
+				Source:      "a",
 				PublishedAt: time.Date(2024, 12, 31, 20, 59, 0, 0, utc),
 				FetchedAt:   time.Date(2025, 1, 2, 3, 4, 5, 0, utc),
 			},
@@ -152,6 +157,7 @@ func Test_finalizeNews(t *testing.T) {
 			want: models.News{
 				Title:       "X",
 				Link:        "https://x",
+				Source:      "x",
 				PublishedAt: time.Date(2026, 2, 3, 4, 5, 6, 0, utc),
 				FetchedAt:   time.Date(2026, 2, 3, 4, 5, 6, 0, utc),
 			},
@@ -167,11 +173,30 @@ func Test_finalizeNews(t *testing.T) {
 			want: models.News{
 				Title:       "Ok",
 				Link:        "https://ok",
+				Source:      "ok",
 				PublishedAt: time.Date(2024, 3, 4, 5, 6, 7, 0, utc),
 				FetchedAt:   time.Date(2024, 3, 4, 5, 6, 7, 0, utc),
 			},
 			want2: true,
 		},
+		{
+			name: "ok: source from link host, language normalized",
+			news: models.News{
+				Title:    "L",
+				Link:     "https://www.Lenta.ru/news/1",
+				Language: "ru-RU",
+			},
+			nowUTC: time.Date(2024, 3, 4, 5, 6, 7, 0, utc),
+			want: models.News{
+				Title:       "L",
+				Link:        "https://www.Lenta.ru/news/1",
+				PublishedAt: time.Date(2024, 3, 4, 5, 6, 7, 0, utc),
+				FetchedAt:   time.Date(2024, 3, 4, 5, 6, 7, 0, utc),
+				Source:      "lenta.ru",
+				Language:    "ru",
+			},
+			want2: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -59,6 +59,7 @@ func (s *Service) StartIngest(ctx context.Context, parser Parser) error {
 }
 
 // ingestOnce — один проход: парсинг всех источников, валидация, сохранение.
+// Впервые сохранённые новости уходят подписчикам живой ленты (WatchNews).
 func (s *Service) ingestOnce(ctx context.Context, parser Parser, urls []string) error {
 	const op = "service/fetcher/ingestOnce"
 
@@ -103,14 +104,18 @@ func (s *Service) ingestOnce(ctx context.Context, parser Parser, urls []string)
 	saveCtx, cancel := context.WithTimeout(ctx, s.cfg.Timeouts.Service)
 	defer cancel()
 
-	if err := s.storage.SaveNews(saveCtx, batch); err != nil {
+	inserted, err := s.storage.SaveNews(saveCtx, batch)
+	if err != nil {
 		return fmt.Errorf("%s: save_news: %w", op, err)
 	}
 
+	s.publishNews(inserted)
+
 	lg.Info("ingest_saved",
 		slog.String("op", op),
 		slog.Int("total_items", total),
 		slog.Int("saved", len(batch)),
+		slog.Int("inserted", len(inserted)),
 		slog.Int("feeds_ok", feedsOK),
 		slog.Int("feeds_err", feedsErr),
 	)
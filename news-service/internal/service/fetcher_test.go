@@ -127,10 +127,10 @@ func TestIngestOnce_SaveBatch_OK(t *testing.T) {
 	var saved []models.News
 	st.EXPECT().
 		SaveNews(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(_ context.Context, items []models.News) error {
+		DoAndReturn(func(_ context.Context, items []models.News) ([]models.News, error) {
 			// Сохранение копии для последующих проверок.
 			saved = append([]models.News(nil), items...)
-			return nil
+			return nil, nil
 		})
 
 	svc := newServiceWithFetcherConfig(t, st, []string{"u1", "u2"}, time.Hour)
@@ -178,11 +178,11 @@ func TestIngestOnce_ParserError_Continues(t *testing.T) {
 
 	st.EXPECT().
 		SaveNews(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(_ context.Context, items []models.News) error {
+		DoAndReturn(func(_ context.Context, items []models.News) ([]models.News, error) {
 			require.Len(t, items, 1)
 			require.Equal(t, "https://ok", items[0].Link)
 			require.Equal(t, "T", items[0].Title)
-			return nil
+			return nil, nil
 		})
 
 	svc := newServiceWithFetcherConfig(t, st, []string{"bad", "ok"}, time.Hour)
@@ -206,7 +206,7 @@ func TestIngestOnce_SaveError_Propagates(t *testing.T) {
 
 	st.EXPECT().
 		SaveNews(gomock.Any(), gomock.Any()).
-		Return(errors.New("db down"))
+		Return(nil, errors.New("db down"))
 
 	svc := newServiceWithFetcherConfig(t, st, []string{"u"}, time.Hour)
 
@@ -249,14 +249,14 @@ func TestStartIngest_OneShotAndCancel(t *testing.T) {
 
 	st.EXPECT().
 		SaveNews(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(_ context.Context, items []models.News) error {
+		DoAndReturn(func(_ context.Context, items []models.News) ([]models.News, error) {
 			require.Len(t, items, 1)
 			require.Equal(t, "https://x", items[0].Link)
 			select {
 			case savedCh <- struct{}{}:
 			default:
 			}
-			return nil
+			return nil, nil
 		})
 
 	svc := newServiceWithFetcherConfig(t, st, sources, 24*time.Hour)
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/pkg/feeds"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+)
+
+// PersonalizedInput — параметры персональной ленты.
+// UserID == uuid.Nil — анонимный читатель (ранжирование без предпочтений).
+type PersonalizedInput struct {
+	UserID    uuid.UUID
+	Limit     int32
+	PageToken string
+}
+
+// ListPersonalizedNews возвращает страницу ленты с учётом предпочтений читателя.
+//
+// Алгоритм:
+//   - предпочтения берутся из PreferencesSource; его отсутствие или ошибка не критичны —
+//     лента ранжируется без них;
+//   - заглушённые категории/источники и чужие языки отсекаются в хранилище,
+//     там же у новостей заполняется IsRead;
+//   - окно кандидатов — limit*candidate_factor новостей: сначала перенесённые
+//     с прошлых страниц, остальное — самые свежие после курсора;
+//   - из окна отбираются limit лучших по score = affinity * 0.5^(возраст/half_life)
+//     с ограничением: не больше ceil(limit*max_source_share) новостей одного источника;
+//   - не прошедшие отбор кандидаты переносятся в токен следующей страницы
+//     (см. personalizedToken): каждая новость попадает в ленту ровно один раз.
+//
+// Нормализация limit — как у ListNews. Ошибки:
+//   - ErrInvalidCursor — битый/чужой page_token;
+//   - прочие ошибки стораджа — обёрнутые и прокинуты наверх.
+func (s *Service) ListPersonalizedNews(ctx context.Context, in PersonalizedInput) (*models.Page, error) {
+	const op = "service/personalized/ListPersonalizedNews"
+
+	lg := log.From(ctx)
+	lg.Info("list_personalized_news_request",
+		slog.String("op", op),
+		slog.Bool("anonymous", in.UserID == uuid.Nil),
+		slog.Int("limit", int(in.Limit)),
+		slog.Bool("has_page_token", in.PageToken != ""),
+	)
+
+	limit := s.normalizeLimit(in.Limit)
+	prefs := s.readerPreferences(ctx, in.UserID)
+
+	pcfg := s.cfg.Personalization
+	factor := int32(max(pcfg.CandidateFactor, 1))
+	window := limit * factor
+
+	cursor, carried, err := decodePersonalizedToken(in.PageToken, int(max(s.cfg.LimitsConfig.Max, limit)*factor))
+	if err != nil {
+		lg.Warn("list_personalized_news_invalid_cursor", slog.String("op", op))
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidCursor)
+	}
+
+	var candidates []models.News
+	if len(carried) > 0 {
+		candidates, err = s.storage.NewsByIDs(ctx, carried, in.UserID)
+		if err != nil {
+			lg.Error("list_personalized_news_storage_error",
+				slog.String("op", op),
+				slog.String("err", err.Error()),
+			)
+
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	fresh := max(window-int32(len(candidates)), 1)
+	page, err := s.storage.ListNews(ctx, models.ListOptions{
+		Limit:     fresh,
+		PageToken: cursor,
+		Reader:    in.UserID,
+		Filter: &models.NewsFilter{
+			ExcludeCategories: prefs.MutedCategories,
+			ExcludeSources:    prefs.MutedSources,
+			Languages:         prefs.Languages,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			lg.Warn("list_personalized_news_invalid_cursor", slog.String("op", op))
+
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidCursor)
+		}
+
+		lg.Error("list_personalized_news_storage_error",
+			slog.String("op", op),
+			slog.String("err", err.Error()),
+		)
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(page.Items) > 0 {
+		cursor = page.NextPageToken
+	}
+	candidates = append(candidates, page.Items...)
+
+	out := &models.Page{
+		Items: rankNews(candidates, prefs.FollowedCategories, int(limit), time.Now().UTC(), pcfg),
+	}
+
+	emitted := make(map[uuid.UUID]struct{}, len(out.Items))
+	for _, it := range out.Items {
+		emitted[it.ID] = struct{}{}
+	}
+
+	var rest []uuid.UUID
+	for _, it := range candidates {
+		if _, ok := emitted[it.ID]; !ok {
+			rest = append(rest, it.ID)
+		}
+	}
+
+	// Неполная выборка после курсора и пустой перенос — кандидатов дальше нет.
+	if len(rest) > 0 || len(page.Items) == int(fresh) {
+		out.NextPageToken = encodePersonalizedToken(cursor, rest)
+	}
+
+	lg.Info("list_personalized_news_ok",
+		slog.String("op", op),
+		slog.Int("candidates", len(candidates)),
+		slog.Int("carried", len(rest)),
+		slog.Int("items", len(out.Items)),
+		slog.Bool("has_next_page", out.NextPageToken != ""),
+	)
+
+	return out, nil
+}
+
+// personalizedToken: "<курсор ListNews>" или "<курсор ListNews>.<base64url(id кандидатов)>" —
+// курсор ListNews (base64url) точки не содержит. Перенесённых кандидатов не больше окна,
+// поэтому токен ограничен по размеру; без переноса он совпадает с курсором ListNews.
+const personalizedTokenSep = "."
+
+func encodePersonalizedToken(cursor string, carried []uuid.UUID) string {
+	if len(carried) == 0 {
+		return cursor
+	}
+
+	raw := make([]byte, 0, len(carried)*len(uuid.UUID{}))
+	for _, id := range carried {
+		raw = append(raw, id[:]...)
+	}
+
+	return cursor + personalizedTokenSep + base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodePersonalizedToken разбирает токен персональной ленты; maxCarried — верхняя
+// граница переноса (больше окна при максимальном limit не бывает).
+func decodePersonalizedToken(token string, maxCarried int) (string, []uuid.UUID, error) {
+	cursor, rest, ok := strings.Cut(strings.TrimSpace(token), personalizedTokenSep)
+	if !ok {
+		return cursor, nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(rest)
+	if err != nil {
+		return "", nil, err
+	}
+
+	size := len(uuid.UUID{})
+	if len(raw) == 0 || len(raw)%size != 0 || len(raw)/size > maxCarried {
+		return "", nil, errors.New("bad carried candidates")
+	}
+
+	carried := make([]uuid.UUID, 0, len(raw)/size)
+	for i := 0; i < len(raw); i += size {
+		carried = append(carried, uuid.UUID(raw[i:i+size]))
+	}
+
+	return cursor, carried, nil
+}
+
+// readerPreferences читает предпочтения читателя. Анонимный вызов, отсутствие
+// источника и его ошибки дают пустые предпочтения (ошибка логируется).
+func (s *Service) readerPreferences(ctx context.Context, userID uuid.UUID) models.Preferences {
+	const op = "service/personalized/readerPreferences"
+
+	if userID == uuid.Nil || s.preferences == nil {
+		return models.Preferences{}
+	}
+
+	p, err := s.preferences.Preferences(ctx, userID)
+	if err != nil {
+		log.From(ctx).Warn("preferences_unavailable",
+			slog.String("op", op),
+			slog.String("user_id", userID.String()),
+			slog.String("err", err.Error()),
+		)
+
+		return models.Preferences{}
+	}
+
+	return *p
+}
+
+// rankNews выбирает до limit новостей из кандидатов по убыванию score
+// (при равенстве — свежие первыми), не больше ceil(limit*MaxSourceShare) от источника.
+func rankNews(items []models.News, followed []string, limit int, now time.Time, cfg config.PersonalizationConfig) []models.News {
+	if len(items) == 0 || limit <= 0 {
+		return nil
+	}
+
+	followedSet := make(map[string]struct{}, len(followed))
+	for _, c := range followed {
+		followedSet[c] = struct{}{}
+	}
+
+	halfLife := cfg.HalfLife.Hours()
+	if halfLife <= 0 {
+		halfLife = 1
+	}
+
+	scores := make([]float64, len(items))
+	order := make([]int, len(items))
+	for i, it := range items {
+		age := now.Sub(it.PublishedAt).Hours()
+		if age < 0 {
+			age = 0
+		}
+
+		affinity := 1.0
+		if _, ok := followedSet[feeds.Category(it.Category)]; ok {
+			affinity += cfg.CategoryBoost
+		}
+
+		scores[i] = affinity * math.Exp2(-age/halfLife)
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		x, y := order[a], order[b]
+		if scores[x] != scores[y] {
+			return scores[x] > scores[y]
+		}
+
+		if !items[x].PublishedAt.Equal(items[y].PublishedAt) {
+			return items[x].PublishedAt.After(items[y].PublishedAt)
+		}
+
+		return bytes.Compare(items[x].ID[:], items[y].ID[:]) > 0
+	})
+
+	perSource := int(math.Ceil(float64(limit) * cfg.MaxSourceShare))
+	if perSource < 1 {
+		perSource = 1
+	}
+
+	out := make([]models.News, 0, min(limit, len(items)))
+	taken := make(map[string]int)
+	for _, i := range order {
+		if len(out) == limit {
+			break
+		}
+
+		src := items[i].Source
+		if taken[src] >= perSource {
+			continue
+		}
+
+		taken[src]++
+		out = append(out, items[i])
+	}
+
+	return out
+}
@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/news-service/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// Unit-тесты персональной ленты (personalized.go):
+//  - rankNews: свежесть × affinity, бонус отслеживаемой категории, лимит на источник;
+//  - ListPersonalizedNews: фильтр из предпочтений, окно кандидатов, курсор и перенос
+//    неотобранных кандидатов (каждая новость ровно один раз), деградация без
+//    предпочтений, маппинг ErrInvalidCursor.
+
+type stubPreferences struct {
+	prefs *models.Preferences
+	err   error
+	calls int
+}
+
+func (s *stubPreferences) Preferences(context.Context, uuid.UUID) (*models.Preferences, error) {
+	s.calls++
+	return s.prefs, s.err
+}
+
+var testPersonalization = config.PersonalizationConfig{
+	CandidateFactor: 3,
+	HalfLife:        time.Hour,
+	CategoryBoost:   1,
+	MaxSourceShare:  0.5,
+}
+
+func newsAt(title, source, category string, published time.Time) models.News {
+	return models.News{ID: uuid.New(), Title: title, Source: source, Category: category, PublishedAt: published}
+}
+
+func titlesOf(items []models.News) []string {
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		out = append(out, it.Title)
+	}
+	return out
+}
+
+func TestRankNews(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("recency_and_affinity", func(t *testing.T) {
+		t.Parallel()
+
+		items := []models.News{
+			newsAt("fresh", "a.ru", "World", now),
+			newsAt("older_followed", "b.ru", "Tech", now.Add(-30*time.Minute)),
+			newsAt("old_followed", "c.ru", "tech", now.Add(-3*time.Hour)),
+		}
+
+		// Подписка на категорию удваивает score: получасовая «Tech» обгоняет свежую «World»,
+		// а трёхчасовая (score 2/8) уступает обеим.
+		got := rankNews(items, []string{"tech"}, 3, now, testPersonalization)
+		require.Equal(t, []string{"older_followed", "fresh", "old_followed"}, titlesOf(got))
+
+		// Без предпочтений — чистая свежесть.
+		got = rankNews(items, nil, 3, now, testPersonalization)
+		require.Equal(t, []string{"fresh", "older_followed", "old_followed"}, titlesOf(got))
+	})
+
+	t.Run("source_cap", func(t *testing.T) {
+		t.Parallel()
+
+		items := []models.News{
+			newsAt("a1", "a.ru", "", now),
+			newsAt("a2", "a.ru", "", now.Add(-time.Minute)),
+			newsAt("a3", "a.ru", "", now.Add(-2*time.Minute)),
+			newsAt("a4", "a.ru", "", now.Add(-3*time.Minute)),
+			newsAt("b1", "b.ru", "", now.Add(-time.Hour)),
+			newsAt("c1", "c.ru", "", now.Add(-2*time.Hour)),
+		}
+
+		// limit 4, доля 0.5 -> не больше 2 от источника.
+		got := rankNews(items, nil, 4, now, testPersonalization)
+		require.Equal(t, []string{"a1", "a2", "b1", "c1"}, titlesOf(got))
+	})
+
+	t.Run("short_when_candidates_lack_variety", func(t *testing.T) {
+		t.Parallel()
+
+		items := []models.News{
+			newsAt("a1", "a.ru", "", now),
+			newsAt("a2", "a.ru", "", now.Add(-time.Minute)),
+			newsAt("a3", "a.ru", "", now.Add(-2*time.Minute)),
+		}
+
+		got := rankNews(items, nil, 3, now, testPersonalization)
+		require.Equal(t, []string{"a1", "a2"}, titlesOf(got))
+		require.Nil(t, rankNews(nil, nil, 3, now, testPersonalization))
+	})
+}
+
+func newPersonalizedSvc(t *testing.T, st storage.Storage, prefs PreferencesSource) *Service {
+	t.Helper()
+
+	svc := New(st, config.Config{
+		LimitsConfig:    config.LimitsConfig{Default: 2, Max: 10},
+		Personalization: testPersonalization,
+	})
+	if prefs != nil {
+		svc.SetPreferencesSource(prefs)
+	}
+
+	return svc
+}
+
+func TestListPersonalizedNews_AppliesPreferences(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now().UTC()
+	uid := uuid.New()
+	prefs := &stubPreferences{prefs: &models.Preferences{
+		FollowedCategories: []string{"tech"},
+		MutedCategories:    []string{"sport"},
+		MutedSources:       []string{"spam.ru"},
+		Languages:          []string{"ru"},
+	}}
+
+	mockSt := mocks.NewMockStorage(ctrl)
+	mockSt.EXPECT().ListNews(gomock.Any(), models.ListOptions{
+		Limit:     6, // default 2 * candidate_factor 3
+		PageToken: "tok",
+		Reader:    uid,
+		Filter: &models.NewsFilter{
+			ExcludeCategories: []string{"sport"},
+			ExcludeSources:    []string{"spam.ru"},
+			Languages:         []string{"ru"},
+		},
+	}).Return(&models.Page{
+		Items: []models.News{
+			newsAt("a", "a.ru", "World", now),
+			newsAt("b", "b.ru", "Tech", now.Add(-10*time.Minute)),
+			newsAt("c", "c.ru", "World", now.Add(-20*time.Minute)),
+			newsAt("d", "d.ru", "World", now.Add(-30*time.Minute)),
+			newsAt("e", "e.ru", "World", now.Add(-40*time.Minute)),
+			newsAt("f", "f.ru", "World", now.Add(-50*time.Minute)),
+		},
+		NextPageToken: "next",
+	}, nil)
+
+	svc := newPersonalizedSvc(t, mockSt, prefs)
+
+	got, err := svc.ListPersonalizedNews(context.Background(), PersonalizedInput{UserID: uid, PageToken: "tok"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a"}, titlesOf(got.Items))
+	require.True(t, strings.HasPrefix(got.NextPageToken, "next."), "курсор на конец окна и перенос неотобранных")
+	require.Equal(t, 1, prefs.calls)
+
+	cursor, carried, err := decodePersonalizedToken(got.NextPageToken, 6)
+	require.NoError(t, err)
+	require.Equal(t, "next", cursor)
+	require.Len(t, carried, 4)
+}
+
+func TestListPersonalizedNews_AnonymousAndDegraded(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now().UTC()
+	page := &models.Page{
+		Items:         []models.News{newsAt("a", "a.ru", "", now)},
+		NextPageToken: "next",
+	}
+
+	uid := uuid.New()
+	mockSt := mocks.NewMockStorage(ctrl)
+	mockSt.EXPECT().
+		ListNews(gomock.Any(), models.ListOptions{Limit: 6, Filter: &models.NewsFilter{}}).
+		Return(page, nil)
+	mockSt.EXPECT().
+		ListNews(gomock.Any(), models.ListOptions{Limit: 6, Reader: uid, Filter: &models.NewsFilter{}}).
+		Return(page, nil)
+
+	prefs := &stubPreferences{err: errors.New("users down")}
+	svc := newPersonalizedSvc(t, mockSt, prefs)
+
+	// Анонимный читатель — предпочтения не запрашиваются.
+	got, err := svc.ListPersonalizedNews(context.Background(), PersonalizedInput{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, titlesOf(got.Items))
+	require.Empty(t, got.NextPageToken, "неполное окно — страниц больше нет")
+	require.Zero(t, prefs.calls)
+
+	// Ошибка users-service не роняет ленту.
+	got, err = svc.ListPersonalizedNews(context.Background(), PersonalizedInput{UserID: uid})
+	require.NoError(t, err)
+	require.Len(t, got.Items, 1)
+	require.Equal(t, 1, prefs.calls)
+}
+
+// memFeed — лента в памяти поверх мока Storage: ListNews с курсором-индексом и NewsByIDs.
+func memFeed(ctrl *gomock.Controller, feed []models.News) *mocks.MockStorage {
+	st := mocks.NewMockStorage(ctrl)
+
+	st.EXPECT().ListNews(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, opts models.ListOptions) (*models.Page, error) {
+			from := 0
+			if opts.PageToken != "" {
+				n, err := strconv.Atoi(strings.TrimPrefix(opts.PageToken, "i"))
+				if err != nil {
+					return nil, storage.ErrInvalidCursor
+				}
+				from = n
+			}
+
+			to := min(from+int(opts.Limit), len(feed))
+			page := &models.Page{Items: append([]models.News(nil), feed[from:to]...)}
+			if to > from {
+				page.NextPageToken = fmt.Sprintf("i%d", to)
+			}
+
+			return page, nil
+		})
+
+	st.EXPECT().NewsByIDs(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, ids []uuid.UUID, _ uuid.UUID) ([]models.News, error) {
+			var out []models.News
+			for _, it := range feed {
+				for _, id := range ids {
+					if it.ID == id {
+						out = append(out, it)
+					}
+				}
+			}
+
+			return out, nil
+		})
+
+	return st
+}
+
+func TestListPersonalizedNews_PagesCoverFeedExactlyOnce(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Лента по убыванию published_at: один источник доминирует, часть новостей
+	// в отслеживаемой категории — отбор на каждой странице оставляет кандидатов.
+	now := time.Now().UTC()
+	sources := []string{"a.ru", "a.ru", "a.ru", "b.ru", "a.ru", "c.ru"}
+	var feed []models.News
+	for i := range 40 {
+		category := "World"
+		if i%7 == 3 {
+			category = "Tech"
+		}
+		feed = append(feed, newsAt(fmt.Sprintf("n%02d", i), sources[i%len(sources)], category, now.Add(-time.Duration(i)*10*time.Minute)))
+	}
+
+	uid := uuid.New()
+	prefs := &stubPreferences{prefs: &models.Preferences{FollowedCategories: []string{"tech"}}}
+	svc := newPersonalizedSvc(t, memFeed(ctrl, feed), prefs)
+
+	seen := make(map[string]int, len(feed))
+	token := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, len(feed), "лента должна закончиться")
+
+		got, err := svc.ListPersonalizedNews(context.Background(), PersonalizedInput{UserID: uid, Limit: 4, PageToken: token})
+		require.NoError(t, err)
+		require.NotEmpty(t, got.Items)
+		for _, it := range got.Items {
+			seen[it.Title]++
+		}
+
+		if got.NextPageToken == "" {
+			break
+		}
+		token = got.NextPageToken
+	}
+
+	require.Len(t, seen, len(feed))
+	for title, n := range seen {
+		require.Equal(t, 1, n, title)
+	}
+}
+
+func TestDecodePersonalizedToken(t *testing.T) {
+	t.Parallel()
+
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	cursor, carried, err := decodePersonalizedToken(encodePersonalizedToken("abc", ids), 2)
+	require.NoError(t, err)
+	require.Equal(t, "abc", cursor)
+	require.Equal(t, ids, carried)
+
+	// Без переноса токен — обычный курсор ListNews.
+	require.Equal(t, "abc", encodePersonalizedToken("abc", nil))
+
+	for _, bad := range []string{"abc.!!", "abc.", "abc.AAAA", encodePersonalizedToken("abc", ids)} {
+		_, _, err = decodePersonalizedToken(bad, 1)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestListPersonalizedNews_InvalidCursor_Mapped(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSt := mocks.NewMockStorage(ctrl)
+	mockSt.EXPECT().ListNews(gomock.Any(), gomock.Any()).Return(nil, storage.ErrInvalidCursor)
+
+	svc := newPersonalizedSvc(t, mockSt, nil)
+
+	_, err := svc.ListPersonalizedNews(context.Background(), PersonalizedInput{PageToken: "bad"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
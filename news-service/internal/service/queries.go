@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/storage"
 
@@ -19,6 +21,9 @@ import (
 // - limit > max -> cfg.LimitsConfig.Max;
 // - пустой pageToken -> первая страница.
 //
+// opts.Reader/OnlyUnread передаются в хранилище как есть: с читателем
+// у новостей заполняется IsRead, OnlyUnread оставляет только непрочитанные.
+//
 // Ошибки:
 // - ErrInvalidCursor — битый/чужой page_token (маппинг storage.ErrInvalidCursor);
 // - прочие ошибки стораджа — обёрнутые и прокинуты наверх.
@@ -30,15 +35,11 @@ func (s *Service) ListNews(ctx context.Context, opts models.ListOptions) (*model
 		slog.String("op", op),
 		slog.Int("limit", int(opts.Limit)),
 		slog.Bool("has_page_token", opts.PageToken != ""),
+		slog.Bool("has_reader", opts.Reader != uuid.Nil),
+		slog.Bool("only_unread", opts.OnlyUnread),
 	)
 
-	if opts.Limit <= 0 {
-		opts.Limit = s.cfg.LimitsConfig.Default
-	}
-
-	if s.cfg.LimitsConfig.Max > 0 && opts.Limit > s.cfg.LimitsConfig.Max {
-		opts.Limit = s.cfg.LimitsConfig.Max
-	}
+	opts.Limit = s.normalizeLimit(opts.Limit)
 
 	page, err := s.storage.ListNews(ctx, opts)
 	if err != nil {
@@ -108,3 +109,114 @@ func (s *Service) NewsByID(ctx context.Context, id string) (*models.News, error)
 
 	return news, nil
 }
+
+// NewsByIDs возвращает новости по списку идентификаторов одним запросом к хранилищу.
+//
+// Поведение:
+//   - дубликаты схлопываются (первое вхождение), порядок запроса сохраняется;
+//   - отсутствующие и некорректные id не ломают вызов — попадают в MissingIDs;
+//   - reader (если задан) заполняет IsRead.
+//
+// Ошибки:
+//   - ErrInvalidArgument — пустой список или больше cfg.LimitsConfig.MaxBatch id;
+//   - прочие ошибки стораджа — обёрнутые и прокинуты наверх.
+func (s *Service) NewsByIDs(ctx context.Context, ids []string, reader uuid.UUID) (*models.NewsBatch, error) {
+	const op = "service/queries/NewsByIDs"
+
+	lg := log.From(ctx)
+
+	// Дубликаты — по каноническому UUID (регистр/формат записи не важны).
+	type requested struct {
+		raw   string
+		id    uuid.UUID
+		valid bool
+	}
+
+	order := make([]requested, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for _, raw := range ids {
+		r := requested{raw: strings.TrimSpace(raw)}
+		key := r.raw
+		if u, err := uuid.Parse(r.raw); err == nil {
+			r.id, r.valid = u, true
+			key = u.String()
+		}
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		order = append(order, r)
+	}
+
+	maxBatch := int(s.cfg.LimitsConfig.MaxBatch)
+	if len(order) == 0 || (maxBatch > 0 && len(order) > maxBatch) {
+		lg.Warn("news_by_ids_invalid_argument",
+			slog.String("op", op),
+			slog.Int("ids", len(order)),
+		)
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	lg.Info("news_by_ids_request",
+		slog.String("op", op),
+		slog.Int("ids", len(order)),
+		slog.Bool("has_reader", reader != uuid.Nil),
+	)
+
+	parsed := make([]uuid.UUID, 0, len(order))
+	for _, r := range order {
+		if r.valid {
+			parsed = append(parsed, r.id)
+		}
+	}
+
+	found, err := s.storage.NewsByIDs(ctx, parsed, reader)
+	if err != nil {
+		lg.Error("news_by_ids_storage_error",
+			slog.String("op", op),
+			slog.String("err", err.Error()),
+		)
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	byID := make(map[uuid.UUID]models.News, len(found))
+	for _, n := range found {
+		byID[n.ID] = n
+	}
+
+	out := &models.NewsBatch{Items: make([]models.News, 0, len(found))}
+	for _, r := range order {
+		n, ok := byID[r.id]
+		if !r.valid || !ok {
+			out.MissingIDs = append(out.MissingIDs, r.raw)
+			continue
+		}
+
+		out.Items = append(out.Items, n)
+	}
+
+	lg.Info("news_by_ids_ok",
+		slog.String("op", op),
+		slog.Int("items", len(out.Items)),
+		slog.Int("missing", len(out.MissingIDs)),
+	)
+
+	return out, nil
+}
+
+// normalizeLimit: limit <= 0 -> cfg.LimitsConfig.Default; limit > max -> cfg.LimitsConfig.Max.
+func (s *Service) normalizeLimit(limit int32) int32 {
+	if limit <= 0 {
+		limit = s.cfg.LimitsConfig.Default
+	}
+
+	if s.cfg.LimitsConfig.Max > 0 && limit > s.cfg.LimitsConfig.Max {
+		limit = s.cfg.LimitsConfig.Max
+	}
+
+	return limit
+}
@@ -3,9 +3,11 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/config"
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/storage"
@@ -26,6 +28,10 @@ import (
 //      * маппинг storage.ErrNotFound → service.ErrNotFound;
 //      * прозрачная прокидка «остальных» ошибок;
 //      * happy-path (возврат сущности как есть).
+//  - NewsByIDs:
+//      * валидация размера батча (пусто / больше MaxBatch после схлопывания дубликатов);
+//      * порядок запроса, отсутствующие и некорректные id в MissingIDs;
+//      * прокидка reader и ошибок стораджа.
 
 // newSvcForTest — фабрика Service с контролируемым cfg и мок-хранилищем.
 func newSvcForTest(t *testing.T, st storage.Storage) *Service {
@@ -248,3 +254,51 @@ func TestNewsByID_OK(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, entity, got)
 }
+
+// TestNewsByIDs — порядок, дубликаты, отсутствующие id и лимит батча.
+func TestNewsByIDs(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSt := mocks.NewMockStorage(ctrl)
+	svc := New(mockSt, config.Config{LimitsConfig: config.LimitsConfig{Default: 12, Max: 100, MaxBatch: 3}})
+
+	a, b, gone := uuid.New(), uuid.New(), uuid.New()
+	reader := uuid.New()
+
+	_, err := svc.NewsByIDs(context.Background(), nil, uuid.Nil)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	// 4 уникальных id > MaxBatch, даже с дубликатом.
+	_, err = svc.NewsByIDs(context.Background(), []string{a.String(), b.String(), a.String(), gone.String(), "x"}, uuid.Nil)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	// Хранилище отдаёт в произвольном порядке; дубликат в другом регистре не считается.
+	mockSt.EXPECT().
+		NewsByIDs(gomock.Any(), []uuid.UUID{b, gone, a}, reader).
+		Return([]models.News{{ID: a, Title: "a"}, {ID: b, Title: "b", IsRead: true}}, nil)
+
+	got, err := svc.NewsByIDs(context.Background(), []string{
+		b.String(), gone.String(), strings.ToUpper(b.String()), " " + a.String(),
+	}, reader)
+	require.NoError(t, err)
+	require.Len(t, got.Items, 2)
+	require.Equal(t, "b", got.Items[0].Title)
+	require.True(t, got.Items[0].IsRead)
+	require.Equal(t, "a", got.Items[1].Title)
+	require.Equal(t, []string{gone.String()}, got.MissingIDs)
+
+	// Некорректный id в хранилище не уходит.
+	mockSt.EXPECT().NewsByIDs(gomock.Any(), []uuid.UUID{}, uuid.Nil).Return(nil, nil)
+	got, err = svc.NewsByIDs(context.Background(), []string{"bad"}, uuid.Nil)
+	require.NoError(t, err)
+	require.Empty(t, got.Items)
+	require.Equal(t, []string{"bad"}, got.MissingIDs)
+
+	mockSt.EXPECT().NewsByIDs(gomock.Any(), []uuid.UUID{a}, uuid.Nil).Return(nil, errors.New("db fail"))
+	_, err = svc.NewsByIDs(context.Background(), []string{a.String()}, uuid.Nil)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrInvalidArgument)
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+)
+
+// maxMarkReadBatch — сколько новостей можно отметить прочитанными за один вызов.
+const maxMarkReadBatch = 100
+
+// MarkRead отмечает новости прочитанными читателем userID.
+//
+// Валидация:
+//   - userID обязателен, ids — от 1 до maxMarkReadBatch (дубликаты схлопываются),
+//     иначе ErrInvalidArgument.
+//
+// Поведение:
+//   - несуществующие новости пропускаются без ошибки;
+//   - у читателя хранится не больше cfg.ReadHistory.MaxPerUser отметок.
+func (s *Service) MarkRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	const op = "service/reads/MarkRead"
+
+	lg := log.From(ctx)
+
+	uniq := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		if id == uuid.Nil {
+			continue
+		}
+
+		if _, ok := seen[id]; ok {
+			continue
+		}
+
+		seen[id] = struct{}{}
+		uniq = append(uniq, id)
+	}
+
+	if userID == uuid.Nil || len(uniq) == 0 || len(uniq) > maxMarkReadBatch {
+		lg.Warn("mark_read_invalid_argument",
+			slog.String("op", op),
+			slog.Int("ids", len(uniq)),
+		)
+
+		return fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	if err := s.storage.MarkRead(ctx, userID, uniq, s.cfg.ReadHistory.MaxPerUser); err != nil {
+		lg.Error("mark_read_storage_error",
+			slog.String("op", op),
+			slog.String("err", err.Error()),
+		)
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkAllRead отмечает прочитанным всё, что ListNews выдаёт с курсором pageToken:
+// страницу, которую клиент запросил с этим токеном, и всё старше. Пустой pageToken —
+// первая страница, то есть всё, опубликованное к текущему моменту.
+//
+// Ошибки:
+//   - ErrInvalidArgument — пустой userID;
+//   - ErrInvalidCursor — битый/чужой page_token.
+func (s *Service) MarkAllRead(ctx context.Context, userID uuid.UUID, pageToken string) error {
+	const op = "service/reads/MarkAllRead"
+
+	lg := log.From(ctx)
+
+	if userID == uuid.Nil {
+		lg.Warn("mark_all_read_invalid_argument", slog.String("op", op))
+
+		return fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	if err := s.storage.MarkAllRead(ctx, userID, pageToken); err != nil {
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			lg.Warn("mark_all_read_invalid_cursor", slog.String("op", op))
+
+			return fmt.Errorf("%s: %w", op, ErrInvalidCursor)
+		}
+
+		lg.Error("mark_all_read_storage_error",
+			slog.String("op", op),
+			slog.String("err", err.Error()),
+		)
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// StartReadHistoryPurge периодически удаляет отметки о прочтении старше
+// cfg.ReadHistory.Retention. Останавливается по ctx.
+func (s *Service) StartReadHistoryPurge(ctx context.Context) {
+	const op = "service/reads/StartReadHistoryPurge"
+
+	cfg := s.cfg.ReadHistory
+	lg := log.From(ctx)
+	lg.Info("read_history_purge_start",
+		slog.String("op", op),
+		slog.Duration("retention", cfg.Retention),
+		slog.Duration("interval", cfg.PurgeInterval),
+	)
+
+	ticker := time.NewTicker(cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		s.purgeReadHistoryOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			lg.Info("read_history_purge_stop", slog.String("op", op))
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeReadHistoryOnce — один проход уборки; ошибки только логируются.
+func (s *Service) purgeReadHistoryOnce(ctx context.Context) {
+	const op = "service/reads/purgeReadHistoryOnce"
+
+	lg := log.From(ctx)
+
+	n, err := s.storage.PurgeReadHistory(ctx, time.Now().UTC().Add(-s.cfg.ReadHistory.Retention))
+	if err != nil {
+		lg.Warn("read_history_purge_error",
+			slog.String("op", op),
+			slog.String("err", err.Error()),
+		)
+
+		return
+	}
+
+	if n > 0 {
+		lg.Info("read_history_purged",
+			slog.String("op", op),
+			slog.Int64("deleted", n),
+		)
+	}
+}
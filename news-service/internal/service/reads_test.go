@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/news-service/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// Unit-тесты истории чтения (reads.go):
+//  - MarkRead: валидация, схлопывание дубликатов, передача MaxPerUser;
+//  - MarkAllRead: валидация, маппинг ErrInvalidCursor;
+//  - purgeReadHistoryOnce: граница retention.
+
+func newReadsSvc(t *testing.T, st storage.Storage) *Service {
+	t.Helper()
+
+	return New(st, config.Config{
+		ReadHistory: config.ReadHistoryConfig{
+			Retention:     24 * time.Hour,
+			MaxPerUser:    50,
+			PurgeInterval: time.Hour,
+		},
+	})
+}
+
+func TestMarkRead(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSt := mocks.NewMockStorage(ctrl)
+	svc := newReadsSvc(t, mockSt)
+
+	uid := uuid.New()
+	a, b := uuid.New(), uuid.New()
+
+	tooMany := make([]uuid.UUID, maxMarkReadBatch+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New()
+	}
+
+	for _, tc := range []struct {
+		name   string
+		userID uuid.UUID
+		ids    []uuid.UUID
+	}{
+		{"no_user", uuid.Nil, []uuid.UUID{a}},
+		{"no_ids", uid, nil},
+		{"only_nil_ids", uid, []uuid.UUID{uuid.Nil}},
+		{"too_many", uid, tooMany},
+	} {
+		err := svc.MarkRead(context.Background(), tc.userID, tc.ids)
+		require.ErrorIs(t, err, ErrInvalidArgument, tc.name)
+	}
+
+	// Дубликаты и Nil отбрасываются, порядок сохраняется.
+	mockSt.EXPECT().MarkRead(gomock.Any(), uid, []uuid.UUID{a, b}, 50).Return(nil)
+	require.NoError(t, svc.MarkRead(context.Background(), uid, []uuid.UUID{a, uuid.Nil, b, a}))
+
+	boom := errors.New("db down")
+	mockSt.EXPECT().MarkRead(gomock.Any(), uid, []uuid.UUID{a}, 50).Return(boom)
+	require.ErrorIs(t, svc.MarkRead(context.Background(), uid, []uuid.UUID{a}), boom)
+}
+
+func TestMarkAllRead(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSt := mocks.NewMockStorage(ctrl)
+	svc := newReadsSvc(t, mockSt)
+
+	uid := uuid.New()
+
+	require.ErrorIs(t, svc.MarkAllRead(context.Background(), uuid.Nil, ""), ErrInvalidArgument)
+
+	mockSt.EXPECT().MarkAllRead(gomock.Any(), uid, "tok").Return(nil)
+	require.NoError(t, svc.MarkAllRead(context.Background(), uid, "tok"))
+
+	mockSt.EXPECT().MarkAllRead(gomock.Any(), uid, "bad").Return(storage.ErrInvalidCursor)
+	require.ErrorIs(t, svc.MarkAllRead(context.Background(), uid, "bad"), ErrInvalidCursor)
+
+	boom := errors.New("db down")
+	mockSt.EXPECT().MarkAllRead(gomock.Any(), uid, "").Return(boom)
+	err := svc.MarkAllRead(context.Background(), uid, "")
+	require.ErrorIs(t, err, boom)
+	require.NotErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestPurgeReadHistoryOnce(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSt := mocks.NewMockStorage(ctrl)
+	svc := newReadsSvc(t, mockSt)
+
+	start := time.Now().UTC()
+	mockSt.EXPECT().
+		PurgeReadHistory(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+			// before = now - retention.
+			require.WithinDuration(t, start.Add(-24*time.Hour), before, time.Minute)
+			return 3, nil
+		})
+	svc.purgeReadHistoryOnce(context.Background())
+
+	// Ошибка только логируется.
+	mockSt.EXPECT().PurgeReadHistory(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db down"))
+	svc.purgeReadHistoryOnce(context.Background())
+}
@@ -2,10 +2,14 @@
 package service
 
 import (
+	"context"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/pkg/pubsub"
 )
 
 var (
@@ -15,12 +19,23 @@ var (
 	// ErrInvalidCursor — битый/чужой page_token.
 	// Транспорт: codes.InvalidArgument.
 	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidArgument — некорректные входные данные.
+	// Транспорт: codes.InvalidArgument.
+	ErrInvalidArgument = errors.New("invalid argument")
 )
 
+// PreferencesSource — предпочтения читателя (users-service).
+// Реализация: internal/users.Client.
+type PreferencesSource interface {
+	Preferences(ctx context.Context, userID uuid.UUID) (*models.Preferences, error)
+}
+
 // Service — описывает бизнес-логику news-service.
 type Service struct {
-	storage storage.Storage
-	cfg     config.Config
+	storage     storage.Storage
+	cfg         config.Config
+	preferences PreferencesSource
+	news        *pubsub.Broker[models.News]
 }
 
 // New создает новый экземпляр Service.
@@ -28,5 +43,12 @@ func New(storage storage.Storage, cfg config.Config) *Service {
 	return &Service{
 		storage: storage,
 		cfg:     cfg,
+		news:    pubsub.New[models.News](),
 	}
 }
+
+// SetPreferencesSource устанавливает источник предпочтений читателя (опционально).
+// Без него персональная лента ранжируется без предпочтений.
+func (s *Service) SetPreferencesSource(p PreferencesSource) {
+	s.preferences = p
+}
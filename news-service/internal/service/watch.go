@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"github.com/pribylovaa/go-news-aggregator/pkg/pubsub"
+)
+
+// newsTopic — ключ брокера живой ленты (одна лента на всех подписчиков).
+const newsTopic = "news"
+
+// NewsWatch — подписка на живую ленту.
+//
+// Порядок выдачи: Replay, затем Live с пропуском Seq <= последнего из Replay
+// (подписка оформляется до чтения пропущенного, поэтому события могут повториться).
+type NewsWatch struct {
+	// Reset — пропущенное после lastEventID не восстановить (больше cfg.Watch.Replay
+	// или неизвестный курсор): клиенту нужно перечитать ленту.
+	Reset bool
+	// Replay — пропущенные новости по возрастанию Seq.
+	Replay []models.News
+	// Live — новые новости; C закрывается, если подписчик не успевает (Lagged).
+	Live *pubsub.Subscription[models.News]
+}
+
+// EventID — курсор события живой ленты (Seq новости).
+func EventID(n models.News) string {
+	return strconv.FormatInt(n.Seq, 10)
+}
+
+// WatchNews подписывает на новые новости (сохранённые ingest этой реплики).
+// lastEventID — EventID последнего полученного события; пусто — только новые.
+// Live обязательно закрыть.
+//
+// Ошибки: ошибка чтения пропущенного из хранилища (подписка при этом закрывается).
+func (s *Service) WatchNews(ctx context.Context, lastEventID string) (*NewsWatch, error) {
+	const op = "service/watch/WatchNews"
+
+	w := &NewsWatch{Live: s.news.Watch(newsTopic, s.cfg.Watch.Buffer)}
+
+	lastEventID = strings.TrimSpace(lastEventID)
+	if lastEventID == "" {
+		return w, nil
+	}
+
+	after, err := strconv.ParseInt(lastEventID, 10, 64)
+	if err != nil || after < 0 {
+		w.Reset = true
+		return w, nil
+	}
+
+	// На одну больше лимита — чтобы отличить «ровно Replay» от «слишком много».
+	limit := s.cfg.Watch.Replay
+	missed, err := s.storage.NewsAfter(ctx, after, limit+1)
+	if err != nil {
+		w.Live.Close()
+		log.From(ctx).Error("watch_news_replay_error",
+			slog.String("op", op),
+			slog.String("err", err.Error()),
+		)
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(missed) > limit {
+		w.Reset = true
+		return w, nil
+	}
+
+	w.Replay = missed
+
+	return w, nil
+}
+
+// publishNews рассылает подписчикам живой ленты впервые сохранённые новости.
+func (s *Service) publishNews(items []models.News) {
+	for _, n := range items {
+		s.news.Publish(newsTopic, n)
+	}
+}
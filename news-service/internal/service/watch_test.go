@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/news-service/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// Unit-тесты живой ленты (watch.go):
+//  - WatchNews: без курсора — только новые; досылка пропущенного;
+//    reset при битом курсоре и при превышении Replay; ошибка хранилища;
+//  - ingestOnce публикует подписчикам только впервые сохранённые новости;
+//  - медленный подписчик отключается (Lagged), не блокируя ingest.
+
+func newWatchSvc(st *mocks.MockStorage, buffer, replay int) *Service {
+	return New(st, config.Config{
+		Fetcher:  config.FetcherConfig{Interval: time.Hour},
+		Timeouts: config.TimeoutConfig{Service: time.Second},
+		Watch:    config.WatchConfig{Buffer: buffer, Replay: replay},
+	})
+}
+
+func seqNews(seq int64) models.News {
+	return models.News{ID: uuid.New(), Title: "t", Link: fmt.Sprintf("https://example.org/%d", seq), Seq: seq}
+}
+
+func TestWatchNews_NoCursor_LiveOnly(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	st := mocks.NewMockStorage(ctrl)
+	svc := newWatchSvc(st, 4, 10)
+
+	w, err := svc.WatchNews(context.Background(), "  ")
+	require.NoError(t, err)
+	defer w.Live.Close()
+
+	require.False(t, w.Reset)
+	require.Empty(t, w.Replay)
+
+	svc.publishNews([]models.News{seqNews(7)})
+	select {
+	case n := <-w.Live.C:
+		require.Equal(t, int64(7), n.Seq)
+	case <-time.After(time.Second):
+		t.Fatal("no live event")
+	}
+}
+
+func TestWatchNews_Replay(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	st := mocks.NewMockStorage(ctrl)
+	svc := newWatchSvc(st, 4, 2)
+
+	missed := []models.News{seqNews(11), seqNews(12)}
+	st.EXPECT().NewsAfter(gomock.Any(), int64(10), 3).Return(missed, nil)
+
+	w, err := svc.WatchNews(context.Background(), "10")
+	require.NoError(t, err)
+	defer w.Live.Close()
+
+	require.False(t, w.Reset)
+	require.Equal(t, missed, w.Replay)
+}
+
+func TestWatchNews_Reset(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	st := mocks.NewMockStorage(ctrl)
+	svc := newWatchSvc(st, 4, 2)
+
+	// Битый курсор — без обращения к хранилищу.
+	for _, id := range []string{"abc", "-1"} {
+		w, err := svc.WatchNews(context.Background(), id)
+		require.NoError(t, err)
+		require.True(t, w.Reset, id)
+		w.Live.Close()
+	}
+
+	// Пропущено больше Replay.
+	st.EXPECT().NewsAfter(gomock.Any(), int64(1), 3).
+		Return([]models.News{seqNews(2), seqNews(3), seqNews(4)}, nil)
+
+	w, err := svc.WatchNews(context.Background(), "1")
+	require.NoError(t, err)
+	defer w.Live.Close()
+	require.True(t, w.Reset)
+	require.Empty(t, w.Replay)
+}
+
+func TestWatchNews_StorageError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	st := mocks.NewMockStorage(ctrl)
+	svc := newWatchSvc(st, 4, 2)
+
+	st.EXPECT().NewsAfter(gomock.Any(), int64(5), 3).Return(nil, errors.New("db down"))
+
+	w, err := svc.WatchNews(context.Background(), "5")
+	require.Error(t, err)
+	require.Nil(t, w)
+}
+
+func TestIngestOnce_PublishesInserted(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	st := mocks.NewMockStorage(ctrl)
+	svc := newWatchSvc(st, 4, 10)
+
+	w, err := svc.WatchNews(context.Background(), "")
+	require.NoError(t, err)
+	defer w.Live.Close()
+
+	inserted := seqNews(42)
+	st.EXPECT().SaveNews(gomock.Any(), gomock.Len(2)).Return([]models.News{inserted}, nil)
+
+	parser := &stubParser{res: []ParseResult{{URL: "u", Items: []models.News{
+		{Title: "a", Link: "https://example.org/a"},
+		{Title: "b", Link: "https://example.org/b"},
+	}}}}
+	require.NoError(t, svc.ingestOnce(context.Background(), parser, []string{"u"}))
+
+	select {
+	case n := <-w.Live.C:
+		require.Equal(t, inserted, n)
+	case <-time.After(time.Second):
+		t.Fatal("no live event")
+	}
+
+	select {
+	case n := <-w.Live.C:
+		t.Fatalf("unexpected event %+v", n)
+	default:
+	}
+}
+
+func TestWatchNews_SlowConsumerEvicted(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	st := mocks.NewMockStorage(ctrl)
+	svc := newWatchSvc(st, 2, 10)
+
+	w, err := svc.WatchNews(context.Background(), "")
+	require.NoError(t, err)
+	defer w.Live.Close()
+
+	// Публикация не блокируется, хотя подписчик ничего не читает.
+	done := make(chan struct{})
+	go func() {
+		svc.publishNews([]models.News{seqNews(1), seqNews(2), seqNews(3), seqNews(4)})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked on slow consumer")
+	}
+
+	var got int
+	for range w.Live.C {
+		got++
+	}
+	require.Equal(t, 2, got)
+	require.True(t, w.Live.Lagged())
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -23,19 +24,23 @@ import (
 //   - long_description — обновляется, только если пришёл непустой и длиннее текущего;
 //   - image_url/category/short_description — обновляются, если пришли новые непустые значения;
 //   - published_at — не меняется;
+//   - source — всегда (выводится из link);
+//   - language — только если пришёл непустой;
 //   - fetched_at — обновляется всегда.
-func (s *Storage) SaveNews(ctx context.Context, items []models.News) error {
+//
+// Возвращает впервые вставленные строки (xmax = 0 у RETURNING) с id и seq, по возрастанию seq.
+func (s *Storage) SaveNews(ctx context.Context, items []models.News) ([]models.News, error) {
 	const op = "storage/postgres/SaveNews"
 
 	if len(items) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	batch := &pgx.Batch{}
 	for _, item := range items {
 		batch.Queue(`
-		INSERT INTO news (title, category, short_description, long_description, link, image_url, published_at, fetched_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO news (title, category, short_description, long_description, link, image_url, published_at, fetched_at, source, language)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		ON CONFLICT (link) DO UPDATE 
 		SET 
 		title = EXCLUDED.title,
@@ -48,26 +53,81 @@ func (s *Storage) SaveNews(ctx context.Context, items []models.News) error {
 			THEN EXCLUDED.category ELSE news.category END,
 		short_description = CASE WHEN EXCLUDED.short_description IS NOT NULL AND EXCLUDED.short_description <> ''
 			THEN EXCLUDED.short_description ELSE news.short_description END,
+		source = EXCLUDED.source,
+		language = CASE WHEN EXCLUDED.language <> '' THEN EXCLUDED.language ELSE news.language END,
 		fetched_at = EXCLUDED.fetched_at
+		RETURNING id, seq, (xmax = 0)
 		`, item.Title, item.Category, item.ShortDescription, item.LongDescription, item.Link,
-			item.ImageURL, item.PublishedAt.UTC(), item.FetchedAt.UTC())
+			item.ImageURL, item.PublishedAt.UTC(), item.FetchedAt.UTC(), item.Source, item.Language)
 	}
 
 	br := s.db.SendBatch(ctx, batch)
 	defer br.Close()
 
+	var inserted []models.News
 	for i := 0; i < batch.Len(); i++ {
-		if _, err := br.Exec(); err != nil {
-			return fmt.Errorf("%s: batch item %d: %w", op, i, err)
+		item := items[i]
+
+		var isNew bool
+		if err := br.QueryRow().Scan(&item.ID, &item.Seq, &isNew); err != nil {
+			return nil, fmt.Errorf("%s: batch item %d: %w", op, i, err)
+		}
+
+		if isNew {
+			inserted = append(inserted, item)
 		}
 	}
 
-	return nil
+	sort.Slice(inserted, func(i, j int) bool { return inserted[i].Seq < inserted[j].Seq })
+
+	return inserted, nil
+}
+
+// NewsAfter возвращает до limit новостей с seq > after по возрастанию seq.
+func (s *Storage) NewsAfter(ctx context.Context, after int64, limit int) ([]models.News, error) {
+	const op = "storage/postgres/NewsAfter"
+
+	rows, err := s.db.Query(ctx, `SELECT `+newsColumns+`, seq FROM news WHERE seq > $1 ORDER BY seq LIMIT $2`, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: query: %w", op, err)
+	}
+	defer rows.Close()
+
+	var out []models.News
+	for rows.Next() {
+		var seq int64
+		news, scanErr := scanNews(rows, &seq)
+		if scanErr != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, scanErr)
+		}
+
+		news.Seq = seq
+		out = append(out, *news)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, rows.Err())
+	}
+
+	return out, nil
 }
 
+// newsColumns — колонки news в порядке scanNews.
+const newsColumns = `id, title, category, short_description, long_description, link, image_url, published_at, fetched_at, source, language`
+
+// readExpr — прочитана ли строка news читателем $N: отметка в news_reads
+// или позиция старше водяного знака read_marks. Оба условия — по первичным ключам.
+const readExpr = `(EXISTS (SELECT 1 FROM news_reads r WHERE r.user_id = $%[1]d AND r.news_id = news.id)
+	OR EXISTS (SELECT 1 FROM read_marks m WHERE m.user_id = $%[1]d
+		AND (news.published_at, news.id) < (m.read_published_at, m.read_news_id)))`
+
 // ListNews возвращает страницу новостей с курсорной пагинацией.
 // Сортировка фиксирована: published_at DESC, id DESC.
 // page_token — непрозрачная строка (base64url).
+// opts.Filter (если задан) сужает выборку: категории сравниваются по lower(category),
+// новости без языка проходят фильтр по Languages.
+// opts.Reader (если задан) вычисляет is_read (см. readExpr); с opts.OnlyUnread
+// прочитанные отсекаются в том же запросе.
 // При некорректном токене возвращает storage.ErrInvalidCursor.
 func (s *Storage) ListNews(ctx context.Context, opts models.ListOptions) (*models.Page, error) {
 	const op = "storage/postgres/ListNews"
@@ -78,31 +138,57 @@ func (s *Storage) ListNews(ctx context.Context, opts models.ListOptions) (*model
 		limit = 1
 	}
 
-	var rows pgx.Rows
-	var err error
+	var (
+		where []string
+		args  []any
+	)
 
-	if opts.PageToken == "" {
-		rows, err = s.db.Query(ctx, `
-		SELECT id, title, category, short_description, long_description, link, image_url, published_at, fetched_at
-		FROM news 
-		ORDER BY published_at DESC, id DESC 
-		LIMIT $1
-		`, limit)
-	} else {
+	if opts.PageToken != "" {
 		pubCur, idCur, decErr := decodePageToken(opts.PageToken)
 		if decErr != nil {
 			return nil, fmt.Errorf("%s: %w", op, storage.ErrInvalidCursor)
 		}
 
-		rows, err = s.db.Query(ctx, `
-		SELECT id, title, category, short_description, long_description, link, image_url, published_at, fetched_at
-		FROM news 
-		WHERE (published_at, id) < ($1, $2)
-		ORDER BY published_at DESC, id DESC
-		LIMIT $3
-		`, pubCur, idCur, limit)
+		args = append(args, pubCur, idCur)
+		where = append(where, "(published_at, id) < ($1, $2)")
+	}
+
+	if f := opts.Filter; f != nil {
+		if len(f.ExcludeCategories) > 0 {
+			args = append(args, f.ExcludeCategories)
+			where = append(where, fmt.Sprintf("lower(category) <> ALL($%d)", len(args)))
+		}
+
+		if len(f.ExcludeSources) > 0 {
+			args = append(args, f.ExcludeSources)
+			where = append(where, fmt.Sprintf("source <> ALL($%d)", len(args)))
+		}
+
+		if len(f.Languages) > 0 {
+			args = append(args, f.Languages)
+			where = append(where, fmt.Sprintf("(language = '' OR language = ANY($%d))", len(args)))
+		}
 	}
 
+	isRead := "false"
+	if opts.Reader != uuid.Nil {
+		args = append(args, opts.Reader)
+		isRead = fmt.Sprintf(readExpr, len(args))
+
+		if opts.OnlyUnread {
+			where = append(where, "NOT "+isRead)
+		}
+	}
+
+	q := `SELECT ` + newsColumns + `, ` + isRead + ` FROM news`
+	if len(where) > 0 {
+		q += ` WHERE ` + strings.Join(where, " AND ")
+	}
+
+	args = append(args, limit)
+	q += fmt.Sprintf(` ORDER BY published_at DESC, id DESC LIMIT $%d`, len(args))
+
+	rows, err := s.db.Query(ctx, q, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -110,26 +196,16 @@ func (s *Storage) ListNews(ctx context.Context, opts models.ListOptions) (*model
 
 	var page models.Page
 	for rows.Next() {
-		var news models.News
-		if scanErr := rows.Scan(
-			&news.ID,
-			&news.Title,
-			&news.Category,
-			&news.ShortDescription,
-			&news.LongDescription,
-			&news.Link,
-			&news.ImageURL,
-			&news.PublishedAt,
-			&news.FetchedAt,
-		); scanErr != nil {
+		var read bool
+
+		news, scanErr := scanNews(rows, &read)
+		if scanErr != nil {
 			return nil, fmt.Errorf("%s: scan row: %w", op, scanErr)
 		}
 
-		// Нормализация в UTC.
-		news.PublishedAt = news.PublishedAt.UTC()
-		news.FetchedAt = news.FetchedAt.UTC()
+		news.IsRead = read
 
-		page.Items = append(page.Items, news)
+		page.Items = append(page.Items, *news)
 	}
 
 	if rows.Err() != nil {
@@ -158,12 +234,72 @@ func (s *Storage) NewsByID(ctx context.Context, id string) (*models.News, error)
 		return nil, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
 	}
 
-	var news models.News
-	err = s.db.QueryRow(ctx, `
-	SELECT id, title, category, short_description, long_description, link, image_url, published_at, fetched_at
+	news, err := scanNews(s.db.QueryRow(ctx, `
+	SELECT `+newsColumns+`
 	FROM news 
 	WHERE id = $1
-	`, correctID).Scan(
+	`, correctID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return news, nil
+}
+
+// NewsByIDs возвращает найденные новости из ids одним запросом (id = ANY).
+// Порядок результата не определён, отсутствующие id пропускаются.
+// reader (если задан) заполняет IsRead — как в ListNews.
+func (s *Storage) NewsByIDs(ctx context.Context, ids []uuid.UUID, reader uuid.UUID) ([]models.News, error) {
+	const op = "storage/postgres/NewsByIDs"
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := []any{ids}
+	isRead := "false"
+	if reader != uuid.Nil {
+		args = append(args, reader)
+		isRead = fmt.Sprintf(readExpr, len(args))
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT `+newsColumns+`, `+isRead+` FROM news WHERE id = ANY($1)`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	out := make([]models.News, 0, len(ids))
+	for rows.Next() {
+		var read bool
+
+		news, scanErr := scanNews(rows, &read)
+		if scanErr != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, scanErr)
+		}
+
+		news.IsRead = read
+
+		out = append(out, *news)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, rows.Err())
+	}
+
+	return out, nil
+}
+
+// scanNews читает строку в порядке newsColumns (и extra — колонки после них)
+// и нормализует время в UTC.
+func scanNews(row pgx.Row, extra ...any) (*models.News, error) {
+	var news models.News
+
+	dest := []any{
 		&news.ID,
 		&news.Title,
 		&news.Category,
@@ -173,13 +309,12 @@ func (s *Storage) NewsByID(ctx context.Context, id string) (*models.News, error)
 		&news.ImageURL,
 		&news.PublishedAt,
 		&news.FetchedAt,
-	)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
-		}
+		&news.Source,
+		&news.Language,
+	}
 
-		return nil, fmt.Errorf("%s: %w", op, err)
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
 	}
 
 	news.PublishedAt = news.PublishedAt.UTC()
@@ -26,8 +26,12 @@ import (
 // — применяют миграции из ./migrations;
 // — проверяют:
 //    SaveNews: insert и upsert по link с политикой «не затирать пустыми/короче»;
+//    SaveNews возвращает только вставленные строки (с seq), NewsAfter — по возрастанию seq;
 //    ListNews: keyset-пагинация (page_token), limit<=0 → 1, тай-брейк по (published_at DESC, id DESC);
+//    ListNews с Filter: исключение категорий/источников, фильтр по языку;
+//    история чтения: MarkRead (вытеснение сверх лимита), MarkAllRead (водяной знак: страница по курсору и всё старше), is_read/only_unread, PurgeReadHistory;
 //    NewsByID: успешный сценарий и ErrNotFound при невалидном UUID;
+//    NewsByIDs: один запрос по ANY, отсутствующие id пропускаются, is_read для читателя;
 //    обработку некорректного page_token (не-base64, нет разделителя, плохие timestamp/UUID);
 //    encode/decode page_token (round-trip).
 
@@ -80,8 +84,10 @@ func startPostgres(t *testing.T) (*Storage, func()) {
 	require.NoError(t, err)
 	defer pool.Close()
 
-	_, err = pool.Exec(ctx, readMigration(t, "1_init_news.up.sql"))
-	require.NoError(t, err)
+	for _, name := range []string{"1_init_news.up.sql", "2_news_source_language.up.sql", "3_read_history.up.sql", "4_news_seq.up.sql"} {
+		_, err = pool.Exec(ctx, readMigration(t, name))
+		require.NoError(t, err)
+	}
 
 	st, err := New(ctx, dsn)
 	require.NoError(t, err)
@@ -109,7 +115,7 @@ func TestIntegration_SaveNews_Upsert_And_ByID_OK(t *testing.T) {
 		PublishedAt:      now.Add(-time.Hour),
 		FetchedAt:        now,
 	}
-	require.NoError(t, st.SaveNews(context.Background(), []models.News{item1}))
+	saveNews(t, st, context.Background(), []models.News{item1})
 
 	page1, err := st.ListNews(context.Background(), models.ListOptions{Limit: 10})
 	require.NoError(t, err)
@@ -134,7 +140,7 @@ func TestIntegration_SaveNews_Upsert_And_ByID_OK(t *testing.T) {
 		PublishedAt:      now.Add(-2 * time.Hour), // не должно поменяться
 		FetchedAt:        now.Add(time.Minute),    // обновится
 	}
-	require.NoError(t, st.SaveNews(context.Background(), []models.News{item2}))
+	saveNews(t, st, context.Background(), []models.News{item2})
 
 	got, err := st.NewsByID(context.Background(), inserted.ID.String())
 	require.NoError(t, err)
@@ -164,7 +170,7 @@ func TestIntegration_SaveNews_NoOverwriteOnEmptyOrShorter(t *testing.T) {
 		PublishedAt:      now.Add(-time.Hour),
 		FetchedAt:        now,
 	}
-	require.NoError(t, st.SaveNews(context.Background(), []models.News{orig}))
+	saveNews(t, st, context.Background(), []models.News{orig})
 
 	upd := models.News{
 		Title:            "v2",
@@ -176,7 +182,7 @@ func TestIntegration_SaveNews_NoOverwriteOnEmptyOrShorter(t *testing.T) {
 		PublishedAt:      now.Add(-2 * time.Hour),
 		FetchedAt:        now.Add(time.Minute),
 	}
-	require.NoError(t, st.SaveNews(context.Background(), []models.News{upd}))
+	saveNews(t, st, context.Background(), []models.News{upd})
 
 	page, err := st.ListNews(context.Background(), models.ListOptions{Limit: 50})
 	require.NoError(t, err)
@@ -217,7 +223,7 @@ func TestIntegration_ListNews_Pagination_OK(t *testing.T) {
 			FetchedAt:        base,
 		})
 	}
-	require.NoError(t, st.SaveNews(context.Background(), batch))
+	saveNews(t, st, context.Background(), batch)
 
 	// Первая страница.
 	p1, err := st.ListNews(context.Background(), models.ListOptions{Limit: 2})
@@ -270,7 +276,7 @@ func TestIntegration_ListNews_LimitZero_DefaultsToOne(t *testing.T) {
 			FetchedAt:        base,
 		},
 	}
-	require.NoError(t, st.SaveNews(context.Background(), items))
+	saveNews(t, st, context.Background(), items)
 
 	p, err := st.ListNews(context.Background(), models.ListOptions{Limit: 0})
 	require.NoError(t, err)
@@ -305,6 +311,46 @@ func TestIntegration_NewsByID_InvalidFormat_IsNotFound(t *testing.T) {
 	require.ErrorIs(t, err, storage.ErrNotFound)
 }
 
+// saveNews — SaveNews с проверкой ошибки; возвращает вставленные новости.
+func saveNews(t *testing.T, st *Storage, ctx context.Context, items []models.News) []models.News {
+	t.Helper()
+	inserted, err := st.SaveNews(ctx, items)
+	require.NoError(t, err)
+	return inserted
+}
+
+func TestIntegration_SaveNews_ReturnsInserted_And_NewsAfter(t *testing.T) {
+	st, cleanup := startPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	item := func(n int) models.News {
+		return models.News{Title: fmt.Sprintf("t%d", n), Link: fmt.Sprintf("https://example.org/seq/%d", n), PublishedAt: now, FetchedAt: now}
+	}
+
+	first := saveNews(t, st, ctx, []models.News{item(1), item(2)})
+	require.Len(t, first, 2)
+	require.NotEqual(t, uuid.Nil, first[0].ID)
+	require.Less(t, first[0].Seq, first[1].Seq)
+
+	// Повтор (upsert) не считается вставкой.
+	second := saveNews(t, st, ctx, []models.News{item(2), item(3)})
+	require.Len(t, second, 1)
+	require.Equal(t, "t3", second[0].Title)
+
+	after, err := st.NewsAfter(ctx, first[0].Seq, 10)
+	require.NoError(t, err)
+	require.Len(t, after, 2)
+	require.Equal(t, first[1].ID, after[0].ID)
+	require.Equal(t, second[0].Seq, after[1].Seq)
+
+	after, err = st.NewsAfter(ctx, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, after, 1)
+	require.Equal(t, first[0].ID, after[0].ID)
+}
+
 func TestIntegration_SaveNews_ContextDeadlineExceeded(t *testing.T) {
 	st, cleanup := startPostgres(t)
 	defer cleanup()
@@ -319,7 +365,7 @@ func TestIntegration_SaveNews_ContextDeadlineExceeded(t *testing.T) {
 		PublishedAt: time.Now().UTC(),
 		FetchedAt:   time.Now().UTC(),
 	}
-	err := st.SaveNews(ctx, []models.News{item})
+	_, err := st.SaveNews(ctx, []models.News{item})
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), context.DeadlineExceeded.Error()))
 }
@@ -352,7 +398,7 @@ func TestIntegration_ListNews_TieBreakers_PaginateStable(t *testing.T) {
 			FetchedAt:        pub,
 		})
 	}
-	require.NoError(t, st.SaveNews(context.Background(), batch))
+	saveNews(t, st, context.Background(), batch)
 
 	p1, err := st.ListNews(context.Background(), models.ListOptions{Limit: 2})
 	require.NoError(t, err)
@@ -391,3 +437,182 @@ func TestDecodePageToken_Errors(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestIntegration_ListNews_Filter(t *testing.T) {
+	st, cleanup := startPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	items := []models.News{
+		{Title: "a", Link: "https://a.ru/1", Category: "Sport", Source: "a.ru", Language: "ru", PublishedAt: now, FetchedAt: now},
+		{Title: "b", Link: "https://b.com/1", Category: "Tech", Source: "b.com", Language: "en", PublishedAt: now.Add(-time.Minute), FetchedAt: now},
+		{Title: "c", Link: "https://c.ru/1", Category: "Tech", Source: "c.ru", Language: "", PublishedAt: now.Add(-2 * time.Minute), FetchedAt: now},
+		{Title: "d", Link: "https://d.ru/1", Category: "World", Source: "d.ru", Language: "ru", PublishedAt: now.Add(-3 * time.Minute), FetchedAt: now},
+	}
+	saveNews(t, st, ctx, items)
+
+	titles := func(p *models.Page) []string {
+		out := make([]string, 0, len(p.Items))
+		for _, it := range p.Items {
+			out = append(out, it.Title)
+		}
+		return out
+	}
+
+	page, err := st.ListNews(ctx, models.ListOptions{Limit: 10, Filter: &models.NewsFilter{
+		ExcludeCategories: []string{"sport"},
+		ExcludeSources:    []string{"d.ru"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "c"}, titles(page))
+	require.Equal(t, "b.com", page.Items[0].Source)
+	require.Equal(t, "en", page.Items[0].Language)
+
+	// Новости без языка проходят фильтр по языку.
+	page, err = st.ListNews(ctx, models.ListOptions{Limit: 10, Filter: &models.NewsFilter{Languages: []string{"ru"}}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "c", "d"}, titles(page))
+
+	// Курсор работает вместе с фильтром.
+	page, err = st.ListNews(ctx, models.ListOptions{Limit: 1, Filter: &models.NewsFilter{Languages: []string{"ru"}}})
+	require.NoError(t, err)
+	page, err = st.ListNews(ctx, models.ListOptions{Limit: 10, PageToken: page.NextPageToken, Filter: &models.NewsFilter{Languages: []string{"ru"}}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "d"}, titles(page))
+}
+
+func TestIntegration_ReadHistory(t *testing.T) {
+	st, cleanup := startPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	items := []models.News{
+		{Title: "a", Link: "https://a.ru/1", PublishedAt: now, FetchedAt: now},
+		{Title: "b", Link: "https://a.ru/2", PublishedAt: now.Add(-time.Minute), FetchedAt: now},
+		{Title: "c", Link: "https://a.ru/3", PublishedAt: now.Add(-2 * time.Minute), FetchedAt: now},
+		{Title: "d", Link: "https://a.ru/4", PublishedAt: now.Add(-3 * time.Minute), FetchedAt: now},
+	}
+	saveNews(t, st, ctx, items)
+
+	reader := uuid.New()
+
+	list := func(opts models.ListOptions) *models.Page {
+		t.Helper()
+		opts.Reader = reader
+		page, err := st.ListNews(ctx, opts)
+		require.NoError(t, err)
+		return page
+	}
+	readTitles := func(p *models.Page) (read, unread []string) {
+		for _, it := range p.Items {
+			if it.IsRead {
+				read = append(read, it.Title)
+			} else {
+				unread = append(unread, it.Title)
+			}
+		}
+		return read, unread
+	}
+
+	all := list(models.ListOptions{Limit: 10})
+	require.Len(t, all.Items, 4)
+	ids := map[string]uuid.UUID{}
+	for _, it := range all.Items {
+		ids[it.Title] = it.ID
+	}
+
+	// Несуществующая новость пропускается; лимит 1 вытесняет более раннюю отметку.
+	require.NoError(t, st.MarkRead(ctx, reader, []uuid.UUID{ids["c"], uuid.New()}, 1))
+	require.NoError(t, st.MarkRead(ctx, reader, []uuid.UUID{ids["a"]}, 1))
+	read, _ := readTitles(list(models.ListOptions{Limit: 10}))
+	require.Equal(t, []string{"a"}, read)
+
+	// Чужой читатель и анонимный запрос отметок не видят.
+	page, err := st.ListNews(ctx, models.ListOptions{Limit: 10, Reader: uuid.New()})
+	require.NoError(t, err)
+	read, _ = readTitles(page)
+	require.Empty(t, read)
+	page, err = st.ListNews(ctx, models.ListOptions{Limit: 10})
+	require.NoError(t, err)
+	read, _ = readTitles(page)
+	require.Empty(t, read)
+
+	// Страница, полученная по курсору, и всё старше: MarkAllRead с тем же курсором
+	// отмечает именно её (c, d), более новая «b» остаётся непрочитанной.
+	first := list(models.ListOptions{Limit: 2})
+	second := list(models.ListOptions{Limit: 2, PageToken: first.NextPageToken})
+	read, unread := readTitles(second)
+	require.Empty(t, read)
+	require.Equal(t, []string{"c", "d"}, unread)
+
+	require.NoError(t, st.MarkAllRead(ctx, reader, first.NextPageToken))
+	read, _ = readTitles(list(models.ListOptions{Limit: 2, PageToken: first.NextPageToken}))
+	require.Equal(t, []string{"c", "d"}, read)
+	read, unread = readTitles(list(models.ListOptions{Limit: 10}))
+	require.Equal(t, []string{"a", "c", "d"}, read)
+	require.Equal(t, []string{"b"}, unread)
+
+	// Знак назад не двигается.
+	require.NoError(t, st.MarkAllRead(ctx, reader, encodePageToken(now.Add(-time.Hour), uuid.Nil)))
+	_, unread = readTitles(list(models.ListOptions{Limit: 10}))
+	require.Equal(t, []string{"b"}, unread)
+
+	// Явные отметки стареют; знак остаётся.
+	n, err := st.PurgeReadHistory(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+	read, unread = readTitles(list(models.ListOptions{Limit: 10}))
+	require.Equal(t, []string{"c", "d"}, read)
+	require.Equal(t, []string{"a", "b"}, unread)
+
+	_, unread = readTitles(list(models.ListOptions{Limit: 10, OnlyUnread: true}))
+	require.Equal(t, []string{"a", "b"}, unread)
+
+	// Пустой курсор — всё, опубликованное к текущему моменту.
+	require.NoError(t, st.MarkAllRead(ctx, reader, ""))
+	require.Empty(t, list(models.ListOptions{Limit: 10, OnlyUnread: true}).Items)
+
+	require.ErrorIs(t, st.MarkAllRead(ctx, reader, "bad"), storage.ErrInvalidCursor)
+}
+
+func TestIntegration_NewsByIDs(t *testing.T) {
+	st, cleanup := startPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	saveNews(t, st, ctx, []models.News{
+		{Title: "a", Link: "https://a.ru/1", PublishedAt: now, FetchedAt: now},
+		{Title: "b", Link: "https://a.ru/2", PublishedAt: now.Add(-time.Minute), FetchedAt: now},
+	})
+
+	page, err := st.ListNews(ctx, models.ListOptions{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	a, b := page.Items[0].ID, page.Items[1].ID
+
+	got, err := st.NewsByIDs(ctx, []uuid.UUID{b, uuid.New(), a}, uuid.Nil)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.ElementsMatch(t, []uuid.UUID{a, b}, []uuid.UUID{got[0].ID, got[1].ID})
+
+	reader := uuid.New()
+	require.NoError(t, st.MarkRead(ctx, reader, []uuid.UUID{b}, 10))
+
+	got, err = st.NewsByIDs(ctx, []uuid.UUID{a, b}, reader)
+	require.NoError(t, err)
+	read := map[uuid.UUID]bool{}
+	for _, n := range got {
+		read[n.ID] = n.IsRead
+	}
+	require.Equal(t, map[uuid.UUID]bool{a: false, b: true}, read)
+
+	got, err = st.NewsByIDs(ctx, nil, uuid.Nil)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/storage"
+)
+
+// maxUUID — наибольший id: водяной знак «всё до момента t» — (t, maxUUID).
+// Знак исключающий: прочитано всё строго старше него.
+var maxUUID = uuid.Must(uuid.Parse("ffffffff-ffff-ffff-ffff-ffffffffffff"))
+
+// MarkRead записывает отметки прочтения и вытесняет самые старые сверх maxPerUser —
+// одной транзакцией. Отметки только для существующих новостей (INSERT ... SELECT).
+func (s *Storage) MarkRead(ctx context.Context, userID uuid.UUID, newsIDs []uuid.UUID, maxPerUser int) error {
+	const op = "storage/postgres/MarkRead"
+
+	if len(newsIDs) == 0 {
+		return nil
+	}
+
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `
+		INSERT INTO news_reads (user_id, news_id)
+		SELECT $1, id FROM news WHERE id = ANY($2)
+		ON CONFLICT (user_id, news_id) DO UPDATE SET read_at = now()
+		`, userID, newsIDs); err != nil {
+			return err
+		}
+
+		if maxPerUser <= 0 {
+			return nil
+		}
+
+		_, err := tx.Exec(ctx, `
+		DELETE FROM news_reads
+		WHERE user_id = $1 AND news_id IN (
+			SELECT news_id FROM news_reads
+			WHERE user_id = $1
+			ORDER BY read_at DESC, news_id DESC
+			OFFSET $2
+		)`, userID, maxPerUser)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkAllRead сдвигает водяной знак читателя вперёд (GREATEST по паре ключей)
+// и удаляет отметки news_reads, которые он покрыл. Знак — позиция курсора pageToken:
+// прочитанным становится ровно то, что ListNews выдаёт с этим курсором (страница и всё старше).
+func (s *Storage) MarkAllRead(ctx context.Context, userID uuid.UUID, pageToken string) error {
+	const op = "storage/postgres/MarkAllRead"
+
+	pub, id := time.Now().UTC(), maxUUID
+	if strings.TrimSpace(pageToken) != "" {
+		var err error
+		pub, id, err = decodePageToken(pageToken)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, storage.ErrInvalidCursor)
+		}
+	}
+
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `
+		INSERT INTO read_marks (user_id, read_published_at, read_news_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			read_published_at = EXCLUDED.read_published_at,
+			read_news_id = EXCLUDED.read_news_id,
+			updated_at = now()
+		WHERE (read_marks.read_published_at, read_marks.read_news_id)
+			< (EXCLUDED.read_published_at, EXCLUDED.read_news_id)
+		`, userID, pub, id); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(ctx, `
+		DELETE FROM news_reads r
+		USING news n, read_marks m
+		WHERE r.user_id = $1 AND m.user_id = $1 AND n.id = r.news_id
+			AND (n.published_at, n.id) < (m.read_published_at, m.read_news_id)`, userID)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// PurgeReadHistory удаляет отметки старше before (водяные знаки не трогает).
+func (s *Storage) PurgeReadHistory(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage/postgres/PurgeReadHistory"
+
+	tag, err := s.db.Exec(ctx, `DELETE FROM news_reads WHERE read_at < $1`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -4,6 +4,9 @@ package storage
 import (
 	"context"
 	"errors"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
 )
@@ -20,18 +23,46 @@ var (
 // NewsStorage описывает операции над сущностью models.News.
 type NewsStorage interface {
 	// SaveNews сохраняет пачку новостей (ожидаемый сценарий — upsert по канонической ссылке link).
+	// Возвращает только впервые вставленные новости (с ID и Seq) в порядке Seq.
 	// Возврат ErrConflict, если реализуем «жёсткую» уникальность без upsert.
-	SaveNews(ctx context.Context, items []models.News) error
+	SaveNews(ctx context.Context, items []models.News) ([]models.News, error)
+	// NewsAfter возвращает до limit новостей, вставленных после seq, в порядке вставки (Seq ASC).
+	NewsAfter(ctx context.Context, seq int64, limit int) ([]models.News, error)
 	// ListNews возвращает страницу новостей, отсортированных по published_at.
+	// opts.Filter (если задан) сужает выборку — см. models.NewsFilter.
+	// opts.Reader (если задан) заполняет IsRead и включает opts.OnlyUnread.
 	// При некорректном page_token должна вернуться ошибка ErrInvalidCursor.
 	ListNews(ctx context.Context, opts models.ListOptions) (*models.Page, error)
 	// NewsByID возвращает новость по её строковому идентификатору (формат — деталь реализации).
 	// Если запись не найдена — ErrNotFound.
 	NewsByID(ctx context.Context, id string) (*models.News, error)
+	// NewsByIDs возвращает существующие новости из ids (порядок не гарантируется,
+	// отсутствующие пропускаются без ошибки). reader (если задан) заполняет IsRead.
+	NewsByIDs(ctx context.Context, ids []uuid.UUID, reader uuid.UUID) ([]models.News, error)
+}
+
+// ReadHistoryStorage описывает историю прочтений читателя.
+// Прочитанность новости — отметка в истории или позиция строго старше «водяного знака»
+// читателя в порядке ленты (published_at DESC, id DESC).
+type ReadHistoryStorage interface {
+	// MarkRead отмечает новости прочитанными (повтор обновляет время отметки).
+	// Несуществующие id молча пропускаются. У читателя остаётся не больше maxPerUser
+	// отметок — самые старые вытесняются.
+	MarkRead(ctx context.Context, userID uuid.UUID, newsIDs []uuid.UUID, maxPerUser int) error
+	// MarkAllRead сдвигает водяной знак читателя к позиции pageToken (курсор ListNews):
+	// прочитанными становятся все новости, которые ListNews выдаёт с этим курсором, —
+	// страница, запрошенная с ним, и все более старые.
+	// Пустой pageToken — все новости, опубликованные к текущему моменту.
+	// Знак только растёт; отметки, покрытые им, удаляются.
+	// При некорректном pageToken — ErrInvalidCursor.
+	MarkAllRead(ctx context.Context, userID uuid.UUID, pageToken string) error
+	// PurgeReadHistory удаляет отметки, сделанные раньше before; возвращает их число.
+	PurgeReadHistory(ctx context.Context, before time.Time) (int64, error)
 }
 
 // Storage задаёт контракт доступа к хранилищу для news-сервиса.
 type Storage interface {
 	NewsStorage
+	ReadHistoryStorage
 	Close()
 }
@@ -3,7 +3,7 @@
 // Принципы:
 //   - Контекст запроса прокидывается в сервис без потерь;
 //   - Ошибки сервиса явно транслируются в коды gRPC:
-//   - ErrInvalidCursor -> codes.InvalidArgument;
+//   - ErrInvalidCursor, ErrInvalidArgument -> codes.InvalidArgument;
 //   - ErrNotFound -> codes.NotFound;
 //   - иные ошибки -> codes.Internal с единым безопасным сообщением.
 package grpc
@@ -11,7 +11,9 @@ package grpc
 import (
 	"context"
 	"errors"
+	"strings"
 
+	"github.com/google/uuid"
 	newsv1 "github.com/pribylovaa/go-news-aggregator/news-service/gen/go/news"
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
 	"github.com/pribylovaa/go-news-aggregator/news-service/internal/service"
@@ -29,18 +31,72 @@ func NewNewsServer(svc *service.Service) *NewsServer {
 	return &NewsServer{service: svc}
 }
 
-// ListNews возвращает страницу новостей.
+// ListNews возвращает страницу новостей; с user_id — с отметками is_read
+// и фильтром only_unread.
 // Маппинг ошибок:
-//   - ErrInvalidCursor -> InvalidArgument;
+//   - неверный user_id, ErrInvalidCursor -> InvalidArgument;
 //   - прочее -> Internal (без раскрытия деталей).
 func (s *NewsServer) ListNews(ctx context.Context, req *newsv1.ListNewsRequest) (*newsv1.ListNewsResponse, error) {
 	const op = "transport/grpc/server/ListNews"
 
-	page, err := s.service.ListNews(ctx, models.ListOptions{
+	opts := models.ListOptions{
+		Limit:      req.GetLimit(),
+		PageToken:  req.GetPageToken(),
+		OnlyUnread: req.GetOnlyUnread(),
+	}
+
+	if raw := strings.TrimSpace(req.GetUserId()); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+		}
+
+		opts.Reader = id
+	}
+
+	page, err := s.service.ListNews(ctx, opts)
+
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	items := make([]*newsv1.News, 0, len(page.Items))
+	for _, item := range page.Items {
+		items = append(items, toProtoNews(item))
+	}
+
+	return &newsv1.ListNewsResponse{
+		Items:         items,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// ListPersonalizedNews возвращает страницу персональной ленты (user_id пуст — анонимно).
+// Маппинг ошибок:
+//   - неверный user_id, ErrInvalidCursor -> InvalidArgument;
+//   - прочее -> Internal (без раскрытия деталей).
+func (s *NewsServer) ListPersonalizedNews(ctx context.Context, req *newsv1.ListPersonalizedNewsRequest) (*newsv1.ListPersonalizedNewsResponse, error) {
+	const op = "transport/grpc/server/ListPersonalizedNews"
+
+	in := service.PersonalizedInput{
 		Limit:     req.GetLimit(),
 		PageToken: req.GetPageToken(),
-	})
+	}
+
+	if raw := strings.TrimSpace(req.GetUserId()); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+		}
+
+		in.UserID = id
+	}
 
+	page, err := s.service.ListPersonalizedNews(ctx, in)
 	if err != nil {
 		if errors.Is(err, service.ErrInvalidCursor) {
 			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
@@ -54,7 +110,7 @@ func (s *NewsServer) ListNews(ctx context.Context, req *newsv1.ListNewsRequest)
 		items = append(items, toProtoNews(item))
 	}
 
-	return &newsv1.ListNewsResponse{
+	return &newsv1.ListPersonalizedNewsResponse{
 		Items:         items,
 		NextPageToken: page.NextPageToken,
 	}, nil
@@ -81,6 +137,163 @@ func (s *NewsServer) NewsByID(ctx context.Context, req *newsv1.NewsByIDRequest)
 	}, nil
 }
 
+// NewsByIDs возвращает новости по списку id; отсутствующие — в missing_ids.
+// Маппинг ошибок:
+//   - неверный user_id, ErrInvalidArgument (пустой/слишком большой список) -> InvalidArgument;
+//   - прочее -> Internal.
+func (s *NewsServer) NewsByIDs(ctx context.Context, req *newsv1.NewsByIDsRequest) (*newsv1.NewsByIDsResponse, error) {
+	const op = "transport/grpc/server/NewsByIDs"
+
+	var reader uuid.UUID
+	if raw := strings.TrimSpace(req.GetUserId()); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+		}
+
+		reader = id
+	}
+
+	batch, err := s.service.NewsByIDs(ctx, req.GetIds(), reader)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidArgument) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	items := make([]*newsv1.News, 0, len(batch.Items))
+	for _, item := range batch.Items {
+		items = append(items, toProtoNews(item))
+	}
+
+	return &newsv1.NewsByIDsResponse{
+		Items:      items,
+		MissingIds: batch.MissingIDs,
+	}, nil
+}
+
+// MarkRead отмечает новости прочитанными.
+// Маппинг ошибок:
+//   - неверный user_id/news_id, ErrInvalidArgument -> InvalidArgument;
+//   - прочее -> Internal.
+func (s *NewsServer) MarkRead(ctx context.Context, req *newsv1.MarkReadRequest) (*newsv1.MarkReadResponse, error) {
+	const op = "transport/grpc/server/MarkRead"
+
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.GetNewsIds()))
+	for _, raw := range req.GetNewsIds() {
+		id, err := uuid.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: invalid news_id %q: %v", op, raw, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := s.service.MarkRead(ctx, userID, ids); err != nil {
+		if errors.Is(err, service.ErrInvalidArgument) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &newsv1.MarkReadResponse{}, nil
+}
+
+// MarkAllRead отмечает прочитанным всё до позиции page_token (пусто — до текущего момента).
+// Маппинг ошибок:
+//   - неверный user_id, ErrInvalidArgument, ErrInvalidCursor -> InvalidArgument;
+//   - прочее -> Internal.
+func (s *NewsServer) MarkAllRead(ctx context.Context, req *newsv1.MarkAllReadRequest) (*newsv1.MarkAllReadResponse, error) {
+	const op = "transport/grpc/server/MarkAllRead"
+
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+
+	if err := s.service.MarkAllRead(ctx, userID, req.GetPageToken()); err != nil {
+		if errors.Is(err, service.ErrInvalidArgument) || errors.Is(err, service.ErrInvalidCursor) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &newsv1.MarkAllReadResponse{}, nil
+}
+
+// WatchNews — server streaming живой ленты: сначала пропущенные после
+// last_event_id (или событие reset), затем новые новости по мере сохранения ingest.
+// Поток живёт до отмены контекста клиентом.
+// Маппинг ошибок:
+//   - клиент не успевает читать (переполнен буфер подписки) -> ResourceExhausted:
+//     переподключение с последним event_id досылает пропущенное;
+//   - прочее -> Internal.
+func (s *NewsServer) WatchNews(req *newsv1.WatchNewsRequest, stream newsv1.NewsService_WatchNewsServer) error {
+	const op = "transport/grpc/server/WatchNews"
+
+	ctx := stream.Context()
+	w, err := s.service.WatchNews(ctx, req.GetLastEventId())
+	if err != nil {
+		return status.Errorf(codes.Internal, "internal server error")
+	}
+	defer w.Live.Close()
+
+	if w.Reset {
+		if err := stream.Send(&newsv1.NewsEvent{ResetRequired: true}); err != nil {
+			return err
+		}
+	}
+
+	var last int64
+	for _, n := range w.Replay {
+		if err := stream.Send(toProtoNewsEvent(n)); err != nil {
+			return err
+		}
+		last = n.Seq
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n, ok := <-w.Live.C:
+			if !ok {
+				if w.Live.Lagged() {
+					return status.Errorf(codes.ResourceExhausted, "%s: client is too slow, resume with last event_id", op)
+				}
+				return nil
+			}
+
+			// Уже отправлено из Replay.
+			if n.Seq <= last {
+				continue
+			}
+
+			if err := stream.Send(toProtoNewsEvent(n)); err != nil {
+				return err
+			}
+			last = n.Seq
+		}
+	}
+}
+
+// toProtoNewsEvent — событие живой ленты с курсором возобновления.
+func toProtoNewsEvent(n models.News) *newsv1.NewsEvent {
+	return &newsv1.NewsEvent{
+		EventId: service.EventID(n),
+		Item:    toProtoNews(n),
+	}
+}
+
 // toProtoNews конвертирует доменную модель News в protobuf-представление.
 func toProtoNews(news models.News) *newsv1.News {
 	return &newsv1.News{
@@ -93,5 +306,8 @@ func toProtoNews(news models.News) *newsv1.News {
 		ImageUrl:         news.ImageURL,
 		PublishedAt:      news.PublishedAt.Unix(),
 		FetchedAt:        news.FetchedAt.Unix(),
+		Source:           news.Source,
+		Language:         news.Language,
+		IsRead:           news.IsRead,
 	}
 }
@@ -31,6 +31,12 @@ func testCfg() config.Config {
 			Default: 12,
 			Max:     300,
 		},
+		Fetcher: config.FetcherConfig{
+			Sources:  []string{"feed"},
+			Interval: time.Hour,
+		},
+		Timeouts: config.TimeoutConfig{Service: time.Second},
+		Watch:    config.WatchConfig{Buffer: 8, Replay: 10},
 	}
 }
 
@@ -303,3 +309,213 @@ func TestNewsByID_Errors_Table(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+func TestListPersonalizedNews(t *testing.T) {
+	t.Parallel()
+
+	svc, st, ctrl := newSvcWithMock(t)
+	defer ctrl.Finish()
+	client, done := startGRPC(t, svc)
+	defer done()
+
+	_, err := client.ListPersonalizedNews(context.Background(), &newsv1.ListPersonalizedNewsRequest{UserId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	now := time.Now().UTC().Truncate(time.Second)
+	item := models.News{ID: uuid.New(), Title: "a", Link: "https://a.ru/1", Source: "a.ru", Language: "ru", PublishedAt: now, FetchedAt: now}
+
+	// Без users-клиента предпочтения пусты; окно = limit при candidate_factor по умолчанию.
+	reader := uuid.New()
+	st.EXPECT().
+		ListNews(gomock.Any(), models.ListOptions{Limit: 2, Reader: reader, Filter: &models.NewsFilter{}}).
+		Return(&models.Page{Items: []models.News{item}, NextPageToken: "next"}, nil)
+
+	resp, err := client.ListPersonalizedNews(context.Background(), &newsv1.ListPersonalizedNewsRequest{UserId: reader.String(), Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, resp.GetItems(), 1)
+	require.Equal(t, "a.ru", resp.GetItems()[0].GetSource())
+	require.Equal(t, "ru", resp.GetItems()[0].GetLanguage())
+	require.Empty(t, resp.GetNextPageToken())
+
+	st.EXPECT().ListNews(gomock.Any(), gomock.Any()).Return(nil, storage.ErrInvalidCursor)
+	_, err = client.ListPersonalizedNews(context.Background(), &newsv1.ListPersonalizedNewsRequest{PageToken: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	st.EXPECT().ListNews(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+	_, err = client.ListPersonalizedNews(context.Background(), &newsv1.ListPersonalizedNewsRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestListNews_Reader(t *testing.T) {
+	t.Parallel()
+
+	svc, st, ctrl := newSvcWithMock(t)
+	defer ctrl.Finish()
+	client, done := startGRPC(t, svc)
+	defer done()
+
+	_, err := client.ListNews(context.Background(), &newsv1.ListNewsRequest{UserId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	reader := uuid.New()
+	item := models.News{ID: uuid.New(), Title: "a", IsRead: true}
+
+	st.EXPECT().
+		ListNews(gomock.Any(), models.ListOptions{Limit: 12, Reader: reader, OnlyUnread: true}).
+		Return(&models.Page{Items: []models.News{item}}, nil)
+
+	resp, err := client.ListNews(context.Background(), &newsv1.ListNewsRequest{UserId: reader.String(), OnlyUnread: true})
+	require.NoError(t, err)
+	require.Len(t, resp.GetItems(), 1)
+	require.True(t, resp.GetItems()[0].GetIsRead())
+}
+
+func TestMarkRead_And_MarkAllRead(t *testing.T) {
+	t.Parallel()
+
+	svc, st, ctrl := newSvcWithMock(t)
+	defer ctrl.Finish()
+	client, done := startGRPC(t, svc)
+	defer done()
+
+	uid, nid := uuid.New(), uuid.New()
+
+	for _, req := range []*newsv1.MarkReadRequest{
+		{UserId: "bad", NewsIds: []string{nid.String()}},
+		{UserId: uid.String(), NewsIds: []string{"bad"}},
+		{UserId: uid.String()},
+	} {
+		_, err := client.MarkRead(context.Background(), req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	st.EXPECT().MarkRead(gomock.Any(), uid, []uuid.UUID{nid}, gomock.Any()).Return(nil)
+	_, err := client.MarkRead(context.Background(), &newsv1.MarkReadRequest{UserId: uid.String(), NewsIds: []string{nid.String()}})
+	require.NoError(t, err)
+
+	st.EXPECT().MarkRead(gomock.Any(), uid, gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+	_, err = client.MarkRead(context.Background(), &newsv1.MarkReadRequest{UserId: uid.String(), NewsIds: []string{nid.String()}})
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	_, err = client.MarkAllRead(context.Background(), &newsv1.MarkAllReadRequest{UserId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	st.EXPECT().MarkAllRead(gomock.Any(), uid, "").Return(nil)
+	_, err = client.MarkAllRead(context.Background(), &newsv1.MarkAllReadRequest{UserId: uid.String()})
+	require.NoError(t, err)
+
+	st.EXPECT().MarkAllRead(gomock.Any(), uid, "bad").Return(storage.ErrInvalidCursor)
+	_, err = client.MarkAllRead(context.Background(), &newsv1.MarkAllReadRequest{UserId: uid.String(), PageToken: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	st.EXPECT().MarkAllRead(gomock.Any(), uid, "").Return(errors.New("db down"))
+	_, err = client.MarkAllRead(context.Background(), &newsv1.MarkAllReadRequest{UserId: uid.String()})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestNewsByIDs(t *testing.T) {
+	t.Parallel()
+
+	svc, st, ctrl := newSvcWithMock(t)
+	defer ctrl.Finish()
+	client, done := startGRPC(t, svc)
+	defer done()
+
+	_, err := client.NewsByIDs(context.Background(), &newsv1.NewsByIDsRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.NewsByIDs(context.Background(), &newsv1.NewsByIDsRequest{Ids: []string{uuid.NewString()}, UserId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	a, gone := uuid.New(), uuid.New()
+	st.EXPECT().
+		NewsByIDs(gomock.Any(), []uuid.UUID{gone, a}, uuid.Nil).
+		Return([]models.News{{ID: a, Title: "a"}}, nil)
+
+	resp, err := client.NewsByIDs(context.Background(), &newsv1.NewsByIDsRequest{Ids: []string{gone.String(), a.String()}})
+	require.NoError(t, err)
+	require.Len(t, resp.GetItems(), 1)
+	require.Equal(t, a.String(), resp.GetItems()[0].GetId())
+	require.Equal(t, []string{gone.String()}, resp.GetMissingIds())
+
+	st.EXPECT().NewsByIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+	_, err = client.NewsByIDs(context.Background(), &newsv1.NewsByIDsRequest{Ids: []string{a.String()}})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+// feedParser — Parser, отдающий одну ленту с заданными элементами.
+type feedParser struct{ items []models.News }
+
+func (p feedParser) ParseMany(_ context.Context, urls []string) <-chan service.ParseResult {
+	ch := make(chan service.ParseResult, 1)
+	ch <- service.ParseResult{URL: urls[0], Items: p.items}
+	close(ch)
+	return ch
+}
+
+func TestWatchNews_ReplayThenLive(t *testing.T) {
+	t.Parallel()
+
+	svc, st, ctrl := newSvcWithMock(t)
+	defer ctrl.Finish()
+	client, done := startGRPC(t, svc)
+	defer done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	at := func(seq int64) models.News {
+		return models.News{ID: uuid.New(), Title: "t", Link: "https://example.org/", Seq: seq}
+	}
+	n11, n12, n13 := at(11), at(12), at(13)
+
+	st.EXPECT().NewsAfter(gomock.Any(), int64(10), 11).Return([]models.News{n11, n12}, nil)
+
+	stream, err := client.WatchNews(ctx, &newsv1.WatchNewsRequest{LastEventId: "10"})
+	require.NoError(t, err)
+
+	for _, want := range []models.News{n11, n12} {
+		ev, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, service.EventID(want), ev.GetEventId())
+		require.Equal(t, want.ID.String(), ev.GetItem().GetId())
+	}
+
+	// Ingest сохранил 12 (уже отправлено из replay) и 13: клиент получит только 13.
+	st.EXPECT().SaveNews(gomock.Any(), gomock.Any()).Return([]models.News{n12, n13}, nil)
+	ingestCtx, stopIngest := context.WithCancel(context.Background())
+	defer stopIngest()
+	go func() {
+		_ = svc.StartIngest(ingestCtx, feedParser{items: []models.News{{Title: "x", Link: "https://example.org/x"}}})
+	}()
+
+	ev, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "13", ev.GetEventId())
+	require.Equal(t, n13.ID.String(), ev.GetItem().GetId())
+}
+
+func TestWatchNews_ResetAndErrors(t *testing.T) {
+	t.Parallel()
+
+	svc, st, ctrl := newSvcWithMock(t)
+	defer ctrl.Finish()
+	client, done := startGRPC(t, svc)
+	defer done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.WatchNews(ctx, &newsv1.WatchNewsRequest{LastEventId: "bad"})
+	require.NoError(t, err)
+	ev, err := stream.Recv()
+	require.NoError(t, err)
+	require.True(t, ev.GetResetRequired())
+	require.Empty(t, ev.GetEventId())
+
+	st.EXPECT().NewsAfter(gomock.Any(), int64(1), 11).Return(nil, errors.New("db down"))
+	stream, err = client.WatchNews(ctx, &newsv1.WatchNewsRequest{LastEventId: "1"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.Internal, status.Code(err))
+}
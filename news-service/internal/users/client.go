@@ -0,0 +1,60 @@
+// users — gRPC-клиент users-service для news-service.
+// Используется только для чтения предпочтений читателя (персональная лента).
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/news-service/gen/go/users"
+	"github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
+
+	"google.golang.org/grpc"
+)
+
+// Client — тонкая обёртка над usersv1.UsersServiceClient.
+// Реализует service.PreferencesSource.
+type Client struct {
+	conn *grpc.ClientConn
+	api  usersv1.UsersServiceClient
+}
+
+// New создаёт клиент users-service по адресу addr (соединение ленивое).
+// creds — транспорт (TLS/mTLS или insecure, см. tlsconfig.Reloader.DialOption).
+func New(addr string, creds grpc.DialOption) (*Client, error) {
+	const op = "users/New"
+
+	if addr == "" {
+		return nil, fmt.Errorf("%s: empty addr", op)
+	}
+
+	conn, err := grpc.NewClient(addr, creds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Client{conn: conn, api: usersv1.NewUsersServiceClient(conn)}, nil
+}
+
+// Preferences возвращает предпочтения читателя (пустые, если он их не задавал).
+func (c *Client) Preferences(ctx context.Context, userID uuid.UUID) (*models.Preferences, error) {
+	const op = "users/Preferences"
+
+	resp, err := c.api.GetPreferences(ctx, &usersv1.GetPreferencesRequest{UserId: userID.String()})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &models.Preferences{
+		FollowedCategories: resp.GetFollowedCategories(),
+		MutedCategories:    resp.GetMutedCategories(),
+		MutedSources:       resp.GetMutedSources(),
+		Languages:          resp.GetLanguages(),
+	}, nil
+}
+
+// Close закрывает соединение.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
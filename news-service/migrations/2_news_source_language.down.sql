@@ -0,0 +1,3 @@
+ALTER TABLE news
+    DROP COLUMN IF EXISTS language,
+    DROP COLUMN IF EXISTS source;
@@ -0,0 +1,9 @@
+-- Источник (хост ссылки без www.) и язык ленты — ключи фильтрации персональной ленты.
+ALTER TABLE news
+    ADD COLUMN IF NOT EXISTS source   text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS language text NOT NULL DEFAULT '';
+
+UPDATE news
+SET source = coalesce(lower(regexp_replace(
+        substring(link from '^[a-zA-Z][a-zA-Z0-9+.-]*://([^/?#:]+)'), '^www\.', '', 'i')), '')
+WHERE source = '';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS news_reads;
+DROP TABLE IF EXISTS read_marks;
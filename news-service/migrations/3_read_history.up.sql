@@ -0,0 +1,22 @@
+-- История прочтений. Две компактные структуры на читателя:
+--  - read_marks — «водяной знак»: всё с (published_at, id) < (read_published_at, read_news_id) прочитано
+--    (операция «отметить всё прочитанным»), одна строка на читателя;
+--  - news_reads — отдельные открытые новости новее знака; число строк на читателя ограничено
+--    (старые вытесняются), строки старше окна хранения удаляются фоновой уборкой.
+CREATE TABLE IF NOT EXISTS read_marks (
+  user_id           uuid PRIMARY KEY,
+  read_published_at timestamptz NOT NULL,
+  read_news_id      uuid NOT NULL,
+  updated_at        timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS news_reads (
+  user_id uuid NOT NULL,
+  news_id uuid NOT NULL REFERENCES news (id) ON DELETE CASCADE,
+  read_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, news_id)
+);
+
+-- Вытеснение самых старых отметок читателя и уборка по окну хранения.
+CREATE INDEX IF NOT EXISTS ix_news_reads_user_read_at ON news_reads (user_id, read_at DESC);
+CREATE INDEX IF NOT EXISTS ix_news_reads_read_at ON news_reads (read_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS ux_news_seq;
+ALTER TABLE news DROP COLUMN IF EXISTS seq;
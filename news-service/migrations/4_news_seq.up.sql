@@ -0,0 +1,6 @@
+-- Порядок вставки новостей — курсор живой ленты (WatchNews, Last-Event-ID).
+-- Upsert существующей строки seq не меняет: поток получают только новые новости.
+ALTER TABLE news
+    ADD COLUMN IF NOT EXISTS seq bigserial;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_news_seq ON news (seq);
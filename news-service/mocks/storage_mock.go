@@ -7,8 +7,10 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 	models "github.com/pribylovaa/go-news-aggregator/news-service/internal/models"
 )
 
@@ -50,6 +52,21 @@ func (mr *MockNewsStorageMockRecorder) ListNews(ctx, opts interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNews", reflect.TypeOf((*MockNewsStorage)(nil).ListNews), ctx, opts)
 }
 
+// NewsAfter mocks base method.
+func (m *MockNewsStorage) NewsAfter(ctx context.Context, seq int64, limit int) ([]models.News, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewsAfter", ctx, seq, limit)
+	ret0, _ := ret[0].([]models.News)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewsAfter indicates an expected call of NewsAfter.
+func (mr *MockNewsStorageMockRecorder) NewsAfter(ctx, seq, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewsAfter", reflect.TypeOf((*MockNewsStorage)(nil).NewsAfter), ctx, seq, limit)
+}
+
 // NewsByID mocks base method.
 func (m *MockNewsStorage) NewsByID(ctx context.Context, id string) (*models.News, error) {
 	m.ctrl.T.Helper()
@@ -65,12 +82,28 @@ func (mr *MockNewsStorageMockRecorder) NewsByID(ctx, id interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewsByID", reflect.TypeOf((*MockNewsStorage)(nil).NewsByID), ctx, id)
 }
 
+// NewsByIDs mocks base method.
+func (m *MockNewsStorage) NewsByIDs(ctx context.Context, ids []uuid.UUID, reader uuid.UUID) ([]models.News, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewsByIDs", ctx, ids, reader)
+	ret0, _ := ret[0].([]models.News)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewsByIDs indicates an expected call of NewsByIDs.
+func (mr *MockNewsStorageMockRecorder) NewsByIDs(ctx, ids, reader interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewsByIDs", reflect.TypeOf((*MockNewsStorage)(nil).NewsByIDs), ctx, ids, reader)
+}
+
 // SaveNews mocks base method.
-func (m *MockNewsStorage) SaveNews(ctx context.Context, items []models.News) error {
+func (m *MockNewsStorage) SaveNews(ctx context.Context, items []models.News) ([]models.News, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SaveNews", ctx, items)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].([]models.News)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SaveNews indicates an expected call of SaveNews.
@@ -79,6 +112,72 @@ func (mr *MockNewsStorageMockRecorder) SaveNews(ctx, items interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNews", reflect.TypeOf((*MockNewsStorage)(nil).SaveNews), ctx, items)
 }
 
+// MockReadHistoryStorage is a mock of ReadHistoryStorage interface.
+type MockReadHistoryStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadHistoryStorageMockRecorder
+}
+
+// MockReadHistoryStorageMockRecorder is the mock recorder for MockReadHistoryStorage.
+type MockReadHistoryStorageMockRecorder struct {
+	mock *MockReadHistoryStorage
+}
+
+// NewMockReadHistoryStorage creates a new mock instance.
+func NewMockReadHistoryStorage(ctrl *gomock.Controller) *MockReadHistoryStorage {
+	mock := &MockReadHistoryStorage{ctrl: ctrl}
+	mock.recorder = &MockReadHistoryStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadHistoryStorage) EXPECT() *MockReadHistoryStorageMockRecorder {
+	return m.recorder
+}
+
+// MarkAllRead mocks base method.
+func (m *MockReadHistoryStorage) MarkAllRead(ctx context.Context, userID uuid.UUID, pageToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx, userID, pageToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockReadHistoryStorageMockRecorder) MarkAllRead(ctx, userID, pageToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockReadHistoryStorage)(nil).MarkAllRead), ctx, userID, pageToken)
+}
+
+// MarkRead mocks base method.
+func (m *MockReadHistoryStorage) MarkRead(ctx context.Context, userID uuid.UUID, newsIDs []uuid.UUID, maxPerUser int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userID, newsIDs, maxPerUser)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockReadHistoryStorageMockRecorder) MarkRead(ctx, userID, newsIDs, maxPerUser interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockReadHistoryStorage)(nil).MarkRead), ctx, userID, newsIDs, maxPerUser)
+}
+
+// PurgeReadHistory mocks base method.
+func (m *MockReadHistoryStorage) PurgeReadHistory(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeReadHistory", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeReadHistory indicates an expected call of PurgeReadHistory.
+func (mr *MockReadHistoryStorageMockRecorder) PurgeReadHistory(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeReadHistory", reflect.TypeOf((*MockReadHistoryStorage)(nil).PurgeReadHistory), ctx, before)
+}
+
 // MockStorage is a mock of Storage interface.
 type MockStorage struct {
 	ctrl     *gomock.Controller
@@ -129,6 +228,49 @@ func (mr *MockStorageMockRecorder) ListNews(ctx, opts interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNews", reflect.TypeOf((*MockStorage)(nil).ListNews), ctx, opts)
 }
 
+// MarkAllRead mocks base method.
+func (m *MockStorage) MarkAllRead(ctx context.Context, userID uuid.UUID, pageToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx, userID, pageToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockStorageMockRecorder) MarkAllRead(ctx, userID, pageToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockStorage)(nil).MarkAllRead), ctx, userID, pageToken)
+}
+
+// MarkRead mocks base method.
+func (m *MockStorage) MarkRead(ctx context.Context, userID uuid.UUID, newsIDs []uuid.UUID, maxPerUser int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userID, newsIDs, maxPerUser)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockStorageMockRecorder) MarkRead(ctx, userID, newsIDs, maxPerUser interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockStorage)(nil).MarkRead), ctx, userID, newsIDs, maxPerUser)
+}
+
+// NewsAfter mocks base method.
+func (m *MockStorage) NewsAfter(ctx context.Context, seq int64, limit int) ([]models.News, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewsAfter", ctx, seq, limit)
+	ret0, _ := ret[0].([]models.News)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewsAfter indicates an expected call of NewsAfter.
+func (mr *MockStorageMockRecorder) NewsAfter(ctx, seq, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewsAfter", reflect.TypeOf((*MockStorage)(nil).NewsAfter), ctx, seq, limit)
+}
+
 // NewsByID mocks base method.
 func (m *MockStorage) NewsByID(ctx context.Context, id string) (*models.News, error) {
 	m.ctrl.T.Helper()
@@ -144,12 +286,43 @@ func (mr *MockStorageMockRecorder) NewsByID(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewsByID", reflect.TypeOf((*MockStorage)(nil).NewsByID), ctx, id)
 }
 
+// NewsByIDs mocks base method.
+func (m *MockStorage) NewsByIDs(ctx context.Context, ids []uuid.UUID, reader uuid.UUID) ([]models.News, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewsByIDs", ctx, ids, reader)
+	ret0, _ := ret[0].([]models.News)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewsByIDs indicates an expected call of NewsByIDs.
+func (mr *MockStorageMockRecorder) NewsByIDs(ctx, ids, reader interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewsByIDs", reflect.TypeOf((*MockStorage)(nil).NewsByIDs), ctx, ids, reader)
+}
+
+// PurgeReadHistory mocks base method.
+func (m *MockStorage) PurgeReadHistory(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeReadHistory", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeReadHistory indicates an expected call of PurgeReadHistory.
+func (mr *MockStorageMockRecorder) PurgeReadHistory(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeReadHistory", reflect.TypeOf((*MockStorage)(nil).PurgeReadHistory), ctx, before)
+}
+
 // SaveNews mocks base method.
-func (m *MockStorage) SaveNews(ctx context.Context, items []models.News) error {
+func (m *MockStorage) SaveNews(ctx context.Context, items []models.News) ([]models.News, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SaveNews", ctx, items)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].([]models.News)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SaveNews indicates an expected call of SaveNews.
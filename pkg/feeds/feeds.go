@@ -0,0 +1,86 @@
+// feeds — нормализация ключей новостной ленты: источник, язык, категория.
+//
+// Общая для users-service (валидация предпочтений читателя) и news-service
+// (проставление ключей при ингесте и фильтрация персональной ленты), чтобы
+// значения с обеих сторон сравнивались побайтно.
+package feeds
+
+import (
+	"net/url"
+	"strings"
+)
+
+// MaxKeyLen — предельная длина нормализованного ключа (в байтах).
+const MaxKeyLen = 64
+
+// Source нормализует источник новости: хост ссылки или сам хост ("Lenta.ru",
+// "https://www.lenta.ru/news/1") -> "lenta.ru". Схема, порт, путь и префикс
+// "www." отбрасываются. Некорректное значение -> "".
+func Source(raw string) string {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	if s == "" {
+		return ""
+	}
+
+	if strings.Contains(s, "://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return ""
+		}
+
+		s = u.Hostname()
+	} else {
+		if i := strings.IndexAny(s, "/?#"); i >= 0 {
+			s = s[:i]
+		}
+
+		if i := strings.LastIndexByte(s, ':'); i >= 0 {
+			s = s[:i]
+		}
+	}
+
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "www."), ".")
+	if s == "" || len(s) > MaxKeyLen || strings.HasPrefix(s, ".") || strings.Contains(s, "..") {
+		return ""
+	}
+
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '.' && r != '-' {
+			return ""
+		}
+	}
+
+	return s
+}
+
+// Language нормализует код языка до основного подтега: "en-US", "en_us" -> "en".
+// Допустимы 2–3 латинские буквы; иначе -> "".
+func Language(raw string) string {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	if i := strings.IndexAny(s, "-_"); i >= 0 {
+		s = s[:i]
+	}
+
+	if len(s) < 2 || len(s) > 3 {
+		return ""
+	}
+
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return ""
+		}
+	}
+
+	return s
+}
+
+// Category нормализует категорию для сравнения: обрезка, нижний регистр,
+// схлопывание пробелов. Пустая или длиннее MaxKeyLen -> "".
+func Category(raw string) string {
+	s := strings.Join(strings.Fields(strings.ToLower(raw)), " ")
+	if len(s) > MaxKeyLen {
+		return ""
+	}
+
+	return s
+}
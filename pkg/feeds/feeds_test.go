@@ -0,0 +1,55 @@
+package feeds
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"https://www.Lenta.ru/news/1?x=1": "lenta.ru",
+		"lenta.ru":                        "lenta.ru",
+		" WWW.RBC.ru:443/path ":           "rbc.ru",
+		"http://news.example.org:8080":    "news.example.org",
+		"":                                "",
+		"bad host":                        "",
+		"a..b":                            "",
+		"https://":                        "",
+		strings.Repeat("a", 65) + ".ru":   "",
+	}
+
+	for in, want := range cases {
+		require.Equal(t, want, Source(in), in)
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"ru":    "ru",
+		"en-US": "en",
+		"pt_br": "pt",
+		"fil":   "fil",
+		"e":     "",
+		"engl":  "",
+		"1a":    "",
+		"":      "",
+	}
+
+	for in, want := range cases {
+		require.Equal(t, want, Language(in), in)
+	}
+}
+
+func TestCategory(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "world news", Category("  World   News "))
+	require.Equal(t, "", Category("   "))
+	require.Equal(t, "", Category(strings.Repeat("x", MaxKeyLen+1)))
+}
@@ -0,0 +1,88 @@
+// identity описывает личность вызывающего, которую api-gateway передаёт
+// внутренним сервисам через gRPC metadata.
+//
+// Концепция:
+//   - Шлюз аутентифицирует запрос и кладёт в исходящий metadata x-user-id и x-user-roles;
+//   - Сервисы читают их через FromIncomingContext и доверяют значениям
+//     (внутренняя сеть: прямого доступа клиентов к сервисам нет);
+//   - Отсутствие x-user-id — анонимный вызов (Caller.UserID == "").
+package identity
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Ключи metadata.
+const (
+	MDUserID    = "x-user-id"
+	MDUserRoles = "x-user-roles"
+)
+
+// Роли.
+const (
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+)
+
+// Caller — личность вызывающего.
+type Caller struct {
+	UserID string
+	Roles  []string
+}
+
+// Authenticated сообщает, известен ли вызывающий.
+func (c Caller) Authenticated() bool { return c.UserID != "" }
+
+// HasRole проверяет наличие роли (без учёта регистра).
+func (c Caller) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if strings.EqualFold(r, role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FromIncomingContext извлекает Caller из входящего metadata.
+// Роли передаются одним значением через запятую либо несколькими значениями ключа;
+// пустые элементы отбрасываются. Без metadata — анонимный Caller.
+func FromIncomingContext(ctx context.Context) Caller {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Caller{}
+	}
+
+	var c Caller
+	if v := md.Get(MDUserID); len(v) > 0 {
+		c.UserID = strings.TrimSpace(v[0])
+	}
+
+	for _, raw := range md.Get(MDUserRoles) {
+		for _, r := range strings.Split(raw, ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				c.Roles = append(c.Roles, r)
+			}
+		}
+	}
+
+	return c
+}
+
+// Pairs возвращает пары ключ/значение для metadata.AppendToOutgoingContext.
+// Для анонимного Caller — nil.
+func (c Caller) Pairs() []string {
+	if !c.Authenticated() {
+		return nil
+	}
+
+	pairs := []string{MDUserID, c.UserID}
+	if len(c.Roles) > 0 {
+		pairs = append(pairs, MDUserRoles, strings.Join(c.Roles, ","))
+	}
+
+	return pairs
+}
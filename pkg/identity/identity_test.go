@@ -0,0 +1,42 @@
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestFromIncomingContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no_metadata", func(t *testing.T) {
+		c := FromIncomingContext(context.Background())
+		require.False(t, c.Authenticated())
+		require.Empty(t, c.Roles)
+	})
+
+	t.Run("user_and_roles", func(t *testing.T) {
+		md := metadata.Pairs(MDUserID, " u1 ", MDUserRoles, "admin, ,Moderator", MDUserRoles, "x")
+		c := FromIncomingContext(metadata.NewIncomingContext(context.Background(), md))
+
+		require.True(t, c.Authenticated())
+		require.Equal(t, "u1", c.UserID)
+		require.Equal(t, []string{"admin", "Moderator", "x"}, c.Roles)
+		require.True(t, c.HasRole(RoleModerator))
+		require.True(t, c.HasRole(RoleAdmin))
+		require.False(t, c.HasRole("editor"))
+	})
+}
+
+func TestCaller_Pairs(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, Caller{Roles: []string{RoleAdmin}}.Pairs())
+	require.Equal(t, []string{MDUserID, "u1"}, Caller{UserID: "u1"}.Pairs())
+	require.Equal(t,
+		[]string{MDUserID, "u1", MDUserRoles, "admin,moderator"},
+		Caller{UserID: "u1", Roles: []string{RoleAdmin, RoleModerator}}.Pairs(),
+	)
+}
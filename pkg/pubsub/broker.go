@@ -0,0 +1,133 @@
+// pubsub — простой in-process брокер событий для живых подписок (server streaming).
+//
+// Принципы:
+//   - подписка адресуется строковым ключом (например, user_id получателя);
+//   - у каждого подписчика собственный буферизированный канал;
+//   - Publish никогда не блокируется: медленный подписчик теряет события,
+//     а счётчик потерь доступен через Dropped (источник истины — хранилище);
+//   - подписчик Watch при переполнении не теряет события молча, а отключается
+//     (Lagged) — и дочитывает пропущенное из хранилища при переподключении.
+package pubsub
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Broker — потокобезопасный брокер событий типа T.
+type Broker[T any] struct {
+	mu      sync.RWMutex
+	subs    map[string]map[*subscriber[T]]struct{}
+	dropped atomic.Int64
+}
+
+type subscriber[T any] struct {
+	ch     chan T
+	once   sync.Once
+	cancel func()
+	// evict — отключать при переполнении буфера вместо потери события.
+	evict  bool
+	lagged atomic.Bool
+}
+
+// Subscription — подписка Watch.
+type Subscription[T any] struct {
+	// C — события; закрывается после Close или отключения медленного подписчика.
+	C   <-chan T
+	sub *subscriber[T]
+}
+
+// Close отписывает и закрывает C; повторный вызов безопасен.
+func (s *Subscription[T]) Close() { s.sub.cancel() }
+
+// Lagged — подписка отключена, потому что не успевала читать события.
+func (s *Subscription[T]) Lagged() bool { return s.sub.lagged.Load() }
+
+// New создаёт пустой брокер.
+func New[T any]() *Broker[T] {
+	return &Broker[T]{subs: make(map[string]map[*subscriber[T]]struct{})}
+}
+
+// Subscribe регистрирует подписчика по ключу key с буфером buffer (минимум 1).
+// Возвращает канал событий и функцию отписки; после отписки канал закрывается.
+func (b *Broker[T]) Subscribe(key string, buffer int) (<-chan T, func()) {
+	sub := b.subscribe(key, buffer, false)
+	return sub.ch, sub.cancel
+}
+
+// Watch — подписка, которая при переполнении буфера отключается (C закрывается,
+// Lagged() == true) вместо потери событий: Publish по-прежнему не блокируется.
+func (b *Broker[T]) Watch(key string, buffer int) *Subscription[T] {
+	sub := b.subscribe(key, buffer, true)
+	return &Subscription[T]{C: sub.ch, sub: sub}
+}
+
+func (b *Broker[T]) subscribe(key string, buffer int, evict bool) *subscriber[T] {
+	if buffer < 1 {
+		buffer = 1
+	}
+
+	sub := &subscriber[T]{ch: make(chan T, buffer), evict: evict}
+
+	b.mu.Lock()
+	set, ok := b.subs[key]
+	if !ok {
+		set = make(map[*subscriber[T]]struct{})
+		b.subs[key] = set
+	}
+	set[sub] = struct{}{}
+	b.mu.Unlock()
+
+	sub.cancel = func() {
+		sub.once.Do(func() {
+			b.mu.Lock()
+			if set, ok := b.subs[key]; ok {
+				delete(set, sub)
+				if len(set) == 0 {
+					delete(b.subs, key)
+				}
+			}
+			close(sub.ch)
+			b.mu.Unlock()
+		})
+	}
+
+	return sub
+}
+
+// Publish рассылает событие всем подписчикам ключа key без блокировки.
+func (b *Broker[T]) Publish(key string, event T) {
+	var lagged []*subscriber[T]
+
+	b.mu.RLock()
+	for sub := range b.subs[key] {
+		select {
+		case sub.ch <- event:
+		default:
+			b.dropped.Add(1)
+			if sub.evict {
+				sub.lagged.Store(true)
+				lagged = append(lagged, sub)
+			}
+		}
+	}
+	b.mu.RUnlock()
+
+	// Отписка берёт блокировку на запись — после RUnlock.
+	for _, sub := range lagged {
+		sub.cancel()
+	}
+}
+
+// Subscribers возвращает число активных подписчиков ключа key.
+func (b *Broker[T]) Subscribers(key string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subs[key])
+}
+
+// Dropped возвращает общее число событий, отброшенных из-за переполнения буферов.
+func (b *Broker[T]) Dropped() int64 {
+	return b.dropped.Load()
+}
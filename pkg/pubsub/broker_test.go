@@ -0,0 +1,86 @@
+package pubsub
+
+// Тесты in-process брокера (pkg/pubsub/broker.go).
+//
+//  Проверяем:
+//  - доставку события всем подписчикам ключа и изоляцию ключей;
+//  - неблокирующий Publish при переполненном буфере (счётчик Dropped);
+//  - Watch: медленный подписчик отключается (Lagged), полученное до этого сохраняется;
+//  - отписку: канал закрывается, повторный cancel безопасен.
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroker_PublishToKeySubscribers(t *testing.T) {
+	b := New[int]()
+
+	a1, cancel1 := b.Subscribe("a", 4)
+	defer cancel1()
+	a2, cancel2 := b.Subscribe("a", 4)
+	defer cancel2()
+	other, cancel3 := b.Subscribe("b", 4)
+	defer cancel3()
+
+	b.Publish("a", 42)
+
+	require.Equal(t, 42, <-a1)
+	require.Equal(t, 42, <-a2)
+	require.Len(t, other, 0)
+	require.Equal(t, 2, b.Subscribers("a"))
+}
+
+func TestBroker_SlowConsumerDropsEvents(t *testing.T) {
+	b := New[int]()
+
+	ch, cancel := b.Subscribe("a", 1)
+	defer cancel()
+
+	b.Publish("a", 1)
+	b.Publish("a", 2)
+
+	require.Equal(t, 1, <-ch)
+	require.EqualValues(t, 1, b.Dropped())
+}
+
+func TestBroker_CancelClosesChannel(t *testing.T) {
+	b := New[int]()
+
+	ch, cancel := b.Subscribe("a", 1)
+	cancel()
+	cancel()
+
+	_, ok := <-ch
+	require.False(t, ok)
+	require.Equal(t, 0, b.Subscribers("a"))
+
+	// Публикация без подписчиков — no-op.
+	b.Publish("a", 1)
+}
+
+func TestBroker_WatchEvictsSlowConsumer(t *testing.T) {
+	b := New[int]()
+
+	fast := b.Watch("a", 4)
+	defer fast.Close()
+	slow := b.Watch("a", 1)
+	defer slow.Close()
+
+	b.Publish("a", 1)
+	b.Publish("a", 2)
+
+	require.Equal(t, 1, <-slow.C)
+	_, ok := <-slow.C
+	require.False(t, ok)
+	require.True(t, slow.Lagged())
+
+	require.False(t, fast.Lagged())
+	require.Equal(t, 1, <-fast.C)
+	require.Equal(t, 2, <-fast.C)
+	require.Equal(t, 1, b.Subscribers("a"))
+
+	// Close после отключения безопасен.
+	slow.Close()
+}
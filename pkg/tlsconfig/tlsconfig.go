@@ -0,0 +1,304 @@
+// tlsconfig — TLS и mTLS для gRPC-серверов и клиентов внутри кластера.
+//
+// Принципы:
+//   - один набор файлов на сервис: CertFile/KeyFile — его сертификат (серверный
+//     и, при mTLS, клиентский), CAFile — CA, которым проверяется собеседник;
+//   - файлы перечитываются при изменении (Run): ротация сертификатов без
+//     рестарта; каждое рукопожатие берёт актуальные сертификат и CA, уже
+//     установленные соединения не рвутся;
+//   - неудачная перезагрузка (например, ключ уже новый, а сертификат ещё старый)
+//     не ломает сервис: остаются прежние файлы, попытка повторяется;
+//   - сервер с ClientAuth требует клиентский сертификат, подписанный CA, и
+//     пускает только клиентов с SAN из AllowedSANs (пустой — любого клиента CA);
+//   - выключенный TLS — nil *Reloader: его методы отдают insecure-креды.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// defaultReloadInterval — период проверки файлов, если ReloadInterval не задан.
+const defaultReloadInterval = 30 * time.Second
+
+// Config — TLS gRPC сервиса; встраивается в config.Config сервисов
+// (env — с префиксом, например GRPC_TLS_).
+type Config struct {
+	// Enabled — TLS для gRPC-сервера и исходящих gRPC-клиентов.
+	Enabled bool `yaml:"enabled" env:"ENABLED"`
+	// CertFile/KeyFile — сертификат сервиса (PEM). Серверу обязателен,
+	// клиенту — только для mTLS.
+	CertFile string `yaml:"cert_file" env:"CERT_FILE"`
+	KeyFile  string `yaml:"key_file"  env:"KEY_FILE"`
+	// CAFile — CA собеседника (PEM): клиент проверяет им сервер (пусто —
+	// системные корни), сервер с ClientAuth — клиентов.
+	CAFile string `yaml:"ca_file" env:"CA_FILE"`
+	// ClientAuth — сервер требует клиентский сертификат (mTLS).
+	ClientAuth bool `yaml:"client_auth" env:"CLIENT_AUTH"`
+	// AllowedSANs — SAN клиентских сертификатов, которых пускает сервер
+	// (DNS, URI вроде spiffe://..., e-mail или IP; точное совпадение).
+	AllowedSANs []string `yaml:"allowed_sans" env:"ALLOWED_SANS" env-separator:","`
+	// ReloadInterval — период проверки файлов на изменение.
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"RELOAD_INTERVAL" env-default:"30s"`
+}
+
+// Reloader — текущие сертификат и CA сервиса; безопасен для конкурентного использования.
+type Reloader struct {
+	cfg     Config
+	allowed map[string]bool
+	cur     atomic.Pointer[material]
+
+	mu sync.Mutex // сериализует Reload
+}
+
+// material — снимок файлов, из которого строятся рукопожатия.
+type material struct {
+	cert  *tls.Certificate // nil — сертификата нет (клиент без mTLS)
+	pool  *x509.CertPool   // nil — системные корни
+	stamp string           // размеры и mtime файлов
+}
+
+// New проверяет конфигурацию и загружает файлы. Выключенный TLS — (nil, nil).
+func New(cfg Config) (*Reloader, error) {
+	const op = "tlsconfig/New"
+
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, fmt.Errorf("%s: cert_file and key_file must be set together", op)
+	}
+	if cfg.ClientAuth && cfg.CAFile == "" {
+		return nil, fmt.Errorf("%s: client_auth requires ca_file", op)
+	}
+	if len(cfg.AllowedSANs) > 0 && !cfg.ClientAuth {
+		return nil, fmt.Errorf("%s: allowed_sans requires client_auth", op)
+	}
+	if cfg.ReloadInterval <= 0 {
+		cfg.ReloadInterval = defaultReloadInterval
+	}
+
+	r := &Reloader{cfg: cfg, allowed: make(map[string]bool, len(cfg.AllowedSANs))}
+	for _, san := range cfg.AllowedSANs {
+		r.allowed[san] = true
+	}
+
+	if _, err := r.Reload(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return r, nil
+}
+
+// Reload перечитывает файлы, если они изменились с прошлой загрузки.
+// При ошибке остаются прежние сертификат и CA.
+func (r *Reloader) Reload() (changed bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stamp, err := r.stamp()
+	if err != nil {
+		return false, err
+	}
+	if old := r.cur.Load(); old != nil && old.stamp == stamp {
+		return false, nil
+	}
+
+	m := &material{stamp: stamp}
+	if r.cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+		if err != nil {
+			return false, fmt.Errorf("load key pair: %w", err)
+		}
+		m.cert = &cert
+	}
+	if r.cfg.CAFile != "" {
+		pem, err := os.ReadFile(r.cfg.CAFile)
+		if err != nil {
+			return false, fmt.Errorf("read ca: %w", err)
+		}
+		m.pool = x509.NewCertPool()
+		if !m.pool.AppendCertsFromPEM(pem) {
+			return false, fmt.Errorf("ca %s: no PEM certificates", r.cfg.CAFile)
+		}
+	}
+
+	r.cur.Store(m)
+
+	return true, nil
+}
+
+// stamp — отпечаток файлов: размер и mtime (по симлинкам — как у секретов k8s).
+func (r *Reloader) stamp() (string, error) {
+	var out string
+	for _, p := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.CAFile} {
+		if p == "" {
+			continue
+		}
+		st, err := os.Stat(p)
+		if err != nil {
+			return "", err
+		}
+		out += fmt.Sprintf("%s:%d:%d;", p, st.Size(), st.ModTime().UnixNano())
+	}
+
+	return out, nil
+}
+
+// Run проверяет файлы каждые ReloadInterval до отмены ctx. Для nil — сразу выходит.
+func (r *Reloader) Run(ctx context.Context, log *slog.Logger) {
+	if r == nil {
+		return
+	}
+
+	t := time.NewTicker(r.cfg.ReloadInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			changed, err := r.Reload()
+			switch {
+			case err != nil:
+				log.Warn("tls_reload_failed", slog.String("err", err.Error()))
+			case changed:
+				log.Info("tls_reloaded", slog.String("cert_file", r.cfg.CertFile))
+			}
+		}
+	}
+}
+
+// ServerOption — креды gRPC-сервера; для nil — без TLS.
+func (r *Reloader) ServerOption() (grpc.ServerOption, error) {
+	if r == nil {
+		return grpc.Creds(insecure.NewCredentials()), nil
+	}
+	if r.cfg.CertFile == "" {
+		return nil, errors.New("tlsconfig: server requires cert_file and key_file")
+	}
+
+	return grpc.Creds(&reloadingCreds{r: r}), nil
+}
+
+// DialOption — креды gRPC-клиента; для nil — без TLS.
+func (r *Reloader) DialOption() grpc.DialOption {
+	if r == nil {
+		return grpc.WithTransportCredentials(insecure.NewCredentials())
+	}
+
+	return grpc.WithTransportCredentials(&reloadingCreds{r: r})
+}
+
+// serverConfig — tls.Config рукопожатия сервера из текущего снимка.
+func (r *Reloader) serverConfig() *tls.Config {
+	m := r.cur.Load()
+
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*m.cert},
+	}
+	if r.cfg.ClientAuth {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		cfg.ClientCAs = m.pool
+		cfg.VerifyConnection = r.verifyClient
+	}
+
+	return cfg
+}
+
+// clientConfig — tls.Config рукопожатия клиента из текущего снимка.
+func (r *Reloader) clientConfig() *tls.Config {
+	m := r.cur.Load()
+
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    m.pool,
+	}
+	if m.cert != nil {
+		cfg.Certificates = []tls.Certificate{*m.cert}
+	}
+
+	return cfg
+}
+
+// verifyClient пускает клиента, если хотя бы один SAN его сертификата
+// есть в AllowedSANs. Цепочка к этому моменту уже проверена.
+func (r *Reloader) verifyClient(cs tls.ConnectionState) error {
+	if len(r.allowed) == 0 {
+		return nil
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("tlsconfig: no client certificate")
+	}
+
+	sans := SANs(cs.PeerCertificates[0])
+	if slices.ContainsFunc(sans, func(s string) bool { return r.allowed[s] }) {
+		return nil
+	}
+
+	return fmt.Errorf("tlsconfig: client certificate SANs %v not allowed", sans)
+}
+
+// SANs — все SAN сертификата строками: DNS, URI, e-mail, IP.
+func SANs(c *x509.Certificate) []string {
+	out := make([]string, 0, len(c.DNSNames)+len(c.URIs)+len(c.EmailAddresses)+len(c.IPAddresses))
+	out = append(out, c.DNSNames...)
+	for _, u := range c.URIs {
+		out = append(out, u.String())
+	}
+	out = append(out, c.EmailAddresses...)
+	for _, ip := range c.IPAddresses {
+		out = append(out, ip.String())
+	}
+
+	return out
+}
+
+// reloadingCreds — credentials.TransportCredentials, которые на каждое
+// рукопожатие строят TLS из текущего снимка Reloader.
+type reloadingCreds struct {
+	r          *Reloader
+	serverName string
+}
+
+func (c *reloadingCreds) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	cfg := c.r.clientConfig()
+	cfg.ServerName = c.serverName
+
+	return credentials.NewTLS(cfg).ClientHandshake(ctx, authority, conn)
+}
+
+func (c *reloadingCreds) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.r.serverConfig()).ServerHandshake(conn)
+}
+
+func (c *reloadingCreds) Info() credentials.ProtocolInfo {
+	return credentials.NewTLS(&tls.Config{ServerName: c.serverName}).Info()
+}
+
+func (c *reloadingCreds) Clone() credentials.TransportCredentials {
+	clone := *c
+	return &clone
+}
+
+// OverrideServerName — устаревший метод интерфейса, нужен для совместимости.
+func (c *reloadingCreds) OverrideServerName(name string) error {
+	c.serverName = name
+	return nil
+}
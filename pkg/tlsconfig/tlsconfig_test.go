@@ -0,0 +1,283 @@
+package tlsconfig
+
+// Интеграционные тесты на локально выпущенном CA (настоящий gRPC-сервер на loopback):
+//   - mTLS: пускаются только клиенты с сертификатом CA и SAN из allowlist;
+//   - ротация: новые файлы подхватываются без рестарта, битые — игнорируются;
+//   - проверка конфигурации и insecure-режим для выключенного TLS.
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+)
+
+// testCA — локальный CA для выпуска сертификатов в тестах.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newCA(t *testing.T, cn string) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue выпускает сертификат сервиса (serverAuth + clientAuth) с SAN:
+// IP 127.0.0.1 и переданными URI.
+func (ca *testCA) issue(t *testing.T, serial int64, uris ...string) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "svc"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	for _, u := range uris {
+		parsed, err := url.Parse(u)
+		require.NoError(t, err)
+		tmpl.URIs = append(tmpl.URIs, parsed)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// files — пути к сертификату, ключу и CA одного сервиса в отдельном каталоге.
+type files struct{ cert, key, ca string }
+
+func writeFiles(t *testing.T, dir string, certPEM, keyPEM, caPEM []byte) files {
+	t.Helper()
+
+	f := files{cert: filepath.Join(dir, "tls.crt"), key: filepath.Join(dir, "tls.key"), ca: filepath.Join(dir, "ca.crt")}
+	require.NoError(t, os.WriteFile(f.cert, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(f.key, keyPEM, 0o600))
+	require.NoError(t, os.WriteFile(f.ca, caPEM, 0o600))
+
+	return f
+}
+
+// serve поднимает gRPC health-сервер с кредами r и возвращает его адрес.
+func serve(t *testing.T, r *Reloader) string {
+	t.Helper()
+
+	opt, err := r.ServerOption()
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(opt)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+// check — health-запрос новым соединением; возвращает серийный номер сертификата сервера.
+func check(t *testing.T, addr string, r *Reloader) (int64, error) {
+	t.Helper()
+
+	conn, err := grpc.NewClient(addr, r.DialOption())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var p peer.Peer
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Peer(&p)); err != nil {
+		return 0, err
+	}
+
+	return p.AuthInfo.(credentials.TLSInfo).State.PeerCertificates[0].SerialNumber.Int64(), nil
+}
+
+func mustNew(t *testing.T, cfg Config) *Reloader {
+	t.Helper()
+
+	cfg.Enabled = true
+	r, err := New(cfg)
+	require.NoError(t, err)
+
+	return r
+}
+
+func TestMTLS_AllowedSANs(t *testing.T) {
+	ca := newCA(t, "cluster-ca")
+	certPEM, keyPEM := ca.issue(t, 10, "spiffe://cluster/news-service")
+	srvFiles := writeFiles(t, t.TempDir(), certPEM, keyPEM, ca.pem)
+
+	addr := serve(t, mustNew(t, Config{
+		CertFile:    srvFiles.cert,
+		KeyFile:     srvFiles.key,
+		CAFile:      srvFiles.ca,
+		ClientAuth:  true,
+		AllowedSANs: []string{"spiffe://cluster/api-gateway"},
+	}))
+
+	client := func(ca *testCA, caPEM []byte, uri string) *Reloader {
+		certPEM, keyPEM := ca.issue(t, 20, uri)
+		f := writeFiles(t, t.TempDir(), certPEM, keyPEM, caPEM)
+		return mustNew(t, Config{CertFile: f.cert, KeyFile: f.key, CAFile: f.ca})
+	}
+
+	serial, err := check(t, addr, client(ca, ca.pem, "spiffe://cluster/api-gateway"))
+	require.NoError(t, err)
+	require.EqualValues(t, 10, serial)
+
+	// SAN не из allowlist.
+	_, err = check(t, addr, client(ca, ca.pem, "spiffe://cluster/users-service"))
+	require.Error(t, err)
+
+	// Сертификат чужого CA.
+	_, err = check(t, addr, client(newCA(t, "rogue-ca"), ca.pem, "spiffe://cluster/api-gateway"))
+	require.Error(t, err)
+
+	// Без клиентского сертификата.
+	caOnly := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caOnly, ca.pem, 0o600))
+	_, err = check(t, addr, mustNew(t, Config{CAFile: caOnly}))
+	require.Error(t, err)
+
+	// Без TLS.
+	_, err = check(t, addr, nil)
+	require.Error(t, err)
+}
+
+func TestReloader_RotatesCertificates(t *testing.T) {
+	ca := newCA(t, "cluster-ca")
+	certPEM, keyPEM := ca.issue(t, 1)
+	dir := t.TempDir()
+	f := writeFiles(t, dir, certPEM, keyPEM, ca.pem)
+
+	srv := mustNew(t, Config{CertFile: f.cert, KeyFile: f.key, ReloadInterval: 10 * time.Millisecond})
+	addr := serve(t, srv)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go srv.Run(ctx, slog.New(slog.DiscardHandler))
+
+	cli := mustNew(t, Config{CAFile: f.ca})
+	serial, err := check(t, addr, cli)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, serial)
+
+	// Ротация: новые соединения получают новый сертификат без рестарта.
+	certPEM, keyPEM = ca.issue(t, 2)
+	writeFiles(t, dir, certPEM, keyPEM, ca.pem)
+	require.Eventually(t, func() bool {
+		serial, err := check(t, addr, cli)
+		return err == nil && serial == 2
+	}, 2*time.Second, 20*time.Millisecond)
+
+	// Ключ от другого сертификата: перезагрузка отклоняется, сервер живёт на прежних файлах.
+	_, otherKey := ca.issue(t, 3)
+	require.NoError(t, os.WriteFile(f.key, otherKey, 0o600))
+	_, err = srv.Reload()
+	require.Error(t, err)
+
+	serial, err = check(t, addr, cli)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, serial)
+}
+
+func TestNew_Validation(t *testing.T) {
+	r, err := New(Config{Enabled: false, CertFile: "ignored"})
+	require.NoError(t, err)
+	require.Nil(t, r)
+
+	// nil — без TLS.
+	_, err = r.ServerOption()
+	require.NoError(t, err)
+	require.NotNil(t, r.DialOption())
+	r.Run(context.Background(), nil)
+
+	ca := newCA(t, "cluster-ca")
+	certPEM, keyPEM := ca.issue(t, 1)
+	f := writeFiles(t, t.TempDir(), certPEM, keyPEM, ca.pem)
+
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"cert without key", Config{CertFile: f.cert}},
+		{"client auth without ca", Config{CertFile: f.cert, KeyFile: f.key, ClientAuth: true}},
+		{"allowed sans without client auth", Config{CertFile: f.cert, KeyFile: f.key, CAFile: f.ca, AllowedSANs: []string{"x"}}},
+		{"missing file", Config{CertFile: f.cert, KeyFile: f.key + ".missing"}},
+		{"ca without certificates", Config{CAFile: f.key}},
+	}
+	for _, tt := range tests {
+		tt.cfg.Enabled = true
+		_, err := New(tt.cfg)
+		require.Error(t, err, tt.name)
+	}
+
+	// Клиент без сертификата не может быть сервером.
+	_, err = mustNew(t, Config{CAFile: f.ca}).ServerOption()
+	require.Error(t, err)
+
+	require.Equal(t, []string{"127.0.0.1"}, SANs(mustLeaf(t, f)))
+}
+
+func mustLeaf(t *testing.T, f files) *x509.Certificate {
+	t.Helper()
+
+	pair, err := tls.LoadX509KeyPair(f.cert, f.key)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	require.NoError(t, err)
+
+	return leaf
+}
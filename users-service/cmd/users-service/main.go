@@ -17,8 +17,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/pribylovaa/go-news-aggregator/pkg/interceptors"
+	"github.com/pribylovaa/go-news-aggregator/pkg/tlsconfig"
 	usersv1 "github.com/pribylovaa/go-news-aggregator/users-service/gen/go/users"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/news"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/service"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage/minio"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage/postgres"
@@ -49,6 +51,21 @@ func main() {
 
 	rootCtx, rootCancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 
+	// TLS gRPC-сервера и клиентов; сертификаты перечитываются с диска до остановки.
+	grpcTLS, err := tlsconfig.New(cfg.GRPC.TLS)
+	if err != nil {
+		log.Error("grpc_tls_init_failed", slog.String("err", err.Error()))
+		rootCancel()
+		os.Exit(1)
+	}
+	grpcCreds, err := grpcTLS.ServerOption()
+	if err != nil {
+		log.Error("grpc_tls_init_failed", slog.String("err", err.Error()))
+		rootCancel()
+		os.Exit(1)
+	}
+	go grpcTLS.Run(rootCtx, log)
+
 	dbCtx, dbCancel := context.WithTimeout(rootCtx, 10*time.Second)
 	profilesStore, err := postgres.New(dbCtx, cfg.Postgres.URL)
 	dbCancel()
@@ -59,6 +76,20 @@ func main() {
 	}
 	log.Info("postgres_connected")
 
+	// Ключи username существующих профилей после миграции 4 (no-op, если всё проставлено).
+	backfillCtx, backfillCancel := context.WithTimeout(rootCtx, time.Minute)
+	backfilled, err := profilesStore.BackfillUsernameKeys(backfillCtx)
+	backfillCancel()
+	if err != nil {
+		log.Error("username_keys_backfill_failed", slog.String("err", err.Error()))
+		rootCancel()
+		profilesStore.Close()
+		os.Exit(1)
+	}
+	if backfilled > 0 {
+		log.Info("username_keys_backfilled", slog.Int("profiles", backfilled))
+	}
+
 	s3Ctx, s3Cancel := context.WithTimeout(rootCtx, 10*time.Second)
 	avatarsStore, err := minio.New(s3Ctx, cfg)
 	s3Cancel()
@@ -71,8 +102,28 @@ func main() {
 	log.Info("minio_connected")
 
 	svc := service.New(profilesStore, avatarsStore, cfg)
+
+	// Опционально: news-service для гидрации закладок.
+	var newsClient *news.Client
+	if cfg.News.Addr != "" {
+		newsClient, err = news.New(cfg.News.Addr, grpcTLS.DialOption())
+		if err != nil {
+			log.Error("news_client_init_failed", slog.String("err", err.Error()))
+			rootCancel()
+			profilesStore.Close()
+			os.Exit(1)
+		}
+		svc.SetNewsSource(newsClient)
+		log.Info("news_client_initialized", slog.String("addr", cfg.News.Addr))
+	} else {
+		log.Warn("news_addr_empty_bookmarks_without_news")
+	}
 	log.Info("service_initialized")
 
+	if cfg.Reaper.Enabled {
+		go svc.StartAvatarReaper(rootCtx)
+	}
+
 	var ready int32 // 0 — not ready; 1 — ready
 	httpAddr := cfg.HTTP.Addr()
 
@@ -109,6 +160,7 @@ func main() {
 	grpc_prometheus.EnableHandlingTimeHistogram()
 
 	grpcOpts := []grpc.ServerOption{
+		grpcCreds,
 		grpc.ChainUnaryInterceptor(
 			interceptors.Recover(log),
 			interceptors.UnaryLoggingInterceptor(log),
@@ -187,6 +239,9 @@ func main() {
 	_ = httpSrv.Shutdown(context.Background())
 
 	rootCancel()
+	if newsClient != nil {
+		_ = newsClient.Close()
+	}
 	profilesStore.Close()
 
 	log.Info("service_stopped")
@@ -22,6 +22,56 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Кому виден атрибут профиля.
+type Visibility int32
+
+const (
+	Visibility_PUBLIC     Visibility = 0 // всем
+	Visibility_REGISTERED Visibility = 1 // только аутентифицированным
+	Visibility_PRIVATE    Visibility = 2 // только владельцу (и admin)
+)
+
+// Enum value maps for Visibility.
+var (
+	Visibility_name = map[int32]string{
+		0: "PUBLIC",
+		1: "REGISTERED",
+		2: "PRIVATE",
+	}
+	Visibility_value = map[string]int32{
+		"PUBLIC":     0,
+		"REGISTERED": 1,
+		"PRIVATE":    2,
+	}
+)
+
+func (x Visibility) Enum() *Visibility {
+	p := new(Visibility)
+	*p = x
+	return p
+}
+
+func (x Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_users_proto_enumTypes[0].Descriptor()
+}
+
+func (Visibility) Type() protoreflect.EnumType {
+	return &file_users_proto_enumTypes[0]
+}
+
+func (x Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Visibility.Descriptor instead.
+func (Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{0}
+}
+
 type Gender int32
 
 const (
@@ -58,11 +108,11 @@ func (x Gender) String() string {
 }
 
 func (Gender) Descriptor() protoreflect.EnumDescriptor {
-	return file_users_proto_enumTypes[0].Descriptor()
+	return file_users_proto_enumTypes[1].Descriptor()
 }
 
 func (Gender) Type() protoreflect.EnumType {
-	return &file_users_proto_enumTypes[0]
+	return &file_users_proto_enumTypes[1]
 }
 
 func (x Gender) Number() protoreflect.EnumNumber {
@@ -71,27 +121,94 @@ func (x Gender) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Gender.Descriptor instead.
 func (Gender) EnumDescriptor() ([]byte, []int) {
-	return file_users_proto_rawDescGZIP(), []int{0}
+	return file_users_proto_rawDescGZIP(), []int{1}
 }
 
-type Profile struct {
+// Настройки видимости атрибутов; username и аватар всегда публичны.
+type Privacy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Age           uint32                 `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
-	AvatarUrl     string                 `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
-	AvatarKey     string                 `protobuf:"bytes,5,opt,name=avatar_key,json=avatarKey,proto3" json:"avatar_key,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Country       string                 `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`
-	Gender        Gender                 `protobuf:"varint,9,opt,name=gender,proto3,enum=users.v1.Gender" json:"gender,omitempty"`
+	Age           Visibility             `protobuf:"varint,1,opt,name=age,proto3,enum=users.v1.Visibility" json:"age,omitempty"`
+	Gender        Visibility             `protobuf:"varint,2,opt,name=gender,proto3,enum=users.v1.Visibility" json:"gender,omitempty"`
+	Country       Visibility             `protobuf:"varint,3,opt,name=country,proto3,enum=users.v1.Visibility" json:"country,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Privacy) Reset() {
+	*x = Privacy{}
+	mi := &file_users_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Privacy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Privacy) ProtoMessage() {}
+
+func (x *Privacy) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Privacy.ProtoReflect.Descriptor instead.
+func (*Privacy) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Privacy) GetAge() Visibility {
+	if x != nil {
+		return x.Age
+	}
+	return Visibility_PUBLIC
+}
+
+func (x *Privacy) GetGender() Visibility {
+	if x != nil {
+		return x.Gender
+	}
+	return Visibility_PUBLIC
+}
+
+func (x *Privacy) GetCountry() Visibility {
+	if x != nil {
+		return x.Country
+	}
+	return Visibility_PUBLIC
+}
+
+type Profile struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username       string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Age            uint32                 `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
+	AvatarUrl      string                 `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	AvatarKey      string                 `protobuf:"bytes,5,opt,name=avatar_key,json=avatarKey,proto3" json:"avatar_key,omitempty"`
+	CreatedAt      int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Country        string                 `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`
+	Gender         Gender                 `protobuf:"varint,9,opt,name=gender,proto3,enum=users.v1.Gender" json:"gender,omitempty"`
+	AvatarVariants []*AvatarVariant       `protobuf:"bytes,10,rep,name=avatar_variants,json=avatarVariants,proto3" json:"avatar_variants,omitempty"` // квадратные превью по возрастанию size
+	// Только в полном представлении (владелец/admin) и только если есть непубличные атрибуты;
+	// у чужого профиля скрытые атрибуты отдаются нулевыми, privacy не заполняется.
+	Privacy        *Privacy `protobuf:"bytes,11,opt,name=privacy,proto3" json:"privacy,omitempty"`
+	FollowersCount uint32   `protobuf:"varint,12,opt,name=followers_count,json=followersCount,proto3" json:"followers_count,omitempty"`
+	FollowingCount uint32   `protobuf:"varint,13,opt,name=following_count,json=followingCount,proto3" json:"following_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
 func (x *Profile) Reset() {
 	*x = Profile{}
-	mi := &file_users_proto_msgTypes[0]
+	mi := &file_users_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -103,7 +220,7 @@ func (x *Profile) String() string {
 func (*Profile) ProtoMessage() {}
 
 func (x *Profile) ProtoReflect() protoreflect.Message {
-	mi := &file_users_proto_msgTypes[0]
+	mi := &file_users_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -116,7 +233,7 @@ func (x *Profile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Profile.ProtoReflect.Descriptor instead.
 func (*Profile) Descriptor() ([]byte, []int) {
-	return file_users_proto_rawDescGZIP(), []int{0}
+	return file_users_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Profile) GetUserId() string {
@@ -182,6 +299,95 @@ func (x *Profile) GetGender() Gender {
 	return Gender_GENDER_UNSPECIFIED
 }
 
+func (x *Profile) GetAvatarVariants() []*AvatarVariant {
+	if x != nil {
+		return x.AvatarVariants
+	}
+	return nil
+}
+
+func (x *Profile) GetPrivacy() *Privacy {
+	if x != nil {
+		return x.Privacy
+	}
+	return nil
+}
+
+func (x *Profile) GetFollowersCount() uint32 {
+	if x != nil {
+		return x.FollowersCount
+	}
+	return 0
+}
+
+func (x *Profile) GetFollowingCount() uint32 {
+	if x != nil {
+		return x.FollowingCount
+	}
+	return 0
+}
+
+// Превью аватара стороной size пикселей.
+type AvatarVariant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Size          uint32                 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"` // пусто, если публичный базовый URL не сконфигурирован
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvatarVariant) Reset() {
+	*x = AvatarVariant{}
+	mi := &file_users_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvatarVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvatarVariant) ProtoMessage() {}
+
+func (x *AvatarVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvatarVariant.ProtoReflect.Descriptor instead.
+func (*AvatarVariant) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AvatarVariant) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *AvatarVariant) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *AvatarVariant) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
 type ProfileByIDRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -191,7 +397,7 @@ type ProfileByIDRequest struct {
 
 func (x *ProfileByIDRequest) Reset() {
 	*x = ProfileByIDRequest{}
-	mi := &file_users_proto_msgTypes[1]
+	mi := &file_users_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -203,7 +409,7 @@ func (x *ProfileByIDRequest) String() string {
 func (*ProfileByIDRequest) ProtoMessage() {}
 
 func (x *ProfileByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_proto_msgTypes[1]
+	mi := &file_users_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -216,7 +422,7 @@ func (x *ProfileByIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileByIDRequest.ProtoReflect.Descriptor instead.
 func (*ProfileByIDRequest) Descriptor() ([]byte, []int) {
-	return file_users_proto_rawDescGZIP(), []int{1}
+	return file_users_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ProfileByIDRequest) GetUserId() string {
@@ -226,6 +432,104 @@ func (x *ProfileByIDRequest) GetUserId() string {
 	return ""
 }
 
+type ProfilesByIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfilesByIDsRequest) Reset() {
+	*x = ProfilesByIDsRequest{}
+	mi := &file_users_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfilesByIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfilesByIDsRequest) ProtoMessage() {}
+
+func (x *ProfilesByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfilesByIDsRequest.ProtoReflect.Descriptor instead.
+func (*ProfilesByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProfilesByIDsRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type ProfilesByIDsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ключ — user_id.
+	Profiles map[string]*Profile `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Ненайденные и некорректные user_id в порядке запроса (без дубликатов).
+	MissingIds    []string `protobuf:"bytes,2,rep,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfilesByIDsResponse) Reset() {
+	*x = ProfilesByIDsResponse{}
+	mi := &file_users_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfilesByIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfilesByIDsResponse) ProtoMessage() {}
+
+func (x *ProfilesByIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfilesByIDsResponse.ProtoReflect.Descriptor instead.
+func (*ProfilesByIDsResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProfilesByIDsResponse) GetProfiles() map[string]*Profile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+func (x *ProfilesByIDsResponse) GetMissingIds() []string {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
 type CreateProfileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -239,7 +543,7 @@ type CreateProfileRequest struct {
 
 func (x *CreateProfileRequest) Reset() {
 	*x = CreateProfileRequest{}
-	mi := &file_users_proto_msgTypes[2]
+	mi := &file_users_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -251,7 +555,7 @@ func (x *CreateProfileRequest) String() string {
 func (*CreateProfileRequest) ProtoMessage() {}
 
 func (x *CreateProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_proto_msgTypes[2]
+	mi := &file_users_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -264,7 +568,7 @@ func (x *CreateProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateProfileRequest.ProtoReflect.Descriptor instead.
 func (*CreateProfileRequest) Descriptor() ([]byte, []int) {
-	return file_users_proto_rawDescGZIP(), []int{2}
+	return file_users_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CreateProfileRequest) GetUserId() string {
@@ -309,15 +613,18 @@ type UpdateProfileRequest struct {
 	Age      uint32                 `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
 	Country  string                 `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
 	Gender   Gender                 `protobuf:"varint,5,opt,name=gender,proto3,enum=users.v1.Gender" json:"gender,omitempty"`
-	// Маска с перечислением обновляемых полей: "username,age,country,gender".
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Маска с перечислением обновляемых полей: "username,age,country,gender",
+	// "privacy.age,privacy.gender,privacy.country".
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Без маски применяется целиком (все три значения), с маской — только перечисленные privacy.*.
+	Privacy       *Privacy `protobuf:"bytes,7,opt,name=privacy,proto3" json:"privacy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateProfileRequest) Reset() {
 	*x = UpdateProfileRequest{}
-	mi := &file_users_proto_msgTypes[3]
+	mi := &file_users_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -329,7 +636,7 @@ func (x *UpdateProfileRequest) String() string {
 func (*UpdateProfileRequest) ProtoMessage() {}
 
 func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_proto_msgTypes[3]
+	mi := &file_users_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -342,7 +649,7 @@ func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
 func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
-	return file_users_proto_rawDescGZIP(), []int{3}
+	return file_users_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateProfileRequest) GetUserId() string {
@@ -387,6 +694,13 @@ func (x *UpdateProfileRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
 	return nil
 }
 
+func (x *UpdateProfileRequest) GetPrivacy() *Privacy {
+	if x != nil {
+		return x.Privacy
+	}
+	return nil
+}
+
 type AvatarUploadURLRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -398,7 +712,7 @@ type AvatarUploadURLRequest struct {
 
 func (x *AvatarUploadURLRequest) Reset() {
 	*x = AvatarUploadURLRequest{}
-	mi := &file_users_proto_msgTypes[4]
+	mi := &file_users_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -410,7 +724,7 @@ func (x *AvatarUploadURLRequest) String() string {
 func (*AvatarUploadURLRequest) ProtoMessage() {}
 
 func (x *AvatarUploadURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_proto_msgTypes[4]
+	mi := &file_users_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -423,7 +737,7 @@ func (x *AvatarUploadURLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AvatarUploadURLRequest.ProtoReflect.Descriptor instead.
 func (*AvatarUploadURLRequest) Descriptor() ([]byte, []int) {
-	return file_users_proto_rawDescGZIP(), []int{4}
+	return file_users_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AvatarUploadURLRequest) GetUserId() string {
@@ -459,7 +773,7 @@ type AvatarUploadURLResponse struct {
 
 func (x *AvatarUploadURLResponse) Reset() {
 	*x = AvatarUploadURLResponse{}
-	mi := &file_users_proto_msgTypes[5]
+	mi := &file_users_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -471,7 +785,7 @@ func (x *AvatarUploadURLResponse) String() string {
 func (*AvatarUploadURLResponse) ProtoMessage() {}
 
 func (x *AvatarUploadURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_proto_msgTypes[5]
+	mi := &file_users_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -484,7 +798,7 @@ func (x *AvatarUploadURLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AvatarUploadURLResponse.ProtoReflect.Descriptor instead.
 func (*AvatarUploadURLResponse) Descriptor() ([]byte, []int) {
-	return file_users_proto_rawDescGZIP(), []int{5}
+	return file_users_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AvatarUploadURLResponse) GetUploadUrl() string {
@@ -525,7 +839,7 @@ type ConfirmAvatarUploadRequest struct {
 
 func (x *ConfirmAvatarUploadRequest) Reset() {
 	*x = ConfirmAvatarUploadRequest{}
-	mi := &file_users_proto_msgTypes[6]
+	mi := &file_users_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -537,7 +851,7 @@ func (x *ConfirmAvatarUploadRequest) String() string {
 func (*ConfirmAvatarUploadRequest) ProtoMessage() {}
 
 func (x *ConfirmAvatarUploadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_proto_msgTypes[6]
+	mi := &file_users_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -550,7 +864,7 @@ func (x *ConfirmAvatarUploadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfirmAvatarUploadRequest.ProtoReflect.Descriptor instead.
 func (*ConfirmAvatarUploadRequest) Descriptor() ([]byte, []int) {
-	return file_users_proto_rawDescGZIP(), []int{6}
+	return file_users_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ConfirmAvatarUploadRequest) GetUserId() string {
@@ -567,14 +881,1198 @@ func (x *ConfirmAvatarUploadRequest) GetAvatarKey() string {
 	return ""
 }
 
-var File_users_proto protoreflect.FileDescriptor
+type DeleteAvatarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_users_proto_rawDesc = "" +
-	"\n" +
-	"\vusers.proto\x12\busers.v1\x1a google/protobuf/field_mask.proto\"\x90\x02\n" +
-	"\aProfile\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
-	"\busername\x18\x02 \x01(\tR\busername\x12\x10\n" +
+func (x *DeleteAvatarRequest) Reset() {
+	*x = DeleteAvatarRequest{}
+	mi := &file_users_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAvatarRequest) ProtoMessage() {}
+
+func (x *DeleteAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAvatarRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteAvatarRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ResolveUsernamesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usernames     []string               `protobuf:"bytes,1,rep,name=usernames,proto3" json:"usernames,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveUsernamesRequest) Reset() {
+	*x = ResolveUsernamesRequest{}
+	mi := &file_users_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveUsernamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveUsernamesRequest) ProtoMessage() {}
+
+func (x *ResolveUsernamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveUsernamesRequest.ProtoReflect.Descriptor instead.
+func (*ResolveUsernamesRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResolveUsernamesRequest) GetUsernames() []string {
+	if x != nil {
+		return x.Usernames
+	}
+	return nil
+}
+
+type ResolveUsernamesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ключ — username в нижнем регистре, значение — user_id.
+	// Ненайденные username в ответ не попадают.
+	UserIds       map[string]string `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveUsernamesResponse) Reset() {
+	*x = ResolveUsernamesResponse{}
+	mi := &file_users_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveUsernamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveUsernamesResponse) ProtoMessage() {}
+
+func (x *ResolveUsernamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveUsernamesResponse.ProtoReflect.Descriptor instead.
+func (*ResolveUsernamesResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ResolveUsernamesResponse) GetUserIds() map[string]string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type CheckUsernameAvailabilityRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Необязательный: username, уже принадлежащий этому пользователю, считается свободным.
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckUsernameAvailabilityRequest) Reset() {
+	*x = CheckUsernameAvailabilityRequest{}
+	mi := &file_users_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckUsernameAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckUsernameAvailabilityRequest) ProtoMessage() {}
+
+func (x *CheckUsernameAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckUsernameAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckUsernameAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CheckUsernameAvailabilityRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CheckUsernameAvailabilityRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CheckUsernameAvailabilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Available     bool                   `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"` // после нормализации
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`     // "" | "invalid" | "reserved" | "taken"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckUsernameAvailabilityResponse) Reset() {
+	*x = CheckUsernameAvailabilityResponse{}
+	mi := &file_users_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckUsernameAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckUsernameAvailabilityResponse) ProtoMessage() {}
+
+func (x *CheckUsernameAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckUsernameAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*CheckUsernameAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CheckUsernameAvailabilityResponse) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *CheckUsernameAvailabilityResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CheckUsernameAvailabilityResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SearchProfilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`  // префикс username, ведущий '@' допускается
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // 0 -> 10, максимум 50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProfilesRequest) Reset() {
+	*x = SearchProfilesRequest{}
+	mi := &file_users_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProfilesRequest) ProtoMessage() {}
+
+func (x *SearchProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProfilesRequest.ProtoReflect.Descriptor instead.
+func (*SearchProfilesRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchProfilesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchProfilesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchProfilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profiles      []*Profile             `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProfilesResponse) Reset() {
+	*x = SearchProfilesResponse{}
+	mi := &file_users_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProfilesResponse) ProtoMessage() {}
+
+func (x *SearchProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProfilesResponse.ProtoReflect.Descriptor instead.
+func (*SearchProfilesResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SearchProfilesResponse) GetProfiles() []*Profile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+type FollowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerId    string                 `protobuf:"bytes,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`
+	FolloweeId    string                 `protobuf:"bytes,2,opt,name=followee_id,json=followeeId,proto3" json:"followee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowRequest) Reset() {
+	*x = FollowRequest{}
+	mi := &file_users_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowRequest) ProtoMessage() {}
+
+func (x *FollowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowRequest.ProtoReflect.Descriptor instead.
+func (*FollowRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FollowRequest) GetFollowerId() string {
+	if x != nil {
+		return x.FollowerId
+	}
+	return ""
+}
+
+func (x *FollowRequest) GetFolloweeId() string {
+	if x != nil {
+		return x.FolloweeId
+	}
+	return ""
+}
+
+type FollowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changed       bool                   `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"` // false — подписка уже была (Follow) или отсутствовала (Unfollow)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowResponse) Reset() {
+	*x = FollowResponse{}
+	mi := &file_users_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowResponse) ProtoMessage() {}
+
+func (x *FollowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowResponse.ProtoReflect.Descriptor instead.
+func (*FollowResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FollowResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+type ListFollowsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 0 -> 20, максимум 100
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowsRequest) Reset() {
+	*x = ListFollowsRequest{}
+	mi := &file_users_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowsRequest) ProtoMessage() {}
+
+func (x *ListFollowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowsRequest.ProtoReflect.Descriptor instead.
+func (*ListFollowsRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListFollowsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFollowsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFollowsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type FollowEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *Profile               `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	FollowedAt    int64                  `protobuf:"varint,2,opt,name=followed_at,json=followedAt,proto3" json:"followed_at,omitempty"` // Unix UTC
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowEntry) Reset() {
+	*x = FollowEntry{}
+	mi := &file_users_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowEntry) ProtoMessage() {}
+
+func (x *FollowEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowEntry.ProtoReflect.Descriptor instead.
+func (*FollowEntry) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *FollowEntry) GetProfile() *Profile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *FollowEntry) GetFollowedAt() int64 {
+	if x != nil {
+		return x.FollowedAt
+	}
+	return 0
+}
+
+type ListFollowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*FollowEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowsResponse) Reset() {
+	*x = ListFollowsResponse{}
+	mi := &file_users_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowsResponse) ProtoMessage() {}
+
+func (x *ListFollowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowsResponse.ProtoReflect.Descriptor instead.
+func (*ListFollowsResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListFollowsResponse) GetEntries() []*FollowEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListFollowsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Предпочтения читателя; значения нормализованы (категории — в нижнем регистре,
+// источники — хосты без "www.", языки — "en", "ru", ...). Заглушение сильнее подписки.
+type Preferences struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	UserId             string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FollowedCategories []string               `protobuf:"bytes,2,rep,name=followed_categories,json=followedCategories,proto3" json:"followed_categories,omitempty"`
+	MutedCategories    []string               `protobuf:"bytes,3,rep,name=muted_categories,json=mutedCategories,proto3" json:"muted_categories,omitempty"`
+	MutedSources       []string               `protobuf:"bytes,4,rep,name=muted_sources,json=mutedSources,proto3" json:"muted_sources,omitempty"`
+	Languages          []string               `protobuf:"bytes,5,rep,name=languages,proto3" json:"languages,omitempty"`                   // пусто — без фильтра по языку
+	UpdatedAt          int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // Unix UTC; 0 — ещё не задавались
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Preferences) Reset() {
+	*x = Preferences{}
+	mi := &file_users_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Preferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Preferences) ProtoMessage() {}
+
+func (x *Preferences) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Preferences.ProtoReflect.Descriptor instead.
+func (*Preferences) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Preferences) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Preferences) GetFollowedCategories() []string {
+	if x != nil {
+		return x.FollowedCategories
+	}
+	return nil
+}
+
+func (x *Preferences) GetMutedCategories() []string {
+	if x != nil {
+		return x.MutedCategories
+	}
+	return nil
+}
+
+func (x *Preferences) GetMutedSources() []string {
+	if x != nil {
+		return x.MutedSources
+	}
+	return nil
+}
+
+func (x *Preferences) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *Preferences) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type GetPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreferencesRequest) Reset() {
+	*x = GetPreferencesRequest{}
+	mi := &file_users_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreferencesRequest) ProtoMessage() {}
+
+func (x *GetPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetPreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UpdatePreferencesRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UserId      string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Preferences *Preferences           `protobuf:"bytes,2,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	// Пути: "followed_categories", "muted_categories", "muted_sources", "languages".
+	// Без маски заменяются все четыре списка.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePreferencesRequest) Reset() {
+	*x = UpdatePreferencesRequest{}
+	mi := &file_users_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePreferencesRequest) ProtoMessage() {}
+
+func (x *UpdatePreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdatePreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdatePreferencesRequest) GetPreferences() *Preferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+func (x *UpdatePreferencesRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type BookmarkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	NewsId        string                 `protobuf:"bytes,2,opt,name=news_id,json=newsId,proto3" json:"news_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookmarkRequest) Reset() {
+	*x = BookmarkRequest{}
+	mi := &file_users_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookmarkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookmarkRequest) ProtoMessage() {}
+
+func (x *BookmarkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookmarkRequest.ProtoReflect.Descriptor instead.
+func (*BookmarkRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BookmarkRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BookmarkRequest) GetNewsId() string {
+	if x != nil {
+		return x.NewsId
+	}
+	return ""
+}
+
+type BookmarkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changed       bool                   `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"` // false — закладка уже была (Add) или отсутствовала (Remove)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookmarkResponse) Reset() {
+	*x = BookmarkResponse{}
+	mi := &file_users_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookmarkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookmarkResponse) ProtoMessage() {}
+
+func (x *BookmarkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookmarkResponse.ProtoReflect.Descriptor instead.
+func (*BookmarkResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BookmarkResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+type ListBookmarksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 0 -> 20, максимум 100
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBookmarksRequest) Reset() {
+	*x = ListBookmarksRequest{}
+	mi := &file_users_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBookmarksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBookmarksRequest) ProtoMessage() {}
+
+func (x *ListBookmarksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBookmarksRequest.ProtoReflect.Descriptor instead.
+func (*ListBookmarksRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListBookmarksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListBookmarksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBookmarksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Снимок новости из news-service на момент выдачи списка.
+type BookmarkedNews struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Category         string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	ShortDescription string                 `protobuf:"bytes,4,opt,name=short_description,json=shortDescription,proto3" json:"short_description,omitempty"`
+	Link             string                 `protobuf:"bytes,5,opt,name=link,proto3" json:"link,omitempty"`
+	ImageUrl         string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	PublishedAt      int64                  `protobuf:"varint,7,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"` // Unix UTC
+	Source           string                 `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"`
+	Language         string                 `protobuf:"bytes,9,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BookmarkedNews) Reset() {
+	*x = BookmarkedNews{}
+	mi := &file_users_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookmarkedNews) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookmarkedNews) ProtoMessage() {}
+
+func (x *BookmarkedNews) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookmarkedNews.ProtoReflect.Descriptor instead.
+func (*BookmarkedNews) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BookmarkedNews) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BookmarkedNews) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *BookmarkedNews) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *BookmarkedNews) GetShortDescription() string {
+	if x != nil {
+		return x.ShortDescription
+	}
+	return ""
+}
+
+func (x *BookmarkedNews) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *BookmarkedNews) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *BookmarkedNews) GetPublishedAt() int64 {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return 0
+}
+
+func (x *BookmarkedNews) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *BookmarkedNews) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type Bookmark struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewsId        string                 `protobuf:"bytes,1,opt,name=news_id,json=newsId,proto3" json:"news_id,omitempty"`
+	SavedAt       int64                  `protobuf:"varint,2,opt,name=saved_at,json=savedAt,proto3" json:"saved_at,omitempty"`             // Unix UTC
+	News          *BookmarkedNews        `protobuf:"bytes,3,opt,name=news,proto3" json:"news,omitempty"`                                   // нет — новость удалена или news-service недоступен
+	NewsMissing   bool                   `protobuf:"varint,4,opt,name=news_missing,json=newsMissing,proto3" json:"news_missing,omitempty"` // true — новости больше нет в news-service
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bookmark) Reset() {
+	*x = Bookmark{}
+	mi := &file_users_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bookmark) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bookmark) ProtoMessage() {}
+
+func (x *Bookmark) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bookmark.ProtoReflect.Descriptor instead.
+func (*Bookmark) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Bookmark) GetNewsId() string {
+	if x != nil {
+		return x.NewsId
+	}
+	return ""
+}
+
+func (x *Bookmark) GetSavedAt() int64 {
+	if x != nil {
+		return x.SavedAt
+	}
+	return 0
+}
+
+func (x *Bookmark) GetNews() *BookmarkedNews {
+	if x != nil {
+		return x.News
+	}
+	return nil
+}
+
+func (x *Bookmark) GetNewsMissing() bool {
+	if x != nil {
+		return x.NewsMissing
+	}
+	return false
+}
+
+type ListBookmarksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bookmarks     []*Bookmark            `protobuf:"bytes,1,rep,name=bookmarks,proto3" json:"bookmarks,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBookmarksResponse) Reset() {
+	*x = ListBookmarksResponse{}
+	mi := &file_users_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBookmarksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBookmarksResponse) ProtoMessage() {}
+
+func (x *ListBookmarksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBookmarksResponse.ProtoReflect.Descriptor instead.
+func (*ListBookmarksResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListBookmarksResponse) GetBookmarks() []*Bookmark {
+	if x != nil {
+		return x.Bookmarks
+	}
+	return nil
+}
+
+func (x *ListBookmarksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_users_proto protoreflect.FileDescriptor
+
+const file_users_proto_rawDesc = "" +
+	"\n" +
+	"\vusers.proto\x12\busers.v1\x1a google/protobuf/field_mask.proto\"\x8f\x01\n" +
+	"\aPrivacy\x12&\n" +
+	"\x03age\x18\x01 \x01(\x0e2\x14.users.v1.VisibilityR\x03age\x12,\n" +
+	"\x06gender\x18\x02 \x01(\x0e2\x14.users.v1.VisibilityR\x06gender\x12.\n" +
+	"\acountry\x18\x03 \x01(\x0e2\x14.users.v1.VisibilityR\acountry\"\xd1\x03\n" +
+	"\aProfile\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x10\n" +
 	"\x03age\x18\x03 \x01(\rR\x03age\x12\x1d\n" +
 	"\n" +
 	"avatar_url\x18\x04 \x01(\tR\tavatarUrl\x12\x1d\n" +
@@ -585,15 +2083,33 @@ const file_users_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\a \x01(\x03R\tupdatedAt\x12\x18\n" +
 	"\acountry\x18\b \x01(\tR\acountry\x12(\n" +
-	"\x06gender\x18\t \x01(\x0e2\x10.users.v1.GenderR\x06gender\"-\n" +
+	"\x06gender\x18\t \x01(\x0e2\x10.users.v1.GenderR\x06gender\x12@\n" +
+	"\x0favatar_variants\x18\n" +
+	" \x03(\v2\x17.users.v1.AvatarVariantR\x0eavatarVariants\x12+\n" +
+	"\aprivacy\x18\v \x01(\v2\x11.users.v1.PrivacyR\aprivacy\x12'\n" +
+	"\x0ffollowers_count\x18\f \x01(\rR\x0efollowersCount\x12'\n" +
+	"\x0ffollowing_count\x18\r \x01(\rR\x0efollowingCount\"G\n" +
+	"\rAvatarVariant\x12\x12\n" +
+	"\x04size\x18\x01 \x01(\rR\x04size\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\"-\n" +
 	"\x12ProfileByIDRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xa1\x01\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"1\n" +
+	"\x14ProfilesByIDsRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"\xd3\x01\n" +
+	"\x15ProfilesByIDsResponse\x12I\n" +
+	"\bprofiles\x18\x01 \x03(\v2-.users.v1.ProfilesByIDsResponse.ProfilesEntryR\bprofiles\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\tR\n" +
+	"missingIds\x1aN\n" +
+	"\rProfilesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12'\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.users.v1.ProfileR\x05value:\x028\x01\"\xa1\x01\n" +
 	"\x14CreateProfileRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x10\n" +
 	"\x03age\x18\x03 \x01(\rR\x03age\x12\x18\n" +
 	"\acountry\x18\x04 \x01(\tR\acountry\x12(\n" +
-	"\x06gender\x18\x05 \x01(\x0e2\x10.users.v1.GenderR\x06gender\"\xde\x01\n" +
+	"\x06gender\x18\x05 \x01(\x0e2\x10.users.v1.GenderR\x06gender\"\x8b\x02\n" +
 	"\x14UpdateProfileRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x10\n" +
@@ -601,7 +2117,8 @@ const file_users_proto_rawDesc = "" +
 	"\acountry\x18\x04 \x01(\tR\acountry\x12(\n" +
 	"\x06gender\x18\x05 \x01(\x0e2\x10.users.v1.GenderR\x06gender\x12;\n" +
 	"\vupdate_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
-	"updateMask\"{\n" +
+	"updateMask\x12+\n" +
+	"\aprivacy\x18\a \x01(\v2\x11.users.v1.PrivacyR\aprivacy\"{\n" +
 	"\x16AvatarUploadURLRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
 	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12%\n" +
@@ -619,19 +2136,123 @@ const file_users_proto_rawDesc = "" +
 	"\x1aConfirmAvatarUploadRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
 	"\n" +
-	"avatar_key\x18\x02 \x01(\tR\tavatarKey*A\n" +
+	"avatar_key\x18\x02 \x01(\tR\tavatarKey\".\n" +
+	"\x13DeleteAvatarRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"7\n" +
+	"\x17ResolveUsernamesRequest\x12\x1c\n" +
+	"\tusernames\x18\x01 \x03(\tR\tusernames\"\xa2\x01\n" +
+	"\x18ResolveUsernamesResponse\x12J\n" +
+	"\buser_ids\x18\x01 \x03(\v2/.users.v1.ResolveUsernamesResponse.UserIdsEntryR\auserIds\x1a:\n" +
+	"\fUserIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"W\n" +
+	" CheckUsernameAvailabilityRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"u\n" +
+	"!CheckUsernameAvailabilityResponse\x12\x1c\n" +
+	"\tavailable\x18\x01 \x01(\bR\tavailable\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"C\n" +
+	"\x15SearchProfilesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"G\n" +
+	"\x16SearchProfilesResponse\x12-\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x11.users.v1.ProfileR\bprofiles\"Q\n" +
+	"\rFollowRequest\x12\x1f\n" +
+	"\vfollower_id\x18\x01 \x01(\tR\n" +
+	"followerId\x12\x1f\n" +
+	"\vfollowee_id\x18\x02 \x01(\tR\n" +
+	"followeeId\"*\n" +
+	"\x0eFollowResponse\x12\x18\n" +
+	"\achanged\x18\x01 \x01(\bR\achanged\"i\n" +
+	"\x12ListFollowsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"[\n" +
+	"\vFollowEntry\x12+\n" +
+	"\aprofile\x18\x01 \x01(\v2\x11.users.v1.ProfileR\aprofile\x12\x1f\n" +
+	"\vfollowed_at\x18\x02 \x01(\x03R\n" +
+	"followedAt\"n\n" +
+	"\x13ListFollowsResponse\x12/\n" +
+	"\aentries\x18\x01 \x03(\v2\x15.users.v1.FollowEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe4\x01\n" +
+	"\vPreferences\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12/\n" +
+	"\x13followed_categories\x18\x02 \x03(\tR\x12followedCategories\x12)\n" +
+	"\x10muted_categories\x18\x03 \x03(\tR\x0fmutedCategories\x12#\n" +
+	"\rmuted_sources\x18\x04 \x03(\tR\fmutedSources\x12\x1c\n" +
+	"\tlanguages\x18\x05 \x03(\tR\tlanguages\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\"0\n" +
+	"\x15GetPreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xa9\x01\n" +
+	"\x18UpdatePreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x127\n" +
+	"\vpreferences\x18\x02 \x01(\v2\x15.users.v1.PreferencesR\vpreferences\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"C\n" +
+	"\x0fBookmarkRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\anews_id\x18\x02 \x01(\tR\x06newsId\",\n" +
+	"\x10BookmarkResponse\x12\x18\n" +
+	"\achanged\x18\x01 \x01(\bR\achanged\"k\n" +
+	"\x14ListBookmarksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x87\x02\n" +
+	"\x0eBookmarkedNews\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12+\n" +
+	"\x11short_description\x18\x04 \x01(\tR\x10shortDescription\x12\x12\n" +
+	"\x04link\x18\x05 \x01(\tR\x04link\x12\x1b\n" +
+	"\timage_url\x18\x06 \x01(\tR\bimageUrl\x12!\n" +
+	"\fpublished_at\x18\a \x01(\x03R\vpublishedAt\x12\x16\n" +
+	"\x06source\x18\b \x01(\tR\x06source\x12\x1a\n" +
+	"\blanguage\x18\t \x01(\tR\blanguage\"\x8f\x01\n" +
+	"\bBookmark\x12\x17\n" +
+	"\anews_id\x18\x01 \x01(\tR\x06newsId\x12\x19\n" +
+	"\bsaved_at\x18\x02 \x01(\x03R\asavedAt\x12,\n" +
+	"\x04news\x18\x03 \x01(\v2\x18.users.v1.BookmarkedNewsR\x04news\x12!\n" +
+	"\fnews_missing\x18\x04 \x01(\bR\vnewsMissing\"q\n" +
+	"\x15ListBookmarksResponse\x120\n" +
+	"\tbookmarks\x18\x01 \x03(\v2\x12.users.v1.BookmarkR\tbookmarks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*5\n" +
+	"\n" +
+	"Visibility\x12\n" +
+	"\n" +
+	"\x06PUBLIC\x10\x00\x12\x0e\n" +
+	"\n" +
+	"REGISTERED\x10\x01\x12\v\n" +
+	"\aPRIVATE\x10\x02*A\n" +
 	"\x06Gender\x12\x16\n" +
 	"\x12GENDER_UNSPECIFIED\x10\x00\x12\b\n" +
 	"\x04MALE\x10\x01\x12\n" +
 	"\n" +
 	"\x06FEMALE\x10\x02\x12\t\n" +
-	"\x05OTHER\x10\x032\xfe\x02\n" +
+	"\x05OTHER\x10\x032\xcb\v\n" +
 	"\fUsersService\x12>\n" +
-	"\vProfileByID\x12\x1c.users.v1.ProfileByIDRequest\x1a\x11.users.v1.Profile\x12B\n" +
+	"\vProfileByID\x12\x1c.users.v1.ProfileByIDRequest\x1a\x11.users.v1.Profile\x12P\n" +
+	"\rProfilesByIDs\x12\x1e.users.v1.ProfilesByIDsRequest\x1a\x1f.users.v1.ProfilesByIDsResponse\x12B\n" +
 	"\rCreateProfile\x12\x1e.users.v1.CreateProfileRequest\x1a\x11.users.v1.Profile\x12B\n" +
 	"\rUpdateProfile\x12\x1e.users.v1.UpdateProfileRequest\x1a\x11.users.v1.Profile\x12V\n" +
 	"\x0fAvatarUploadURL\x12 .users.v1.AvatarUploadURLRequest\x1a!.users.v1.AvatarUploadURLResponse\x12N\n" +
-	"\x13ConfirmAvatarUpload\x12$.users.v1.ConfirmAvatarUploadRequest\x1a\x11.users.v1.ProfileBAZ?github.com/pribylovaa/go-news-aggregator/proto/users/v1;usersv1b\x06proto3"
+	"\x13ConfirmAvatarUpload\x12$.users.v1.ConfirmAvatarUploadRequest\x1a\x11.users.v1.Profile\x12@\n" +
+	"\fDeleteAvatar\x12\x1d.users.v1.DeleteAvatarRequest\x1a\x11.users.v1.Profile\x12Y\n" +
+	"\x10ResolveUsernames\x12!.users.v1.ResolveUsernamesRequest\x1a\".users.v1.ResolveUsernamesResponse\x12t\n" +
+	"\x19CheckUsernameAvailability\x12*.users.v1.CheckUsernameAvailabilityRequest\x1a+.users.v1.CheckUsernameAvailabilityResponse\x12S\n" +
+	"\x0eSearchProfiles\x12\x1f.users.v1.SearchProfilesRequest\x1a .users.v1.SearchProfilesResponse\x12;\n" +
+	"\x06Follow\x12\x17.users.v1.FollowRequest\x1a\x18.users.v1.FollowResponse\x12=\n" +
+	"\bUnfollow\x12\x17.users.v1.FollowRequest\x1a\x18.users.v1.FollowResponse\x12L\n" +
+	"\rListFollowers\x12\x1c.users.v1.ListFollowsRequest\x1a\x1d.users.v1.ListFollowsResponse\x12L\n" +
+	"\rListFollowing\x12\x1c.users.v1.ListFollowsRequest\x1a\x1d.users.v1.ListFollowsResponse\x12H\n" +
+	"\x0eGetPreferences\x12\x1f.users.v1.GetPreferencesRequest\x1a\x15.users.v1.Preferences\x12N\n" +
+	"\x11UpdatePreferences\x12\".users.v1.UpdatePreferencesRequest\x1a\x15.users.v1.Preferences\x12D\n" +
+	"\vAddBookmark\x12\x19.users.v1.BookmarkRequest\x1a\x1a.users.v1.BookmarkResponse\x12G\n" +
+	"\x0eRemoveBookmark\x12\x19.users.v1.BookmarkRequest\x1a\x1a.users.v1.BookmarkResponse\x12P\n" +
+	"\rListBookmarks\x12\x1e.users.v1.ListBookmarksRequest\x1a\x1f.users.v1.ListBookmarksResponseBAZ?github.com/pribylovaa/go-news-aggregator/proto/users/v1;usersv1b\x06proto3"
 
 var (
 	file_users_proto_rawDescOnce sync.Once
@@ -645,41 +2266,113 @@ func file_users_proto_rawDescGZIP() []byte {
 	return file_users_proto_rawDescData
 }
 
-var file_users_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_users_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_users_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_users_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_users_proto_goTypes = []any{
-	(Gender)(0),                        // 0: users.v1.Gender
-	(*Profile)(nil),                    // 1: users.v1.Profile
-	(*ProfileByIDRequest)(nil),         // 2: users.v1.ProfileByIDRequest
-	(*CreateProfileRequest)(nil),       // 3: users.v1.CreateProfileRequest
-	(*UpdateProfileRequest)(nil),       // 4: users.v1.UpdateProfileRequest
-	(*AvatarUploadURLRequest)(nil),     // 5: users.v1.AvatarUploadURLRequest
-	(*AvatarUploadURLResponse)(nil),    // 6: users.v1.AvatarUploadURLResponse
-	(*ConfirmAvatarUploadRequest)(nil), // 7: users.v1.ConfirmAvatarUploadRequest
-	nil,                                // 8: users.v1.AvatarUploadURLResponse.RequiredHeadersEntry
-	(*fieldmaskpb.FieldMask)(nil),      // 9: google.protobuf.FieldMask
+	(Visibility)(0),                           // 0: users.v1.Visibility
+	(Gender)(0),                               // 1: users.v1.Gender
+	(*Privacy)(nil),                           // 2: users.v1.Privacy
+	(*Profile)(nil),                           // 3: users.v1.Profile
+	(*AvatarVariant)(nil),                     // 4: users.v1.AvatarVariant
+	(*ProfileByIDRequest)(nil),                // 5: users.v1.ProfileByIDRequest
+	(*ProfilesByIDsRequest)(nil),              // 6: users.v1.ProfilesByIDsRequest
+	(*ProfilesByIDsResponse)(nil),             // 7: users.v1.ProfilesByIDsResponse
+	(*CreateProfileRequest)(nil),              // 8: users.v1.CreateProfileRequest
+	(*UpdateProfileRequest)(nil),              // 9: users.v1.UpdateProfileRequest
+	(*AvatarUploadURLRequest)(nil),            // 10: users.v1.AvatarUploadURLRequest
+	(*AvatarUploadURLResponse)(nil),           // 11: users.v1.AvatarUploadURLResponse
+	(*ConfirmAvatarUploadRequest)(nil),        // 12: users.v1.ConfirmAvatarUploadRequest
+	(*DeleteAvatarRequest)(nil),               // 13: users.v1.DeleteAvatarRequest
+	(*ResolveUsernamesRequest)(nil),           // 14: users.v1.ResolveUsernamesRequest
+	(*ResolveUsernamesResponse)(nil),          // 15: users.v1.ResolveUsernamesResponse
+	(*CheckUsernameAvailabilityRequest)(nil),  // 16: users.v1.CheckUsernameAvailabilityRequest
+	(*CheckUsernameAvailabilityResponse)(nil), // 17: users.v1.CheckUsernameAvailabilityResponse
+	(*SearchProfilesRequest)(nil),             // 18: users.v1.SearchProfilesRequest
+	(*SearchProfilesResponse)(nil),            // 19: users.v1.SearchProfilesResponse
+	(*FollowRequest)(nil),                     // 20: users.v1.FollowRequest
+	(*FollowResponse)(nil),                    // 21: users.v1.FollowResponse
+	(*ListFollowsRequest)(nil),                // 22: users.v1.ListFollowsRequest
+	(*FollowEntry)(nil),                       // 23: users.v1.FollowEntry
+	(*ListFollowsResponse)(nil),               // 24: users.v1.ListFollowsResponse
+	(*Preferences)(nil),                       // 25: users.v1.Preferences
+	(*GetPreferencesRequest)(nil),             // 26: users.v1.GetPreferencesRequest
+	(*UpdatePreferencesRequest)(nil),          // 27: users.v1.UpdatePreferencesRequest
+	(*BookmarkRequest)(nil),                   // 28: users.v1.BookmarkRequest
+	(*BookmarkResponse)(nil),                  // 29: users.v1.BookmarkResponse
+	(*ListBookmarksRequest)(nil),              // 30: users.v1.ListBookmarksRequest
+	(*BookmarkedNews)(nil),                    // 31: users.v1.BookmarkedNews
+	(*Bookmark)(nil),                          // 32: users.v1.Bookmark
+	(*ListBookmarksResponse)(nil),             // 33: users.v1.ListBookmarksResponse
+	nil,                                       // 34: users.v1.ProfilesByIDsResponse.ProfilesEntry
+	nil,                                       // 35: users.v1.AvatarUploadURLResponse.RequiredHeadersEntry
+	nil,                                       // 36: users.v1.ResolveUsernamesResponse.UserIdsEntry
+	(*fieldmaskpb.FieldMask)(nil),             // 37: google.protobuf.FieldMask
 }
 var file_users_proto_depIdxs = []int32{
-	0,  // 0: users.v1.Profile.gender:type_name -> users.v1.Gender
-	0,  // 1: users.v1.CreateProfileRequest.gender:type_name -> users.v1.Gender
-	0,  // 2: users.v1.UpdateProfileRequest.gender:type_name -> users.v1.Gender
-	9,  // 3: users.v1.UpdateProfileRequest.update_mask:type_name -> google.protobuf.FieldMask
-	8,  // 4: users.v1.AvatarUploadURLResponse.required_headers:type_name -> users.v1.AvatarUploadURLResponse.RequiredHeadersEntry
-	2,  // 5: users.v1.UsersService.ProfileByID:input_type -> users.v1.ProfileByIDRequest
-	3,  // 6: users.v1.UsersService.CreateProfile:input_type -> users.v1.CreateProfileRequest
-	4,  // 7: users.v1.UsersService.UpdateProfile:input_type -> users.v1.UpdateProfileRequest
-	5,  // 8: users.v1.UsersService.AvatarUploadURL:input_type -> users.v1.AvatarUploadURLRequest
-	7,  // 9: users.v1.UsersService.ConfirmAvatarUpload:input_type -> users.v1.ConfirmAvatarUploadRequest
-	1,  // 10: users.v1.UsersService.ProfileByID:output_type -> users.v1.Profile
-	1,  // 11: users.v1.UsersService.CreateProfile:output_type -> users.v1.Profile
-	1,  // 12: users.v1.UsersService.UpdateProfile:output_type -> users.v1.Profile
-	6,  // 13: users.v1.UsersService.AvatarUploadURL:output_type -> users.v1.AvatarUploadURLResponse
-	1,  // 14: users.v1.UsersService.ConfirmAvatarUpload:output_type -> users.v1.Profile
-	10, // [10:15] is the sub-list for method output_type
-	5,  // [5:10] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	0,  // 0: users.v1.Privacy.age:type_name -> users.v1.Visibility
+	0,  // 1: users.v1.Privacy.gender:type_name -> users.v1.Visibility
+	0,  // 2: users.v1.Privacy.country:type_name -> users.v1.Visibility
+	1,  // 3: users.v1.Profile.gender:type_name -> users.v1.Gender
+	4,  // 4: users.v1.Profile.avatar_variants:type_name -> users.v1.AvatarVariant
+	2,  // 5: users.v1.Profile.privacy:type_name -> users.v1.Privacy
+	34, // 6: users.v1.ProfilesByIDsResponse.profiles:type_name -> users.v1.ProfilesByIDsResponse.ProfilesEntry
+	1,  // 7: users.v1.CreateProfileRequest.gender:type_name -> users.v1.Gender
+	1,  // 8: users.v1.UpdateProfileRequest.gender:type_name -> users.v1.Gender
+	37, // 9: users.v1.UpdateProfileRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 10: users.v1.UpdateProfileRequest.privacy:type_name -> users.v1.Privacy
+	35, // 11: users.v1.AvatarUploadURLResponse.required_headers:type_name -> users.v1.AvatarUploadURLResponse.RequiredHeadersEntry
+	36, // 12: users.v1.ResolveUsernamesResponse.user_ids:type_name -> users.v1.ResolveUsernamesResponse.UserIdsEntry
+	3,  // 13: users.v1.SearchProfilesResponse.profiles:type_name -> users.v1.Profile
+	3,  // 14: users.v1.FollowEntry.profile:type_name -> users.v1.Profile
+	23, // 15: users.v1.ListFollowsResponse.entries:type_name -> users.v1.FollowEntry
+	25, // 16: users.v1.UpdatePreferencesRequest.preferences:type_name -> users.v1.Preferences
+	37, // 17: users.v1.UpdatePreferencesRequest.update_mask:type_name -> google.protobuf.FieldMask
+	31, // 18: users.v1.Bookmark.news:type_name -> users.v1.BookmarkedNews
+	32, // 19: users.v1.ListBookmarksResponse.bookmarks:type_name -> users.v1.Bookmark
+	3,  // 20: users.v1.ProfilesByIDsResponse.ProfilesEntry.value:type_name -> users.v1.Profile
+	5,  // 21: users.v1.UsersService.ProfileByID:input_type -> users.v1.ProfileByIDRequest
+	6,  // 22: users.v1.UsersService.ProfilesByIDs:input_type -> users.v1.ProfilesByIDsRequest
+	8,  // 23: users.v1.UsersService.CreateProfile:input_type -> users.v1.CreateProfileRequest
+	9,  // 24: users.v1.UsersService.UpdateProfile:input_type -> users.v1.UpdateProfileRequest
+	10, // 25: users.v1.UsersService.AvatarUploadURL:input_type -> users.v1.AvatarUploadURLRequest
+	12, // 26: users.v1.UsersService.ConfirmAvatarUpload:input_type -> users.v1.ConfirmAvatarUploadRequest
+	13, // 27: users.v1.UsersService.DeleteAvatar:input_type -> users.v1.DeleteAvatarRequest
+	14, // 28: users.v1.UsersService.ResolveUsernames:input_type -> users.v1.ResolveUsernamesRequest
+	16, // 29: users.v1.UsersService.CheckUsernameAvailability:input_type -> users.v1.CheckUsernameAvailabilityRequest
+	18, // 30: users.v1.UsersService.SearchProfiles:input_type -> users.v1.SearchProfilesRequest
+	20, // 31: users.v1.UsersService.Follow:input_type -> users.v1.FollowRequest
+	20, // 32: users.v1.UsersService.Unfollow:input_type -> users.v1.FollowRequest
+	22, // 33: users.v1.UsersService.ListFollowers:input_type -> users.v1.ListFollowsRequest
+	22, // 34: users.v1.UsersService.ListFollowing:input_type -> users.v1.ListFollowsRequest
+	26, // 35: users.v1.UsersService.GetPreferences:input_type -> users.v1.GetPreferencesRequest
+	27, // 36: users.v1.UsersService.UpdatePreferences:input_type -> users.v1.UpdatePreferencesRequest
+	28, // 37: users.v1.UsersService.AddBookmark:input_type -> users.v1.BookmarkRequest
+	28, // 38: users.v1.UsersService.RemoveBookmark:input_type -> users.v1.BookmarkRequest
+	30, // 39: users.v1.UsersService.ListBookmarks:input_type -> users.v1.ListBookmarksRequest
+	3,  // 40: users.v1.UsersService.ProfileByID:output_type -> users.v1.Profile
+	7,  // 41: users.v1.UsersService.ProfilesByIDs:output_type -> users.v1.ProfilesByIDsResponse
+	3,  // 42: users.v1.UsersService.CreateProfile:output_type -> users.v1.Profile
+	3,  // 43: users.v1.UsersService.UpdateProfile:output_type -> users.v1.Profile
+	11, // 44: users.v1.UsersService.AvatarUploadURL:output_type -> users.v1.AvatarUploadURLResponse
+	3,  // 45: users.v1.UsersService.ConfirmAvatarUpload:output_type -> users.v1.Profile
+	3,  // 46: users.v1.UsersService.DeleteAvatar:output_type -> users.v1.Profile
+	15, // 47: users.v1.UsersService.ResolveUsernames:output_type -> users.v1.ResolveUsernamesResponse
+	17, // 48: users.v1.UsersService.CheckUsernameAvailability:output_type -> users.v1.CheckUsernameAvailabilityResponse
+	19, // 49: users.v1.UsersService.SearchProfiles:output_type -> users.v1.SearchProfilesResponse
+	21, // 50: users.v1.UsersService.Follow:output_type -> users.v1.FollowResponse
+	21, // 51: users.v1.UsersService.Unfollow:output_type -> users.v1.FollowResponse
+	24, // 52: users.v1.UsersService.ListFollowers:output_type -> users.v1.ListFollowsResponse
+	24, // 53: users.v1.UsersService.ListFollowing:output_type -> users.v1.ListFollowsResponse
+	25, // 54: users.v1.UsersService.GetPreferences:output_type -> users.v1.Preferences
+	25, // 55: users.v1.UsersService.UpdatePreferences:output_type -> users.v1.Preferences
+	29, // 56: users.v1.UsersService.AddBookmark:output_type -> users.v1.BookmarkResponse
+	29, // 57: users.v1.UsersService.RemoveBookmark:output_type -> users.v1.BookmarkResponse
+	33, // 58: users.v1.UsersService.ListBookmarks:output_type -> users.v1.ListBookmarksResponse
+	40, // [40:59] is the sub-list for method output_type
+	21, // [21:40] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_users_proto_init() }
@@ -692,8 +2385,8 @@ func file_users_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_users_proto_rawDesc), len(file_users_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   8,
+			NumEnums:      2,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/pribylovaa/go-news-aggregator/pkg/tlsconfig"
 )
 
 // Config — корневая конфигурация сервиса.
@@ -20,13 +21,25 @@ type Config struct {
 	Postgres PostgresConfig `yaml:"postgres"`
 	S3       S3Config       `yaml:"s3"`
 	Avatar   AvatarConfig   `yaml:"avatar"`
+	Reaper   ReaperConfig   `yaml:"avatar_reaper"`
+	Username UsernameConfig `yaml:"username"`
+	Limits   LimitsConfig   `yaml:"limits"`
 	Timeouts TimeoutConfig  `yaml:"timeouts"`
+	News     NewsConfig     `yaml:"news"`
+}
+
+// NewsConfig — подключение к news-service для гидрации закладок.
+// Пустой Addr — закладки отдаются без новостей и не проверяются при сохранении.
+type NewsConfig struct {
+	Addr string `yaml:"addr" env:"NEWS_ADDR"`
 }
 
 // GRPCConfig — сетевые настройки gRPC-сервера.
 type GRPCConfig struct {
 	Host string `yaml:"host" env:"GRPC_HOST" env-default:"0.0.0.0"`
 	Port string `yaml:"port" env:"GRPC_PORT" env-default:"50053"`
+	// TLS — TLS/mTLS gRPC-сервера и исходящих gRPC-клиентов (pkg/tlsconfig).
+	TLS tlsconfig.Config `yaml:"tls" env-prefix:"GRPC_TLS_"`
 }
 
 // HTTPConfig — сетевые настройки HTTP-сервера.
@@ -58,9 +71,40 @@ type S3Config struct {
 	PublicBaseURL string        `yaml:"public_base_url" env:"S3_PUBLIC_BASE_URL" env-default:""`
 }
 
+// AvatarConfig — ограничения и обработка аватаров.
+// MaxPixels — предел width*height исходника (защита от decompression bomb);
+// Sizes — стороны квадратных превью, генерируемых при подтверждении загрузки.
 type AvatarConfig struct {
 	MaxSizeBytes        int64    `yaml:"max_size_bytes" env:"AVATAR_MAX_SIZE_BYTES" env-default:"5242880"`
 	AllowedContentTypes []string `yaml:"allowed_content_types" env:"AVATAR_ALLOWED_CONTENT_TYPES" env-separator:"," env-default:"image/jpeg,image/png"`
+	MaxPixels           int64    `yaml:"max_pixels" env:"AVATAR_MAX_PIXELS" env-default:"16000000"`
+	Sizes               []int    `yaml:"sizes" env:"AVATAR_SIZES" env-separator:"," env-default:"64,128,512"`
+}
+
+// ReaperConfig — фоновая уборка осиротевших объектов под avatars/.
+// Grace — сколько живёт неподтверждённая загрузка (объект без ссылки из профиля);
+// Retention — сколько хранится заменённый или удалённый аватар (на случай отката/кэшей CDN);
+// DryRun — только считать и логировать кандидатов, ничего не удаляя.
+type ReaperConfig struct {
+	Enabled   bool          `yaml:"enabled" env:"AVATAR_REAPER_ENABLED"`
+	DryRun    bool          `yaml:"dry_run" env:"AVATAR_REAPER_DRY_RUN"`
+	Interval  time.Duration `yaml:"interval" env:"AVATAR_REAPER_INTERVAL" env-default:"1h"`
+	Grace     time.Duration `yaml:"grace" env:"AVATAR_REAPER_GRACE" env-default:"24h"`
+	Retention time.Duration `yaml:"retention" env:"AVATAR_REAPER_RETENTION" env-default:"168h"`
+	BatchSize int           `yaml:"batch_size" env:"AVATAR_REAPER_BATCH_SIZE" env-default:"500"`
+}
+
+// UsernameConfig — политика имён пользователей.
+// Reserved — имена, недоступные для регистрации; сравнение идёт по канонической
+// форме (см. internal/usernames), поэтому "Admin" и "аdmin" с кириллицей тоже заняты.
+type UsernameConfig struct {
+	Reserved []string `yaml:"reserved" env:"USERNAME_RESERVED" env-separator:"," env-default:"admin,administrator,moderator,mod,root,system,support,staff,official,security,help,api,null,undefined,me"`
+}
+
+// LimitsConfig — ограничения на размер пакетных запросов.
+// MaxProfilesBatch — максимум user_id в одном ProfilesByIDs.
+type LimitsConfig struct {
+	MaxProfilesBatch int `yaml:"max_profiles_batch" env:"MAX_PROFILES_BATCH" env-default:"200"`
 }
 
 // TimeoutConfig — таймауты сервиса.
@@ -173,6 +217,34 @@ func (c *Config) validate() error {
 		c.Avatar.MaxSizeBytes = 5 * 1024 * 1024 // 5 MiB
 	}
 
+	if c.Avatar.MaxPixels == 0 {
+		c.Avatar.MaxPixels = 16_000_000
+	}
+
+	if len(c.Avatar.Sizes) == 0 {
+		c.Avatar.Sizes = []int{64, 128, 512}
+	}
+
+	if c.Reaper.Interval == 0 {
+		c.Reaper.Interval = time.Hour
+	}
+
+	if c.Reaper.Grace == 0 {
+		c.Reaper.Grace = 24 * time.Hour
+	}
+
+	if c.Reaper.Retention == 0 {
+		c.Reaper.Retention = 7 * 24 * time.Hour
+	}
+
+	if c.Reaper.BatchSize == 0 {
+		c.Reaper.BatchSize = 500
+	}
+
+	if c.Limits.MaxProfilesBatch == 0 {
+		c.Limits.MaxProfilesBatch = 200
+	}
+
 	if c.Postgres.URL == "" {
 		return fmt.Errorf("postgres.url is required")
 	}
@@ -229,5 +301,33 @@ func (c *Config) validate() error {
 		return fmt.Errorf("avatar.allowed_content_types must not be empty")
 	}
 
+	if c.Avatar.MaxPixels < 0 {
+		return fmt.Errorf("avatar.max_pixels must be >= 0")
+	}
+
+	for _, size := range c.Avatar.Sizes {
+		if size < 16 || size > 2048 {
+			return fmt.Errorf("avatar.sizes must be within 16..2048, got %d", size)
+		}
+	}
+
+	if c.Reaper.Interval < 0 || c.Reaper.Grace < 0 || c.Reaper.Retention < 0 {
+		return fmt.Errorf("avatar_reaper.interval, grace and retention must be >= 0")
+	}
+
+	// Неподтверждённая загрузка должна пережить presigned URL, иначе клиент
+	// может успеть залить объект уже после его удаления.
+	if c.Reaper.Grace < c.S3.PresignTTL {
+		return fmt.Errorf("avatar_reaper.grace must be >= s3.presign_ttl")
+	}
+
+	if c.Reaper.BatchSize < 0 || c.Reaper.BatchSize > 1000 {
+		return fmt.Errorf("avatar_reaper.batch_size must be within 1..1000")
+	}
+
+	if c.Limits.MaxProfilesBatch < 0 || c.Limits.MaxProfilesBatch > 1000 {
+		return fmt.Errorf("limits.max_profiles_batch must be within 1..1000")
+	}
+
 	return nil
 }
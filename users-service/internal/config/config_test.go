@@ -374,3 +374,69 @@ avatar: { max_size_bytes: 0, allowed_content_types: ["image/jpeg"] }
 	cfg := MustLoad(cfgPath)
 	require.Equal(t, int64(5242880), cfg.Avatar.MaxSizeBytes)
 }
+
+func TestLoad_AvatarProcessing_DefaultsAndValidation(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "avatar_processing.yaml", `
+postgres: { url: "postgres://x" }
+s3: { endpoint: "http://minio:9000", root_user: "root", root_password: "rootpass", bucket: "avatars" }
+avatar: { allowed_content_types: ["image/png"] }
+`)
+	cfg := MustLoad(cfgPath)
+	require.Equal(t, int64(16_000_000), cfg.Avatar.MaxPixels)
+	require.Equal(t, []int{64, 128, 512}, cfg.Avatar.Sizes)
+
+	badPath := writeFile(t, dir, "avatar_bad_sizes.yaml", `
+postgres: { url: "postgres://x" }
+s3: { endpoint: "http://minio:9000", root_user: "root", root_password: "rootpass", bucket: "avatars" }
+avatar: { allowed_content_types: ["image/png"], sizes: [64, 4096] }
+`)
+	_, err := Load(badPath)
+	require.Error(t, err)
+}
+
+func TestLoad_AvatarReaper_DefaultsAndValidation(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfgPath := writeFile(t, dir, "reaper.yaml", minimalYAML)
+	cfg := MustLoad(cfgPath)
+	require.False(t, cfg.Reaper.Enabled)
+	require.False(t, cfg.Reaper.DryRun)
+	require.Equal(t, time.Hour, cfg.Reaper.Interval)
+	require.Equal(t, 24*time.Hour, cfg.Reaper.Grace)
+	require.Equal(t, 168*time.Hour, cfg.Reaper.Retention)
+	require.Equal(t, 500, cfg.Reaper.BatchSize)
+
+	shortGrace := writeFile(t, dir, "reaper_short_grace.yaml", minimalYAML+`
+avatar_reaper: { grace: "5m" }
+`)
+	_, err := Load(shortGrace)
+	require.Error(t, err)
+
+	bigBatch := writeFile(t, dir, "reaper_big_batch.yaml", minimalYAML+`
+avatar_reaper: { batch_size: 5000 }
+`)
+	_, err = Load(bigBatch)
+	require.Error(t, err)
+}
+
+func TestLoad_ReservedUsernames_Default(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfg := MustLoad(writeFile(t, dir, "usernames.yaml", minimalYAML))
+	require.Contains(t, cfg.Username.Reserved, "admin")
+	require.Contains(t, cfg.Username.Reserved, "moderator")
+}
+
+func TestLoad_Limits_DefaultsAndValidation(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfg := MustLoad(writeFile(t, dir, "limits.yaml", minimalYAML))
+	require.Equal(t, 200, cfg.Limits.MaxProfilesBatch)
+
+	_, err := Load(writeFile(t, dir, "limits_bad.yaml", minimalYAML+`
+limits: { max_profiles_batch: 5000 }
+`))
+	require.Error(t, err)
+}
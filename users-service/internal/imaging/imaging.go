@@ -0,0 +1,184 @@
+// imaging — серверная обработка загруженных аватаров (только stdlib).
+//
+// Process:
+//   - определяет реальный формат по содержимому (а не по Content-Type загрузки);
+//   - до полного декодирования читает только заголовок и отклоняет изображения,
+//     число пикселей которых превышает лимит (защита от decompression bomb);
+//   - перекодирует оригинал: EXIF/ICC/текстовые чанки и любые "хвосты" полиглотов не переносятся;
+//   - строит квадратные превью фиксированных размеров (центр-кроп + усреднение по площади).
+//
+// Поддерживаемые форматы: JPEG и PNG. Ориентация из EXIF не применяется.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+var (
+	// ErrUnsupportedFormat — содержимое не является изображением поддерживаемого формата.
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	// ErrTooManyPixels — размеры изображения превышают лимит.
+	ErrTooManyPixels = errors.New("image dimensions exceed limit")
+)
+
+// defaultJPEGQuality — качество перекодирования JPEG, если не задано в Options.
+const defaultJPEGQuality = 85
+
+// Options — параметры обработки.
+//   - MaxPixels: максимум width*height исходника (> 0);
+//   - Sizes: стороны квадратных превью в пикселях;
+//   - JPEGQuality: 1..100, 0 — defaultJPEGQuality.
+type Options struct {
+	MaxPixels   int64
+	Sizes       []int
+	JPEGQuality int
+}
+
+// Variant — закодированное превью стороной Size.
+type Variant struct {
+	Size int
+	Data []byte
+}
+
+// Result — результат обработки: перекодированный оригинал и превью в том же формате.
+type Result struct {
+	ContentType string // image/jpeg | image/png
+	Ext         string // .jpg | .png
+	Width       int
+	Height      int
+	Original    []byte
+	Variants    []Variant
+}
+
+// Process проверяет и перекодирует изображение data.
+// Ошибки: ErrUnsupportedFormat, ErrTooManyPixels; прочие — ошибки кодирования.
+func Process(data []byte, opts Options) (*Result, error) {
+	contentType := http.DetectContentType(data)
+
+	var ext string
+	switch contentType {
+	case "image/jpeg":
+		ext = ".jpg"
+	case "image/png":
+		ext = ".png"
+	default:
+		return nil, fmt.Errorf("%w: detected %s", ErrUnsupportedFormat, contentType)
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || "image/"+format != contentType {
+		return nil, fmt.Errorf("%w: malformed %s", ErrUnsupportedFormat, contentType)
+	}
+
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > opts.MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrTooManyPixels, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: decode: %v", ErrUnsupportedFormat, err)
+	}
+
+	quality := opts.JPEGQuality
+	if quality <= 0 || quality > 100 {
+		quality = defaultJPEGQuality
+	}
+
+	enc := func(m image.Image) ([]byte, error) {
+		var buf bytes.Buffer
+		if contentType == "image/jpeg" {
+			if err := jpeg.Encode(&buf, m, &jpeg.Options{Quality: quality}); err != nil {
+				return nil, err
+			}
+		} else if err := (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(&buf, m); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	out := &Result{
+		ContentType: contentType,
+		Ext:         ext,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+	}
+
+	if out.Original, err = enc(img); err != nil {
+		return nil, fmt.Errorf("encode original: %w", err)
+	}
+
+	square := cropSquare(img)
+	for _, size := range opts.Sizes {
+		b, err := enc(resize(square, size))
+		if err != nil {
+			return nil, fmt.Errorf("encode %dpx: %w", size, err)
+		}
+
+		out.Variants = append(out.Variants, Variant{Size: size, Data: b})
+	}
+
+	return out, nil
+}
+
+// cropSquare вырезает центральный квадрат и приводит его к *image.RGBA
+// (премультиплицированные каналы — корректное усреднение с прозрачностью).
+func cropSquare(img image.Image) *image.RGBA {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	sp := image.Pt(b.Min.X+(b.Dx()-side)/2, b.Min.Y+(b.Dy()-side)/2)
+
+	dst := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(dst, dst.Bounds(), img, sp, draw.Src)
+
+	return dst
+}
+
+// resize масштабирует квадрат src к стороне size усреднением по площади (box filter).
+// При увеличении каждый пиксель результата берёт ближайший пиксель исходника.
+func resize(src *image.RGBA, size int) *image.RGBA {
+	side := src.Bounds().Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	span := func(i int) (int, int) {
+		lo, hi := i*side/size, (i+1)*side/size
+		if hi <= lo {
+			hi = lo + 1
+		}
+
+		return lo, hi
+	}
+
+	for dy := 0; dy < size; dy++ {
+		sy0, sy1 := span(dy)
+		for dx := 0; dx < size; dx++ {
+			sx0, sx1 := span(dx)
+
+			var sum [4]uint64
+			for sy := sy0; sy < sy1; sy++ {
+				row := src.Pix[sy*src.Stride+sx0*4 : sy*src.Stride+sx1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += uint64(row[i])
+					sum[1] += uint64(row[i+1])
+					sum[2] += uint64(row[i+2])
+					sum[3] += uint64(row[i+3])
+				}
+			}
+
+			n := uint64((sy1 - sy0) * (sx1 - sx0))
+			off := dy*dst.Stride + dx*4
+			for c := 0; c < 4; c++ {
+				dst.Pix[off+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+
+	return dst
+}
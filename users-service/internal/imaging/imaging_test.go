@@ -0,0 +1,136 @@
+package imaging
+
+// Тесты обработки аватаров (internal/imaging):
+//  - определение формата по содержимому и отказ для не-изображений/полиглотов;
+//  - защита от decompression bomb по числу пикселей (до полного декодирования);
+//  - удаление метаданных при перекодировании;
+//  - размеры и центр-кроп превью.
+//
+// Запуск:
+//   go test ./internal/imaging -v -race -count=1
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, w, h int, fill func(x, y int) color.Color) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, fill(x, y))
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+// withTextChunk вставляет tEXt-чанк (метаданные) сразу после IHDR.
+func withTextChunk(t *testing.T, data []byte, text string) []byte {
+	t.Helper()
+
+	const ihdrEnd = 8 + 4 + 4 + 13 + 4 // сигнатура + IHDR (длина, тип, данные, CRC)
+	payload := append([]byte("tEXt"), []byte(text)...)
+
+	chunk := make([]byte, 4, 4+len(payload)+4)
+	binary.BigEndian.PutUint32(chunk, uint32(len(payload)-4))
+	chunk = append(chunk, payload...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(payload))
+
+	out := append([]byte{}, data[:ihdrEnd]...)
+	out = append(out, chunk...)
+
+	return append(out, data[ihdrEnd:]...)
+}
+
+func TestProcess_PNG_VariantsAndMetadataStripped(t *testing.T) {
+	red := color.NRGBA{R: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+	// 300x100: центральный квадрат 100..200 — синий, края — красные.
+	src := encodePNG(t, 300, 100, func(x, _ int) color.Color {
+		if x >= 100 && x < 200 {
+			return blue
+		}
+		return red
+	})
+	src = withTextChunk(t, src, "GPS\x0055.75,37.61")
+	src = append(src, []byte("<script>alert(1)</script>")...) // хвост полиглота
+
+	res, err := Process(src, Options{MaxPixels: 1 << 20, Sizes: []int{16, 64, 128}})
+	require.NoError(t, err)
+	require.Equal(t, "image/png", res.ContentType)
+	require.Equal(t, ".png", res.Ext)
+	require.Equal(t, 300, res.Width)
+	require.Equal(t, 100, res.Height)
+
+	require.NotContains(t, string(res.Original), "GPS")
+	require.NotContains(t, string(res.Original), "<script>")
+
+	require.Len(t, res.Variants, 3)
+	for _, v := range res.Variants {
+		img, err := png.Decode(bytes.NewReader(v.Data))
+		require.NoError(t, err)
+		require.Equal(t, image.Rect(0, 0, v.Size, v.Size), img.Bounds())
+
+		r, g, b, _ := img.At(v.Size/2, v.Size/2).RGBA()
+		require.Equal(t, [3]uint32{0, 0, 0xffff}, [3]uint32{r, g, b}, "center crop must keep the middle")
+	}
+}
+
+func TestProcess_JPEG(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 60))
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+
+	res, err := Process(buf.Bytes(), Options{MaxPixels: 1 << 20, Sizes: []int{64}})
+	require.NoError(t, err)
+	require.Equal(t, "image/jpeg", res.ContentType)
+
+	out, err := jpeg.Decode(bytes.NewReader(res.Variants[0].Data))
+	require.NoError(t, err)
+	require.Equal(t, 64, out.Bounds().Dx())
+}
+
+func TestProcess_RejectsNonImages(t *testing.T) {
+	cases := map[string][]byte{
+		"garbage":   bytes.Repeat([]byte{0x42}, 64),
+		"html":      []byte("<html><body>hi</body></html>"),
+		"gif":       []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;"),
+		"truncated": encodePNG(t, 8, 8, func(int, int) color.Color { return color.White })[:40],
+	}
+
+	for name, data := range cases {
+		_, err := Process(data, Options{MaxPixels: 1 << 20, Sizes: []int{64}})
+		require.True(t, errors.Is(err, ErrUnsupportedFormat), "%s: got %v", name, err)
+	}
+}
+
+func TestProcess_DecompressionBomb(t *testing.T) {
+	src := encodePNG(t, 8, 8, func(int, int) color.Color { return color.White })
+
+	// Подменяем размеры в IHDR на 100000x100000: пиксели не распаковываются, отказ по заголовку.
+	bomb := append([]byte{}, src...)
+	binary.BigEndian.PutUint32(bomb[16:], 100000)
+	binary.BigEndian.PutUint32(bomb[20:], 100000)
+	binary.BigEndian.PutUint32(bomb[29:], crc32.ChecksumIEEE(bomb[12:29]))
+
+	_, err := Process(bomb, Options{MaxPixels: 16_000_000, Sizes: []int{64}})
+	require.ErrorIs(t, err, ErrTooManyPixels)
+
+	_, err = Process(src, Options{MaxPixels: 63, Sizes: []int{64}})
+	require.ErrorIs(t, err, ErrTooManyPixels)
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// News — снимок новости из news-service, которым гидрируются закладки.
+type News struct {
+	ID               uuid.UUID
+	Title            string
+	Category         string
+	ShortDescription string
+	Link             string
+	ImageURL         string
+	Source           string
+	Language         string
+	PublishedAt      time.Time
+}
+
+// Bookmark — закладка читателя.
+//
+// Особенности:
+//   - News заполняется при выдаче списка; nil — новость удалена из news-service
+//     (NewsMissing = true) либо news-service недоступен (NewsMissing = false);
+//   - закладка на удалённую новость не удаляется автоматически — читатель
+//     видит её и может убрать сам.
+type Bookmark struct {
+	NewsID      uuid.UUID
+	SavedAt     time.Time
+	News        *News
+	NewsMissing bool
+}
+
+// BookmarksPage — страница закладок, сначала недавно сохранённые.
+// NextPageToken пуст, если страниц больше нет.
+type BookmarksPage struct {
+	Items         []Bookmark
+	NextPageToken string
+}
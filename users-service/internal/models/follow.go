@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// FollowEntry — элемент списка подписчиков/подписок: профиль второй стороны
+// и момент подписки.
+type FollowEntry struct {
+	Profile    Profile
+	FollowedAt time.Time
+}
+
+// FollowsPage — страница подписчиков/подписок, сначала новые.
+// NextPageToken пуст, если страниц больше нет.
+type FollowsPage struct {
+	Items         []FollowEntry
+	NextPageToken string
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Preferences — предпочтения читателя для персональной ленты news-service.
+//
+// Особенности:
+//   - значения нормализованы (pkg/feeds): категории — в нижнем регистре,
+//     источники — хосты без "www.", языки — основной подтег ("en", "ru");
+//   - заглушение сильнее подписки: категория из MutedCategories скрывается,
+//     даже если она есть в FollowedCategories;
+//   - пустой Languages — без фильтра по языку;
+//   - UpdatedAt нулевой, если пользователь предпочтения ещё не задавал.
+type Preferences struct {
+	UserID             uuid.UUID
+	FollowedCategories []string
+	MutedCategories    []string
+	MutedSources       []string
+	Languages          []string
+	UpdatedAt          time.Time
+}
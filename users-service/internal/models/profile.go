@@ -31,16 +31,107 @@ func (g Gender) String() string {
 	}
 }
 
+// Visibility — кому виден атрибут профиля.
+type Visibility int16
+
+const (
+	// VisibilityPublic — всем, включая анонимных.
+	VisibilityPublic Visibility = iota
+	// VisibilityRegistered — только аутентифицированным пользователям.
+	VisibilityRegistered
+	// VisibilityPrivate — только владельцу (и администраторам).
+	VisibilityPrivate
+)
+
+func (v Visibility) String() string {
+	switch v {
+	case VisibilityRegistered:
+		return "registered"
+	case VisibilityPrivate:
+		return "private"
+	default:
+		return "public"
+	}
+}
+
+// Privacy — настройки видимости атрибутов профиля (profiles.privacy, JSONB).
+// Отсутствующий в JSON ключ означает VisibilityPublic.
+// username и аватар всегда публичны — они нужны для подписи комментариев.
+type Privacy struct {
+	Age     Visibility `json:"age,omitempty"`
+	Gender  Visibility `json:"gender,omitempty"`
+	Country Visibility `json:"country,omitempty"`
+}
+
+// Viewer — кто запрашивает профиль (см. Profile.RedactFor).
+// UserID == uuid.Nil — анонимный вызов.
+type Viewer struct {
+	UserID uuid.UUID
+	Admin  bool
+}
+
+// AvatarVariant — квадратное превью аватара стороной Size пикселей.
+// Хранится в profiles.avatar_variants (JSONB).
+type AvatarVariant struct {
+	Size int    `json:"size"`
+	Key  string `json:"key"`
+	URL  string `json:"url,omitempty"`
+}
+
 // Profile — внутренняя доменная модель.
 // CreatedAt/UpdateAt - наружу/внутрь gRPC конвертируем в int64.
+// AvatarVariants упорядочены по возрастанию Size; пусто — аватар не задан
+// или загружен до появления серверной обработки.
+// Privacy — настройки видимости; сторонним зрителям отдаётся копия после RedactFor.
+// FollowersCount/FollowingCount — денормализованные счётчики подписок (см. FollowEntry).
 type Profile struct {
-	UserID    uuid.UUID
-	Username  string
-	Age       uint32
-	Country   string
-	Gender    Gender
-	AvatarKey string
-	AvatarURL string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	UserID         uuid.UUID
+	Username       string
+	Age            uint32
+	Country        string
+	Gender         Gender
+	AvatarKey      string
+	AvatarURL      string
+	AvatarVariants []AvatarVariant
+	Privacy        Privacy
+	FollowersCount uint32
+	FollowingCount uint32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// RedactFor возвращает копию профиля в представлении для viewer:
+//   - владелец и администратор видят всё, включая Privacy;
+//   - аутентифицированный пользователь — public и registered атрибуты;
+//   - анонимный — только public.
+//
+// Скрытые атрибуты обнуляются, Privacy у чужого профиля не раскрывается.
+func (p Profile) RedactFor(viewer Viewer) Profile {
+	if viewer.Admin || (viewer.UserID != uuid.Nil && viewer.UserID == p.UserID) {
+		return p
+	}
+
+	visible := func(v Visibility) bool {
+		switch v {
+		case VisibilityPublic:
+			return true
+		case VisibilityRegistered:
+			return viewer.UserID != uuid.Nil
+		default:
+			return false
+		}
+	}
+
+	if !visible(p.Privacy.Age) {
+		p.Age = 0
+	}
+	if !visible(p.Privacy.Gender) {
+		p.Gender = GenderUnspecified
+	}
+	if !visible(p.Privacy.Country) {
+		p.Country = ""
+	}
+	p.Privacy = Privacy{}
+
+	return p
 }
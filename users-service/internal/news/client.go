@@ -0,0 +1,94 @@
+// news — gRPC-клиент news-service для users-service.
+// Используется только для гидрации закладок читателя новостями.
+package news
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	newsv1 "github.com/pribylovaa/go-news-aggregator/users-service/gen/go/news"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+
+	"google.golang.org/grpc"
+)
+
+// batchSize — id в одном NewsByIDs (не больше limits.max_batch news-service по умолчанию).
+const batchSize = 100
+
+// Client — тонкая обёртка над newsv1.NewsServiceClient.
+// Реализует service.NewsSource.
+type Client struct {
+	conn *grpc.ClientConn
+	api  newsv1.NewsServiceClient
+}
+
+// New создаёт клиент news-service по адресу addr (соединение ленивое).
+// creds — транспорт (TLS/mTLS или insecure, см. tlsconfig.Reloader.DialOption).
+func New(addr string, creds grpc.DialOption) (*Client, error) {
+	const op = "news/New"
+
+	if addr == "" {
+		return nil, fmt.Errorf("%s: empty addr", op)
+	}
+
+	conn, err := grpc.NewClient(addr, creds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Client{conn: conn, api: newsv1.NewNewsServiceClient(conn)}, nil
+}
+
+// NewsByIDs получает новости пакетным RPC NewsByIDs (по batchSize id за вызов).
+// Отсутствующих новостей (missing_ids) просто нет в ответе; ошибка любого вызова
+// прерывает выборку целиком.
+func (c *Client) NewsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]models.News, error) {
+	const op = "news/NewsByIDs"
+
+	out := make(map[uuid.UUID]models.News, len(ids))
+	for start := 0; start < len(ids); start += batchSize {
+		chunk := ids[start:min(start+batchSize, len(ids))]
+
+		req := &newsv1.NewsByIDsRequest{Ids: make([]string, 0, len(chunk))}
+		for _, id := range chunk {
+			req.Ids = append(req.Ids, id.String())
+		}
+
+		resp, err := c.api.NewsByIDs(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, n := range resp.GetItems() {
+			id, err := uuid.Parse(n.GetId())
+			if err != nil {
+				continue
+			}
+
+			out[id] = fromProtoNews(id, n)
+		}
+	}
+
+	return out, nil
+}
+
+// Close закрывает соединение.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func fromProtoNews(id uuid.UUID, n *newsv1.News) models.News {
+	return models.News{
+		ID:               id,
+		Title:            n.GetTitle(),
+		Category:         n.GetCategory(),
+		ShortDescription: n.GetShortDescription(),
+		Link:             n.GetLink(),
+		ImageURL:         n.GetImageUrl(),
+		Source:           n.GetSource(),
+		Language:         n.GetLanguage(),
+		PublishedAt:      time.Unix(n.GetPublishedAt(), 0).UTC(),
+	}
+}
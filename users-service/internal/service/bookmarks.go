@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+)
+
+// Границы страницы ListBookmarks.
+const (
+	defaultBookmarksPageSize = 20
+	maxBookmarksPageSize     = 100
+)
+
+// ListBookmarksInput — параметры постраничной выдачи закладок.
+type ListBookmarksInput struct {
+	UserID    uuid.UUID
+	PageSize  int32
+	PageToken string
+}
+
+// AddBookmark сохраняет закладку userID на newsID.
+//
+// Валидация:
+//   - оба id обязательны — иначе ErrInvalidArgument.
+//
+// Поведение:
+//   - повторное сохранение — no-op (changed=false);
+//   - если подключён NewsSource и новости нет — ErrNotFound; недоступность
+//     news-service сохранению не мешает (закладка проверится при выдаче списка);
+//   - отсутствующий профиль -> ErrNotFound; прочие ошибки стораджа -> ErrInternal.
+func (s *Service) AddBookmark(ctx context.Context, userID, newsID uuid.UUID) (bool, error) {
+	const op = "service/bookmarks/AddBookmark"
+
+	lg := log.From(ctx).With("op", op, "user_id", userID.String(), "news_id", newsID.String())
+
+	if userID == uuid.Nil || newsID == uuid.Nil {
+		lg.Warn("invalid argument: user_id/news_id")
+
+		return false, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	if s.news != nil {
+		found, err := s.news.NewsByIDs(ctx, []uuid.UUID{newsID})
+		switch {
+		case err != nil:
+			lg.Warn("news source unavailable, bookmark saved unchecked", "err", err)
+		case len(found) == 0:
+			lg.Warn("news not found")
+
+			return false, fmt.Errorf("%s: %w", op, ErrNotFound)
+		}
+	}
+
+	changed, err := s.profilesStorage.AddBookmark(ctx, userID, newsID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFoundProfile) {
+			lg.Warn("profile not found")
+
+			return false, fmt.Errorf("%s: %w", op, ErrNotFound)
+		}
+
+		lg.Error("storage error on AddBookmark", "err", err)
+
+		return false, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	return changed, nil
+}
+
+// RemoveBookmark удаляет закладку userID на newsID.
+//
+// Валидация — как у AddBookmark. Отсутствующая закладка — no-op (changed=false).
+// Существование новости не проверяется: убрать закладку на удалённую новость можно всегда.
+func (s *Service) RemoveBookmark(ctx context.Context, userID, newsID uuid.UUID) (bool, error) {
+	const op = "service/bookmarks/RemoveBookmark"
+
+	lg := log.From(ctx).With("op", op, "user_id", userID.String(), "news_id", newsID.String())
+
+	if userID == uuid.Nil || newsID == uuid.Nil {
+		lg.Warn("invalid argument: user_id/news_id")
+
+		return false, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	changed, err := s.profilesStorage.RemoveBookmark(ctx, userID, newsID)
+	if err != nil {
+		lg.Error("storage error on RemoveBookmark", "err", err)
+
+		return false, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	return changed, nil
+}
+
+// ListBookmarks — закладки пользователя, сначала недавно сохранённые,
+// с новостями из NewsSource (один батч-запрос на страницу).
+//
+// Валидация:
+//   - UserID обязателен; PageSize: 0 -> defaultBookmarksPageSize, больше максимума -> обрезается,
+//     отрицательный -> ErrInvalidArgument.
+//
+// Гидрация:
+//   - новость, которой больше нет в news-service, — закладка с NewsMissing = true;
+//   - ошибка или отсутствие NewsSource — закладки без News (ошибка логируется).
+//
+// Ошибки:
+//   - ErrInvalidCursor — некорректный page_token;
+//   - ErrInternal — иные ошибки стораджа.
+func (s *Service) ListBookmarks(ctx context.Context, in ListBookmarksInput) (*models.BookmarksPage, error) {
+	const op = "service/bookmarks/ListBookmarks"
+
+	lg := log.From(ctx).With("op", op, "user_id", in.UserID.String())
+
+	if in.UserID == uuid.Nil || in.PageSize < 0 {
+		lg.Warn("invalid argument: user_id/page_size", "page_size", in.PageSize)
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	size := int(in.PageSize)
+	switch {
+	case size == 0:
+		size = defaultBookmarksPageSize
+	case size > maxBookmarksPageSize:
+		size = maxBookmarksPageSize
+	}
+
+	page, err := s.profilesStorage.ListBookmarks(ctx, in.UserID, size, in.PageToken)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			lg.Warn("invalid cursor")
+
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidCursor)
+		}
+
+		lg.Error("storage error on ListBookmarks", "err", err)
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	if s.news == nil || len(page.Items) == 0 {
+		return page, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(page.Items))
+	for _, b := range page.Items {
+		ids = append(ids, b.NewsID)
+	}
+
+	found, err := s.news.NewsByIDs(ctx, ids)
+	if err != nil {
+		lg.Warn("news source unavailable, bookmarks without news", "err", err)
+
+		return page, nil
+	}
+
+	for i := range page.Items {
+		if n, ok := found[page.Items[i].NewsID]; ok {
+			page.Items[i].News = &n
+		} else {
+			page.Items[i].NewsMissing = true
+		}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+// stubNews — NewsSource с фиксированным набором новостей.
+type stubNews struct {
+	news  map[uuid.UUID]models.News
+	err   error
+	calls int
+}
+
+func (s *stubNews) NewsByIDs(_ context.Context, ids []uuid.UUID) (map[uuid.UUID]models.News, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	out := make(map[uuid.UUID]models.News)
+	for _, id := range ids {
+		if n, ok := s.news[id]; ok {
+			out[id] = n
+		}
+	}
+
+	return out, nil
+}
+
+func TestService_AddRemoveBookmark(t *testing.T) {
+	s, mp, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	uid, nid, gone := uuid.New(), uuid.New(), uuid.New()
+	news := &stubNews{news: map[uuid.UUID]models.News{nid: {ID: nid, Title: "t"}}}
+	s.SetNewsSource(news)
+
+	_, err := s.AddBookmark(context.Background(), uid, uuid.Nil)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = s.RemoveBookmark(context.Background(), uuid.Nil, nid)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	// Новости нет в news-service — закладка не создаётся.
+	_, err = s.AddBookmark(context.Background(), uid, gone)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	mp.EXPECT().AddBookmark(gomock.Any(), uid, nid).Return(true, nil)
+	changed, err := s.AddBookmark(context.Background(), uid, nid)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	// news-service недоступен — сохраняем без проверки.
+	news.err = errors.New("news down")
+	mp.EXPECT().AddBookmark(gomock.Any(), uid, gone).Return(false, storage.ErrNotFoundProfile)
+	_, err = s.AddBookmark(context.Background(), uid, gone)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Удаление не смотрит в news-service.
+	calls := news.calls
+	mp.EXPECT().RemoveBookmark(gomock.Any(), uid, gone).Return(false, nil)
+	changed, err = s.RemoveBookmark(context.Background(), uid, gone)
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Equal(t, calls, news.calls)
+}
+
+func TestService_ListBookmarks(t *testing.T) {
+	s, mp, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	uid, live, purged := uuid.New(), uuid.New(), uuid.New()
+	saved := time.Unix(1710000000, 0).UTC()
+	page := func() *models.BookmarksPage {
+		return &models.BookmarksPage{
+			Items:         []models.Bookmark{{NewsID: live, SavedAt: saved}, {NewsID: purged, SavedAt: saved}},
+			NextPageToken: "next",
+		}
+	}
+
+	_, err := s.ListBookmarks(context.Background(), ListBookmarksInput{UserID: uid, PageSize: -1})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	// Без NewsSource — закладки как есть.
+	mp.EXPECT().ListBookmarks(gomock.Any(), uid, defaultBookmarksPageSize, "").Return(page(), nil)
+	got, err := s.ListBookmarks(context.Background(), ListBookmarksInput{UserID: uid})
+	require.NoError(t, err)
+	require.Nil(t, got.Items[0].News)
+	require.False(t, got.Items[1].NewsMissing)
+
+	news := &stubNews{news: map[uuid.UUID]models.News{live: {ID: live, Title: "live"}}}
+	s.SetNewsSource(news)
+
+	mp.EXPECT().ListBookmarks(gomock.Any(), uid, maxBookmarksPageSize, "tok").Return(page(), nil)
+	got, err = s.ListBookmarks(context.Background(), ListBookmarksInput{UserID: uid, PageSize: 1000, PageToken: "tok"})
+	require.NoError(t, err)
+	require.Equal(t, "next", got.NextPageToken)
+	require.Equal(t, "live", got.Items[0].News.Title)
+	require.False(t, got.Items[0].NewsMissing)
+	require.Nil(t, got.Items[1].News)
+	require.True(t, got.Items[1].NewsMissing, "удалённая новость помечается, закладка остаётся")
+	require.Equal(t, 1, news.calls, "один батч на страницу")
+
+	// Ошибка news-service — закладки без новостей, но и без ложных NewsMissing.
+	news.err = errors.New("news down")
+	mp.EXPECT().ListBookmarks(gomock.Any(), uid, defaultBookmarksPageSize, "").Return(page(), nil)
+	got, err = s.ListBookmarks(context.Background(), ListBookmarksInput{UserID: uid})
+	require.NoError(t, err)
+	require.Nil(t, got.Items[0].News)
+	require.False(t, got.Items[1].NewsMissing)
+
+	mp.EXPECT().ListBookmarks(gomock.Any(), uid, defaultBookmarksPageSize, "bad").Return(nil, storage.ErrInvalidCursor)
+	_, err = s.ListBookmarks(context.Background(), ListBookmarksInput{UserID: uid, PageToken: "bad"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	mp.EXPECT().ListBookmarks(gomock.Any(), uid, defaultBookmarksPageSize, "").Return(nil, errors.New("db down"))
+	_, err = s.ListBookmarks(context.Background(), ListBookmarksInput{UserID: uid})
+	require.ErrorIs(t, err, ErrInternal)
+}
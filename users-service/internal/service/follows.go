@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+)
+
+// Границы страницы ListFollowers/ListFollowing.
+const (
+	defaultFollowsPageSize = 20
+	maxFollowsPageSize     = 100
+)
+
+// ListFollowsInput — параметры постраничной выдачи подписчиков/подписок.
+// Viewer — кто смотрит список (профили в выдаче проходят RedactFor).
+type ListFollowsInput struct {
+	UserID    uuid.UUID
+	PageSize  int32
+	PageToken string
+	Viewer    models.Viewer
+}
+
+// Follow подписывает followerID на followeeID.
+//
+// Валидация:
+//   - оба id обязательны и различны — иначе ErrInvalidArgument.
+//
+// Поведение:
+//   - повторная подписка — no-op (changed=false);
+//   - отсутствующий профиль любой из сторон -> ErrNotFound;
+//   - прочие ошибки стораджа -> ErrInternal.
+func (s *Service) Follow(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	const op = "service/follows/Follow"
+
+	lg := log.From(ctx).With("op", op, "follower_id", followerID.String(), "followee_id", followeeID.String())
+
+	if followerID == uuid.Nil || followeeID == uuid.Nil || followerID == followeeID {
+		lg.Warn("invalid argument: follower/followee")
+
+		return false, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	changed, err := s.profilesStorage.Follow(ctx, followerID, followeeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFoundProfile):
+			lg.Warn("profile not found")
+
+			return false, fmt.Errorf("%s: %w", op, ErrNotFound)
+		case errors.Is(err, storage.ErrInvalidArgument):
+			lg.Warn("self-follow rejected by storage")
+
+			return false, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+		default:
+			lg.Error("storage error on Follow", "err", err)
+
+			return false, fmt.Errorf("%s: %w", op, ErrInternal)
+		}
+	}
+
+	return changed, nil
+}
+
+// Unfollow отменяет подписку followerID на followeeID.
+//
+// Валидация — как у Follow. Отсутствующая подписка — no-op (changed=false).
+func (s *Service) Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	const op = "service/follows/Unfollow"
+
+	lg := log.From(ctx).With("op", op, "follower_id", followerID.String(), "followee_id", followeeID.String())
+
+	if followerID == uuid.Nil || followeeID == uuid.Nil || followerID == followeeID {
+		lg.Warn("invalid argument: follower/followee")
+
+		return false, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	changed, err := s.profilesStorage.Unfollow(ctx, followerID, followeeID)
+	if err != nil {
+		lg.Error("storage error on Unfollow", "err", err)
+
+		return false, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	return changed, nil
+}
+
+// ListFollowers — подписчики пользователя, сначала новые подписки.
+//
+// Валидация:
+//   - UserID обязателен; PageSize: 0 -> defaultFollowsPageSize, больше максимума -> обрезается,
+//     отрицательный -> ErrInvalidArgument.
+//
+// Поведение/ошибки:
+//   - ErrInvalidCursor — некорректный page_token;
+//   - ErrInternal — иные ошибки стораджа.
+func (s *Service) ListFollowers(ctx context.Context, in ListFollowsInput) (*models.FollowsPage, error) {
+	return s.listFollows(ctx, "service/follows/ListFollowers", in, s.profilesStorage.ListFollowers)
+}
+
+// ListFollowing — на кого подписан пользователь, сначала новые подписки.
+// Валидация и ошибки — как у ListFollowers.
+func (s *Service) ListFollowing(ctx context.Context, in ListFollowsInput) (*models.FollowsPage, error) {
+	return s.listFollows(ctx, "service/follows/ListFollowing", in, s.profilesStorage.ListFollowing)
+}
+
+// listFollows — общая часть ListFollowers/ListFollowing.
+func (s *Service) listFollows(
+	ctx context.Context,
+	op string,
+	in ListFollowsInput,
+	list func(ctx context.Context, userID uuid.UUID, pageSize int, pageToken string) (*models.FollowsPage, error),
+) (*models.FollowsPage, error) {
+	lg := log.From(ctx).With("op", op, "user_id", in.UserID.String())
+
+	if in.UserID == uuid.Nil || in.PageSize < 0 {
+		lg.Warn("invalid argument: user_id/page_size", "page_size", in.PageSize)
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	size := int(in.PageSize)
+	switch {
+	case size == 0:
+		size = defaultFollowsPageSize
+	case size > maxFollowsPageSize:
+		size = maxFollowsPageSize
+	}
+
+	page, err := list(ctx, in.UserID, size, in.PageToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvalidCursor):
+			lg.Warn("invalid cursor")
+
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidCursor)
+		default:
+			lg.Error("storage error on list follows", "err", err)
+
+			return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+		}
+	}
+
+	for i := range page.Items {
+		page.Items[i].Profile = page.Items[i].Profile.RedactFor(in.Viewer)
+	}
+
+	return page, nil
+}
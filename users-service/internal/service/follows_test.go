@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Follow(t *testing.T) {
+	s, mp, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	a, b := uuid.New(), uuid.New()
+
+	for _, pair := range [][2]uuid.UUID{{uuid.Nil, b}, {a, uuid.Nil}, {a, a}} {
+		_, err := s.Follow(context.Background(), pair[0], pair[1])
+		require.ErrorIs(t, err, ErrInvalidArgument)
+	}
+
+	mp.EXPECT().Follow(gomock.Any(), a, b).Return(true, nil)
+	changed, err := s.Follow(context.Background(), a, b)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	mp.EXPECT().Follow(gomock.Any(), a, b).Return(false, storage.ErrNotFoundProfile)
+	_, err = s.Follow(context.Background(), a, b)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	mp.EXPECT().Unfollow(gomock.Any(), a, b).Return(false, nil)
+	changed, err = s.Unfollow(context.Background(), a, b)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	mp.EXPECT().Unfollow(gomock.Any(), a, b).Return(false, errors.New("db down"))
+	_, err = s.Unfollow(context.Background(), a, b)
+	require.ErrorIs(t, err, ErrInternal)
+}
+
+func TestService_ListFollowers(t *testing.T) {
+	s, mp, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	uid, follower := uuid.New(), uuid.New()
+
+	for _, in := range []ListFollowsInput{{}, {UserID: uid, PageSize: -1}} {
+		_, err := s.ListFollowers(context.Background(), in)
+		require.ErrorIs(t, err, ErrInvalidArgument)
+	}
+
+	// Размер страницы: 0 -> по умолчанию, больше максимума -> обрезается.
+	mp.EXPECT().ListFollowers(gomock.Any(), uid, defaultFollowsPageSize, "").Return(&models.FollowsPage{}, nil)
+	_, err := s.ListFollowers(context.Background(), ListFollowsInput{UserID: uid})
+	require.NoError(t, err)
+
+	p := mustProfile(follower, "bob")
+	p.Privacy = models.Privacy{Age: models.VisibilityPrivate}
+	mp.EXPECT().ListFollowers(gomock.Any(), uid, maxFollowsPageSize, "tok").Return(&models.FollowsPage{
+		Items:         []models.FollowEntry{{Profile: *p, FollowedAt: time.Now()}},
+		NextPageToken: "next",
+	}, nil)
+
+	page, err := s.ListFollowers(context.Background(), ListFollowsInput{UserID: uid, PageSize: 1000, PageToken: "tok"})
+	require.NoError(t, err)
+	require.Equal(t, "next", page.NextPageToken)
+	require.Len(t, page.Items, 1)
+	require.Zero(t, page.Items[0].Profile.Age, "profiles are redacted for the viewer")
+
+	mp.EXPECT().ListFollowing(gomock.Any(), uid, defaultFollowsPageSize, "bad").Return(nil, storage.ErrInvalidCursor)
+	_, err = s.ListFollowing(context.Background(), ListFollowsInput{UserID: uid, PageToken: "bad"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
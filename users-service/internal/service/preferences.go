@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/pkg/feeds"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+)
+
+// maxPreferenceItems — предельный размер каждого списка предпочтений.
+const maxPreferenceItems = 50
+
+// UpdatePreferencesInput — частичное изменение предпочтений.
+// nil — список не меняется; указатель на пустой срез — список очищается.
+type UpdatePreferencesInput struct {
+	UserID             uuid.UUID
+	FollowedCategories *[]string
+	MutedCategories    *[]string
+	MutedSources       *[]string
+	Languages          *[]string
+}
+
+// Preferences возвращает предпочтения пользователя (пустые, если не заданы).
+//
+// Ошибки:
+//   - ErrInvalidArgument — пустой userID;
+//   - ErrInternal — ошибки стораджа.
+func (s *Service) Preferences(ctx context.Context, userID uuid.UUID) (*models.Preferences, error) {
+	const op = "service/preferences/Preferences"
+
+	lg := log.From(ctx).With("op", op, "user_id", userID.String())
+
+	if userID == uuid.Nil {
+		lg.Warn("invalid argument: user_id")
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	p, err := s.profilesStorage.PreferencesByID(ctx, userID)
+	if err != nil {
+		lg.Error("storage error on PreferencesByID", "err", err)
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	return p, nil
+}
+
+// UpdatePreferences нормализует и сохраняет переданные списки предпочтений.
+//
+// Нормализация (pkg/feeds) — категории в нижнем регистре, источники — хосты
+// без "www.", языки — основной подтег; дубликаты схлопываются с сохранением порядка.
+//
+// Ошибки:
+//   - ErrInvalidArgument — пустой userID, ни одного изменяемого списка, некорректное
+//     значение или больше maxPreferenceItems элементов в списке;
+//   - ErrNotFound — профиль не существует;
+//   - ErrInternal — прочие ошибки стораджа.
+func (s *Service) UpdatePreferences(ctx context.Context, in UpdatePreferencesInput) (*models.Preferences, error) {
+	const op = "service/preferences/UpdatePreferences"
+
+	lg := log.From(ctx).With("op", op, "user_id", in.UserID.String())
+
+	if in.UserID == uuid.Nil {
+		lg.Warn("invalid argument: user_id")
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	var (
+		update storage.PreferencesUpdate
+		bad    string
+	)
+
+	for _, f := range []struct {
+		name string
+		in   *[]string
+		out  **[]string
+		norm func(string) string
+	}{
+		{"followed_categories", in.FollowedCategories, &update.FollowedCategories, feeds.Category},
+		{"muted_categories", in.MutedCategories, &update.MutedCategories, feeds.Category},
+		{"muted_sources", in.MutedSources, &update.MutedSources, feeds.Source},
+		{"languages", in.Languages, &update.Languages, feeds.Language},
+	} {
+		if f.in == nil {
+			continue
+		}
+
+		list, ok := normalizePreferenceList(*f.in, f.norm)
+		if !ok {
+			bad = f.name
+			break
+		}
+
+		*f.out = &list
+	}
+
+	if bad != "" {
+		lg.Warn("invalid argument: preference list", "field", bad)
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	if update == (storage.PreferencesUpdate{}) {
+		lg.Warn("invalid argument: nothing to update")
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	p, err := s.profilesStorage.UpdatePreferences(ctx, in.UserID, update)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFoundProfile) {
+			lg.Warn("profile not found")
+
+			return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
+		}
+
+		lg.Error("storage error on UpdatePreferences", "err", err)
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	return p, nil
+}
+
+// normalizePreferenceList приводит значения через norm и убирает дубликаты.
+// ok=false — некорректное значение или превышен maxPreferenceItems.
+func normalizePreferenceList(in []string, norm func(string) string) ([]string, bool) {
+	out := make([]string, 0, len(in))
+	seen := make(map[string]struct{}, len(in))
+
+	for _, raw := range in {
+		v := norm(raw)
+		if v == "" {
+			return nil, false
+		}
+
+		if _, dup := seen[v]; dup {
+			continue
+		}
+
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+
+	if len(out) > maxPreferenceItems {
+		return nil, false
+	}
+
+	return out, true
+}
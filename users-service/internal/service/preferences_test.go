@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Preferences(t *testing.T) {
+	s, mp, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+
+	_, err := s.Preferences(context.Background(), uuid.Nil)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	mp.EXPECT().PreferencesByID(gomock.Any(), uid).Return(&models.Preferences{UserID: uid}, nil)
+	p, err := s.Preferences(context.Background(), uid)
+	require.NoError(t, err)
+	require.Equal(t, uid, p.UserID)
+
+	mp.EXPECT().PreferencesByID(gomock.Any(), uid).Return(nil, errors.New("db down"))
+	_, err = s.Preferences(context.Background(), uid)
+	require.ErrorIs(t, err, ErrInternal)
+}
+
+func TestService_UpdatePreferences(t *testing.T) {
+	s, mp, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+	list := func(v ...string) *[]string { return &v }
+
+	tooMany := make([]string, maxPreferenceItems+1)
+	for i := range tooMany {
+		tooMany[i] = "c" + strconv.Itoa(i)
+	}
+
+	for _, in := range []UpdatePreferencesInput{
+		{FollowedCategories: list("tech")},                  // нет user_id
+		{UserID: uid},                                       // нечего менять
+		{UserID: uid, MutedSources: list("not a host")},     // некорректный источник
+		{UserID: uid, Languages: list("english")},           // некорректный язык
+		{UserID: uid, MutedCategories: list("   ")},         // пустая категория
+		{UserID: uid, FollowedCategories: list(tooMany...)}, // слишком много
+	} {
+		_, err := s.UpdatePreferences(context.Background(), in)
+		require.ErrorIs(t, err, ErrInvalidArgument)
+	}
+
+	// Нормализация и схлопывание дубликатов; непереданные списки не трогаются.
+	mp.EXPECT().UpdatePreferences(gomock.Any(), uid, storage.PreferencesUpdate{
+		FollowedCategories: list("tech", "world news"),
+		MutedSources:       list("lenta.ru"),
+		Languages:          list("en", "ru"),
+	}).Return(&models.Preferences{UserID: uid}, nil)
+	_, err := s.UpdatePreferences(context.Background(), UpdatePreferencesInput{
+		UserID:             uid,
+		FollowedCategories: list(" Tech", "World  News", "tech"),
+		MutedSources:       list("https://www.lenta.ru/rss"),
+		Languages:          list("en-US", "ru", "EN"),
+	})
+	require.NoError(t, err)
+
+	// Пустой список — очистка.
+	mp.EXPECT().UpdatePreferences(gomock.Any(), uid, storage.PreferencesUpdate{
+		MutedCategories: &[]string{},
+	}).Return(&models.Preferences{UserID: uid}, nil)
+	_, err = s.UpdatePreferences(context.Background(), UpdatePreferencesInput{UserID: uid, MutedCategories: &[]string{}})
+	require.NoError(t, err)
+
+	mp.EXPECT().UpdatePreferences(gomock.Any(), uid, gomock.Any()).Return(nil, storage.ErrNotFoundProfile)
+	_, err = s.UpdatePreferences(context.Background(), UpdatePreferencesInput{UserID: uid, Languages: list("ru")})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	mp.EXPECT().UpdatePreferences(gomock.Any(), uid, gomock.Any()).Return(nil, errors.New("db down"))
+	_, err = s.UpdatePreferences(context.Background(), UpdatePreferencesInput{UserID: uid, Languages: list("ru")})
+	require.ErrorIs(t, err, ErrInternal)
+}
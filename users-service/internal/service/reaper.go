@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Причины удаления объекта аватара (лейбл reason метрик).
+const (
+	reapReasonUnconfirmed = "unconfirmed"
+	reapReasonReplaced    = "replaced"
+)
+
+var (
+	reaperCandidates = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "users",
+		Subsystem: "avatar_reaper",
+		Name:      "candidates_total",
+		Help:      "Objects selected for deletion (including dry-run passes).",
+	}, []string{"reason"})
+
+	reaperDeletedObjects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "users",
+		Subsystem: "avatar_reaper",
+		Name:      "deleted_objects_total",
+		Help:      "Objects actually deleted from the bucket.",
+	}, []string{"reason"})
+
+	reaperDeletedBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "users",
+		Subsystem: "avatar_reaper",
+		Name:      "deleted_bytes_total",
+		Help:      "Bytes freed by deleted objects.",
+	})
+
+	reaperRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "users",
+		Subsystem: "avatar_reaper",
+		Name:      "runs_total",
+		Help:      "Reaper passes by result (ok/error).",
+	}, []string{"result"})
+
+	reaperLastSuccess = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "users",
+		Subsystem: "avatar_reaper",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful pass.",
+	})
+)
+
+// ReapStats — итоги одного прохода уборки.
+//   - Scanned: просмотрено объектов под avatars/;
+//   - Kept: оставлено (используется профилем, не истёк grace/retention, чужой формат ключа);
+//   - Unconfirmed/Replaced: выбрано к удалению по соответствующей причине;
+//   - Deleted/Bytes: фактически удалено (в dry-run — 0).
+type ReapStats struct {
+	Scanned     int
+	Kept        int
+	Unconfirmed int
+	Replaced    int
+	Deleted     int
+	Bytes       int64
+}
+
+// StartAvatarReaper запускает периодическую уборку бакета с интервалом cfg.Reaper.Interval.
+// Первый проход выполняется сразу. Блокирует до отмены ctx; ошибки прохода логируются.
+func (s *Service) StartAvatarReaper(ctx context.Context) {
+	const op = "service/reaper/StartAvatarReaper"
+
+	lg := log.From(ctx).With("op", op)
+	lg.Info("avatar_reaper_start",
+		"interval", s.cfg.Reaper.Interval,
+		"grace", s.cfg.Reaper.Grace,
+		"retention", s.cfg.Reaper.Retention,
+		"dry_run", s.cfg.Reaper.DryRun,
+	)
+
+	ticker := time.NewTicker(s.cfg.Reaper.Interval)
+	defer ticker.Stop()
+
+	for {
+		stats, err := s.ReapAvatarsOnce(ctx, time.Now().UTC())
+		if err != nil {
+			lg.Warn("avatar_reaper_tick_error", "err", err)
+		} else {
+			lg.Info("avatar_reaper_tick",
+				"scanned", stats.Scanned,
+				"kept", stats.Kept,
+				"unconfirmed", stats.Unconfirmed,
+				"replaced", stats.Replaced,
+				"deleted", stats.Deleted,
+				"bytes", stats.Bytes,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			lg.Info("avatar_reaper_stop")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReapAvatarsOnce — один проход уборки объектов под avatars/ на момент now.
+//
+// Объект (оригинал или превью — сравнение идёт по storage.AvatarStem) удаляется, если:
+//   - он относится к заменённому/удалённому аватару и с момента замены прошло не меньше Retention;
+//   - на него не ссылается ни профиль, ни replaced_avatars (неподтверждённая загрузка),
+//     и с момента записи прошло не меньше Grace.
+//
+// Объекты обрабатываются пачками по cfg.Reaper.BatchSize: один запрос ссылок на пачку,
+// одно пакетное удаление. Ключи нераспознанного формата не трогаются.
+// Записи replaced_avatars забываются в конце прохода, после удаления всех их объектов.
+// В dry-run кандидаты только считаются и логируются.
+func (s *Service) ReapAvatarsOnce(ctx context.Context, now time.Time) (ReapStats, error) {
+	const op = "service/reaper/ReapAvatarsOnce"
+
+	lg := log.From(ctx).With("op", op)
+	cfg := s.cfg.Reaper
+
+	var stats ReapStats
+	batch := make([]storage.AvatarObject, 0, cfg.BatchSize)
+	forget := make(map[string]struct{})
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		defer func() { batch = batch[:0] }()
+
+		owners := make([]uuid.UUID, 0, len(batch))
+		seen := make(map[uuid.UUID]struct{}, len(batch))
+		for _, obj := range batch {
+			if uid, ok := storage.AvatarOwner(obj.Key); ok {
+				if _, dup := seen[uid]; !dup {
+					seen[uid] = struct{}{}
+					owners = append(owners, uid)
+				}
+			}
+		}
+
+		refs, err := s.profilesStorage.AvatarRefs(ctx, owners)
+		if err != nil {
+			return err
+		}
+
+		var keys []string
+		var bytes int64
+		var unconfirmed, replaced int
+		var replacedKeys []string
+		for _, obj := range batch {
+			reason, replacedKey := classifyAvatarObject(obj, refs, now, cfg.Grace, cfg.Retention)
+			switch reason {
+			case reapReasonUnconfirmed:
+				unconfirmed++
+			case reapReasonReplaced:
+				replaced++
+				replacedKeys = append(replacedKeys, replacedKey)
+			default:
+				stats.Kept++
+				continue
+			}
+
+			reaperCandidates.WithLabelValues(reason).Inc()
+			keys = append(keys, obj.Key)
+			bytes += obj.Size
+
+			lg.Debug("avatar_reaper_candidate", "key", obj.Key, "reason", reason, "dry_run", cfg.DryRun)
+		}
+
+		stats.Unconfirmed += unconfirmed
+		stats.Replaced += replaced
+
+		if cfg.DryRun || len(keys) == 0 {
+			return nil
+		}
+
+		if err := s.avatarsStorage.DeleteAvatarObjects(ctx, keys); err != nil {
+			return err
+		}
+
+		stats.Deleted += len(keys)
+		stats.Bytes += bytes
+		reaperDeletedObjects.WithLabelValues(reapReasonUnconfirmed).Add(float64(unconfirmed))
+		reaperDeletedObjects.WithLabelValues(reapReasonReplaced).Add(float64(replaced))
+		reaperDeletedBytes.Add(float64(bytes))
+
+		for _, k := range replacedKeys {
+			forget[k] = struct{}{}
+		}
+
+		return nil
+	}
+
+	err := s.avatarsStorage.ListAvatarObjects(ctx, func(obj storage.AvatarObject) error {
+		stats.Scanned++
+		batch = append(batch, obj)
+		if len(batch) >= cfg.BatchSize {
+			return flush()
+		}
+
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+
+	if err == nil && len(forget) > 0 {
+		keys := make([]string, 0, len(forget))
+		for k := range forget {
+			keys = append(keys, k)
+		}
+
+		err = s.profilesStorage.ForgetReplacedAvatars(ctx, keys)
+	}
+
+	if err != nil {
+		reaperRuns.WithLabelValues("error").Inc()
+		lg.Error("avatar reaper pass failed", "err", err)
+
+		return stats, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	reaperRuns.WithLabelValues("ok").Inc()
+	reaperLastSuccess.Set(float64(now.Unix()))
+
+	return stats, nil
+}
+
+// classifyAvatarObject возвращает причину удаления объекта ("" — объект остаётся)
+// и, для заменённых аватаров, ключ записи replaced_avatars.
+func classifyAvatarObject(obj storage.AvatarObject, refs map[uuid.UUID]storage.AvatarRefs, now time.Time, grace, retention time.Duration) (string, string) {
+	uid, ok := storage.AvatarOwner(obj.Key)
+	if !ok {
+		return "", ""
+	}
+
+	stem := storage.AvatarStem(obj.Key)
+	ref := refs[uid]
+
+	if ref.Current != "" && storage.AvatarStem(ref.Current) == stem {
+		return "", ""
+	}
+
+	for key, at := range ref.Replaced {
+		if storage.AvatarStem(key) != stem {
+			continue
+		}
+
+		if now.Sub(at) >= retention {
+			return reapReasonReplaced, key
+		}
+
+		return "", ""
+	}
+
+	if now.Sub(obj.LastModified) >= grace {
+		return reapReasonUnconfirmed, ""
+	}
+
+	return "", ""
+}
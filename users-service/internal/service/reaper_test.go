@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/users-service/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func newReaperWithMocks(t *testing.T, dryRun bool, batch int) (*Service, *mocks.MockProfilesStorage, *mocks.MockAvatarsStorage) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mp := mocks.NewMockProfilesStorage(ctrl)
+	ma := mocks.NewMockAvatarsStorage(ctrl)
+	cfg := &config.Config{Reaper: config.ReaperConfig{
+		DryRun:    dryRun,
+		Grace:     24 * time.Hour,
+		Retention: 7 * 24 * time.Hour,
+		BatchSize: batch,
+	}}
+
+	return New(mp, ma, cfg), mp, ma
+}
+
+// listObjects — подставляет фиксированный набор объектов в ListAvatarObjects.
+func listObjects(objs []storage.AvatarObject) func(context.Context, func(storage.AvatarObject) error) error {
+	return func(_ context.Context, fn func(storage.AvatarObject) error) error {
+		for _, o := range objs {
+			if err := fn(o); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// reaperFixture — набор объектов одного пользователя на момент now:
+// текущий аватар cur (+превью), давно заменённый old (+превью), недавно заменённый fresh,
+// старая неподтверждённая загрузка stale, свежая неподтверждённая загрузка pending,
+// объект пользователя без профиля ghost и ключ нераспознанного формата.
+func reaperFixture(now time.Time) (uuid.UUID, []storage.AvatarObject, map[uuid.UUID]storage.AvatarRefs) {
+	uid := uuid.New()
+	ghost := uuid.New()
+	p := "avatars/" + uid.String() + "/"
+	old := now.Add(-30 * 24 * time.Hour)
+
+	objs := []storage.AvatarObject{
+		{Key: p + "cur.png", Size: 10, LastModified: old},
+		{Key: p + "cur_64.png", Size: 1, LastModified: old},
+		{Key: p + "old.jpg", Size: 20, LastModified: old},
+		{Key: p + "old_64.png", Size: 2, LastModified: old},
+		{Key: p + "fresh.png", Size: 30, LastModified: old},
+		{Key: p + "stale.png", Size: 40, LastModified: now.Add(-48 * time.Hour)},
+		{Key: p + "pending.png", Size: 50, LastModified: now.Add(-time.Hour)},
+		{Key: "avatars/" + ghost.String() + "/x.png", Size: 60, LastModified: old},
+		{Key: "avatars/readme.txt", Size: 70, LastModified: old},
+	}
+
+	refs := map[uuid.UUID]storage.AvatarRefs{
+		uid: {
+			Current: p + "cur.png",
+			Replaced: map[string]time.Time{
+				p + "old.jpg":   now.Add(-8 * 24 * time.Hour),
+				p + "fresh.png": now.Add(-time.Hour),
+			},
+		},
+	}
+
+	return uid, objs, refs
+}
+
+func TestService_ReapAvatarsOnce_DeletesExpired(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	uid, objs, refs := reaperFixture(now)
+	p := "avatars/" + uid.String() + "/"
+
+	s, mp, ma := newReaperWithMocks(t, false, 100)
+
+	ma.EXPECT().ListAvatarObjects(gomock.Any(), gomock.Any()).DoAndReturn(listObjects(objs))
+	mp.EXPECT().AvatarRefs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, ids []uuid.UUID) (map[uuid.UUID]storage.AvatarRefs, error) {
+			require.Len(t, ids, 2, "owners are deduplicated")
+			return refs, nil
+		})
+	ma.EXPECT().DeleteAvatarObjects(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, keys []string) error {
+			require.ElementsMatch(t, []string{
+				p + "old.jpg", p + "old_64.png", p + "stale.png",
+				objs[7].Key,
+			}, keys)
+			return nil
+		})
+	mp.EXPECT().ForgetReplacedAvatars(gomock.Any(), []string{p + "old.jpg"}).Return(nil)
+
+	stats, err := s.ReapAvatarsOnce(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, ReapStats{Scanned: 9, Kept: 5, Unconfirmed: 2, Replaced: 2, Deleted: 4, Bytes: 20 + 2 + 40 + 60}, stats)
+}
+
+func TestService_ReapAvatarsOnce_DryRun(t *testing.T) {
+	now := time.Now().UTC()
+	_, objs, refs := reaperFixture(now)
+
+	s, mp, ma := newReaperWithMocks(t, true, 4)
+
+	ma.EXPECT().ListAvatarObjects(gomock.Any(), gomock.Any()).DoAndReturn(listObjects(objs))
+	mp.EXPECT().AvatarRefs(gomock.Any(), gomock.Any()).Return(refs, nil).Times(3) // 4 + 4 + 1
+	// DeleteAvatarObjects/ForgetReplacedAvatars в dry-run не вызываются.
+
+	stats, err := s.ReapAvatarsOnce(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, 9, stats.Scanned)
+	require.Equal(t, 2, stats.Unconfirmed)
+	require.Equal(t, 2, stats.Replaced)
+	require.Zero(t, stats.Deleted)
+	require.Zero(t, stats.Bytes)
+}
+
+func TestService_ReapAvatarsOnce_Errors(t *testing.T) {
+	now := time.Now().UTC()
+	_, objs, refs := reaperFixture(now)
+
+	t.Run("refs", func(t *testing.T) {
+		s, mp, ma := newReaperWithMocks(t, false, 100)
+		ma.EXPECT().ListAvatarObjects(gomock.Any(), gomock.Any()).DoAndReturn(listObjects(objs))
+		mp.EXPECT().AvatarRefs(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+
+		_, err := s.ReapAvatarsOnce(context.Background(), now)
+		require.ErrorIs(t, err, ErrInternal)
+	})
+
+	t.Run("delete: replaced rows are kept", func(t *testing.T) {
+		s, mp, ma := newReaperWithMocks(t, false, 100)
+		ma.EXPECT().ListAvatarObjects(gomock.Any(), gomock.Any()).DoAndReturn(listObjects(objs))
+		mp.EXPECT().AvatarRefs(gomock.Any(), gomock.Any()).Return(refs, nil)
+		ma.EXPECT().DeleteAvatarObjects(gomock.Any(), gomock.Any()).Return(errors.New("s3 down"))
+
+		_, err := s.ReapAvatarsOnce(context.Background(), now)
+		require.ErrorIs(t, err, ErrInternal)
+	})
+}
@@ -1,12 +1,19 @@
 // service содержит бизнес-логику users-сервиса:
 // - операции над профилем (чтение/создание/частичный апдейт);
-// - работа с аватарами (выдача presigned URL и подтверждение загрузки).
+// - политика username (уникальность, резерв, похожие символы) и поиск по префиксу (usernames.go);
+// - работа с аватарами (выдача presigned URL, подтверждение загрузки, удаление);
+// - подписки между пользователями (follows.go);
+// - предпочтения читателя (preferences.go) и закладки (bookmarks.go);
+// - фоновая уборка осиротевших объектов аватаров (reaper.go).
 package service
 
 import (
+	"context"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
 )
 
@@ -17,15 +24,24 @@ var (
 	ErrNotFound = errors.New("not found")
 	// ErrAlreadyExists — конфликт уникальности/дубликат.
 	ErrAlreadyExists = errors.New("already exists")
+	// ErrInvalidCursor — некорректный page_token.
+	ErrInvalidCursor = errors.New("invalid cursor")
 	// ErrInternal — внутренняя ошибка сервиса.
 	ErrInternal = errors.New("internal")
 )
 
+// NewsSource — источник новостей для гидрации закладок (news-service).
+type NewsSource interface {
+	// NewsByIDs возвращает найденные новости по id; отсутствующих id в ответе нет.
+	NewsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]models.News, error)
+}
+
 // Service — описывает бизнес-логику users-service.
 type Service struct {
 	cfg             *config.Config
 	profilesStorage storage.ProfilesStorage
 	avatarsStorage  storage.AvatarsStorage
+	news            NewsSource
 }
 
 // New создает новый экземпляр Service.
@@ -36,3 +52,9 @@ func New(profilesStorage storage.ProfilesStorage, avatarsStorage storage.Avatars
 		cfg:             cfg,
 	}
 }
+
+// SetNewsSource подключает news-service для гидрации закладок (опционально):
+// без него закладки отдаются без новостей.
+func (s *Service) SetNewsSource(news NewsSource) {
+	s.news = news
+}
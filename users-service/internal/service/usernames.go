@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/pkg/log"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/usernames"
+)
+
+// Причины недоступности username (UsernameAvailability.Reason).
+const (
+	UsernameInvalid  = "invalid"
+	UsernameReserved = "reserved"
+	UsernameTaken    = "taken"
+)
+
+// Границы выдачи SearchProfiles.
+const (
+	defaultSearchProfilesLimit = 10
+	maxSearchProfilesLimit     = 50
+)
+
+// UsernameAvailability — результат CheckUsernameAvailability.
+//   - Username: username после нормализации (пусто для UsernameInvalid);
+//   - Reason: одна из констант Username* ("" при Available=true).
+type UsernameAvailability struct {
+	Username  string
+	Available bool
+	Reason    string
+}
+
+// normalizeUsername нормализует username (usernames.Normalize) и проверяет его
+// по списку cfg.Username.Reserved (сравнение по канонической форме).
+// Возвращает нормализованное значение и причину отказа ("" — username допустим).
+func (s *Service) normalizeUsername(raw string) (string, string) {
+	name, err := usernames.Normalize(raw)
+	if err != nil {
+		return "", UsernameInvalid
+	}
+
+	key := usernames.Canonical(name)
+	for _, r := range s.cfg.Username.Reserved {
+		if usernames.Canonical(r) == key {
+			return name, UsernameReserved
+		}
+	}
+
+	return name, ""
+}
+
+// CheckUsernameAvailability проверяет, может ли пользователь занять username.
+//
+// Поведение:
+//   - недопустимый или зарезервированный username -> Available=false с причиной;
+//   - username занят другим профилем (по канонической форме) -> UsernameTaken;
+//   - username, уже принадлежащий userID (в любом регистре/написании), считается доступным;
+//     userID необязателен (uuid.Nil — проверка до регистрации);
+//   - ошибки стораджа маппятся в ErrInternal.
+func (s *Service) CheckUsernameAvailability(ctx context.Context, username string, userID uuid.UUID) (*UsernameAvailability, error) {
+	const op = "service/usernames/CheckUsernameAvailability"
+	lg := log.From(ctx).With("op", op)
+
+	name, reason := s.normalizeUsername(username)
+	if reason != "" {
+		return &UsernameAvailability{Username: name, Reason: reason}, nil
+	}
+
+	owner, err := s.profilesStorage.ProfileByUsername(ctx, name)
+	switch {
+	case errors.Is(err, storage.ErrNotFoundProfile):
+		return &UsernameAvailability{Username: name, Available: true}, nil
+	case err != nil:
+		lg.Error("storage error on ProfileByUsername", "err", err)
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	if userID != uuid.Nil && owner.UserID == userID {
+		return &UsernameAvailability{Username: name, Available: true}, nil
+	}
+
+	return &UsernameAvailability{Username: name, Reason: UsernameTaken}, nil
+}
+
+// SearchProfiles — префиксный поиск профилей по username (автодополнение @упоминаний).
+//
+// Валидация:
+//   - query: ведущий '@' отбрасывается, остаток проверяется как username (usernames.Normalize);
+//   - limit: 0 -> defaultSearchProfilesLimit, больше maxSearchProfilesLimit -> обрезается,
+//     отрицательный -> ErrInvalidArgument.
+//
+// Сравнение идёт по канонической форме, поэтому "adm" найдёт и "Admin", и "аdmin".
+// Профили возвращаются в представлении для viewer (см. models.Profile.RedactFor).
+func (s *Service) SearchProfiles(ctx context.Context, query string, limit int, viewer models.Viewer) ([]models.Profile, error) {
+	const op = "service/usernames/SearchProfiles"
+	lg := log.From(ctx).With("op", op)
+
+	prefix, err := usernames.Normalize(strings.TrimPrefix(strings.TrimSpace(query), "@"))
+	if err != nil || limit < 0 {
+		lg.Warn("invalid search query", "limit", limit)
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	switch {
+	case limit == 0:
+		limit = defaultSearchProfilesLimit
+	case limit > maxSearchProfilesLimit:
+		limit = maxSearchProfilesLimit
+	}
+
+	result, err := s.profilesStorage.SearchProfiles(ctx, prefix, limit)
+	if err != nil {
+		lg.Error("storage error on SearchProfiles", "err", err)
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	for i := range result {
+		result[i] = result[i].RedactFor(viewer)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/config"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/users-service/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func newServiceWithReserved(t *testing.T, reserved ...string) (*Service, *mocks.MockProfilesStorage) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mp := mocks.NewMockProfilesStorage(ctrl)
+	cfg := &config.Config{Username: config.UsernameConfig{Reserved: reserved}}
+
+	return New(mp, mocks.NewMockAvatarsStorage(ctrl), cfg), mp
+}
+
+func TestService_CreateProfile_ReservedAndInvalidUsername(t *testing.T) {
+	s, _ := newServiceWithReserved(t, "admin")
+
+	for _, name := range []string{"Admin", "аdmin" /* кириллическая а */, "adm1n", "bad name", ".dot"} {
+		_, err := s.CreateProfile(context.Background(), CreateProfileInput{UserID: uuid.New(), Username: name})
+		require.ErrorIs(t, err, ErrInvalidArgument, name)
+	}
+}
+
+func TestService_UpdateProfile_UsernameTaken(t *testing.T) {
+	s, mp := newServiceWithReserved(t, "admin")
+	uid := uuid.New()
+
+	reserved := "ADMIN"
+	_, err := s.UpdateProfile(context.Background(), UpdateProfileInput{UserID: uid, Username: &reserved})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	name := "bob"
+	mp.EXPECT().UpdateProfile(gomock.Any(), uid, gomock.Any()).Return(nil, storage.ErrAlreadyExists)
+	_, err = s.UpdateProfile(context.Background(), UpdateProfileInput{UserID: uid, Username: &name})
+	require.ErrorIs(t, err, ErrAlreadyExists)
+}
+
+func TestService_CheckUsernameAvailability(t *testing.T) {
+	s, mp := newServiceWithReserved(t, "support")
+	me, other := uuid.New(), uuid.New()
+
+	res, err := s.CheckUsernameAvailability(context.Background(), "  ", uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, UsernameAvailability{Reason: UsernameInvalid}, *res)
+
+	res, err = s.CheckUsernameAvailability(context.Background(), "Support", uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, UsernameAvailability{Username: "Support", Reason: UsernameReserved}, *res)
+
+	mp.EXPECT().ProfileByUsername(gomock.Any(), "alice").Return(nil, storage.ErrNotFoundProfile)
+	res, err = s.CheckUsernameAvailability(context.Background(), " alice ", uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, UsernameAvailability{Username: "alice", Available: true}, *res)
+
+	mp.EXPECT().ProfileByUsername(gomock.Any(), "Bob").Return(&models.Profile{UserID: other, Username: "bob"}, nil)
+	res, err = s.CheckUsernameAvailability(context.Background(), "Bob", me)
+	require.NoError(t, err)
+	require.Equal(t, UsernameAvailability{Username: "Bob", Reason: UsernameTaken}, *res)
+
+	// Смена регистра собственного username допустима.
+	mp.EXPECT().ProfileByUsername(gomock.Any(), "Bob").Return(&models.Profile{UserID: me, Username: "bob"}, nil)
+	res, err = s.CheckUsernameAvailability(context.Background(), "Bob", me)
+	require.NoError(t, err)
+	require.True(t, res.Available)
+
+	mp.EXPECT().ProfileByUsername(gomock.Any(), "carol").Return(nil, errors.New("db down"))
+	_, err = s.CheckUsernameAvailability(context.Background(), "carol", me)
+	require.ErrorIs(t, err, ErrInternal)
+}
+
+func TestService_SearchProfiles(t *testing.T) {
+	s, mp := newServiceWithReserved(t)
+
+	for _, q := range []string{"", "@", "with space"} {
+		_, err := s.SearchProfiles(context.Background(), q, 5, models.Viewer{})
+		require.ErrorIs(t, err, ErrInvalidArgument, q)
+	}
+
+	_, err := s.SearchProfiles(context.Background(), "al", -1, models.Viewer{})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	want := []models.Profile{{UserID: uuid.New(), Username: "alice"}}
+	mp.EXPECT().SearchProfiles(gomock.Any(), "al", defaultSearchProfilesLimit).Return(want, nil)
+	got, err := s.SearchProfiles(context.Background(), "@al", 0, models.Viewer{})
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	mp.EXPECT().SearchProfiles(gomock.Any(), "al", maxSearchProfilesLimit).Return(nil, nil)
+	_, err = s.SearchProfiles(context.Background(), "al", 1000, models.Viewer{})
+	require.NoError(t, err)
+
+	mp.EXPECT().SearchProfiles(gomock.Any(), "al", 5).Return(nil, errors.New("db down"))
+	_, err = s.SearchProfiles(context.Background(), "al", 5, models.Viewer{})
+	require.ErrorIs(t, err, ErrInternal)
+}
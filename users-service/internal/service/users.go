@@ -10,6 +10,7 @@ import (
 	"github.com/pribylovaa/go-news-aggregator/pkg/log"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/usernames"
 )
 
 // Входные структуры сервисного слоя.
@@ -27,8 +28,11 @@ type UpdateProfileInput struct {
 	Age      *uint32
 	Country  *string
 	Gender   *models.Gender
+	// Privacy — новая видимость атрибутов: ключ ("age", "gender", "country") -> значение.
+	Privacy map[string]models.Visibility
 	// Mask — список обновляемых полей. Поддерживаются:
-	// "username", "age", "country", "gender".
+	// "username", "age", "country", "gender",
+	// "privacy.age", "privacy.gender", "privacy.country".
 	// Если пусто — обновятся только поля, для которых заданы указатели,
 	// иначе — для каждого поля из mask указатель обязателен (иначе ErrInvalidArgument).
 	Mask []string
@@ -45,15 +49,19 @@ type ConfirmAvatarUploadInput struct {
 	AvatarKey string
 }
 
-// ProfileByID возвращает профиль по идентификатору пользователя.
+// maxResolveUsernames — верхняя граница числа username в одном запросе ResolveUsernames.
+const maxResolveUsernames = 100
+
+// ProfileByID возвращает профиль по идентификатору пользователя в представлении для viewer.
 //
 // Валидация:
 //   - userID не должен быть нулевым (uuid.Nil) — иначе ErrInvalidArgument.
 //
 // Поведение:
 //   - при отсутствии записи возвращает ErrNotFound;
+//   - скрытые для viewer атрибуты обнуляются (см. models.Profile.RedactFor);
 //   - ошибки стораджа/БД/контекста маппятся в ErrInternal.
-func (s *Service) ProfileByID(ctx context.Context, userID uuid.UUID) (*models.Profile, error) {
+func (s *Service) ProfileByID(ctx context.Context, userID uuid.UUID, viewer models.Viewer) (*models.Profile, error) {
 	const op = "service/users/ProfileByID"
 
 	lg := log.From(ctx).With("op", op, "user_id", userID.String())
@@ -78,18 +86,81 @@ func (s *Service) ProfileByID(ctx context.Context, userID uuid.UUID) (*models.Pr
 		}
 	}
 
-	return result, nil
+	redacted := result.RedactFor(viewer)
+
+	return &redacted, nil
+}
+
+// ProfilesByIDs возвращает профили для набора пользователей (одним запросом к стораджу).
+//
+// Валидация:
+//   - список не пуст, не содержит uuid.Nil и после удаления дубликатов
+//     не превышает cfg.Limits.MaxProfilesBatch — иначе ErrInvalidArgument.
+//
+// Поведение:
+//   - found — профили по user_id в представлении для viewer;
+//     missing — ненайденные user_id в порядке запроса;
+//   - ошибки стораджа маппятся в ErrInternal.
+func (s *Service) ProfilesByIDs(ctx context.Context, userIDs []uuid.UUID, viewer models.Viewer) (map[uuid.UUID]models.Profile, []uuid.UUID, error) {
+	const op = "service/users/ProfilesByIDs"
+	lg := log.From(ctx).With("op", op)
+
+	seen := make(map[uuid.UUID]struct{}, len(userIDs))
+	unique := make([]uuid.UUID, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id == uuid.Nil {
+			lg.Warn("invalid argument: nil user_id in batch")
+
+			return nil, nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+		}
+
+		if _, dup := seen[id]; dup {
+			continue
+		}
+
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+
+	if len(unique) == 0 || len(unique) > s.cfg.Limits.MaxProfilesBatch {
+		lg.Warn("invalid argument: batch size", "count", len(unique))
+
+		return nil, nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	profiles, err := s.profilesStorage.ProfilesByIDs(ctx, unique)
+	if err != nil {
+		lg.Error("storage error on ProfilesByIDs", "err", err)
+
+		return nil, nil, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	found := make(map[uuid.UUID]models.Profile, len(profiles))
+	for _, p := range profiles {
+		found[p.UserID] = p.RedactFor(viewer)
+	}
+
+	var missing []uuid.UUID
+	for _, id := range unique {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	return found, missing, nil
 }
 
 // CreateProfile создаёт новый профиль пользователя.
 //
 // Валидация:
 //   - userID обязателен (uuid.Nil -> ErrInvalidArgument);
-//   - username нормализуется (TrimSpace) и не должен быть пустым;
+//   - username нормализуется и проверяется (см. normalizeUsername): недопустимый
+//     или зарезервированный username -> ErrInvalidArgument;
 //   - gender должен входить в допустимый диапазон [GenderUnspecified..GenderOther].
 //
 // Поведение:
-//   - при конфликте уникальности возвращает ErrAlreadyExists;
+//   - при конфликте уникальности (user_id или username по канонической форме)
+//     возвращает ErrAlreadyExists;
 //   - иные ошибки стораджа маппятся в ErrInternal.
 //
 // Возвращает:
@@ -104,13 +175,13 @@ func (s *Service) CreateProfile(ctx context.Context, input CreateProfileInput) (
 		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
 	}
 
-	input.Username = strings.TrimSpace(input.Username)
-
-	if input.Username == "" {
-		lg.Warn("invalid argument: empty username")
+	username, reason := s.normalizeUsername(input.Username)
+	if reason != "" {
+		lg.Warn("invalid argument: username rejected", "reason", reason)
 
 		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
 	}
+	input.Username = username
 
 	if input.Gender < models.GenderUnspecified || input.Gender > models.GenderOther {
 		lg.Warn("invalid argument: gender out of range", "gender", input.Gender)
@@ -146,14 +217,18 @@ func (s *Service) CreateProfile(ctx context.Context, input CreateProfileInput) (
 // UpdateProfile выполняет частичное обновление полей профиля.
 //
 // Маска/правила:
-//   - поддерживаются пути: "username", "age", "country", "gender";
-//   - если mask пуст — обновляются все поля, для которых переданы непустые указатели;
-//   - если mask непуст — для каждого поля из mask указатель обязателен, иначе ErrInvalidArgument;
-//   - username при обновлении также нормализуется и не может быть пустым (TrimSpace == "").
+//   - поддерживаются пути: "username", "age", "country", "gender" и
+//     "privacy.age", "privacy.gender", "privacy.country";
+//   - если mask пуст — обновляются все поля, для которых переданы непустые указатели
+//     (и все ключи Privacy);
+//   - если mask непуст — для каждого поля из mask значение обязательно, иначе ErrInvalidArgument;
+//   - username при обновлении проверяется так же, как при создании (см. normalizeUsername);
+//   - видимость должна входить в [VisibilityPublic..VisibilityPrivate].
 //
 // Поведение:
 //   - no-op (пустой апдейт) допустим — updated_at всё равно увеличится на уровне БД;
 //   - при отсутствии записи возвращает ErrNotFound;
+//   - если новый username занят другим профилем — ErrAlreadyExists;
 //   - все прочие ошибки стораджа маппятся в ErrInternal.
 func (s *Service) UpdateProfile(ctx context.Context, input UpdateProfileInput) (*models.Profile, error) {
 	const op = "service/users/UpdateProfile"
@@ -167,10 +242,13 @@ func (s *Service) UpdateProfile(ctx context.Context, input UpdateProfileInput) (
 	}
 
 	allowed := map[string]struct{}{
-		"username": {},
-		"age":      {},
-		"country":  {},
-		"gender":   {},
+		"username":        {},
+		"age":             {},
+		"country":         {},
+		"gender":          {},
+		"privacy.age":     {},
+		"privacy.gender":  {},
+		"privacy.country": {},
 	}
 
 	for _, f := range input.Mask {
@@ -200,10 +278,9 @@ func (s *Service) UpdateProfile(ctx context.Context, input UpdateProfileInput) (
 	// username.
 	if useField("username") {
 		if input.Username != nil {
-			val := strings.TrimSpace(*input.Username)
-
-			if val == "" {
-				lg.Warn("invalid argument: empty username in update")
+			val, reason := s.normalizeUsername(*input.Username)
+			if reason != "" {
+				lg.Warn("invalid argument: username rejected in update", "reason", reason)
 
 				return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
 			}
@@ -256,6 +333,43 @@ func (s *Service) UpdateProfile(ctx context.Context, input UpdateProfileInput) (
 		}
 	}
 
+	// privacy.<field>: значение из input.Privacy; ключи вне списка — ErrInvalidArgument.
+	for field := range input.Privacy {
+		if _, ok := allowed["privacy."+field]; !ok {
+			lg.Warn("invalid privacy field", "field", field)
+
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+		}
+	}
+
+	for _, field := range []string{"age", "gender", "country"} {
+		if !useField("privacy." + field) {
+			continue
+		}
+
+		v, ok := input.Privacy[field]
+		if !ok {
+			if len(input.Mask) > 0 {
+				lg.Warn("mask requires privacy value but it is missing", "field", field)
+
+				return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+			}
+
+			continue
+		}
+
+		if v < models.VisibilityPublic || v > models.VisibilityPrivate {
+			lg.Warn("invalid argument: visibility out of range", "field", field, "visibility", v)
+
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+		}
+
+		if upd.Privacy == nil {
+			upd.Privacy = make(map[string]models.Visibility, 3)
+		}
+		upd.Privacy[field] = v
+	}
+
 	result, err := s.profilesStorage.UpdateProfile(ctx, input.UserID, upd)
 	if err != nil {
 		switch {
@@ -263,6 +377,10 @@ func (s *Service) UpdateProfile(ctx context.Context, input UpdateProfileInput) (
 			lg.Warn("profile not found")
 
 			return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
+		case errors.Is(err, storage.ErrAlreadyExists):
+			lg.Warn("username already taken")
+
+			return nil, fmt.Errorf("%s: %w", op, ErrAlreadyExists)
 		default:
 			lg.Error("storage error", "err", err)
 
@@ -317,9 +435,10 @@ func (s *Service) AvatarUploadURL(ctx context.Context, input AvatarUploadURLInpu
 // ConfirmAvatarUpload подтверждает успешную загрузку аватара и фиксирует атрибуты в профиле.
 //
 // Процесс:
-//  1. в storage.AvatarsStorage проверяется ключ (принадлежность userID, наличие),
-//     соответствие ограничениям типа/размера; при успехе формируется публичный URL (если сконфигурирован);
-//  2. в storage.ProfilesStorage выполняется апдейт записи профиля (avatar_key/url + updated_at).
+//  1. storage.AvatarsStorage проверяет ключ (принадлежность userID, наличие, размер),
+//     определяет реальный формат по содержимому, отклоняет слишком большие по пикселям изображения,
+//     перекодирует оригинал без метаданных и сохраняет превью (cfg.Avatar.Sizes);
+//  2. в storage.ProfilesStorage выполняется апдейт записи профиля (avatar_key/url/превью + updated_at).
 //
 // Валидация:
 //   - userID обязателен; avatarKey не пуст.
@@ -339,7 +458,7 @@ func (s *Service) ConfirmAvatarUpload(ctx context.Context, input ConfirmAvatarUp
 		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
 	}
 
-	publicURL, err := s.avatarsStorage.CheckAvatarUpload(ctx, input.UserID, input.AvatarKey)
+	processed, err := s.avatarsStorage.ProcessAvatarUpload(ctx, input.UserID, input.AvatarKey)
 	if err != nil {
 		switch {
 		case errors.Is(err, storage.ErrInvalidArgument):
@@ -351,13 +470,13 @@ func (s *Service) ConfirmAvatarUpload(ctx context.Context, input ConfirmAvatarUp
 
 			return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
 		default:
-			lg.Error("storage error on CheckAvatarUpload", "err", err)
+			lg.Error("storage error on ProcessAvatarUpload", "err", err)
 
 			return nil, fmt.Errorf("%s: %w", op, ErrInternal)
 		}
 	}
 
-	result, err := s.profilesStorage.ConfirmAvatarUpload(ctx, input.UserID, input.AvatarKey, publicURL)
+	result, err := s.profilesStorage.ConfirmAvatarUpload(ctx, input.UserID, input.AvatarKey, processed.URL, processed.Variants)
 	if err != nil {
 		switch {
 		case errors.Is(err, storage.ErrNotFoundProfile):
@@ -373,3 +492,98 @@ func (s *Service) ConfirmAvatarUpload(ctx context.Context, input ConfirmAvatarUp
 
 	return result, nil
 }
+
+// DeleteAvatar сбрасывает аватар пользователя к аватару по умолчанию.
+// Объекты в бакете сразу не удаляются: прежний ключ помечается заменённым
+// и убирается фоновой уборкой по истечении cfg.Reaper.Retention (см. reaper.go).
+//
+// Поведение/ошибки:
+//   - ErrInvalidArgument — userID == uuid.Nil;
+//   - ErrNotFound — профиль отсутствует;
+//   - ErrInternal — прочие ошибки стораджа.
+func (s *Service) DeleteAvatar(ctx context.Context, userID uuid.UUID) (*models.Profile, error) {
+	const op = "service/users/DeleteAvatar"
+
+	lg := log.From(ctx).With("op", op, "user_id", userID.String())
+
+	if userID == uuid.Nil {
+		lg.Warn("invalid user_id")
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	result, err := s.profilesStorage.DeleteAvatar(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFoundProfile):
+			lg.Warn("profile not found on delete avatar")
+
+			return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
+		default:
+			lg.Error("storage error on DeleteAvatar", "err", err)
+
+			return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+		}
+	}
+
+	return result, nil
+}
+
+// ResolveUsernames разрешает набор username в идентификаторы пользователей.
+//
+// Валидация:
+//   - usernames нормализуются (TrimSpace, lower), пустые значения и дубликаты отбрасываются;
+//   - после нормализации список не должен быть пустым и не должен превышать maxResolveUsernames.
+//
+// Поведение:
+//   - ключи результата — запрошенные username в нижнем регистре; ненайденные отсутствуют в map;
+//   - сопоставление — по канонической форме (usernames.Canonical), как при регистрации:
+//     "Admin" и "аdmin" разрешаются во владельца имени "admin";
+//   - ошибки стораджа маппятся в ErrInternal.
+func (s *Service) ResolveUsernames(ctx context.Context, names []string) (map[string]uuid.UUID, error) {
+	const op = "service/users/ResolveUsernames"
+	lg := log.From(ctx).With("op", op)
+
+	seen := make(map[string]struct{}, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, u := range names {
+		u = strings.ToLower(strings.TrimSpace(u))
+		if u == "" {
+			continue
+		}
+
+		if _, ok := seen[u]; ok {
+			continue
+		}
+
+		seen[u] = struct{}{}
+		normalized = append(normalized, u)
+	}
+
+	if len(normalized) == 0 || len(normalized) > maxResolveUsernames {
+		lg.Warn("invalid argument: usernames count", "count", len(normalized))
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidArgument)
+	}
+
+	profiles, err := s.profilesStorage.ProfilesByUsernames(ctx, normalized)
+	if err != nil {
+		lg.Error("storage error on ProfilesByUsernames", "err", err)
+
+		return nil, fmt.Errorf("%s: %w", op, ErrInternal)
+	}
+
+	byKey := make(map[string]uuid.UUID, len(profiles))
+	for _, p := range profiles {
+		byKey[usernames.Canonical(p.Username)] = p.UserID
+	}
+
+	result := make(map[string]uuid.UUID, len(normalized))
+	for _, u := range normalized {
+		if id, ok := byKey[usernames.Canonical(u)]; ok {
+			result[u] = id
+		}
+	}
+
+	return result, nil
+}
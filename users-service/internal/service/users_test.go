@@ -58,7 +58,7 @@ func TestService_ProfileByID_InvalidArgument(t *testing.T) {
 	s, _, _, ctrl := newServiceWithMocks(t)
 	defer ctrl.Finish()
 
-	_, err := s.ProfileByID(context.Background(), uuid.Nil)
+	_, err := s.ProfileByID(context.Background(), uuid.Nil, models.Viewer{})
 	require.ErrorIs(t, err, ErrInvalidArgument)
 }
 
@@ -70,7 +70,7 @@ func TestService_ProfileByID_NotFound(t *testing.T) {
 	uid := uuid.New()
 	mp.EXPECT().ProfileByID(gomock.Any(), uid).Return(nil, storage.ErrNotFoundProfile)
 
-	_, err := s.ProfileByID(context.Background(), uid)
+	_, err := s.ProfileByID(context.Background(), uid, models.Viewer{UserID: uid})
 	require.ErrorIs(t, err, ErrNotFound)
 }
 
@@ -83,11 +83,51 @@ func TestService_ProfileByID_OK(t *testing.T) {
 	want := mustProfile(uid, "alice")
 	mp.EXPECT().ProfileByID(gomock.Any(), uid).Return(want, nil)
 
-	got, err := s.ProfileByID(context.Background(), uid)
+	got, err := s.ProfileByID(context.Background(), uid, models.Viewer{UserID: uid})
 	require.NoError(t, err)
 	require.Equal(t, want, got)
 }
 
+// Приватность: владелец и admin видят всё, registered/public атрибуты — по статусу зрителя.
+func TestService_ProfileByID_Redaction(t *testing.T) {
+	s, mp, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+	stored := mustProfile(uid, "alice")
+	stored.Privacy = models.Privacy{
+		Age:     models.VisibilityPrivate,
+		Gender:  models.VisibilityRegistered,
+		Country: models.VisibilityPublic,
+	}
+	mp.EXPECT().ProfileByID(gomock.Any(), uid).Return(stored, nil).Times(4)
+
+	owner, err := s.ProfileByID(context.Background(), uid, models.Viewer{UserID: uid})
+	require.NoError(t, err)
+	require.Equal(t, stored, owner)
+
+	admin, err := s.ProfileByID(context.Background(), uid, models.Viewer{UserID: uuid.New(), Admin: true})
+	require.NoError(t, err)
+	require.Equal(t, stored, admin)
+
+	registered, err := s.ProfileByID(context.Background(), uid, models.Viewer{UserID: uuid.New()})
+	require.NoError(t, err)
+	require.Zero(t, registered.Age)
+	require.Equal(t, models.GenderFemale, registered.Gender)
+	require.Equal(t, "LV", registered.Country)
+	require.Equal(t, models.Privacy{}, registered.Privacy)
+
+	anon, err := s.ProfileByID(context.Background(), uid, models.Viewer{})
+	require.NoError(t, err)
+	require.Zero(t, anon.Age)
+	require.Equal(t, models.GenderUnspecified, anon.Gender)
+	require.Equal(t, "LV", anon.Country)
+	require.Equal(t, "alice", anon.Username)
+
+	// Исходная модель стораджа не модифицируется.
+	require.Equal(t, uint32(21), stored.Age)
+}
+
 // Валидация: пустой userID, пустой username (после TrimSpace), неверный gender.
 func TestService_CreateProfile_ValidationErrors(t *testing.T) {
 	s, _, _, ctrl := newServiceWithMocks(t)
@@ -262,6 +302,43 @@ func TestService_UpdateProfile_Mask_CountryClearOK(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// privacy.*: по маске передаются только перечисленные ключи; неизвестные ключи
+// и значения вне диапазона -> ErrInvalidArgument.
+func TestService_UpdateProfile_Privacy(t *testing.T) {
+	s, mp, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+
+	for _, in := range []UpdateProfileInput{
+		{UserID: uid, Privacy: map[string]models.Visibility{"username": models.VisibilityPrivate}},
+		{UserID: uid, Privacy: map[string]models.Visibility{"age": models.Visibility(7)}},
+		{UserID: uid, Mask: []string{"privacy.age"}},
+		{UserID: uid, Mask: []string{"privacy.avatar"}, Privacy: map[string]models.Visibility{"age": 0}},
+	} {
+		_, err := s.UpdateProfile(context.Background(), in)
+		require.ErrorIs(t, err, ErrInvalidArgument)
+	}
+
+	mp.EXPECT().
+		UpdateProfile(gomock.Any(), uid, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, upd storage.ProfileUpdate) (*models.Profile, error) {
+			require.Equal(t, map[string]models.Visibility{"age": models.VisibilityPrivate}, upd.Privacy)
+			require.Nil(t, upd.Gender)
+			return mustProfile(uid, "john"), nil
+		})
+
+	_, err := s.UpdateProfile(context.Background(), UpdateProfileInput{
+		UserID: uid,
+		Mask:   []string{"privacy.age"},
+		Privacy: map[string]models.Visibility{
+			"age":     models.VisibilityPrivate,
+			"country": models.VisibilityRegistered,
+		},
+	})
+	require.NoError(t, err)
+}
+
 // Маппинг: storage.ErrNotFoundProfile -> ErrNotFound.
 func TestService_UpdateProfile_NotFound(t *testing.T) {
 	s, mp, _, ctrl := newServiceWithMocks(t)
@@ -424,11 +501,11 @@ func TestService_ConfirmAvatarUpload_AvatarsErrors(t *testing.T) {
 	uid := uuid.New()
 	key := "avatars/" + uid.String() + "/a.png"
 
-	ma.EXPECT().CheckAvatarUpload(gomock.Any(), uid, key).Return("", storage.ErrInvalidArgument)
+	ma.EXPECT().ProcessAvatarUpload(gomock.Any(), uid, key).Return(nil, storage.ErrInvalidArgument)
 	_, err := s.ConfirmAvatarUpload(context.Background(), ConfirmAvatarUploadInput{UserID: uid, AvatarKey: key})
 	require.ErrorIs(t, err, ErrInvalidArgument)
 
-	ma.EXPECT().CheckAvatarUpload(gomock.Any(), uid, key).Return("", storage.ErrNotFoundAvatar)
+	ma.EXPECT().ProcessAvatarUpload(gomock.Any(), uid, key).Return(nil, storage.ErrNotFoundAvatar)
 	_, err = s.ConfirmAvatarUpload(context.Background(), ConfirmAvatarUploadInput{UserID: uid, AvatarKey: key})
 	require.ErrorIs(t, err, ErrNotFound)
 }
@@ -441,7 +518,7 @@ func TestService_ConfirmAvatarUpload_AvatarsInternal(t *testing.T) {
 	uid := uuid.New()
 	key := "avatars/" + uid.String() + "/a.png"
 
-	ma.EXPECT().CheckAvatarUpload(gomock.Any(), uid, key).Return("", errors.New("s3 down"))
+	ma.EXPECT().ProcessAvatarUpload(gomock.Any(), uid, key).Return(nil, errors.New("s3 down"))
 	_, err := s.ConfirmAvatarUpload(context.Background(), ConfirmAvatarUploadInput{UserID: uid, AvatarKey: key})
 	require.ErrorIs(t, err, ErrInternal)
 }
@@ -454,8 +531,9 @@ func TestService_ConfirmAvatarUpload_ProfileNotFound(t *testing.T) {
 	uid := uuid.New()
 	key := "avatars/" + uid.String() + "/a.png"
 	public := "http://cdn/a.png"
-	ma.EXPECT().CheckAvatarUpload(gomock.Any(), uid, key).Return(public, nil)
-	mp.EXPECT().ConfirmAvatarUpload(gomock.Any(), uid, key, public).Return(nil, storage.ErrNotFoundProfile)
+	variants := []models.AvatarVariant{{Size: 64, Key: "avatars/" + uid.String() + "/a_64.png", URL: "http://cdn/a_64.png"}}
+	ma.EXPECT().ProcessAvatarUpload(gomock.Any(), uid, key).Return(&storage.ProcessedAvatar{URL: public, Variants: variants}, nil)
+	mp.EXPECT().ConfirmAvatarUpload(gomock.Any(), uid, key, public, variants).Return(nil, storage.ErrNotFoundProfile)
 
 	_, err := s.ConfirmAvatarUpload(context.Background(), ConfirmAvatarUploadInput{UserID: uid, AvatarKey: key})
 	require.ErrorIs(t, err, ErrNotFound)
@@ -469,8 +547,9 @@ func TestService_ConfirmAvatarUpload_ProfileInternal(t *testing.T) {
 	uid := uuid.New()
 	key := "avatars/" + uid.String() + "/a.png"
 	public := "http://cdn/a.png"
-	ma.EXPECT().CheckAvatarUpload(gomock.Any(), uid, key).Return(public, nil)
-	mp.EXPECT().ConfirmAvatarUpload(gomock.Any(), uid, key, public).Return(nil, errors.New("pg down"))
+	variants := []models.AvatarVariant{{Size: 64, Key: "avatars/" + uid.String() + "/a_64.png", URL: "http://cdn/a_64.png"}}
+	ma.EXPECT().ProcessAvatarUpload(gomock.Any(), uid, key).Return(&storage.ProcessedAvatar{URL: public, Variants: variants}, nil)
+	mp.EXPECT().ConfirmAvatarUpload(gomock.Any(), uid, key, public, variants).Return(nil, errors.New("pg down"))
 
 	_, err := s.ConfirmAvatarUpload(context.Background(), ConfirmAvatarUploadInput{UserID: uid, AvatarKey: key})
 	require.ErrorIs(t, err, ErrInternal)
@@ -487,11 +566,108 @@ func TestService_ConfirmAvatarUpload_OK(t *testing.T) {
 	want := mustProfile(uid, "z")
 	want.AvatarKey = key
 	want.AvatarURL = public
+	variants := []models.AvatarVariant{{Size: 64, Key: "avatars/" + uid.String() + "/a_64.png", URL: "http://cdn/a_64.png"}}
+	want.AvatarVariants = variants
 
-	ma.EXPECT().CheckAvatarUpload(gomock.Any(), uid, key).Return(public, nil)
-	mp.EXPECT().ConfirmAvatarUpload(gomock.Any(), uid, key, public).Return(want, nil)
+	ma.EXPECT().ProcessAvatarUpload(gomock.Any(), uid, key).Return(&storage.ProcessedAvatar{URL: public, Variants: variants}, nil)
+	mp.EXPECT().ConfirmAvatarUpload(gomock.Any(), uid, key, public, variants).Return(want, nil)
 
 	got, err := s.ConfirmAvatarUpload(context.Background(), ConfirmAvatarUploadInput{UserID: uid, AvatarKey: key})
 	require.NoError(t, err)
 	require.Equal(t, want, got)
 }
+
+// Валидация: пустой список (в т.ч. после TrimSpace) и превышение лимита -> ErrInvalidArgument.
+func TestService_ResolveUsernames_Validation(t *testing.T) {
+	s, _, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := s.ResolveUsernames(context.Background(), nil)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = s.ResolveUsernames(context.Background(), []string{" ", ""})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	many := make([]string, 0, maxResolveUsernames+1)
+	for i := 0; i <= maxResolveUsernames; i++ {
+		many = append(many, uuid.NewString())
+	}
+	_, err = s.ResolveUsernames(context.Background(), many)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+// Нормализация (trim/lower/dedup) и сопоставление по канонической форме.
+func TestService_ResolveUsernames_OK(t *testing.T) {
+	s, mp, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	admin, bob := uuid.New(), uuid.New()
+	mp.EXPECT().ProfilesByUsernames(gomock.Any(), []string{"alice", "аdmin", "bob", "adm1n"}).Return([]models.Profile{
+		*mustProfile(admin, "admin"),
+		*mustProfile(bob, "bob"),
+	}, nil)
+
+	// "аdmin" (кириллическая «а») и "adm1n" — то же имя, что "admin".
+	got, err := s.ResolveUsernames(context.Background(), []string{" Alice ", "ALICE", "аdmin", "bob", "adm1n"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]uuid.UUID{"аdmin": admin, "adm1n": admin, "bob": bob}, got)
+}
+
+// Маппинг: ошибка стораджа -> ErrInternal.
+func TestService_ResolveUsernames_InternalError(t *testing.T) {
+	s, mp, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	mp.EXPECT().ProfilesByUsernames(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+
+	_, err := s.ResolveUsernames(context.Background(), []string{"alice"})
+	require.ErrorIs(t, err, ErrInternal)
+}
+
+func TestService_DeleteAvatar(t *testing.T) {
+	s, mp, _, ctrl := newServiceWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := s.DeleteAvatar(context.Background(), uuid.Nil)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	uid := uuid.New()
+	mp.EXPECT().DeleteAvatar(gomock.Any(), uid).Return(nil, storage.ErrNotFoundProfile)
+	_, err = s.DeleteAvatar(context.Background(), uid)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	mp.EXPECT().DeleteAvatar(gomock.Any(), uid).Return(nil, errors.New("db down"))
+	_, err = s.DeleteAvatar(context.Background(), uid)
+	require.ErrorIs(t, err, ErrInternal)
+
+	want := mustProfile(uid, "u")
+	mp.EXPECT().DeleteAvatar(gomock.Any(), uid).Return(want, nil)
+	got, err := s.DeleteAvatar(context.Background(), uid)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestService_ProfilesByIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mp := mocks.NewMockProfilesStorage(ctrl)
+	s := New(mp, mocks.NewMockAvatarsStorage(ctrl), &config.Config{Limits: config.LimitsConfig{MaxProfilesBatch: 3}})
+
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	for _, ids := range [][]uuid.UUID{nil, {a, uuid.Nil}, {a, b, c, d}} {
+		_, _, err := s.ProfilesByIDs(context.Background(), ids, models.Viewer{})
+		require.ErrorIs(t, err, ErrInvalidArgument)
+	}
+
+	// Дубликаты схлопываются до лимита; missing — в порядке запроса.
+	mp.EXPECT().ProfilesByIDs(gomock.Any(), []uuid.UUID{c, a, b}).Return([]models.Profile{*mustProfile(a, "a")}, nil)
+	found, missing, err := s.ProfilesByIDs(context.Background(), []uuid.UUID{c, a, a, b}, models.Viewer{})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "a", found[a].Username)
+	require.Equal(t, []uuid.UUID{c, b}, missing)
+
+	mp.EXPECT().ProfilesByIDs(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+	_, _, err = s.ProfilesByIDs(context.Background(), []uuid.UUID{a}, models.Viewer{})
+	require.ErrorIs(t, err, ErrInternal)
+}
@@ -3,9 +3,13 @@ package storage
 import (
 	"context"
 	"errors"
+	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
 )
 
 var (
@@ -27,16 +31,74 @@ type UploadInfo struct {
 	RequiredHeader map[string]string
 }
 
+// ProcessedAvatar — результат серверной обработки загруженного аватара.
+//   - URL: публичный URL перекодированного оригинала (пусто, если PublicBaseURL не задан);
+//   - Variants: превью фиксированных размеров (по возрастанию Size).
+type ProcessedAvatar struct {
+	URL      string
+	Variants []models.AvatarVariant
+}
+
+// AvatarObject — объект бакета под префиксом avatars/ (для фоновой уборки).
+type AvatarObject struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
 // Avatars — контракт генерации presigned URL и подтверждения факта загрузки.
 type Avatars interface {
 	// AvatarUploadURL генерирует presigned PUT. Внутри — валидация contentType и contentLength.
 	AvatarUploadURL(ctx context.Context, userID uuid.UUID, contentType string, contentLength int64) (*UploadInfo, error)
-	// CheckAvatarUpload - проверяет факт загрузки по key (наличие, тип, размер).
-	// Возвращает публичный URL (если сконфигурирован PublicBaseURL) и финальный key.
-	CheckAvatarUpload(ctx context.Context, userID uuid.UUID, key string) (publicURL string, err error)
+	// ProcessAvatarUpload подтверждает загрузку по key: скачивает объект, определяет реальный формат,
+	// отклоняет decompression bomb, перекодирует оригинал без метаданных (на месте)
+	// и сохраняет превью под производными ключами (см. VariantKey).
+	// Ошибки: ErrInvalidArgument (чужой ключ, размер, формат, размеры), ErrNotFoundAvatar.
+	ProcessAvatarUpload(ctx context.Context, userID uuid.UUID, key string) (*ProcessedAvatar, error)
+	// ListAvatarObjects обходит объекты под префиксом avatars/ и вызывает fn для каждого.
+	// Ошибка fn прерывает обход и возвращается как есть.
+	ListAvatarObjects(ctx context.Context, fn func(AvatarObject) error) error
+	// DeleteAvatarObjects удаляет объекты по ключам; отсутствующие ключи ошибкой не считаются.
+	DeleteAvatarObjects(ctx context.Context, keys []string) error
 }
 
 // AvatarsStorage — алиас-обёртка для внедрения зависимости.
 type AvatarsStorage interface {
 	Avatars
 }
+
+// AvatarStem возвращает общую часть ключей оригинала и его превью —
+// "avatars/<userID>/<uuid>" без расширения и суффикса размера.
+func AvatarStem(key string) string {
+	stem := strings.TrimSuffix(key, path.Ext(key))
+	if i := strings.LastIndexByte(stem, '_'); i > 0 {
+		if _, err := strconv.Atoi(stem[i+1:]); err == nil {
+			stem = stem[:i]
+		}
+	}
+
+	return stem
+}
+
+// AvatarOwner извлекает userID из ключа вида "avatars/<userID>/...".
+func AvatarOwner(key string) (uuid.UUID, bool) {
+	rest, ok := strings.CutPrefix(key, "avatars/")
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	id, _, ok := strings.Cut(rest, "/")
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	uid, err := uuid.Parse(id)
+
+	return uid, err == nil
+}
+
+// VariantKey возвращает ключ превью стороной size для оригинала key:
+// "avatars/<userID>/<uuid>.png" -> "avatars/<userID>/<uuid>_64.png".
+func VariantKey(key string, size int, ext string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "_" + strconv.Itoa(size) + ext
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+)
+
+// Bookmarks — контракт репозитория закладок читателя.
+// Хранилище знает только news_id; гидрация новостями — забота сервиса.
+type Bookmarks interface {
+	// AddBookmark сохраняет закладку (идемпотентно). Возвращает true, если она создана.
+	// Отсутствующий профиль -> ErrNotFoundProfile.
+	AddBookmark(ctx context.Context, userID, newsID uuid.UUID) (bool, error)
+	// RemoveBookmark удаляет закладку (идемпотентно). Возвращает true, если она была.
+	RemoveBookmark(ctx context.Context, userID, newsID uuid.UUID) (bool, error)
+	// ListBookmarks — закладки userID без News, сначала недавно сохранённые.
+	// При некорректном pageToken — ErrInvalidCursor.
+	ListBookmarks(ctx context.Context, userID uuid.UUID, pageSize int, pageToken string) (*models.BookmarksPage, error)
+}
@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+)
+
+// ErrInvalidCursor — битый/чужой page_token.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Follows — контракт репозитория подписок между пользователями.
+// Счётчики FollowersCount/FollowingCount профилей обновляются вместе с подпиской.
+type Follows interface {
+	// Follow подписывает follower на followee (идемпотентно).
+	// Возвращает true, если подписка создана (false — уже была).
+	// Отсутствующий профиль любой из сторон -> ErrNotFoundProfile.
+	Follow(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
+	// Unfollow удаляет подписку (идемпотентно). Возвращает true, если она была.
+	Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
+	// ListFollowers — подписчики userID, сначала новые подписки.
+	// При некорректном pageToken — ErrInvalidCursor.
+	ListFollowers(ctx context.Context, userID uuid.UUID, pageSize int, pageToken string) (*models.FollowsPage, error)
+	// ListFollowing — на кого подписан userID, сначала новые подписки.
+	// При некорректном pageToken — ErrInvalidCursor.
+	ListFollowing(ctx context.Context, userID uuid.UUID, pageSize int, pageToken string) (*models.FollowsPage, error)
+}
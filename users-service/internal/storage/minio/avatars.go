@@ -1,13 +1,19 @@
 package minio
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/google/uuid"
 	mclient "github.com/minio/minio-go/v7"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/imaging"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
 )
 
@@ -59,42 +65,167 @@ func (s *AvatarsStorage) AvatarUploadURL(ctx context.Context, userID uuid.UUID,
 	return info, nil
 }
 
-// CheckAvatarUpload подтверждает факт загрузки по key:
-// проверяет, что объект существует и удовлетворяет ограничениям размера/типа.
-// Возвращает публичный URL (если PublicBaseURL задан), иначе — пустую строку.
-func (s *AvatarsStorage) CheckAvatarUpload(ctx context.Context, userID uuid.UUID, key string) (publicURL string, err error) {
-	op := "storage/minio/avatars/CheckAvatarUpload"
+// ProcessAvatarUpload подтверждает и обрабатывает загрузку по key:
+//  1. ключ должен принадлежать userID, объект — существовать и укладываться в MaxSizeBytes;
+//  2. объект скачивается и обрабатывается internal/imaging: реальный формат определяется
+//     по содержимому и должен входить в AllowedContentTypes, число пикселей ограничено MaxPixels;
+//  3. перекодированный оригинал (без метаданных) перезаписывает объект key,
+//     превью сохраняются под VariantKey(key, size, ext).
+//
+// Публичные URL формируются, если задан PublicBaseURL, иначе остаются пустыми.
+func (s *AvatarsStorage) ProcessAvatarUpload(ctx context.Context, userID uuid.UUID, key string) (*storage.ProcessedAvatar, error) {
+	op := "storage/minio/avatars/ProcessAvatarUpload"
 
 	prefix := "avatars/" + userID.String() + "/"
 	if !strings.HasPrefix(key, prefix) {
-		return "", storage.ErrInvalidArgument
+		return nil, storage.ErrInvalidArgument
 	}
 
 	objInfo, err := s.client.StatObject(ctx, s.cfg.S3.Bucket, key, mclient.StatObjectOptions{})
 	if err != nil {
-		errResp := mclient.ToErrorResponse(err)
-		if errResp.Code == "NoSuchKey" || errResp.StatusCode == 404 {
-			return "", storage.ErrNotFoundAvatar
+		if isNotFound(err) {
+			return nil, storage.ErrNotFoundAvatar
 		}
 
-		return "", fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	if objInfo.Size <= 0 || objInfo.Size > s.cfg.Avatar.MaxSizeBytes {
-		return "", storage.ErrInvalidArgument
+		return nil, storage.ErrInvalidArgument
 	}
 
-	if ct := objInfo.ContentType; ct != "" && !isAllowedContentType(s.cfg.Avatar.AllowedContentTypes, ct) {
-		return "", storage.ErrInvalidArgument
+	obj, err := s.client.GetObject(ctx, s.cfg.S3.Bucket, key, mclient.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: get: %w", op, err)
 	}
+	defer obj.Close()
+
+	// Объект мог быть перезаписан после StatObject: читаем не больше лимита.
+	data, err := io.ReadAll(io.LimitReader(obj, s.cfg.Avatar.MaxSizeBytes+1))
+	if err != nil {
+		if isNotFound(err) {
+			return nil, storage.ErrNotFoundAvatar
+		}
+
+		return nil, fmt.Errorf("%s: read: %w", op, err)
+	}
+
+	if int64(len(data)) > s.cfg.Avatar.MaxSizeBytes {
+		return nil, storage.ErrInvalidArgument
+	}
+
+	res, err := imaging.Process(data, imaging.Options{
+		MaxPixels: s.cfg.Avatar.MaxPixels,
+		Sizes:     s.cfg.Avatar.Sizes,
+	})
+	if err != nil {
+		if errors.Is(err, imaging.ErrUnsupportedFormat) || errors.Is(err, imaging.ErrTooManyPixels) {
+			return nil, fmt.Errorf("%s: %v: %w", op, err, storage.ErrInvalidArgument)
+		}
 
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !isAllowedContentType(s.cfg.Avatar.AllowedContentTypes, res.ContentType) {
+		return nil, fmt.Errorf("%s: detected %s: %w", op, res.ContentType, storage.ErrInvalidArgument)
+	}
+
+	if err := s.put(ctx, key, res.ContentType, res.Original); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	out := &storage.ProcessedAvatar{URL: s.publicURL(key)}
+	for _, v := range res.Variants {
+		vkey := storage.VariantKey(key, v.Size, res.Ext)
+		if err := s.put(ctx, vkey, res.ContentType, v.Data); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		out.Variants = append(out.Variants, models.AvatarVariant{Size: v.Size, Key: vkey, URL: s.publicURL(vkey)})
+	}
+
+	sort.Slice(out.Variants, func(i, j int) bool { return out.Variants[i].Size < out.Variants[j].Size })
+
+	return out, nil
+}
+
+// ListAvatarObjects обходит объекты под префиксом avatars/ (рекурсивно).
+// Ошибка fn прерывает обход и возвращается как есть; листинг при этом отменяется.
+func (s *AvatarsStorage) ListAvatarObjects(ctx context.Context, fn func(storage.AvatarObject) error) error {
+	op := "storage/minio/avatars/ListAvatarObjects"
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objects := s.client.ListObjects(ctx, s.cfg.S3.Bucket, mclient.ListObjectsOptions{
+		Prefix:    "avatars/",
+		Recursive: true,
+	})
+
+	for obj := range objects {
+		if obj.Err != nil {
+			return fmt.Errorf("%s: %w", op, obj.Err)
+		}
+
+		if err := fn(storage.AvatarObject{Key: obj.Key, Size: obj.Size, LastModified: obj.LastModified}); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+// DeleteAvatarObjects удаляет объекты пакетно (RemoveObjects); отсутствующие ключи
+// ошибкой не считаются. Возвращается первая ошибка удаления.
+func (s *AvatarsStorage) DeleteAvatarObjects(ctx context.Context, keys []string) error {
+	op := "storage/minio/avatars/DeleteAvatarObjects"
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	objects := make(chan mclient.ObjectInfo, len(keys))
+	for _, key := range keys {
+		objects <- mclient.ObjectInfo{Key: key}
+	}
+	close(objects)
+
+	var firstErr error
+	for res := range s.client.RemoveObjects(ctx, s.cfg.S3.Bucket, objects, mclient.RemoveObjectsOptions{}) {
+		if res.Err != nil && !isNotFound(res.Err) && firstErr == nil {
+			firstErr = fmt.Errorf("%s: %s: %w", op, res.ObjectName, res.Err)
+		}
+	}
+
+	return firstErr
+}
+
+// put сохраняет объект целиком (аватары невелики, multipart не нужен).
+func (s *AvatarsStorage) put(ctx context.Context, key, contentType string, data []byte) error {
+	_, err := s.client.PutObject(ctx, s.cfg.S3.Bucket, key, bytes.NewReader(data), int64(len(data)), mclient.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// publicURL возвращает публичный URL объекта или пустую строку, если PublicBaseURL не задан.
+func (s *AvatarsStorage) publicURL(key string) string {
 	if s.cfg.S3.PublicBaseURL == "" {
-		return "", nil
+		return ""
 	}
 
-	base := strings.TrimRight(s.cfg.S3.PublicBaseURL, "/")
+	return strings.TrimRight(s.cfg.S3.PublicBaseURL, "/") + "/" + key
+}
+
+// isNotFound — объект отсутствует в бакете.
+func isNotFound(err error) bool {
+	errResp := mclient.ToErrorResponse(err)
 
-	return base + "/" + key, nil
+	return errResp.Code == "NoSuchKey" || errResp.StatusCode == 404
 }
 
 // isAllowedContentType проверяет, что тип содержимого входит в allow-list.
@@ -3,7 +3,8 @@
 // настраивает Secure/creds и проверяет наличие целевого бакета.
 // avatars.go — реализация методов Avatars поверх клиента MinIO:
 //   - генерация presigned PUT URL для загрузки аватара;
-//   - подтверждение загрузки (валидация факта, размера и типа).
+//   - подтверждение загрузки: проверка размера, определение реального формата,
+//     перекодирование без метаданных и генерация превью (internal/imaging).
 package minio
 
 import (
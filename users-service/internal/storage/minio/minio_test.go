@@ -3,7 +3,10 @@ package minio
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"image"
+	"image/png"
 	"net/http"
 	"net/url"
 	"os"
@@ -27,8 +30,8 @@ import (
 // — проверяют:
 //    New: успешное подключение и ошибку при отсутствии бакета;
 //    AvatarUploadURL: выдачу presigned PUT и валидации по типу/размеру;
-//    CheckAvatarUpload: подтверждение существующего объекта, сбор публичного URL,
-//    и ошибки на "чужой" ключ/несуществующий объект.
+//    ProcessAvatarUpload: перекодирование и превью, сбор публичных URL,
+//    и ошибки на "чужой" ключ/несуществующий объект/не-изображение.
 //
 // Запуск:
 //   GO_TEST_INTEGRATION=1 go test ./internal/storage/minio -v -race -count=1
@@ -86,6 +89,8 @@ func startMinio(t *testing.T, createBucket bool) (*AvatarsStorage, func(), strin
 		Avatar: config.AvatarConfig{
 			MaxSizeBytes:        1 << 20, // 1 MiB
 			AllowedContentTypes: []string{"image/png", "image/jpeg", "image/webp"},
+			MaxPixels:           1 << 20,
+			Sizes:               []int{64, 128},
 		},
 	}
 
@@ -108,7 +113,41 @@ func TestIntegration_New_BucketMustExist(t *testing.T) {
 	_, _, _ = startMinio(t, false)
 }
 
-func TestIntegration_AvatarUploadURL_And_CheckAvatarUpload_OK(t *testing.T) {
+// pngBody кодирует однотонный PNG размера w x h.
+func pngBody(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+// putPresigned загружает body по presigned URL, выданному AvatarUploadURL.
+func putPresigned(t *testing.T, st *AvatarsStorage, uid uuid.UUID, body []byte) *storage.UploadInfo {
+	t.Helper()
+
+	ui, err := st.AvatarUploadURL(context.Background(), uid, "image/png", int64(len(body)))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPut, ui.UploadURL, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "image/png")
+	req.ContentLength = int64(len(body))
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Less(t, resp.StatusCode, 300, "PUT must succeed")
+
+	return ui
+}
+
+func TestIntegration_AvatarUploadURL_And_ProcessAvatarUpload_OK(t *testing.T) {
 	st, cleanup, _ := startMinio(t, true)
 	defer cleanup()
 
@@ -124,19 +163,38 @@ func TestIntegration_AvatarUploadURL_And_CheckAvatarUpload_OK(t *testing.T) {
 	require.Equal(t, "image/png", ui.RequiredHeader["Content-Type"])
 	require.Equal(t, strconv.Itoa(bodySize), ui.RequiredHeader["Content-Length"])
 
-	body := bytes.Repeat([]byte{0x42}, bodySize)
-	req, err := http.NewRequest(http.MethodPut, ui.UploadURL, bytes.NewReader(body))
-	require.NoError(t, err)
-	req.Header.Set("Content-Type", "image/png")
-	req.ContentLength = int64(bodySize)
-	resp, err := http.DefaultClient.Do(req)
-	require.NoError(t, err)
-	_ = resp.Body.Close()
-	require.Less(t, resp.StatusCode, 300, "PUT must succeed")
+	ui = putPresigned(t, st, uid, pngBody(t, 300, 200))
 
-	public, err := st.CheckAvatarUpload(context.Background(), uid, ui.AvatarKey)
+	res, err := st.ProcessAvatarUpload(context.Background(), uid, ui.AvatarKey)
 	require.NoError(t, err)
-	require.Equal(t, "http://cdn.local/"+ui.AvatarKey, public)
+	require.Equal(t, "http://cdn.local/"+ui.AvatarKey, res.URL)
+	require.Len(t, res.Variants, 2)
+
+	for i, size := range []int{64, 128} {
+		v := res.Variants[i]
+		require.Equal(t, size, v.Size)
+		require.Equal(t, storage.VariantKey(ui.AvatarKey, size, ".png"), v.Key)
+		require.Equal(t, "http://cdn.local/"+v.Key, v.URL)
+
+		obj, err := st.client.GetObject(context.Background(), st.cfg.S3.Bucket, v.Key, mclient.GetObjectOptions{})
+		require.NoError(t, err)
+		img, err := png.Decode(obj)
+		_ = obj.Close()
+		require.NoError(t, err)
+		require.Equal(t, size, img.Bounds().Dx())
+		require.Equal(t, size, img.Bounds().Dy())
+	}
+}
+
+func TestIntegration_ProcessAvatarUpload_RejectsNonImage(t *testing.T) {
+	st, cleanup, _ := startMinio(t, true)
+	defer cleanup()
+
+	uid := uuid.New()
+	ui := putPresigned(t, st, uid, bytes.Repeat([]byte{0x42}, 64))
+
+	_, err := st.ProcessAvatarUpload(context.Background(), uid, ui.AvatarKey)
+	require.ErrorIs(t, err, storage.ErrInvalidArgument)
 }
 
 func TestIntegration_AvatarUploadURL_InvalidArgs(t *testing.T) {
@@ -154,7 +212,7 @@ func TestIntegration_AvatarUploadURL_InvalidArgs(t *testing.T) {
 	require.ErrorIs(t, err, storage.ErrInvalidArgument)
 }
 
-func TestIntegration_CheckAvatarUpload_Errors(t *testing.T) {
+func TestIntegration_ProcessAvatarUpload_Errors(t *testing.T) {
 	st, cleanup, _ := startMinio(t, true)
 	defer cleanup()
 
@@ -162,38 +220,27 @@ func TestIntegration_CheckAvatarUpload_Errors(t *testing.T) {
 	other := uuid.New()
 
 	// Ключ с "чужим" префиксом.
-	_, err := st.CheckAvatarUpload(context.Background(), uid, "avatars/"+other.String()+"/x.png")
+	_, err := st.ProcessAvatarUpload(context.Background(), uid, "avatars/"+other.String()+"/x.png")
 	require.Error(t, err)
 	require.ErrorIs(t, err, storage.ErrInvalidArgument)
 
 	// Не существует.
-	_, err = st.CheckAvatarUpload(context.Background(), uid, "avatars/"+uid.String()+"/missing.png")
+	_, err = st.ProcessAvatarUpload(context.Background(), uid, "avatars/"+uid.String()+"/missing.png")
 	require.Error(t, err)
 	require.ErrorIs(t, err, storage.ErrNotFoundAvatar)
 }
 
-func TestIntegration_CheckAvatarUpload_PublicBase_TrailingSlash_OK(t *testing.T) {
+func TestIntegration_ProcessAvatarUpload_PublicBase_TrailingSlash_OK(t *testing.T) {
 	st, cleanup, _ := startMinio(t, true)
 	defer cleanup()
 
 	uid := uuid.New()
-	ui, err := st.AvatarUploadURL(context.Background(), uid, "image/png", 1)
-	require.NoError(t, err)
-
-	// PUT 1 байт.
-	req, err := http.NewRequest(http.MethodPut, ui.UploadURL, bytes.NewReader([]byte{0x1}))
-	require.NoError(t, err)
-	req.Header.Set("Content-Type", "image/png")
-	req.ContentLength = 1
-	resp, err := http.DefaultClient.Do(req)
-	require.NoError(t, err)
-	_ = resp.Body.Close()
-	require.Less(t, resp.StatusCode, 300)
+	ui := putPresigned(t, st, uid, pngBody(t, 16, 16))
 
 	st.cfg.S3.PublicBaseURL = "http://cdn.local/"
-	public, err := st.CheckAvatarUpload(context.Background(), uid, ui.AvatarKey)
+	res, err := st.ProcessAvatarUpload(context.Background(), uid, ui.AvatarKey)
 	require.NoError(t, err)
-	require.Equal(t, "http://cdn.local/"+ui.AvatarKey, public)
+	require.Equal(t, "http://cdn.local/"+ui.AvatarKey, res.URL)
 }
 
 func TestIntegration_New_EndpointWithoutScheme_OK(t *testing.T) {
@@ -262,7 +309,7 @@ func TestIntegration_AvatarUploadURL_TTL_Expires(t *testing.T) {
 	require.GreaterOrEqual(t, resp.StatusCode, 400, "expired presigned URL must be rejected")
 }
 
-func TestIntegration_CheckAvatarUpload_SizeTooBig_AfterUpload(t *testing.T) {
+func TestIntegration_ProcessAvatarUpload_SizeTooBig_AfterUpload(t *testing.T) {
 	st, cleanup, _ := startMinio(t, true)
 	defer cleanup()
 
@@ -282,7 +329,45 @@ func TestIntegration_CheckAvatarUpload_SizeTooBig_AfterUpload(t *testing.T) {
 
 	st.cfg.Avatar.MaxSizeBytes = 4
 
-	_, err = st.CheckAvatarUpload(context.Background(), uid, ui.AvatarKey)
+	_, err = st.ProcessAvatarUpload(context.Background(), uid, ui.AvatarKey)
 	require.Error(t, err)
 	require.ErrorIs(t, err, storage.ErrInvalidArgument)
 }
+
+func TestIntegration_ListAndDeleteAvatarObjects(t *testing.T) {
+	st, cleanup, _ := startMinio(t, true)
+	defer cleanup()
+
+	ctx := context.Background()
+	uid := uuid.New()
+	ui := putPresigned(t, st, uid, pngBody(t, 100, 100))
+	res, err := st.ProcessAvatarUpload(ctx, uid, ui.AvatarKey)
+	require.NoError(t, err)
+
+	// Посторонний объект вне avatars/ в обход не попадает.
+	require.NoError(t, st.put(ctx, "other/file.txt", "text/plain", []byte("x")))
+
+	collect := func() []string {
+		var keys []string
+		require.NoError(t, st.ListAvatarObjects(ctx, func(o storage.AvatarObject) error {
+			require.Positive(t, o.Size)
+			require.False(t, o.LastModified.IsZero())
+			keys = append(keys, o.Key)
+			return nil
+		}))
+		return keys
+	}
+
+	want := []string{ui.AvatarKey}
+	for _, v := range res.Variants {
+		want = append(want, v.Key)
+	}
+	require.ElementsMatch(t, want, collect())
+
+	// Ошибка колбэка прерывает обход.
+	stop := errors.New("stop")
+	require.ErrorIs(t, st.ListAvatarObjects(ctx, func(storage.AvatarObject) error { return stop }), stop)
+
+	require.NoError(t, st.DeleteAvatarObjects(ctx, append(want, "avatars/"+uid.String()+"/missing.png")))
+	require.Empty(t, collect())
+}
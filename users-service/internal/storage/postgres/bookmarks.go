@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+)
+
+// AddBookmark сохраняет закладку; повторное сохранение — no-op (false).
+// Отсутствующий профиль (нарушение FK) — storage.ErrNotFoundProfile.
+func (s *ProfilesStorage) AddBookmark(ctx context.Context, userID, newsID uuid.UUID) (bool, error) {
+	const op = "storage/postgres/bookmarks/AddBookmark"
+
+	tag, err := s.db.Exec(ctx, `
+	INSERT INTO bookmarks (user_id, news_id) VALUES ($1, $2)
+	ON CONFLICT DO NOTHING`, userID, newsID)
+	if err != nil {
+		if pgErrCode(err) == "23503" {
+			return false, fmt.Errorf("%s: %w", op, storage.ErrNotFoundProfile)
+		}
+
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// RemoveBookmark удаляет закладку; отсутствующая закладка — no-op (false).
+func (s *ProfilesStorage) RemoveBookmark(ctx context.Context, userID, newsID uuid.UUID) (bool, error) {
+	const op = "storage/postgres/bookmarks/RemoveBookmark"
+
+	tag, err := s.db.Exec(ctx, `
+	DELETE FROM bookmarks WHERE user_id = $1 AND news_id = $2`, userID, newsID)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListBookmarks — keyset-выборка закладок userID по (created_at, news_id) DESC.
+// Курсор — тот же формат, что у подписок (encodeFollowCursor). Берётся pageSize+1 строк,
+// чтобы понять, есть ли следующая страница.
+func (s *ProfilesStorage) ListBookmarks(ctx context.Context, userID uuid.UUID, pageSize int, pageToken string) (*models.BookmarksPage, error) {
+	const op = "storage/postgres/bookmarks/ListBookmarks"
+
+	args := []any{userID}
+	after := ""
+
+	if strings.TrimSpace(pageToken) != "" {
+		t, id, err := decodeFollowCursor(pageToken)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrInvalidCursor)
+		}
+
+		after = " AND (created_at, news_id) < ($2, $3)"
+		args = append(args, t, id)
+	}
+
+	args = append(args, pageSize+1)
+	q := fmt.Sprintf(`
+	SELECT news_id, created_at
+	FROM bookmarks
+	WHERE user_id = $1%s
+	ORDER BY created_at DESC, news_id DESC
+	LIMIT $%d`, after, len(args))
+
+	rows, err := s.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	page := &models.BookmarksPage{}
+	for rows.Next() {
+		var b models.Bookmark
+		if err := rows.Scan(&b.NewsID, &b.SavedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		b.SavedAt = b.SavedAt.UTC()
+		page.Items = append(page.Items, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(page.Items) > pageSize {
+		page.Items = page.Items[:pageSize]
+		last := page.Items[len(page.Items)-1]
+		page.NextPageToken = encodeFollowCursor(last.SavedAt, last.NewsID)
+	}
+
+	return page, nil
+}
@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+)
+
+// Follow создаёт подписку и увеличивает счётчики обеих сторон в одной транзакции.
+// Повторная подписка — no-op (false). Отсутствующий профиль (нарушение FK) —
+// storage.ErrNotFoundProfile; подписка на себя (CHECK) — storage.ErrInvalidArgument.
+func (s *ProfilesStorage) Follow(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	const op = "storage/postgres/follows/Follow"
+
+	var created bool
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+		INSERT INTO follows (follower_id, followee_id) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`, followerID, followeeID)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+		created = true
+
+		return bumpFollowCounts(ctx, tx, followerID, followeeID, 1)
+	})
+	if err != nil {
+		switch pgErrCode(err) {
+		case "23503":
+			return false, fmt.Errorf("%s: %w", op, storage.ErrNotFoundProfile)
+		case "23514":
+			return false, fmt.Errorf("%s: %w", op, storage.ErrInvalidArgument)
+		}
+
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// Unfollow удаляет подписку и уменьшает счётчики обеих сторон в одной транзакции.
+// Отсутствующая подписка — no-op (false).
+func (s *ProfilesStorage) Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	const op = "storage/postgres/follows/Unfollow"
+
+	var removed bool
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+		DELETE FROM follows WHERE follower_id = $1 AND followee_id = $2`, followerID, followeeID)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+		removed = true
+
+		return bumpFollowCounts(ctx, tx, followerID, followeeID, -1)
+	})
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return removed, nil
+}
+
+// bumpFollowCounts сдвигает following_count подписчика и followers_count автора на delta
+// (не опускаясь ниже нуля).
+func bumpFollowCounts(ctx context.Context, tx pgx.Tx, followerID, followeeID uuid.UUID, delta int) error {
+	if _, err := tx.Exec(ctx, `
+	UPDATE profiles SET following_count = GREATEST(following_count + $2, 0) WHERE user_id = $1`,
+		followerID, delta); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, `
+	UPDATE profiles SET followers_count = GREATEST(followers_count + $2, 0) WHERE user_id = $1`,
+		followeeID, delta)
+
+	return err
+}
+
+// ListFollowers — подписчики userID с профилями, сначала новые подписки.
+// Курсор — (created_at, follower_id) последнего элемента, см. encodeFollowCursor.
+func (s *ProfilesStorage) ListFollowers(ctx context.Context, userID uuid.UUID, pageSize int, pageToken string) (*models.FollowsPage, error) {
+	const op = "storage/postgres/follows/ListFollowers"
+
+	page, err := s.listFollows(ctx, "followee_id", "follower_id", userID, pageSize, pageToken)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return page, nil
+}
+
+// ListFollowing — на кого подписан userID (с профилями), сначала новые подписки.
+// Курсор — (created_at, followee_id) последнего элемента, см. encodeFollowCursor.
+func (s *ProfilesStorage) ListFollowing(ctx context.Context, userID uuid.UUID, pageSize int, pageToken string) (*models.FollowsPage, error) {
+	const op = "storage/postgres/follows/ListFollowing"
+
+	page, err := s.listFollows(ctx, "follower_id", "followee_id", userID, pageSize, pageToken)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return page, nil
+}
+
+// listFollows — общая keyset-выборка: строки follows с self = userID, профили
+// второй стороны (other), сортировка created_at DESC, other DESC. Берётся
+// pageSize+1 строк, чтобы понять, есть ли следующая страница.
+func (s *ProfilesStorage) listFollows(ctx context.Context, self, other string, userID uuid.UUID, pageSize int, pageToken string) (*models.FollowsPage, error) {
+	args := []any{userID}
+	after := ""
+
+	if strings.TrimSpace(pageToken) != "" {
+		t, id, err := decodeFollowCursor(pageToken)
+		if err != nil {
+			return nil, storage.ErrInvalidCursor
+		}
+
+		after = fmt.Sprintf(" AND (created_at, %s) < ($2, $3)", other)
+		args = append(args, t, id)
+	}
+
+	args = append(args, pageSize+1)
+	q := fmt.Sprintf(`
+	SELECT %s, f.followed_at
+	FROM (
+		SELECT %s AS other_id, created_at AS followed_at
+		FROM follows
+		WHERE %s = $1%s
+		ORDER BY created_at DESC, %s DESC
+		LIMIT $%d
+	) f
+	JOIN profiles ON profiles.user_id = f.other_id
+	ORDER BY f.followed_at DESC, f.other_id DESC`,
+		profileColumns, other, self, after, other, len(args))
+
+	rows, err := s.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &models.FollowsPage{}
+	for rows.Next() {
+		var followedAt time.Time
+
+		p, err := scanProfile(rows, &followedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		page.Items = append(page.Items, models.FollowEntry{Profile: *p, FollowedAt: followedAt})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Items) > pageSize {
+		page.Items = page.Items[:pageSize]
+		last := page.Items[len(page.Items)-1]
+		page.NextPageToken = encodeFollowCursor(last.FollowedAt, last.Profile.UserID)
+	}
+
+	return page, nil
+}
+
+// encodeFollowCursor кодирует ключ последнего элемента страницы: "<unix_nano>|<uuid>" в base64url.
+func encodeFollowCursor(t time.Time, id uuid.UUID) string {
+	raw := strconv.FormatInt(t.UTC().UnixNano(), 10) + "|" + id.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeFollowCursor — обратное преобразование encodeFollowCursor.
+func decodeFollowCursor(token string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(token))
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+
+	nanos, rest, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, uuid.Nil, errors.New("bad cursor")
+	}
+
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+
+	id, err := uuid.Parse(rest)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+
+	return time.Unix(0, n).UTC(), id, nil
+}
+
+// pgErrCode возвращает SQLSTATE ошибки PostgreSQL ("" — не ошибка сервера БД).
+func pgErrCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+
+	return ""
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+)
+
+const preferencesColumns = `user_id, followed_categories, muted_categories, muted_sources, languages, updated_at`
+
+// PreferencesByID возвращает предпочтения пользователя. Пока запись не создана —
+// пустые предпочтения с нулевым UpdatedAt (существование профиля не проверяется).
+func (s *ProfilesStorage) PreferencesByID(ctx context.Context, userID uuid.UUID) (*models.Preferences, error) {
+	const op = "storage/postgres/preferences/PreferencesByID"
+
+	row := s.db.QueryRow(ctx, `SELECT `+preferencesColumns+` FROM preferences WHERE user_id = $1`, userID)
+
+	p, err := scanPreferences(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &models.Preferences{UserID: userID}, nil
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return p, nil
+}
+
+// UpdatePreferences — upsert: при первом вызове создаёт запись (непереданные списки
+// пустые), затем меняет только переданные списки; updated_at = now().
+// Отсутствующий профиль (нарушение FK) -> storage.ErrNotFoundProfile.
+func (s *ProfilesStorage) UpdatePreferences(ctx context.Context, userID uuid.UUID, update storage.PreferencesUpdate) (*models.Preferences, error) {
+	const op = "storage/postgres/preferences/UpdatePreferences"
+
+	cols := []string{"user_id"}
+	args := []any{userID}
+	sets := []string{"updated_at = now()"}
+
+	for _, f := range []struct {
+		col string
+		val *[]string
+	}{
+		{"followed_categories", update.FollowedCategories},
+		{"muted_categories", update.MutedCategories},
+		{"muted_sources", update.MutedSources},
+		{"languages", update.Languages},
+	} {
+		if f.val == nil {
+			continue
+		}
+
+		v := *f.val
+		if v == nil {
+			v = []string{}
+		}
+
+		cols = append(cols, f.col)
+		args = append(args, v)
+		sets = append(sets, fmt.Sprintf("%s = EXCLUDED.%s", f.col, f.col))
+	}
+
+	placeholders := make([]string, len(args))
+	for i := range args {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	q := fmt.Sprintf(`
+	INSERT INTO preferences (%s) VALUES (%s)
+	ON CONFLICT (user_id) DO UPDATE SET %s
+	RETURNING %s`,
+		strings.Join(cols, ", "), strings.Join(placeholders, ", "), strings.Join(sets, ", "), preferencesColumns)
+
+	p, err := scanPreferences(s.db.QueryRow(ctx, q, args...))
+	if err != nil {
+		if pgErrCode(err) == "23503" {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrNotFoundProfile)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return p, nil
+}
+
+// scanPreferences читает строку в порядке preferencesColumns.
+func scanPreferences(row pgx.Row) (*models.Preferences, error) {
+	var p models.Preferences
+	if err := row.Scan(
+		&p.UserID,
+		&p.FollowedCategories,
+		&p.MutedCategories,
+		&p.MutedSources,
+		&p.Languages,
+		&p.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	p.UpdatedAt = p.UpdatedAt.UTC()
+
+	return &p, nil
+}
@@ -2,32 +2,37 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/storage"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/usernames"
 )
 
 // profileColumns — единый список колонок таблицы profiles,
 // используемый в SELECT/RETURNING, чтобы гарантировать одинаковый порядок сканирования.
 const profileColumns = `
-user_id, username, age, country, gender, avatar_key, avatar_url, created_at, updated_at
+user_id, username, age, country, gender, avatar_key, avatar_url, avatar_variants, privacy,
+followers_count, following_count, created_at, updated_at
 `
 
 // scanProfile сканирует одну строку профиля из результата запроса
 // в доменную модель с корректными кастами типов (INT -> uint32, SMALLINT -> models.Gender).
-func scanProfile(row pgx.Row) (*models.Profile, error) {
+// extra — приёмники для колонок, выбранных после profileColumns.
+func scanProfile(row pgx.Row, extra ...any) (*models.Profile, error) {
 	var profile models.Profile
-	var age int32
+	var age, followers, following int32
 	var gender int16
 
-	if err := row.Scan(
+	dest := []any{
 		&profile.UserID,
 		&profile.Username,
 		&age,
@@ -35,12 +40,21 @@ func scanProfile(row pgx.Row) (*models.Profile, error) {
 		&gender,
 		&profile.AvatarKey,
 		&profile.AvatarURL,
+		&profile.AvatarVariants,
+		&profile.Privacy,
+		&followers,
+		&following,
 		&profile.CreatedAt,
 		&profile.UpdatedAt,
-	); err != nil {
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
 		return nil, err
 	}
 
+	profile.FollowersCount = uint32(max(followers, 0))
+	profile.FollowingCount = uint32(max(following, 0))
+
 	if age < 0 {
 		age = 0
 	}
@@ -51,14 +65,14 @@ func scanProfile(row pgx.Row) (*models.Profile, error) {
 	return &profile, nil
 }
 
-// CreateProfile вставляет новую запись профиля.
-// Ошибки: storage.ErrAlreadyExists при конфликте уникальности (PK/UNIQUE), иные — как есть.
+// CreateProfile вставляет новую запись профиля; username_key = usernames.Canonical(username).
+// Ошибки: storage.ErrAlreadyExists при конфликте уникальности (user_id или username_key), иные — как есть.
 func (s *ProfilesStorage) CreateProfile(ctx context.Context, profile *models.Profile) (*models.Profile, error) {
 	const op = "storage/postgres/profiles/CreateProfile"
 
 	q := `
-	INSERT INTO profiles (user_id, username, age, country, gender, avatar_key, avatar_url)
-	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	INSERT INTO profiles (user_id, username, age, country, gender, avatar_key, avatar_url, username_key)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	RETURNING 
 	` + profileColumns
 
@@ -75,12 +89,12 @@ func (s *ProfilesStorage) CreateProfile(ctx context.Context, profile *models.Pro
 		int16(profile.Gender),
 		profile.AvatarKey,
 		profile.AvatarURL,
+		usernames.Canonical(profile.Username),
 	)
 
 	result, err := scanProfile(row)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		if isUniqueViolation(err) {
 			return nil, fmt.Errorf("%s: %w", op, storage.ErrAlreadyExists)
 		}
 
@@ -111,9 +125,45 @@ func (s *ProfilesStorage) ProfileByID(ctx context.Context, userID uuid.UUID) (*m
 	return result, nil
 }
 
+// ProfilesByIDs возвращает профили по набору user_id (один запрос с ANY($1)).
+// Отсутствующие user_id пропускаются; порядок результата не определён.
+func (s *ProfilesStorage) ProfilesByIDs(ctx context.Context, userIDs []uuid.UUID) ([]models.Profile, error) {
+	const op = "storage/postgres/profiles/ProfilesByIDs"
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	q := `SELECT ` + profileColumns + ` FROM profiles WHERE user_id = ANY($1)`
+
+	rows, err := s.db.Query(ctx, q, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	result := make([]models.Profile, 0, len(userIDs))
+	for rows.Next() {
+		p, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		result = append(result, *p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
 // UpdateProfile выполняет частичный апдейт: обновляет только поля,
 // указанные непустыми pointer-полями, и всегда сдвигает updated_at = now().
-// Ошибки: storage.ErrNotFoundProfile при отсутствии записи.
+// Вместе с username пересчитывается username_key.
+// Ошибки: storage.ErrNotFoundProfile при отсутствии записи,
+// storage.ErrAlreadyExists — если новый username уже занят.
 func (s *ProfilesStorage) UpdateProfile(ctx context.Context, userID uuid.UUID, update storage.ProfileUpdate) (*models.Profile, error) {
 	const op = "storage/postgres/profiles/UpdateProfile"
 
@@ -123,6 +173,8 @@ func (s *ProfilesStorage) UpdateProfile(ctx context.Context, userID uuid.UUID, u
 	if update.Username != nil {
 		sets = append(sets, fmt.Sprintf("username = $%d", len(args)+1))
 		args = append(args, *update.Username)
+		sets = append(sets, fmt.Sprintf("username_key = $%d", len(args)+1))
+		args = append(args, usernames.Canonical(*update.Username))
 	}
 
 	if update.Age != nil {
@@ -144,6 +196,17 @@ func (s *ProfilesStorage) UpdateProfile(ctx context.Context, userID uuid.UUID, u
 		args = append(args, int16(*update.Gender))
 	}
 
+	// privacy сливается с текущим значением: ключи, не вошедшие в апдейт, не меняются.
+	if len(update.Privacy) > 0 {
+		raw, err := json.Marshal(update.Privacy)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		sets = append(sets, fmt.Sprintf("privacy = privacy || $%d::jsonb", len(args)+1))
+		args = append(args, string(raw))
+	}
+
 	idx := len(args) + 1
 	q := fmt.Sprintf(`UPDATE profiles SET %s WHERE user_id = $%d RETURNING %s`,
 		strings.Join(sets, ", "), idx, profileColumns)
@@ -156,28 +219,227 @@ func (s *ProfilesStorage) UpdateProfile(ctx context.Context, userID uuid.UUID, u
 			return nil, fmt.Errorf("%s: %w", op, storage.ErrNotFoundProfile)
 		}
 
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrAlreadyExists)
+		}
+
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return result, nil
 }
 
-// ConfirmAvatarUpload фиксирует avatar_key и (опционально) avatar_url
-// после успешной проверки объекта в S3/MinIO. Всегда обновляет updated_at.
+// ConfirmAvatarUpload фиксирует avatar_key, (опционально) avatar_url и превью (JSONB)
+// после успешной обработки объекта в S3/MinIO. Всегда обновляет updated_at.
+// Прежний avatar_key попадает в replaced_avatars (см. replaceAvatar).
 // Ошибки: storage.ErrNotFoundProfile при отсутствии записи.
-func (s *ProfilesStorage) ConfirmAvatarUpload(ctx context.Context, userID uuid.UUID, key, publicURL string) (*models.Profile, error) {
+func (s *ProfilesStorage) ConfirmAvatarUpload(ctx context.Context, userID uuid.UUID, key, publicURL string, variants []models.AvatarVariant) (*models.Profile, error) {
 	const op = "storage/postgres/profiles/ConfirmAvatarUpload"
 
-	q := `
-	UPDATE profiles 
-	SET avatar_key = $2, avatar_url = $3, updated_at = now()
-	WHERE user_id = $1
-	RETURNING
-	` + profileColumns
+	result, err := s.replaceAvatar(ctx, userID, key, publicURL, variants)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	row := s.db.QueryRow(ctx, q, userID, key, publicURL)
+	return result, nil
+}
 
-	result, err := scanProfile(row)
+// DeleteAvatar сбрасывает аватар профиля (avatar_key/avatar_url/avatar_variants),
+// прежний avatar_key попадает в replaced_avatars. Для профиля без аватара — no-op
+// (кроме updated_at).
+// Ошибки: storage.ErrNotFoundProfile при отсутствии записи.
+func (s *ProfilesStorage) DeleteAvatar(ctx context.Context, userID uuid.UUID) (*models.Profile, error) {
+	const op = "storage/postgres/profiles/DeleteAvatar"
+
+	result, err := s.replaceAvatar(ctx, userID, "", "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// replaceAvatar в одной транзакции:
+//  1. блокирует строку профиля и читает прежний avatar_key;
+//  2. записывает новые атрибуты аватара;
+//  3. запоминает прежний ключ в replaced_avatars (если он был и отличается от нового);
+//  4. убирает новый ключ из replaced_avatars — повторное подтверждение
+//     ранее заменённого объекта не должно приводить к его удалению.
+func (s *ProfilesStorage) replaceAvatar(ctx context.Context, userID uuid.UUID, key, publicURL string, variants []models.AvatarVariant) (*models.Profile, error) {
+	if variants == nil {
+		variants = []models.AvatarVariant{}
+	}
+
+	var result *models.Profile
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		var oldKey string
+		err := tx.QueryRow(ctx, `SELECT avatar_key FROM profiles WHERE user_id = $1 FOR UPDATE`, userID).Scan(&oldKey)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return storage.ErrNotFoundProfile
+			}
+
+			return err
+		}
+
+		q := `
+		UPDATE profiles 
+		SET avatar_key = $2, avatar_url = $3, avatar_variants = $4, updated_at = now()
+		WHERE user_id = $1
+		RETURNING
+		` + profileColumns
+
+		result, err = scanProfile(tx.QueryRow(ctx, q, userID, key, publicURL, variants))
+		if err != nil {
+			return err
+		}
+
+		if oldKey != "" && oldKey != key {
+			_, err = tx.Exec(ctx, `
+			INSERT INTO replaced_avatars (key, user_id) VALUES ($1, $2)
+			ON CONFLICT (key) DO UPDATE SET replaced_at = now()`, oldKey, userID)
+			if err != nil {
+				return err
+			}
+		}
+
+		if key != "" {
+			if _, err := tx.Exec(ctx, `DELETE FROM replaced_avatars WHERE key = $1`, key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AvatarRefs возвращает текущий и заменённые avatar_key для набора пользователей
+// (два запроса с ANY($1)). Пользователи без профиля в результат не попадают,
+// даже если для них остались записи в replaced_avatars.
+func (s *ProfilesStorage) AvatarRefs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]storage.AvatarRefs, error) {
+	const op = "storage/postgres/profiles/AvatarRefs"
+
+	result := make(map[uuid.UUID]storage.AvatarRefs, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT user_id, avatar_key FROM profiles WHERE user_id = ANY($1)`, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for rows.Next() {
+		var id uuid.UUID
+		var key string
+		if err := rows.Scan(&id, &key); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		result[id] = storage.AvatarRefs{Current: key, Replaced: map[string]time.Time{}}
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err = s.db.Query(ctx, `SELECT user_id, key, replaced_at FROM replaced_avatars WHERE user_id = ANY($1)`, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var key string
+		var at time.Time
+		if err := rows.Scan(&id, &key, &at); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if refs, ok := result[id]; ok {
+			refs.Replaced[key] = at
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// ForgetReplacedAvatars удаляет записи replaced_avatars по ключам.
+func (s *ProfilesStorage) ForgetReplacedAvatars(ctx context.Context, keys []string) error {
+	const op = "storage/postgres/profiles/ForgetReplacedAvatars"
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if _, err := s.db.Exec(ctx, `DELETE FROM replaced_avatars WHERE key = ANY($1)`, keys); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ProfilesByUsernames возвращает профили по списку username с учётом канонической
+// формы (usernames.Canonical): регистр и похожие символы не учитываются. Каждой
+// канонической форме соответствует не больше одного профиля (уникальный username_key).
+func (s *ProfilesStorage) ProfilesByUsernames(ctx context.Context, names []string) ([]models.Profile, error) {
+	const op = "storage/postgres/profiles/ProfilesByUsernames"
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(names))
+	for _, u := range names {
+		keys = append(keys, usernames.Canonical(u))
+	}
+
+	q := `SELECT ` + profileColumns + ` FROM profiles WHERE username_key = ANY($1)`
+
+	rows, err := s.db.Query(ctx, q, keys)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var result []models.Profile
+	for rows.Next() {
+		p, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		result = append(result, *p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// ProfileByUsername возвращает профиль, чей username совпадает с username
+// по канонической форме (регистр и похожие символы не учитываются).
+// Ошибки: storage.ErrNotFoundProfile, либо ошибка выполнения запроса.
+func (s *ProfilesStorage) ProfileByUsername(ctx context.Context, username string) (*models.Profile, error) {
+	const op = "storage/postgres/profiles/ProfileByUsername"
+
+	q := `SELECT ` + profileColumns + ` FROM profiles WHERE username_key = $1`
+
+	result, err := scanProfile(s.db.QueryRow(ctx, q, usernames.Canonical(username)))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%s: %w", op, storage.ErrNotFoundProfile)
@@ -188,3 +450,105 @@ func (s *ProfilesStorage) ConfirmAvatarUpload(ctx context.Context, userID uuid.U
 
 	return result, nil
 }
+
+// SearchProfiles возвращает до limit профилей, чья каноническая форма username
+// начинается с канонической формы prefix, в порядке username_key.
+// Использует индекс text_pattern_ops; спецсимволы LIKE в prefix экранируются.
+// Служебные ключи с '#' — временные 'legacy#<user_id>' и '<имя>#<user_id>' у проигравших
+// BackfillUsernameKeys — исключаются: username, за которым они стоят, принадлежит другому
+// профилю или ещё не закреплён ('#' в канонической форме не встречается).
+func (s *ProfilesStorage) SearchProfiles(ctx context.Context, prefix string, limit int) ([]models.Profile, error) {
+	const op = "storage/postgres/profiles/SearchProfiles"
+
+	pattern := likeEscaper.Replace(usernames.Canonical(prefix)) + "%"
+
+	q := `SELECT ` + profileColumns + ` FROM profiles WHERE username_key LIKE $1 ESCAPE '\' AND position('#' in username_key) = 0 ORDER BY username_key LIMIT $2`
+
+	rows, err := s.db.Query(ctx, q, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var result []models.Profile
+	for rows.Next() {
+		p, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		result = append(result, *p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// legacyKeyPattern — временные ключи, проставленные миграцией 4 существующим профилям.
+const legacyKeyPattern = `legacy#%`
+
+// BackfillUsernameKeys заменяет временные ключи миграции 4 на usernames.Canonical(username).
+// Профили обходятся от ранних к поздним: если каноническая форма уже занята (более ранним
+// профилем или новым, созданным после миграции), профиль получает ключ с суффиксом
+// '#<user_id>' — имя остаётся за первым владельцем. Идемпотентна; возвращает число обновлённых профилей.
+func (s *ProfilesStorage) BackfillUsernameKeys(ctx context.Context) (int, error) {
+	const op = "storage/postgres/profiles/BackfillUsernameKeys"
+
+	type legacy struct {
+		id       uuid.UUID
+		username string
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT user_id, username FROM profiles WHERE username_key LIKE $1 ORDER BY created_at ASC, user_id ASC`, legacyKeyPattern)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var pending []legacy
+	for rows.Next() {
+		var l legacy
+		if err := rows.Scan(&l.id, &l.username); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		pending = append(pending, l)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	const q = `UPDATE profiles SET username_key = $2 WHERE user_id = $1 AND username_key LIKE $3`
+
+	updated := 0
+	for _, l := range pending {
+		key := usernames.Canonical(l.username)
+
+		tag, err := s.db.Exec(ctx, q, l.id, key, legacyKeyPattern)
+		if isUniqueViolation(err) {
+			tag, err = s.db.Exec(ctx, q, l.id, key+"#"+l.id.String(), legacyKeyPattern)
+		}
+		if err != nil {
+			return updated, fmt.Errorf("%s: %w", op, err)
+		}
+
+		updated += int(tag.RowsAffected())
+	}
+
+	return updated, nil
+}
+
+// likeEscaper экранирует спецсимволы шаблона LIKE (ESCAPE '\').
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// isUniqueViolation — нарушение ограничения уникальности (SQLSTATE 23505).
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
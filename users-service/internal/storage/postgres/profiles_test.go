@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -26,7 +27,8 @@ import (
 //    CreateProfile: успешную вставку и ErrAlreadyExists при повторе PK;
 //    ProfileByID: успешный сценарий и ErrNotFoundProfile на отсутствующую запись;
 //    UpdateProfile: частичное обновление, инкремент updated_at, no-op при пустом апдейте (updated_at всё равно сдвигается);
-//    ConfirmAvatarUpload: фиксацию avatar_key/url и ErrNotFoundProfile, если записи нет;
+//    ConfirmAvatarUpload: фиксацию avatar_key/url/превью и ErrNotFoundProfile, если записи нет;
+//    BackfillUsernameKeys: канонические ключи legacy-профилей, закреплённые за самым ранним владельцем;
 //    поведение при истёкшем контексте (context deadline exceeded).
 //
 // Запуск локально:
@@ -40,6 +42,18 @@ func repoRootFromThisFile() string {
 	return filepath.Clean(filepath.Join(filepath.Dir(thisFile), "..", "..", ".."))
 }
 
+// migrations — up-миграции в порядке применения.
+var migrations = []string{
+	"1_init_profiles.up.sql",
+	"2_avatar_variants.up.sql",
+	"3_replaced_avatars.up.sql",
+	"4_username_key.up.sql",
+	"5_profile_privacy.up.sql",
+	"6_follows.up.sql",
+	"7_preferences.up.sql",
+	"8_bookmarks.up.sql",
+}
+
 // readMigration — читает содержимое SQL-миграции из подкаталога ./migrations.
 func readMigration(t *testing.T, name string) string {
 	t.Helper()
@@ -54,6 +68,13 @@ func readMigration(t *testing.T, name string) string {
 // применяет миграции users и возвращает инициализированное хранилище и функцию очистки.
 // Если переменная окружения GO_TEST_INTEGRATION не установлена — тест пропускается.
 func startPostgres(t *testing.T) (*ProfilesStorage, func()) {
+	t.Helper()
+	return startPostgresSeeded(t, nil)
+}
+
+// startPostgresSeeded — как startPostgres, но перед миграцией-ключом seeds
+// выполняет соответствующий SQL (данные «старой» схемы).
+func startPostgresSeeded(t *testing.T, seeds map[string]string) (*ProfilesStorage, func()) {
 	t.Helper()
 	if os.Getenv("GO_TEST_INTEGRATION") == "" {
 		t.Skip("integration tests are disabled (set GO_TEST_INTEGRATION=1)")
@@ -82,8 +103,14 @@ func startPostgres(t *testing.T) (*ProfilesStorage, func()) {
 	require.NoError(t, err)
 	defer pool.Close()
 
-	_, err = pool.Exec(ctx, readMigration(t, "1_init_profiles.up.sql"))
-	require.NoError(t, err)
+	for _, name := range migrations {
+		if seed, ok := seeds[name]; ok {
+			_, err = pool.Exec(ctx, seed)
+			require.NoError(t, err, "seed before %s", name)
+		}
+		_, err = pool.Exec(ctx, readMigration(t, name))
+		require.NoError(t, err, "apply migration %s", name)
+	}
 
 	st, err := New(ctx, dsn)
 	require.NoError(t, err)
@@ -197,6 +224,33 @@ func TestIntegration_UpdateProfile_Empty_NoOp_BumpsUpdatedAt(t *testing.T) {
 	require.True(t, got.UpdatedAt.After(orig.UpdatedAt), "updated_at must increase even on empty update")
 }
 
+func TestIntegration_UpdateProfile_PrivacyMerge(t *testing.T) {
+	st, cleanup := startPostgres(t)
+	defer cleanup()
+
+	uid := uuid.New()
+	orig, err := st.CreateProfile(context.Background(), &models.Profile{UserID: uid, Username: "shy"})
+	require.NoError(t, err)
+	require.Equal(t, models.Privacy{}, orig.Privacy)
+
+	got, err := st.UpdateProfile(context.Background(), uid, storage.ProfileUpdate{
+		Privacy: map[string]models.Visibility{"age": models.VisibilityPrivate, "country": models.VisibilityRegistered},
+	})
+	require.NoError(t, err)
+	require.Equal(t, models.Privacy{Age: models.VisibilityPrivate, Country: models.VisibilityRegistered}, got.Privacy)
+
+	// Ключи вне апдейта сохраняются; явный public перезаписывает прежнее значение.
+	got, err = st.UpdateProfile(context.Background(), uid, storage.ProfileUpdate{
+		Privacy: map[string]models.Visibility{"age": models.VisibilityPublic},
+	})
+	require.NoError(t, err)
+	require.Equal(t, models.Privacy{Country: models.VisibilityRegistered}, got.Privacy)
+
+	byID, err := st.ProfileByID(context.Background(), uid)
+	require.NoError(t, err)
+	require.Equal(t, got.Privacy, byID.Privacy)
+}
+
 func TestIntegration_UpdateProfile_NotFound(t *testing.T) {
 	st, cleanup := startPostgres(t)
 	defer cleanup()
@@ -219,18 +273,29 @@ func TestIntegration_ConfirmAvatarUpload_OK(t *testing.T) {
 
 	time.Sleep(1100 * time.Millisecond)
 
-	got, err := st.ConfirmAvatarUpload(context.Background(), uid, "avatars/"+uid.String()+"/a.png", "https://cdn.example/a.png")
+	require.Empty(t, orig.AvatarVariants)
+
+	variants := []models.AvatarVariant{
+		{Size: 64, Key: "avatars/" + uid.String() + "/a_64.png", URL: "https://cdn.example/a_64.png"},
+		{Size: 128, Key: "avatars/" + uid.String() + "/a_128.png", URL: "https://cdn.example/a_128.png"},
+	}
+	got, err := st.ConfirmAvatarUpload(context.Background(), uid, "avatars/"+uid.String()+"/a.png", "https://cdn.example/a.png", variants)
 	require.NoError(t, err)
 	require.Equal(t, "avatars/"+uid.String()+"/a.png", got.AvatarKey)
 	require.Equal(t, "https://cdn.example/a.png", got.AvatarURL)
+	require.Equal(t, variants, got.AvatarVariants)
 	require.True(t, got.UpdatedAt.After(orig.UpdatedAt))
+
+	again, err := st.ProfileByID(context.Background(), uid)
+	require.NoError(t, err)
+	require.Equal(t, variants, again.AvatarVariants)
 }
 
 func TestIntegration_ConfirmAvatarUpload_NotFound(t *testing.T) {
 	st, cleanup := startPostgres(t)
 	defer cleanup()
 
-	_, err := st.ConfirmAvatarUpload(context.Background(), uuid.New(), "k", "u")
+	_, err := st.ConfirmAvatarUpload(context.Background(), uuid.New(), "k", "u", nil)
 	require.Error(t, err)
 	require.ErrorIs(t, err, storage.ErrNotFoundProfile)
 }
@@ -249,3 +314,403 @@ func TestIntegration_CreateProfile_ContextDeadlineExceeded(t *testing.T) {
 }
 
 func ptr[T any](v T) *T { return &v }
+
+func TestIntegration_ProfilesByUsernames_Canonical(t *testing.T) {
+	st, cleanup := startPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	alice := uuid.New()
+	_, err := st.CreateProfile(ctx, &models.Profile{UserID: alice, Username: "Alice"})
+	require.NoError(t, err)
+	_, err = st.CreateProfile(ctx, &models.Profile{UserID: uuid.New(), Username: "bob"})
+	require.NoError(t, err)
+
+	got, err := st.ProfilesByUsernames(ctx, []string{"ALICE", "ghost"})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, alice, got[0].UserID)
+
+	// Похожие символы сводятся так же, как при регистрации.
+	got, err = st.ProfilesByUsernames(ctx, []string{"аl1ce"})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, alice, got[0].UserID)
+
+	got, err = st.ProfilesByUsernames(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestIntegration_ReplacedAvatars_Lifecycle(t *testing.T) {
+	st, cleanup := startPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	uid := uuid.New()
+	_, err := st.CreateProfile(ctx, &models.Profile{UserID: uid, Username: "rep"})
+	require.NoError(t, err)
+
+	keyA := "avatars/" + uid.String() + "/a.png"
+	keyB := "avatars/" + uid.String() + "/b.png"
+
+	_, err = st.ConfirmAvatarUpload(ctx, uid, keyA, "", nil)
+	require.NoError(t, err)
+
+	refs, err := st.AvatarRefs(ctx, []uuid.UUID{uid, uuid.New()})
+	require.NoError(t, err)
+	require.Len(t, refs, 1, "users without profile are omitted")
+	require.Equal(t, keyA, refs[uid].Current)
+	require.Empty(t, refs[uid].Replaced)
+
+	// Замена: прежний ключ запоминается.
+	_, err = st.ConfirmAvatarUpload(ctx, uid, keyB, "", nil)
+	require.NoError(t, err)
+	refs, err = st.AvatarRefs(ctx, []uuid.UUID{uid})
+	require.NoError(t, err)
+	require.Equal(t, keyB, refs[uid].Current)
+	require.Contains(t, refs[uid].Replaced, keyA)
+
+	// Удаление: аватар сброшен, ключ B тоже среди заменённых.
+	got, err := st.DeleteAvatar(ctx, uid)
+	require.NoError(t, err)
+	require.Empty(t, got.AvatarKey)
+	require.Empty(t, got.AvatarURL)
+	require.Empty(t, got.AvatarVariants)
+
+	refs, err = st.AvatarRefs(ctx, []uuid.UUID{uid})
+	require.NoError(t, err)
+	require.Empty(t, refs[uid].Current)
+	require.Len(t, refs[uid].Replaced, 2)
+
+	// Повторное подтверждение заменённого объекта возвращает его в работу.
+	_, err = st.ConfirmAvatarUpload(ctx, uid, keyA, "", nil)
+	require.NoError(t, err)
+	refs, err = st.AvatarRefs(ctx, []uuid.UUID{uid})
+	require.NoError(t, err)
+	require.NotContains(t, refs[uid].Replaced, keyA)
+	require.Contains(t, refs[uid].Replaced, keyB)
+
+	require.NoError(t, st.ForgetReplacedAvatars(ctx, []string{keyB}))
+	refs, err = st.AvatarRefs(ctx, []uuid.UUID{uid})
+	require.NoError(t, err)
+	require.Empty(t, refs[uid].Replaced)
+
+	_, err = st.DeleteAvatar(ctx, uuid.New())
+	require.ErrorIs(t, err, storage.ErrNotFoundProfile)
+}
+
+func TestIntegration_Usernames_UniqueAndSearch(t *testing.T) {
+	st, cleanup := startPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	alice := uuid.New()
+	_, err := st.CreateProfile(ctx, &models.Profile{UserID: alice, Username: "Alice"})
+	require.NoError(t, err)
+
+	// Регистр и похожие символы (кириллическая «а») не дают занять имя повторно.
+	for _, name := range []string{"alice", "ALICE", "аlice"} {
+		_, err = st.CreateProfile(ctx, &models.Profile{UserID: uuid.New(), Username: name})
+		require.ErrorIs(t, err, storage.ErrAlreadyExists, name)
+	}
+
+	bob := uuid.New()
+	_, err = st.CreateProfile(ctx, &models.Profile{UserID: bob, Username: "bob"})
+	require.NoError(t, err)
+
+	taken := "Alice"
+	_, err = st.UpdateProfile(ctx, bob, storage.ProfileUpdate{Username: &taken})
+	require.ErrorIs(t, err, storage.ErrAlreadyExists)
+
+	// Свой username можно сменить на вариант в другом регистре.
+	upper := "ALICE"
+	got, err := st.UpdateProfile(ctx, alice, storage.ProfileUpdate{Username: &upper})
+	require.NoError(t, err)
+	require.Equal(t, "ALICE", got.Username)
+
+	got, err = st.ProfileByUsername(ctx, "аlice")
+	require.NoError(t, err)
+	require.Equal(t, alice, got.UserID)
+
+	_, err = st.ProfileByUsername(ctx, "ghost")
+	require.ErrorIs(t, err, storage.ErrNotFoundProfile)
+
+	for _, name := range []string{"alina", "al_x", "alxe"} {
+		_, err = st.CreateProfile(ctx, &models.Profile{UserID: uuid.New(), Username: name})
+		require.NoError(t, err)
+	}
+
+	found, err := st.SearchProfiles(ctx, "AL", 10)
+	require.NoError(t, err)
+	require.Len(t, found, 4)
+
+	// '_' в префиксе — литерал, а не шаблон LIKE.
+	found, err = st.SearchProfiles(ctx, "al_", 10)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "al_x", found[0].Username)
+
+	found, err = st.SearchProfiles(ctx, "al", 2)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+}
+
+func TestIntegration_BackfillUsernameKeys_Legacy(t *testing.T) {
+	admin, adminUpper, other := uuid.New(), uuid.New(), uuid.New()
+
+	// Профили до миграции 4: "ADMIN" и "adm1n" — дубли "admin" по канонической форме.
+	st, cleanup := startPostgresSeeded(t, map[string]string{
+		"4_username_key.up.sql": fmt.Sprintf(`INSERT INTO profiles (user_id, username, created_at) VALUES
+			('%s', 'admin', now() - interval '3 hour'),
+			('%s', 'ADMIN', now() - interval '2 hour'),
+			('%s', 'adm1n', now() - interval '1 hour')`, admin, adminUpper, other),
+	})
+	defer cleanup()
+
+	ctx := context.Background()
+	n, err := st.BackfillUsernameKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+
+	// Повторный запуск ничего не меняет.
+	n, err = st.BackfillUsernameKeys(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	// Имя закреплено за самым ранним владельцем, похожие варианты заняты.
+	got, err := st.ProfileByUsername(ctx, "Admin")
+	require.NoError(t, err)
+	require.Equal(t, admin, got.UserID)
+
+	for _, name := range []string{"Admin", "admin", "аdmin"} {
+		_, err = st.CreateProfile(ctx, &models.Profile{UserID: uuid.New(), Username: name})
+		require.ErrorIs(t, err, storage.ErrAlreadyExists, name)
+	}
+}
+
+func TestIntegration_SearchProfiles_SkipsServiceKeys(t *testing.T) {
+	admin, adminUpper, legacy := uuid.New(), uuid.New(), uuid.New()
+
+	st, cleanup := startPostgresSeeded(t, map[string]string{
+		"4_username_key.up.sql": fmt.Sprintf(`INSERT INTO profiles (user_id, username, created_at) VALUES
+			('%s', 'admin', now() - interval '3 hour'),
+			('%s', 'ADMIN', now() - interval '2 hour'),
+			('%s', 'legacy', now() - interval '1 hour')`, admin, adminUpper, legacy),
+	})
+	defer cleanup()
+
+	ctx := context.Background()
+	fan := uuid.New()
+	_, err := st.CreateProfile(ctx, &models.Profile{UserID: fan, Username: "legacyfan"})
+	require.NoError(t, err)
+
+	// До бэкфилла: временные ключи 'legacy#<user_id>' под префикс "legacy" не попадают.
+	found, err := st.SearchProfiles(ctx, "legacy", 10)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, fan, found[0].UserID)
+
+	_, err = st.BackfillUsernameKeys(ctx)
+	require.NoError(t, err)
+
+	// После: "ADMIN" с ключом 'admin#<user_id>' не выдаётся за владельца имени.
+	found, err = st.SearchProfiles(ctx, "adm", 10)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, admin, found[0].UserID)
+}
+
+func TestIntegration_ProfilesByIDs(t *testing.T) {
+	st, cleanup := startPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	a, b := uuid.New(), uuid.New()
+	for i, id := range []uuid.UUID{a, b} {
+		_, err := st.CreateProfile(ctx, &models.Profile{UserID: id, Username: fmt.Sprintf("batch%d", i)})
+		require.NoError(t, err)
+	}
+
+	got, err := st.ProfilesByIDs(ctx, []uuid.UUID{b, uuid.New(), a})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	ids := []uuid.UUID{got[0].UserID, got[1].UserID}
+	require.ElementsMatch(t, []uuid.UUID{a, b}, ids)
+
+	got, err = st.ProfilesByIDs(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestIntegration_Follows(t *testing.T) {
+	st, cleanup := startPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	me := uuid.New()
+	_, err := st.CreateProfile(ctx, &models.Profile{UserID: me, Username: "me_follows"})
+	require.NoError(t, err)
+
+	others := make([]uuid.UUID, 3)
+	for i := range others {
+		others[i] = uuid.New()
+		_, err := st.CreateProfile(ctx, &models.Profile{UserID: others[i], Username: "author" + strconv.Itoa(i)})
+		require.NoError(t, err)
+
+		created, err := st.Follow(ctx, me, others[i])
+		require.NoError(t, err)
+		require.True(t, created)
+	}
+
+	// Повтор — no-op, счётчики не растут.
+	created, err := st.Follow(ctx, me, others[0])
+	require.NoError(t, err)
+	require.False(t, created)
+
+	_, err = st.Follow(ctx, me, uuid.New())
+	require.ErrorIs(t, err, storage.ErrNotFoundProfile)
+
+	_, err = st.Follow(ctx, me, me)
+	require.ErrorIs(t, err, storage.ErrInvalidArgument)
+
+	p, err := st.ProfileByID(ctx, me)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, p.FollowingCount)
+
+	p, err = st.ProfileByID(ctx, others[0])
+	require.NoError(t, err)
+	require.EqualValues(t, 1, p.FollowersCount)
+
+	// Постранично, сначала новые подписки.
+	page, err := st.ListFollowing(ctx, me, 2, "")
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	require.Equal(t, others[2], page.Items[0].Profile.UserID)
+	require.NotEmpty(t, page.NextPageToken)
+
+	page, err = st.ListFollowing(ctx, me, 2, page.NextPageToken)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	require.Equal(t, others[0], page.Items[0].Profile.UserID)
+	require.Empty(t, page.NextPageToken)
+
+	_, err = st.ListFollowing(ctx, me, 2, "%%%")
+	require.ErrorIs(t, err, storage.ErrInvalidCursor)
+
+	followers, err := st.ListFollowers(ctx, others[1], 10, "")
+	require.NoError(t, err)
+	require.Len(t, followers.Items, 1)
+	require.Equal(t, me, followers.Items[0].Profile.UserID)
+	require.False(t, followers.Items[0].FollowedAt.IsZero())
+
+	removed, err := st.Unfollow(ctx, me, others[1])
+	require.NoError(t, err)
+	require.True(t, removed)
+
+	removed, err = st.Unfollow(ctx, me, others[1])
+	require.NoError(t, err)
+	require.False(t, removed)
+
+	p, err = st.ProfileByID(ctx, me)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, p.FollowingCount)
+}
+
+func TestIntegration_Preferences(t *testing.T) {
+	st, cleanup := startPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	uid := uuid.New()
+	_, err := st.CreateProfile(ctx, &models.Profile{UserID: uid, Username: "reader"})
+	require.NoError(t, err)
+
+	// Пока не заданы — пустые, без ошибки.
+	p, err := st.PreferencesByID(ctx, uid)
+	require.NoError(t, err)
+	require.Empty(t, p.FollowedCategories)
+	require.True(t, p.UpdatedAt.IsZero())
+
+	followed := []string{"tech", "science"}
+	p, err = st.UpdatePreferences(ctx, uid, storage.PreferencesUpdate{FollowedCategories: &followed})
+	require.NoError(t, err)
+	require.Equal(t, followed, p.FollowedCategories)
+	require.Empty(t, p.MutedSources)
+	require.False(t, p.UpdatedAt.IsZero())
+
+	// Частичный апдейт не трогает остальные списки.
+	sources := []string{"lenta.ru"}
+	p, err = st.UpdatePreferences(ctx, uid, storage.PreferencesUpdate{MutedSources: &sources})
+	require.NoError(t, err)
+	require.Equal(t, followed, p.FollowedCategories)
+	require.Equal(t, sources, p.MutedSources)
+
+	empty := []string{}
+	_, err = st.UpdatePreferences(ctx, uid, storage.PreferencesUpdate{FollowedCategories: &empty})
+	require.NoError(t, err)
+
+	p, err = st.PreferencesByID(ctx, uid)
+	require.NoError(t, err)
+	require.Empty(t, p.FollowedCategories)
+	require.Equal(t, sources, p.MutedSources)
+
+	_, err = st.UpdatePreferences(ctx, uuid.New(), storage.PreferencesUpdate{MutedSources: &sources})
+	require.ErrorIs(t, err, storage.ErrNotFoundProfile)
+}
+
+func TestIntegration_Bookmarks(t *testing.T) {
+	st, cleanup := startPostgres(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	uid := uuid.New()
+	_, err := st.CreateProfile(ctx, &models.Profile{UserID: uid, Username: "bookworm"})
+	require.NoError(t, err)
+
+	news := make([]uuid.UUID, 3)
+	for i := range news {
+		news[i] = uuid.New()
+
+		created, err := st.AddBookmark(ctx, uid, news[i])
+		require.NoError(t, err)
+		require.True(t, created)
+	}
+
+	// Повтор — no-op.
+	created, err := st.AddBookmark(ctx, uid, news[0])
+	require.NoError(t, err)
+	require.False(t, created)
+
+	_, err = st.AddBookmark(ctx, uuid.New(), news[0])
+	require.ErrorIs(t, err, storage.ErrNotFoundProfile)
+
+	// Сначала недавно сохранённые; страницы по 2 без пересечений.
+	page, err := st.ListBookmarks(ctx, uid, 2, "")
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	require.Equal(t, news[2], page.Items[0].NewsID)
+	require.NotEmpty(t, page.NextPageToken)
+
+	page2, err := st.ListBookmarks(ctx, uid, 2, page.NextPageToken)
+	require.NoError(t, err)
+	require.Len(t, page2.Items, 1)
+	require.Equal(t, news[0], page2.Items[0].NewsID)
+	require.Empty(t, page2.NextPageToken)
+
+	_, err = st.ListBookmarks(ctx, uid, 2, "not-a-cursor")
+	require.ErrorIs(t, err, storage.ErrInvalidCursor)
+
+	removed, err := st.RemoveBookmark(ctx, uid, news[1])
+	require.NoError(t, err)
+	require.True(t, removed)
+
+	removed, err = st.RemoveBookmark(ctx, uid, news[1])
+	require.NoError(t, err)
+	require.False(t, removed)
+
+	page, err = st.ListBookmarks(ctx, uid, 10, "")
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+)
+
+// PreferencesUpdate — частичный апдейт предпочтений.
+// nil-указатель — список не меняется; указатель на пустой срез — список очищается.
+type PreferencesUpdate struct {
+	FollowedCategories *[]string
+	MutedCategories    *[]string
+	MutedSources       *[]string
+	Languages          *[]string
+}
+
+// Preferences — контракт репозитория предпочтений читателя.
+type Preferences interface {
+	// PreferencesByID возвращает предпочтения пользователя; если они ещё не заданы —
+	// пустые предпочтения с нулевым UpdatedAt (без ошибки).
+	PreferencesByID(ctx context.Context, userID uuid.UUID) (*models.Preferences, error)
+	// UpdatePreferences применяет частичный апдейт (создаёт запись при первом вызове)
+	// и обновляет updated_at. Отсутствующий профиль -> ErrNotFoundProfile.
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, update PreferencesUpdate) (*models.Preferences, error)
+}
@@ -3,11 +3,15 @@
 // profiles.go - работа с профилями в БД (создание/чтение/частичное обновление)
 // и фиксация атрибутов аватара после успешного подтверждения загрузки в S3/MinIO.
 // avatars.go - контракт для работы с загрузкой аватаров в S3/MinIO.
+// follows.go - подписки между пользователями.
+// preferences.go - предпочтения читателя для персональной ленты.
+// bookmarks.go - закладки читателя («прочитать позже»).
 package storage
 
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
@@ -16,17 +20,28 @@ import (
 var (
 	// ErrNotFoundProfile — профиль не найден.
 	ErrNotFoundProfile = errors.New("not found")
-	// ErrAlreadyExists — профиль с тем же первичным ключом/уникальным полем уже существует.
+	// ErrAlreadyExists — профиль с тем же user_id или username (по канонической форме) уже существует.
 	ErrAlreadyExists = errors.New("already exists")
 )
 
 // ProfileUpdate — частичный апдейт профиля.
 // Параметры задаются pointer-полями: только непустые указатели обновляются в БД.
+// Privacy — частичное изменение видимости: ключ ("age", "gender", "country") -> значение;
+// ключи, которых нет в map, сохраняют прежнюю видимость.
 type ProfileUpdate struct {
 	Username *string
 	Age      *uint32
 	Country  *string
 	Gender   *models.Gender
+	Privacy  map[string]models.Visibility
+}
+
+// AvatarRefs — ссылки профиля на объекты аватаров (для фоновой уборки бакета).
+//   - Current: текущий avatar_key ("" — аватар не задан);
+//   - Replaced: заменённые или удалённые avatar_key -> момент замены.
+type AvatarRefs struct {
+	Current  string
+	Replaced map[string]time.Time
 }
 
 // Profile — контракт репозитория профилей.
@@ -35,16 +50,39 @@ type Profile interface {
 	CreateProfile(ctx context.Context, profile *models.Profile) (*models.Profile, error)
 	// ProfileByID возвращает профиль по user_id.
 	ProfileByID(ctx context.Context, userID uuid.UUID) (*models.Profile, error)
+	// ProfilesByIDs возвращает профили по набору user_id одним запросом;
+	// отсутствующие user_id в результат не попадают, порядок не гарантируется.
+	ProfilesByIDs(ctx context.Context, userIDs []uuid.UUID) ([]models.Profile, error)
 	// UpdateProfile выполняет частичное обновление полей, указанных в update.
-	// Реализация должна обновить updated_at.
+	// Реализация должна обновить updated_at; занятый username -> ErrAlreadyExists.
 	UpdateProfile(ctx context.Context, userID uuid.UUID, update ProfileUpdate) (*models.Profile, error)
-	// ConfirmAvatarUpload фиксирует новый avatar_key и (опционально) avatar_url в записи профиля.
-	// Необходимо вызвать после успешного подтверждения загрузки в S3/MinIO.
-	ConfirmAvatarUpload(ctx context.Context, userID uuid.UUID, key, publicURL string) (*models.Profile, error)
+	// ConfirmAvatarUpload фиксирует новый avatar_key, (опционально) avatar_url и превью в записи профиля.
+	// Необходимо вызвать после успешной обработки загрузки в S3/MinIO.
+	// Прежний avatar_key (если был) запоминается как заменённый — см. AvatarRefs.
+	ConfirmAvatarUpload(ctx context.Context, userID uuid.UUID, key, publicURL string, variants []models.AvatarVariant) (*models.Profile, error)
+	// DeleteAvatar сбрасывает аватар профиля к аватару по умолчанию;
+	// прежний avatar_key запоминается как заменённый.
+	DeleteAvatar(ctx context.Context, userID uuid.UUID) (*models.Profile, error)
+	// AvatarRefs возвращает ссылки на аватары для набора пользователей.
+	// Пользователи без профиля в результат не попадают.
+	AvatarRefs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]AvatarRefs, error)
+	// ProfileByUsername ищет профиль по username с учётом канонической формы
+	// (internal/usernames.Canonical): регистр и похожие символы не учитываются.
+	ProfileByUsername(ctx context.Context, username string) (*models.Profile, error)
+	// SearchProfiles — префиксный поиск по канонической форме username, до limit профилей.
+	SearchProfiles(ctx context.Context, prefix string, limit int) ([]models.Profile, error)
+	// ForgetReplacedAvatars удаляет записи о заменённых аватарах (после удаления объектов).
+	ForgetReplacedAvatars(ctx context.Context, keys []string) error
+	// ProfilesByUsernames возвращает профили, каноническая форма username которых
+	// совпадает с канонической формой одного из usernames. Отсутствующие просто не попадают в результат.
+	ProfilesByUsernames(ctx context.Context, usernames []string) ([]models.Profile, error)
 }
 
 // ProfilesStorage — верхнеуровневый интерфейс хранилища профилей.
 type ProfilesStorage interface {
 	Profile
+	Follows
+	Preferences
+	Bookmarks
 	Close()
 }
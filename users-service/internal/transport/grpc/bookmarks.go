@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/users-service/gen/go/users"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddBookmark сохраняет закладку user_id на news_id.
+// Маппинг ошибок:
+//   - неверный UUID, ErrInvalidArgument -> InvalidArgument;
+//   - ErrNotFound (нет профиля или новости) -> NotFound;
+//   - прочее -> Internal.
+func (s *UsersServer) AddBookmark(ctx context.Context, req *usersv1.BookmarkRequest) (*usersv1.BookmarkResponse, error) {
+	const op = "transport/grpc/users/AddBookmark"
+
+	return s.bookmark(ctx, op, req, s.service.AddBookmark)
+}
+
+// RemoveBookmark удаляет закладку; маппинг ошибок — как у AddBookmark.
+func (s *UsersServer) RemoveBookmark(ctx context.Context, req *usersv1.BookmarkRequest) (*usersv1.BookmarkResponse, error) {
+	const op = "transport/grpc/users/RemoveBookmark"
+
+	return s.bookmark(ctx, op, req, s.service.RemoveBookmark)
+}
+
+func (s *UsersServer) bookmark(
+	ctx context.Context,
+	op string,
+	req *usersv1.BookmarkRequest,
+	call func(ctx context.Context, userID, newsID uuid.UUID) (bool, error),
+) (*usersv1.BookmarkResponse, error) {
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+
+	newsID, err := uuid.Parse(strings.TrimSpace(req.GetNewsId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid news_id: %v", op, err)
+	}
+
+	changed, err := call(ctx, userID, newsID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		case errors.Is(err, service.ErrNotFound):
+			return nil, status.Errorf(codes.NotFound, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return &usersv1.BookmarkResponse{Changed: changed}, nil
+}
+
+// ListBookmarks — закладки пользователя с новостями, сначала недавно сохранённые.
+// Маппинг ошибок:
+//   - неверный UUID, ErrInvalidArgument, ErrInvalidCursor -> InvalidArgument;
+//   - прочее -> Internal.
+func (s *UsersServer) ListBookmarks(ctx context.Context, req *usersv1.ListBookmarksRequest) (*usersv1.ListBookmarksResponse, error) {
+	const op = "transport/grpc/users/ListBookmarks"
+
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+
+	page, err := s.service.ListBookmarks(ctx, service.ListBookmarksInput{
+		UserID:    userID,
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument), errors.Is(err, service.ErrInvalidCursor):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	resp := &usersv1.ListBookmarksResponse{
+		Bookmarks:     make([]*usersv1.Bookmark, 0, len(page.Items)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, b := range page.Items {
+		resp.Bookmarks = append(resp.Bookmarks, &usersv1.Bookmark{
+			NewsId:      b.NewsID.String(),
+			SavedAt:     b.SavedAt.Unix(),
+			News:        toProtoBookmarkedNews(b.News),
+			NewsMissing: b.NewsMissing,
+		})
+	}
+
+	return resp, nil
+}
+
+// toProtoBookmarkedNews конвертирует снимок новости в protobuf (nil -> nil).
+func toProtoBookmarkedNews(n *models.News) *usersv1.BookmarkedNews {
+	if n == nil {
+		return nil
+	}
+
+	return &usersv1.BookmarkedNews{
+		Id:               n.ID.String(),
+		Title:            n.Title,
+		Category:         n.Category,
+		ShortDescription: n.ShortDescription,
+		Link:             n.Link,
+		ImageUrl:         n.ImageURL,
+		PublishedAt:      n.PublishedAt.Unix(),
+		Source:           n.Source,
+		Language:         n.Language,
+	}
+}
@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/users-service/gen/go/users"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Follow подписывает follower_id на followee_id.
+// Маппинг ошибок:
+//   - неверный UUID, ErrInvalidArgument (в т.ч. подписка на себя) -> InvalidArgument;
+//   - ErrNotFound -> NotFound;
+//   - прочее -> Internal.
+func (s *UsersServer) Follow(ctx context.Context, req *usersv1.FollowRequest) (*usersv1.FollowResponse, error) {
+	const op = "transport/grpc/users/Follow"
+
+	return s.follow(ctx, op, req, s.service.Follow)
+}
+
+// Unfollow отменяет подписку; маппинг ошибок — как у Follow.
+func (s *UsersServer) Unfollow(ctx context.Context, req *usersv1.FollowRequest) (*usersv1.FollowResponse, error) {
+	const op = "transport/grpc/users/Unfollow"
+
+	return s.follow(ctx, op, req, s.service.Unfollow)
+}
+
+func (s *UsersServer) follow(
+	ctx context.Context,
+	op string,
+	req *usersv1.FollowRequest,
+	call func(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error),
+) (*usersv1.FollowResponse, error) {
+	followerID, err := uuid.Parse(strings.TrimSpace(req.GetFollowerId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid follower_id: %v", op, err)
+	}
+
+	followeeID, err := uuid.Parse(strings.TrimSpace(req.GetFolloweeId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid followee_id: %v", op, err)
+	}
+
+	changed, err := call(ctx, followerID, followeeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		case errors.Is(err, service.ErrNotFound):
+			return nil, status.Errorf(codes.NotFound, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return &usersv1.FollowResponse{Changed: changed}, nil
+}
+
+// ListFollowers — подписчики пользователя (профили — в представлении для вызывающего).
+// Маппинг ошибок:
+//   - неверный UUID, ErrInvalidArgument, ErrInvalidCursor -> InvalidArgument;
+//   - прочее -> Internal.
+func (s *UsersServer) ListFollowers(ctx context.Context, req *usersv1.ListFollowsRequest) (*usersv1.ListFollowsResponse, error) {
+	const op = "transport/grpc/users/ListFollowers"
+
+	return s.listFollows(ctx, op, req, s.service.ListFollowers)
+}
+
+// ListFollowing — подписки пользователя; маппинг ошибок — как у ListFollowers.
+func (s *UsersServer) ListFollowing(ctx context.Context, req *usersv1.ListFollowsRequest) (*usersv1.ListFollowsResponse, error) {
+	const op = "transport/grpc/users/ListFollowing"
+
+	return s.listFollows(ctx, op, req, s.service.ListFollowing)
+}
+
+func (s *UsersServer) listFollows(
+	ctx context.Context,
+	op string,
+	req *usersv1.ListFollowsRequest,
+	list func(ctx context.Context, in service.ListFollowsInput) (*models.FollowsPage, error),
+) (*usersv1.ListFollowsResponse, error) {
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+
+	page, err := list(ctx, service.ListFollowsInput{
+		UserID:    userID,
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+		Viewer:    viewerFromContext(ctx),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument), errors.Is(err, service.ErrInvalidCursor):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	resp := &usersv1.ListFollowsResponse{
+		Entries:       make([]*usersv1.FollowEntry, 0, len(page.Items)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, e := range page.Items {
+		resp.Entries = append(resp.Entries, &usersv1.FollowEntry{
+			Profile:    toProtoProfile(e.Profile),
+			FollowedAt: e.FollowedAt.Unix(),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/users-service/gen/go/users"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetPreferences возвращает предпочтения читателя (пустые, если не заданы).
+// Маппинг ошибок:
+//   - неверный UUID, ErrInvalidArgument -> InvalidArgument;
+//   - прочее -> Internal.
+func (s *UsersServer) GetPreferences(ctx context.Context, req *usersv1.GetPreferencesRequest) (*usersv1.Preferences, error) {
+	const op = "transport/grpc/users/GetPreferences"
+
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+
+	p, err := s.service.Preferences(ctx, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidArgument) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return toProtoPreferences(*p), nil
+}
+
+// UpdatePreferences меняет списки предпочтений: с update_mask — только перечисленные,
+// без маски — все четыре.
+// Маппинг ошибок:
+//   - неверный UUID, неизвестный путь маски, ErrInvalidArgument -> InvalidArgument;
+//   - ErrNotFound -> NotFound;
+//   - прочее -> Internal.
+func (s *UsersServer) UpdatePreferences(ctx context.Context, req *usersv1.UpdatePreferencesRequest) (*usersv1.Preferences, error) {
+	const op = "transport/grpc/users/UpdatePreferences"
+
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+
+	p := req.GetPreferences()
+	lists := map[string][]string{
+		"followed_categories": p.GetFollowedCategories(),
+		"muted_categories":    p.GetMutedCategories(),
+		"muted_sources":       p.GetMutedSources(),
+		"languages":           p.GetLanguages(),
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"followed_categories", "muted_categories", "muted_sources", "languages"}
+	}
+
+	in := service.UpdatePreferencesInput{UserID: userID}
+	for _, raw := range paths {
+		path := strings.ToLower(strings.TrimSpace(raw))
+
+		list, ok := lists[path]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: unknown update_mask path %q", op, raw)
+		}
+
+		if list == nil {
+			list = []string{}
+		}
+
+		switch path {
+		case "followed_categories":
+			in.FollowedCategories = &list
+		case "muted_categories":
+			in.MutedCategories = &list
+		case "muted_sources":
+			in.MutedSources = &list
+		case "languages":
+			in.Languages = &list
+		}
+	}
+
+	out, err := s.service.UpdatePreferences(ctx, in)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		case errors.Is(err, service.ErrNotFound):
+			return nil, status.Errorf(codes.NotFound, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return toProtoPreferences(*out), nil
+}
+
+// toProtoPreferences конвертирует доменные предпочтения в protobuf.
+func toProtoPreferences(p models.Preferences) *usersv1.Preferences {
+	out := &usersv1.Preferences{
+		UserId:             p.UserID.String(),
+		FollowedCategories: p.FollowedCategories,
+		MutedCategories:    p.MutedCategories,
+		MutedSources:       p.MutedSources,
+		Languages:          p.Languages,
+	}
+
+	if !p.UpdatedAt.IsZero() {
+		out.UpdatedAt = p.UpdatedAt.Unix()
+	}
+
+	return out
+}
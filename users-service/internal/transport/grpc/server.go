@@ -35,7 +35,8 @@ func NewUsersServer(svc *service.Service) *UsersServer {
 	return &UsersServer{service: svc}
 }
 
-// ProfileByID возвращает профиль по идентификатору пользователя.
+// ProfileByID возвращает профиль по идентификатору пользователя
+// в представлении для вызывающего (см. viewerFromContext).
 // Маппинг ошибок:
 //   - неверный UUID -> InvalidArgument;
 //   - ErrNotFound -> NotFound;
@@ -48,7 +49,7 @@ func (s *UsersServer) ProfileByID(ctx context.Context, req *usersv1.ProfileByIDR
 		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
 	}
 
-	profile, err := s.service.ProfileByID(ctx, userID)
+	profile, err := s.service.ProfileByID(ctx, userID, viewerFromContext(ctx))
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrNotFound):
@@ -63,6 +64,64 @@ func (s *UsersServer) ProfileByID(ctx context.Context, req *usersv1.ProfileByIDR
 	return toProtoProfile(*profile), nil
 }
 
+// ProfilesByIDs возвращает профили пачкой.
+// Некорректные user_id не считаются ошибкой запроса: они попадают в missing_ids
+// вместе с ненайденными (чтобы один «битый» id не ломал рендер страницы).
+// Маппинг ошибок:
+//   - ErrInvalidArgument (пустой список, превышен лимит) -> InvalidArgument;
+//   - прочее -> Internal.
+func (s *UsersServer) ProfilesByIDs(ctx context.Context, req *usersv1.ProfilesByIDsRequest) (*usersv1.ProfilesByIDsResponse, error) {
+	const op = "transport/grpc/users/ProfilesByIDs"
+
+	raw := req.GetUserIds()
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, r := range raw {
+		if id, err := uuid.Parse(strings.TrimSpace(r)); err == nil && id != uuid.Nil {
+			ids = append(ids, id)
+		}
+	}
+
+	found := map[uuid.UUID]models.Profile{}
+	if len(ids) > 0 || len(raw) == 0 {
+		var err error
+		found, _, err = s.service.ProfilesByIDs(ctx, ids, viewerFromContext(ctx))
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidArgument):
+				return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+			default:
+				return nil, status.Errorf(codes.Internal, "internal server error")
+			}
+		}
+	}
+
+	resp := &usersv1.ProfilesByIDsResponse{Profiles: make(map[string]*usersv1.Profile, len(found))}
+	for id, p := range found {
+		resp.Profiles[id.String()] = toProtoProfile(p)
+	}
+
+	seen := make(map[string]struct{}, len(raw))
+	for _, r := range raw {
+		key := strings.TrimSpace(r)
+		if id, err := uuid.Parse(key); err == nil {
+			key = id.String()
+		}
+
+		if _, ok := resp.Profiles[key]; ok {
+			continue
+		}
+
+		if _, dup := seen[key]; dup {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		resp.MissingIds = append(resp.MissingIds, key)
+	}
+
+	return resp, nil
+}
+
 // CreateProfile создаёт новый профиль.
 // Маппинг ошибок:
 //   - неверный UUID -> InvalidArgument;
@@ -104,6 +163,7 @@ func (s *UsersServer) CreateProfile(ctx context.Context, req *usersv1.CreateProf
 //   - неверный UUID -> InvalidArgument;
 //   - ErrInvalidArgument -> InvalidArgument;
 //   - ErrNotFound -> NotFound;
+//   - ErrAlreadyExists (username занят) -> AlreadyExists;
 //   - прочее -> Internal.
 //
 // Правила передачи значений:
@@ -187,6 +247,21 @@ func (s *UsersServer) UpdateProfile(ctx context.Context, req *usersv1.UpdateProf
 		in.Gender = &g
 	}
 
+	// privacy.* (без маски — все три значения, если privacy передан).
+	if useMask {
+		fields := map[string]bool{}
+		for _, p := range mask {
+			if field, ok := strings.CutPrefix(p, "privacy."); ok {
+				fields[field] = true
+			}
+		}
+		if len(fields) > 0 {
+			in.Privacy = fromProtoPrivacy(req.GetPrivacy(), fields)
+		}
+	} else if req.GetPrivacy() != nil {
+		in.Privacy = fromProtoPrivacy(req.GetPrivacy(), nil)
+	}
+
 	profile, err := s.service.UpdateProfile(ctx, in)
 	if err != nil {
 		switch {
@@ -194,6 +269,8 @@ func (s *UsersServer) UpdateProfile(ctx context.Context, req *usersv1.UpdateProf
 			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
 		case errors.Is(err, service.ErrNotFound):
 			return nil, status.Errorf(codes.NotFound, "%s: %v", op, err)
+		case errors.Is(err, service.ErrAlreadyExists):
+			return nil, status.Errorf(codes.AlreadyExists, "%s: %v", op, err)
 		default:
 			return nil, status.Errorf(codes.Internal, "internal server error")
 		}
@@ -286,6 +363,116 @@ func (s *UsersServer) ConfirmAvatarUpload(ctx context.Context, req *usersv1.Conf
 	return toProtoProfile(*profile), nil
 }
 
+// DeleteAvatar сбрасывает аватар пользователя к аватару по умолчанию.
+// Маппинг ошибок:
+//   - неверный UUID -> InvalidArgument;
+//   - ErrInvalidArgument -> InvalidArgument;
+//   - ErrNotFound -> NotFound;
+//   - прочее -> Internal.
+func (s *UsersServer) DeleteAvatar(ctx context.Context, req *usersv1.DeleteAvatarRequest) (*usersv1.Profile, error) {
+	const op = "transport/grpc/users/DeleteAvatar"
+
+	userID, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+	}
+
+	profile, err := s.service.DeleteAvatar(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		case errors.Is(err, service.ErrNotFound):
+			return nil, status.Errorf(codes.NotFound, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	return toProtoProfile(*profile), nil
+}
+
+// ResolveUsernames разрешает username в user_id (по канонической форме, см. internal/usernames).
+// Маппинг ошибок:
+//   - ErrInvalidArgument -> InvalidArgument;
+//   - прочее -> Internal.
+func (s *UsersServer) ResolveUsernames(ctx context.Context, req *usersv1.ResolveUsernamesRequest) (*usersv1.ResolveUsernamesResponse, error) {
+	const op = "transport/grpc/users/ResolveUsernames"
+
+	ids, err := s.service.ResolveUsernames(ctx, req.GetUsernames())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	resp := &usersv1.ResolveUsernamesResponse{UserIds: make(map[string]string, len(ids))}
+	for username, id := range ids {
+		resp.UserIds[username] = id.String()
+	}
+
+	return resp, nil
+}
+
+// CheckUsernameAvailability проверяет, свободен ли username.
+// Маппинг ошибок:
+//   - неверный UUID (если user_id задан) -> InvalidArgument;
+//   - прочее -> Internal.
+//
+// Недопустимый/зарезервированный/занятый username — не ошибка, а available=false с reason.
+func (s *UsersServer) CheckUsernameAvailability(ctx context.Context, req *usersv1.CheckUsernameAvailabilityRequest) (*usersv1.CheckUsernameAvailabilityResponse, error) {
+	const op = "transport/grpc/users/CheckUsernameAvailability"
+
+	userID := uuid.Nil
+	if raw := strings.TrimSpace(req.GetUserId()); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: invalid user_id: %v", op, err)
+		}
+
+		userID = id
+	}
+
+	res, err := s.service.CheckUsernameAvailability(ctx, req.GetUsername(), userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &usersv1.CheckUsernameAvailabilityResponse{
+		Available: res.Available,
+		Username:  res.Username,
+		Reason:    res.Reason,
+	}, nil
+}
+
+// SearchProfiles — префиксный поиск профилей по username.
+// Маппинг ошибок:
+//   - ErrInvalidArgument -> InvalidArgument;
+//   - прочее -> Internal.
+func (s *UsersServer) SearchProfiles(ctx context.Context, req *usersv1.SearchProfilesRequest) (*usersv1.SearchProfilesResponse, error) {
+	const op = "transport/grpc/users/SearchProfiles"
+
+	profiles, err := s.service.SearchProfiles(ctx, req.GetQuery(), int(req.GetLimit()), viewerFromContext(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "internal server error")
+		}
+	}
+
+	resp := &usersv1.SearchProfilesResponse{Profiles: make([]*usersv1.Profile, 0, len(profiles))}
+	for _, p := range profiles {
+		resp.Profiles = append(resp.Profiles, toProtoProfile(p))
+	}
+
+	return resp, nil
+}
+
 // toProtoProfile конвертирует доменную модель Profile в protobuf-представление.
 func toProtoProfile(p models.Profile) *usersv1.Profile {
 	return &usersv1.Profile{
@@ -298,9 +485,28 @@ func toProtoProfile(p models.Profile) *usersv1.Profile {
 		UpdatedAt: p.UpdatedAt.Unix(),
 		Country:   p.Country,
 		Gender:    toProtoGender(p.Gender),
+
+		AvatarVariants: toProtoAvatarVariants(p.AvatarVariants),
+		Privacy:        toProtoPrivacy(p.Privacy),
+		FollowersCount: p.FollowersCount,
+		FollowingCount: p.FollowingCount,
 	}
 }
 
+// toProtoAvatarVariants конвертирует превью аватара в protobuf-представление.
+func toProtoAvatarVariants(variants []models.AvatarVariant) []*usersv1.AvatarVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	out := make([]*usersv1.AvatarVariant, 0, len(variants))
+	for _, v := range variants {
+		out = append(out, &usersv1.AvatarVariant{Size: uint32(v.Size), Url: v.URL, Key: v.Key})
+	}
+
+	return out
+}
+
 // toProtoGender конвертирует доменную модель Gender в protobuf-представление.
 func toProtoGender(gender models.Gender) usersv1.Gender {
 	switch gender {
@@ -16,6 +16,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
 	usersv1 "github.com/pribylovaa/go-news-aggregator/users-service/gen/go/users"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/config"
 	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
@@ -24,6 +25,7 @@ import (
 	"github.com/pribylovaa/go-news-aggregator/users-service/mocks"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
@@ -36,7 +38,7 @@ func newServerWithMocks(t *testing.T) (*UsersServer, *mocks.MockProfilesStorage,
 	mp := mocks.NewMockProfilesStorage(ctrl)
 	ma := mocks.NewMockAvatarsStorage(ctrl)
 
-	svc := service.New(mp, ma, &config.Config{})
+	svc := service.New(mp, ma, &config.Config{Limits: config.LimitsConfig{MaxProfilesBatch: 200}})
 	srv := NewUsersServer(svc)
 
 	return srv, mp, ma, ctrl
@@ -53,6 +55,10 @@ func mustProfile(uid uuid.UUID, name string) *models.Profile {
 		Gender:    models.GenderFemale,
 		AvatarKey: "avatars/" + uid.String() + "/a.png",
 		AvatarURL: "http://cdn/a.png",
+		AvatarVariants: []models.AvatarVariant{
+			{Size: 64, Key: "avatars/" + uid.String() + "/a_64.png", URL: "http://cdn/a_64.png"},
+			{Size: 128, Key: "avatars/" + uid.String() + "/a_128.png", URL: "http://cdn/a_128.png"},
+		},
 		CreatedAt: ts,
 		UpdatedAt: ts.Add(time.Minute),
 	}
@@ -91,6 +97,44 @@ func TestGRPC_ProfileByID_Internal(t *testing.T) {
 	require.Equal(t, codes.Internal, status.Code(err))
 }
 
+// Зритель определяется по metadata: аноним/чужой получает профиль без скрытых атрибутов
+// и без privacy, владелец и admin — полный.
+func TestGRPC_ProfileByID_PrivacyByCaller(t *testing.T) {
+	srv, mp, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+	stored := mustProfile(uid, "alice")
+	stored.Privacy = models.Privacy{Age: models.VisibilityRegistered, Country: models.VisibilityPrivate}
+	mp.EXPECT().ProfileByID(gomock.Any(), uid).Return(stored, nil).AnyTimes()
+
+	as := func(pairs ...string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+	}
+
+	anon, err := srv.ProfileByID(context.Background(), &usersv1.ProfileByIDRequest{UserId: uid.String()})
+	require.NoError(t, err)
+	require.Zero(t, anon.GetAge())
+	require.Empty(t, anon.GetCountry())
+	require.Nil(t, anon.GetPrivacy())
+
+	other, err := srv.ProfileByID(as(identity.MDUserID, uuid.NewString()), &usersv1.ProfileByIDRequest{UserId: uid.String()})
+	require.NoError(t, err)
+	require.EqualValues(t, 21, other.GetAge())
+	require.Empty(t, other.GetCountry())
+
+	for _, ctx := range []context.Context{
+		as(identity.MDUserID, uid.String()),
+		as(identity.MDUserID, uuid.NewString(), identity.MDUserRoles, "admin"),
+	} {
+		full, err := srv.ProfileByID(ctx, &usersv1.ProfileByIDRequest{UserId: uid.String()})
+		require.NoError(t, err)
+		require.Equal(t, "LV", full.GetCountry())
+		require.Equal(t, usersv1.Visibility_REGISTERED, full.GetPrivacy().GetAge())
+		require.Equal(t, usersv1.Visibility_PRIVATE, full.GetPrivacy().GetCountry())
+	}
+}
+
 func TestGRPC_ProfileByID_OK(t *testing.T) {
 	srv, mp, _, ctrl := newServerWithMocks(t)
 	defer ctrl.Finish()
@@ -108,6 +152,9 @@ func TestGRPC_ProfileByID_OK(t *testing.T) {
 	require.Equal(t, usersv1.Gender_FEMALE, got.GetGender())
 	require.Equal(t, want.AvatarKey, got.GetAvatarKey())
 	require.Equal(t, want.AvatarURL, got.GetAvatarUrl())
+	require.Len(t, got.GetAvatarVariants(), 2)
+	require.EqualValues(t, 64, got.GetAvatarVariants()[0].GetSize())
+	require.Equal(t, "http://cdn/a_64.png", got.GetAvatarVariants()[0].GetUrl())
 	require.Equal(t, want.CreatedAt.Unix(), got.GetCreatedAt())
 	require.Equal(t, want.UpdatedAt.Unix(), got.GetUpdatedAt())
 }
@@ -253,6 +300,39 @@ func TestGRPC_UpdateProfile_WithMask_SetsOnlyMaskedFields(t *testing.T) {
 	require.Equal(t, "", got.GetCountry())
 }
 
+func TestGRPC_UpdateProfile_PrivacyMask(t *testing.T) {
+	srv, mp, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+	want := mustProfile(uid, "neo")
+	want.Privacy = models.Privacy{Gender: models.VisibilityPrivate}
+
+	mp.EXPECT().
+		UpdateProfile(gomock.Any(), uid, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, upd storage.ProfileUpdate) (*models.Profile, error) {
+			require.Equal(t, map[string]models.Visibility{"gender": models.VisibilityPrivate}, upd.Privacy)
+			require.Nil(t, upd.Username)
+			return want, nil
+		})
+
+	got, err := srv.UpdateProfile(context.Background(), &usersv1.UpdateProfileRequest{
+		UserId:     uid.String(),
+		Username:   "ignored",
+		Privacy:    &usersv1.Privacy{Gender: usersv1.Visibility_PRIVATE, Age: usersv1.Visibility_PRIVATE},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"privacy.gender"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, usersv1.Visibility_PRIVATE, got.GetPrivacy().GetGender())
+
+	// Неизвестное значение enum не превращается молча в PUBLIC.
+	_, err = srv.UpdateProfile(context.Background(), &usersv1.UpdateProfileRequest{
+		UserId:  uid.String(),
+		Privacy: &usersv1.Privacy{Age: usersv1.Visibility(9)},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestGRPC_UpdateProfile_NoMask_UsesNonZeroProto3Fields(t *testing.T) {
 	srv, mp, _, ctrl := newServerWithMocks(t)
 	defer ctrl.Finish()
@@ -415,8 +495,8 @@ func TestGRPC_ConfirmAvatarUpload_ProfileNotFound(t *testing.T) {
 	key := "avatars/" + uid.String() + "/a.png"
 	public := "http://cdn.local/a.png"
 
-	ma.EXPECT().CheckAvatarUpload(gomock.Any(), uid, key).Return(public, nil)
-	mp.EXPECT().ConfirmAvatarUpload(gomock.Any(), uid, key, public).Return(nil, storage.ErrNotFoundProfile)
+	ma.EXPECT().ProcessAvatarUpload(gomock.Any(), uid, key).Return(&storage.ProcessedAvatar{URL: public}, nil)
+	mp.EXPECT().ConfirmAvatarUpload(gomock.Any(), uid, key, public, nil).Return(nil, storage.ErrNotFoundProfile)
 
 	_, err := srv.ConfirmAvatarUpload(context.Background(), &usersv1.ConfirmAvatarUploadRequest{
 		UserId:    uid.String(),
@@ -434,8 +514,8 @@ func TestGRPC_ConfirmAvatarUpload_Internal(t *testing.T) {
 	key := "avatars/" + uid.String() + "/a.png"
 	public := "http://cdn.local/a.png"
 
-	ma.EXPECT().CheckAvatarUpload(gomock.Any(), uid, key).Return(public, nil)
-	mp.EXPECT().ConfirmAvatarUpload(gomock.Any(), uid, key, public).Return(nil, errors.New("db down"))
+	ma.EXPECT().ProcessAvatarUpload(gomock.Any(), uid, key).Return(&storage.ProcessedAvatar{URL: public}, nil)
+	mp.EXPECT().ConfirmAvatarUpload(gomock.Any(), uid, key, public, nil).Return(nil, errors.New("db down"))
 
 	_, err := srv.ConfirmAvatarUpload(context.Background(), &usersv1.ConfirmAvatarUploadRequest{
 		UserId:    uid.String(),
@@ -456,8 +536,8 @@ func TestGRPC_ConfirmAvatarUpload_OK(t *testing.T) {
 	want.AvatarKey = key
 	want.AvatarURL = public
 
-	ma.EXPECT().CheckAvatarUpload(gomock.Any(), uid, key).Return(public, nil)
-	mp.EXPECT().ConfirmAvatarUpload(gomock.Any(), uid, key, public).Return(want, nil)
+	ma.EXPECT().ProcessAvatarUpload(gomock.Any(), uid, key).Return(&storage.ProcessedAvatar{URL: public}, nil)
+	mp.EXPECT().ConfirmAvatarUpload(gomock.Any(), uid, key, public, nil).Return(want, nil)
 
 	got, err := srv.ConfirmAvatarUpload(context.Background(), &usersv1.ConfirmAvatarUploadRequest{
 		UserId:    uid.String(),
@@ -468,3 +548,259 @@ func TestGRPC_ConfirmAvatarUpload_OK(t *testing.T) {
 	require.Equal(t, public, got.GetAvatarUrl())
 	require.Equal(t, uid.String(), got.GetUserId())
 }
+
+func TestGRPC_ResolveUsernames_InvalidArgument(t *testing.T) {
+	srv, _, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := srv.ResolveUsernames(context.Background(), &usersv1.ResolveUsernamesRequest{})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPC_ResolveUsernames_OK(t *testing.T) {
+	srv, mp, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+	mp.EXPECT().ProfilesByUsernames(gomock.Any(), []string{"alice", "ghost"}).
+		Return([]models.Profile{*mustProfile(uid, "Alice")}, nil)
+
+	got, err := srv.ResolveUsernames(context.Background(), &usersv1.ResolveUsernamesRequest{Usernames: []string{"Alice", "ghost"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"alice": uid.String()}, got.GetUserIds())
+}
+
+func TestGRPC_DeleteAvatar(t *testing.T) {
+	srv, mp, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := srv.DeleteAvatar(context.Background(), &usersv1.DeleteAvatarRequest{UserId: "bad-uuid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	uid := uuid.New()
+	mp.EXPECT().DeleteAvatar(gomock.Any(), uid).Return(nil, storage.ErrNotFoundProfile)
+	_, err = srv.DeleteAvatar(context.Background(), &usersv1.DeleteAvatarRequest{UserId: uid.String()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	mp.EXPECT().DeleteAvatar(gomock.Any(), uid).Return(nil, errors.New("db down"))
+	_, err = srv.DeleteAvatar(context.Background(), &usersv1.DeleteAvatarRequest{UserId: uid.String()})
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	mp.EXPECT().DeleteAvatar(gomock.Any(), uid).Return(&models.Profile{UserID: uid, Username: "u"}, nil)
+	got, err := srv.DeleteAvatar(context.Background(), &usersv1.DeleteAvatarRequest{UserId: uid.String()})
+	require.NoError(t, err)
+	require.Equal(t, uid.String(), got.GetUserId())
+	require.Empty(t, got.GetAvatarUrl())
+}
+
+func TestGRPC_UpdateProfile_UsernameTaken(t *testing.T) {
+	srv, mp, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+	mp.EXPECT().UpdateProfile(gomock.Any(), uid, gomock.Any()).Return(nil, storage.ErrAlreadyExists)
+
+	_, err := srv.UpdateProfile(context.Background(), &usersv1.UpdateProfileRequest{UserId: uid.String(), Username: "taken"})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestGRPC_CheckUsernameAvailability(t *testing.T) {
+	srv, mp, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := srv.CheckUsernameAvailability(context.Background(), &usersv1.CheckUsernameAvailabilityRequest{Username: "a", UserId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := srv.CheckUsernameAvailability(context.Background(), &usersv1.CheckUsernameAvailabilityRequest{Username: "bad name"})
+	require.NoError(t, err)
+	require.False(t, resp.GetAvailable())
+	require.Equal(t, "invalid", resp.GetReason())
+
+	mp.EXPECT().ProfileByUsername(gomock.Any(), "neo").Return(nil, storage.ErrNotFoundProfile)
+	resp, err = srv.CheckUsernameAvailability(context.Background(), &usersv1.CheckUsernameAvailabilityRequest{Username: "neo"})
+	require.NoError(t, err)
+	require.True(t, resp.GetAvailable())
+	require.Equal(t, "neo", resp.GetUsername())
+
+	mp.EXPECT().ProfileByUsername(gomock.Any(), "neo").Return(nil, errors.New("db down"))
+	_, err = srv.CheckUsernameAvailability(context.Background(), &usersv1.CheckUsernameAvailabilityRequest{Username: "neo"})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGRPC_SearchProfiles(t *testing.T) {
+	srv, mp, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := srv.SearchProfiles(context.Background(), &usersv1.SearchProfilesRequest{Query: "@"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	uid := uuid.New()
+	mp.EXPECT().SearchProfiles(gomock.Any(), "ne", 3).Return([]models.Profile{{UserID: uid, Username: "neo"}}, nil)
+	resp, err := srv.SearchProfiles(context.Background(), &usersv1.SearchProfilesRequest{Query: "@ne", Limit: 3})
+	require.NoError(t, err)
+	require.Len(t, resp.GetProfiles(), 1)
+	require.Equal(t, uid.String(), resp.GetProfiles()[0].GetUserId())
+}
+
+func TestGRPC_ProfilesByIDs(t *testing.T) {
+	srv, mp, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	_, err := srv.ProfilesByIDs(context.Background(), &usersv1.ProfilesByIDsRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Только некорректные id — без обращения к стораджу.
+	resp, err := srv.ProfilesByIDs(context.Background(), &usersv1.ProfilesByIDsRequest{UserIds: []string{"bad", "bad"}})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetProfiles())
+	require.Equal(t, []string{"bad"}, resp.GetMissingIds())
+
+	a, b := uuid.New(), uuid.New()
+	mp.EXPECT().ProfilesByIDs(gomock.Any(), []uuid.UUID{b, a}).Return([]models.Profile{{UserID: a, Username: "a"}}, nil)
+	resp, err = srv.ProfilesByIDs(context.Background(), &usersv1.ProfilesByIDsRequest{
+		UserIds: []string{b.String(), "bad", a.String()},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetProfiles(), 1)
+	require.Equal(t, "a", resp.GetProfiles()[a.String()].GetUsername())
+	require.Equal(t, []string{b.String(), "bad"}, resp.GetMissingIds())
+
+	mp.EXPECT().ProfilesByIDs(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+	_, err = srv.ProfilesByIDs(context.Background(), &usersv1.ProfilesByIDsRequest{UserIds: []string{a.String()}})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGRPC_FollowAndList(t *testing.T) {
+	srv, mp, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	a, b := uuid.New(), uuid.New()
+
+	_, err := srv.Follow(context.Background(), &usersv1.FollowRequest{FollowerId: "bad", FolloweeId: b.String()})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.Follow(context.Background(), &usersv1.FollowRequest{FollowerId: a.String(), FolloweeId: a.String()})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mp.EXPECT().Follow(gomock.Any(), a, b).Return(false, storage.ErrNotFoundProfile)
+	_, err = srv.Follow(context.Background(), &usersv1.FollowRequest{FollowerId: a.String(), FolloweeId: b.String()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	mp.EXPECT().Unfollow(gomock.Any(), a, b).Return(true, nil)
+	resp, err := srv.Unfollow(context.Background(), &usersv1.FollowRequest{FollowerId: a.String(), FolloweeId: b.String()})
+	require.NoError(t, err)
+	require.True(t, resp.GetChanged())
+
+	p := mustProfile(b, "bob")
+	p.FollowersCount, p.FollowingCount = 3, 1
+	followed := time.Unix(1710000100, 0).UTC()
+	mp.EXPECT().ListFollowing(gomock.Any(), a, 20, "").Return(&models.FollowsPage{
+		Items:         []models.FollowEntry{{Profile: *p, FollowedAt: followed}},
+		NextPageToken: "next",
+	}, nil)
+
+	list, err := srv.ListFollowing(context.Background(), &usersv1.ListFollowsRequest{UserId: a.String()})
+	require.NoError(t, err)
+	require.Equal(t, "next", list.GetNextPageToken())
+	require.Len(t, list.GetEntries(), 1)
+	require.Equal(t, followed.Unix(), list.GetEntries()[0].GetFollowedAt())
+	require.EqualValues(t, 3, list.GetEntries()[0].GetProfile().GetFollowersCount())
+	require.EqualValues(t, 1, list.GetEntries()[0].GetProfile().GetFollowingCount())
+
+	mp.EXPECT().ListFollowers(gomock.Any(), a, 20, "bad").Return(nil, storage.ErrInvalidCursor)
+	_, err = srv.ListFollowers(context.Background(), &usersv1.ListFollowsRequest{UserId: a.String(), PageToken: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPC_Preferences(t *testing.T) {
+	srv, mp, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	uid := uuid.New()
+
+	_, err := srv.GetPreferences(context.Background(), &usersv1.GetPreferencesRequest{UserId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mp.EXPECT().PreferencesByID(gomock.Any(), uid).Return(&models.Preferences{UserID: uid}, nil)
+	got, err := srv.GetPreferences(context.Background(), &usersv1.GetPreferencesRequest{UserId: uid.String()})
+	require.NoError(t, err)
+	require.Equal(t, uid.String(), got.GetUserId())
+	require.Zero(t, got.GetUpdatedAt())
+
+	_, err = srv.UpdatePreferences(context.Background(), &usersv1.UpdatePreferencesRequest{
+		UserId:     uid.String(),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"bogus"}},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// С маской меняются только перечисленные списки (пустой — очистка).
+	updated := time.Unix(1710000200, 0).UTC()
+	mp.EXPECT().UpdatePreferences(gomock.Any(), uid, storage.PreferencesUpdate{
+		MutedSources: &[]string{"lenta.ru"},
+		Languages:    &[]string{},
+	}).Return(&models.Preferences{UserID: uid, MutedSources: []string{"lenta.ru"}, UpdatedAt: updated}, nil)
+
+	got, err = srv.UpdatePreferences(context.Background(), &usersv1.UpdatePreferencesRequest{
+		UserId: uid.String(),
+		Preferences: &usersv1.Preferences{
+			FollowedCategories: []string{"ignored"},
+			MutedSources:       []string{"www.lenta.ru"},
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"muted_sources", "Languages"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"lenta.ru"}, got.GetMutedSources())
+	require.Equal(t, updated.Unix(), got.GetUpdatedAt())
+
+	// Без маски заменяются все списки.
+	mp.EXPECT().UpdatePreferences(gomock.Any(), uid, storage.PreferencesUpdate{
+		FollowedCategories: &[]string{"tech"},
+		MutedCategories:    &[]string{},
+		MutedSources:       &[]string{},
+		Languages:          &[]string{},
+	}).Return(nil, storage.ErrNotFoundProfile)
+
+	_, err = srv.UpdatePreferences(context.Background(), &usersv1.UpdatePreferencesRequest{
+		UserId:      uid.String(),
+		Preferences: &usersv1.Preferences{FollowedCategories: []string{"Tech"}},
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPC_Bookmarks(t *testing.T) {
+	srv, mp, _, ctrl := newServerWithMocks(t)
+	defer ctrl.Finish()
+
+	uid, nid := uuid.New(), uuid.New()
+
+	_, err := srv.AddBookmark(context.Background(), &usersv1.BookmarkRequest{UserId: uid.String(), NewsId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mp.EXPECT().AddBookmark(gomock.Any(), uid, nid).Return(false, storage.ErrNotFoundProfile)
+	_, err = srv.AddBookmark(context.Background(), &usersv1.BookmarkRequest{UserId: uid.String(), NewsId: nid.String()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	mp.EXPECT().RemoveBookmark(gomock.Any(), uid, nid).Return(true, nil)
+	resp, err := srv.RemoveBookmark(context.Background(), &usersv1.BookmarkRequest{UserId: uid.String(), NewsId: nid.String()})
+	require.NoError(t, err)
+	require.True(t, resp.GetChanged())
+
+	saved := time.Unix(1710000300, 0).UTC()
+	mp.EXPECT().ListBookmarks(gomock.Any(), uid, 20, "").Return(&models.BookmarksPage{
+		Items:         []models.Bookmark{{NewsID: nid, SavedAt: saved}},
+		NextPageToken: "next",
+	}, nil)
+
+	list, err := srv.ListBookmarks(context.Background(), &usersv1.ListBookmarksRequest{UserId: uid.String()})
+	require.NoError(t, err)
+	require.Equal(t, "next", list.GetNextPageToken())
+	require.Len(t, list.GetBookmarks(), 1)
+	require.Equal(t, nid.String(), list.GetBookmarks()[0].GetNewsId())
+	require.Equal(t, saved.Unix(), list.GetBookmarks()[0].GetSavedAt())
+	require.Nil(t, list.GetBookmarks()[0].GetNews())
+
+	mp.EXPECT().ListBookmarks(gomock.Any(), uid, 20, "bad").Return(nil, storage.ErrInvalidCursor)
+	_, err = srv.ListBookmarks(context.Background(), &usersv1.ListBookmarksRequest{UserId: uid.String(), PageToken: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pribylovaa/go-news-aggregator/pkg/identity"
+	usersv1 "github.com/pribylovaa/go-news-aggregator/users-service/gen/go/users"
+	"github.com/pribylovaa/go-news-aggregator/users-service/internal/models"
+)
+
+// viewerFromContext определяет зрителя профиля по входящему metadata
+// (x-user-id/x-user-roles, см. pkg/identity). Некорректный x-user-id
+// трактуется как анонимный вызов — без ошибки, чтобы не ломать чтение.
+func viewerFromContext(ctx context.Context) models.Viewer {
+	caller := identity.FromIncomingContext(ctx)
+
+	var viewer models.Viewer
+	if id, err := uuid.Parse(caller.UserID); err == nil {
+		viewer.UserID = id
+	}
+	viewer.Admin = caller.HasRole(identity.RoleAdmin)
+
+	return viewer
+}
+
+// toProtoPrivacy конвертирует настройки видимости в protobuf-представление;
+// «всё публично» (в т.ч. у чужого профиля после RedactFor) — nil.
+func toProtoPrivacy(p models.Privacy) *usersv1.Privacy {
+	if p == (models.Privacy{}) {
+		return nil
+	}
+
+	return &usersv1.Privacy{
+		Age:     toProtoVisibility(p.Age),
+		Gender:  toProtoVisibility(p.Gender),
+		Country: toProtoVisibility(p.Country),
+	}
+}
+
+// fromProtoPrivacy конвертирует видимость из запроса в map для UpdateProfileInput.Privacy;
+// fields ограничивает набор ключей (nil — все три).
+func fromProtoPrivacy(p *usersv1.Privacy, fields map[string]bool) map[string]models.Visibility {
+	all := map[string]usersv1.Visibility{
+		"age":     p.GetAge(),
+		"gender":  p.GetGender(),
+		"country": p.GetCountry(),
+	}
+
+	out := make(map[string]models.Visibility, len(all))
+	for field, v := range all {
+		if fields != nil && !fields[field] {
+			continue
+		}
+		out[field] = fromProtoVisibility(v)
+	}
+
+	return out
+}
+
+func toProtoVisibility(v models.Visibility) usersv1.Visibility {
+	switch v {
+	case models.VisibilityRegistered:
+		return usersv1.Visibility_REGISTERED
+	case models.VisibilityPrivate:
+		return usersv1.Visibility_PRIVATE
+	default:
+		return usersv1.Visibility_PUBLIC
+	}
+}
+
+// fromProtoVisibility — неизвестные значения enum передаются как есть,
+// чтобы сервис отклонил их (ErrInvalidArgument), а не молча сделал публичными.
+func fromProtoVisibility(v usersv1.Visibility) models.Visibility {
+	switch v {
+	case usersv1.Visibility_PUBLIC:
+		return models.VisibilityPublic
+	case usersv1.Visibility_REGISTERED:
+		return models.VisibilityRegistered
+	case usersv1.Visibility_PRIVATE:
+		return models.VisibilityPrivate
+	default:
+		return models.Visibility(v)
+	}
+}
@@ -0,0 +1,90 @@
+// usernames — правила для username: допустимый вид и каноническая форма.
+//
+// Каноническая форма (Canonical) — ключ уникальности и поиска: два username
+// с одинаковой канонической формой считаются одним именем. Она строится так:
+//  1. NFKC + приведение регистра (полноширинные и стилизованные символы -> обычные);
+//  2. замена визуально похожих символов других письменностей на латиницу
+//     (кириллическая «а» -> a, греческая «ο» -> o и т.п.);
+//  3. сведение «похожих» латинских последовательностей: 0->o, 1/i->l, rn->m, vv->w;
+//  4. сведение разделителей '.' и '-' к '_'.
+//
+// Это упрощённый «скелет» в духе Unicode TS #39: он намеренно строже
+// точного сравнения — "admin", "Admin", "аdmin" (кириллица) и "adm1n" совпадают.
+package usernames
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLen — максимальная длина username в символах.
+const MaxLen = 32
+
+// ErrInvalid — username пуст, слишком длинный или содержит недопустимые символы.
+var ErrInvalid = errors.New("invalid username")
+
+// Normalize приводит username к виду для хранения (TrimSpace + NFC) и проверяет его:
+// 1..MaxLen символов; буквы, цифры, '_', '.', '-'; первый символ — буква, цифра или '_'
+// (тот же набор, что распознаётся в @упоминаниях comments-service).
+func Normalize(name string) (string, error) {
+	name = norm.NFC.String(strings.TrimSpace(name))
+
+	n := utf8.RuneCountInString(name)
+	if n == 0 || n > MaxLen {
+		return "", ErrInvalid
+	}
+
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '_':
+		case (r == '.' || r == '-') && i > 0:
+		default:
+			return "", ErrInvalid
+		}
+	}
+
+	return name, nil
+}
+
+var folder = cases.Fold()
+
+// confusables — однобуквенные замены (после NFKC и case folding).
+var confusables = map[rune]rune{
+	// Кириллица.
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o',
+	'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'ѕ': 's', 'і': 'l', 'ї': 'l',
+	'ј': 'j', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w', 'һ': 'h', 'ӏ': 'l', 'ь': 'b',
+	// Греческий.
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'l', 'κ': 'k', 'ν': 'v', 'ο': 'o',
+	'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'ω': 'w', 'ς': 'c', 'σ': 'o',
+	// Латиница и цифры.
+	'0': 'o', '1': 'l', 'i': 'l', '|': 'l', '5': 's', '$': 's',
+	// Разделители.
+	'.': '_', '-': '_',
+}
+
+// sequences — многобуквенные замены, применяются после однобуквенных.
+var sequences = strings.NewReplacer("rn", "m", "vv", "w")
+
+// Canonical возвращает каноническую форму username (см. описание пакета).
+// Ожидает значение после Normalize; для произвольной строки результат тоже определён.
+func Canonical(name string) string {
+	name = folder.String(norm.NFKC.String(strings.TrimSpace(name)))
+
+	var b strings.Builder
+	b.Grow(len(name))
+	for _, r := range name {
+		if c, ok := confusables[r]; ok {
+			r = c
+		}
+
+		b.WriteRune(r)
+	}
+
+	return sequences.Replace(b.String())
+}
@@ -0,0 +1,52 @@
+package usernames
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	ok := map[string]string{
+		"  alice  ":   "alice",
+		"Иван_Петров": "Иван_Петров",
+		"john.doe-1":  "john.doe-1",
+		"_x":          "_x",
+		"é":          "é", // NFC
+	}
+	for in, want := range ok {
+		got, err := Normalize(in)
+		require.NoError(t, err, in)
+		require.Equal(t, want, got)
+	}
+
+	for _, in := range []string{"", "   ", ".dot", "-dash", "with space", "semi;colon", "@at", strings.Repeat("a", MaxLen+1)} {
+		_, err := Normalize(in)
+		require.ErrorIs(t, err, ErrInvalid, in)
+	}
+
+	_, err := Normalize(strings.Repeat("я", MaxLen))
+	require.NoError(t, err, "length is counted in runes")
+}
+
+func TestCanonical_Confusables(t *testing.T) {
+	t.Parallel()
+
+	groups := [][]string{
+		{"admin", "Admin", "ADMIN", "аdmin" /* кириллическая а */, "adm1n", "admIn", "ａｄｍｉｎ" /* полноширинные */},
+		{"modern", "modem"},
+		{"john.doe", "john-doe", "john_doe", "J0HN_D0E"},
+		{"paypal", "рaypal" /* кириллическая р */, "pаypаl"},
+	}
+	for _, g := range groups {
+		for _, name := range g[1:] {
+			require.Equal(t, Canonical(g[0]), Canonical(name), "%q vs %q", g[0], name)
+		}
+	}
+
+	require.NotEqual(t, Canonical("alice"), Canonical("bob"))
+	require.NotEqual(t, Canonical("иван"), Canonical("ivan"), "genuine Cyrillic names stay distinct")
+}
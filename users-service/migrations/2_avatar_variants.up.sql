@@ -0,0 +1,2 @@
+ALTER TABLE profiles
+  ADD COLUMN IF NOT EXISTS avatar_variants JSONB NOT NULL DEFAULT '[]'::jsonb;
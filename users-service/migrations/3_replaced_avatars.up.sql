@@ -0,0 +1,9 @@
+-- Заменённые/удалённые аватары: объекты остаются в бакете ещё avatar_reaper.retention,
+-- затем удаляются фоновой уборкой вместе с превью.
+CREATE TABLE IF NOT EXISTS replaced_avatars (
+  key         TEXT PRIMARY KEY,
+  user_id     UUID NOT NULL,
+  replaced_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS replaced_avatars_user_id_idx ON replaced_avatars (user_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS profiles_username_key_prefix_idx;
+DROP INDEX IF EXISTS profiles_username_key_uq;
+ALTER TABLE profiles DROP COLUMN IF EXISTS username_key;
@@ -0,0 +1,16 @@
+-- username_key — каноническая форма username (регистр и похожие символы сведены,
+-- вычисляется приложением, см. internal/usernames). Уникальна.
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS username_key TEXT;
+
+-- Существующие профили получают временный уникальный ключ 'legacy#<user_id>':
+-- каноническую форму SQL не вычислить. Настоящие ключи проставляет приложение при
+-- старте (ProfilesStorage.BackfillUsernameKeys) — за самым ранним владельцем имени
+-- остаётся чистый ключ, остальные получают суффикс '#<user_id>'.
+UPDATE profiles SET username_key = 'legacy#' || user_id::text WHERE username_key IS NULL;
+
+ALTER TABLE profiles ALTER COLUMN username_key SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS profiles_username_key_uq ON profiles (username_key);
+
+-- Префиксный поиск (LIKE 'prefix%') независимо от collation базы.
+CREATE INDEX IF NOT EXISTS profiles_username_key_prefix_idx ON profiles (username_key text_pattern_ops);
@@ -0,0 +1,4 @@
+-- Видимость атрибутов профиля: {"age": 0..2, "gender": 0..2, "country": 0..2},
+-- 0 — public, 1 — registered, 2 — private; отсутствующий ключ — public.
+ALTER TABLE profiles
+  ADD COLUMN IF NOT EXISTS privacy JSONB NOT NULL DEFAULT '{}'::jsonb;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS follows;
+ALTER TABLE profiles
+  DROP COLUMN IF EXISTS following_count,
+  DROP COLUMN IF EXISTS followers_count;
@@ -0,0 +1,16 @@
+-- Подписки между пользователями; счётчики на профиле обновляются в той же транзакции.
+ALTER TABLE profiles
+  ADD COLUMN IF NOT EXISTS followers_count INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS following_count INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS follows (
+  follower_id UUID NOT NULL REFERENCES profiles (user_id) ON DELETE CASCADE,
+  followee_id UUID NOT NULL REFERENCES profiles (user_id) ON DELETE CASCADE,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (follower_id, followee_id),
+  CHECK (follower_id <> followee_id)
+);
+
+-- Постраничные выборки подписчиков/подписок: (created_at, id) DESC.
+CREATE INDEX IF NOT EXISTS follows_followee_idx ON follows (followee_id, created_at DESC, follower_id DESC);
+CREATE INDEX IF NOT EXISTS follows_follower_idx ON follows (follower_id, created_at DESC, followee_id DESC);
@@ -0,0 +1,9 @@
+-- Предпочтения читателя (персональная лента news-service); значения нормализованы сервисом.
+CREATE TABLE IF NOT EXISTS preferences (
+  user_id             UUID PRIMARY KEY REFERENCES profiles (user_id) ON DELETE CASCADE,
+  followed_categories TEXT[] NOT NULL DEFAULT '{}',
+  muted_categories    TEXT[] NOT NULL DEFAULT '{}',
+  muted_sources       TEXT[] NOT NULL DEFAULT '{}',
+  languages           TEXT[] NOT NULL DEFAULT '{}',
+  updated_at          TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,11 @@
+-- Закладки читателя («прочитать позже»). news_id — ссылка на news-service без FK:
+-- новость может быть удалена там, закладка при этом остаётся.
+CREATE TABLE IF NOT EXISTS bookmarks (
+  user_id    UUID NOT NULL REFERENCES profiles (user_id) ON DELETE CASCADE,
+  news_id    UUID NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, news_id)
+);
+
+-- Постраничная выдача: (created_at, news_id) DESC.
+CREATE INDEX IF NOT EXISTS bookmarks_user_idx ON bookmarks (user_id, created_at DESC, news_id DESC);
@@ -51,17 +51,45 @@ func (mr *MockAvatarsStorageMockRecorder) AvatarUploadURL(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvatarUploadURL", reflect.TypeOf((*MockAvatarsStorage)(nil).AvatarUploadURL), arg0, arg1, arg2, arg3)
 }
 
-// CheckAvatarUpload mocks base method.
-func (m *MockAvatarsStorage) CheckAvatarUpload(arg0 context.Context, arg1 uuid.UUID, arg2 string) (string, error) {
+// DeleteAvatarObjects mocks base method.
+func (m *MockAvatarsStorage) DeleteAvatarObjects(arg0 context.Context, arg1 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckAvatarUpload", arg0, arg1, arg2)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "DeleteAvatarObjects", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAvatarObjects indicates an expected call of DeleteAvatarObjects.
+func (mr *MockAvatarsStorageMockRecorder) DeleteAvatarObjects(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatarObjects", reflect.TypeOf((*MockAvatarsStorage)(nil).DeleteAvatarObjects), arg0, arg1)
+}
+
+// ListAvatarObjects mocks base method.
+func (m *MockAvatarsStorage) ListAvatarObjects(arg0 context.Context, arg1 func(storage.AvatarObject) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvatarObjects", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListAvatarObjects indicates an expected call of ListAvatarObjects.
+func (mr *MockAvatarsStorageMockRecorder) ListAvatarObjects(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvatarObjects", reflect.TypeOf((*MockAvatarsStorage)(nil).ListAvatarObjects), arg0, arg1)
+}
+
+// ProcessAvatarUpload mocks base method.
+func (m *MockAvatarsStorage) ProcessAvatarUpload(arg0 context.Context, arg1 uuid.UUID, arg2 string) (*storage.ProcessedAvatar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessAvatarUpload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*storage.ProcessedAvatar)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CheckAvatarUpload indicates an expected call of CheckAvatarUpload.
-func (mr *MockAvatarsStorageMockRecorder) CheckAvatarUpload(arg0, arg1, arg2 interface{}) *gomock.Call {
+// ProcessAvatarUpload indicates an expected call of ProcessAvatarUpload.
+func (mr *MockAvatarsStorageMockRecorder) ProcessAvatarUpload(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAvatarUpload", reflect.TypeOf((*MockAvatarsStorage)(nil).CheckAvatarUpload), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAvatarUpload", reflect.TypeOf((*MockAvatarsStorage)(nil).ProcessAvatarUpload), arg0, arg1, arg2)
 }
@@ -37,6 +37,36 @@ func (m *MockProfilesStorage) EXPECT() *MockProfilesStorageMockRecorder {
 	return m.recorder
 }
 
+// AddBookmark mocks base method.
+func (m *MockProfilesStorage) AddBookmark(arg0 context.Context, arg1, arg2 uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookmark", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBookmark indicates an expected call of AddBookmark.
+func (mr *MockProfilesStorageMockRecorder) AddBookmark(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookmark", reflect.TypeOf((*MockProfilesStorage)(nil).AddBookmark), arg0, arg1, arg2)
+}
+
+// AvatarRefs mocks base method.
+func (m *MockProfilesStorage) AvatarRefs(arg0 context.Context, arg1 []uuid.UUID) (map[uuid.UUID]storage.AvatarRefs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvatarRefs", arg0, arg1)
+	ret0, _ := ret[0].(map[uuid.UUID]storage.AvatarRefs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AvatarRefs indicates an expected call of AvatarRefs.
+func (mr *MockProfilesStorageMockRecorder) AvatarRefs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvatarRefs", reflect.TypeOf((*MockProfilesStorage)(nil).AvatarRefs), arg0, arg1)
+}
+
 // Close mocks base method.
 func (m *MockProfilesStorage) Close() {
 	m.ctrl.T.Helper()
@@ -50,18 +80,18 @@ func (mr *MockProfilesStorageMockRecorder) Close() *gomock.Call {
 }
 
 // ConfirmAvatarUpload mocks base method.
-func (m *MockProfilesStorage) ConfirmAvatarUpload(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string) (*models.Profile, error) {
+func (m *MockProfilesStorage) ConfirmAvatarUpload(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string, arg4 []models.AvatarVariant) (*models.Profile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfirmAvatarUpload", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "ConfirmAvatarUpload", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(*models.Profile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ConfirmAvatarUpload indicates an expected call of ConfirmAvatarUpload.
-func (mr *MockProfilesStorageMockRecorder) ConfirmAvatarUpload(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockProfilesStorageMockRecorder) ConfirmAvatarUpload(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmAvatarUpload", reflect.TypeOf((*MockProfilesStorage)(nil).ConfirmAvatarUpload), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmAvatarUpload", reflect.TypeOf((*MockProfilesStorage)(nil).ConfirmAvatarUpload), arg0, arg1, arg2, arg3, arg4)
 }
 
 // CreateProfile mocks base method.
@@ -79,6 +109,110 @@ func (mr *MockProfilesStorageMockRecorder) CreateProfile(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProfile", reflect.TypeOf((*MockProfilesStorage)(nil).CreateProfile), arg0, arg1)
 }
 
+// DeleteAvatar mocks base method.
+func (m *MockProfilesStorage) DeleteAvatar(arg0 context.Context, arg1 uuid.UUID) (*models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAvatar", arg0, arg1)
+	ret0, _ := ret[0].(*models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAvatar indicates an expected call of DeleteAvatar.
+func (mr *MockProfilesStorageMockRecorder) DeleteAvatar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatar", reflect.TypeOf((*MockProfilesStorage)(nil).DeleteAvatar), arg0, arg1)
+}
+
+// Follow mocks base method.
+func (m *MockProfilesStorage) Follow(arg0 context.Context, arg1, arg2 uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *MockProfilesStorageMockRecorder) Follow(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*MockProfilesStorage)(nil).Follow), arg0, arg1, arg2)
+}
+
+// ForgetReplacedAvatars mocks base method.
+func (m *MockProfilesStorage) ForgetReplacedAvatars(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgetReplacedAvatars", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgetReplacedAvatars indicates an expected call of ForgetReplacedAvatars.
+func (mr *MockProfilesStorageMockRecorder) ForgetReplacedAvatars(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgetReplacedAvatars", reflect.TypeOf((*MockProfilesStorage)(nil).ForgetReplacedAvatars), arg0, arg1)
+}
+
+// ListBookmarks mocks base method.
+func (m *MockProfilesStorage) ListBookmarks(arg0 context.Context, arg1 uuid.UUID, arg2 int, arg3 string) (*models.BookmarksPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookmarks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.BookmarksPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookmarks indicates an expected call of ListBookmarks.
+func (mr *MockProfilesStorageMockRecorder) ListBookmarks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookmarks", reflect.TypeOf((*MockProfilesStorage)(nil).ListBookmarks), arg0, arg1, arg2, arg3)
+}
+
+// ListFollowers mocks base method.
+func (m *MockProfilesStorage) ListFollowers(arg0 context.Context, arg1 uuid.UUID, arg2 int, arg3 string) (*models.FollowsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.FollowsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowers indicates an expected call of ListFollowers.
+func (mr *MockProfilesStorageMockRecorder) ListFollowers(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowers", reflect.TypeOf((*MockProfilesStorage)(nil).ListFollowers), arg0, arg1, arg2, arg3)
+}
+
+// ListFollowing mocks base method.
+func (m *MockProfilesStorage) ListFollowing(arg0 context.Context, arg1 uuid.UUID, arg2 int, arg3 string) (*models.FollowsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowing", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.FollowsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowing indicates an expected call of ListFollowing.
+func (mr *MockProfilesStorageMockRecorder) ListFollowing(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowing", reflect.TypeOf((*MockProfilesStorage)(nil).ListFollowing), arg0, arg1, arg2, arg3)
+}
+
+// PreferencesByID mocks base method.
+func (m *MockProfilesStorage) PreferencesByID(arg0 context.Context, arg1 uuid.UUID) (*models.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreferencesByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreferencesByID indicates an expected call of PreferencesByID.
+func (mr *MockProfilesStorageMockRecorder) PreferencesByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreferencesByID", reflect.TypeOf((*MockProfilesStorage)(nil).PreferencesByID), arg0, arg1)
+}
+
 // ProfileByID mocks base method.
 func (m *MockProfilesStorage) ProfileByID(arg0 context.Context, arg1 uuid.UUID) (*models.Profile, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +228,111 @@ func (mr *MockProfilesStorageMockRecorder) ProfileByID(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProfileByID", reflect.TypeOf((*MockProfilesStorage)(nil).ProfileByID), arg0, arg1)
 }
 
+// ProfileByUsername mocks base method.
+func (m *MockProfilesStorage) ProfileByUsername(arg0 context.Context, arg1 string) (*models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProfileByUsername", arg0, arg1)
+	ret0, _ := ret[0].(*models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProfileByUsername indicates an expected call of ProfileByUsername.
+func (mr *MockProfilesStorageMockRecorder) ProfileByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProfileByUsername", reflect.TypeOf((*MockProfilesStorage)(nil).ProfileByUsername), arg0, arg1)
+}
+
+// ProfilesByIDs mocks base method.
+func (m *MockProfilesStorage) ProfilesByIDs(arg0 context.Context, arg1 []uuid.UUID) ([]models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProfilesByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProfilesByIDs indicates an expected call of ProfilesByIDs.
+func (mr *MockProfilesStorageMockRecorder) ProfilesByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProfilesByIDs", reflect.TypeOf((*MockProfilesStorage)(nil).ProfilesByIDs), arg0, arg1)
+}
+
+// ProfilesByUsernames mocks base method.
+func (m *MockProfilesStorage) ProfilesByUsernames(arg0 context.Context, arg1 []string) ([]models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProfilesByUsernames", arg0, arg1)
+	ret0, _ := ret[0].([]models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProfilesByUsernames indicates an expected call of ProfilesByUsernames.
+func (mr *MockProfilesStorageMockRecorder) ProfilesByUsernames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProfilesByUsernames", reflect.TypeOf((*MockProfilesStorage)(nil).ProfilesByUsernames), arg0, arg1)
+}
+
+// RemoveBookmark mocks base method.
+func (m *MockProfilesStorage) RemoveBookmark(arg0 context.Context, arg1, arg2 uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBookmark", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveBookmark indicates an expected call of RemoveBookmark.
+func (mr *MockProfilesStorageMockRecorder) RemoveBookmark(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBookmark", reflect.TypeOf((*MockProfilesStorage)(nil).RemoveBookmark), arg0, arg1, arg2)
+}
+
+// SearchProfiles mocks base method.
+func (m *MockProfilesStorage) SearchProfiles(arg0 context.Context, arg1 string, arg2 int) ([]models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchProfiles", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchProfiles indicates an expected call of SearchProfiles.
+func (mr *MockProfilesStorageMockRecorder) SearchProfiles(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProfiles", reflect.TypeOf((*MockProfilesStorage)(nil).SearchProfiles), arg0, arg1, arg2)
+}
+
+// Unfollow mocks base method.
+func (m *MockProfilesStorage) Unfollow(arg0 context.Context, arg1, arg2 uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfollow", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unfollow indicates an expected call of Unfollow.
+func (mr *MockProfilesStorageMockRecorder) Unfollow(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfollow", reflect.TypeOf((*MockProfilesStorage)(nil).Unfollow), arg0, arg1, arg2)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockProfilesStorage) UpdatePreferences(arg0 context.Context, arg1 uuid.UUID, arg2 storage.PreferencesUpdate) (*models.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockProfilesStorageMockRecorder) UpdatePreferences(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockProfilesStorage)(nil).UpdatePreferences), arg0, arg1, arg2)
+}
+
 // UpdateProfile mocks base method.
 func (m *MockProfilesStorage) UpdateProfile(arg0 context.Context, arg1 uuid.UUID, arg2 storage.ProfileUpdate) (*models.Profile, error) {
 	m.ctrl.T.Helper()